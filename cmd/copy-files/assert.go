@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/internal/tui/shared"
+)
+
+// Exit codes for --assert-synced, --verify, --scan-only, --gen-script, --tree-digest and syncs.
+const (
+	exitInSync   = 0 // Also a successful --scan-only or --gen-script, and matching --tree-digest trees
+	exitError    = 1
+	exitDrift    = 6
+	exitDeadline = 7 // A sync that --deadline or --max-duration stopped with files deferred
+)
+
+// runAssertSynced analyzes source against destination without changing either and reports
+// whether they are in sync. Analysis alone leaves both trees as they are, even temp files that
+// interrupted copies left, which only a sync removes. Every planned create, overwrite,
+// permission update and delete is listed as drift. Returns the process exit code.
+func runAssertSynced(cfg *config.Config, out, errOut io.Writer) int {
+	engine, err := syncengine.NewEngineFromConfig(cfg)
+	if err != nil {
+		fmt.Fprintf(errOut, "Error: failed to initialize engine: %v\n", err)
+		return exitError
+	}
+	defer engine.Close()
+
+	engine.ApplyConfig(cfg)
+
+	// A saved plan describes a previous run, not the trees as they are now
+	engine.Resume = false
+
+	if cfg.AnalysisLogPath != "" {
+		err = engine.EnableAnalysisLogging(cfg.AnalysisLogPath)
+		if err != nil {
+			fmt.Fprintf(errOut, "Error: %v\n", err)
+			return exitError
+		}
+	}
+
+	err = engine.Analyze()
+	if err != nil {
+		fmt.Fprintf(errOut, "Error: %v\n", err)
+		return exitError
+	}
+
+	entries := engine.PlanEntries()
+	if len(entries) == 0 {
+		fmt.Fprintf(out, "In sync: %s and %s\n", cfg.SourcePath, cfg.DestPath)
+		return exitInSync
+	}
+
+	counts := make(map[syncengine.FileAction]int)
+	for _, entry := range entries {
+		counts[entry.Action]++
+	}
+
+	var parts []string
+
+	for _, summary := range []struct {
+		action syncengine.FileAction
+		label  string
+	}{
+		{syncengine.ActionCreate, "to create"},
+		{syncengine.ActionOverwrite, "to overwrite"},
+		{syncengine.ActionMetadata, "with different permissions"},
+		{syncengine.ActionDelete, "to delete"},
+	} {
+		if counts[summary.action] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[summary.action], summary.label))
+		}
+	}
+
+	fmt.Fprintf(out, "Drift detected between %s and %s: %s\n", cfg.SourcePath, cfg.DestPath, strings.Join(parts, ", "))
+
+	for _, entry := range entries {
+		fmt.Fprintf(out, "  %-9s %s\n", entry.Action, entry.RelativePath)
+	}
+
+	return exitDrift
+}
+
+// verifyExitCode returns the exit code of a --verify run from the finished TUI model: exitInSync
+// if the destination mirrors the source, exitDrift if it doesn't, or exitError if the check
+// didn't finish.
+func verifyExitCode(model tea.Model, runErr error) int {
+	engine, state, _ := finalOutcome(model, runErr)
+	if state != shared.StateVerified || engine == nil {
+		return exitError
+	}
+
+	report := engine.GetStatus().Verify
+	if report == nil {
+		return exitError
+	}
+
+	if !report.Clean() {
+		return exitDrift
+	}
+
+	return exitInSync
+}
+
+// syncExitCode returns the exit code of a sync from the finished TUI model: exitDeadline if the
+// deadline deferred files to a later run, which the TUI reports as complete, and otherwise
+// exitInSync.
+func syncExitCode(model tea.Model, runErr error) int {
+	engine, state, _ := finalOutcome(model, runErr)
+	if state == shared.StateComplete && engine != nil && engine.GetStatus().DeferredFiles > 0 {
+		return exitDeadline
+	}
+
+	return exitInSync
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/internal/tui"
+	"github.com/joe/copy-files/internal/tui/shared"
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+func TestRunAssertSynced_ReportsDriftWithoutChangingAnything(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	g.Expect(os.WriteFile(filepath.Join(sourceDir, "new.txt"), []byte("new"), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(destDir, "orphan.txt"), []byte("orphan"), 0o600)).To(Succeed())
+
+	cfg := &config.Config{SourcePath: sourceDir, DestPath: destDir, TypeOfChange: config.Content}
+
+	var out, errOut bytes.Buffer
+
+	g.Expect(runAssertSynced(cfg, &out, &errOut)).To(Equal(exitDrift))
+	g.Expect(errOut.String()).To(BeEmpty())
+	g.Expect(out.String()).To(ContainSubstring("1 to create, 1 to delete"))
+	g.Expect(out.String()).To(ContainSubstring("create    new.txt"))
+	g.Expect(out.String()).To(ContainSubstring("delete    orphan.txt"))
+
+	// Nothing was copied or deleted
+	_, err := os.Stat(filepath.Join(destDir, "new.txt"))
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+	g.Expect(filepath.Join(destDir, "orphan.txt")).To(BeAnExistingFile())
+}
+
+func TestRunAssertSynced_InSync(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	// Files written one after the other needn't get the same modtime
+	modTime := time.Now().Add(-time.Hour)
+
+	for _, dir := range []string{sourceDir, destDir} {
+		g.Expect(os.WriteFile(filepath.Join(dir, "same.txt"), []byte("same"), 0o600)).To(Succeed())
+		g.Expect(os.Chtimes(filepath.Join(dir, "same.txt"), modTime, modTime)).To(Succeed())
+	}
+
+	cfg := &config.Config{SourcePath: sourceDir, DestPath: destDir, TypeOfChange: config.Content}
+
+	var out, errOut bytes.Buffer
+
+	g.Expect(runAssertSynced(cfg, &out, &errOut)).To(Equal(exitInSync))
+	g.Expect(out.String()).To(HavePrefix("In sync:"))
+}
+
+func TestRunAssertSynced_LeavesStaleTempFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	tempPath := filepath.Join(destDir, "report.pdf"+fileops.TempSuffix)
+	g.Expect(os.WriteFile(filepath.Join(sourceDir, "report.pdf"), []byte("full report"), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(tempPath, []byte("full re"), 0o600)).To(Succeed())
+
+	cfg := &config.Config{SourcePath: sourceDir, DestPath: destDir, TypeOfChange: config.Content}
+
+	var out, errOut bytes.Buffer
+
+	g.Expect(runAssertSynced(cfg, &out, &errOut)).To(Equal(exitDrift))
+	g.Expect(out.String()).To(ContainSubstring("1 to create\n"))
+	g.Expect(tempPath).To(BeARegularFile())
+}
+
+func TestVerifyExitCode(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	g.Expect(os.WriteFile(filepath.Join(sourceDir, "same.txt"), []byte("same"), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(destDir, "same.txt"), []byte("same"), 0o600)).To(Succeed())
+
+	verified := func() tea.Model {
+		engine, err := syncengine.NewEngine(sourceDir, destDir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		engine.ChangeType = config.Content
+
+		_, err = engine.Verify()
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		model, _ := tui.NewAppModel(&config.Config{}).Update(shared.TransitionToVerifySummaryMsg{Engine: engine})
+
+		return model
+	}
+
+	g.Expect(verifyExitCode(verified(), nil)).To(Equal(exitInSync))
+
+	g.Expect(os.WriteFile(filepath.Join(destDir, "extra.txt"), []byte("extra"), 0o600)).To(Succeed())
+	g.Expect(verifyExitCode(verified(), nil)).To(Equal(exitDrift))
+
+	// A check that never finished, such as one quit during analysis, isn't a pass
+	g.Expect(verifyExitCode(tui.NewAppModel(&config.Config{}), nil)).To(Equal(exitError))
+	g.Expect(verifyExitCode(nil, errors.New("terminal went away"))).To(Equal(exitError))
+}
+
+func TestSyncExitCode(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	synced := func(deadline time.Time) tea.Model {
+		sourceDir := t.TempDir()
+		destDir := t.TempDir()
+
+		g.Expect(os.WriteFile(filepath.Join(sourceDir, "a.txt"), []byte("a"), 0o600)).To(Succeed())
+		g.Expect(os.WriteFile(filepath.Join(sourceDir, "b.txt"), []byte("b"), 0o600)).To(Succeed())
+
+		engine, err := syncengine.NewEngine(sourceDir, destDir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		engine.Deadline = deadline
+
+		g.Expect(engine.Analyze()).To(Succeed())
+
+		err = engine.Sync()
+		if deadline.Before(time.Now()) {
+			g.Expect(err).To(MatchError(syncengine.ErrDeadlineReached))
+		} else {
+			g.Expect(err).ShouldNot(HaveOccurred())
+		}
+
+		model, _ := tui.NewAppModel(&config.Config{}).Update(shared.EngineInitializedMsg{Engine: engine})
+		model, _ = model.Update(shared.TransitionToSummaryMsg{FinalState: shared.StateComplete})
+
+		return model
+	}
+
+	g.Expect(syncExitCode(synced(time.Now().Add(time.Hour)), nil)).To(Equal(exitInSync))
+	g.Expect(syncExitCode(synced(time.Now().Add(-time.Minute)), nil)).To(Equal(exitDeadline))
+	g.Expect(syncExitCode(nil, errors.New("terminal went away"))).To(Equal(exitInSync))
+}
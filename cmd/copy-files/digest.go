@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// runTreeDigest prints one digest of the source tree and one of the destination tree, and
+// whether they match, without changing either. Returns the process exit code.
+func runTreeDigest(cfg *config.Config, out, errOut io.Writer) int {
+	engine, err := syncengine.NewEngineFromConfig(cfg)
+	if err != nil {
+		fmt.Fprintf(errOut, "Error: failed to initialize engine: %v\n", err)
+		return exitError
+	}
+	defer engine.Close()
+
+	engine.ApplyConfig(cfg)
+
+	sourceDigest, err := engine.TreeDigest(engine.SourcePath)
+	if err != nil {
+		fmt.Fprintf(errOut, "Error: %v\n", err)
+		return exitError
+	}
+
+	destDigest, err := engine.TreeDigest(engine.DestPath)
+	if err != nil {
+		fmt.Fprintf(errOut, "Error: %v\n", err)
+		return exitError
+	}
+
+	fmt.Fprintf(out, "Source:      %s  %s\n", sourceDigest, cfg.SourcePath)
+	fmt.Fprintf(out, "Destination: %s  %s\n", destDigest, cfg.DestPath)
+
+	if sourceDigest != destDigest {
+		fmt.Fprintln(out, "Trees differ")
+		return exitDrift
+	}
+
+	fmt.Fprintln(out, "Trees match")
+
+	return exitInSync
+}
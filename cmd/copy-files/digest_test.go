@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+)
+
+func TestRunTreeDigest_ReportsWhetherTreesMatch(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	for _, dir := range []string{sourceDir, destDir} {
+		g.Expect(os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o600)).To(Succeed())
+	}
+
+	cfg := &config.Config{SourcePath: sourceDir, DestPath: destDir, TreeDigest: true}
+
+	var out, errOut bytes.Buffer
+
+	g.Expect(runTreeDigest(cfg, &out, &errOut)).To(Equal(exitInSync))
+	g.Expect(errOut.String()).To(BeEmpty())
+	g.Expect(out.String()).To(ContainSubstring("Trees match"))
+
+	g.Expect(os.WriteFile(filepath.Join(destDir, "extra.txt"), []byte("x"), 0o600)).To(Succeed())
+	out.Reset()
+
+	g.Expect(runTreeDigest(cfg, &out, &errOut)).To(Equal(exitDrift))
+	g.Expect(out.String()).To(ContainSubstring("Trees differ"))
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/pkg/filesystem"
+	"golang.org/x/term" //nolint:depguard // Required for reading passwords without echo
+)
+
+// setupKeychain prepares keychain for the run's SFTP hosts, every --source's and the
+// destination's: each remote host with no password in the environment, the keychain or the
+// credentials file is prompted for once, and the answer is stored in the keychain for later
+// runs once it has been used to connect, so a mistyped password is never saved. An empty
+// answer skips the host, leaving it to key-based authentication. Returns the keychain to look
+// passwords up in, or nil if it can't be read, in which case the environment and credentials
+// file are used as before.
+func setupKeychain(
+	cfg *config.Config,
+	keychain filesystem.Keychain,
+	readPassword func(prompt string) (string, error),
+	errOut io.Writer,
+) filesystem.Keychain {
+	credsPath, err := filesystem.CredentialsFilePath()
+	if err != nil {
+		credsPath = ""
+	}
+
+	// Passwords entered this run are used even if storing them fails
+	session := &sessionKeychain{
+		Keychain:  keychain,
+		entered:   make(map[string]string),
+		confirmed: make(map[string]bool),
+		errOut:    errOut,
+	}
+	seen := make(map[string]bool)
+
+	for _, pathStr := range append(cfg.SourcePaths(), cfg.DestPath) {
+		parsed, err := filesystem.ParsePath(pathStr)
+		if err != nil || !parsed.IsRemote {
+			continue
+		}
+
+		if seen[parsed.Host] {
+			continue
+		}
+
+		seen[parsed.Host] = true
+
+		cred, err := filesystem.ResolveCredentialWithKeychain(parsed.User, parsed.Host, credsPath, keychain)
+		if err != nil {
+			fmt.Fprintf(errOut, "Warning: keychain not used: %v\n", err)
+			return nil
+		}
+
+		if cred != nil {
+			continue
+		}
+
+		password, err := readPassword(fmt.Sprintf("Password for %s@%s (saved to the keychain, Enter to skip): ",
+			parsed.User, parsed.Host))
+		if err != nil || password == "" {
+			continue
+		}
+
+		session.entered[parsed.Host] = password
+	}
+
+	return session
+}
+
+// promptPassword reads a password from the terminal without echoing it. Without a terminal
+// there is no one to ask, so it returns an empty password.
+func promptPassword(prompt string) (string, error) {
+	stdin := int(os.Stdin.Fd())
+	if !term.IsTerminal(stdin) {
+		return "", nil
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+
+	password, err := term.ReadPassword(stdin)
+
+	fmt.Fprintln(os.Stderr)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+
+	return string(password), nil
+}
+
+// sessionKeychain is a keychain that also returns the passwords entered this run, and stores
+// each in the keychain when it is confirmed to work.
+type sessionKeychain struct {
+	filesystem.Keychain
+
+	entered   map[string]string
+	confirmed map[string]bool // Hosts whose entered password has been stored, or tried to be
+	errOut    io.Writer
+}
+
+// Confirm stores the password entered this run for host, now that it has been used to connect.
+func (k *sessionKeychain) Confirm(host string) {
+	password, ok := k.entered[host]
+	if !ok || k.confirmed[host] {
+		return
+	}
+
+	k.confirmed[host] = true
+
+	err := k.Keychain.Store(host, password)
+	if err != nil {
+		fmt.Fprintf(k.errOut, "Warning: failed to save the password for %s to the keychain: %v\n", host, err)
+	}
+}
+
+func (k *sessionKeychain) Lookup(host string) (string, bool, error) {
+	if password, ok := k.entered[host]; ok {
+		return password, true, nil
+	}
+
+	return k.Keychain.Lookup(host) //nolint:wrapcheck // Wrapped by ResolveCredentialWithKeychain
+}
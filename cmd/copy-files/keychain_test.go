@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+func TestSetupKeychain_PromptsOnceAndStores(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	cfg := &config.Config{
+		SourcePath: "sftp://alice@new.test/data",
+		DestPath:   "sftp://alice@new.test/backup",
+	}
+
+	stored := memoryKeychain{}
+
+	var prompts []string
+
+	readPassword := func(prompt string) (string, error) {
+		prompts = append(prompts, prompt)
+		return "hunter2", nil
+	}
+
+	var errOut bytes.Buffer
+
+	keychain := setupKeychain(cfg, stored, readPassword, &errOut)
+	g.Expect(keychain).NotTo(BeNil())
+	g.Expect(prompts).To(HaveLen(1))
+	g.Expect(prompts[0]).To(ContainSubstring("alice@new.test"))
+
+	// It's used this run, but only stored once a connection has accepted it
+	g.Expect(stored).To(BeEmpty())
+	password, found, err := keychain.Lookup("new.test")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(password).To(Equal("hunter2"))
+
+	confirmer, ok := keychain.(filesystem.KeychainConfirmer)
+	g.Expect(ok).To(BeTrue())
+	confirmer.Confirm("new.test")
+	g.Expect(stored).To(HaveKeyWithValue("new.test", "hunter2"))
+	g.Expect(errOut.String()).To(BeEmpty())
+
+	// The next run finds it stored and doesn't ask
+	prompts = nil
+
+	setupKeychain(cfg, stored, readPassword, &errOut)
+	g.Expect(prompts).To(BeEmpty())
+}
+
+func TestSetupKeychain_PromptsForEverySource(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	cfg := &config.Config{
+		Sources:  []string{"sftp://alice@one.test/data", "sftp://alice@two.test/data"},
+		DestPath: "sftp://alice@backup.test/all",
+	}
+
+	var prompts []string
+
+	readPassword := func(prompt string) (string, error) {
+		prompts = append(prompts, prompt)
+		return "hunter2", nil
+	}
+
+	var errOut bytes.Buffer
+
+	setupKeychain(cfg, memoryKeychain{}, readPassword, &errOut)
+	g.Expect(prompts).To(HaveLen(3))
+	g.Expect(prompts[0]).To(ContainSubstring("alice@one.test"))
+	g.Expect(prompts[1]).To(ContainSubstring("alice@two.test"))
+	g.Expect(prompts[2]).To(ContainSubstring("alice@backup.test"))
+}
+
+func TestSetupKeychain_LocalPathsNeverPrompt(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	cfg := &config.Config{SourcePath: t.TempDir(), DestPath: t.TempDir()}
+
+	readPassword := func(string) (string, error) {
+		t.Fatal("prompted for a local path")
+		return "", nil
+	}
+
+	var errOut bytes.Buffer
+
+	setupKeychain(cfg, memoryKeychain{}, readPassword, &errOut)
+	g.Expect(errOut.String()).To(BeEmpty())
+}
+
+// memoryKeychain is an in-memory keychain of host -> password.
+type memoryKeychain map[string]string
+
+func (k memoryKeychain) Lookup(host string) (string, bool, error) {
+	password, ok := k[host]
+	return password, ok, nil
+}
+
+func (k memoryKeychain) Store(host, password string) error {
+	k[host] = password
+	return nil
+}
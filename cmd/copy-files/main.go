@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/joe/copy-files/internal/config"
 	"github.com/joe/copy-files/internal/tui"
+	"github.com/joe/copy-files/pkg/filesystem"
 	"golang.org/x/term" //nolint:depguard // Required for TTY detection
 )
 
@@ -19,20 +20,82 @@ func main() {
 		os.Exit(1)
 	}
 
+	for _, warning := range cfg.Warnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	if cfg.Keychain {
+		keychain, err := filesystem.SystemKeychain()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v; using the environment and credentials file for passwords\n", err)
+		} else {
+			cfg.PasswordKeychain = setupKeychain(cfg, keychain, promptPassword, os.Stderr)
+		}
+	}
+
+	if cfg.AssertSynced {
+		os.Exit(runAssertSynced(cfg, os.Stdout, os.Stderr))
+	}
+
+	if cfg.ScanOnly {
+		os.Exit(runScanOnly(cfg, os.Stdout, os.Stderr))
+	}
+
+	if cfg.GenScript != "" {
+		os.Exit(runGenScript(cfg, os.Stdout, os.Stderr))
+	}
+
+	if cfg.TreeDigest {
+		os.Exit(runTreeDigest(cfg, os.Stdout, os.Stderr))
+	}
+
 	// Create and run TUI
 	model := tui.NewAppModel(cfg)
 
 	// Only use alt screen if stdout is a TTY
 	var opts []tea.ProgramOption
-	if term.IsTerminal(int(os.Stdout.Fd())) {
+
+	stdoutIsTTY := term.IsTerminal(int(os.Stdout.Fd()))
+	if stdoutIsTTY {
 		opts = append(opts, tea.WithAltScreen())
 	}
 
+	// JSON progress would be drawn over by the TUI on a terminal, so it's only for scripts
+	var progress *progressReporter
+
+	if cfg.JSONProgress {
+		if stdoutIsTTY {
+			fmt.Fprintln(os.Stderr, "Warning: --json-progress is ignored when stdout is a terminal")
+		} else {
+			progress = newProgressReporter(os.Stderr)
+			opts = append(opts, tea.WithFilter(progress.filter))
+		}
+	}
+
 	p := tea.NewProgram(model, opts...)
 
-	_, err = p.Run()
+	finalModel, err := p.Run()
+
+	if progress != nil {
+		progress.finish(finalModel, err)
+	}
+
+	if cfg.SummaryJSON != "" {
+		writeSummaryJSON(cfg, finalModel, err, os.Stderr)
+	}
+
+	if cfg.Webhook != "" {
+		notifyWebhook(cfg, finalModel, err, os.Stderr)
+	}
+
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+
+	if cfg.Verify {
+		os.Exit(verifyExitCode(finalModel, err))
+	}
+
+	os.Exit(syncExitCode(finalModel, err))
 }
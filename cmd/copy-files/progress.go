@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/internal/tui/shared"
+)
+
+// progressInterval is the least time between --json-progress lines.
+const progressInterval = 500 * time.Millisecond
+
+// Progress phases reported after analysis, which reports its own phase names.
+const (
+	progressPhaseStarting = "starting"
+	progressPhaseSyncing  = "syncing"
+	progressPhaseComplete = "complete"
+)
+
+// progressLine is one --json-progress NDJSON object: a "progress" line while the run goes on,
+// and one "summary" line, with the run's status, when it ends.
+type progressLine struct {
+	Type             string  `json:"type"`
+	Phase            string  `json:"phase"`
+	Status           string  `json:"status,omitempty"` // Summary only: "complete", "cancelled", "error" or "verified"
+	Error            string  `json:"error,omitempty"`
+	FilesProcessed   int     `json:"files_processed"`
+	FilesTotal       int     `json:"files_total"`
+	FilesFailed      int     `json:"files_failed"`
+	BytesTransferred int64   `json:"bytes_transferred"`
+	BytesTotal       int64   `json:"bytes_total"`
+	BytesPerSecond   float64 `json:"bytes_per_second"`
+	ActiveWorkers    int32   `json:"active_workers"`
+	CurrentFile      string  `json:"current_file,omitempty"`
+	DurationSeconds  float64 `json:"duration_seconds,omitempty"` // Summary only
+}
+
+// progressReporter writes --json-progress lines, at most one progress line per
+// progressInterval.
+type progressReporter struct {
+	mu   sync.Mutex
+	out  io.Writer
+	now  func() time.Time
+	last time.Time
+}
+
+func newProgressReporter(out io.Writer) *progressReporter {
+	return &progressReporter{out: out, now: time.Now}
+}
+
+// filter is a tea.Program filter that starts reporting on each engine as the TUI creates it.
+// It never changes the messages it sees.
+func (r *progressReporter) filter(_ tea.Model, msg tea.Msg) tea.Msg {
+	if initialized, ok := msg.(shared.EngineInitializedMsg); ok && initialized.Engine != nil {
+		engine := initialized.Engine
+
+		// The callback's status is the engine's live one, so a locked copy is taken instead
+		engine.RegisterStatusCallback(func(*syncengine.Status) {
+			r.report(engine)
+		})
+	}
+
+	return msg
+}
+
+// report writes a progress line for engine, unless one was written less than
+// progressInterval ago.
+func (r *progressReporter) report(engine *syncengine.Engine) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if now.Sub(r.last) < progressInterval {
+		return
+	}
+
+	r.last = now
+
+	r.write(newProgressLine("progress", engine.GetStatus()))
+}
+
+// finish writes the summary line for a run that ended as model says, or with runErr.
+func (r *progressReporter) finish(model tea.Model, runErr error) {
+	engine, state, outcomeErr := finalOutcome(model, runErr)
+
+	line := progressLine{Type: "summary", Phase: progressPhaseComplete}
+
+	if engine != nil {
+		status := engine.GetStatus()
+		line = newProgressLine("summary", status)
+
+		if !status.StartTime.IsZero() {
+			end := status.EndTime
+			if end.IsZero() {
+				end = r.now()
+			}
+
+			line.DurationSeconds = end.Sub(status.StartTime).Seconds()
+		}
+	}
+
+	line.Status = state
+
+	if outcomeErr != nil {
+		line.Error = outcomeErr.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.write(line)
+}
+
+// write encodes line as one line of output. Must be called with r.mu held. A failed write
+// only loses progress, so it isn't reported.
+func (r *progressReporter) write(line progressLine) {
+	data, err := json.Marshal(line)
+	if err != nil {
+		return
+	}
+
+	_, _ = r.out.Write(append(data, '\n'))
+}
+
+// newProgressLine reports status as a line of the given type.
+func newProgressLine(lineType string, status *syncengine.Status) progressLine {
+	return progressLine{
+		Type:             lineType,
+		Phase:            progressPhase(status),
+		FilesProcessed:   status.ProcessedFiles,
+		FilesTotal:       status.TotalFiles,
+		FilesFailed:      status.FailedFiles,
+		BytesTransferred: status.TransferredBytes,
+		BytesTotal:       status.TotalBytes,
+		BytesPerSecond:   status.BytesPerSecond,
+		ActiveWorkers:    status.ActiveWorkers,
+		CurrentFile:      status.CurrentFile,
+	}
+}
+
+// progressPhase names the run's phase: the analysis phase (such as "scanning_source" or
+// "comparing") until analysis completes, then "syncing" until the sync completes.
+func progressPhase(status *syncengine.Status) string {
+	switch {
+	case status.FinalizationPhase == progressPhaseComplete:
+		return progressPhaseComplete
+	case status.AnalysisPhase == progressPhaseComplete:
+		return progressPhaseSyncing
+	case status.AnalysisPhase == "":
+		return progressPhaseStarting
+	default:
+		return status.AnalysisPhase
+	}
+}
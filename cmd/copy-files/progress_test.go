@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/internal/tui/shared"
+)
+
+func TestProgressReporter_ThrottlesProgressLines(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(sourceDir, "a.txt"), []byte("hello"), 0o600)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	var out bytes.Buffer
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	reporter := newProgressReporter(&out)
+	reporter.now = func() time.Time { return now }
+
+	msg := shared.EngineInitializedMsg{Engine: engine}
+	g.Expect(reporter.filter(nil, msg)).To(Equal(msg))
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	lines := progressLines(t, out.String())
+	g.Expect(lines).To(HaveLen(1), "the clock didn't move, so later updates are throttled")
+	g.Expect(lines[0].Type).To(Equal("progress"))
+
+	now = now.Add(progressInterval)
+
+	g.Expect(engine.Sync()).To(Succeed())
+
+	lines = progressLines(t, out.String())
+	g.Expect(lines).To(HaveLen(2))
+	g.Expect(lines[1].FilesTotal).To(Equal(1))
+	g.Expect(lines[1].BytesTotal).To(Equal(int64(5)))
+
+	reporter.finish(finishedRun{engine: engine, state: shared.StateComplete}, nil)
+
+	lines = progressLines(t, out.String())
+	g.Expect(lines).To(HaveLen(3))
+
+	summary := lines[2]
+	g.Expect(summary.Type).To(Equal("summary"))
+	g.Expect(summary.Status).To(Equal(shared.StateComplete))
+	g.Expect(summary.Phase).To(Equal("complete"))
+	g.Expect(summary.FilesProcessed).To(Equal(1))
+	g.Expect(summary.BytesTransferred).To(Equal(int64(5)))
+}
+
+func TestProgressReporter_SummarizesRunFailure(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	var out bytes.Buffer
+
+	newProgressReporter(&out).finish(nil, errors.New("terminal went away"))
+
+	lines := progressLines(t, out.String())
+	g.Expect(lines).To(HaveLen(1))
+	g.Expect(lines[0].Type).To(Equal("summary"))
+	g.Expect(lines[0].Status).To(Equal(shared.StateError))
+	g.Expect(lines[0].Error).To(Equal("terminal went away"))
+}
+
+// finishedRun is a TUI model whose run has ended.
+type finishedRun struct {
+	engine *syncengine.Engine
+	state  string
+}
+
+func (r finishedRun) Init() tea.Cmd                       { return nil }
+func (r finishedRun) Update(tea.Msg) (tea.Model, tea.Cmd) { return r, nil }
+func (r finishedRun) View() string                        { return "" }
+func (r finishedRun) Engine() *syncengine.Engine          { return r.engine }
+func (r finishedRun) Outcome() (string, error)            { return r.state, nil }
+
+// progressLines decodes NDJSON output.
+func progressLines(t *testing.T, out string) []progressLine {
+	t.Helper()
+
+	var lines []progressLine
+
+	for _, text := range strings.Split(strings.TrimSpace(out), "\n") {
+		var line progressLine
+
+		err := json.Unmarshal([]byte(text), &line)
+		if err != nil {
+			t.Fatalf("invalid progress line %q: %v", text, err)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// runScanOnly scans the source and saves the listing to --source-index, so syncs to several
+// destinations can share one scan. No destination is read. Returns the process exit code.
+func runScanOnly(cfg *config.Config, out, errOut io.Writer) int {
+	engine, err := syncengine.NewEngineFromConfig(cfg)
+	if err != nil {
+		fmt.Fprintf(errOut, "Error: failed to initialize engine: %v\n", err)
+		return exitError
+	}
+	defer engine.Close()
+
+	engine.ApplyConfig(cfg)
+
+	count, err := engine.BuildSourceIndex()
+	if err != nil {
+		fmt.Fprintf(errOut, "Error: %v\n", err)
+		return exitError
+	}
+
+	fmt.Fprintf(out, "Indexed %d entries from %s into %s\n", count, cfg.SourcePath, cfg.SourceIndex)
+
+	return exitInSync
+}
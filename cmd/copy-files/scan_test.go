@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+)
+
+func TestRunScanOnly_WritesSourceIndex(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	indexPath := filepath.Join(t.TempDir(), "source.idx")
+
+	g.Expect(os.WriteFile(filepath.Join(sourceDir, "a.txt"), []byte("a"), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(sourceDir, "b.txt"), []byte("b"), 0o600)).To(Succeed())
+
+	cfg := &config.Config{SourcePath: sourceDir, SourceIndex: indexPath, ScanOnly: true}
+
+	var out, errOut bytes.Buffer
+
+	g.Expect(runScanOnly(cfg, &out, &errOut)).To(Equal(exitInSync))
+	g.Expect(errOut.String()).To(BeEmpty())
+	g.Expect(out.String()).To(HavePrefix("Indexed 2 entries"))
+	g.Expect(indexPath).To(BeAnExistingFile())
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// runGenScript analyzes source against destination and writes the planned sync to --gen-script
+// as an executable shell script, changing neither tree. Returns the process exit code.
+func runGenScript(cfg *config.Config, out, errOut io.Writer) int {
+	engine, err := syncengine.NewEngineFromConfig(cfg)
+	if err != nil {
+		fmt.Fprintf(errOut, "Error: failed to initialize engine: %v\n", err)
+		return exitError
+	}
+	defer engine.Close()
+
+	engine.ApplyConfig(cfg)
+
+	// A saved plan describes a previous run, not the trees as they are now
+	engine.Resume = false
+
+	err = engine.Analyze()
+	if err != nil {
+		fmt.Fprintf(errOut, "Error: %v\n", err)
+		return exitError
+	}
+
+	//nolint:gosec,mnd // The script is meant to be executable by its owner
+	file, err := os.OpenFile(cfg.GenScript, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o700)
+	if err != nil {
+		fmt.Fprintf(errOut, "Error: failed to create script: %v\n", err)
+		return exitError
+	}
+
+	commands, err := engine.WriteScript(file)
+
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		fmt.Fprintf(errOut, "Error: %v\n", err)
+		return exitError
+	}
+
+	fmt.Fprintf(out, "Wrote %d commands to %s; review it, then run it with sh\n", commands, cfg.GenScript)
+
+	return exitInSync
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+func TestRunGenScript_WritesScriptWithoutSyncing(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	scriptPath := filepath.Join(t.TempDir(), "sync.sh")
+
+	g.Expect(os.WriteFile(filepath.Join(sourceDir, "new.txt"), []byte("new"), 0o600)).To(Succeed())
+
+	cfg := &config.Config{SourcePath: sourceDir, DestPath: destDir, TypeOfChange: config.Content, GenScript: scriptPath}
+
+	var out, errOut bytes.Buffer
+
+	g.Expect(runGenScript(cfg, &out, &errOut)).To(Equal(exitInSync))
+	g.Expect(errOut.String()).To(BeEmpty())
+	g.Expect(out.String()).To(HavePrefix("Wrote"))
+
+	data, err := os.ReadFile(scriptPath)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`cp -p "$src"/'new.txt' "$dst"/'new.txt'`))
+
+	// Nothing was copied
+	_, err = os.Stat(filepath.Join(destDir, "new.txt"))
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+}
+
+func TestRunGenScript_LeavesStaleTempFilesForTheScript(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	scriptPath := filepath.Join(t.TempDir(), "sync.sh")
+
+	tempPath := filepath.Join(destDir, "report.pdf"+fileops.TempSuffix)
+	g.Expect(os.WriteFile(filepath.Join(sourceDir, "report.pdf"), []byte("full report"), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(tempPath, []byte("full re"), 0o600)).To(Succeed())
+
+	cfg := &config.Config{SourcePath: sourceDir, DestPath: destDir, TypeOfChange: config.Content, GenScript: scriptPath}
+
+	var out, errOut bytes.Buffer
+
+	g.Expect(runGenScript(cfg, &out, &errOut)).To(Equal(exitInSync))
+	g.Expect(errOut.String()).To(BeEmpty())
+
+	data, err := os.ReadFile(scriptPath)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`rm -f "$dst"/'report.pdf` + fileops.TempSuffix + `'`))
+
+	// The script removes it, not generating the script
+	g.Expect(tempPath).To(BeARegularFile())
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// summaryFilePermissions lets other users read the summary, as they can the log.
+const summaryFilePermissions = 0o644
+
+// runSummary is the JSON summary of how a run ended, written to --summary-json and POSTed to
+// --webhook.
+type runSummary struct {
+	Status           string             `json:"status"` // "complete", "cancelled", "error" or "verified"
+	Error            string             `json:"error,omitempty"`
+	Source           string             `json:"source"`
+	Destination      string             `json:"destination"`
+	StartedAt        time.Time          `json:"started_at,omitzero"`
+	FinishedAt       time.Time          `json:"finished_at"`
+	DurationSeconds  float64            `json:"duration_seconds"`
+	PhaseSeconds     map[string]float64 `json:"phase_seconds,omitempty"` // By phase, e.g. "scan_source" or "copy"
+	FilesToSync      int                `json:"files_to_sync"`
+	FilesSynced      int                `json:"files_synced"`
+	FilesFailed      int                `json:"files_failed"`
+	FilesCancelled   int                `json:"files_cancelled"`
+	FilesDeleted     int                `json:"files_deleted"`
+	BytesToSync      int64              `json:"bytes_to_sync"`
+	BytesTransferred int64              `json:"bytes_transferred"`
+	Bottleneck       string             `json:"bottleneck,omitempty"` // "source", "destination" or "balanced"
+	MaxWorkers       int                `json:"max_workers"`
+	SizeHistogram    map[string]int     `json:"size_histogram,omitempty"`
+	Errors           []fileFailure      `json:"errors"`
+}
+
+// fileFailure is one file that failed to sync, with its error.
+type fileFailure struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// newRunSummary summarizes a run that ended in state, with engine nil if it ended before one was
+// created, listing the first maxErrors files that failed (0 = all of them). Times are written as
+// RFC 3339.
+func newRunSummary(cfg *config.Config, engine *syncengine.Engine, state string, runErr error,
+	now time.Time, maxErrors int,
+) runSummary {
+	summary := runSummary{
+		Status:      state,
+		Source:      cfg.SourcePath,
+		Destination: cfg.DestPath,
+		FinishedAt:  now,
+		Errors:      []fileFailure{},
+	}
+
+	if runErr != nil {
+		summary.Error = runErr.Error()
+	}
+
+	if engine == nil {
+		return summary
+	}
+
+	status := engine.GetStatus()
+
+	summary.StartedAt = status.StartTime
+	if !status.EndTime.IsZero() {
+		summary.FinishedAt = status.EndTime
+	}
+
+	if !status.StartTime.IsZero() {
+		summary.DurationSeconds = summary.FinishedAt.Sub(status.StartTime).Seconds()
+	}
+
+	for _, timing := range status.PhaseTimings() {
+		if summary.PhaseSeconds == nil {
+			summary.PhaseSeconds = make(map[string]float64)
+		}
+
+		summary.PhaseSeconds[strings.ReplaceAll(timing.Phase, " ", "_")] = timing.Duration.Seconds()
+	}
+
+	summary.FilesToSync = status.TotalFiles
+	summary.FilesSynced = status.ProcessedFiles
+	summary.FilesFailed = status.FailedFiles
+	summary.FilesCancelled = status.CancelledFiles
+	summary.FilesDeleted = status.FilesDeleted
+	summary.BytesToSync = status.TotalBytes
+	summary.BytesTransferred = status.TransferredBytes
+	summary.Bottleneck = status.Bottleneck
+	summary.MaxWorkers = status.MaxWorkers
+	summary.SizeHistogram = status.SizeHistogram
+
+	fileErrors := status.Errors
+	if maxErrors > 0 {
+		fileErrors = fileErrors[:min(len(fileErrors), maxErrors)]
+	}
+
+	for _, fileErr := range fileErrors {
+		summary.Errors = append(summary.Errors, fileFailure{Path: fileErr.FilePath, Error: fileErr.Error.Error()})
+	}
+
+	return summary
+}
+
+// writeSummaryJSON writes how the run ended to --summary-json, whether it completed, was
+// cancelled or failed, reporting a failed write on errOut.
+func writeSummaryJSON(cfg *config.Config, model tea.Model, runErr error, errOut io.Writer) {
+	engine, state, outcomeErr := finalOutcome(model, runErr)
+
+	data, err := json.MarshalIndent(newRunSummary(cfg, engine, state, outcomeErr, time.Now(), 0), "", "  ")
+	if err == nil {
+		err = os.WriteFile(cfg.SummaryJSON, append(data, '\n'), summaryFilePermissions)
+	}
+
+	if err != nil {
+		fmt.Fprintf(errOut, "Warning: failed to write summary to %s: %v\n", cfg.SummaryJSON, err)
+	}
+}
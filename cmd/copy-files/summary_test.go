@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/internal/tui/shared"
+)
+
+func TestWriteSummaryJSON_RecordsCancelledRun(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(sourceDir, "a.txt"), []byte("hello"), 0o600)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	engine.Status.FailedFiles = 1
+	engine.Status.Errors = append(engine.Status.Errors,
+		syncengine.FileError{FilePath: "b.txt", Error: errors.New("permission denied")})
+
+	cfg := &config.Config{SourcePath: sourceDir, DestPath: "/dst", SummaryJSON: filepath.Join(t.TempDir(), "run.json")}
+
+	var errOut bytes.Buffer
+
+	writeSummaryJSON(cfg, finishedRun{engine: engine, state: shared.StateCancelled}, nil, &errOut)
+	g.Expect(errOut.String()).To(BeEmpty())
+
+	data, err := os.ReadFile(cfg.SummaryJSON)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	var summary runSummary
+
+	g.Expect(json.Unmarshal(data, &summary)).To(Succeed())
+	g.Expect(summary.Status).To(Equal(shared.StateCancelled))
+	g.Expect(summary.FilesSynced).To(Equal(1))
+	g.Expect(summary.FilesFailed).To(Equal(1))
+	g.Expect(summary.BytesTransferred).To(Equal(int64(5)))
+	g.Expect(summary.MaxWorkers).To(BeNumerically(">", 0))
+	g.Expect(summary.Errors).To(Equal([]fileFailure{{Path: "b.txt", Error: "permission denied"}}))
+
+	var raw map[string]any
+
+	g.Expect(json.Unmarshal(data, &raw)).To(Succeed())
+
+	for _, key := range []string{"started_at", "finished_at"} {
+		g.Expect(raw).To(HaveKey(key))
+		_, err := time.Parse(time.RFC3339, raw[key].(string))
+		g.Expect(err).ShouldNot(HaveOccurred(), key)
+	}
+
+	g.Expect(raw).To(HaveKey("duration_seconds"))
+}
+
+func TestNewRunSummary_ReportsPhaseSeconds(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine, err := syncengine.NewEngine(t.TempDir(), t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.Status.PhaseDurations = map[string]time.Duration{
+		syncengine.PhaseScanSource: 2 * time.Second,
+		syncengine.PhaseCopy:       90 * time.Second,
+	}
+
+	cfg := &config.Config{SourcePath: "/src", DestPath: "/dst"}
+	summary := newRunSummary(cfg, engine, shared.StateComplete, nil, time.Now(), 0)
+	g.Expect(summary.PhaseSeconds).To(Equal(map[string]float64{"scan_source": 2, "copy": 90}))
+
+	data, err := json.Marshal(summary)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`"phase_seconds":{"copy":90,"scan_source":2}`))
+}
+
+func TestNewRunSummary_ListsTheFirstMaxErrors(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine, err := syncengine.NewEngine(t.TempDir(), t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	for _, path := range []string{"a.txt", "b.txt", "c.txt"} {
+		engine.Status.Errors = append(engine.Status.Errors,
+			syncengine.FileError{FilePath: path, Error: errors.New("permission denied")})
+	}
+
+	cfg := &config.Config{SourcePath: "/src", DestPath: "/dst"}
+
+	// The webhook lists the first few, --summary-json all of them
+	limited := newRunSummary(cfg, engine, shared.StateComplete, nil, time.Now(), 2)
+	g.Expect(limited.Errors).To(HaveLen(2))
+	g.Expect(limited.Errors[1].Path).To(Equal("b.txt"))
+
+	all := newRunSummary(cfg, engine, shared.StateComplete, nil, time.Now(), 0)
+	g.Expect(all.Errors).To(HaveLen(3))
+}
+
+func TestWriteSummaryJSON_RecordsRunFailure(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	cfg := &config.Config{SourcePath: "/src", DestPath: "/dst", SummaryJSON: filepath.Join(t.TempDir(), "run.json")}
+
+	var errOut bytes.Buffer
+
+	writeSummaryJSON(cfg, nil, errors.New("terminal went away"), &errOut)
+	g.Expect(errOut.String()).To(BeEmpty())
+
+	data, err := os.ReadFile(cfg.SummaryJSON)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	var summary runSummary
+
+	g.Expect(json.Unmarshal(data, &summary)).To(Succeed())
+	g.Expect(summary.Status).To(Equal(shared.StateError))
+	g.Expect(summary.Error).To(Equal("terminal went away"))
+	g.Expect(summary.Source).To(Equal("/src"))
+	g.Expect(summary.Errors).To(BeEmpty())
+}
+
+func TestWriteSummaryJSON_ReportsWriteFailure(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	cfg := &config.Config{SummaryJSON: filepath.Join(t.TempDir(), "missing", "run.json")}
+
+	var errOut bytes.Buffer
+
+	writeSummaryJSON(cfg, nil, nil, &errOut)
+	g.Expect(errOut.String()).To(HavePrefix("Warning: failed to write summary"))
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/internal/tui/shared"
+)
+
+// Webhook delivery.
+const (
+	webhookAttempts        = 3
+	webhookRetryDelay      = 2 * time.Second
+	webhookTimeout         = 10 * time.Second
+	webhookMaxErrors       = 20                     // Errors listed in the payload; the rest are only counted
+	webhookSignatureHeader = "X-Glowsync-Signature" // "sha256=" + hex HMAC-SHA256 of the body
+)
+
+// runOutcome is the finished TUI model, which knows how the run ended.
+type runOutcome interface {
+	Engine() *syncengine.Engine
+	Outcome() (string, error)
+}
+
+// finalOutcome reports the run's engine, nil if the run ended before one was created, and how
+// the run ended, from the finished TUI model or the error the program stopped with.
+func finalOutcome(model tea.Model, runErr error) (*syncengine.Engine, string, error) {
+	if run, ok := model.(runOutcome); ok && runErr == nil {
+		state, outcomeErr := run.Outcome()
+
+		return run.Engine(), state, outcomeErr
+	}
+
+	return nil, shared.StateError, runErr
+}
+
+// notifyWebhook POSTs how the run ended to --webhook, reporting delivery on errOut. A failed
+// delivery is only reported; it never changes the run's exit code.
+func notifyWebhook(cfg *config.Config, model tea.Model, runErr error, errOut io.Writer) {
+	engine, state, outcomeErr := finalOutcome(model, runErr)
+
+	payload := newRunSummary(cfg, engine, state, outcomeErr, time.Now(), webhookMaxErrors)
+	client := &http.Client{Timeout: webhookTimeout}
+
+	err := sendWebhook(client, cfg.Webhook, cfg.WebhookSecret, payload, webhookRetryDelay)
+	if err != nil {
+		fmt.Fprintf(errOut, "Warning: webhook to %s failed: %v\n", cfg.Webhook, err)
+		return
+	}
+
+	fmt.Fprintf(errOut, "Webhook delivered to %s (status %s)\n", cfg.Webhook, state)
+}
+
+// sendWebhook POSTs payload as JSON to webhookURL, signing the body with secret if one is set.
+// Network errors, 429s and 5xx responses are retried after retryDelay, up to webhookAttempts
+// tries in all.
+func sendWebhook(client *http.Client, webhookURL, secret string, payload runSummary,
+	retryDelay time.Duration,
+) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	var signature string
+
+	if secret != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		signature = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	for attempt := 1; ; attempt++ {
+		retry, err := postWebhook(client, webhookURL, body, signature)
+		if err == nil {
+			return nil
+		}
+
+		if !retry || attempt == webhookAttempts {
+			return fmt.Errorf("attempt %d of %d: %w", attempt, webhookAttempts, err)
+		}
+
+		time.Sleep(retryDelay)
+	}
+}
+
+// postWebhook makes one delivery attempt, reporting whether a failure is worth retrying.
+func postWebhook(client *http.Client, webhookURL string, body []byte, signature string) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, webhookURL, bytes.NewReader(body)) //nolint:noctx // Client has a timeout
+	if err != nil {
+		return false, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "glowsync")
+
+	if signature != "" {
+		req.Header.Set(webhookSignatureHeader, signature)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to send webhook: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+
+		return retry, fmt.Errorf("webhook receiver returned %s", resp.Status)
+	}
+
+	return false, nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+)
+
+func TestSendWebhook_SignsPayload(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	var (
+		body      []byte
+		signature string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(webhookSignatureHeader)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	payload := runSummary{Status: "complete", Source: "/src", Destination: "/dst", FilesSynced: 3}
+
+	g.Expect(sendWebhook(server.Client(), server.URL, "s3cret", payload, 0)).To(Succeed())
+
+	var received runSummary
+
+	g.Expect(json.Unmarshal(body, &received)).To(Succeed())
+	g.Expect(received.Status).To(Equal("complete"))
+	g.Expect(received.FilesSynced).To(Equal(3))
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	g.Expect(signature).To(Equal("sha256=" + hex.EncodeToString(mac.Sum(nil))))
+}
+
+func TestSendWebhook_RetriesServerErrors(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Header.Get(webhookSignatureHeader)).To(BeEmpty())
+
+		if calls.Add(1) < webhookAttempts {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	g.Expect(sendWebhook(server.Client(), server.URL, "", runSummary{Status: "error"}, 0)).To(Succeed())
+	g.Expect(calls.Load()).To(Equal(int32(webhookAttempts)))
+}
+
+func TestSendWebhook_DoesNotRetryRejections(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	err := sendWebhook(server.Client(), server.URL, "", runSummary{Status: "complete"}, 0)
+	g.Expect(err).To(MatchError(ContainSubstring("401")))
+	g.Expect(calls.Load()).To(Equal(int32(1)))
+}
+
+func TestNotifyWebhook_ReportsRunFailure(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	received := make(chan runSummary, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var payload runSummary
+
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		received <- payload
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{SourcePath: "/src", DestPath: "/dst", Webhook: server.URL}
+
+	var errOut bytes.Buffer
+
+	notifyWebhook(cfg, nil, errors.New("terminal went away"), &errOut)
+
+	var payload runSummary
+
+	g.Eventually(received).WithTimeout(time.Second).Should(Receive(&payload))
+	g.Expect(payload.Status).To(Equal("error"))
+	g.Expect(payload.Error).To(Equal("terminal went away"))
+	g.Expect(payload.Source).To(Equal("/src"))
+	g.Expect(errOut.String()).To(HavePrefix("Webhook delivered"))
+}
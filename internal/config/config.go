@@ -6,19 +6,93 @@ package config
 import (
 	"errors"
 	"fmt"
+	"log/slog"
+	"math"
+	"net/url"
 	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alexflint/go-arg"
 	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
 )
 
 // Exported constants.
 const (
 	// DefaultMaxWorkers is the default maximum number of concurrent workers
 	DefaultMaxWorkers = 4
+	// DefaultModTimeSlack is the default modification time difference ModTimeNewer ignores,
+	// FAT's timestamp resolution
+	DefaultModTimeSlack = 2 * time.Second
+	// DefaultProgressInterval is the default least time between progress updates
+	DefaultProgressInterval = 100 * time.Millisecond
+)
+
+// ByteRate is a number of bytes per second, given like 500KB, 10MB or 1.5GB
+type ByteRate int64
+
+// UnmarshalText implements encoding.TextUnmarshaler for go-arg
+func (br *ByteRate) UnmarshalText(text []byte) error {
+	parsed, err := ParseByteRate(string(text))
+	if err != nil {
+		return err
+	}
+
+	*br = parsed
+
+	return nil
+}
+
+// ByteSize is a number of bytes, given like 500KB, 100MB or 1.5GB
+type ByteSize int64
+
+// UnmarshalText implements encoding.TextUnmarshaler for go-arg
+func (bs *ByteSize) UnmarshalText(text []byte) error {
+	parsed, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+
+	*bs = parsed
+
+	return nil
+}
+
+// CaseConflict decides source files whose paths differ only in letter case, which can't
+// coexist on a case-insensitive destination
+type CaseConflict string
+
+// CaseConflict values.
+const (
+	// CaseConflictOff - don't check (whichever copy lands last wins)
+	CaseConflictOff CaseConflict = ""
+	// CaseConflictError - stop the analysis, listing the colliding paths
+	CaseConflictError CaseConflict = "error"
+	// CaseConflictSkip - leave every colliding file out of the sync
+	CaseConflictSkip CaseConflict = "skip"
+	// CaseConflictFirstBySort - sync the path that sorts first and leave out the others
+	CaseConflictFirstBySort CaseConflict = "first-by-sort"
 )
 
+// UnmarshalText implements encoding.TextUnmarshaler for go-arg
+func (cc *CaseConflict) UnmarshalText(text []byte) error {
+	parsed, err := ParseCaseConflict(string(text))
+	if err != nil {
+		return err
+	}
+
+	*cc = parsed
+
+	return nil
+}
+
 // ChangeType represents the type of changes expected in the sync operation
 type ChangeType int
 
@@ -26,7 +100,7 @@ type ChangeType int
 const (
 	// MonotonicCount - only files added OR removed (not both)
 	MonotonicCount ChangeType = iota
-	// FluctuatingCount - files added AND removed
+	// FluctuatingCount - files added AND removed, and modified files compared by size
 	FluctuatingCount
 	// Content - files may be altered (content changes)
 	Content
@@ -34,6 +108,10 @@ const (
 	DeviousContent
 	// Paranoid - meticulous byte-by-byte comparison
 	Paranoid
+	// QuickContent - size plus hashes of the start, middle and end of each file
+	QuickContent
+	// ModTimeNewer - files whose source modtime is newer than the destination's
+	ModTimeNewer
 )
 
 // String returns the string representation of ChangeType
@@ -49,6 +127,10 @@ func (ct *ChangeType) String() string {
 		return "devious-content-changes"
 	case Paranoid:
 		return "paranoid-does-not-mean-wrong"
+	case QuickContent:
+		return "quick-content"
+	case ModTimeNewer:
+		return "modtime-newer"
 	default:
 		return "unknown"
 	}
@@ -66,13 +148,371 @@ func (ct *ChangeType) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// ClockTime is a local time of day given as HH:MM
+type ClockTime struct {
+	Hour   int
+	Minute int
+	Set    bool // Whether a time was given
+}
+
+// Next returns the first occurrence of the time of day at or after now, in now's location.
+func (ct ClockTime) Next(now time.Time) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), ct.Hour, ct.Minute, 0, 0, now.Location())
+	if next.Before(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for go-arg
+func (ct *ClockTime) UnmarshalText(text []byte) error {
+	parsed, err := time.Parse("15:04", string(text))
+	if err != nil {
+		return fmt.Errorf("%w: %s (expected HH:MM)", ErrInvalidClockTime, text)
+	}
+
+	*ct = ClockTime{Hour: parsed.Hour(), Minute: parsed.Minute(), Set: true}
+
+	return nil
+}
+
+// CollisionPolicy decides source files that --flatten would copy to the same destination name
+type CollisionPolicy string
+
+// CollisionPolicy values.
+const (
+	// CollisionRename - sync them all, numbering the names after the first (photo-1.jpg, ...)
+	CollisionRename CollisionPolicy = ""
+	// CollisionSkip - sync the path that sorts first and leave out the others
+	CollisionSkip CollisionPolicy = "skip"
+	// CollisionOverwriteNewest - sync the most recently modified file and leave out the others
+	CollisionOverwriteNewest CollisionPolicy = "overwrite-newest"
+)
+
+// UnmarshalText implements encoding.TextUnmarshaler for go-arg
+func (cp *CollisionPolicy) UnmarshalText(text []byte) error {
+	parsed, err := ParseCollisionPolicy(string(text))
+	if err != nil {
+		return err
+	}
+
+	*cp = parsed
+
+	return nil
+}
+
+// DestFSType names a destination filesystem whose filename restrictions apply
+type DestFSType string
+
+// DestFSType values.
+const (
+	// DestFSAuto - detect the destination filesystem type (no restrictions if unknown)
+	DestFSAuto DestFSType = ""
+	// DestFSExt4 - Linux ext4 (names up to 255 bytes)
+	DestFSExt4 DestFSType = "ext4"
+	// DestFSNTFS - Windows NTFS (no <>:"/\|?* or trailing spaces/dots, no reserved device names)
+	DestFSNTFS DestFSType = "ntfs"
+	// DestFSVFAT - FAT/exFAT (same restrictions as NTFS)
+	DestFSVFAT DestFSType = "vfat"
+)
+
+// UnmarshalText implements encoding.TextUnmarshaler for go-arg
+func (ft *DestFSType) UnmarshalText(text []byte) error {
+	parsed, err := ParseDestFSType(string(text))
+	if err != nil {
+		return err
+	}
+
+	*ft = parsed
+
+	return nil
+}
+
+// ErrorCategories is a set of error categories, given as a comma-separated list
+type ErrorCategories []fileops.ErrorCategory
+
+// UnmarshalText implements encoding.TextUnmarshaler for go-arg
+func (ec *ErrorCategories) UnmarshalText(text []byte) error {
+	parsed, err := ParseErrorCategories(string(text))
+	if err != nil {
+		return err
+	}
+
+	*ec = parsed
+
+	return nil
+}
+
+// HashAlgorithm selects the hash file contents are compared and verified with
+type HashAlgorithm string
+
+// HashAlgorithm values.
+const (
+	// HashSHA256 - SHA-256, the default
+	HashSHA256 HashAlgorithm = "sha256"
+	// HashBLAKE3 - BLAKE3: cryptographic, and several times faster than SHA-256
+	HashBLAKE3 HashAlgorithm = "blake3"
+	// HashXXHash - 64-bit xxHash: fastest, but not cryptographic, so only for trusted sources
+	HashXXHash HashAlgorithm = "xxhash"
+)
+
+// UnmarshalText implements encoding.TextUnmarshaler for go-arg
+func (ha *HashAlgorithm) UnmarshalText(text []byte) error {
+	parsed, err := ParseHashAlgorithm(string(text))
+	if err != nil {
+		return err
+	}
+
+	*ha = parsed
+
+	return nil
+}
+
+// LogFormat selects how the log file's records are written
+type LogFormat string
+
+// LogFormat values.
+const (
+	// LogFormatText - key=value pairs, one record per line, the default
+	LogFormatText LogFormat = ""
+	// LogFormatJSON - one JSON object per line, for tools that parse the log
+	LogFormatJSON LogFormat = "json"
+)
+
+// UnmarshalText implements encoding.TextUnmarshaler for go-arg
+func (lf *LogFormat) UnmarshalText(text []byte) error {
+	parsed, err := ParseLogFormat(string(text))
+	if err != nil {
+		return err
+	}
+
+	*lf = parsed
+
+	return nil
+}
+
+// LogLevel is the least severe level written to the log file
+type LogLevel string
+
+// LogLevel values.
+const (
+	// LogLevelInfo - phases, plan decisions, scaling and failures, the default
+	LogLevelInfo LogLevel = ""
+	// LogLevelDebug - also every file copied, scaling evaluations and --verbose instrumentation
+	LogLevelDebug LogLevel = "debug"
+	// LogLevelWarn - only warnings and failures
+	LogLevelWarn LogLevel = "warn"
+)
+
+// SlogLevel returns the slog level the log file's handler filters at.
+func (ll LogLevel) SlogLevel() slog.Level {
+	switch ll {
+	case LogLevelDebug:
+		return slog.LevelDebug
+	case LogLevelWarn:
+		return slog.LevelWarn
+	case LogLevelInfo:
+	}
+
+	return slog.LevelInfo
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for go-arg
+func (ll *LogLevel) UnmarshalText(text []byte) error {
+	parsed, err := ParseLogLevel(string(text))
+	if err != nil {
+		return err
+	}
+
+	*ll = parsed
+
+	return nil
+}
+
+// OwnerFilter selects source files by owner. A nil ID matches any owner.
+type OwnerFilter struct {
+	UID *int
+	GID *int
+}
+
+// Active reports whether the filter selects files by owner at all
+func (of OwnerFilter) Active() bool {
+	return of.UID != nil || of.GID != nil
+}
+
+// Matches reports whether a file with the given owner is selected.
+// Files whose owner couldn't be read never match an active filter.
+func (of OwnerFilter) Matches(uid, gid int, known bool) bool {
+	if !of.Active() {
+		return true
+	}
+
+	if !known {
+		return false
+	}
+
+	return (of.UID == nil || *of.UID == uid) && (of.GID == nil || *of.GID == gid)
+}
+
+// ProcessOrder is the order files are copied in
+type ProcessOrder string
+
+// ProcessOrder values.
+const (
+	// OrderDefault - the order analysis found them in
+	OrderDefault ProcessOrder = ""
+	// OrderNewestFirst - most recently modified source files first
+	OrderNewestFirst ProcessOrder = "newest-first"
+)
+
+// UnmarshalText implements encoding.TextUnmarshaler for go-arg
+func (po *ProcessOrder) UnmarshalText(text []byte) error {
+	parsed, err := ParseProcessOrder(string(text))
+	if err != nil {
+		return err
+	}
+
+	*po = parsed
+
+	return nil
+}
+
+// ReflinkMode controls whether files are cloned (copy-on-write) instead of copied
+type ReflinkMode string
+
+// ReflinkMode values.
+const (
+	// ReflinkNever - always copy file contents
+	ReflinkNever ReflinkMode = "never"
+	// ReflinkAuto - clone when the filesystem supports it, otherwise copy
+	ReflinkAuto ReflinkMode = "auto"
+	// ReflinkAlways - clone every file, failing those that can't be cloned
+	ReflinkAlways ReflinkMode = "always"
+)
+
+// UnmarshalText implements encoding.TextUnmarshaler for go-arg
+func (rm *ReflinkMode) UnmarshalText(text []byte) error {
+	parsed, err := ParseReflinkMode(string(text))
+	if err != nil {
+		return err
+	}
+
+	*rm = parsed
+
+	return nil
+}
+
+// SourceDir is one of several sources synced into one destination, each into its own folder
+type SourceDir struct {
+	Name string // Folder at the destination it is synced into
+	Path string // Local path or URL, as for a single --source
+}
+
+// SymlinkMode controls how symbolic links are scanned and copied
+type SymlinkMode string
+
+// SymlinkMode values.
+const (
+	// SymlinkFollow - copy each source link's target, walking linked directories (links back to
+	// a directory being walked are left out)
+	SymlinkFollow SymlinkMode = "follow"
+	// SymlinkPreserve - recreate source links at the destination, pointing at the same targets
+	SymlinkPreserve SymlinkMode = "preserve"
+	// SymlinkSkip - leave links out of the sync: source links aren't copied and destination
+	// links aren't deleted
+	SymlinkSkip SymlinkMode = "skip"
+)
+
+// UnmarshalText implements encoding.TextUnmarshaler for go-arg
+func (sm *SymlinkMode) UnmarshalText(text []byte) error {
+	parsed, err := ParseSymlinkMode(string(text))
+	if err != nil {
+		return err
+	}
+
+	*sm = parsed
+
+	return nil
+}
+
+// TimeBound is a point in time given as a date (2024-01-31), a date and time (RFC 3339), or
+// a duration before now (90m, 12h, 7d, 2w)
+type TimeBound struct {
+	Time time.Time // Zero when not given
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for go-arg
+func (tb *TimeBound) UnmarshalText(text []byte) error {
+	parsed, err := ParseTimeBound(string(text), time.Now())
+	if err != nil {
+		return err
+	}
+
+	tb.Time = parsed
+
+	return nil
+}
+
+// MissingXattr decides files that lack the compared extended attribute on either copy
+type MissingXattr string
+
+// MissingXattr values.
+const (
+	// MissingXattrSync - sync the file
+	MissingXattrSync MissingXattr = "sync"
+	// MissingXattrSkip - leave the destination copy as it is
+	MissingXattrSkip MissingXattr = "skip"
+)
+
+// UnmarshalText implements encoding.TextUnmarshaler for go-arg
+func (mx *MissingXattr) UnmarshalText(text []byte) error {
+	parsed, err := ParseMissingXattr(string(text))
+	if err != nil {
+		return err
+	}
+
+	*mx = parsed
+
+	return nil
+}
+
 // Exported variables.
 var (
+	ErrBaselineNotDirectory   = errors.New("baseline path is not a directory")
+	ErrDestInsideSource       = errors.New("destination is inside the source")
 	ErrDestPathNotDirectory   = errors.New("destination path is not a directory")
 	ErrDestPathNotExist       = errors.New("destination path does not exist")
 	ErrDestPathRequired       = errors.New("destination path is required")
+	ErrDuplicateSourceName    = errors.New("two sources would sync into the same folder")
+	ErrGenScriptMultiSource   = errors.New("--gen-script needs a single --source")
+	ErrGenScriptRemote        = errors.New("--gen-script needs local source and destination paths")
+	ErrInvalidByteRate        = errors.New("invalid byte rate")
+	ErrInvalidByteSize        = errors.New("invalid size")
+	ErrInvalidCaseConflict    = errors.New("invalid case conflict policy")
 	ErrInvalidChangeType      = errors.New("invalid change type")
+	ErrInvalidClockTime       = errors.New("invalid time of day")
+	ErrInvalidCollisionPolicy = errors.New("invalid collision policy")
+	ErrInvalidDestFSType      = errors.New("invalid destination filesystem type")
+	ErrInvalidErrorCategory   = errors.New("invalid error category")
 	ErrInvalidFilePattern     = errors.New("invalid file pattern")
+	ErrInvalidHashAlgorithm   = errors.New("invalid checksum algorithm")
+	ErrInvalidLogFormat       = errors.New("invalid log format")
+	ErrInvalidLogLevel        = errors.New("invalid log level")
+	ErrInvalidMissingXattr    = errors.New("invalid missing-xattr policy")
+	ErrInvalidModTimeWindow   = errors.New("--newer-than is not before --older-than")
+	ErrInvalidOwner           = errors.New("invalid owner")
+	ErrInvalidProcessOrder    = errors.New("invalid processing order")
+	ErrInvalidReflinkMode     = errors.New("invalid reflink mode")
+	ErrInvalidSizeRange       = errors.New("--min-size is above --max-size")
+	ErrInvalidSymlinkMode     = errors.New("invalid symlink mode")
+	ErrInvalidTimeBound       = errors.New("invalid time")
+	ErrInvalidWebhookURL      = errors.New("invalid webhook URL")
+	ErrInvalidWorkerBounds    = errors.New("--min-workers is above --max-workers")
+	ErrMoveFromSnapshot       = errors.New("--move can't remove files from a read-only --snapshot-source")
+	ErrSameSourceAndDest      = errors.New("source and destination are the same directory")
+	ErrSourceIndexRequired    = errors.New("--scan-only requires --source-index")
+	ErrSourceInsideDest       = errors.New("source is inside the destination")
 	ErrSourcePathNotDirectory = errors.New("source path is not a directory")
 	ErrSourcePathNotExist     = errors.New("source path does not exist")
 	ErrSourcePathRequired     = errors.New("source path is required")
@@ -80,15 +520,131 @@ var (
 
 // Config holds the application configuration
 type Config struct {
-	SourcePath       string     `arg:"-s,--source"             help:"Source directory path"`
-	DestPath         string     `arg:"-d,--dest"               help:"Destination directory path"`
-	FilePattern      string     `arg:"--filter"                help:"File pattern filter (glob syntax, e.g., *.mov, **/*.{mov,mp4})"` //nolint:lll
-	InteractiveMode  bool       `arg:"-i,--interactive"        help:"Run in interactive mode"`
-	SkipConfirmation bool       `arg:"--yes,-y"                help:"Skip confirmation screen and proceed directly to sync"`                                                                                                                                                                //nolint:lll
-	AdaptiveMode     bool       `arg:"--adaptive"              default:"true"                    help:"Use adaptive concurrency"`                                                                                                                                                           //nolint:lll,tagalign
-	Workers          int        `arg:"-w,--workers"            default:"4"                       help:"Number of workers (0 = adaptive)"`                                                                                                                                                   //nolint:lll,tagalign
-	TypeOfChange     ChangeType `arg:"--type-of-change,--type" default:"monotonic-count"         help:"Type of changes expected: monotonic-count|fluctuating-count|content|devious-content-changes|paranoid-does-not-mean-wrong (aliases: monotonic|fluctuating|content|devious|paranoid)"` //nolint:lll,tagalign // Struct tag with comprehensive help text
-	Verbose          bool       `arg:"-v,--verbose"            help:"Enable verbose progress logging"`                                                                                                                                                                                      //nolint:tagalign
+	Sources             []string        `arg:"-s,--source,separate"    help:"Source directory path; give it more than once to sync several sources into one destination, each into its own folder: named after the source, or NAME=PATH to choose"` //nolint:lll
+	DestPath            string          `arg:"-d,--dest"               help:"Destination directory path"`
+	FilePattern         string          `arg:"--filter"                help:"File pattern filter (glob syntax, e.g., *.mov, **/*.{mov,mp4})"` //nolint:lll
+	InteractiveMode     bool            `arg:"-i,--interactive"        help:"Run in interactive mode"`
+	SkipConfirmation    bool            `arg:"--yes,-y"                help:"Skip confirmation screen and proceed directly to sync"`                                                                                                                                                                //nolint:lll
+	Exclude             []string        `arg:"--exclude,separate"      help:"Leave out files matching this glob pattern, and never delete them from the destination (repeatable, e.g., **/node_modules/**, *.{tmp,log})"`                                                                           //nolint:lll
+	AdaptiveMode        bool            `arg:"--adaptive"              default:"true"                    help:"Use adaptive concurrency"`                                                                                                                                                           //nolint:lll,tagalign
+	Workers             int             `arg:"-w,--workers"            default:"4"                       help:"Number of workers (0 = adaptive)"`                                                                                                                                                   //nolint:lll,tagalign
+	TypeOfChange        ChangeType      `arg:"--type-of-change,--type" default:"monotonic-count"         help:"Kind of change: monotonic-count|fluctuating-count|content|devious-content-changes|paranoid-does-not-mean-wrong|quick-content|modtime-newer (aliases: first word of each mode name)"` //nolint:lll,tagalign // Struct tag with comprehensive help text
+	Verbose             bool            `arg:"-v,--verbose"            help:"Enable verbose progress logging"`                                                                                                                                                                                      //nolint:tagalign
+	AnalysisLogPath     string          `arg:"--analysis-log"          help:"Write analysis decisions to a separate log file"`                                                                                                                                                                      //nolint:tagalign
+	MaxDepth            int             `arg:"--max-depth"             help:"Maximum directory depth to scan (0 = unlimited)"`                                                                                                                                                                      //nolint:tagalign
+	Resume              bool            `arg:"--resume"                help:"Resume an interrupted sync from its saved plan"`                                                                                                                                                                       //nolint:tagalign
+	DestFS              DestFSType      `arg:"--dest-fs"               help:"Destination filesystem naming rules: vfat, ntfs, ext4 (default: detect)"`                                                                                                                                              //nolint:tagalign
+	SanitizeNames       bool            `arg:"--sanitize-names"        help:"Replace characters the destination filesystem cannot store"`                                                                                                                                                           //nolint:tagalign
+	KeepNewest          int             `arg:"--keep-newest"           help:"Only sync the newest N files in each source directory (0 = all)"`                                                                                                                                                      //nolint:tagalign
+	PruneOlder          bool            `arg:"--prune-older"           help:"Delete destination copies of files --keep-newest leaves out"`                                                                                                                                                          //nolint:tagalign
+	Repair              bool            `arg:"--repair"                help:"Verify destination files by hash and re-copy only corrupted ones"`                                                                                                                                                     //nolint:tagalign
+	Preallocate         bool            `arg:"--preallocate"           help:"Reserve each destination file's full size before copying to reduce fragmentation (Linux only)"`                                                                                                                        //nolint:tagalign
+	PreservePermissions bool            `arg:"--preserve-permissions"  help:"Copy permission bits and fix permission-only changes on re-runs"`                                                                                                                                                      //nolint:tagalign
+	PreserveEmptyDirs   bool            `arg:"--preserve-empty-dirs"   help:"Create source directories at the destination even when no files are copied into them, such as empty ones"`                                                                                                             //nolint:tagalign
+	MaxOpsPerSecond     int             `arg:"--max-ops"               help:"Maximum filesystem operations per second across all workers, for request-throttled backends (0 = unlimited)"`                                                                                                          //nolint:tagalign
+	CAStore             bool            `arg:"--ca-store"              help:"Store each unique file content once under objects/<hash> with a path index at the destination root"`                                                                                                                   //nolint:tagalign
+	SampleSize          int64           `arg:"--sample-size"           help:"Bytes read from the start, middle and end of each file by --type quick-content (0 = 64KB)"`                                                                                                                            //nolint:tagalign
+	ModTimeSlack        time.Duration   `arg:"--modtime-slack"         help:"Modification time differences --type modtime-newer ignores, for filesystems with coarse timestamps like FAT and SMB (default: 2s)"`                                                                                    //nolint:tagalign
+	ChecksumAlgo        HashAlgorithm   `arg:"--checksum-algo,--checksum" help:"Hash used to compare and verify file contents: sha256, blake3 (cryptographic and faster), xxhash (fastest, NOT cryptographic)"`                                                                                     //nolint:tagalign
+	DestHashBloom       string          `arg:"--dest-hash-bloom"       help:"Compare against this Bloom filter of destination hashes instead of scanning the destination"`                                                                                                                          //nolint:tagalign
+	WriteDestHashBloom  string          `arg:"--write-dest-hash-bloom" help:"After a successful sync, write a Bloom filter of destination hashes to this file"`                                                                                                                                     //nolint:tagalign
+	BloomFPR            float64         `arg:"--bloom-fpr"             help:"False-positive rate of the filter --write-dest-hash-bloom writes (0 = 0.01)"`                                                                                                                                          //nolint:tagalign
+	Reflink             ReflinkMode     `arg:"--reflink"               help:"Clone files instead of copying them on copy-on-write filesystems: auto, always, never (Linux only)"`                                                                                                                   //nolint:tagalign
+	BatchSmallFiles     bool            `arg:"--batch-small-files"     help:"Send small files to SFTP destinations in tar batches unpacked by the remote tar"`                                                                                                                                      //nolint:tagalign
+	BatchThreshold      int64           `arg:"--batch-threshold"       help:"Files smaller than this many bytes are batched by --batch-small-files (0 = 64KB)"`                                                                                                                                     //nolint:tagalign
+	Deadline            ClockTime       `arg:"--deadline"              help:"Stop starting new files at this local time (HH:MM); files in flight finish and the rest are deferred"`                                                                                                                 //nolint:tagalign
+	MaxDuration         time.Duration   `arg:"--max-duration"          help:"Stop starting new files this long after the run starts, e.g. 4h (0 = no limit)"`                                                                                                                                       //nolint:tagalign
+	Order               ProcessOrder    `arg:"--order"                 help:"Order to copy files in: default (as found) or newest-first (most recently modified first)"`                                                                                                                            //nolint:tagalign
+	AssertSynced        bool            `arg:"--assert-synced"         help:"Only analyze: exit 0 if the destination is in sync, or list the drift and exit 6 (no TUI, nothing changed)"`                                                                                                           //nolint:tagalign
+	Verify              bool            `arg:"--verify"                help:"Only check that the destination still mirrors the source: list missing, mismatched and extra files and exit 6 if any (nothing changed)"`                                                                               //nolint:tagalign
+	ClockSkewThreshold  time.Duration   `arg:"--clock-skew-threshold"  help:"Warn when the destination's clock is more than this far off the source's, e.g. 2s (0 = don't check)"`                                                                                                                  //nolint:tagalign
+	CompareRuns         bool            `arg:"--compare-runs"          help:"Compare this run's statistics with the previous run's and warn about sudden changes, like an empty source"`                                                                                                            //nolint:tagalign
+	BaselineDir         string          `arg:"--baseline-dir"          help:"Leave out source files whose content matches the same path in this local directory, e.g. a base image"`                                                                                                                //nolint:tagalign
+	VerifyTotals        bool            `arg:"--verify-totals"         help:"After syncing, check that the bytes transferred add up to the sizes of the files copied"`                                                                                                                              //nolint:tagalign
+	Owner               string          `arg:"--owner"                 help:"Only sync source files owned by this user (name looked up on this machine)"`                                                                                                                                           //nolint:tagalign
+	OwnerUID            *int            `arg:"--owner-uid"             help:"Only sync source files owned by this numeric user ID"`                                                                                                                                                                 //nolint:tagalign
+	OwnerGID            *int            `arg:"--owner-gid"             help:"Only sync source files owned by this numeric group ID"`                                                                                                                                                                //nolint:tagalign
+	MaxLoad             float64         `arg:"--max-load"              help:"Scale adaptive workers down while the 1-minute load average is above this, e.g. 4.0 (0 = ignore load; Linux and macOS)"`                                                                                               //nolint:tagalign
+	Itemize             string          `arg:"--itemize"               help:"Write an rsync --itemize-changes style line (e.g. >f.st...... path) to this file for every destination change"`                                                                                                        //nolint:tagalign
+	RequireMount        bool            `arg:"--require-mount"         help:"Refuse to sync unless the destination is a mount point, so an unmounted share is not filled or mirrored onto (local destinations)"`                                                                                    //nolint:tagalign
+	MountMarker         string          `arg:"--mount-marker"          help:"Refuse to sync unless this file exists in the destination root, e.g. a marker placed on the mounted share"`                                                                                                            //nolint:tagalign
+	SkipErrors          ErrorCategories `arg:"--skip-errors"           help:"Comma-separated error categories that are recorded but don't count toward the abort limit: permission, locked, vanished, full, io, unknown"`                                                                           //nolint:tagalign
+	SnapshotSource      bool            `arg:"--snapshot-source"       help:"Sync from a read-only btrfs, ZFS or LVM snapshot of the source, removed afterwards, for a consistent copy of a live system (Linux, usually needs root)"`                                                               //nolint:tagalign
+	SourceIndex         string          `arg:"--source-index"          help:"Load the source listing from this file instead of scanning when it is still fresh, and save each fresh scan to it"`                                                                                                    //nolint:tagalign
+	RefreshIndex        bool            `arg:"--refresh-index"         help:"Rescan the source and rewrite --source-index even if the saved listing looks fresh"`                                                                                                                                   //nolint:tagalign
+	SourceIndexMaxAge   time.Duration   `arg:"--source-index-max-age"  help:"Rescan when --source-index is older than this, e.g. 1h, to catch files rewritten in place (0 = no limit)"`                                                                                                             //nolint:tagalign
+	ScanOnly            bool            `arg:"--scan-only"             help:"Only scan the source and save it to --source-index for later syncs (no destination, no TUI)"`                                                                                                                          //nolint:tagalign
+	KnownFailures       int             `arg:"--known-failures"        help:"Track files that fail run after run; after this many failed runs in a row their errors are logged in one line and listed apart from new ones (0 = off)"`                                                               //nolint:tagalign
+	SkipKnownFailures   bool            `arg:"--skip-known-failures"   help:"Leave known persistent failures out of the plan (implies --known-failures 3 unless set)"`                                                                                                                              //nolint:tagalign
+	GenScript           string          `arg:"--gen-script"            help:"Only analyze, and write the planned sync to this file as a shell script of cp, mkdir and rm commands to review and run yourself (no TUI, nothing changed)"`                                                            //nolint:tagalign
+	XattrCompare        string          `arg:"--xattr-compare"         help:"Decide which files need a sync by this extended attribute (e.g. user.asset_version) instead of --type-of-change, copying it along (Linux, local paths only)"`                                                          //nolint:tagalign
+	XattrNewer          bool            `arg:"--xattr-newer"           help:"With --xattr-compare, only sync files whose source version is higher (e.g. 1.10 over 1.9)"`                                                                                                                            //nolint:tagalign
+	XattrMissing        MissingXattr    `arg:"--xattr-missing"         help:"With --xattr-compare, what to do with files lacking the attribute on either side: sync, skip (default: sync)"`                                                                                                         //nolint:tagalign
+	MaxWritesPerDir     int             `arg:"--max-writes-per-dir"    help:"Maximum workers writing into any one destination directory at once, to avoid seek thrashing on spinning disks (0 = unlimited)"`                                                                                        //nolint:tagalign
+	Webhook             string          `arg:"--webhook"               help:"POST a JSON summary of the run to this URL when it finishes, whether it completed, was cancelled or failed"`                                                                                                           //nolint:tagalign
+	WebhookSecret       string          `arg:"--webhook-secret"        help:"Sign --webhook requests with HMAC-SHA256 using this secret, in the X-Glowsync-Signature header"`                                                                                                                       //nolint:tagalign
+	Converge            int             `arg:"--converge"              help:"After syncing, analyze and sync again until an analysis finds nothing left, for sources that change during the run, with at most this many sync passes (0 = sync once)"`                                               //nolint:tagalign
+	TreeDigest          bool            `arg:"--tree-digest"           help:"Only hash every file in the source and destination, and print one digest of each tree and whether they match (exit 0 if they do, 6 if not; no TUI, nothing changed)"`                                                  //nolint:tagalign
+	ProtectDestEdits    bool            `arg:"--protect-dest-edits"    help:"Keep destination files that are newer than the source and a different size, as likely edits made at the destination, and list them in the summary instead of overwriting them"`                                        //nolint:tagalign
+	CaseConflicts       CaseConflict    `arg:"--case-conflicts"        help:"Source paths differing only in case, which a case-insensitive destination holds as one: off, error (stop and list them), skip (sync none), first-by-sort (sync the first) (default: off)"`                             //nolint:tagalign
+	EstimateSavings     bool            `arg:"--estimate-savings"      help:"During analysis, estimate what deduplication and preserving sparse files would save on the source, shown before syncing (slow: hashes every file that shares its size with another)"`                                  //nolint:tagalign
+	Keychain            bool            `arg:"--keychain"              help:"Keep SFTP passwords in the system keychain (macOS keychain, Linux Secret Service): prompt once per host and store the answer, then reuse it silently"`                                                                 //nolint:tagalign
+	ResumeManifest      bool            `arg:"--resume-manifest"       help:"Keep a manifest of finished copies at the destination while syncing, so a rerun after an interruption skips them and resumes half-written files"`                                                                      //nolint:tagalign
+	VerifyResumed       bool            `arg:"--verify-resumed"        help:"Before carrying on a half-written file (--resume-manifest), check what was already copied matches the source, and copy it from scratch if not"`                                                                        //nolint:tagalign
+	Flatten             bool            `arg:"--flatten"               help:"Copy every source file straight into the destination directory, dropping its subdirectories; orphans are kept, never deleted"`                                                                                         //nolint:tagalign
+	OnCollision         CollisionPolicy `arg:"--on-collision"          help:"Files --flatten gives the same name: rename (number the later ones), skip (sync the first by path), overwrite-newest (sync the newest) (default: rename)"`                                                             //nolint:tagalign
+	MinSize             ByteSize        `arg:"--min-size"              help:"Only sync files of at least this size, e.g. 100MB; smaller files are left alone at both ends (0 = no minimum)"`                                                                                                        //nolint:tagalign
+	MaxSize             ByteSize        `arg:"--max-size"              help:"Only sync files of at most this size, e.g. 1MB; larger files are left alone at both ends (0 = no maximum)"`                                                                                                            //nolint:tagalign
+	NewerThan           TimeBound       `arg:"--newer-than"            help:"Only sync files modified within this long, e.g. 7d, 12h or 2w, or since this date, e.g. 2024-01-31; older files are left alone at both ends"`                                                                          //nolint:tagalign
+	OlderThan           TimeBound       `arg:"--older-than"            help:"Only sync files last modified longer ago than this, e.g. 30d, or before this date, e.g. 2024-01-31; newer files are left alone at both ends"`                                                                          //nolint:tagalign
+	Move                bool            `arg:"--move"                  help:"Move files instead of copying them: renamed when both paths are local, otherwise copied and removed from the source once the copy is confirmed; destination files not in the source are kept"`                         //nolint:tagalign
+	LogLevel            LogLevel        `arg:"--log-level"             help:"Least severe records written to the log file: debug (also every file copied and scaling evaluation), info, warn (default: info)"`                                                                                      //nolint:tagalign
+	LogFormat           LogFormat       `arg:"--log-format"            help:"How log file records are written: text (key=value pairs) or json (one object per line) (default: text)"`                                                                                                               //nolint:tagalign
+	VerifyAfterCopy     bool            `arg:"--verify-after-copy"     help:"Hash each destination file after copying and compare it with the source, hashed as it was copied; mismatches fail the file"`                                                                                           //nolint:tagalign
+	MaxRate             ByteRate        `arg:"--max-rate"              help:"Maximum bytes per second copied across all workers, e.g. 10MB or 1.5GB (0 = unlimited)"`                                                                                                                               //nolint:tagalign
+	Symlinks            SymlinkMode     `arg:"--symlinks"              help:"How symbolic links are handled: follow (copy their targets), preserve (recreate them as links), skip (leave them out) (default: follow)"`                                                                              //nolint:tagalign
+	JSONProgress        bool            `arg:"--json-progress"         help:"When stdout isn't a terminal, write progress to stderr as JSON lines about every 500ms, then a summary line when the run ends"`                                                                                        //nolint:tagalign
+	ProgressInterval    time.Duration   `arg:"--progress-interval"     help:"Least time between progress updates while copying and between status updates while analyzing, e.g. 500ms for slow terminals (0 = update on every change)"`                                                             //nolint:tagalign
+	SummaryJSON         string          `arg:"--summary-json"          help:"When the run ends, whether it completed, was cancelled or failed, write its final status to this file as JSON: counts, bytes, timing, bottleneck, peak workers and every file that failed"`                            //nolint:tagalign
+	Reconcile           bool            `arg:"--reconcile"             help:"After a complete sync, scan both trees again and report source files missing or a different size at the destination"`                                                                                                  //nolint:tagalign
+	ReconcileHashes     bool            `arg:"--reconcile-hashes"      help:"With --reconcile, also hash files whose sizes match but whose modtimes differ"`                                                                                                                                        //nolint:tagalign
+	InPlace             bool            `arg:"--in-place"              help:"Write copies straight to their destination files instead of to temp files renamed into place when complete"`                                                                                                           //nolint:tagalign
+	MaxRetries          int             `arg:"--max-retries"           help:"Retry a failed copy up to this many times, with exponential backoff, before recording it as failed (0 = don't retry)"`                                                                                                 //nolint:tagalign
+	RetryDelay          time.Duration   `arg:"--retry-delay"           help:"With --max-retries, wait before the first retry, doubling for each retry after it, e.g. 500ms (default: 1s)"`                                                                                                          //nolint:tagalign
+	NoDelete            bool            `arg:"--no-delete"             help:"Only add and update files: keep destination files and directories that aren't in the source instead of deleting them"`                                                                                                 //nolint:tagalign
+	TrashDir            string          `arg:"--trash-dir"             help:"Move files that aren't in the source into a timestamped folder under this destination-side directory instead of deleting them"`                                                                                        //nolint:tagalign
+	ConfirmDeletesOver  int             `arg:"--confirm-deletes-over"  help:"Stop for confirmation before deleting more than this many destination files that aren't in the source; --yes confirms (0 = no limit)"`                                                                                 //nolint:tagalign
+	NoCache             bool            `arg:"--no-cache"              help:"Hash every source file the comparison needs instead of reusing hashes cached by earlier runs for files whose size and modtime are unchanged"`                                                                          //nolint:tagalign
+	MinWorkers          int             `arg:"--min-workers"           help:"Fewest workers adaptive mode starts with and scales down to (0 = 1)"`                                                                                                                                                  //nolint:tagalign
+	MaxWorkers          int             `arg:"--max-workers"           help:"Most workers adaptive mode scales up to, e.g. to limit contention on a share (0 = one per CPU, at least 4)"`                                                                                                           //nolint:tagalign
+	Sparse              bool            `arg:"--sparse"                help:"Leave runs of zeros in copied files as holes in the destination, for disk images and other sparse files"`                                                                                                              //nolint:tagalign
+	CopyBufferSize      ByteSize        `arg:"--copy-buffer-size"      help:"Bytes each copy reads and writes at a time, e.g. 1MB; buffers are reused across files (0 = 64KB)"`                                                                                                                     //nolint:tagalign
+
+	// The source, or every --source joined for display; PostProcessConfig sets it from Sources
+	SourcePath string `arg:"-"`
+
+	// Where SFTP passwords are looked up after the environment, set by main for --keychain
+	// (nil = no keychain)
+	PasswordKeychain filesystem.Keychain `arg:"-"`
+}
+
+// DeadlineFrom returns when a run starting at start must stop starting new files: the earlier of
+// --deadline and start plus --max-duration, or the zero time if neither is set.
+func (cfg Config) DeadlineFrom(start time.Time) time.Time {
+	var deadline time.Time
+
+	if cfg.Deadline.Set {
+		deadline = cfg.Deadline.Next(start)
+	}
+
+	if cfg.MaxDuration > 0 {
+		byDuration := start.Add(cfg.MaxDuration)
+		if deadline.IsZero() || byDuration.Before(deadline) {
+			deadline = byDuration
+		}
+	}
+
+	return deadline
 }
 
 // Description returns the program description for go-arg
@@ -96,35 +652,74 @@ func (Config) Description() string {
 	return "A fast file synchronization CLI tool with a rich Terminal UI"
 }
 
+// SourceDirs returns the sources to sync into their own folders when --source was given more
+// than once, or nil for a single source synced into the destination itself.
+func (cfg Config) SourceDirs() []SourceDir {
+	if len(cfg.Sources) < 2 { //nolint:mnd // More than one source
+		return nil
+	}
+
+	dirs := make([]SourceDir, 0, len(cfg.Sources))
+	for _, source := range cfg.Sources {
+		dirs = append(dirs, ParseSourceDir(source))
+	}
+
+	return dirs
+}
+
+// SourcePaths returns every source's path: each --source's, or SourcePath for a single source.
+func (cfg Config) SourcePaths() []string {
+	dirs := cfg.SourceDirs()
+	if dirs == nil {
+		return []string{cfg.SourcePath}
+	}
+
+	paths := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		paths = append(paths, dir.Path)
+	}
+
+	return paths
+}
+
 // ValidatePaths validates that source and destination paths are valid.
-// Supports both local paths and SFTP URLs (sftp://user@host:port/path).
-// For SFTP URLs, basic URL parsing is validated, but remote existence cannot be
-// checked until connection time.
+// Supports local paths, SFTP URLs (sftp://user@host:port/path) and object store URLs
+// (s3://bucket/prefix, gs://bucket/prefix). For URLs, basic URL parsing is validated, but
+// remote existence cannot be checked until connection time.
 func (cfg Config) ValidatePaths() error {
 	// Check source path is provided
 	if cfg.SourcePath == "" {
 		return ErrSourcePathRequired
 	}
 
-	// Check destination path is provided
-	if cfg.DestPath == "" {
+	// A scan-only run has nowhere to put its scan but the index
+	if cfg.ScanOnly && cfg.SourceIndex == "" {
+		return ErrSourceIndexRequired
+	}
+
+	// Check destination path is provided; a scan-only run doesn't need one
+	if cfg.DestPath == "" && !cfg.ScanOnly {
 		return ErrDestPathRequired
 	}
 
-	// Check if source is an SFTP URL
-	if strings.HasPrefix(cfg.SourcePath, "sftp://") {
-		// Validate SFTP URL format
-		if err := validateSFTPURL(cfg.SourcePath); err != nil { //nolint:noinlineerr,lll // Inline validation is idiomatic for config checks
-			return fmt.Errorf("invalid source SFTP URL: %w", err)
-		}
-		// Cannot validate remote paths until connection - will be validated during engine init
-	} else {
-		// Validate local source path
-		if err := validateLocalPath(cfg.SourcePath, "source"); err != nil { //nolint:noinlineerr,lll // Inline validation is idiomatic for config checks
+	for _, sourcePath := range cfg.SourcePaths() {
+		err := validateSourcePath(sourcePath)
+		if err != nil {
 			return err
 		}
 	}
 
+	// Several sources sync into one destination only if each has its own folder there
+	seen := make(map[string]bool)
+
+	for _, dir := range cfg.SourceDirs() {
+		if seen[dir.Name] {
+			return fmt.Errorf("%w: %s (name them with NAME=PATH)", ErrDuplicateSourceName, dir.Name)
+		}
+
+		seen[dir.Name] = true
+	}
+
 	// Check if destination is an SFTP URL
 	if strings.HasPrefix(cfg.DestPath, "sftp://") {
 		// Validate SFTP URL format
@@ -132,13 +727,44 @@ func (cfg Config) ValidatePaths() error {
 			return fmt.Errorf("invalid destination SFTP URL: %w", err)
 		}
 		// Cannot validate remote paths until connection - will be validated during engine init
-	} else {
-		// Validate local destination path
+	} else if filesystem.IsObjectStoreURL(cfg.DestPath) {
+		if _, err := filesystem.ParseObjectStoreURL(cfg.DestPath); err != nil { //nolint:noinlineerr,lll // Inline validation is idiomatic for config checks
+			return fmt.Errorf("invalid destination URL: %w", err)
+		}
+	} else if cfg.DestPath != "" {
+		// Validate local destination path (scan-only runs have none)
 		if err := validateLocalPath(cfg.DestPath, "destination"); err != nil { //nolint:noinlineerr,lll // Inline validation is idiomatic for config checks
 			return err
 		}
 	}
 
+	// Syncing a directory into itself scans its own output, and deletes would remove its input
+	if cfg.DestPath != "" {
+		for _, sourcePath := range cfg.SourcePaths() {
+			err := CheckPathOverlap(sourcePath, cfg.DestPath)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// The script runs cp and rm on this machine, from one source
+	if cfg.GenScript != "" && (isRemotePath(cfg.SourcePath) || isRemotePath(cfg.DestPath)) {
+		return ErrGenScriptRemote
+	}
+
+	if cfg.GenScript != "" && len(cfg.SourceDirs()) > 0 {
+		return ErrGenScriptMultiSource
+	}
+
+	// The baseline is read directly, so it must be a local directory
+	if cfg.BaselineDir != "" {
+		info, err := os.Stat(cfg.BaselineDir)
+		if err != nil || !info.IsDir() {
+			return fmt.Errorf("%w: %s", ErrBaselineNotDirectory, cfg.BaselineDir)
+		}
+	}
+
 	return nil
 }
 
@@ -147,6 +773,145 @@ func (Config) Version() string {
 	return "copy-files 1.0.0"
 }
 
+// Warnings returns problems with the configuration that don't prevent a run, such as
+// passwords embedded in SFTP URLs, which are ignored in favor of stored credentials.
+func (cfg Config) Warnings() []string {
+	var warnings []string
+
+	for _, path := range append(cfg.SourcePaths(), cfg.DestPath) {
+		if !strings.HasPrefix(path, "sftp://") {
+			continue
+		}
+
+		u, err := url.Parse(path) //nolint:varnamelen // u is idiomatic for URL
+		if err != nil || u.User == nil {
+			continue
+		}
+
+		if _, hasPassword := u.User.Password(); hasPassword {
+			warnings = append(warnings, fmt.Sprintf(
+				"the URL for %s includes a password, which is ignored; "+
+					"set GLOWSYNC_<HOST>_PASSWORD or use ~/.config/glowsync/credentials instead", u.Hostname()))
+		}
+	}
+
+	if cfg.WebhookSecret != "" && cfg.Webhook == "" {
+		warnings = append(warnings, "--webhook-secret has no effect without --webhook")
+	}
+
+	if cfg.ReconcileHashes && !cfg.Reconcile {
+		warnings = append(warnings, "--reconcile-hashes has no effect without --reconcile")
+	}
+
+	if cfg.RetryDelay > 0 && cfg.MaxRetries == 0 {
+		warnings = append(warnings, "--retry-delay has no effect without --max-retries")
+	}
+
+	if cfg.TrashDir != "" && cfg.NoDelete {
+		warnings = append(warnings, "--trash-dir has no effect with --no-delete")
+	}
+
+	if cfg.ConfirmDeletesOver > 0 && cfg.NoDelete {
+		warnings = append(warnings, "--confirm-deletes-over has no effect with --no-delete")
+	}
+
+	if cfg.CAStore && cfg.ChecksumAlgo != "" && cfg.ChecksumAlgo != HashSHA256 {
+		warnings = append(warnings, "--checksum-algo has no effect with --ca-store, which names content by SHA-256")
+	}
+
+	if cfg.VerifyResumed && !cfg.ResumeManifest {
+		warnings = append(warnings, "--verify-resumed has no effect without --resume-manifest")
+	}
+
+	if cfg.OnCollision != CollisionRename && !cfg.Flatten {
+		warnings = append(warnings, "--on-collision has no effect without --flatten")
+	}
+
+	if cfg.Flatten && cfg.CAStore {
+		warnings = append(warnings, "--flatten has no effect with --ca-store, which names content by hash")
+	}
+
+	if cfg.Move && cfg.CAStore {
+		warnings = append(warnings, "--move has no effect with --ca-store, which is always copied into")
+	}
+
+	if cfg.Preallocate && cfg.Sparse {
+		warnings = append(warnings, "--preallocate has no effect with --sparse")
+	}
+
+	if (cfg.MinWorkers > 0 || cfg.MaxWorkers > 0) && !cfg.AdaptiveMode {
+		warnings = append(warnings, "--min-workers and --max-workers have no effect with --adaptive=false")
+	}
+
+	return warnings
+}
+
+// CheckPathOverlap returns an error if source and dest are the same directory or one is inside
+// the other, where a sync would copy into its own source or delete it as orphans. Local paths
+// are compared after making them absolute and resolving symbolic links, so different spellings
+// of one directory match; SFTP and object store paths are compared by name on the same host or
+// bucket.
+func CheckPathOverlap(source, dest string) error {
+	srcScope, srcPath, srcOK := pathLocation(source)
+	dstScope, dstPath, dstOK := pathLocation(dest)
+
+	if !srcOK || !dstOK || srcScope != dstScope {
+		return nil
+	}
+
+	local := srcScope == ""
+
+	switch {
+	case ancestorDepth(dstPath, srcPath, local) == 0:
+		return fmt.Errorf("%w: %s and %s", ErrSameSourceAndDest, source, dest)
+	case ancestorDepth(dstPath, srcPath, local) > 0:
+		return fmt.Errorf("%w: %s is inside %s", ErrDestInsideSource, dest, source)
+	case ancestorDepth(srcPath, dstPath, local) > 0:
+		return fmt.Errorf("%w: %s is inside %s", ErrSourceInsideDest, source, dest)
+	}
+
+	return nil
+}
+
+// ParseByteRate parses a byte rate such as 500KB, 10MB, 1.5GB or a plain number of bytes.
+// Units are powers of 1024, matching how sizes are displayed, and a trailing "/s" is allowed.
+func ParseByteRate(rateStr string) (ByteRate, error) {
+	value, ok := parseBytes(strings.TrimSuffix(strings.ToUpper(strings.TrimSpace(rateStr)), "/S"))
+	if !ok {
+		return 0, fmt.Errorf("%w: %s (e.g. 500KB, 10MB, 1.5GB)", ErrInvalidByteRate, rateStr)
+	}
+
+	return ByteRate(value), nil
+}
+
+// ParseByteSize parses a size such as 500KB, 100MB, 1.5GB or a plain number of bytes.
+// Units are powers of 1024, matching how sizes are displayed.
+func ParseByteSize(sizeStr string) (ByteSize, error) {
+	value, ok := parseBytes(strings.ToUpper(strings.TrimSpace(sizeStr)))
+	if !ok {
+		return 0, fmt.Errorf("%w: %s (e.g. 500KB, 100MB, 1.5GB)", ErrInvalidByteSize, sizeStr)
+	}
+
+	return ByteSize(value), nil
+}
+
+// ParseCaseConflict parses a string into a CaseConflict
+func ParseCaseConflict(policyStr string) (CaseConflict, error) {
+	switch strings.ToLower(policyStr) {
+	case "", "off":
+		return CaseConflictOff, nil
+	case "error":
+		return CaseConflictError, nil
+	case "skip":
+		return CaseConflictSkip, nil
+	case "first-by-sort", "first":
+		return CaseConflictFirstBySort, nil
+	default:
+		return CaseConflictOff, fmt.Errorf("%w: %s (valid: off, error, skip, first-by-sort)",
+			ErrInvalidCaseConflict, policyStr)
+	}
+}
+
 // ParseChangeType parses a string into a ChangeType
 func ParseChangeType(changeTypeStr string) (ChangeType, error) {
 	changeTypeStr = strings.ToLower(changeTypeStr)
@@ -161,19 +926,213 @@ func ParseChangeType(changeTypeStr string) (ChangeType, error) {
 		return DeviousContent, nil
 	case "paranoid-does-not-mean-wrong", "paranoid":
 		return Paranoid, nil
+	case "quick-content", "quick":
+		return QuickContent, nil
+	case "modtime-newer", "modtime":
+		return ModTimeNewer, nil
 	default:
 		return MonotonicCount, fmt.Errorf(
-			"%w: %s (valid: monotonic, fluctuating, content, devious, paranoid)",
+			"%w: %s (valid: monotonic, fluctuating, content, devious, paranoid, quick, modtime)",
 			ErrInvalidChangeType, changeTypeStr)
 	}
 }
 
+// ParseCollisionPolicy parses a string into a CollisionPolicy
+func ParseCollisionPolicy(policyStr string) (CollisionPolicy, error) {
+	switch strings.ToLower(policyStr) {
+	case "", "rename":
+		return CollisionRename, nil
+	case "skip":
+		return CollisionSkip, nil
+	case "overwrite-newest", "newest":
+		return CollisionOverwriteNewest, nil
+	default:
+		return CollisionRename, fmt.Errorf("%w: %s (valid: rename, skip, overwrite-newest)",
+			ErrInvalidCollisionPolicy, policyStr)
+	}
+}
+
+// ParseDestFSType parses a string into a DestFSType
+func ParseDestFSType(fsTypeStr string) (DestFSType, error) {
+	switch strings.ToLower(fsTypeStr) {
+	case "", "auto":
+		return DestFSAuto, nil
+	case "ext4":
+		return DestFSExt4, nil
+	case "ntfs":
+		return DestFSNTFS, nil
+	case "vfat", "fat", "fat32", "exfat":
+		return DestFSVFAT, nil
+	default:
+		return DestFSAuto, fmt.Errorf("%w: %s (valid: vfat, ntfs, ext4)", ErrInvalidDestFSType, fsTypeStr)
+	}
+}
+
+// ParseErrorCategories parses a comma-separated list of error category names
+func ParseErrorCategories(categoriesStr string) (ErrorCategories, error) {
+	var categories ErrorCategories
+
+	for _, name := range strings.Split(categoriesStr, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+			continue
+		case "permission":
+			categories = append(categories, fileops.CategoryPermission)
+		case "locked":
+			categories = append(categories, fileops.CategoryLocked)
+		case "vanished":
+			categories = append(categories, fileops.CategorySourceVanished)
+		case "full":
+			categories = append(categories, fileops.CategoryDestFull)
+		case "io":
+			categories = append(categories, fileops.CategoryIO)
+		case "unknown":
+			categories = append(categories, fileops.CategoryUnknown)
+		default:
+			return nil, fmt.Errorf(
+				"%w: %s (valid: permission, locked, vanished, full, io, unknown)", ErrInvalidErrorCategory, name)
+		}
+	}
+
+	return categories, nil
+}
+
+// ParseHashAlgorithm parses a string into a HashAlgorithm
+func ParseHashAlgorithm(algorithmStr string) (HashAlgorithm, error) {
+	switch strings.ToLower(algorithmStr) {
+	case "", "sha256", "sha-256":
+		return HashSHA256, nil
+	case "blake3":
+		return HashBLAKE3, nil
+	case "xxhash", "xxh64", "xxhash64":
+		return HashXXHash, nil
+	default:
+		return HashSHA256, fmt.Errorf("%w: %s (valid: sha256, blake3, xxhash)", ErrInvalidHashAlgorithm, algorithmStr)
+	}
+}
+
+// ParseLogFormat parses a string into a LogFormat
+func ParseLogFormat(formatStr string) (LogFormat, error) {
+	switch strings.ToLower(formatStr) {
+	case "", "text":
+		return LogFormatText, nil
+	case "json":
+		return LogFormatJSON, nil
+	default:
+		return LogFormatText, fmt.Errorf("%w: %s (valid: text, json)", ErrInvalidLogFormat, formatStr)
+	}
+}
+
+// ParseLogLevel parses a string into a LogLevel
+func ParseLogLevel(levelStr string) (LogLevel, error) {
+	switch strings.ToLower(levelStr) {
+	case "", "info":
+		return LogLevelInfo, nil
+	case "debug":
+		return LogLevelDebug, nil
+	case "warn", "warning":
+		return LogLevelWarn, nil
+	default:
+		return LogLevelInfo, fmt.Errorf("%w: %s (valid: debug, info, warn)", ErrInvalidLogLevel, levelStr)
+	}
+}
+
+// ParseMissingXattr parses a string into a MissingXattr
+func ParseMissingXattr(policyStr string) (MissingXattr, error) {
+	switch strings.ToLower(policyStr) {
+	case "", "sync":
+		return MissingXattrSync, nil
+	case "skip":
+		return MissingXattrSkip, nil
+	default:
+		return MissingXattrSync, fmt.Errorf("%w: %s (valid: sync, skip)", ErrInvalidMissingXattr, policyStr)
+	}
+}
+
+// ParseProcessOrder parses a string into a ProcessOrder
+func ParseProcessOrder(orderStr string) (ProcessOrder, error) {
+	switch strings.ToLower(orderStr) {
+	case "", "default":
+		return OrderDefault, nil
+	case "newest-first", "newest":
+		return OrderNewestFirst, nil
+	default:
+		return OrderDefault, fmt.Errorf("%w: %s (valid: default, newest-first)", ErrInvalidProcessOrder, orderStr)
+	}
+}
+
+// ParseReflinkMode parses a string into a ReflinkMode
+func ParseReflinkMode(modeStr string) (ReflinkMode, error) {
+	switch strings.ToLower(modeStr) {
+	case "", "never":
+		return ReflinkNever, nil
+	case "auto":
+		return ReflinkAuto, nil
+	case "always":
+		return ReflinkAlways, nil
+	default:
+		return ReflinkNever, fmt.Errorf("%w: %s (valid: auto, always, never)", ErrInvalidReflinkMode, modeStr)
+	}
+}
+
+// ParseSourceDir parses a --source given with others: NAME=PATH syncs PATH into the folder NAME,
+// and a bare PATH into a folder named after its last element.
+func ParseSourceDir(sourceStr string) SourceDir {
+	name, sourcePath, found := strings.Cut(sourceStr, "=")
+	if found && name != "" && !strings.ContainsAny(name, `/\:`) {
+		return SourceDir{Name: name, Path: sourcePath}
+	}
+
+	return SourceDir{Name: filepath.Base(strings.TrimRight(sourceStr, `/\`)), Path: sourceStr}
+}
+
+// ParseSymlinkMode parses a string into a SymlinkMode
+func ParseSymlinkMode(modeStr string) (SymlinkMode, error) {
+	switch strings.ToLower(modeStr) {
+	case "", "follow":
+		return SymlinkFollow, nil
+	case "preserve":
+		return SymlinkPreserve, nil
+	case "skip":
+		return SymlinkSkip, nil
+	default:
+		return SymlinkFollow, fmt.Errorf("%w: %s (valid: follow, preserve, skip)", ErrInvalidSymlinkMode, modeStr)
+	}
+}
+
+// ParseTimeBound parses a date (2024-01-31, local time), a date and time (2024-01-31 15:04,
+// or RFC 3339 with a zone), or a duration before now: a number with an s, m, h, d (days) or
+// w (weeks) unit, or any Go duration such as 1h30m.
+func ParseTimeBound(boundStr string, now time.Time) (time.Time, error) {
+	str := strings.TrimSpace(boundStr)
+
+	if ago, ok := parseAgo(str); ok {
+		return now.Add(-ago), nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, str)
+	if err == nil {
+		return parsed, nil
+	}
+
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02T15:04:05"} {
+		parsed, err = time.ParseInLocation(layout, str, now.Location())
+		if err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %s (e.g. 7d, 12h, 2024-01-31)", ErrInvalidTimeBound, boundStr)
+}
+
 // ParseFlags parses command-line flags and returns configuration
 func ParseFlags() (*Config, error) {
 	cfg := &Config{
-		AdaptiveMode: true,
-		Workers:      DefaultMaxWorkers,
-		TypeOfChange: MonotonicCount,
+		AdaptiveMode:     true,
+		Workers:          DefaultMaxWorkers,
+		TypeOfChange:     MonotonicCount,
+		ModTimeSlack:     DefaultModTimeSlack,
+		ProgressInterval: DefaultProgressInterval,
 	}
 
 	arg.MustParse(cfg)
@@ -183,14 +1142,53 @@ func ParseFlags() (*Config, error) {
 
 // PostProcessConfig applies post-processing logic to a parsed config
 func PostProcessConfig(cfg *Config) (*Config, error) {
+	if cfg.SourcePath == "" {
+		cfg.SourcePath = strings.Join(cfg.Sources, ", ")
+	}
+
 	// If no flags provided, default to interactive mode
 	if cfg.SourcePath == "" && cfg.DestPath == "" {
 		cfg.InteractiveMode = true
 	}
 
-	// Validate paths if not in interactive mode
-	if !cfg.InteractiveMode {
-		err := cfg.ValidatePaths()
+	err := resolveOwner(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateWebhookURL(cfg.Webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxWorkers > 0 && cfg.MinWorkers > cfg.MaxWorkers {
+		return nil, fmt.Errorf("%w: %d > %d", ErrInvalidWorkerBounds, cfg.MinWorkers, cfg.MaxWorkers)
+	}
+
+	if cfg.MaxSize > 0 && cfg.MinSize > cfg.MaxSize {
+		return nil, fmt.Errorf("%w: %d > %d bytes", ErrInvalidSizeRange, cfg.MinSize, cfg.MaxSize)
+	}
+
+	if !cfg.NewerThan.Time.IsZero() && !cfg.OlderThan.Time.IsZero() && !cfg.NewerThan.Time.Before(cfg.OlderThan.Time) {
+		return nil, fmt.Errorf("%w: %s is not before %s", ErrInvalidModTimeWindow,
+			cfg.NewerThan.Time.Format(time.RFC3339), cfg.OlderThan.Time.Format(time.RFC3339))
+	}
+
+	if cfg.Move && cfg.SnapshotSource {
+		return nil, ErrMoveFromSnapshot
+	}
+
+	for _, pattern := range cfg.Exclude {
+		err = ValidateFilePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate paths if not in interactive mode; --assert-synced, --scan-only, --gen-script and
+	// --tree-digest have no TUI to ask for them
+	if !cfg.InteractiveMode || cfg.AssertSynced || cfg.ScanOnly || cfg.GenScript != "" || cfg.TreeDigest {
+		err = cfg.ValidatePaths()
 		if err != nil {
 			return nil, err
 		}
@@ -218,6 +1216,49 @@ func ValidateFilePattern(pattern string) error {
 	return nil
 }
 
+// resolveOwner looks up --owner's user ID so the filter only needs numeric IDs.
+func resolveOwner(cfg *Config) error {
+	if cfg.Owner == "" {
+		return nil
+	}
+
+	if cfg.OwnerUID != nil {
+		return fmt.Errorf("%w: --owner and --owner-uid both select a user", ErrInvalidOwner)
+	}
+
+	owner, err := user.Lookup(cfg.Owner)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidOwner, err)
+	}
+
+	uid, err := strconv.Atoi(owner.Uid)
+	if err != nil {
+		return fmt.Errorf("%w: %s has no numeric user ID (%s)", ErrInvalidOwner, cfg.Owner, owner.Uid)
+	}
+
+	cfg.OwnerUID = &uid
+
+	return nil
+}
+
+// validateSourcePath validates a source's SFTP or object store URL, or that its local path is a
+// directory. Remote paths can't be checked until the engine connects.
+func validateSourcePath(sourcePath string) error {
+	if strings.HasPrefix(sourcePath, "sftp://") {
+		if err := validateSFTPURL(sourcePath); err != nil { //nolint:noinlineerr,lll // Inline validation is idiomatic for config checks
+			return fmt.Errorf("invalid source SFTP URL: %w", err)
+		}
+	} else if filesystem.IsObjectStoreURL(sourcePath) {
+		if _, err := filesystem.ParseObjectStoreURL(sourcePath); err != nil { //nolint:noinlineerr,lll // Inline validation is idiomatic for config checks
+			return fmt.Errorf("invalid source URL: %w", err)
+		}
+	} else {
+		return validateLocalPath(sourcePath, "source")
+	}
+
+	return nil
+}
+
 // validateLocalPath validates that a local path exists and is a directory
 func validateLocalPath(path, pathType string) error {
 	info, err := os.Stat(path)
@@ -244,6 +1285,93 @@ func validateLocalPath(path, pathType string) error {
 	return nil
 }
 
+// pathLocation splits a source or destination into the host or bucket it's on ("" for local)
+// and its path there, resolved for local paths. ok is false for a path that doesn't parse.
+func pathLocation(location string) (scope, resolved string, ok bool) {
+	if filesystem.IsObjectStoreURL(location) {
+		parsed, err := filesystem.ParseObjectStoreURL(location)
+		if err != nil {
+			return "", "", false
+		}
+
+		return parsed.Scheme + "://" + parsed.Bucket, parsed.Prefix, true
+	}
+
+	parsed, err := filesystem.ParsePath(location)
+	if err != nil {
+		return "", "", false
+	}
+
+	// Relative SFTP paths start from the user's home directory, so the user is part of the scope
+	if parsed.IsRemote {
+		return fmt.Sprintf("sftp://%s@%s:%d", parsed.User, parsed.Host, parsed.Port), path.Clean(parsed.Path), true
+	}
+
+	return "", resolveLocalPath(parsed.LocalPath), true
+}
+
+// resolveLocalPath makes localPath absolute and resolves symbolic links in as much of it as
+// exists, so a destination that hasn't been created yet still resolves through its parents.
+func resolveLocalPath(localPath string) string {
+	abs, err := filepath.Abs(localPath)
+	if err != nil {
+		return filepath.Clean(localPath)
+	}
+
+	missing := ""
+
+	for dir := abs; ; dir = filepath.Dir(dir) {
+		resolved, err := filepath.EvalSymlinks(dir)
+		if err == nil {
+			return filepath.Join(resolved, missing)
+		}
+
+		if filepath.Dir(dir) == dir {
+			return abs
+		}
+
+		missing = filepath.Join(filepath.Base(dir), missing)
+	}
+}
+
+// ancestorDepth returns how many levels above child ancestor is: 0 when they're the same
+// directory, or -1 when ancestor isn't child or above it. Local directories also match when
+// they're the same file, as differently cased names are on case-insensitive filesystems.
+func ancestorDepth(child, ancestor string, local bool) int {
+	parent := path.Dir
+
+	var ancestorInfo os.FileInfo
+
+	if local {
+		parent = filepath.Dir
+		ancestorInfo, _ = os.Stat(ancestor)
+	}
+
+	for depth, dir := 0, child; ; depth++ {
+		if dir == ancestor {
+			return depth
+		}
+
+		if ancestorInfo != nil {
+			info, err := os.Stat(dir)
+			if err == nil && os.SameFile(info, ancestorInfo) {
+				return depth
+			}
+		}
+
+		if parent(dir) == dir {
+			return -1
+		}
+
+		dir = parent(dir)
+	}
+}
+
+// isRemotePath reports whether path is an SFTP or object store URL rather than a local path.
+func isRemotePath(path string) bool {
+	return strings.HasPrefix(path, "sftp://") || filesystem.IsObjectStoreURL(path)
+}
+
 // validateSFTPURL validates basic SFTP URL format
 func validateSFTPURL(sftpURL string) error {
 	// Basic validation - just check it has required components
@@ -256,3 +1384,69 @@ func validateSFTPURL(sftpURL string) error {
 
 	return nil
 }
+
+// validateWebhookURL checks that a --webhook URL is an absolute http or https URL.
+// An empty URL (no webhook) is valid.
+func validateWebhookURL(webhookURL string) error {
+	if webhookURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(webhookURL) //nolint:varnamelen // u is idiomatic for URL
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidWebhookURL, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: %s (must be an http:// or https:// URL)", ErrInvalidWebhookURL, webhookURL)
+	}
+
+	return nil
+}
+
+// parseBytes parses an upper-case number of bytes with an optional K, M, G or T unit, and an
+// optional B or iB after it, reporting false for anything else or a negative number.
+func parseBytes(str string) (float64, bool) {
+	str = strings.TrimSuffix(strings.TrimSuffix(str, "B"), "I")
+
+	multiplier := 1.0
+
+	if str != "" {
+		if exp := strings.IndexByte("KMGT", str[len(str)-1]); exp >= 0 {
+			multiplier = math.Pow(1024, float64(exp+1)) //nolint:mnd // Binary units
+			str = str[:len(str)-1]
+		}
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(str), 64)
+	if err != nil || value < 0 || math.IsInf(value, 0) || math.IsNaN(value) {
+		return 0, false
+	}
+
+	return value * multiplier, true
+}
+
+// parseAgo parses a non-negative duration with a d (days) or w (weeks) unit, or any Go duration.
+func parseAgo(str string) (time.Duration, bool) {
+	const day = 24 * time.Hour
+
+	units := map[byte]time.Duration{'d': day, 'w': 7 * day} //nolint:mnd // Days in a week
+
+	if str != "" {
+		if unit, ok := units[str[len(str)-1]]; ok {
+			value, err := strconv.ParseFloat(str[:len(str)-1], 64)
+			if err != nil || value < 0 || math.IsInf(value, 0) {
+				return 0, false
+			}
+
+			return time.Duration(value * float64(unit)), true
+		}
+	}
+
+	ago, err := time.ParseDuration(str)
+	if err != nil || ago < 0 {
+		return 0, false
+	}
+
+	return ago, true
+}
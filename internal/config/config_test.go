@@ -2,10 +2,17 @@
 package config_test
 
 import (
+	"errors"
 	"os"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/pkg/fileops"
 	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
 )
 
@@ -21,6 +28,8 @@ func TestChangeTypeString(t *testing.T) {
 		{config.Content, "content"},
 		{config.DeviousContent, "devious-content-changes"},
 		{config.Paranoid, "paranoid-does-not-mean-wrong"},
+		{config.QuickContent, "quick-content"},
+		{config.ModTimeNewer, "modtime-newer"},
 		{config.ChangeType(999), "unknown"},
 	}
 
@@ -42,6 +51,8 @@ func TestChangeTypeUnmarshalText(t *testing.T) {
 		{"monotonic", config.MonotonicCount, false},
 		{"content", config.Content, false},
 		{"paranoid", config.Paranoid, false},
+		{"quick-content", config.QuickContent, false},
+		{"quick", config.QuickContent, false},
 		{"invalid", config.MonotonicCount, true},
 	}
 
@@ -60,6 +71,63 @@ func TestChangeTypeUnmarshalText(t *testing.T) {
 	}
 }
 
+func TestCheckPathOverlap(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	source := filepath.Join(root, "source")
+	inside := filepath.Join(source, "backup")
+	link := filepath.Join(root, "link")
+
+	if err := os.MkdirAll(inside, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink(source, link); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		source  string
+		dest    string
+		wantErr error
+	}{
+		{"same directory", source, source + "/", config.ErrSameSourceAndDest},
+		{"relative spelling", ".", wd, config.ErrSameSourceAndDest},
+		{"symlink to the source", source, link, config.ErrSameSourceAndDest},
+		{"dest inside source", source, inside, config.ErrDestInsideSource},
+		{"missing dest inside source", source, filepath.Join(inside, "new", "dir"), config.ErrDestInsideSource},
+		{"dest inside source through a symlink", link, inside, config.ErrDestInsideSource},
+		{"source inside dest", inside, source, config.ErrSourceInsideDest},
+		{"siblings", inside, filepath.Join(source, "backup-2"), nil},
+		{"same SFTP path", "sftp://joe@host/data", "sftp://joe@host/data/", config.ErrSameSourceAndDest},
+		{"SFTP dest inside source", "sftp://joe@host//srv", "sftp://joe@host//srv/copy", config.ErrDestInsideSource},
+		{"SFTP paths on different hosts", "sftp://joe@a//srv", "sftp://joe@b//srv", nil},
+		{"local and SFTP", source, "sftp://joe@host/" + source, nil},
+		{"bucket prefix inside source", "s3://bucket/photos", "s3://bucket/photos/copy", config.ErrDestInsideSource},
+		{"whole bucket", "s3://bucket/photos", "s3://bucket", config.ErrSourceInsideDest},
+		{"different buckets", "s3://a/photos", "s3://b/photos", nil},
+	}
+
+	for _, tt := range tests {
+		err := config.CheckPathOverlap(tt.source, tt.dest)
+
+		if tt.wantErr == nil && err != nil {
+			t.Errorf("%s: CheckPathOverlap() error = %v, want nil", tt.name, err)
+		}
+
+		if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: CheckPathOverlap() error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
 func TestConfigDescription(t *testing.T) {
 	t.Parallel()
 
@@ -71,6 +139,42 @@ func TestConfigDescription(t *testing.T) {
 	}
 }
 
+func TestConfigDeadlineFrom(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 5, 1, 22, 30, 0, 0, time.UTC)
+
+	var deadline config.ClockTime
+
+	err := deadline.UnmarshalText([]byte("03:00"))
+	if err != nil {
+		t.Fatalf("UnmarshalText() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		cfg      config.Config
+		expected time.Time
+	}{
+		{"neither", config.Config{}, time.Time{}},
+		{"deadline tomorrow", config.Config{Deadline: deadline}, time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC)},
+		{"max duration", config.Config{MaxDuration: time.Hour}, start.Add(time.Hour)},
+		{"earlier of both", config.Config{Deadline: deadline, MaxDuration: 8 * time.Hour}, time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got := tt.cfg.DeadlineFrom(start)
+		if !got.Equal(tt.expected) {
+			t.Errorf("%s: DeadlineFrom() = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+
+	err = deadline.UnmarshalText([]byte("25:00"))
+	if !errors.Is(err, config.ErrInvalidClockTime) {
+		t.Errorf("UnmarshalText(25:00) error = %v, want ErrInvalidClockTime", err)
+	}
+}
+
 func TestConfigVersion(t *testing.T) {
 	t.Parallel()
 
@@ -82,6 +186,70 @@ func TestConfigVersion(t *testing.T) {
 	}
 }
 
+func TestParseByteRate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected config.ByteRate
+		wantErr  bool
+	}{
+		{"0", 0, false},
+		{"4096", 4096, false},
+		{"500KB", 500 * 1024, false},
+		{"10MB", 10 * 1024 * 1024, false},
+		{"10mb/s", 10 * 1024 * 1024, false},
+		{"1.5GB", 1536 * 1024 * 1024, false},
+		{"2GiB", 2 * 1024 * 1024 * 1024, false},
+		{"", 0, true},
+		{"fast", 0, true},
+		{"-1MB", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := config.ParseByteRate(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseByteRate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("ParseByteRate(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected config.ByteSize
+		wantErr  bool
+	}{
+		{"0", 0, false},
+		{"4096", 4096, false},
+		{"100MB", 100 * 1024 * 1024, false},
+		{" 1.5gb ", 1536 * 1024 * 1024, false},
+		{"2TiB", 2 * 1024 * 1024 * 1024 * 1024, false},
+		{"10MB/s", 0, true},
+		{"big", 0, true},
+		{"-1KB", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := config.ParseByteSize(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseByteSize(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("ParseByteSize(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
 func TestParseChangeType(t *testing.T) {
 	t.Parallel()
 
@@ -101,6 +269,10 @@ func TestParseChangeType(t *testing.T) {
 		{"devious", config.DeviousContent, false},
 		{"paranoid-does-not-mean-wrong", config.Paranoid, false},
 		{"paranoid", config.Paranoid, false},
+		{"quick-content", config.QuickContent, false},
+		{"quick", config.QuickContent, false},
+		{"modtime-newer", config.ModTimeNewer, false},
+		{"modtime", config.ModTimeNewer, false},
 		{"invalid", config.MonotonicCount, true},
 		{"", config.MonotonicCount, true},
 	}
@@ -141,6 +313,442 @@ func TestParseFlags(t *testing.T) {
 	if !cfg.InteractiveMode {
 		t.Error("InteractiveMode should be true when no paths are provided")
 	}
+
+	if cfg.ProgressInterval != config.DefaultProgressInterval {
+		t.Errorf("ProgressInterval = %v, want %v", cfg.ProgressInterval, config.DefaultProgressInterval)
+	}
+
+	if cfg.ModTimeSlack != config.DefaultModTimeSlack {
+		t.Errorf("ModTimeSlack = %v, want %v", cfg.ModTimeSlack, config.DefaultModTimeSlack)
+	}
+}
+
+func TestParseErrorCategories(t *testing.T) {
+	t.Parallel()
+
+	got, err := config.ParseErrorCategories("permission, Locked,vanished")
+	if err != nil {
+		t.Fatalf("ParseErrorCategories() error = %v", err)
+	}
+
+	expected := config.ErrorCategories{fileops.CategoryPermission, fileops.CategoryLocked, fileops.CategorySourceVanished}
+	if !slices.Equal(got, expected) {
+		t.Errorf("ParseErrorCategories() = %v, want %v", got, expected)
+	}
+
+	_, err = config.ParseErrorCategories("permission,flaky")
+	if !errors.Is(err, config.ErrInvalidErrorCategory) {
+		t.Errorf("ParseErrorCategories(\"permission,flaky\") error = %v, want ErrInvalidErrorCategory", err)
+	}
+}
+
+func TestParseProcessOrder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected config.ProcessOrder
+		wantErr  bool
+	}{
+		{"", config.OrderDefault, false},
+		{"default", config.OrderDefault, false},
+		{"newest-first", config.OrderNewestFirst, false},
+		{"Newest", config.OrderNewestFirst, false},
+		{"oldest-first", config.OrderDefault, true},
+	}
+
+	for _, tt := range tests {
+		got, err := config.ParseProcessOrder(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseProcessOrder(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("ParseProcessOrder(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseReflinkMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected config.ReflinkMode
+		wantErr  bool
+	}{
+		{"", config.ReflinkNever, false},
+		{"never", config.ReflinkNever, false},
+		{"auto", config.ReflinkAuto, false},
+		{"ALWAYS", config.ReflinkAlways, false},
+		{"sometimes", config.ReflinkNever, true},
+	}
+
+	for _, tt := range tests {
+		got, err := config.ParseReflinkMode(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseReflinkMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("ParseReflinkMode(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseHashAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected config.HashAlgorithm
+		wantErr  bool
+	}{
+		{"", config.HashSHA256, false},
+		{"sha256", config.HashSHA256, false},
+		{"BLAKE3", config.HashBLAKE3, false},
+		{"xxhash", config.HashXXHash, false},
+		{"xxh64", config.HashXXHash, false},
+		{"md5", config.HashSHA256, true},
+	}
+
+	for _, tt := range tests {
+		got, err := config.ParseHashAlgorithm(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseHashAlgorithm(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("ParseHashAlgorithm(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseCaseConflict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected config.CaseConflict
+		wantErr  bool
+	}{
+		{"", config.CaseConflictOff, false},
+		{"off", config.CaseConflictOff, false},
+		{"ERROR", config.CaseConflictError, false},
+		{"skip", config.CaseConflictSkip, false},
+		{"first-by-sort", config.CaseConflictFirstBySort, false},
+		{"first", config.CaseConflictFirstBySort, false},
+		{"last", config.CaseConflictOff, true},
+	}
+
+	for _, tt := range tests {
+		got, err := config.ParseCaseConflict(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCaseConflict(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("ParseCaseConflict(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseCollisionPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected config.CollisionPolicy
+		wantErr  bool
+	}{
+		{"", config.CollisionRename, false},
+		{"rename", config.CollisionRename, false},
+		{"SKIP", config.CollisionSkip, false},
+		{"overwrite-newest", config.CollisionOverwriteNewest, false},
+		{"newest", config.CollisionOverwriteNewest, false},
+		{"overwrite", config.CollisionRename, true},
+	}
+
+	for _, tt := range tests {
+		got, err := config.ParseCollisionPolicy(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCollisionPolicy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("ParseCollisionPolicy(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseLogFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected config.LogFormat
+		wantErr  bool
+	}{
+		{"", config.LogFormatText, false},
+		{"text", config.LogFormatText, false},
+		{"JSON", config.LogFormatJSON, false},
+		{"logfmt", config.LogFormatText, true},
+	}
+
+	for _, tt := range tests {
+		got, err := config.ParseLogFormat(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLogFormat(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("ParseLogFormat(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected config.LogLevel
+		wantErr  bool
+	}{
+		{"", config.LogLevelInfo, false},
+		{"info", config.LogLevelInfo, false},
+		{"DEBUG", config.LogLevelDebug, false},
+		{"warn", config.LogLevelWarn, false},
+		{"warning", config.LogLevelWarn, false},
+		{"error", config.LogLevelInfo, true},
+	}
+
+	for _, tt := range tests {
+		got, err := config.ParseLogLevel(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLogLevel(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("ParseLogLevel(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseTimeBound(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input    string
+		expected time.Time
+		wantErr  bool
+	}{
+		{"7d", now.AddDate(0, 0, -7), false},
+		{"2w", now.AddDate(0, 0, -14), false},
+		{"12h", now.Add(-12 * time.Hour), false},
+		{"1h30m", now.Add(-90 * time.Minute), false},
+		{"2024-01-31", time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), false},
+		{"2024-01-31 15:04", time.Date(2024, 1, 31, 15, 4, 0, 0, time.UTC), false},
+		{"2024-01-31T15:04:05+01:00", time.Date(2024, 1, 31, 14, 4, 5, 0, time.UTC), false},
+		{"-7d", time.Time{}, true},
+		{"2024", time.Time{}, true},
+		{"last week", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := config.ParseTimeBound(tt.input, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTimeBound(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+
+		if !tt.wantErr && !got.Equal(tt.expected) {
+			t.Errorf("ParseTimeBound(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseMissingXattr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected config.MissingXattr
+		wantErr  bool
+	}{
+		{"", config.MissingXattrSync, false},
+		{"sync", config.MissingXattrSync, false},
+		{"SKIP", config.MissingXattrSkip, false},
+		{"ignore", config.MissingXattrSync, true},
+	}
+
+	for _, tt := range tests {
+		got, err := config.ParseMissingXattr(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMissingXattr(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("ParseMissingXattr(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseSymlinkMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected config.SymlinkMode
+		wantErr  bool
+	}{
+		{"", config.SymlinkFollow, false},
+		{"follow", config.SymlinkFollow, false},
+		{"PRESERVE", config.SymlinkPreserve, false},
+		{"skip", config.SymlinkSkip, false},
+		{"copy", config.SymlinkFollow, true},
+	}
+
+	for _, tt := range tests {
+		got, err := config.ParseSymlinkMode(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSymlinkMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("ParseSymlinkMode(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseSourceDir(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected config.SourceDir
+	}{
+		{"/home/me/photos", config.SourceDir{Name: "photos", Path: "/home/me/photos"}},
+		{"/home/me/photos/", config.SourceDir{Name: "photos", Path: "/home/me/photos/"}},
+		{"pics=/home/me/photos", config.SourceDir{Name: "pics", Path: "/home/me/photos"}},
+		{"sftp://me@host/srv/docs", config.SourceDir{Name: "docs", Path: "sftp://me@host/srv/docs"}},
+		{"/data/a=b", config.SourceDir{Name: "a=b", Path: "/data/a=b"}},
+	}
+
+	for _, tt := range tests {
+		got := config.ParseSourceDir(tt.input)
+		if got != tt.expected {
+			t.Errorf("ParseSourceDir(%q) = %+v, want %+v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestOwnerFilter_Matches(t *testing.T) {
+	t.Parallel()
+
+	uid, gid := 1000, 100
+
+	tests := []struct {
+		name   string
+		filter config.OwnerFilter
+		uid    int
+		gid    int
+		known  bool
+		want   bool
+	}{
+		{name: "inactive filter matches anything", filter: config.OwnerFilter{}, known: false, want: true},
+		{name: "matching uid", filter: config.OwnerFilter{UID: &uid}, uid: 1000, gid: 5, known: true, want: true},
+		{name: "other uid", filter: config.OwnerFilter{UID: &uid}, uid: 0, known: true, want: false},
+		{name: "uid and gid must both match", filter: config.OwnerFilter{UID: &uid, GID: &gid}, uid: 1000, gid: 5, known: true, want: false},
+		{name: "unknown owner never matches", filter: config.OwnerFilter{UID: &uid}, uid: 1000, known: false, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.filter.Matches(tt.uid, tt.gid, tt.known)
+			if got != tt.want {
+				t.Errorf("Matches(%d, %d, %v) = %v, want %v", tt.uid, tt.gid, tt.known, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostProcessConfig_ResolvesOwner(t *testing.T) {
+	t.Parallel()
+
+	current, err := user.Current()
+	if err != nil {
+		t.Skipf("no current user: %v", err)
+	}
+
+	cfg, err := config.PostProcessConfig(&config.Config{Owner: current.Username})
+	if err != nil {
+		t.Fatalf("PostProcessConfig() error = %v", err)
+	}
+
+	if cfg.OwnerUID == nil || strconv.Itoa(*cfg.OwnerUID) != current.Uid {
+		t.Errorf("OwnerUID = %v, want %s", cfg.OwnerUID, current.Uid)
+	}
+}
+
+func TestPostProcessConfig_RejectsMinWorkersAboveMax(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.PostProcessConfig(&config.Config{MinWorkers: 8, MaxWorkers: 4})
+	if !errors.Is(err, config.ErrInvalidWorkerBounds) {
+		t.Errorf("PostProcessConfig() error = %v, want ErrInvalidWorkerBounds", err)
+	}
+
+	// No --max-workers: the ceiling is only known at sync time, and the floor is capped by it
+	_, err = config.PostProcessConfig(&config.Config{MinWorkers: 8})
+	if err != nil {
+		t.Errorf("PostProcessConfig() error = %v, want nil", err)
+	}
+}
+
+func TestPostProcessConfig_RejectsMinSizeAboveMax(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.PostProcessConfig(&config.Config{MinSize: 2048, MaxSize: 1024})
+	if !errors.Is(err, config.ErrInvalidSizeRange) {
+		t.Errorf("PostProcessConfig() error = %v, want ErrInvalidSizeRange", err)
+	}
+}
+
+func TestPostProcessConfig_RejectsEmptyModTimeWindow(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	_, err := config.PostProcessConfig(&config.Config{
+		NewerThan: config.TimeBound{Time: now.Add(-time.Hour)},
+		OlderThan: config.TimeBound{Time: now.Add(-2 * time.Hour)},
+	})
+	if !errors.Is(err, config.ErrInvalidModTimeWindow) {
+		t.Errorf("PostProcessConfig() error = %v, want ErrInvalidModTimeWindow", err)
+	}
+}
+
+func TestPostProcessConfig_RejectsMoveFromSnapshot(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.PostProcessConfig(&config.Config{Move: true, SnapshotSource: true})
+	if !errors.Is(err, config.ErrMoveFromSnapshot) {
+		t.Errorf("PostProcessConfig() error = %v, want ErrMoveFromSnapshot", err)
+	}
 }
 
 func TestPostProcessConfig(t *testing.T) {
@@ -164,12 +772,36 @@ func TestPostProcessConfig(t *testing.T) {
 			wantInteractive: true,
 			wantErr:         false,
 		},
+		{
+			name:            "assert-synced without paths - should error",
+			cfg:             config.Config{AssertSynced: true},
+			wantInteractive: false,
+			wantErr:         true,
+		},
 		{
 			name:            "invalid source path - should error",
 			cfg:             config.Config{SourcePath: "/nonexistent", DestPath: "/some/dest"},
 			wantInteractive: false,
 			wantErr:         true,
 		},
+		{
+			name:            "unknown owner - should error",
+			cfg:             config.Config{Owner: "no-such-user-glowsync"},
+			wantInteractive: true,
+			wantErr:         true,
+		},
+		{
+			name:            "webhook URL without http scheme - should error",
+			cfg:             config.Config{Webhook: "hooks.example.com/glowsync"},
+			wantInteractive: true,
+			wantErr:         true,
+		},
+		{
+			name:            "invalid exclude pattern - should error",
+			cfg:             config.Config{Exclude: []string{"*.tmp", "[unclosed"}},
+			wantInteractive: true,
+			wantErr:         true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -262,6 +894,7 @@ func TestValidatePaths(t *testing.T) {
 	// without changing the config package to accept a FileSystem interface.
 	// For now, we test the logic without filesystem access by using paths
 	// that we know will fail validation (empty strings).
+	sourceA, sourceB, dest := t.TempDir(), t.TempDir(), t.TempDir()
 
 	tests := []struct {
 		name    string
@@ -278,6 +911,70 @@ func TestValidatePaths(t *testing.T) {
 			cfg:     config.Config{SourcePath: "/some/source", DestPath: ""},
 			wantErr: true,
 		},
+		{
+			name:    "baseline is not a directory",
+			cfg:     config.Config{SourcePath: sourceA, DestPath: dest, BaselineDir: "/nonexistent/baseline"},
+			wantErr: true,
+		},
+		{
+			name:    "scan-only needs no dest path",
+			cfg:     config.Config{SourcePath: "/", ScanOnly: true, SourceIndex: "/tmp/source.idx"},
+			wantErr: false,
+		},
+		{
+			name:    "gen-script with a remote destination",
+			cfg:     config.Config{SourcePath: "/", DestPath: "sftp://user@host/dest", GenScript: "/tmp/sync.sh"},
+			wantErr: true,
+		},
+		{
+			name:    "scan-only without source index",
+			cfg:     config.Config{SourcePath: "/", ScanOnly: true},
+			wantErr: true,
+		},
+		{
+			name:    "object store destination",
+			cfg:     config.Config{SourcePath: "/", DestPath: "s3://bucket/backups"},
+			wantErr: false,
+		},
+		{
+			name:    "object store destination without a bucket",
+			cfg:     config.Config{SourcePath: "/", DestPath: "gs:///backups"},
+			wantErr: true,
+		},
+		{
+			name:    "gen-script with an object store destination",
+			cfg:     config.Config{SourcePath: "/", DestPath: "s3://bucket/backups", GenScript: "/tmp/sync.sh"},
+			wantErr: true,
+		},
+		{
+			name:    "several sources",
+			cfg:     config.Config{SourcePath: sourceA + ", " + sourceB, Sources: []string{sourceA, "b=" + sourceB}, DestPath: dest},
+			wantErr: false,
+		},
+		{
+			name: "several sources into the same folder",
+			cfg: config.Config{
+				SourcePath: sourceA + ", " + sourceB,
+				Sources:    []string{sourceA, filepath.Base(sourceA) + "=" + sourceB},
+				DestPath:   dest,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "several sources, one missing",
+			cfg:     config.Config{SourcePath: sourceA + ", /nonexistent", Sources: []string{sourceA, "/nonexistent"}, DestPath: dest},
+			wantErr: true,
+		},
+		{
+			name:    "destination is the source",
+			cfg:     config.Config{SourcePath: sourceA, DestPath: sourceA},
+			wantErr: true,
+		},
+		{
+			name:    "several sources, one holding the destination",
+			cfg:     config.Config{SourcePath: sourceA + ", ..", Sources: []string{sourceA, filepath.Dir(dest)}, DestPath: dest},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -291,3 +988,115 @@ func TestValidatePaths(t *testing.T) {
 		})
 	}
 }
+
+func TestWarnings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		cfg       config.Config
+		wantCount int
+	}{
+		{
+			name:      "local paths",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst"},
+			wantCount: 0,
+		},
+		{
+			name:      "SFTP URL without password",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "sftp://user@host/path"},
+			wantCount: 0,
+		},
+		{
+			name:      "SFTP URLs with passwords",
+			cfg:       config.Config{SourcePath: "sftp://user:pw@a/path", DestPath: "sftp://user:pw@b/path"},
+			wantCount: 2,
+		},
+		{
+			name:      "webhook secret without webhook",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst", WebhookSecret: "s3cret"},
+			wantCount: 1,
+		},
+		{
+			name:      "reconcile hashes without reconcile",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst", ReconcileHashes: true},
+			wantCount: 1,
+		},
+		{
+			name:      "retry delay without retries",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst", RetryDelay: time.Second},
+			wantCount: 1,
+		},
+		{
+			name:      "trash dir without deletion",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst", TrashDir: "/trash", NoDelete: true},
+			wantCount: 1,
+		},
+		{
+			name:      "delete confirmation without deletion",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst", ConfirmDeletesOver: 100, NoDelete: true},
+			wantCount: 1,
+		},
+		{
+			name:      "preallocate with sparse",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst", Preallocate: true, Sparse: true},
+			wantCount: 1,
+		},
+		{
+			name:      "checksum algorithm with ca store",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst", CAStore: true, ChecksumAlgo: config.HashBLAKE3},
+			wantCount: 1,
+		},
+		{
+			name:      "verify resumed without resume manifest",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst", VerifyResumed: true},
+			wantCount: 1,
+		},
+		{
+			name:      "verify resumed with resume manifest",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst", VerifyResumed: true, ResumeManifest: true},
+			wantCount: 0,
+		},
+		{
+			name:      "collision policy without flatten",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst", OnCollision: config.CollisionSkip},
+			wantCount: 1,
+		},
+		{
+			name:      "collision policy with flatten",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst", Flatten: true, OnCollision: config.CollisionSkip},
+			wantCount: 0,
+		},
+		{
+			name:      "flatten with content-addressed store",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst", Flatten: true, CAStore: true},
+			wantCount: 1,
+		},
+		{
+			name:      "move with content-addressed store",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst", Move: true, CAStore: true},
+			wantCount: 1,
+		},
+		{
+			name:      "worker bounds without adaptive mode",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst", MaxWorkers: 8},
+			wantCount: 1,
+		},
+		{
+			name:      "worker bounds with adaptive mode",
+			cfg:       config.Config{SourcePath: "/src", DestPath: "/dst", AdaptiveMode: true, MinWorkers: 2, MaxWorkers: 8},
+			wantCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.cfg.Warnings()
+			if len(got) != tt.wantCount {
+				t.Errorf("Warnings() = %v, want %d warnings", got, tt.wantCount)
+			}
+		})
+	}
+}
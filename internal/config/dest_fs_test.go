@@ -0,0 +1,42 @@
+package config_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/joe/copy-files/internal/config"
+)
+
+func TestParseDestFSType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected config.DestFSType
+		wantErr  bool
+	}{
+		{"", config.DestFSAuto, false},
+		{"auto", config.DestFSAuto, false},
+		{"ext4", config.DestFSExt4, false},
+		{"NTFS", config.DestFSNTFS, false},
+		{"vfat", config.DestFSVFAT, false},
+		{"exfat", config.DestFSVFAT, false},
+		{"zfs", config.DestFSAuto, true},
+	}
+
+	for _, tt := range tests {
+		got, err := config.ParseDestFSType(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDestFSType(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+
+		if tt.wantErr && !errors.Is(err, config.ErrInvalidDestFSType) {
+			t.Errorf("ParseDestFSType(%q) error = %v, want ErrInvalidDestFSType", tt.input, err)
+		}
+
+		if !tt.wantErr && got != tt.expected {
+			t.Errorf("ParseDestFSType(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -56,7 +56,7 @@ func TestValidateSFTPURL(t *testing.T) {
 		{
 			name:       "source with trailing slash is considered valid",
 			sourcePath: "sftp://user@host/",
-			destPath:   "sftp://user@host/dest",
+			destPath:   "sftp://user@backup/dest",
 			wantErr:    false, // Has 3 slashes, so passes validation
 		},
 		{
@@ -0,0 +1,65 @@
+package syncengine_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_AnalysisLog_CapturesAllEntries verifies that the analysis log file
+// receives every analysis entry, not just the window retained in Status.AnalysisLog.
+func TestEngine_AnalysisLog_CapturesAllEntries(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	for i := range 15 {
+		createTestFile(t, sourceDir, fmt.Sprintf("file%02d.txt", i), "content")
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+
+	logPath := filepath.Join(t.TempDir(), "analysis.log")
+	err = engine.EnableAnalysisLogging(logPath)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = engine.Analyze()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.CloseLog()
+
+	data, err := os.ReadFile(logPath)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	status := engine.GetStatus()
+
+	// The in-memory log is capped, the file is not
+	g.Expect(len(lines)).To(BeNumerically(">", len(status.AnalysisLog)))
+	g.Expect(lines[0]).To(ContainSubstring("Starting analysis..."))
+	g.Expect(string(data)).To(ContainSubstring("Analysis complete!"))
+}
+
+// TestEngine_AnalysisLog_InvalidPath verifies that an unwritable path is reported.
+func TestEngine_AnalysisLog_InvalidPath(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine, err := syncengine.NewEngine(t.TempDir(), t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = engine.EnableAnalysisLogging(filepath.Join(t.TempDir(), "missing", "analysis.log"))
+	g.Expect(err).Should(HaveOccurred())
+}
@@ -0,0 +1,47 @@
+package syncengine
+
+import "time"
+
+// analysisSample is ScannedBytes at one moment during analysis.
+type analysisSample struct {
+	at    time.Time
+	bytes int64
+}
+
+// analysisSampleInterval is the least time between kept samples, so a fast scan reporting
+// every file doesn't fill the window with thousands of them.
+const analysisSampleInterval = 250 * time.Millisecond
+
+// startAnalysisRate restarts AnalysisRate from ScannedBytes as of now, e.g. when ScannedBytes
+// starts counting something else. Must be called with the Status mutex already locked.
+func (s *Status) startAnalysisRate(now time.Time) {
+	s.analysisSamples = append(s.analysisSamples[:0], analysisSample{at: now, bytes: s.ScannedBytes})
+	s.AnalysisRate = 0
+}
+
+// addAnalysisSample records ScannedBytes as of now and updates AnalysisRate to the rate over the
+// last AnalysisRateWindow. The newest sample from before the window is kept as the baseline, so
+// the rate always spans the whole window once analysis has run that long.
+// Must be called with the Status mutex already locked.
+func (s *Status) addAnalysisSample(now time.Time) {
+	count := len(s.analysisSamples)
+	if count == 0 || now.Sub(s.analysisSamples[count-1].at) >= analysisSampleInterval {
+		s.analysisSamples = append(s.analysisSamples, analysisSample{at: now, bytes: s.ScannedBytes})
+	}
+
+	cutoff := now.Add(-AnalysisRateWindow)
+	first := 0
+
+	for first+1 < len(s.analysisSamples) && !s.analysisSamples[first+1].at.After(cutoff) {
+		first++
+	}
+
+	s.analysisSamples = s.analysisSamples[first:]
+
+	baseline := s.analysisSamples[0]
+
+	elapsed := now.Sub(baseline.at).Seconds()
+	if elapsed > 0 {
+		s.AnalysisRate = float64(s.ScannedBytes-baseline.bytes) / elapsed
+	}
+}
@@ -0,0 +1,73 @@
+package syncengine //nolint:testpackage // Testing private methods
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Gomega convention
+)
+
+// TestAddAnalysisSample_FollowsRecentRate verifies that AnalysisRate is the rate over the last
+// AnalysisRateWindow, so a slowdown shows at once instead of being averaged into the whole scan.
+func TestAddAnalysisSample_FollowsRecentRate(t *testing.T) {
+	t.Parallel()
+
+	gomega := NewWithT(t)
+
+	const megabyte = 1024 * 1024
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	status := &Status{}
+	status.startAnalysisRate(start)
+
+	// 10s at 10 MB/s, then 5s at 1 MB/s
+	for second := 1; second <= 15; second++ {
+		if second <= 10 {
+			status.ScannedBytes += 10 * megabyte
+		} else {
+			status.ScannedBytes += megabyte
+		}
+
+		status.addAnalysisSample(start.Add(time.Duration(second) * time.Second))
+	}
+
+	gomega.Expect(status.AnalysisRate).To(BeNumerically("~", megabyte, 1))
+
+	// Restarting drops the old samples along with the rate
+	status.ScannedBytes = 0
+	status.startAnalysisRate(start.Add(15 * time.Second))
+	gomega.Expect(status.AnalysisRate).To(BeZero())
+
+	status.ScannedBytes = 4 * megabyte
+	status.addAnalysisSample(start.Add(17 * time.Second))
+	gomega.Expect(status.AnalysisRate).To(BeNumerically("~", 2*megabyte, 1))
+}
+
+// TestCalculateAnalysisProgress_EstimatesFromRate verifies that the analysis ETA is the bytes left
+// at AnalysisRate, and that there is none while the total is still being counted.
+func TestCalculateAnalysisProgress_EstimatesFromRate(t *testing.T) {
+	t.Parallel()
+
+	gomega := NewWithT(t)
+
+	const megabyte = 1024 * 1024
+
+	status := &Status{
+		ScannedBytes:      40 * megabyte,
+		TotalBytesToScan:  100 * megabyte,
+		AnalysisStartTime: time.Now().Add(-20 * time.Second),
+		AnalysisRate:      megabyte,
+	}
+
+	progress := status.CalculateAnalysisProgress()
+	gomega.Expect(progress.IsCounting).To(BeFalse())
+	gomega.Expect(progress.EstimatedTimeRemaining).To(Equal(time.Minute))
+	gomega.Expect(progress.TimePercent).To(BeNumerically("~", 25.0, 0.5))
+
+	// Still counting: the rate alone can't say how much is left
+	status.TotalBytesToScan = 0
+
+	progress = status.CalculateAnalysisProgress()
+	gomega.Expect(progress.IsCounting).To(BeTrue())
+	gomega.Expect(progress.EstimatedTimeRemaining).To(BeZero())
+}
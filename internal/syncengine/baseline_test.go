@@ -0,0 +1,50 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_BaselineDir_ExcludesIdenticalFiles verifies that source files identical to the
+// baseline are left out, files that differ or are missing from it are synced, and destination
+// copies of left-out files are kept.
+func TestEngine_BaselineDir_ExcludesIdenticalFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	baselineDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "same.txt", "from the base image")
+	createNestedTestFile(t, sourceDir, "etc/changed.conf", "overlay setting")
+	createTestFile(t, sourceDir, "new.txt", "overlay only")
+	createTestFile(t, baselineDir, "same.txt", "from the base image")
+	createNestedTestFile(t, baselineDir, "etc/changed.conf", "base setting!!")
+	createTestFile(t, destDir, "same.txt", "stale copy")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.BaselineDir = baselineDir
+	engine.Workers = 1
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.GetStatus().BaselineExcludedFiles).To(Equal(1))
+	g.Expect(engine.Sync()).To(Succeed())
+
+	for name, content := range map[string]string{
+		"etc/changed.conf": "overlay setting",
+		"new.txt":          "overlay only",
+		"same.txt":         "stale copy",
+	} {
+		data, err := os.ReadFile(filepath.Join(destDir, name))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(string(data)).To(Equal(content))
+	}
+}
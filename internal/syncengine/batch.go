@@ -0,0 +1,44 @@
+package syncengine
+
+// Exported constants.
+const (
+	// DefaultBatchThreshold is the size below which BatchSmallFiles sends a file in a batch (64KB)
+	DefaultBatchThreshold = 64 * 1024
+	// MaxBatchBytes caps the content of one batch, so a failed batch costs little to redo (32MB)
+	MaxBatchBytes = 32 * 1024 * 1024
+	// MaxBatchFiles caps the number of files in one batch
+	MaxBatchFiles = 1000
+)
+
+// planBatches groups files smaller than threshold into batches of at most MaxBatchFiles files and
+// MaxBatchBytes bytes, in plan order. Larger files, and links that are recreated rather than
+// copied, are left out to be transferred individually.
+func planBatches(files []*FileToSync, threshold int64) [][]*FileToSync {
+	var (
+		batches [][]*FileToSync
+		current []*FileToSync
+		size    int64
+	)
+
+	for _, file := range files {
+		if file.Size >= threshold || file.Symlink {
+			continue
+		}
+
+		if len(current) == MaxBatchFiles || (len(current) > 0 && size+file.Size > MaxBatchBytes) {
+			batches = append(batches, current)
+			current = nil
+			size = 0
+		}
+
+		current = append(current, file)
+		size += file.Size
+	}
+
+	// A lone small file gains nothing from a batch
+	if len(current) > 1 {
+		batches = append(batches, current)
+	}
+
+	return batches
+}
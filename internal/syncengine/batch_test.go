@@ -0,0 +1,131 @@
+package syncengine_test
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestEngine_BatchSmallFiles_SendsSmallFilesTogether verifies that small files go through one tar
+// batch, large files are copied individually, and both land with their content and modtimes.
+func TestEngine_BatchSmallFiles_SendsSmallFilesTogether(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "a")
+	createTestFile(t, sourceDir, "b.txt", "bb")
+	createNestedTestFile(t, sourceDir, "sub/c.txt", "ccc")
+	createTestFile(t, sourceDir, "large.txt", "larger than the threshold")
+
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	g.Expect(os.Chtimes(filepath.Join(sourceDir, "sub", "c.txt"), modTime, modTime)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.FileOps.DestFS = &tarExtractingFS{RealFileSystem: filesystem.NewRealFileSystem()}
+	engine.BatchSmallFiles = true
+	engine.BatchThreshold = 10
+	engine.Workers = 1
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.Errors).To(BeEmpty())
+	g.Expect(status.Batches).To(Equal(1))
+	g.Expect(status.BatchedFiles).To(Equal(3))
+	g.Expect(status.ProcessedFiles).To(Equal(4))
+
+	for name, content := range map[string]string{
+		"a.txt": "a", "b.txt": "bb", "sub/c.txt": "ccc", "large.txt": "larger than the threshold",
+	} {
+		data, err := os.ReadFile(filepath.Join(destDir, name))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(string(data)).To(Equal(content))
+	}
+
+	info, err := os.Stat(filepath.Join(destDir, "sub", "c.txt"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(info.ModTime().Equal(modTime)).To(BeTrue())
+}
+
+// TestEngine_BatchSmallFiles_CopiesIndividuallyWithoutExtractor verifies that destinations that
+// can't unpack tar streams get every file copied as usual.
+func TestEngine_BatchSmallFiles_CopiesIndividuallyWithoutExtractor(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "a")
+	createTestFile(t, sourceDir, "b.txt", "b")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.BatchSmallFiles = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.BatchedFiles).To(BeZero())
+	g.Expect(status.ProcessedFiles).To(Equal(2))
+}
+
+// tarExtractingFS is a local filesystem that unpacks tar streams itself, standing in for a remote tar.
+type tarExtractingFS struct {
+	*filesystem.RealFileSystem
+}
+
+func (fs *tarExtractingFS) ExtractTar(dir string, r io.Reader) error {
+	reader := tar.NewReader(r)
+
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("reading tar: %w", err)
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(header.Name))
+
+		err = os.MkdirAll(filepath.Dir(path), 0o750)
+		if err != nil {
+			return fmt.Errorf("creating parent of %s: %w", path, err)
+		}
+
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", header.Name, err)
+		}
+
+		err = os.WriteFile(path, data, 0o600)
+		if err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+
+		err = os.Chtimes(path, header.ModTime, header.ModTime)
+		if err != nil {
+			return fmt.Errorf("setting times on %s: %w", path, err)
+		}
+	}
+}
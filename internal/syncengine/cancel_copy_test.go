@@ -0,0 +1,45 @@
+package syncengine_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_CancelDuringCopy_CountsAsCancelled verifies that a copy aborted by Cancel is
+// counted in CancelledFiles rather than FailedFiles, even though CopyFileWithStats wraps
+// the cancellation error with path context before handleCopyError sees it.
+func TestEngine_CancelDuringCopy_CountsAsCancelled(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "file.txt", "content")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.Workers = 1
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	// Cancel as soon as the file is opened, so the copy loop sees the cancellation
+	engine.RegisterStatusCallback(func(_ *syncengine.Status) {
+		engine.Cancel()
+	})
+
+	err = engine.Sync()
+	g.Expect(err).To(MatchError(syncengine.ErrCancelled))
+
+	status := engine.GetStatus()
+	g.Expect(status.CancelledFiles).To(Equal(1))
+	g.Expect(status.CancelledCopies).To(ConsistOf("file.txt"))
+	g.Expect(status.FailedFiles).To(BeZero())
+	g.Expect(status.Errors).To(BeEmpty())
+}
@@ -0,0 +1,141 @@
+package syncengine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// Exported constants.
+const (
+	// CAStoreIndexFile is the index at the destination root mapping source paths to content hashes
+	CAStoreIndexFile = "index.json"
+	// CAStoreIndexVersion is the current index file format version
+	CAStoreIndexVersion = 1
+	// CAStoreObjectsDir holds the stored content, one file per unique hash
+	CAStoreObjectsDir = "objects"
+	// CAStoreIncomingDir, under CAStoreObjectsDir, holds content being stored until it is hashed
+	CAStoreIncomingDir = "incoming"
+)
+
+// caStoreEntry records the stored content of one source path.
+type caStoreEntry struct {
+	Hash    string    `json:"hash"` // SHA-256 of the content, hex encoded
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// caStoreIndexFile is the on-disk index format.
+type caStoreIndexFile struct {
+	Version int                     `json:"version"`
+	Files   map[string]caStoreEntry `json:"files"` // Source relative path -> stored content
+}
+
+// caStoreIndex is the in-memory index, updated by sync workers as content is stored.
+type caStoreIndex struct {
+	mu      sync.Mutex
+	files   map[string]caStoreEntry
+	pending map[string]caStoreEntry // Planned paths awaiting a hash (size and modtime from the scan)
+	objects map[string]*sync.Mutex  // Per-hash locks so two workers never write the same object
+	dirty   bool                    // Whether files changed since loading
+}
+
+// lockObject serializes work on one object so identical files synced concurrently are stored once.
+func (idx *caStoreIndex) lockObject(hash string) func() {
+	idx.mu.Lock()
+
+	lock, ok := idx.objects[hash]
+	if !ok {
+		lock = &sync.Mutex{}
+		idx.objects[hash] = lock
+	}
+	idx.mu.Unlock()
+
+	lock.Lock()
+
+	return lock.Unlock
+}
+
+// record maps a planned path to its stored content.
+func (idx *caStoreIndex) record(relPath, hash string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	entry := idx.pending[relPath]
+	entry.Hash = hash
+	idx.files[relPath] = entry
+	idx.dirty = true
+
+	delete(idx.pending, relPath)
+}
+
+// marshal encodes the index for writing.
+func (idx *caStoreIndex) marshal() ([]byte, error) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	data, err := json.MarshalIndent(caStoreIndexFile{Version: CAStoreIndexVersion, Files: idx.files}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode store index: %w", err)
+	}
+
+	return data, nil
+}
+
+// caObjectPath returns where content with the given hash is stored, relative to the destination root.
+// Objects are fanned out by the first two hex digits to keep directories small.
+func caObjectPath(hash string) string {
+	return filepath.Join(CAStoreObjectsDir, hash[:2], hash)
+}
+
+// caIncomingPath returns where a source path's content is written before it is renamed to its
+// hash, relative to the destination root. The name is fixed per path so an interrupted copy
+// can resume, and hashed so any path fits in one flat directory.
+func caIncomingPath(relPath string) string {
+	sum := sha256.Sum256([]byte(relPath))
+
+	return filepath.Join(CAStoreObjectsDir, CAStoreIncomingDir, hex.EncodeToString(sum[:]))
+}
+
+// loadCAStoreIndex reads the destination's store index, returning an empty index if there is none yet.
+func loadCAStoreIndex(ops *fileops.FileOps, path string) (*caStoreIndex, error) {
+	index := &caStoreIndex{
+		files:   make(map[string]caStoreEntry),
+		pending: make(map[string]caStoreEntry),
+		objects: make(map[string]*sync.Mutex),
+	}
+
+	data, err := ops.ReadDestFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return index, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read store index: %w", err)
+	}
+
+	var file caStoreIndexFile
+
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse store index %s: %w", path, err)
+	}
+
+	if file.Version != CAStoreIndexVersion {
+		return nil, fmt.Errorf("unsupported store index version %d in %s", file.Version, path)
+	}
+
+	if file.Files != nil {
+		index.files = file.Files
+	}
+
+	return index, nil
+}
@@ -0,0 +1,151 @@
+package syncengine_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// TestEngine_CAStore_StoresIdenticalContentOnce verifies that duplicate files share one object,
+// the index maps every path to it, and an unchanged re-run has nothing to store.
+func TestEngine_CAStore_StoresIdenticalContentOnce(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "same content")
+	createNestedTestFile(t, sourceDir, "copies/b.txt", "same content")
+	createTestFile(t, sourceDir, "c.txt", "different")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.CAStore = true
+	engine.Workers = 1
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.PlanEntries()).To(HaveLen(3))
+	g.Expect(engine.Sync()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.Errors).To(BeEmpty())
+	g.Expect(status.DedupedFiles).To(Equal(1))
+
+	sum := sha256.Sum256([]byte("same content"))
+	hash := hex.EncodeToString(sum[:])
+
+	data, err := os.ReadFile(filepath.Join(destDir, syncengine.CAStoreObjectsDir, hash[:2], hash))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal("same content"))
+
+	// The destination holds objects, not a mirror of the source
+	_, err = os.Stat(filepath.Join(destDir, "a.txt"))
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+
+	var index struct {
+		Files map[string]struct {
+			Hash string `json:"hash"`
+		} `json:"files"`
+	}
+
+	data, err = os.ReadFile(filepath.Join(destDir, syncengine.CAStoreIndexFile))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(json.Unmarshal(data, &index)).To(Succeed())
+	g.Expect(index.Files).To(HaveLen(3))
+	g.Expect(index.Files["a.txt"].Hash).To(Equal(hash))
+	g.Expect(index.Files[filepath.Join("copies", "b.txt")].Hash).To(Equal(hash))
+
+	rerun, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rerun.CAStore = true
+
+	g.Expect(rerun.Analyze()).To(Succeed())
+	g.Expect(rerun.GetStatus().TotalFiles).To(BeZero())
+	g.Expect(rerun.GetStatus().AlreadySyncedFiles).To(Equal(3))
+}
+
+// TestEngine_CAStore_DropsRemovedPaths verifies that paths deleted from the source leave the index.
+func TestEngine_CAStore_DropsRemovedPaths(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "keep.txt", "keep")
+	createTestFile(t, sourceDir, "gone.txt", "gone")
+
+	for range 2 {
+		engine, err := syncengine.NewEngine(sourceDir, destDir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		engine.CAStore = true
+
+		g.Expect(engine.Analyze()).To(Succeed())
+		g.Expect(engine.Sync()).To(Succeed())
+
+		g.Expect(os.Remove(filepath.Join(sourceDir, "gone.txt"))).To(Or(Succeed(), MatchError(os.ErrNotExist)))
+	}
+
+	data, err := os.ReadFile(filepath.Join(destDir, syncengine.CAStoreIndexFile))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring("keep.txt"))
+	g.Expect(string(data)).NotTo(ContainSubstring("gone.txt"))
+}
+
+// TestEngine_CAStore_RenamesContentToItsHash verifies that content is stored only by renaming
+// it to its hash: every object is named for what it holds, and no temp objects are left.
+func TestEngine_CAStore_RenamesContentToItsHash(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "same content")
+	createTestFile(t, sourceDir, "b.txt", "same content")
+	createTestFile(t, sourceDir, "c.txt", "different")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.CAStore = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+	g.Expect(engine.GetStatus().Errors).To(BeEmpty())
+
+	objects := 0
+	objectsDir := filepath.Join(destDir, syncengine.CAStoreObjectsDir)
+
+	g.Expect(filepath.WalkDir(objectsDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		data, err := os.ReadFile(path)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		sum := sha256.Sum256(data)
+		g.Expect(entry.Name()).To(Equal(hex.EncodeToString(sum[:])), "object %s", path)
+
+		objects++
+
+		return nil
+	})).To(Succeed())
+
+	g.Expect(objects).To(Equal(2))
+	g.Expect(filepath.Join(destDir, syncengine.CAStoreIndexFile+fileops.TempSuffix)).NotTo(BeAnExistingFile())
+}
@@ -0,0 +1,138 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestEngine_ClockSkew_MeasuresDestinationClock verifies that a destination whose clock runs ahead
+// is measured, flagged past the threshold, and that the probe file is cleaned up.
+func TestEngine_ClockSkew_MeasuresDestinationClock(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "a")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.FileOps.DestFS = &skewedFS{RealFileSystem: filesystem.NewRealFileSystem(), skew: time.Hour}
+	engine.ClockSkewThreshold = time.Minute
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.ClockSkewMeasured).To(BeTrue())
+	g.Expect(status.ExcessiveClockSkew).To(BeTrue())
+	g.Expect(status.ClockSkew).To(BeNumerically("~", time.Hour, 5*time.Second))
+
+	_, err = os.Stat(filepath.Join(destDir, syncengine.ClockSkewProbeFile))
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+}
+
+// TestEngine_ClockSkew_WithinThreshold verifies that a small skew is measured but not flagged,
+// and that local destinations aren't probed at all.
+func TestEngine_ClockSkew_WithinThreshold(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "a")
+
+	remote, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	remote.FileOps.DestFS = &skewedFS{RealFileSystem: filesystem.NewRealFileSystem()}
+	remote.ClockSkewThreshold = time.Minute
+
+	g.Expect(remote.Analyze()).To(Succeed())
+	g.Expect(remote.GetStatus().ClockSkewMeasured).To(BeTrue())
+	g.Expect(remote.GetStatus().ExcessiveClockSkew).To(BeFalse())
+
+	local, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	local.ClockSkewThreshold = time.Minute
+
+	g.Expect(local.Analyze()).To(Succeed())
+	g.Expect(local.GetStatus().ClockSkewMeasured).To(BeFalse())
+}
+
+// TestEngine_ClockSkew_MeasuresSourceClockToo verifies that a remote source's clock is probed as
+// well, so the skew is between the two ends rather than between the destination and this machine.
+func TestEngine_ClockSkew_MeasuresSourceClockToo(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "a")
+
+	// Both ends an hour ahead agree with each other
+	sameSkew, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	sameSkew.FileOps.SourceFS = &skewedFS{RealFileSystem: filesystem.NewRealFileSystem(), skew: time.Hour}
+	sameSkew.FileOps.DestFS = &skewedFS{RealFileSystem: filesystem.NewRealFileSystem(), skew: time.Hour}
+	sameSkew.ClockSkewThreshold = time.Minute
+
+	g.Expect(sameSkew.Analyze()).To(Succeed())
+	g.Expect(sameSkew.GetStatus().ClockSkewMeasured).To(BeTrue())
+	g.Expect(sameSkew.GetStatus().ExcessiveClockSkew).To(BeFalse())
+
+	// A source an hour ahead of a local destination leaves the destination an hour behind
+	sourceAhead, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	sourceAhead.FileOps.SourceFS = &skewedFS{RealFileSystem: filesystem.NewRealFileSystem(), skew: time.Hour}
+	sourceAhead.ClockSkewThreshold = time.Minute
+
+	g.Expect(sourceAhead.Analyze()).To(Succeed())
+
+	status := sourceAhead.GetStatus()
+	g.Expect(status.ExcessiveClockSkew).To(BeTrue())
+	g.Expect(status.ClockSkew).To(BeNumerically("~", -time.Hour, 5*time.Second))
+
+	_, err = os.Stat(filepath.Join(sourceDir, syncengine.ClockSkewProbeFile))
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+}
+
+// skewedFS is a local filesystem that reports modtimes as if its clock were skew ahead,
+// standing in for a remote host.
+type skewedFS struct {
+	*filesystem.RealFileSystem
+
+	skew time.Duration
+}
+
+func (fs *skewedFS) Stat(path string) (os.FileInfo, error) {
+	info, err := fs.RealFileSystem.Stat(path)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Test double passes errors through
+	}
+
+	return skewedFileInfo{FileInfo: info, skew: fs.skew}, nil
+}
+
+type skewedFileInfo struct {
+	os.FileInfo
+
+	skew time.Duration
+}
+
+func (fi skewedFileInfo) ModTime() time.Time {
+	return fi.FileInfo.ModTime().Add(fi.skew)
+}
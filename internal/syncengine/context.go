@@ -0,0 +1,50 @@
+package syncengine
+
+import (
+	"context"
+	"fmt"
+)
+
+// AnalyzeContext is Analyze, stopped early the way Cancel stops it when ctx is done. An
+// analysis stopped by ctx returns an error wrapping both ErrAnalysisCancelled and ctx.Err(),
+// so a deadline can be told apart with errors.Is(err, context.DeadlineExceeded).
+func (e *Engine) AnalyzeContext(ctx context.Context) error {
+	defer e.cancelWith(ctx)()
+
+	return e.contextError(ctx, e.Analyze(), ErrAnalysisCancelled)
+}
+
+// SyncContext is Sync, stopped early the way Cancel stops it when ctx is done. A sync stopped
+// by ctx returns an error wrapping ctx.Err(), and ErrSyncCancelled if Sync itself didn't fail.
+func (e *Engine) SyncContext(ctx context.Context) error {
+	defer e.cancelWith(ctx)()
+
+	return e.contextError(ctx, e.Sync(), ErrSyncCancelled)
+}
+
+// cancelWith cancels the engine when ctx is done, until the returned function is called.
+// A ctx that is already done cancels it before returning, so no work starts.
+func (e *Engine) cancelWith(ctx context.Context) func() {
+	if ctx.Err() != nil {
+		e.Cancel()
+	}
+
+	stop := context.AfterFunc(ctx, e.Cancel)
+
+	return func() { stop() }
+}
+
+// contextError adds ctx's error to err when ctx cancelled the engine, using cancelled when
+// the run itself returned no error.
+func (e *Engine) contextError(ctx context.Context, err, cancelled error) error {
+	ctxErr := ctx.Err()
+	if ctxErr == nil || e.checkCancellation() == nil {
+		return err
+	}
+
+	if err == nil {
+		err = cancelled
+	}
+
+	return fmt.Errorf("%w: %w", err, ctxErr)
+}
@@ -0,0 +1,82 @@
+package syncengine_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+func TestEngine_AnalyzeContext_DoneContextCancels(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	createTestFile(t, sourceDir, "a.txt", "alpha")
+
+	engine, err := syncengine.NewEngine(sourceDir, t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	err = engine.AnalyzeContext(ctx)
+	g.Expect(err).To(MatchError(syncengine.ErrAnalysisCancelled))
+	g.Expect(err).To(MatchError(context.DeadlineExceeded))
+}
+
+// TestEngine_SyncContext_DeadlineStopsCopy verifies that a context deadline reached mid-copy
+// stops the sync and is reported in its error.
+func TestEngine_SyncContext_DeadlineStopsCopy(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "big.bin", strings.Repeat("x", 1<<20))
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	// About 16 seconds for the whole file, so the deadline comes first
+	engine.MaxBytesPerSecond = 64 << 10
+
+	g.Expect(engine.AnalyzeContext(context.Background())).To(Succeed())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = engine.SyncContext(ctx)
+
+	g.Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+	g.Expect(err).To(MatchError(syncengine.ErrCancelled))
+	g.Expect(err).To(MatchError(context.DeadlineExceeded))
+	g.Expect(engine.GetStatus().ProcessedFiles).To(Equal(0))
+}
+
+func TestEngine_SyncContext_Completes(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "alpha")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	g.Expect(engine.AnalyzeContext(ctx)).To(Succeed())
+	g.Expect(engine.SyncContext(ctx)).To(Succeed())
+
+	g.Expect(readFile(t, destDir, "a.txt")).To(Equal("alpha"))
+}
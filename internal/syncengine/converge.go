@@ -0,0 +1,186 @@
+package syncengine
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ConvergePass is the plan one converge pass's analysis found.
+type ConvergePass struct {
+	FilesToSync   int
+	BytesToSync   int64
+	FilesToDelete int
+}
+
+// empty reports whether the pass found nothing left to do.
+func (p ConvergePass) empty() bool {
+	return p.FilesToSync == 0 && p.FilesToDelete == 0
+}
+
+// FormatConvergePasses lists the files each pass planned to sync, first pass first (e.g. "1000 → 12 → 0").
+func FormatConvergePasses(passes []ConvergePass) string {
+	counts := make([]string, 0, len(passes))
+
+	for _, pass := range passes {
+		counts = append(counts, strconv.Itoa(pass.FilesToSync))
+	}
+
+	return strings.Join(counts, " → ")
+}
+
+// carriedTotals are the run's totals from earlier converge passes, which a fresh analysis
+// resets. Progress and the summary count every pass, not just the latest.
+type carriedTotals struct {
+	filesToSync        []*FileToSync
+	totalFiles         int
+	totalBytes         int64
+	alreadySyncedFiles int
+	alreadySyncedBytes int64
+	filesToDelete      int
+	bytesToDelete      int64
+	filesDeleted       int
+	bytesDeleted       int64
+}
+
+// converge repeats analysis and sync after the first pass until an analysis plans nothing,
+// so files that changed while earlier passes ran still reach the destination. It gives up
+// with a warning once ConvergeIterations passes have synced without converging.
+func (e *Engine) converge() error {
+	if e.ConvergeIterations <= 0 {
+		return nil
+	}
+
+	// Neither is updated until the run ends, so every later pass would plan the same files again
+	if e.CAStore || e.DestHashBloom != "" {
+		e.logWarn("Converge passes need a scanned destination; syncing once")
+		return nil
+	}
+
+	// Nothing was planned, so the run took no time for the source to change in
+	if e.recordConvergePass().empty() {
+		return nil
+	}
+
+	for pass := 2; ; pass++ {
+		carried := e.startConvergePass()
+
+		err := e.Analyze()
+		if err != nil {
+			return err
+		}
+
+		plan := e.recordConvergePass()
+
+		e.logToFile("Converge pass", "pass", pass, "files", plan.FilesToSync, "bytes", plan.BytesToSync,
+			"files_to_delete", plan.FilesToDelete)
+
+		if plan.empty() {
+			e.restoreCarriedTotals(carried)
+			e.logToFile("Converged", "passes", pass-1)
+
+			return nil
+		}
+
+		if pass > e.ConvergeIterations {
+			e.restoreCarriedTotals(carried)
+
+			e.Status.mu.Lock()
+			e.Status.NotConverged = true
+			e.Status.mu.Unlock()
+
+			e.logWarn("Not converged: the source is changing faster than it syncs",
+				"passes", e.ConvergeIterations, "files", plan.FilesToSync+plan.FilesToDelete)
+
+			return nil
+		}
+
+		// The new plan's copies count toward the run's totals. Its files and deletions join the
+		// earlier passes' afterwards, since workers and deletion work from this pass's own
+		e.Status.mu.Lock()
+		e.Status.TotalFiles += carried.totalFiles
+		e.Status.TotalBytes += carried.totalBytes
+		e.Status.AlreadySyncedFiles = carried.alreadySyncedFiles
+		e.Status.AlreadySyncedBytes = carried.alreadySyncedBytes
+		e.Status.mu.Unlock()
+
+		err = e.syncPass()
+
+		e.Status.mu.Lock()
+		e.Status.FilesToSync = append(carried.filesToSync, e.Status.FilesToSync...)
+		e.Status.FilesToDelete += carried.filesToDelete
+		e.Status.BytesToDelete += carried.bytesToDelete
+		e.Status.FilesDeleted += carried.filesDeleted
+		e.Status.BytesDeleted += carried.bytesDeleted
+		e.Status.mu.Unlock()
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// recordConvergePass adds the plan the latest analysis found to ConvergePasses.
+func (e *Engine) recordConvergePass() ConvergePass {
+	e.Status.mu.Lock()
+	defer e.Status.mu.Unlock()
+
+	plan := ConvergePass{
+		FilesToSync:   e.Status.TotalFiles,
+		BytesToSync:   e.Status.TotalBytes,
+		FilesToDelete: e.Status.FilesToDelete,
+	}
+	e.Status.ConvergePasses = append(e.Status.ConvergePasses, plan)
+
+	return plan
+}
+
+// restoreCarriedTotals puts back the earlier passes' totals after an analysis that won't be
+// synced, so the summary describes what the run did rather than what it left.
+func (e *Engine) restoreCarriedTotals(carried carriedTotals) {
+	e.Status.mu.Lock()
+	defer e.Status.mu.Unlock()
+
+	e.Status.FilesToSync = carried.filesToSync
+	e.Status.TotalFiles = carried.totalFiles
+	e.Status.TotalBytes = carried.totalBytes
+	e.Status.AlreadySyncedFiles = carried.alreadySyncedFiles
+	e.Status.AlreadySyncedBytes = carried.alreadySyncedBytes
+	e.Status.FilesToDelete = carried.filesToDelete
+	e.Status.BytesToDelete = carried.bytesToDelete
+	e.Status.FilesDeleted = carried.filesDeleted
+	e.Status.BytesDeleted = carried.bytesDeleted
+	e.Status.DeletionComplete = true
+}
+
+// startConvergePass clears what the previous analysis planned so the next one starts fresh,
+// returning the run's totals so far.
+func (e *Engine) startConvergePass() carriedTotals {
+	// Each pass snapshots the source anew and rescans it rather than trusting a saved index
+	e.releaseSnapshot()
+	e.RefreshSourceIndex = true
+
+	e.planMu.Lock()
+	e.plannedDeletions = nil
+	e.planMu.Unlock()
+
+	e.permissionUpdates = nil
+	e.typeChangedPaths = nil
+
+	e.Status.mu.Lock()
+	defer e.Status.mu.Unlock()
+
+	e.Status.TypeChanges = nil
+	e.Status.FinalizationPhase = ""
+
+	return carriedTotals{
+		filesToSync:        e.Status.FilesToSync,
+		totalFiles:         e.Status.TotalFiles,
+		totalBytes:         e.Status.TotalBytes,
+		alreadySyncedFiles: e.Status.AlreadySyncedFiles,
+		alreadySyncedBytes: e.Status.AlreadySyncedBytes,
+		filesToDelete:      e.Status.FilesToDelete,
+		bytesToDelete:      e.Status.BytesToDelete,
+		filesDeleted:       e.Status.FilesDeleted,
+		bytesDeleted:       e.Status.BytesDeleted,
+	}
+}
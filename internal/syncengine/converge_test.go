@@ -0,0 +1,146 @@
+package syncengine_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestEngine_Converge_SyncsFilesWrittenDuringTheRun verifies that files appearing in the source
+// while a pass copies are picked up by the next pass, and the run stops once nothing is left.
+func TestEngine_Converge_SyncsFilesWrittenDuringTheRun(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "first.txt", "first")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.FileOps = fileops.NewDualFileOps(filesystem.NewRealFileSystem(),
+		&growingSourceFS{RealFileSystem: filesystem.NewRealFileSystem(), sourceDir: sourceDir, limit: 1})
+	engine.ChangeType = config.Content
+	engine.ConvergeIterations = 5
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.Errors).To(BeEmpty())
+	g.Expect(status.NotConverged).To(BeFalse())
+	g.Expect(status.ConvergePasses).To(Equal([]syncengine.ConvergePass{
+		{FilesToSync: 1, BytesToSync: 5},
+		{FilesToSync: 1, BytesToSync: 6},
+		{},
+	}))
+
+	// Totals cover every pass, not just the last
+	g.Expect(status.ProcessedFiles).To(Equal(2))
+	g.Expect(status.TotalFiles).To(Equal(2))
+	g.Expect(status.TotalBytes).To(Equal(int64(11)))
+
+	g.Expect(filepath.Join(destDir, "first.txt")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "late-1.txt")).To(BeAnExistingFile())
+}
+
+// TestEngine_Converge_WarnsWhenSourceKeepsChanging verifies that a source changing on every
+// pass stops at the iteration cap and is reported as not converged.
+func TestEngine_Converge_WarnsWhenSourceKeepsChanging(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "first.txt", "first")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.FileOps = fileops.NewDualFileOps(filesystem.NewRealFileSystem(),
+		&growingSourceFS{RealFileSystem: filesystem.NewRealFileSystem(), sourceDir: sourceDir})
+	engine.ChangeType = config.Content
+	engine.ConvergeIterations = 2
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.NotConverged).To(BeTrue())
+	g.Expect(status.ConvergePasses).To(HaveLen(3))
+	g.Expect(status.ProcessedFiles).To(Equal(2))
+	g.Expect(status.TotalFiles).To(Equal(2))
+
+	// The last analysis found late-2.txt but the cap stopped it from being synced
+	g.Expect(filepath.Join(destDir, "late-1.txt")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "late-2.txt")).NotTo(BeAnExistingFile())
+}
+
+// TestEngine_Converge_OffSyncsOnce verifies that without ConvergeIterations the run ends after
+// one pass, leaving files written during it for the next run.
+func TestEngine_Converge_OffSyncsOnce(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "first.txt", "first")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.FileOps = fileops.NewDualFileOps(filesystem.NewRealFileSystem(),
+		&growingSourceFS{RealFileSystem: filesystem.NewRealFileSystem(), sourceDir: sourceDir, limit: 1})
+	engine.ChangeType = config.Content
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.ConvergePasses).To(BeEmpty())
+	g.Expect(filepath.Join(sourceDir, "late-1.txt")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "late-1.txt")).NotTo(BeAnExistingFile())
+}
+
+// growingSourceFS is a destination filesystem that writes a new source file (late-1.txt,
+// late-2.txt, ...) each time a file is created, like a source still being written to during
+// the sync. It stops after limit files (0 = never).
+type growingSourceFS struct {
+	*filesystem.RealFileSystem
+
+	sourceDir string
+	limit     int
+	mu        sync.Mutex
+	written   int
+}
+
+func (f *growingSourceFS) Create(path string) (filesystem.File, error) {
+	f.mu.Lock()
+	if f.limit == 0 || f.written < f.limit {
+		f.written++
+
+		name := fmt.Sprintf("late-%d.txt", f.written)
+
+		err := os.WriteFile(filepath.Join(f.sourceDir, name), []byte(name[:6]), 0o600)
+		if err != nil {
+			f.mu.Unlock()
+			return nil, err
+		}
+	}
+	f.mu.Unlock()
+
+	return f.RealFileSystem.Create(path)
+}
@@ -0,0 +1,66 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_Deadline_DefersUnstartedFiles verifies that files not started by the deadline are
+// left for a later run and reported with ErrDeadlineReached.
+func TestEngine_Deadline_DefersUnstartedFiles(t *testing.T) {
+	t.Parallel()
+
+	for _, adaptive := range []bool{false, true} {
+		g := NewWithT(t)
+
+		sourceDir := t.TempDir()
+		destDir := t.TempDir()
+
+		createTestFile(t, sourceDir, "a.txt", "a")
+		createTestFile(t, sourceDir, "b.txt", "b")
+
+		engine, err := syncengine.NewEngine(sourceDir, destDir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		engine.AdaptiveMode = adaptive
+		engine.Deadline = time.Now().Add(-time.Minute)
+
+		g.Expect(engine.Analyze()).To(Succeed())
+		g.Expect(engine.Sync()).To(MatchError(syncengine.ErrDeadlineReached))
+
+		status := engine.GetStatus()
+		g.Expect(status.DeferredFiles).To(Equal(2))
+		g.Expect(status.ProcessedFiles).To(BeZero())
+
+		_, err = os.Stat(filepath.Join(destDir, "a.txt"))
+		g.Expect(os.IsNotExist(err)).To(BeTrue())
+	}
+}
+
+// TestEngine_Deadline_FutureDeadlineSyncsEverything verifies that a deadline that doesn't pass
+// changes nothing.
+func TestEngine_Deadline_FutureDeadlineSyncsEverything(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "a")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.Deadline = time.Now().Add(time.Hour)
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+	g.Expect(engine.GetStatus().DeferredFiles).To(BeZero())
+	g.Expect(engine.GetStatus().ProcessedFiles).To(Equal(1))
+}
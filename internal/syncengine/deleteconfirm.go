@@ -0,0 +1,53 @@
+package syncengine
+
+import "fmt"
+
+// ConfirmDeletions lets the sync delete more orphaned files than MaxDeleteWithoutConfirm, once
+// someone has seen how many there are. It holds for the rest of the engine's runs, including
+// converge passes.
+func (e *Engine) ConfirmDeletions() {
+	e.deletesOK.Store(true)
+
+	e.Status.mu.Lock()
+	required := e.Status.DeletionConfirmationRequired
+	e.Status.DeletionConfirmationRequired = false
+	e.Status.mu.Unlock()
+
+	if required {
+		e.logToFile("Deletions confirmed")
+		e.notifyStatusUpdate()
+	}
+}
+
+// requireDeletionConfirmation flags a plan deleting more files than MaxDeleteWithoutConfirm
+// allows, unless ConfirmDeletions was already called.
+func (e *Engine) requireDeletionConfirmation(filesToDelete int) {
+	required := e.overDeleteLimit(filesToDelete) && !e.deletesOK.Load()
+
+	e.Status.mu.Lock()
+	e.Status.DeletionConfirmationRequired = required
+	e.Status.mu.Unlock()
+
+	if required {
+		e.logAnalysis(fmt.Sprintf("Deleting %d files needs confirmation (limit %d)",
+			filesToDelete, e.MaxDeleteWithoutConfirm))
+	}
+}
+
+// checkDeletionsConfirmed returns ErrDeleteNotConfirmed if the sync is about to delete more files
+// than MaxDeleteWithoutConfirm allows without ConfirmDeletions.
+func (e *Engine) checkDeletionsConfirmed(filesToDelete int) error {
+	if !e.overDeleteLimit(filesToDelete) || e.deletesOK.Load() {
+		return nil
+	}
+
+	e.logWarn("Deletions not confirmed", "files", filesToDelete, "limit", e.MaxDeleteWithoutConfirm)
+
+	return fmt.Errorf("%w: %d files to delete, over the limit of %d",
+		ErrDeleteNotConfirmed, filesToDelete, e.MaxDeleteWithoutConfirm)
+}
+
+// overDeleteLimit reports whether deleting filesToDelete files needs confirmation.
+func (e *Engine) overDeleteLimit(filesToDelete int) bool {
+	return e.MaxDeleteWithoutConfirm > 0 && filesToDelete > e.MaxDeleteWithoutConfirm
+}
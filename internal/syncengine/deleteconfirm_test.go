@@ -0,0 +1,76 @@
+package syncengine_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_MaxDeleteWithoutConfirm_StopsUntilConfirmed verifies that a plan deleting more files
+// than the limit is flagged by Analyze, still previewed, and deletes nothing until confirmed.
+func TestEngine_MaxDeleteWithoutConfirm_StopsUntilConfirmed(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "new.txt", "new")
+	createTestFile(t, destDir, "old1.txt", "one")
+	createTestFile(t, destDir, "old2.txt", "two")
+
+	newEngine := func() *syncengine.Engine {
+		engine, err := syncengine.NewEngine(sourceDir, destDir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		engine.ChangeType = config.FluctuatingCount
+		engine.MaxDeleteWithoutConfirm = 1
+
+		g.Expect(engine.Analyze()).To(Succeed())
+
+		return engine
+	}
+
+	engine := newEngine()
+	g.Expect(engine.GetStatus().DeletionConfirmationRequired).To(BeTrue())
+	g.Expect(engine.PlanEntries()).To(ContainElement(
+		syncengine.PlanEntry{RelativePath: "old1.txt", Size: 3, Action: syncengine.ActionDelete}))
+
+	g.Expect(engine.Sync()).To(MatchError(syncengine.ErrDeleteNotConfirmed))
+	g.Expect(readDir(t, destDir)).To(Equal(map[string]string{"old1.txt": "one", "old2.txt": "two"}))
+
+	engine = newEngine()
+	engine.ConfirmDeletions()
+	g.Expect(engine.GetStatus().DeletionConfirmationRequired).To(BeFalse())
+
+	g.Expect(engine.Sync()).To(Succeed())
+	g.Expect(readDir(t, destDir)).To(Equal(map[string]string{"new.txt": "new"}))
+}
+
+// TestEngine_MaxDeleteWithoutConfirm_AllowsDeletionsWithinLimit verifies that deleting no more
+// files than the limit needs no confirmation.
+func TestEngine_MaxDeleteWithoutConfirm_AllowsDeletionsWithinLimit(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "new.txt", "new")
+	createTestFile(t, destDir, "old.txt", "old")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.MaxDeleteWithoutConfirm = 1
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.GetStatus().DeletionConfirmationRequired).To(BeFalse())
+
+	g.Expect(engine.Sync()).To(Succeed())
+	g.Expect(readDir(t, destDir)).To(Equal(map[string]string{"new.txt": "new"}))
+}
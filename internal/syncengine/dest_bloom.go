@@ -0,0 +1,130 @@
+package syncengine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/joe/copy-files/pkg/bloom"
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// destBloomKey is the filter key for a file: its path and content hash, so content that
+// exists elsewhere in the destination doesn't count as this file being present.
+func destBloomKey(relPath, hash string) []byte {
+	return []byte(filepath.ToSlash(relPath) + "\x00" + hash)
+}
+
+// destBloomHashesPath returns where the hashes a filter was built from are kept, beside the
+// filter, so the next filter written only hashes files that changed.
+func destBloomHashesPath(filterPath string) string {
+	return filterPath + ".hashes.json"
+}
+
+// recordDestHash notes that the destination file at relPath, while it has size and modTime,
+// holds content with hash, for writeDestHashBloom. Unknown hashes ("") aren't recorded.
+func (e *Engine) recordDestHash(relPath string, size int64, modTime time.Time, hash string) {
+	if e.WriteDestHashBloom == "" || hash == "" {
+		return
+	}
+
+	e.destHashesOnce.Do(func() {
+		e.destHashes = &hashCache{Algorithm: e.FileOps.HashAlgorithm, Files: make(map[string]hashCacheEntry)}
+	})
+
+	e.destHashes.store(relPath, size, modTime, hash)
+}
+
+// destFilesAfterSync returns the files a complete sync leaves at the destination: those
+// analysis listed, less deleted orphans, with the files the sync wrote taking their source's
+// size and modtime, and those DestHashBloom analysis found present without a listing. Plans
+// made without accounting for every destination file (resumed, repair, or counted instead of
+// compared) leave the destination to be scanned instead.
+func (e *Engine) destFilesAfterSync() (map[string]*fileops.FileInfo, error) {
+	if !e.destListed {
+		destFiles, err := e.FileOps.ScanDestDirectoryWithProgress(e.DestPath, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan destination for hash filter: %w", err)
+		}
+
+		for relPath, dstFile := range destFiles {
+			if dstFile.IsDir {
+				delete(destFiles, relPath)
+			}
+		}
+
+		return destFiles, nil
+	}
+
+	files := make(map[string]*fileops.FileInfo)
+
+	for relPath, dstFile := range e.analysisDestFiles {
+		if dstFile.IsDir {
+			continue
+		}
+
+		if _, inSource := e.analysisSourceFiles[relPath]; !inSource && e.deletesOrphans() {
+			continue
+		}
+
+		files[relPath] = dstFile
+	}
+
+	e.Status.mu.RLock()
+	for _, fileToSync := range e.Status.FilesToSync {
+		if fileToSync.Status == fileStatusComplete {
+			files[fileToSync.destPath()] = &fileops.FileInfo{Size: fileToSync.Size, ModTime: fileToSync.ModTime}
+		}
+	}
+	e.Status.mu.RUnlock()
+
+	if e.destHashes != nil {
+		for relPath, entry := range e.destHashes.Files {
+			if _, ok := files[relPath]; !ok {
+				files[relPath] = &fileops.FileInfo{Size: entry.Size, ModTime: time.Unix(0, entry.ModTime)}
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// loadDestBloom reads a filter written by saveDestBloom.
+func loadDestBloom(path string) (*bloom.Filter, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is set by the caller
+	if err != nil {
+		return nil, fmt.Errorf("failed to read destination hash filter: %w", err)
+	}
+
+	var filter bloom.Filter
+
+	err = filter.UnmarshalBinary(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse destination hash filter %s: %w", path, err)
+	}
+
+	return &filter, nil
+}
+
+// saveDestBloom writes a filter, replacing any existing file only once the new one is complete.
+func saveDestBloom(path string, filter *bloom.Filter) error {
+	data, err := filter.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode destination hash filter: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+
+	err = os.WriteFile(tmpPath, data, 0o600) //nolint:mnd // Owner-only permissions
+	if err != nil {
+		return fmt.Errorf("failed to write destination hash filter: %w", err)
+	}
+
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return fmt.Errorf("failed to save destination hash filter: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_DestHashBloom_SkipsFilesInFilter verifies that a filter written after one sync lets
+// the next sync skip unchanged files without scanning the destination, while changed and new
+// files are copied and nothing is deleted.
+func TestEngine_DestHashBloom_SkipsFilesInFilter(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	filterPath := filepath.Join(t.TempDir(), "dest.bloom")
+
+	createTestFile(t, sourceDir, "same.txt", "same")
+	createTestFile(t, sourceDir, "changed.txt", "before")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.WriteDestHashBloom = filterPath
+	engine.BloomFalsePositiveRate = 0.0001
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+	g.Expect(filterPath).To(BeAnExistingFile())
+
+	createTestFile(t, sourceDir, "changed.txt", "after")
+	createTestFile(t, sourceDir, "new.txt", "new")
+	createTestFile(t, destDir, "orphan.txt", "orphan")
+
+	rerun, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rerun.DestHashBloom = filterPath
+
+	g.Expect(rerun.Analyze()).To(Succeed())
+	g.Expect(rerun.PlanEntries()).To(ConsistOf(
+		syncengine.PlanEntry{RelativePath: "changed.txt", Size: 5, Action: syncengine.ActionCreate},
+		syncengine.PlanEntry{RelativePath: "new.txt", Size: 3, Action: syncengine.ActionCreate},
+	))
+	g.Expect(rerun.GetStatus().AlreadySyncedFiles).To(Equal(1))
+
+	g.Expect(rerun.Sync()).To(Succeed())
+
+	data, err := os.ReadFile(filepath.Join(destDir, "changed.txt"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal("after"))
+	g.Expect(filepath.Join(destDir, "orphan.txt")).To(BeAnExistingFile())
+}
+
+// TestEngine_WriteDestHashBloom_HashesOnlyChangedFiles verifies that rewriting the filter reuses
+// the hashes recorded beside it for unchanged destination files and takes changed files' hashes
+// from the sync. A destination file rewritten with its size and modtime kept isn't hashed again,
+// which is what shows the recorded hash was reused.
+func TestEngine_WriteDestHashBloom_HashesOnlyChangedFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	filterPath := filepath.Join(t.TempDir(), "dest.bloom")
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	createTestFile(t, sourceDir, "same.txt", "same")
+	createTestFile(t, sourceDir, "changed.txt", "before")
+	g.Expect(os.Chtimes(filepath.Join(sourceDir, "same.txt"), modTime, modTime)).To(Succeed())
+
+	for run := range 2 {
+		engine, err := syncengine.NewEngine(sourceDir, destDir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		engine.ChangeType = config.Content
+		engine.WriteDestHashBloom = filterPath
+
+		g.Expect(engine.Analyze()).To(Succeed())
+		g.Expect(engine.Sync()).To(Succeed())
+
+		if run == 0 {
+			createTestFile(t, sourceDir, "changed.txt", "after")
+
+			samePath := filepath.Join(destDir, "same.txt")
+			g.Expect(os.WriteFile(samePath, []byte("SAME"), 0o600)).To(Succeed())
+			g.Expect(os.Chtimes(samePath, modTime, modTime)).To(Succeed())
+		}
+	}
+
+	g.Expect(filterPath + ".hashes.json").To(BeAnExistingFile())
+
+	rerun, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rerun.DestHashBloom = filterPath
+
+	g.Expect(rerun.Analyze()).To(Succeed())
+	g.Expect(rerun.PlanEntries()).To(BeEmpty())
+	g.Expect(rerun.GetStatus().AlreadySyncedFiles).To(Equal(2))
+}
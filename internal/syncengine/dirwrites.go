@@ -0,0 +1,73 @@
+package syncengine
+
+import (
+	"sync"
+)
+
+// dirWriteLimiter caps how many workers write into any one destination directory at once, so
+// files bound for different directories still copy in parallel. On spinning disks, many
+// concurrent writers in one directory thrash the heads between its files.
+// A nil *dirWriteLimiter imposes no limit.
+type dirWriteLimiter struct {
+	mu    sync.Mutex
+	limit int
+	dirs  map[string]*dirWriteSlots
+}
+
+// dirWriteSlots is one directory's semaphore, and how many workers hold or await it.
+type dirWriteSlots struct {
+	slots chan struct{}
+	users int
+}
+
+// newDirWriteLimiter returns a limiter allowing perDir concurrent writes into each directory,
+// or nil (unlimited) if perDir is not positive.
+func newDirWriteLimiter(perDir int) *dirWriteLimiter {
+	if perDir <= 0 {
+		return nil
+	}
+
+	return &dirWriteLimiter{limit: perDir, dirs: make(map[string]*dirWriteSlots)}
+}
+
+// acquire blocks until a write into dir is allowed, returning the function that gives the slot
+// back. Returns false if cancelChan is closed first.
+func (l *dirWriteLimiter) acquire(dir string, cancelChan <-chan struct{}) (func(), bool) {
+	if l == nil {
+		return func() {}, true
+	}
+
+	l.mu.Lock()
+
+	entry, ok := l.dirs[dir]
+	if !ok {
+		entry = &dirWriteSlots{slots: make(chan struct{}, l.limit)}
+		l.dirs[dir] = entry
+	}
+
+	entry.users++
+	l.mu.Unlock()
+
+	select {
+	case entry.slots <- struct{}{}:
+		return func() {
+			<-entry.slots
+			l.done(dir, entry)
+		}, true
+	case <-cancelChan:
+		l.done(dir, entry)
+
+		return nil, false
+	}
+}
+
+// done drops a user of dir's slots, forgetting the directory once nobody is writing into it.
+func (l *dirWriteLimiter) done(dir string, entry *dirWriteSlots) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry.users--
+	if entry.users == 0 {
+		delete(l.dirs, dir)
+	}
+}
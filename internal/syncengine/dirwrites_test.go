@@ -0,0 +1,133 @@
+package syncengine_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestEngine_MaxWritesPerDir_CapsEachDirectory verifies that no more than the cap of workers
+// write into one destination directory at once, while every file is still copied.
+func TestEngine_MaxWritesPerDir_CapsEachDirectory(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	for _, dir := range []string{"a", "b"} {
+		for i := range 4 {
+			createNestedTestFile(t, sourceDir, fmt.Sprintf("%s/file%d.txt", dir, i), "content")
+		}
+	}
+
+	dest := &concurrentCreateFS{RealFileSystem: filesystem.NewRealFileSystem(), delay: 20 * time.Millisecond}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.FileOps = fileops.NewDualFileOps(filesystem.NewRealFileSystem(), dest)
+	engine.ChangeType = config.FluctuatingCount
+	engine.AdaptiveMode = false
+	engine.Workers = 4
+	engine.MaxWritesPerDir = 1
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+	g.Expect(engine.GetStatus().Errors).To(BeEmpty())
+
+	g.Expect(dest.maxPerDir).To(Equal(1))
+
+	for _, dir := range []string{"a", "b"} {
+		for i := range 4 {
+			g.Expect(filepath.Join(destDir, dir, fmt.Sprintf("file%d.txt", i))).To(BeAnExistingFile())
+		}
+	}
+}
+
+// BenchmarkEngine_MaxWritesPerDir syncs a directory-dense tree with unlimited and capped
+// per-directory writes. On spinning disks the capped run should be faster; on SSDs and tmpfs
+// expect little difference.
+func BenchmarkEngine_MaxWritesPerDir(b *testing.B) {
+	sourceDir := b.TempDir()
+	content := make([]byte, 64*1024)
+
+	for dir := range 8 {
+		dirPath := filepath.Join(sourceDir, fmt.Sprintf("dir%d", dir))
+		if err := os.MkdirAll(dirPath, 0o755); err != nil {
+			b.Fatal(err)
+		}
+
+		for i := range 32 {
+			if err := os.WriteFile(filepath.Join(dirPath, fmt.Sprintf("file%d.bin", i)), content, 0o600); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	for _, perDir := range []int{0, 1, 2} {
+		b.Run(fmt.Sprintf("perDir=%d", perDir), func(b *testing.B) {
+			for range b.N {
+				engine, err := syncengine.NewEngine(sourceDir, b.TempDir())
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				engine.AdaptiveMode = false
+				engine.Workers = 8
+				engine.MaxWritesPerDir = perDir
+
+				err = engine.Analyze()
+				if err == nil {
+					err = engine.Sync()
+				}
+
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// concurrentCreateFS records the most files being created in one directory at the same time,
+// holding each create for delay so overlapping writers are seen.
+type concurrentCreateFS struct {
+	*filesystem.RealFileSystem
+
+	delay     time.Duration
+	mu        sync.Mutex
+	active    map[string]int
+	maxPerDir int
+}
+
+func (f *concurrentCreateFS) Create(path string) (filesystem.File, error) {
+	dir := filepath.Dir(path)
+
+	f.mu.Lock()
+	if f.active == nil {
+		f.active = make(map[string]int)
+	}
+
+	f.active[dir]++
+	f.maxPerDir = max(f.maxPerDir, f.active[dir])
+	f.mu.Unlock()
+
+	time.Sleep(f.delay)
+
+	f.mu.Lock()
+	f.active[dir]--
+	f.mu.Unlock()
+
+	return f.RealFileSystem.Create(path)
+}
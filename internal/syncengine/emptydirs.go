@@ -0,0 +1,88 @@
+package syncengine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// dirToCreate is a source directory PreserveEmptyDirs creates at the destination.
+type dirToCreate struct {
+	relPath     string
+	destRelPath string
+	mode        os.FileMode
+}
+
+// planDirCreation plans creating the source directory at relPath at the destination when
+// PreserveEmptyDirs is set and the destination has no directory there. Directories holding
+// files to copy are planned too, since creating them first costs a mkdir the copy would do.
+func (e *Engine) planDirCreation(relPath string, srcFile, dstFile *fileops.FileInfo) {
+	if !e.PreserveEmptyDirs || e.Flatten || (dstFile != nil && dstFile.IsDir) {
+		return
+	}
+
+	destRelPath := relPath
+	if destName, ok := e.destNames[relPath]; ok {
+		destRelPath = destName
+	}
+
+	mode := os.FileMode(fileops.DefaultDirPermissions)
+	if e.PreservePermissions {
+		mode = srcFile.Mode.Perm()
+	}
+
+	e.dirsToCreate = append(e.dirsToCreate, dirToCreate{relPath: relPath, destRelPath: destRelPath, mode: mode})
+	e.logAnalysis("  + Directory missing at destination: " + relPath)
+
+	e.Status.mu.Lock()
+	e.Status.DirsToCreate = len(e.dirsToCreate)
+	e.Status.mu.Unlock()
+}
+
+// createPlannedDirs creates the directories planDirCreation planned, parents first, before any
+// file is copied. Failures are recorded as file errors rather than stopping the sync.
+func (e *Engine) createPlannedDirs() error {
+	if len(e.dirsToCreate) == 0 {
+		return nil
+	}
+
+	e.logToFile("Creating directories", "dirs", len(e.dirsToCreate))
+
+	for _, dir := range sortedDirsToCreate(e.dirsToCreate) {
+		err := e.checkCancellation()
+		if err != nil {
+			return err
+		}
+
+		err = e.FileOps.MkdirAllDest(filepath.Join(e.DestPath, dir.destRelPath), dir.mode)
+
+		e.Status.mu.Lock()
+		if err != nil {
+			e.recordError(dir.relPath, fmt.Errorf("failed to create directory: %w", err))
+		} else {
+			e.Status.DirsCreated++
+		}
+		e.Status.mu.Unlock()
+
+		if err == nil {
+			e.itemize(itemizeNewDir, dir.destRelPath+"/")
+		}
+	}
+
+	e.notifyStatusUpdate()
+
+	return nil
+}
+
+// sortedDirsToCreate returns dirs ordered by destination path, so parents come before their
+// children and each is created with its own mode rather than a child's MkdirAll default.
+func sortedDirsToCreate(dirs []dirToCreate) []dirToCreate {
+	sorted := append([]dirToCreate(nil), dirs...)
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].destRelPath < sorted[j].destRelPath })
+
+	return sorted
+}
@@ -0,0 +1,99 @@
+package syncengine_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_PreserveEmptyDirs_CreatesMissingDirs verifies that empty source directories are
+// created at the destination, and empty ones already there are kept rather than deleted.
+func TestEngine_PreserveEmptyDirs_CreatesMissingDirs(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "hello")
+	g.Expect(os.MkdirAll(filepath.Join(sourceDir, "new", "deeper"), 0o750)).To(Succeed())
+	g.Expect(os.Mkdir(filepath.Join(sourceDir, "keep"), 0o750)).To(Succeed())
+	g.Expect(os.Mkdir(filepath.Join(destDir, "keep"), 0o750)).To(Succeed())
+	g.Expect(os.Mkdir(filepath.Join(destDir, "orphan"), 0o750)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.PreserveEmptyDirs = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.GetStatus().DirsToCreate).To(Equal(2))
+	g.Expect(engine.Sync()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.DirsCreated).To(Equal(2))
+	g.Expect(status.Errors).To(BeEmpty())
+	g.Expect(filepath.Join(destDir, "new", "deeper")).To(BeADirectory())
+	g.Expect(filepath.Join(destDir, "keep")).To(BeADirectory())
+	g.Expect(filepath.Join(destDir, "orphan")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "a.txt")).To(BeARegularFile())
+}
+
+// TestEngine_PreserveEmptyDirs_Off verifies that without PreserveEmptyDirs, only the
+// directories copied files land in are created.
+func TestEngine_PreserveEmptyDirs_Off(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createNestedTestFile(t, sourceDir, "full/a.txt", "hello")
+	g.Expect(os.Mkdir(filepath.Join(sourceDir, "empty"), 0o750)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(engine.GetStatus().DirsCreated).To(BeZero())
+	g.Expect(filepath.Join(destDir, "full", "a.txt")).To(BeARegularFile())
+	g.Expect(filepath.Join(destDir, "empty")).NotTo(BeAnExistingFile())
+}
+
+// TestEngine_WriteScript_PreserveEmptyDirs verifies that the script creates the planned
+// directories, parents first.
+func TestEngine_WriteScript_PreserveEmptyDirs(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+
+	g.Expect(os.MkdirAll(filepath.Join(sourceDir, "b", "c"), 0o750)).To(Succeed())
+	g.Expect(os.Mkdir(filepath.Join(sourceDir, "a"), 0o750)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.PreserveEmptyDirs = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	var script bytes.Buffer
+
+	_, err = engine.WriteScript(&script)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(script.String()).To(ContainSubstring(
+		"mkdir -p \"$dst\"/'a'\nmkdir -p \"$dst\"/'b'\nmkdir -p \"$dst\"/'b/c'\n"))
+}
@@ -4,6 +4,7 @@ package syncengine_test
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
@@ -194,3 +195,17 @@ func createTestFile(t *testing.T, dir, name, content string) {
 		t.Fatalf("Failed to create test file: %v", err)
 	}
 }
+
+// createNestedTestFile creates a test file at a relative path, creating parent directories as needed.
+func createNestedTestFile(t *testing.T, dir, relPath, content string) {
+	t.Helper()
+	path := filepath.Join(dir, relPath)
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+	err = os.WriteFile(path, []byte(content), 0o644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+}
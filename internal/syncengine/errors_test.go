@@ -0,0 +1,163 @@
+package syncengine_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestEngine_Sync_SourceVanishedIsClassified verifies that a file removed between analysis
+// and sync surfaces as ErrSourceVanished through the wrapped sync error.
+func TestEngine_Sync_SourceVanishedIsClassified(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "gone.txt", "content")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(os.Remove(filepath.Join(sourceDir, "gone.txt"))).To(Succeed())
+
+	err = engine.Sync()
+	g.Expect(errors.Is(err, syncengine.ErrFilesFailed)).To(BeTrue())
+	g.Expect(errors.Is(err, syncengine.ErrSourceVanished)).To(BeTrue())
+
+	var copyErr *syncengine.CopyError
+	g.Expect(errors.As(err, &copyErr)).To(BeTrue())
+	g.Expect(copyErr.Path).To(Equal(filepath.Join(sourceDir, "gone.txt")))
+
+	status := engine.GetStatus()
+	g.Expect(status.FailedFiles).To(Equal(1))
+	g.Expect(status.CancelledFiles).To(BeZero())
+}
+
+// TestEngine_Cancel_AnalysisErrorIsCancelled verifies that analysis cancellation matches ErrCancelled.
+func TestEngine_Cancel_AnalysisErrorIsCancelled(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine, err := syncengine.NewEngine(t.TempDir(), t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.Cancel()
+
+	err = engine.Analyze()
+	g.Expect(errors.Is(err, syncengine.ErrAnalysisCancelled)).To(BeTrue())
+	g.Expect(errors.Is(err, syncengine.ErrCancelled)).To(BeTrue())
+}
+
+// TestEngine_ContentMode_CopiesNewFiles verifies that a missing destination file is
+// recognized through the wrapped stat error instead of failing the copy.
+func TestEngine_ContentMode_CopiesNewFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "new.txt", "content")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+	g.Expect(filepath.Join(destDir, "new.txt")).To(BeAnExistingFile())
+}
+
+// TestEngine_SkippableErrors_OnlySeriousErrorsAbort verifies that errors in skippable categories
+// are recorded without counting toward the abort limit, while other categories still abort.
+func TestEngine_SkippableErrors_OnlySeriousErrorsAbort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		denied      int
+		broken      int
+		skippable   []fileops.ErrorCategory
+		expectAbort bool
+	}{
+		{"permission errors abort by default", 15, 0, nil, true},
+		{"skippable permission errors don't abort", 15, 0, []fileops.ErrorCategory{fileops.CategoryPermission}, false},
+		{"I/O errors still abort", 15, syncengine.MaxErrorsBeforeAbort, []fileops.ErrorCategory{fileops.CategoryPermission}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			sourceDir := t.TempDir()
+			destDir := t.TempDir()
+
+			createTestFile(t, sourceDir, "ok.txt", "ok")
+
+			for i := range tt.denied {
+				createTestFile(t, sourceDir, fmt.Sprintf("denied-%02d.txt", i), "denied")
+			}
+
+			for i := range tt.broken {
+				createTestFile(t, sourceDir, fmt.Sprintf("broken-%02d.txt", i), "broken")
+			}
+
+			engine, err := syncengine.NewEngine(sourceDir, destDir)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			engine.FileOps = fileops.NewDualFileOps(
+				failingOpenFS{filesystem.NewRealFileSystem()}, filesystem.NewRealFileSystem())
+			engine.AdaptiveMode = true
+			engine.SkippableErrors = tt.skippable
+
+			g.Expect(engine.Analyze()).To(Succeed())
+
+			err = engine.Sync()
+			g.Expect(err).Should(HaveOccurred())
+			g.Expect(errors.Is(err, syncengine.ErrSyncAborted)).To(Equal(tt.expectAbort))
+
+			if !tt.expectAbort {
+				status := engine.GetStatus()
+				g.Expect(status.Errors).To(HaveLen(tt.denied))
+				g.Expect(status.SkippedErrors).To(Equal(tt.denied))
+				g.Expect(filepath.Join(destDir, "ok.txt")).To(BeAnExistingFile())
+			}
+		})
+	}
+}
+
+// failingOpenFS fails to open "denied-" files with a permission error and "broken-" files with
+// an I/O error.
+type failingOpenFS struct {
+	*filesystem.RealFileSystem
+}
+
+func (f failingOpenFS) Open(path string) (filesystem.File, error) {
+	switch name := filepath.Base(path); {
+	case strings.HasPrefix(name, "denied-"):
+		return nil, &fs.PathError{Op: "open", Path: path, Err: syscall.EACCES}
+	case strings.HasPrefix(name, "broken-"):
+		return nil, &fs.PathError{Op: "open", Path: path, Err: syscall.EIO}
+	}
+
+	return f.RealFileSystem.Open(path)
+}
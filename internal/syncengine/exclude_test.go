@@ -0,0 +1,46 @@
+package syncengine_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_Exclude_LeavesOutMatchesAndKeepsThemAtDest verifies that excluded source files
+// aren't copied, even when the include pattern matches them, and that excluded destination
+// files aren't deleted as orphans.
+func TestEngine_Exclude_LeavesOutMatchesAndKeepsThemAtDest(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "main.go", "package main")
+	createTestFile(t, sourceDir, "build.log", "log")
+	createNestedTestFile(t, sourceDir, "web/node_modules/pkg/index.js", "module")
+	createNestedTestFile(t, sourceDir, "web/app.js", "app")
+	createTestFile(t, destDir, "old.tmp", "scratch")
+	createTestFile(t, destDir, "stale.txt", "orphan")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.FilePattern = "**/*.{go,log,js}"
+	engine.ExcludePatterns = []string{"**/node_modules/**", "*.{tmp,log}"}
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(filepath.Join(destDir, "main.go")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "web", "app.js")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "build.log")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "web", "node_modules", "pkg", "index.js")).NotTo(BeAnExistingFile())
+
+	// Excluded destination files are outside the sync; other orphans still go
+	g.Expect(filepath.Join(destDir, "old.tmp")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "stale.txt")).NotTo(BeAnExistingFile())
+}
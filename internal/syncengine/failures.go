@@ -0,0 +1,120 @@
+package syncengine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Exported constants.
+const (
+	// DefaultKnownFailureRuns is how many runs in a row a file must fail before it's a known
+	// persistent failure, when SkipKnownFailures is set without KnownFailureRuns
+	DefaultKnownFailureRuns = 3
+)
+
+// failureRecord is one path's history of failing in consecutive runs.
+type failureRecord struct {
+	Runs       int       `json:"runs"` // Consecutive runs the path failed in
+	LastError  string    `json:"last_error"`
+	LastFailed time.Time `json:"last_failed"`
+}
+
+// failureHistory is the on-disk record of paths that failed in recent runs.
+type failureHistory struct {
+	Files map[string]failureRecord `json:"files"` // Relative path -> failures
+}
+
+// known reports whether relPath failed in at least runs consecutive runs.
+func (h *failureHistory) known(relPath string, runs int) bool {
+	return h.Files[relPath].Runs >= runs
+}
+
+// update records one run: failed paths gain a run, and paths that are no longer failing are
+// forgotten. Paths in kept (e.g. skipped or deferred this run) keep their count untouched.
+func (h *failureHistory) update(failed map[string]string, kept map[string]bool, now time.Time) {
+	for relPath := range h.Files {
+		if _, stillFailing := failed[relPath]; !stillFailing && !kept[relPath] {
+			delete(h.Files, relPath)
+		}
+	}
+
+	for relPath, message := range failed {
+		record := h.Files[relPath]
+		record.Runs++
+		record.LastError = message
+		record.LastFailed = now
+		h.Files[relPath] = record
+	}
+}
+
+// DefaultFailureHistoryPath returns where per-file failure counts are kept for a source/dest pair.
+func DefaultFailureHistoryPath(source, dest string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	sum := sha256.Sum256([]byte(source + "\x00" + dest))
+
+	return filepath.Join(dir, "glowsync", "failures-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// loadFailureHistory reads the failure history, returning an empty one if there is none yet.
+func loadFailureHistory(path string) (*failureHistory, error) {
+	history := &failureHistory{Files: make(map[string]failureRecord)}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path is our own failure history file
+	if errors.Is(err, fs.ErrNotExist) {
+		return history, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read failure history: %w", err)
+	}
+
+	err = json.Unmarshal(data, history)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse failure history %s: %w", path, err)
+	}
+
+	if history.Files == nil {
+		history.Files = make(map[string]failureRecord)
+	}
+
+	return history, nil
+}
+
+// saveFailureHistory writes the failure history for the next run to consult.
+func saveFailureHistory(path string, history *failureHistory) error {
+	data, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("failed to encode failure history: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o750) //nolint:mnd // Standard directory permissions
+	if err != nil {
+		return fmt.Errorf("failed to create failure history directory: %w", err)
+	}
+
+	// Write to a temp file and rename so an interruption never leaves a torn history file
+	tmpPath := path + ".tmp"
+
+	err = os.WriteFile(tmpPath, data, 0o600) //nolint:mnd // Owner-only permissions
+	if err != nil {
+		return fmt.Errorf("failed to write failure history: %w", err)
+	}
+
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return fmt.Errorf("failed to save failure history: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestEngine_KnownFailures_MarkedThenSkipped verifies that a file failing in consecutive runs
+// becomes a known persistent failure, and that SkipKnownFailures then leaves it out of the plan.
+func TestEngine_KnownFailures_MarkedThenSkipped(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	historyPath := filepath.Join(t.TempDir(), "failures.json")
+
+	createTestFile(t, sourceDir, "ok.txt", "ok")
+	createTestFile(t, sourceDir, "denied-locked.txt", "denied")
+
+	run := func(skip bool) *syncengine.Status {
+		engine, err := syncengine.NewEngine(sourceDir, destDir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		engine.FileOps = fileops.NewDualFileOps(
+			failingOpenFS{filesystem.NewRealFileSystem()}, filesystem.NewRealFileSystem())
+		engine.ChangeType = config.Content
+		engine.KnownFailureRuns = 2
+		engine.SkipKnownFailures = skip
+		engine.FailureHistoryPath = historyPath
+
+		g.Expect(engine.Analyze()).To(Succeed())
+
+		_ = engine.Sync()
+
+		return engine.GetStatus()
+	}
+
+	status := run(false)
+	g.Expect(status.Errors).To(HaveLen(1))
+	g.Expect(status.Errors[0].KnownFailure).To(BeFalse())
+
+	status = run(false)
+	g.Expect(status.Errors).To(HaveLen(1))
+	g.Expect(status.Errors[0].KnownFailure).To(BeFalse())
+
+	// Two failed runs in a row make it a known persistent failure
+	status = run(false)
+	g.Expect(status.Errors).To(HaveLen(1))
+	g.Expect(status.Errors[0].KnownFailure).To(BeTrue())
+
+	status = run(true)
+	g.Expect(status.Errors).To(BeEmpty())
+	g.Expect(status.KnownFailuresSkipped).To(ConsistOf("denied-locked.txt"))
+	g.Expect(status.TotalFiles).To(BeZero())
+}
+
+// TestEngine_KnownFailures_ForgottenOnceSynced verifies that a file that syncs again loses its
+// failure count, so a later failure is reported as new.
+func TestEngine_KnownFailures_ForgottenOnceSynced(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	historyPath := filepath.Join(t.TempDir(), "failures.json")
+
+	createTestFile(t, sourceDir, "denied-once.txt", "content")
+
+	run := func(failing bool) *syncengine.Status {
+		engine, err := syncengine.NewEngine(sourceDir, destDir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		if failing {
+			engine.FileOps = fileops.NewDualFileOps(
+				failingOpenFS{filesystem.NewRealFileSystem()}, filesystem.NewRealFileSystem())
+		}
+
+		engine.ChangeType = config.Content
+		engine.KnownFailureRuns = 1
+		engine.FailureHistoryPath = historyPath
+
+		g.Expect(engine.Analyze()).To(Succeed())
+
+		_ = engine.Sync()
+
+		return engine.GetStatus()
+	}
+
+	g.Expect(run(true).Errors).To(HaveLen(1))
+	g.Expect(run(false).Errors).To(BeEmpty())
+	g.Expect(os.Remove(filepath.Join(destDir, "denied-once.txt"))).To(Succeed())
+
+	status := run(true)
+	g.Expect(status.Errors).To(HaveLen(1))
+	g.Expect(status.Errors[0].KnownFailure).To(BeFalse())
+}
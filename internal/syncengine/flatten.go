@@ -0,0 +1,230 @@
+package syncengine
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// FlattenCollision is a set of source files Flatten would give the same destination name, and
+// which of them CollisionPolicy synced, under what names.
+type FlattenCollision struct {
+	Name  string          // The name they share
+	Paths []string        // Sorted
+	Kept  []SanitizedName // The paths synced, and the names they were synced under
+}
+
+// String describes the collision and its outcome, e.g.
+// "a.jpg: x/a.jpg, y/a.jpg → kept x/a.jpg, y/a.jpg as a-1.jpg".
+func (c FlattenCollision) String() string {
+	kept := make([]string, 0, len(c.Kept))
+	for _, file := range c.Kept {
+		if file.DestPath == c.Name {
+			kept = append(kept, file.SourcePath)
+		} else {
+			kept = append(kept, file.SourcePath+" as "+file.DestPath)
+		}
+	}
+
+	return c.Name + ": " + strings.Join(c.Paths, ", ") + " → kept " + strings.Join(kept, ", ")
+}
+
+// applyFlatten maps every source file to its name alone at the destination root when Flatten is
+// set, resolving files that would share a name by CollisionPolicy. Source directories are left
+// out, as nothing is synced into them, and dest entries are re-keyed by the source path that
+// syncs to them, as applyNameRules does for sanitized names.
+func (e *Engine) applyFlatten(sourceFiles, destFiles map[string]*fileops.FileInfo) {
+	e.Status.mu.Lock()
+	e.Status.FlattenCollisions = nil
+	e.Status.mu.Unlock()
+
+	if !e.Flatten {
+		return
+	}
+
+	// Sorted so collisions resolve the same way on every run
+	relPaths := make([]string, 0, len(sourceFiles))
+
+	for relPath, srcFile := range sourceFiles {
+		if srcFile.IsDir {
+			delete(sourceFiles, relPath)
+			continue
+		}
+
+		relPaths = append(relPaths, relPath)
+	}
+
+	sort.Strings(relPaths)
+
+	// Names are taken from the destination path, so a sanitized name stays sanitized
+	groups := make(map[string][]string)
+
+	for _, relPath := range relPaths {
+		destPath := relPath
+		if destName, ok := e.destNames[relPath]; ok {
+			destPath = destName
+		}
+
+		name := filepath.Base(destPath)
+		groups[name] = append(groups[name], relPath)
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	destNames := make(map[string]string, len(relPaths))
+	claimed := make(map[string]bool, len(relPaths))
+	collisions := make([]FlattenCollision, 0)
+
+	for _, name := range names {
+		paths := groups[name]
+		if len(paths) == 1 {
+			destNames[paths[0]] = name
+			claimed[name] = true
+
+			continue
+		}
+
+		collision := FlattenCollision{Name: name, Paths: paths}
+
+		switch e.CollisionPolicy {
+		case config.CollisionSkip:
+			collision.Kept = []SanitizedName{{SourcePath: paths[0], DestPath: name}}
+		case config.CollisionOverwriteNewest:
+			newest := paths[0]
+			for _, relPath := range paths[1:] {
+				if sourceFiles[relPath].ModTime.After(sourceFiles[newest].ModTime) {
+					newest = relPath
+				}
+			}
+
+			collision.Kept = []SanitizedName{{SourcePath: newest, DestPath: name}}
+		case config.CollisionRename:
+			collision.Kept = numberCollidingNames(name, paths, groups, claimed)
+		}
+
+		for _, kept := range collision.Kept {
+			destNames[kept.SourcePath] = kept.DestPath
+			claimed[kept.DestPath] = true
+		}
+
+		for _, relPath := range paths {
+			if _, ok := destNames[relPath]; !ok {
+				delete(sourceFiles, relPath)
+			}
+		}
+
+		collisions = append(collisions, collision)
+		e.logAnalysis("  ! Flatten collision: " + collision.String())
+	}
+
+	e.rekeyFlattenedDest(sourceFiles, destFiles, destNames)
+
+	e.Status.mu.Lock()
+	e.Status.FlattenCollisions = collisions
+	e.Status.mu.Unlock()
+
+	e.logAnalysis(fmt.Sprintf("Flattening %d files into the destination root", len(destNames)))
+
+	if len(collisions) > 0 {
+		e.logAnalysis(fmt.Sprintf("⚠ %d names are shared by several source files (--on-collision %s)",
+			len(collisions), e.collisionPolicyName()))
+	}
+}
+
+// collisionPolicyName returns CollisionPolicy as given on the command line.
+func (e *Engine) collisionPolicyName() string {
+	if e.CollisionPolicy == config.CollisionRename {
+		return "rename"
+	}
+
+	return string(e.CollisionPolicy)
+}
+
+// deletesOrphans reports whether destination files missing from the source are deleted. A
+// flattened sync can't tell files it put in the destination from ones that were already there,
+// or came from other sources consolidated into it, so it never deletes; nor does a move, as the
+// files it moved are missing from the source by design.
+func (e *Engine) deletesOrphans() bool {
+	return e.DeleteOrphans && !e.Flatten && !e.Move
+}
+
+// rekeyFlattenedDest re-keys dest entries by the source path syncing to them. Entries no source
+// syncs to stay as kept orphans, except those at a path that is also a source path (left by a
+// sync that didn't flatten), which would otherwise be compared against that source file.
+func (e *Engine) rekeyFlattenedDest(sourceFiles, destFiles map[string]*fileops.FileInfo, destNames map[string]string) {
+	// Where each entry actually is, as applyNameRules keys sanitized ones by their source path
+	atDest := make(map[string]*fileops.FileInfo, len(destFiles))
+
+	for key, dstFile := range destFiles {
+		if destName, ok := e.destNames[key]; ok {
+			atDest[destName] = dstFile
+		} else {
+			atDest[key] = dstFile
+		}
+	}
+
+	clear(destFiles)
+
+	for relPath, destName := range destNames {
+		if dstFile, ok := atDest[destName]; ok {
+			destFiles[relPath] = dstFile
+			delete(atDest, destName)
+		}
+	}
+
+	for destPath, dstFile := range atDest {
+		if _, ok := sourceFiles[destPath]; !ok {
+			destFiles[destPath] = dstFile
+		}
+	}
+
+	e.destNames = make(map[string]string, len(destNames))
+
+	for relPath, destName := range destNames {
+		if destName != relPath {
+			e.destNames[relPath] = destName
+		}
+	}
+}
+
+// numberCollidingNames keeps name for the first of paths and numbers the names of the others
+// (photo-1.jpg, photo-2.jpg, ...), skipping numbers that would take another file's name.
+//
+//nolint:lll // Signature carries the names already in use
+func numberCollidingNames(name string, paths []string, groups map[string][]string, claimed map[string]bool) []SanitizedName {
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+
+	// A dotfile like .bashrc is all stem
+	if stem == "" {
+		stem, ext = name, ""
+	}
+
+	kept := []SanitizedName{{SourcePath: paths[0], DestPath: name}}
+	number := 0
+
+	for _, relPath := range paths[1:] {
+		for {
+			number++
+
+			numbered := fmt.Sprintf("%s-%d%s", stem, number, ext)
+			if _, taken := groups[numbered]; !taken && !claimed[numbered] {
+				kept = append(kept, SanitizedName{SourcePath: relPath, DestPath: numbered})
+				claimed[numbered] = true
+
+				break
+			}
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,156 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_Flatten_RenamesCollisions verifies that flattening copies files from every source
+// directory into the destination root, numbering names shared by files in different
+// directories, and that a second run finds everything synced.
+func TestEngine_Flatten_RenamesCollisions(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createNestedTestFile(t, sourceDir, "2023/IMG_1.jpg", "first")
+	createNestedTestFile(t, sourceDir, "2024/IMG_1.jpg", "second")
+	createNestedTestFile(t, sourceDir, "2024/trip/IMG_2.jpg", "third")
+
+	newEngine := func() *syncengine.Engine {
+		engine, err := syncengine.NewEngine(sourceDir, destDir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		engine.ChangeType = config.FluctuatingCount
+		engine.Flatten = true
+
+		return engine
+	}
+
+	engine := newEngine()
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(readDir(t, destDir)).To(Equal(map[string]string{
+		"IMG_1.jpg":   "first",
+		"IMG_1-1.jpg": "second",
+		"IMG_2.jpg":   "third",
+	}))
+
+	collisions := engine.GetStatus().FlattenCollisions
+	g.Expect(collisions).To(HaveLen(1))
+	g.Expect(collisions[0].String()).To(Equal(
+		"IMG_1.jpg: 2023/IMG_1.jpg, 2024/IMG_1.jpg → kept 2023/IMG_1.jpg, 2024/IMG_1.jpg as IMG_1-1.jpg"))
+
+	rerun := newEngine()
+	g.Expect(rerun.Analyze()).To(Succeed())
+	g.Expect(rerun.Status.FilesToSync).To(BeEmpty())
+}
+
+// TestEngine_Flatten_CollisionPolicies verifies that skip syncs the first colliding file by path
+// and overwrite-newest the most recently modified one.
+func TestEngine_Flatten_CollisionPolicies(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		policy config.CollisionPolicy
+		want   string
+	}{
+		{policy: config.CollisionSkip, want: "older"},
+		{policy: config.CollisionOverwriteNewest, want: "newer"},
+	} {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			sourceDir := t.TempDir()
+			destDir := t.TempDir()
+
+			createNestedTestFile(t, sourceDir, "a/notes.txt", "older")
+			createNestedTestFile(t, sourceDir, "b/notes.txt", "newer")
+
+			past := time.Now().Add(-time.Hour)
+			g.Expect(os.Chtimes(filepath.Join(sourceDir, "a", "notes.txt"), past, past)).To(Succeed())
+
+			engine, err := syncengine.NewEngine(sourceDir, destDir)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			engine.ChangeType = config.FluctuatingCount
+			engine.Flatten = true
+			engine.CollisionPolicy = tc.policy
+
+			g.Expect(engine.Analyze()).To(Succeed())
+			g.Expect(engine.Sync()).To(Succeed())
+
+			g.Expect(readDir(t, destDir)).To(Equal(map[string]string{"notes.txt": tc.want}))
+		})
+	}
+}
+
+// TestEngine_Flatten_KeepsOrphans verifies that a flattened sync leaves files it didn't copy in
+// place, including a copy left in a subdirectory by an earlier sync that didn't flatten.
+func TestEngine_Flatten_KeepsOrphans(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createNestedTestFile(t, sourceDir, "docs/report.txt", "new")
+	createTestFile(t, destDir, "other-source.txt", "kept")
+	createNestedTestFile(t, destDir, "docs/report.txt", "old")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.Flatten = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(readDir(t, destDir)).To(Equal(map[string]string{
+		"report.txt":       "new",
+		"other-source.txt": "kept",
+	}))
+
+	nested, err := os.ReadFile(filepath.Join(destDir, "docs", "report.txt"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(nested)).To(Equal("old"))
+}
+
+// readDir returns the names and contents of the regular files directly in dir.
+func readDir(t *testing.T, dir string) map[string]string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", dir, err)
+	}
+
+	files := make(map[string]string)
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", entry.Name(), err)
+		}
+
+		files[entry.Name()] = string(content)
+	}
+
+	return files
+}
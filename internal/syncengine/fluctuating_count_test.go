@@ -0,0 +1,55 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_FluctuatingCount_ComparesSizes verifies that fluctuating-count mode re-copies files
+// whose sizes differ, ignores same-size files with different modtimes, copies missing files and
+// deletes orphans.
+func TestEngine_FluctuatingCount_ComparesSizes(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "same.txt", "unchanged")
+	createTestFile(t, destDir, "same.txt", "unchanged")
+	createTestFile(t, sourceDir, "grown.txt", "longer contents")
+	createTestFile(t, destDir, "grown.txt", "short")
+	createTestFile(t, sourceDir, "touched.txt", "new text")
+	createTestFile(t, destDir, "touched.txt", "old text")
+	createTestFile(t, sourceDir, "added.txt", "added")
+	createTestFile(t, destDir, "removed.txt", "removed")
+
+	later := time.Now().Add(time.Hour)
+	g.Expect(os.Chtimes(filepath.Join(sourceDir, "touched.txt"), later, later)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	g.Expect(engine.PlanEntries()).To(ConsistOf(
+		syncengine.PlanEntry{RelativePath: "grown.txt", Size: 15, Action: syncengine.ActionOverwrite},
+		syncengine.PlanEntry{RelativePath: "added.txt", Size: 5, Action: syncengine.ActionCreate},
+		syncengine.PlanEntry{RelativePath: "removed.txt", Size: 7, Action: syncengine.ActionDelete},
+	))
+
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(readFile(t, destDir, "grown.txt")).To(Equal("longer contents"))
+	g.Expect(readFile(t, destDir, "touched.txt")).To(Equal("old text"))
+	g.Expect(filepath.Join(destDir, "removed.txt")).NotTo(BeAnExistingFile())
+}
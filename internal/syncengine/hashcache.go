@@ -0,0 +1,233 @@
+package syncengine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// errHashCacheAlgorithm is returned for a hash cache made with a different hash algorithm.
+var errHashCacheAlgorithm = errors.New("hash cache is for another algorithm")
+
+// hashCacheEntry is a source file's hash, valid while its size and modtime are unchanged.
+type hashCacheEntry struct {
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mod_time"` // Unix nanoseconds
+	Hash    string `json:"hash"`
+}
+
+// hashCache is the on-disk record of source file hashes from earlier runs. It is safe for
+// concurrent use by workers.
+type hashCache struct {
+	mu        sync.Mutex
+	Algorithm fileops.HashAlgorithm     `json:"algorithm"` // What made the hashes (empty = SHA-256)
+	Files     map[string]hashCacheEntry `json:"files"`     // Relative path -> hash
+	dirty     bool
+}
+
+// lookup returns relPath's cached hash if the file still has the size and modtime it was
+// hashed with. A nil cache holds nothing.
+func (c *hashCache) lookup(relPath string, size int64, modTime time.Time) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.Files[relPath]
+	if !ok || entry.Size != size || entry.ModTime != modTime.UnixNano() {
+		return "", false
+	}
+
+	return entry.Hash, true
+}
+
+// store records relPath's hash, replacing any entry for an earlier version of the file.
+func (c *hashCache) store(relPath string, size int64, modTime time.Time, hash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Files[relPath] = hashCacheEntry{Size: size, ModTime: modTime.UnixNano(), Hash: hash}
+	c.dirty = true
+}
+
+// DefaultHashCachePath returns where source file hashes are cached for a source.
+func DefaultHashCachePath(source string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	sum := sha256.Sum256([]byte(source))
+
+	return filepath.Join(dir, "glowsync", "hashes-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// loadHashCache reads the hash cache of algorithm's hashes, returning an empty one if there is
+// none yet. A cache that can't be read or parsed, or holds another algorithm's hashes, is also
+// replaced by an empty one, and the error says why.
+func loadHashCache(path string, algorithm fileops.HashAlgorithm) (*hashCache, error) {
+	cache := &hashCache{Algorithm: algorithm, Files: make(map[string]hashCacheEntry)}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path is our own hash cache file
+	if errors.Is(err, fs.ErrNotExist) {
+		return cache, nil
+	}
+
+	if err != nil {
+		return cache, fmt.Errorf("failed to read hash cache: %w", err)
+	}
+
+	var saved hashCache
+
+	err = json.Unmarshal(data, &saved)
+	if err != nil {
+		return cache, fmt.Errorf("failed to parse hash cache %s: %w", path, err)
+	}
+
+	if saved.Algorithm == "" {
+		saved.Algorithm = fileops.HashSHA256
+	}
+
+	if saved.Algorithm != algorithm {
+		return cache, fmt.Errorf("%w: %s holds %s hashes, not %s", errHashCacheAlgorithm, path, saved.Algorithm, algorithm)
+	}
+
+	if saved.Files != nil {
+		cache.Files = saved.Files
+	}
+
+	return cache, nil
+}
+
+// save writes the hash cache if anything was added to it, keeping only entries for paths in
+// keep (nil = keep every entry) so files gone from the source don't pile up.
+func (c *hashCache) save(path string, keep func(relPath string) bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.dirty {
+		return nil
+	}
+
+	if keep != nil {
+		for relPath := range c.Files {
+			if !keep(relPath) {
+				delete(c.Files, relPath)
+			}
+		}
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode hash cache: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o750) //nolint:mnd // Standard directory permissions
+	if err != nil {
+		return fmt.Errorf("failed to create hash cache directory: %w", err)
+	}
+
+	// Write to a temp file and rename so an interruption never leaves a torn cache file
+	tmpPath := path + ".tmp"
+
+	err = os.WriteFile(tmpPath, data, 0o600) //nolint:mnd // Owner-only permissions
+	if err != nil {
+		return fmt.Errorf("failed to write hash cache: %w", err)
+	}
+
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return fmt.Errorf("failed to save hash cache: %w", err)
+	}
+
+	c.dirty = false
+
+	return nil
+}
+
+// hashAlgorithm returns the algorithm files are hashed with: HashAlgorithm, or SHA-256 if it's
+// unset or CAStore names content by hash.
+func (e *Engine) hashAlgorithm() fileops.HashAlgorithm {
+	if e.HashAlgorithm == "" || e.CAStore {
+		return fileops.HashSHA256
+	}
+
+	return fileops.HashAlgorithm(e.HashAlgorithm)
+}
+
+// hashCachePath returns the configured hash cache path or the default for this source.
+func (e *Engine) hashCachePath() string {
+	if e.HashCachePath != "" {
+		return e.HashCachePath
+	}
+
+	return DefaultHashCachePath(e.originalSourcePath())
+}
+
+// sourceHash returns the hash of the source file at relPath, from the hash cache if the file's
+// size and modtime match the cached entry, and otherwise hashing it and caching the result.
+func (e *Engine) sourceHash(relPath string, size int64, modTime time.Time) (string, error) {
+	srcPath := filepath.Join(e.SourcePath, relPath)
+
+	if !e.HashCache {
+		return e.FileOps.ComputeFileHash(srcPath) //nolint:wrapcheck // Callers add the relative path
+	}
+
+	e.hashCacheOnce.Do(func() {
+		var err error
+
+		e.hashCache, err = loadHashCache(e.hashCachePath(), e.FileOps.HashAlgorithm)
+		if err != nil {
+			e.logWarn("Discarding hash cache", "error", err)
+		}
+	})
+
+	if hash, ok := e.hashCache.lookup(relPath, size, modTime); ok {
+		e.Status.mu.Lock()
+		e.Status.CachedHashes++
+		e.Status.mu.Unlock()
+
+		return hash, nil
+	}
+
+	hash, err := e.FileOps.ComputeFileHash(srcPath)
+	if err != nil {
+		return "", err //nolint:wrapcheck // Callers add the relative path
+	}
+
+	e.hashCache.store(relPath, size, modTime, hash)
+
+	return hash, nil
+}
+
+// saveHashCache saves hashes computed this run for the next one; a failure only costs rehashing.
+func (e *Engine) saveHashCache() {
+	if e.hashCache == nil {
+		return
+	}
+
+	var keep func(string) bool
+
+	if sourceFiles := e.analysisSourceFiles; sourceFiles != nil {
+		keep = func(relPath string) bool {
+			_, ok := sourceFiles[relPath]
+			return ok
+		}
+	}
+
+	err := e.hashCache.save(e.hashCachePath(), keep)
+	if err != nil {
+		e.logWarn(err.Error())
+	}
+}
@@ -0,0 +1,165 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_HashCache_ReusesUnchangedSourceHashes verifies that a second run reuses the
+// source hashes of unchanged files, and hashes a file again once its modtime changes.
+func TestEngine_HashCache_ReusesUnchangedSourceHashes(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	cachePath := filepath.Join(t.TempDir(), "hashes.json")
+
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		createTestFile(t, sourceDir, name, "contents of "+name)
+		createTestFile(t, destDir, name, "contents of "+name)
+	}
+
+	analyze := func() *syncengine.Engine {
+		engine, err := syncengine.NewEngine(sourceDir, destDir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		engine.ChangeType = config.DeviousContent
+		engine.HashCache = true
+		engine.HashCachePath = cachePath
+
+		g.Expect(engine.Analyze()).To(Succeed())
+
+		return engine
+	}
+
+	g.Expect(analyze().GetStatus().CachedHashes).To(Equal(0))
+	g.Expect(cachePath).To(BeAnExistingFile())
+	g.Expect(analyze().GetStatus().CachedHashes).To(Equal(3))
+
+	// Same size and a new modtime: the cached hash no longer applies
+	createTestFile(t, sourceDir, "b.txt", "CONTENTS OF b.txt")
+
+	later := time.Now().Add(time.Hour)
+	g.Expect(os.Chtimes(filepath.Join(sourceDir, "b.txt"), later, later)).To(Succeed())
+
+	engine := analyze()
+	g.Expect(engine.GetStatus().CachedHashes).To(Equal(2))
+	g.Expect(engine.PlanEntries()).To(ConsistOf(
+		syncengine.PlanEntry{RelativePath: "b.txt", Size: 17, Action: syncengine.ActionOverwrite},
+	))
+}
+
+// TestEngine_HashCache_SwitchingAlgorithmRehashes verifies that hashes cached with one algorithm
+// aren't reused with another, and that the cache then holds the new algorithm's hashes.
+func TestEngine_HashCache_SwitchingAlgorithmRehashes(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	cachePath := filepath.Join(t.TempDir(), "hashes.json")
+
+	for _, name := range []string{"a.txt", "b.txt"} {
+		createTestFile(t, sourceDir, name, "contents of "+name)
+		createTestFile(t, destDir, name, "contents of "+name)
+	}
+
+	analyze := func(algorithm config.HashAlgorithm) *syncengine.Engine {
+		engine, err := syncengine.NewEngine(sourceDir, destDir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		engine.ChangeType = config.DeviousContent
+		engine.HashCache = true
+		engine.HashCachePath = cachePath
+		engine.HashAlgorithm = algorithm
+
+		g.Expect(engine.Analyze()).To(Succeed())
+
+		return engine
+	}
+
+	g.Expect(analyze(config.HashSHA256).GetStatus().CachedHashes).To(Equal(0))
+	g.Expect(analyze(config.HashSHA256).GetStatus().CachedHashes).To(Equal(2))
+
+	engine := analyze(config.HashBLAKE3)
+	g.Expect(engine.GetStatus().CachedHashes).To(Equal(0))
+	g.Expect(engine.PlanEntries()).To(BeEmpty())
+
+	g.Expect(analyze(config.HashBLAKE3).GetStatus().CachedHashes).To(Equal(2))
+	g.Expect(analyze(config.HashSHA256).GetStatus().CachedHashes).To(Equal(0))
+}
+
+// TestEngine_HashCache_DiscardsCorruptCache verifies that an unreadable cache is replaced
+// rather than failing the run.
+func TestEngine_HashCache_DiscardsCorruptCache(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	cachePath := filepath.Join(t.TempDir(), "hashes.json")
+
+	createTestFile(t, sourceDir, "a.txt", "alpha")
+	createTestFile(t, destDir, "a.txt", "ALPHA")
+	g.Expect(os.WriteFile(cachePath, []byte(`{"files": {"a.txt": tru`), 0o600)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.DeviousContent
+	engine.HashCache = true
+	engine.HashCachePath = cachePath
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.GetStatus().TotalFiles).To(Equal(1))
+
+	data, err := os.ReadFile(cachePath)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`"a.txt"`))
+}
+
+// TestEngine_HashCache_ConcurrentWorkers verifies that content-mode workers share the cache
+// safely and save what they hashed.
+func TestEngine_HashCache_ConcurrentWorkers(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	cachePath := filepath.Join(t.TempDir(), "hashes.json")
+	later := time.Now().Add(time.Hour)
+
+	names := []string{"a.txt", "b.txt", "c.txt", "d.txt", "e.txt", "f.txt"}
+	for _, name := range names {
+		createTestFile(t, sourceDir, name, "same "+name)
+		createTestFile(t, destDir, name, "same "+name)
+		g.Expect(os.Chtimes(filepath.Join(destDir, name), later, later)).To(Succeed())
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.Workers = 4
+	engine.AdaptiveMode = false
+	engine.HashCache = true
+	engine.HashCachePath = cachePath
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	data, err := os.ReadFile(cachePath)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	for _, name := range names {
+		g.Expect(string(data)).To(ContainSubstring(`"` + name + `"`))
+	}
+}
@@ -0,0 +1,108 @@
+package syncengine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Exported constants.
+const (
+	// RunSpikeFactor is how many times the previous run's files to sync counts as a spike
+	RunSpikeFactor = 10
+	// RunSpikeMinFiles is the fewest files to sync that can count as a spike, so small trees stay quiet
+	RunSpikeMinFiles = 100
+)
+
+// RunSummary is one run's statistics, persisted so the next run can report how things changed.
+type RunSummary struct {
+	Time          time.Time `json:"time"`
+	SourceFiles   int       `json:"source_files"`
+	SourceBytes   int64     `json:"source_bytes"`
+	FilesToSync   int       `json:"files_to_sync"`
+	BytesToSync   int64     `json:"bytes_to_sync"`
+	FilesToDelete int       `json:"files_to_delete"`
+}
+
+// DefaultRunHistoryPath returns where the previous run's summary is kept for a source/dest pair.
+func DefaultRunHistoryPath(source, dest string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	sum := sha256.Sum256([]byte(source + "\x00" + dest))
+
+	return filepath.Join(dir, "glowsync", "history-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// loadRunSummary reads the previous run's summary, returning nil if there is none yet.
+func loadRunSummary(path string) (*RunSummary, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is our own history file
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil //nolint:nilnil // No previous run is a valid outcome
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run history: %w", err)
+	}
+
+	var summary RunSummary
+
+	err = json.Unmarshal(data, &summary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse run history %s: %w", path, err)
+	}
+
+	return &summary, nil
+}
+
+// runDeltaWarning describes a change since the previous run that suggests something is wrong,
+// such as the source suddenly being empty or far more files needing a sync than usual.
+// Returns "" if nothing looks off.
+func runDeltaWarning(prev, cur RunSummary) string {
+	switch {
+	case prev.SourceFiles > 0 && cur.SourceFiles == 0:
+		return fmt.Sprintf("source is empty but had %d files last run; is it mounted?", prev.SourceFiles)
+	case cur.SourceFiles < prev.SourceFiles/2:
+		return fmt.Sprintf("source shrank from %d to %d files since last run", prev.SourceFiles, cur.SourceFiles)
+	case cur.FilesToSync >= RunSpikeMinFiles && cur.FilesToSync > prev.FilesToSync*RunSpikeFactor:
+		return fmt.Sprintf("%d files to sync, up from %d last run", cur.FilesToSync, prev.FilesToSync)
+	}
+
+	return ""
+}
+
+// saveRunSummary writes this run's summary for the next run to compare against.
+func saveRunSummary(path string, summary RunSummary) error {
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("failed to encode run history: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o750) //nolint:mnd // Standard directory permissions
+	if err != nil {
+		return fmt.Errorf("failed to create run history directory: %w", err)
+	}
+
+	// Write to a temp file and rename so an interruption never leaves a torn history file
+	tmpPath := path + ".tmp"
+
+	err = os.WriteFile(tmpPath, data, 0o600) //nolint:mnd // Owner-only permissions
+	if err != nil {
+		return fmt.Errorf("failed to write run history: %w", err)
+	}
+
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return fmt.Errorf("failed to save run history: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_CompareRuns_LoadsPreviousRun verifies that a completed sync records its statistics
+// and the next run loads them for comparison.
+func TestEngine_CompareRuns_LoadsPreviousRun(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	historyPath := filepath.Join(t.TempDir(), "history.json")
+
+	createTestFile(t, sourceDir, "a.txt", "a")
+	createTestFile(t, sourceDir, "b.txt", "b")
+
+	first := newCompareRunsEngine(t, sourceDir, destDir, historyPath)
+	g.Expect(first.Analyze()).To(Succeed())
+	g.Expect(first.GetStatus().PreviousRun).To(BeNil())
+	g.Expect(first.Sync()).To(Succeed())
+
+	createTestFile(t, sourceDir, "c.txt", "c")
+
+	second := newCompareRunsEngine(t, sourceDir, destDir, historyPath)
+	g.Expect(second.Analyze()).To(Succeed())
+
+	status := second.GetStatus()
+	g.Expect(status.PreviousRun).NotTo(BeNil())
+	g.Expect(status.PreviousRun.SourceFiles).To(Equal(2))
+	g.Expect(status.PreviousRun.FilesToSync).To(Equal(2))
+	g.Expect(status.TotalFilesInSource).To(Equal(3))
+	g.Expect(status.RunDeltaWarning).To(BeEmpty())
+}
+
+// TestEngine_CompareRuns_WarnsWhenSourceEmpties verifies that a source which suddenly has no
+// files, as when it isn't mounted, is flagged before anything is deleted.
+func TestEngine_CompareRuns_WarnsWhenSourceEmpties(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	historyPath := filepath.Join(t.TempDir(), "history.json")
+
+	createTestFile(t, sourceDir, "a.txt", "a")
+	createTestFile(t, sourceDir, "b.txt", "b")
+
+	first := newCompareRunsEngine(t, sourceDir, destDir, historyPath)
+	g.Expect(first.Analyze()).To(Succeed())
+	g.Expect(first.Sync()).To(Succeed())
+
+	g.Expect(os.Remove(filepath.Join(sourceDir, "a.txt"))).To(Succeed())
+	g.Expect(os.Remove(filepath.Join(sourceDir, "b.txt"))).To(Succeed())
+
+	second := newCompareRunsEngine(t, sourceDir, destDir, historyPath)
+	g.Expect(second.Analyze()).To(Succeed())
+	g.Expect(second.GetStatus().RunDeltaWarning).To(ContainSubstring("source is empty but had 2 files"))
+}
+
+func newCompareRunsEngine(t *testing.T, sourceDir, destDir, historyPath string) *syncengine.Engine {
+	t.Helper()
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	engine.ChangeType = config.Content
+	engine.CompareRuns = true
+	engine.RunHistoryPath = historyPath
+	engine.Workers = 1
+
+	return engine
+}
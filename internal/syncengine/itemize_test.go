@@ -0,0 +1,60 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_Itemize_WritesRsyncStyleChanges verifies that new files, size changes, time changes
+// and deletions are recorded with rsync's --itemize-changes flags.
+func TestEngine_Itemize_WritesRsyncStyleChanges(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	itemizePath := filepath.Join(t.TempDir(), "itemize.txt")
+
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	createTestFile(t, sourceDir, "new.txt", "new")
+	createTestFile(t, sourceDir, "grown.txt", "grown content")
+	createTestFile(t, destDir, "grown.txt", "grown")
+	createTestFile(t, sourceDir, "touched.txt", "touched")
+	createTestFile(t, destDir, "touched.txt", "TOUCHED")
+	createTestFile(t, destDir, "orphan.txt", "orphan")
+
+	for _, dir := range []string{sourceDir, destDir} {
+		g.Expect(os.Chtimes(filepath.Join(dir, "grown.txt"), modTime, modTime)).To(Succeed())
+	}
+
+	g.Expect(os.Chtimes(filepath.Join(destDir, "touched.txt"), modTime, modTime)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.Workers = 1
+
+	g.Expect(engine.EnableItemizeLog(itemizePath)).To(Succeed())
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+	engine.CloseLog()
+
+	data, err := os.ReadFile(itemizePath)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(strings.Split(strings.TrimSpace(string(data)), "\n")).To(ConsistOf(
+		">f+++++++++ new.txt",
+		">f.s....... grown.txt",
+		">f..t...... touched.txt",
+		"*deleting   orphan.txt",
+	))
+}
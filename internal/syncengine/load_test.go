@@ -0,0 +1,70 @@
+package syncengine_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// TestHillClimbingScalingDecision_ScalesDownUnderLoad verifies that a load average above
+// MaxLoadAverage removes a worker even when throughput improved.
+func TestHillClimbingScalingDecision_ScalesDownUnderLoad(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine, err := syncengine.NewEngine(t.TempDir(), t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.MaxLoadAverage = 2.0
+	engine.LoadSampler = func() (float64, error) { return 8.0, nil }
+	engine.SetDesiredWorkers(3)
+
+	g.Expect(engine.SampleLoad()).To(Succeed())
+
+	workerControl := make(chan bool, 10)
+	state := &syncengine.AdaptiveScalingState{
+		LastThroughput: 5.0 * 1024.0 * 1024.0,
+		LastAdjustment: 1,
+		LastCheckTime:  time.Now(),
+	}
+
+	// Throughput improved by 20%, which would normally add a worker
+	newState := engine.HillClimbingScalingDecision(state, 6.0*1024.0*1024.0, 3, 10, workerControl)
+
+	g.Expect(workerControl).NotTo(Receive())
+	g.Expect(newState.LastAdjustment).To(Equal(-1))
+	g.Expect(engine.GetDesiredWorkers()).To(Equal(int32(2)))
+	g.Expect(engine.GetStatus().LoadAverage).To(Equal(8.0))
+	g.Expect(engine.GetStatus().LoadThrottled).To(BeTrue())
+}
+
+// TestEngine_MaxLoadAverage_UnsupportedPlatform verifies that a platform without a load average
+// syncs normally and reports that the limit had no effect.
+func TestEngine_MaxLoadAverage_UnsupportedPlatform(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "file.txt", "content")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.AdaptiveMode = true
+	engine.MaxLoadAverage = 2.0
+	engine.LoadSampler = func() (float64, error) { return 0, fileops.ErrLoadAverageNotSupported }
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.LoadAverageUnsupported).To(BeTrue())
+	g.Expect(status.LoadThrottled).To(BeFalse())
+	g.Expect(status.ProcessedFiles).To(Equal(1))
+}
@@ -0,0 +1,56 @@
+package syncengine
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/joe/copy-files/internal/config"
+)
+
+// newLogger returns a logger writing records at level and above to w, as JSON lines or as
+// key=value text.
+func newLogger(w io.Writer, level config.LogLevel, format config.LogFormat) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level.SlogLevel()}
+
+	if format == config.LogFormatJSON {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// logAt writes a record to the log file (if enabled). args are slog key/value pairs; the keys
+// used across the engine are file, size, bytes, worker and phase.
+func (e *Engine) logAt(level slog.Level, message string, args ...any) {
+	logger := e.logger.Load()
+	if logger == nil {
+		return
+	}
+
+	logger.Log(context.Background(), level, message, args...)
+}
+
+// logDebug writes a debug record: per-file and per-evaluation detail, dropped at info level.
+func (e *Engine) logDebug(message string, args ...any) {
+	e.logAt(slog.LevelDebug, message, args...)
+}
+
+// logScaling writes an adaptive scaling decision as a structured event, so scaling behavior can
+// be analyzed from the log: ratio is the rate compared against the previous evaluation's (0 on
+// the first), and workers_before and workers_after the desired worker counts either side of it.
+func (e *Engine) logScaling(message string, ratio float64, before, after int, args ...any) {
+	e.logToFile(message, append([]any{
+		"event", "scaling", "ratio", ratio, "workers_before", before, "workers_after", after,
+	}, args...)...)
+}
+
+// logToFile writes an info record to the log file (if enabled).
+func (e *Engine) logToFile(message string, args ...any) {
+	e.logAt(slog.LevelInfo, message, args...)
+}
+
+// logWarn writes a warning record: failures and problems a run carries on past.
+func (e *Engine) logWarn(message string, args ...any) {
+	e.logAt(slog.LevelWarn, message, args...)
+}
@@ -0,0 +1,138 @@
+package syncengine_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_FileLogging_LogsScalingDecisionsAsEvents verifies that a hill-climbing decision is
+// written as one JSON record carrying the throughput ratio and the worker counts either side.
+func TestEngine_FileLogging_LogsScalingDecisionsAsEvents(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine, err := syncengine.NewEngine(t.TempDir(), t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.LogFormat = config.LogFormatJSON
+	engine.SetDesiredWorkers(3)
+
+	logPath := filepath.Join(t.TempDir(), "sync.log")
+	g.Expect(engine.EnableFileLogging(logPath)).To(Succeed())
+
+	workerControl := make(chan bool, 10)
+	state := &syncengine.AdaptiveScalingState{
+		LastThroughput: 5.0 * 1024.0 * 1024.0,
+		LastAdjustment: 1,
+		LastCheckTime:  time.Now(),
+	}
+
+	// Throughput improved by 20%, continuing to add workers
+	engine.HillClimbingScalingDecision(state, 6.0*1024.0*1024.0, 3, 10, workerControl)
+	engine.CloseLog()
+
+	var scaling []map[string]any
+
+	for _, record := range readLogRecords(t, logPath) {
+		if record["event"] == "scaling" {
+			scaling = append(scaling, record)
+		}
+	}
+
+	g.Expect(scaling).To(HaveLen(1))
+	g.Expect(scaling[0]).To(HaveKeyWithValue("level", "INFO"))
+	g.Expect(scaling[0]).To(HaveKeyWithValue("ratio", BeNumerically("~", 1.2, 0.001)))
+	g.Expect(scaling[0]).To(HaveKeyWithValue("workers_before", BeNumerically("==", 3)))
+	g.Expect(scaling[0]).To(HaveKeyWithValue("workers_after", BeNumerically("==", 4)))
+}
+
+// TestEngine_FileLogging_LevelFiltersPerFileRecords verifies that each copied file is logged, with
+// its size and worker, at debug level, and left out at the default info level.
+func TestEngine_FileLogging_LevelFiltersPerFileRecords(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		level      config.LogLevel
+		wantCopied int
+	}{
+		{name: "debug", level: config.LogLevelDebug, wantCopied: 2},
+		{name: "info", level: config.LogLevelInfo, wantCopied: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			sourceDir := t.TempDir()
+			destDir := t.TempDir()
+
+			createTestFile(t, sourceDir, "a.txt", "first")
+			createTestFile(t, sourceDir, "b.txt", "second")
+
+			engine, err := syncengine.NewEngine(sourceDir, destDir)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			engine.ChangeType = config.FluctuatingCount
+			engine.LogLevel = tc.level
+			engine.LogFormat = config.LogFormatJSON
+
+			logPath := filepath.Join(t.TempDir(), "sync.log")
+			g.Expect(engine.EnableFileLogging(logPath)).To(Succeed())
+
+			g.Expect(engine.Analyze()).To(Succeed())
+			g.Expect(engine.Sync()).To(Succeed())
+			engine.CloseLog()
+
+			var copied []map[string]any
+
+			for _, record := range readLogRecords(t, logPath) {
+				if record["msg"] == "Copied" {
+					copied = append(copied, record)
+				}
+			}
+
+			g.Expect(copied).To(HaveLen(tc.wantCopied))
+
+			for _, record := range copied {
+				g.Expect(record).To(HaveKeyWithValue("file", BeElementOf("a.txt", "b.txt")))
+				g.Expect(record).To(HaveKey("size"))
+				g.Expect(record).To(HaveKey("worker"))
+			}
+		})
+	}
+}
+
+// readLogRecords returns the JSON records in a log file, one per line.
+func readLogRecords(t *testing.T, path string) []map[string]any {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", path, err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	var records []map[string]any
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := make(map[string]any)
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Failed to parse log line %q: %v", scanner.Text(), err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
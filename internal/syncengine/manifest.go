@@ -0,0 +1,213 @@
+package syncengine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// Exported constants.
+const (
+	// ManifestFile is the resume manifest kept at the destination root by ResumeFromManifest
+	ManifestFile = ".glowsync-state.json"
+	// ManifestVersion is the current manifest file format version
+	ManifestVersion = 1
+)
+
+// Manifest entry states.
+const (
+	manifestCopying  = "copying"  // The copy started but hadn't finished when the manifest was saved
+	manifestComplete = "complete" // The copy finished
+)
+
+// manifestEntry records one source file's copy, with the source size and modtime it was copied at.
+type manifestEntry struct {
+	State   string    `json:"state"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// manifestFile is the on-disk manifest format.
+type manifestFile struct {
+	Version int                      `json:"version"`
+	Files   map[string]manifestEntry `json:"files"` // Source relative path -> copy state
+}
+
+// syncManifest is the in-memory manifest, updated by sync workers as copies start and finish
+// and saved to the destination at most every ResumeSaveInterval.
+type syncManifest struct {
+	mu       sync.Mutex
+	ops      *fileops.FileOps
+	path     string
+	files    map[string]manifestEntry
+	lastSave time.Time
+}
+
+// decide returns what the manifest knows about a source file: a file whose copy never finished
+// must be copied again, carrying on from its partial copy if one was kept, and a finished one whose source hasn't changed since and
+// whose destination copy has the right size needs no comparison. decided is false if the
+// manifest doesn't cover the file as it is now.
+func (m *syncManifest) decide(relPath string, srcFile, dstFile *fileops.FileInfo) (needsSync, decided bool) {
+	if m == nil {
+		return false, false
+	}
+
+	m.mu.Lock()
+	entry, ok := m.files[relPath]
+	m.mu.Unlock()
+
+	if !ok || entry.Size != srcFile.Size || !entry.ModTime.Equal(srcFile.ModTime) {
+		return false, false
+	}
+
+	if entry.State == manifestCopying {
+		return true, true
+	}
+
+	if dstFile == nil || dstFile.Size != srcFile.Size {
+		return false, false
+	}
+
+	return false, true
+}
+
+// resumable reports whether the partial copy of a file an earlier run left can be carried on:
+// the manifest has its copy as started but not finished, and the source hasn't changed since.
+func (m *syncManifest) resumable(relPath string, sourceFiles map[string]*fileops.FileInfo) bool {
+	if m == nil {
+		return false
+	}
+
+	srcFile, ok := sourceFiles[relPath]
+	if !ok {
+		return false
+	}
+
+	m.mu.Lock()
+	entry, ok := m.files[relPath]
+	m.mu.Unlock()
+
+	return ok && entry.State == manifestCopying && entry.Size == srcFile.Size && entry.ModTime.Equal(srcFile.ModTime)
+}
+
+// markCopying records that a file's copy has started.
+func (m *syncManifest) markCopying(file *FileToSync) {
+	m.mark(file, manifestCopying)
+}
+
+// markComplete records that a file's copy has finished.
+func (m *syncManifest) markComplete(file *FileToSync) {
+	m.mark(file, manifestComplete)
+}
+
+// mark sets a file's state and periodically saves the manifest. A failed periodic save only
+// costs the next run some comparisons, so it isn't reported.
+func (m *syncManifest) mark(file *FileToSync, state string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.files[file.RelativePath] = manifestEntry{State: state, Size: file.Size, ModTime: file.ModTime}
+
+	if time.Since(m.lastSave) >= ResumeSaveInterval {
+		_ = m.saveLocked()
+	}
+}
+
+// save writes the manifest to the destination.
+func (m *syncManifest) save() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.saveLocked()
+}
+
+// saveLocked writes the manifest. Must be called with m.mu held.
+func (m *syncManifest) saveLocked() error {
+	m.lastSave = time.Now()
+
+	data, err := json.Marshal(manifestFile{Version: ManifestVersion, Files: m.files})
+	if err != nil {
+		return fmt.Errorf("failed to encode resume manifest: %w", err)
+	}
+
+	err = m.ops.WriteDestFile(m.path, data)
+	if err != nil {
+		return fmt.Errorf("failed to save resume manifest: %w", err)
+	}
+
+	return nil
+}
+
+// loadManifest reads the destination's resume manifest, starting an empty one if there is none
+// yet. A manifest that can't be parsed, e.g. torn by a crash while it was written, is also
+// replaced by an empty one, since losing it only costs comparisons.
+func (e *Engine) loadManifest() error {
+	manifest := &syncManifest{
+		ops:   e.FileOps,
+		path:  filepath.Join(e.DestPath, ManifestFile),
+		files: make(map[string]manifestEntry),
+	}
+
+	e.manifest = manifest
+
+	data, err := e.FileOps.ReadDestFile(manifest.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		e.logAnalysis("No resume manifest at the destination - comparing every file")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read resume manifest: %w", err)
+	}
+
+	var file manifestFile
+
+	err = json.Unmarshal(data, &file)
+	if err != nil || file.Version != ManifestVersion {
+		e.logAnalysis(fmt.Sprintf("Warning: ignoring unreadable resume manifest %s", manifest.path))
+		return nil
+	}
+
+	if file.Files != nil {
+		manifest.files = file.Files
+	}
+
+	e.logAnalysis(fmt.Sprintf("Loaded resume manifest with %d files", len(manifest.files)))
+
+	return nil
+}
+
+// finishManifest removes the manifest after a complete sync, since the destination then
+// matches the plan, or saves it so the next run can skip what this one copied.
+func (e *Engine) finishManifest(syncErr error) {
+	if e.manifest == nil {
+		return
+	}
+
+	if syncErr == nil && e.checkCancellation() == nil {
+		err := e.FileOps.RemoveFromDest(e.manifest.path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			e.logWarn("Failed to remove resume manifest", "error", err)
+		}
+
+		return
+	}
+
+	err := e.manifest.save()
+	if err != nil {
+		e.logWarn(err.Error())
+		return
+	}
+
+	e.logToFile("Saved resume manifest - rerun with --resume-manifest to skip the files already copied")
+}
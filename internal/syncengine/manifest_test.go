@@ -0,0 +1,214 @@
+package syncengine_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestEngine_ResumeFromManifest_SkipsCopiedAndRecopiesPartial verifies that files the
+// manifest says were copied aren't compared again, files it says were being copied are copied
+// from scratch, and the manifest is removed once the sync completes.
+func TestEngine_ResumeFromManifest_SkipsCopiedAndRecopiesPartial(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "done.txt", "done content")
+	createTestFile(t, sourceDir, "partial.txt", "partial content")
+	createTestFile(t, sourceDir, "new.txt", "new")
+
+	// Same sizes, different bytes: only the manifest can make done.txt count as synced
+	createTestFile(t, destDir, "done.txt", "DONE CONTENT")
+	createTestFile(t, destDir, "partial.txt", "partial\x00\x00\x00\x00\x00\x00\x00\x00")
+
+	writeManifest(t, sourceDir, destDir, map[string]string{"done.txt": "complete", "partial.txt": "copying"})
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.ResumeFromManifest = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.ManifestSkippedFiles).To(Equal(1))
+	g.Expect(status.ManifestRecopiedFiles).To(Equal(1))
+	g.Expect(status.FilesToDelete).To(Equal(0))
+
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(readFile(t, destDir, "done.txt")).To(Equal("DONE CONTENT"))
+	g.Expect(readFile(t, destDir, "partial.txt")).To(Equal("partial content"))
+	g.Expect(readFile(t, destDir, "new.txt")).To(Equal("new"))
+	g.Expect(filepath.Join(destDir, syncengine.ManifestFile)).NotTo(BeAnExistingFile())
+}
+
+// TestEngine_ResumeFromManifest_ResumesPartialCopy verifies that a partial temp file the
+// manifest says was being copied is kept and carried on from its size rather than copied again:
+// its bytes are kept as they are, which the deliberately different prefix here shows.
+func TestEngine_ResumeFromManifest_ResumesPartialCopy(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "big.bin", "first half|second half")
+	createTestFile(t, destDir, "big.bin"+fileops.TempSuffix, "FIRST HALF")
+
+	writeManifest(t, sourceDir, destDir, map[string]string{"big.bin": "copying"})
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.ResumeFromManifest = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.GetStatus().FilesToDelete).To(Equal(0))
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(readFile(t, destDir, "big.bin")).To(Equal("FIRST HALF|second half"))
+	g.Expect(filepath.Join(destDir, "big.bin"+fileops.TempSuffix)).NotTo(BeAnExistingFile())
+
+	status := engine.GetStatus()
+	g.Expect(status.ResumedFiles).To(Equal(1))
+	g.Expect(status.ResumedBytes).To(Equal(int64(len("FIRST HALF"))))
+	g.Expect(status.TransferredBytes).To(Equal(int64(len("first half|second half"))))
+}
+
+// TestEngine_VerifyResumed_RecopiesMismatchedPartialCopy verifies that with VerifyResumed a
+// partial copy that doesn't match the start of the source is copied again from scratch.
+func TestEngine_VerifyResumed_RecopiesMismatchedPartialCopy(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "big.bin", "first half|second half")
+	createTestFile(t, destDir, "big.bin"+fileops.TempSuffix, "FIRST HALF")
+
+	writeManifest(t, sourceDir, destDir, map[string]string{"big.bin": "copying"})
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.ResumeFromManifest = true
+	engine.VerifyResumed = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(readFile(t, destDir, "big.bin")).To(Equal("first half|second half"))
+	g.Expect(engine.GetStatus().ResumedFiles).To(Equal(0))
+}
+
+// TestEngine_ResumeFromManifest_SavesStateOnFailure verifies that a sync that doesn't finish
+// leaves a manifest marking finished copies complete and the failed one still copying.
+func TestEngine_ResumeFromManifest_SavesStateOnFailure(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "good.txt", "good")
+	createTestFile(t, sourceDir, "bad.txt", "bad")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.FileOps = fileops.NewDualFileOps(filesystem.NewRealFileSystem(),
+		&failingCreateFS{RealFileSystem: filesystem.NewRealFileSystem(), name: "bad.txt"})
+	engine.ResumeFromManifest = true
+	engine.Workers = 1
+	engine.AdaptiveMode = false
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).NotTo(Succeed())
+
+	data, err := os.ReadFile(filepath.Join(destDir, syncengine.ManifestFile))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	var manifest struct {
+		Files map[string]struct {
+			State string `json:"state"`
+		} `json:"files"`
+	}
+
+	g.Expect(json.Unmarshal(data, &manifest)).To(Succeed())
+	g.Expect(manifest.Files).To(HaveLen(2))
+	g.Expect(manifest.Files["good.txt"].State).To(Equal("complete"))
+	g.Expect(manifest.Files["bad.txt"].State).To(Equal("copying"))
+}
+
+// writeManifest writes a resume manifest at destDir giving each source file the state listed.
+func writeManifest(t *testing.T, sourceDir, destDir string, states map[string]string) {
+	t.Helper()
+
+	files := make(map[string]any, len(states))
+
+	for name, state := range states {
+		info, err := os.Stat(filepath.Join(sourceDir, name))
+		if err != nil {
+			t.Fatalf("Failed to stat %s: %v", name, err)
+		}
+
+		files[name] = map[string]any{"state": state, "size": info.Size(), "mod_time": info.ModTime().Format(time.RFC3339Nano)}
+	}
+
+	data, err := json.Marshal(map[string]any{"version": syncengine.ManifestVersion, "files": files})
+	if err != nil {
+		t.Fatalf("Failed to encode manifest: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(destDir, syncengine.ManifestFile), data, 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+}
+
+// readFile returns the content of dir/name.
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", name, err)
+	}
+
+	return string(data)
+}
+
+// failingCreateFS is a destination filesystem that fails to create files with a given name,
+// including their copies' temp files.
+type failingCreateFS struct {
+	*filesystem.RealFileSystem
+
+	name string
+}
+
+func (f *failingCreateFS) Create(path string) (filesystem.File, error) {
+	if strings.TrimSuffix(filepath.Base(path), fileops.TempSuffix) == f.name {
+		return nil, errors.New("disk full") //nolint:err113 // Test error
+	}
+
+	return f.RealFileSystem.Create(path)
+}
@@ -0,0 +1,107 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_MaxDepth_IgnoresDeepFiles verifies that files below the depth limit
+// are neither synced from source nor deleted from destination as orphans.
+func TestEngine_MaxDepth_IgnoresDeepFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createNestedTestFile(t, sourceDir, "top.txt", "top")
+	createNestedTestFile(t, sourceDir, "a/shallow.txt", "shallow")
+	createNestedTestFile(t, sourceDir, "a/b/c/deep.txt", "deep")
+	createNestedTestFile(t, destDir, "a/b/c/dest-only.txt", "keep me")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.MaxDepth = 2
+
+	err = engine.Analyze()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	status := engine.GetStatus()
+	g.Expect(status.TotalFiles).To(Equal(2), "Only top.txt and a/shallow.txt are within depth")
+	g.Expect(status.FilesToDelete).To(Equal(0), "Deep destination files must not be orphans")
+
+	err = engine.Sync()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(filepath.Join(destDir, "top.txt")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "a", "shallow.txt")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "a", "b", "c", "deep.txt")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "a", "b", "c", "dest-only.txt")).To(BeAnExistingFile())
+}
+
+// TestEngine_MaxDepth_ZeroIsUnlimited verifies the default depth scans everything.
+func TestEngine_MaxDepth_ZeroIsUnlimited(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createNestedTestFile(t, sourceDir, "a/b/c/d/e/f/deep.txt", "deep")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+
+	err = engine.Analyze()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = engine.Sync()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	_, err = os.Stat(filepath.Join(destDir, "a", "b", "c", "d", "e", "f", "deep.txt"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+}
+
+// TestEngine_MaxDepth_NeverReadsDeeperDirectories verifies that directories at the depth limit
+// are never read, on either side, so an unreadable one below it doesn't fail the analysis.
+// Root can read unreadable directories, so the test is skipped as root.
+func TestEngine_MaxDepth_NeverReadsDeeperDirectories(t *testing.T) {
+	t.Parallel()
+
+	if os.Geteuid() == 0 {
+		t.Skip("root can read unreadable directories")
+	}
+
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createNestedTestFile(t, sourceDir, "a/shallow.txt", "shallow")
+	createNestedTestFile(t, sourceDir, "a/b/deep.txt", "deep")
+	createNestedTestFile(t, destDir, "a/b/dest-only.txt", "keep me")
+
+	for _, dir := range []string{filepath.Join(sourceDir, "a", "b"), filepath.Join(destDir, "a", "b")} {
+		g.Expect(os.Chmod(dir, 0)).To(Succeed())
+		t.Cleanup(func() { _ = os.Chmod(dir, 0o755) })
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.MaxDepth = 2
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.GetStatus().TotalFiles).To(Equal(1))
+}
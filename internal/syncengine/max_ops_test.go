@@ -0,0 +1,48 @@
+package syncengine_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_MaxOpsPerSecond_CapsAggregateRate verifies that the operation cap applies across
+// all workers, so many small files take at least as long as their operations allow.
+func TestEngine_MaxOpsPerSecond_CapsAggregateRate(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	const fileCount = 5
+
+	for i := range fileCount {
+		createTestFile(t, sourceDir, fmt.Sprintf("file%d.txt", i), "content")
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.Workers = 4
+	engine.MaxOpsPerSecond = 100 // One op every 10ms
+
+	start := time.Now()
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	// Each copy opens, creates the directory, creates the file and sets times: 4 ops per file
+	g.Expect(time.Since(start)).To(BeNumerically(">=", (fileCount*4-1)*10*time.Millisecond))
+
+	for i := range fileCount {
+		g.Expect(filepath.Join(destDir, fmt.Sprintf("file%d.txt", i))).To(BeAnExistingFile())
+	}
+}
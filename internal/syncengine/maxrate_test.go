@@ -0,0 +1,41 @@
+package syncengine_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_MaxBytesPerSecond_ThrottlesAllWorkers verifies that the byte rate cap holds across
+// every worker together, not per worker.
+func TestEngine_MaxBytesPerSecond_ThrottlesAllWorkers(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	for _, name := range []string{"a.bin", "b.bin", "c.bin", "d.bin"} {
+		createTestFile(t, sourceDir, name, strings.Repeat("x", 50_000))
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.Workers = 4
+	engine.MaxBytesPerSecond = 100_000
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	start := time.Now()
+
+	g.Expect(engine.Sync()).To(Succeed())
+
+	// 200KB is the first second's 100KB burst, then 100KB more at 100KB/s
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 900*time.Millisecond))
+	g.Expect(engine.GetStatus().ProcessedFiles).To(Equal(4))
+}
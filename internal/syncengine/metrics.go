@@ -10,6 +10,10 @@ const (
 	NumProgressDimensions = 3.0
 	// ProgressPercentageScale converts 0-1 range to 0-100 range.
 	ProgressPercentageScale = 100.0
+	// RateSampleWindow is how far back WorkerMetrics.RecentSamples reaches.
+	RateSampleWindow = 10 * time.Second
+	// AnalysisRateWindow is how far back Status.AnalysisRate is averaged.
+	AnalysisRateWindow = 5 * time.Second
 )
 
 // ProgressMetrics encapsulates all progress calculation results for display.
@@ -41,9 +45,18 @@ type ProgressMetrics struct {
 	EstimatedTimeRemaining time.Duration
 }
 
+// PhaseTiming is the total wall-clock time a sync spent in one phase.
+type PhaseTiming struct {
+	// Phase is one of the Phase* names.
+	Phase string
+
+	// Duration is the accumulated time spent in the phase.
+	Duration time.Duration
+}
+
 // RateSample represents a point-in-time performance measurement.
-// Samples are collected when files complete and stored in a rolling window
-// to track recent performance trends.
+// Samples are collected about once a second while a file is copying and again
+// when it completes, and stored in a rolling window to track recent performance trends.
 type RateSample struct {
 	// Timestamp is when this sample was recorded.
 	Timestamp time.Time
@@ -59,11 +72,25 @@ type RateSample struct {
 
 	// ActiveWorkers is the number of workers active when this sample was taken.
 	ActiveWorkers int
+
+	// WorkerID is the worker whose copy this sample measured, numbered from 1 in the order the
+	// workers started; 0 for samples not taken by a worker.
+	WorkerID int
+}
+
+// WorkerRate is one worker's transfer rate over the samples in the rolling window.
+type WorkerRate struct {
+	// WorkerID is the worker's id, as in RateSample.WorkerID.
+	WorkerID int
+
+	// BytesPerSecond is the worker's transfer rate in bytes/sec.
+	BytesPerSecond float64
 }
 
 // WorkerMetrics tracks per-worker performance using a rolling window approach.
-// Metrics are calculated based on recent samples (typically last 5 files) to
+// Metrics are calculated from the samples taken in the last RateSampleWindow to
 // ensure responsiveness to changing network or disk performance conditions.
+// Status.CalculateWorkerMetrics computes them from a live Status.
 type WorkerMetrics struct {
 	// ReadPercent is the percentage of time workers spent reading from source.
 	// Calculated from recent samples in the rolling window.
@@ -81,8 +108,8 @@ type WorkerMetrics struct {
 	// Calculated from recent samples in the rolling window.
 	TotalRate float64
 
-	// RecentSamples maintains a rolling window of recent performance measurements.
-	// Used to calculate the above metrics based on recent activity rather than
-	// cumulative totals, ensuring metrics reflect current performance.
+	// RecentSamples maintains a rolling window of recent performance measurements,
+	// oldest first. Used to calculate the above metrics based on recent activity rather
+	// than cumulative totals, ensuring metrics reflect current performance.
 	RecentSamples []RateSample
 }
@@ -285,6 +285,88 @@ func TestCalculateWorkerMetricsWithoutSamples(t *testing.T) { //nolint:varnamele
 	})
 }
 
+// TestCalculateWorkerMetricsConcurrentWithSamples verifies that metrics can be read while
+// samples are being added, and that the window drops samples older than RateSampleWindow.
+func TestCalculateWorkerMetricsConcurrentWithSamples(t *testing.T) {
+	t.Parallel()
+
+	gomega := NewWithT(t)
+
+	status := &Status{}
+	start := time.Now()
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for idx := range 200 {
+			status.mu.Lock()
+			status.addRateSample(RateSample{
+				Timestamp:        start.Add(time.Duration(idx) * 100 * time.Millisecond),
+				BytesTransferred: 1000,
+				ActiveWorkers:    2,
+			})
+			status.mu.Unlock()
+		}
+	}()
+
+	for range 200 {
+		metrics := status.CalculateWorkerMetrics()
+		for _, sample := range metrics.RecentSamples {
+			gomega.Expect(sample.BytesTransferred).To(Equal(int64(1000)))
+		}
+	}
+
+	<-done
+
+	metrics := status.CalculateWorkerMetrics()
+
+	// Samples 100ms apart, so the window spans 10s worth of them plus the one at the cutoff
+	gomega.Expect(metrics.RecentSamples).To(HaveLen(101))
+	gomega.Expect(metrics.TotalRate).To(BeNumerically("~", 101*1000/10.0, 1.0))
+	gomega.Expect(metrics.PerWorkerRate).To(BeNumerically("~", metrics.TotalRate/2, 1.0))
+}
+
+// TestEvaluationDueEveryInterval verifies that adaptive scaling evaluates on elapsed time by
+// the engine's TimeProvider, whatever the number of files completed.
+func TestEvaluationDueEveryInterval(t *testing.T) {
+	t.Parallel()
+
+	gomega := NewWithT(t)
+
+	clock := &fixedTimeProvider{now: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	engine := &Engine{TimeProvider: clock}
+
+	// No evaluation yet: due straight away, to set the baseline
+	state := &AdaptiveScalingState{}
+	gomega.Expect(engine.evaluationDue(state)).To(BeTrue())
+
+	state.LastCheckTime = clock.now
+
+	clock.now = clock.now.Add(evaluationInterval - time.Second)
+	gomega.Expect(engine.evaluationDue(state)).To(BeFalse())
+
+	clock.now = clock.now.Add(time.Second)
+	gomega.Expect(engine.evaluationDue(state)).To(BeTrue())
+	gomega.Expect(evaluationInterval).To(Equal(RateSampleWindow))
+}
+
+func TestCompletionEstimate(t *testing.T) {
+	t.Parallel()
+
+	gomega := NewWithT(t)
+	completion := time.Now().Add(time.Hour)
+
+	status := &Status{CompletionTime: completion, EstimateSamples: MinEstimateSamples - 1}
+	_, ok := status.CompletionEstimate()
+	gomega.Expect(ok).To(BeFalse(), "estimate should wait for enough samples")
+
+	status.EstimateSamples = MinEstimateSamples
+	estimate, ok := status.CompletionEstimate()
+	gomega.Expect(ok).To(BeTrue())
+	gomega.Expect(estimate).To(Equal(completion))
+}
+
 func TestComputeProgressMetrics(t *testing.T) {
 	t.Parallel()
 
@@ -322,3 +404,47 @@ func TestComputeProgressMetrics(t *testing.T) {
 		gomega.Expect(status.Workers.WritePercent).To(BeNumerically("~", 40.0, 0.1))
 	})
 }
+
+// TestComputeProgressMetrics_PerWorkerRates verifies that each worker's rate is its bytes over
+// the window's span, ordered by worker id, leaving out samples not taken by a worker.
+func TestComputeProgressMetrics_PerWorkerRates(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	now := time.Now()
+
+	status := &Status{
+		Workers: WorkerMetrics{
+			RecentSamples: []RateSample{
+				{Timestamp: now.Add(-4 * time.Second), BytesTransferred: 400, WorkerID: 2},
+				{Timestamp: now.Add(-3 * time.Second), BytesTransferred: 800, WorkerID: 1},
+				{Timestamp: now.Add(-2 * time.Second), BytesTransferred: 1000},
+				{Timestamp: now, BytesTransferred: 400, WorkerID: 1},
+			},
+		},
+	}
+
+	status.ComputeProgressMetrics()
+
+	// 4 second span: worker 1 moved 1200 bytes, worker 2 400
+	g.Expect(status.PerWorkerBytesPerSecond).To(Equal([]WorkerRate{
+		{WorkerID: 1, BytesPerSecond: 300},
+		{WorkerID: 2, BytesPerSecond: 100},
+	}))
+
+	// A single sample has no span to measure a rate over
+	status.Workers.RecentSamples = status.Workers.RecentSamples[3:]
+	status.ComputeProgressMetrics()
+	g.Expect(status.PerWorkerBytesPerSecond).To(BeEmpty())
+}
+
+// fixedTimeProvider is a TimeProvider whose time only changes when a test sets it.
+type fixedTimeProvider struct {
+	now time.Time
+}
+
+func (f *fixedTimeProvider) Now() time.Time { return f.now }
+
+func (f *fixedTimeProvider) NewTicker(d time.Duration) Ticker {
+	return &RealTicker{ticker: time.NewTicker(d)}
+}
@@ -0,0 +1,91 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_ModifiedAfter_LeavesOldFilesAlone verifies that files modified before
+// ModifiedAfter are left out of the plan and counted, and that neither their destination copies
+// nor old destination-only files are deleted as orphans, while recent orphans still are.
+func TestEngine_ModifiedAfter_LeavesOldFilesAlone(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	monthAgo := time.Now().AddDate(0, 0, -30)
+
+	createTestFile(t, sourceDir, "new.txt", "new")
+	createTestFile(t, sourceDir, "old.txt", "changed at the source")
+	createTestFile(t, destDir, "old.txt", "old copy")
+	createTestFile(t, destDir, "ancient.txt", "old orphan")
+	createTestFile(t, destDir, "recent.txt", "recent orphan")
+
+	for _, path := range []string{
+		filepath.Join(sourceDir, "old.txt"),
+		filepath.Join(destDir, "old.txt"),
+		filepath.Join(destDir, "ancient.txt"),
+	} {
+		g.Expect(os.Chtimes(path, monthAgo, monthAgo)).To(Succeed())
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.ModifiedAfter = time.Now().AddDate(0, 0, -7)
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	g.Expect(engine.Status.FilesToSync).To(HaveLen(1))
+	g.Expect(engine.Status.FilesToSync[0].RelativePath).To(Equal("new.txt"))
+	g.Expect(engine.GetStatus().ModTimeSkippedFiles).To(Equal(1))
+
+	g.Expect(engine.Sync()).To(Succeed())
+
+	old, err := os.ReadFile(filepath.Join(destDir, "old.txt"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(old)).To(Equal("old copy"))
+
+	g.Expect(filepath.Join(destDir, "ancient.txt")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "recent.txt")).ToNot(BeAnExistingFile())
+}
+
+// TestEngine_ModifiedBefore_LeavesNewFilesOut verifies that ModifiedBefore leaves out files
+// modified at or after it.
+func TestEngine_ModifiedBefore_LeavesNewFilesOut(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	cutoff := time.Now().Add(-time.Hour)
+
+	createTestFile(t, sourceDir, "before.txt", "before")
+	createTestFile(t, sourceDir, "at.txt", "at")
+	g.Expect(os.Chtimes(filepath.Join(sourceDir, "before.txt"), cutoff.Add(-time.Minute), cutoff.Add(-time.Minute))).
+		To(Succeed())
+	g.Expect(os.Chtimes(filepath.Join(sourceDir, "at.txt"), cutoff, cutoff)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.ModifiedBefore = cutoff
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	g.Expect(engine.Status.FilesToSync).To(HaveLen(1))
+	g.Expect(engine.Status.FilesToSync[0].RelativePath).To(Equal("before.txt"))
+	g.Expect(engine.GetStatus().ModTimeSkippedFiles).To(Equal(1))
+}
@@ -0,0 +1,78 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_ModTimeNewer_CopiesOnlyNewerSources verifies that modtime-newer mode re-copies
+// files whose source is newer by more than the slack, ignoring size, older sources and
+// differences within the slack.
+func TestEngine_ModTimeNewer_CopiesOnlyNewerSources(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	setFile := func(dir, name, content string, modTime time.Time) {
+		createTestFile(t, dir, name, content)
+		g.Expect(os.Chtimes(filepath.Join(dir, name), modTime, modTime)).To(Succeed())
+	}
+
+	setFile(sourceDir, "newer.txt", "new", base.Add(time.Minute))
+	setFile(destDir, "newer.txt", "old", base)
+	setFile(sourceDir, "older.txt", "older but longer", base)
+	setFile(destDir, "older.txt", "edited", base.Add(time.Minute))
+	setFile(sourceDir, "rounded.txt", "same", base.Add(time.Second))
+	setFile(destDir, "rounded.txt", "same", base)
+	setFile(sourceDir, "missing.txt", "missing", base)
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.ModTimeNewer
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.PlanEntries()).To(ConsistOf(
+		syncengine.PlanEntry{RelativePath: "newer.txt", Size: 3, Action: syncengine.ActionOverwrite},
+		syncengine.PlanEntry{RelativePath: "missing.txt", Size: 7, Action: syncengine.ActionCreate},
+	))
+}
+
+// TestEngine_ModTimeNewer_ZeroSlack verifies that without slack, any newer source modtime
+// counts.
+func TestEngine_ModTimeNewer_ZeroSlack(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	createTestFile(t, sourceDir, "a.txt", "same")
+	createTestFile(t, destDir, "a.txt", "same")
+	g.Expect(os.Chtimes(filepath.Join(sourceDir, "a.txt"), base, base.Add(time.Second))).To(Succeed())
+	g.Expect(os.Chtimes(filepath.Join(destDir, "a.txt"), base, base)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.ModTimeNewer
+	engine.ModTimeSlack = 0
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.PlanEntries()).To(ConsistOf(
+		syncengine.PlanEntry{RelativePath: "a.txt", Size: 4, Action: syncengine.ActionOverwrite},
+	))
+}
@@ -0,0 +1,84 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_MountMarker_MissingMarkerFailsAnalysis verifies that a destination without the
+// marker is refused before anything is planned.
+func TestEngine_MountMarker_MissingMarkerFailsAnalysis(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "file.txt", "content")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.MountMarker = ".mounted"
+
+	err = engine.Analyze()
+	g.Expect(err).To(MatchError(syncengine.ErrDestNotMounted))
+	g.Expect(err.Error()).To(ContainSubstring(".mounted"))
+	g.Expect(engine.PlanEntries()).To(BeEmpty())
+}
+
+// TestEngine_MountMarker_KeepsMarkerAtDest verifies that a present marker lets the sync run
+// and isn't deleted as an orphan.
+func TestEngine_MountMarker_KeepsMarkerAtDest(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "file.txt", "content")
+	createTestFile(t, destDir, ".mounted", "")
+	createTestFile(t, destDir, "orphan.txt", "orphan")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.MountMarker = ".mounted"
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.PlanEntries()).To(ConsistOf(
+		syncengine.PlanEntry{RelativePath: "file.txt", Size: 7, Action: syncengine.ActionCreate},
+		syncengine.PlanEntry{RelativePath: "orphan.txt", Size: 6, Action: syncengine.ActionDelete},
+	))
+	g.Expect(engine.Sync()).To(Succeed())
+
+	_, err = os.Stat(filepath.Join(destDir, ".mounted"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	_, err = os.Stat(filepath.Join(destDir, "orphan.txt"))
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+}
+
+// TestEngine_RequireMount_RefusesPlainDirectory verifies that a destination directory on its
+// parent's filesystem is refused.
+func TestEngine_RequireMount_RefusesPlainDirectory(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := filepath.Join(t.TempDir(), "backup")
+	g.Expect(os.Mkdir(destDir, 0o755)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.RequireMount = true
+
+	g.Expect(engine.Analyze()).To(MatchError(syncengine.ErrDestNotMounted))
+}
@@ -0,0 +1,103 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestEngine_Move_RenamesLocalFiles verifies that a move between local directories renames every
+// file into the destination, counting their bytes as transferred, and that a second run leaves
+// the moved files in place rather than deleting them as orphans.
+func TestEngine_Move_RenamesLocalFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "first")
+	createNestedTestFile(t, sourceDir, "sub/b.txt", "second")
+
+	newEngine := func() *syncengine.Engine {
+		engine, err := syncengine.NewEngine(sourceDir, destDir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		engine.ChangeType = config.FluctuatingCount
+		engine.Move = true
+
+		return engine
+	}
+
+	engine := newEngine()
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.Errors).To(BeEmpty())
+	g.Expect(status.MovedFiles).To(Equal(2))
+	g.Expect(status.RenamedFiles).To(Equal(2))
+	g.Expect(status.TransferredBytes).To(Equal(status.TotalBytes))
+
+	g.Expect(filepath.Join(sourceDir, "a.txt")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join(sourceDir, "sub", "b.txt")).NotTo(BeAnExistingFile())
+	g.Expect(readDir(t, destDir)).To(Equal(map[string]string{"a.txt": "first"}))
+	g.Expect(os.ReadFile(filepath.Join(destDir, "sub", "b.txt"))).To(Equal([]byte("second")))
+
+	rerun := newEngine()
+	g.Expect(rerun.Analyze()).To(Succeed())
+	g.Expect(rerun.Sync()).To(Succeed())
+
+	g.Expect(readDir(t, destDir)).To(Equal(map[string]string{"a.txt": "first"}))
+	g.Expect(filepath.Join(destDir, "sub", "b.txt")).To(BeAnExistingFile())
+}
+
+// TestEngine_Move_CopiesAcrossFilesystems verifies that a file that can't be renamed is copied
+// and verified before its source is removed.
+func TestEngine_Move_CopiesAcrossFilesystems(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "photo.jpg", "pixels")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.FileOps = fileops.NewDualFileOps(filesystem.NewRealFileSystem(), otherDeviceFS{filesystem.NewRealFileSystem()})
+	engine.ChangeType = config.FluctuatingCount
+	engine.Move = true
+	engine.VerifyAfterCopy = true
+	engine.InPlace = true // Renames fail on this destination, temp files' included
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.Errors).To(BeEmpty())
+	g.Expect(status.MovedFiles).To(Equal(1))
+	g.Expect(status.RenamedFiles).To(BeZero())
+	g.Expect(status.VerifiedFiles).To(Equal(1))
+
+	g.Expect(filepath.Join(sourceDir, "photo.jpg")).NotTo(BeAnExistingFile())
+	g.Expect(readDir(t, destDir)).To(Equal(map[string]string{"photo.jpg": "pixels"}))
+}
+
+// otherDeviceFS is a local filesystem that isn't recognized as the source's, as a destination on
+// another device wouldn't be rename-able to, and whose renames fail.
+type otherDeviceFS struct {
+	*filesystem.RealFileSystem
+}
+
+func (otherDeviceFS) Rename(oldpath, newpath string) error {
+	return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: os.ErrInvalid}
+}
@@ -0,0 +1,190 @@
+package syncengine
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// Exported constants.
+const (
+	// MaxNameLength is the longest path component ext4, NTFS and FAT can store
+	MaxNameLength = 255
+	// SanitizeReplacement replaces characters the destination filesystem cannot store
+	SanitizeReplacement = "_"
+)
+
+// SanitizedName records a source path renamed to fit destination filename rules
+type SanitizedName struct {
+	SourcePath string
+	DestPath   string
+}
+
+// CaseConflict is a set of source files whose paths differ only in letter case, and which of
+// them CaseConflictPolicy synced.
+type CaseConflict struct {
+	Paths []string // Sorted
+	Kept  string   // The path synced, or "" if none were
+}
+
+// String describes the conflict and its outcome, e.g. "README.md, Readme.md → kept README.md".
+func (c CaseConflict) String() string {
+	outcome := "skipped all"
+	if c.Kept != "" {
+		outcome = "kept " + c.Kept
+	}
+
+	return strings.Join(c.Paths, ", ") + " → " + outcome
+}
+
+// windowsIllegalChars are characters NTFS and FAT reject in file names (besides control characters)
+const windowsIllegalChars = `<>:"\|?*`
+
+// windowsReservedNames are device names NTFS and FAT reject regardless of extension
+//
+//nolint:gochecknoglobals // Lookup table
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// illegalNameReason returns why relPath cannot be stored on fsType, or "" if it can.
+func illegalNameReason(relPath string, fsType config.DestFSType) string {
+	for _, name := range strings.Split(filepath.ToSlash(relPath), "/") {
+		reason := illegalComponentReason(name, fsType)
+		if reason != "" {
+			return fmt.Sprintf("%q %s", name, reason)
+		}
+	}
+
+	return ""
+}
+
+// illegalComponentReason checks a single path component against fsType's rules.
+func illegalComponentReason(name string, fsType config.DestFSType) string {
+	switch fsType {
+	case config.DestFSExt4:
+		if len(name) > MaxNameLength {
+			return fmt.Sprintf("is longer than %d bytes", MaxNameLength)
+		}
+	case config.DestFSNTFS, config.DestFSVFAT:
+		if utf8.RuneCountInString(name) > MaxNameLength {
+			return fmt.Sprintf("is longer than %d characters", MaxNameLength)
+		}
+
+		for _, r := range name {
+			if r < ' ' || strings.ContainsRune(windowsIllegalChars, r) {
+				return fmt.Sprintf("contains %q", r)
+			}
+		}
+
+		if strings.HasSuffix(name, " ") || strings.HasSuffix(name, ".") {
+			return "ends with a space or dot"
+		}
+
+		if isWindowsReservedName(name) {
+			return "is a reserved device name"
+		}
+	case config.DestFSAuto:
+	}
+
+	return ""
+}
+
+// isWindowsReservedName reports whether name is a device name such as CON or COM1.txt
+func isWindowsReservedName(name string) bool {
+	base, _, _ := strings.Cut(name, ".")
+
+	return windowsReservedNames[strings.ToUpper(strings.TrimRight(base, " "))]
+}
+
+// sanitizeName maps each component of relPath to a name fsType can store.
+// The mapping is deterministic so repeated syncs produce the same destination names.
+// Names that are too long are left unchanged and remain illegal.
+func sanitizeName(relPath string, fsType config.DestFSType) string {
+	if fsType != config.DestFSNTFS && fsType != config.DestFSVFAT {
+		return relPath
+	}
+
+	parts := strings.Split(filepath.ToSlash(relPath), "/")
+	for i, name := range parts {
+		parts[i] = sanitizeWindowsComponent(name)
+	}
+
+	return filepath.FromSlash(strings.Join(parts, "/"))
+}
+
+// sanitizeWindowsComponent replaces characters, trailing spaces/dots and device names NTFS and FAT reject.
+func sanitizeWindowsComponent(name string) string {
+	var builder strings.Builder
+
+	for _, r := range name {
+		if r < ' ' || strings.ContainsRune(windowsIllegalChars, r) {
+			builder.WriteString(SanitizeReplacement)
+		} else {
+			builder.WriteRune(r)
+		}
+	}
+
+	sanitized := builder.String()
+
+	trimmed := strings.TrimRight(sanitized, " .")
+	if trimmed != sanitized {
+		sanitized = trimmed + strings.Repeat(SanitizeReplacement, len(sanitized)-len(trimmed))
+	}
+
+	if isWindowsReservedName(sanitized) {
+		base, ext, hasExt := strings.Cut(sanitized, ".")
+		sanitized = base + SanitizeReplacement
+		if hasExt {
+			sanitized += "." + ext
+		}
+	}
+
+	return sanitized
+}
+
+// caseConflictGroups returns the sets of source files whose paths differ only in case, each
+// sorted, ordered by their first path so runs resolve them the same way.
+func caseConflictGroups(sourceFiles map[string]*fileops.FileInfo) [][]string {
+	byFolded := make(map[string][]string)
+
+	for relPath, info := range sourceFiles {
+		if !info.IsDir {
+			folded := strings.ToLower(relPath)
+			byFolded[folded] = append(byFolded[folded], relPath)
+		}
+	}
+
+	groups := make([][]string, 0)
+
+	for _, paths := range byFolded {
+		if len(paths) > 1 {
+			sort.Strings(paths)
+			groups = append(groups, paths)
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0] < groups[j][0] })
+
+	return groups
+}
+
+// hasPathPrefix reports whether relPath is inside any of dirs
+func hasPathPrefix(relPath string, dirs []string) bool {
+	for _, dir := range dirs {
+		if strings.HasPrefix(relPath, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,164 @@
+package syncengine_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_DestFSType_ReportsIllegalNames verifies that names the destination
+// filesystem can't store are reported up front and left out of the plan.
+func TestEngine_DestFSType_ReportsIllegalNames(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "ok.txt", "content")
+	createTestFile(t, sourceDir, "10:30 meeting.txt", "content")
+	createTestFile(t, sourceDir, "what?.txt", "content")
+	createTestFile(t, sourceDir, "trailing.", "content")
+	createTestFile(t, sourceDir, "CON.txt", "content")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.DestFSType = config.DestFSVFAT
+
+	err = engine.Analyze()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	status := engine.GetStatus()
+	g.Expect(status.IllegalNames).To(ConsistOf("10:30 meeting.txt", "what?.txt", "trailing.", "CON.txt"))
+	g.Expect(status.SanitizedNames).To(BeEmpty())
+	g.Expect(status.TotalFiles).To(Equal(1))
+}
+
+// TestEngine_SanitizeNames_RenamesConsistently verifies that sanitized names are copied
+// under their safe name and recognized as synced on the next run.
+func TestEngine_SanitizeNames_RenamesConsistently(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "ok.txt", "content")
+	createNestedTestFile(t, sourceDir, "dir:1/what?.txt", "content")
+
+	newEngine := func() *syncengine.Engine {
+		engine, err := syncengine.NewEngine(sourceDir, destDir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		engine.ChangeType = config.FluctuatingCount
+		engine.DestFSType = config.DestFSNTFS
+		engine.SanitizeNames = true
+
+		return engine
+	}
+
+	engine := newEngine()
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.IllegalNames).To(BeEmpty())
+	g.Expect(status.SanitizedNames).To(ConsistOf(syncengine.SanitizedName{
+		SourcePath: filepath.Join("dir:1", "what?.txt"),
+		DestPath:   filepath.Join("dir_1", "what_.txt"),
+	}))
+
+	g.Expect(engine.Sync()).To(Succeed())
+	g.Expect(filepath.Join(destDir, "dir_1", "what_.txt")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "dir:1")).NotTo(BeAnExistingFile())
+
+	// A second run maps to the same names, so nothing is copied or deleted
+	again := newEngine()
+	g.Expect(again.Analyze()).To(Succeed())
+
+	status = again.GetStatus()
+	g.Expect(status.TotalFiles).To(BeZero())
+	g.Expect(status.FilesOnlyInDest).To(BeZero())
+}
+
+// TestEngine_CaseConflictPolicy_ResolvesDeterministically verifies each policy's handling of
+// source files whose paths differ only in case, and that other files are unaffected.
+func TestEngine_CaseConflictPolicy_ResolvesDeterministically(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		policy  config.CaseConflict
+		kept    string
+		planned []string
+	}{
+		{config.CaseConflictSkip, "", []string{"other.txt"}},
+		{config.CaseConflictFirstBySort, "README.md", []string{"README.md", "other.txt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			sourceDir := t.TempDir()
+			destDir := t.TempDir()
+
+			createTestFile(t, sourceDir, "Readme.md", "mixed")
+			createTestFile(t, sourceDir, "README.md", "upper")
+			createTestFile(t, sourceDir, "other.txt", "other")
+
+			// The destination copy of a left-out spelling isn't an orphan
+			createTestFile(t, destDir, "Readme.md", "mixed")
+
+			engine, err := syncengine.NewEngine(sourceDir, destDir)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			engine.ChangeType = config.Content
+			engine.CaseConflictPolicy = tt.policy
+
+			g.Expect(engine.Analyze()).To(Succeed())
+
+			status := engine.GetStatus()
+			g.Expect(status.CaseConflicts).To(Equal([]syncengine.CaseConflict{
+				{Paths: []string{"README.md", "Readme.md"}, Kept: tt.kept},
+			}))
+			g.Expect(status.FilesToDelete).To(BeZero())
+
+			planned := make([]string, 0)
+			for _, entry := range engine.PlanEntries() {
+				planned = append(planned, entry.RelativePath)
+			}
+
+			g.Expect(planned).To(ConsistOf(tt.planned))
+		})
+	}
+}
+
+// TestEngine_CaseConflictPolicy_ErrorListsCollisions verifies that the error policy stops the
+// analysis and names every colliding path.
+func TestEngine_CaseConflictPolicy_ErrorListsCollisions(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "Readme.md", "mixed")
+	createTestFile(t, sourceDir, "README.md", "upper")
+	createNestedTestFile(t, sourceDir, "docs/a.txt", "a")
+	createNestedTestFile(t, sourceDir, "docs/A.TXT", "A")
+
+	engine, err := syncengine.NewEngine(sourceDir, t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.CaseConflictPolicy = config.CaseConflictError
+
+	err = engine.Analyze()
+	g.Expect(err).To(MatchError(syncengine.ErrCaseConflict))
+	g.Expect(err.Error()).To(ContainSubstring("README.md, Readme.md; " +
+		filepath.Join("docs", "A.TXT") + ", " + filepath.Join("docs", "a.txt")))
+}
@@ -0,0 +1,68 @@
+package syncengine_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_DeleteOrphansOff_KeepsDestinationExtras verifies that with DeleteOrphans off, files
+// and directories only in the destination survive the sync while new files are still copied.
+func TestEngine_DeleteOrphansOff_KeepsDestinationExtras(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "new.txt", "new")
+	createTestFile(t, destDir, "other.txt", "someone else's")
+	createNestedTestFile(t, destDir, "other-dir/file.txt", "also theirs")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.DeleteOrphans = false
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.DeletionDisabled).To(BeTrue())
+	g.Expect(status.OrphansKept).To(Equal(2))
+	g.Expect(status.OrphanBytesKept).To(Equal(int64(len("someone else's") + len("also theirs"))))
+	g.Expect(status.FilesToDelete).To(Equal(0))
+	g.Expect(engine.PlanEntries()).To(ConsistOf(
+		syncengine.PlanEntry{RelativePath: "new.txt", Size: 3, Action: syncengine.ActionCreate},
+	))
+
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(readFile(t, destDir, "new.txt")).To(Equal("new"))
+	g.Expect(readFile(t, destDir, "other.txt")).To(Equal("someone else's"))
+	g.Expect(readFile(t, destDir, "other-dir/file.txt")).To(Equal("also theirs"))
+	g.Expect(engine.GetStatus().FilesDeleted).To(Equal(0))
+}
+
+// TestEngine_DeleteOrphansOff_SkipsCountShortcut verifies that a kept orphan can't make the
+// destination's file count match the source's and hide a missing file.
+func TestEngine_DeleteOrphansOff_SkipsCountShortcut(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "new.txt", "new")
+	createTestFile(t, destDir, "other.txt", "theirs")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.DeleteOrphans = false
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	g.Expect(engine.GetStatus().TotalFiles).To(Equal(1))
+}
@@ -0,0 +1,46 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_Order_NewestFirst verifies that the copy queue is sorted by source modtime, newest first.
+func TestEngine_Order_NewestFirst(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i, name := range []string{"oldest.txt", "middle.txt", "newest.txt"} {
+		createTestFile(t, sourceDir, name, name)
+
+		modTime := base.Add(time.Duration(i) * time.Hour)
+		g.Expect(os.Chtimes(filepath.Join(sourceDir, name), modTime, modTime)).To(Succeed())
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.Order = config.OrderNewestFirst
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	var order []string
+	for _, file := range engine.Status.FilesToSync {
+		order = append(order, file.RelativePath)
+	}
+
+	g.Expect(order).To(Equal([]string{"newest.txt", "middle.txt", "oldest.txt"}))
+	g.Expect(engine.Status.FilesToSync[0].ModTime).To(BeTemporally("==", base.Add(2*time.Hour)))
+}
@@ -0,0 +1,46 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestNewEngine_RejectsOverlappingPaths verifies that an engine can't be created to sync a
+// directory into itself, into a directory inside it, or into one of its parents, however the
+// paths are spelled.
+func TestNewEngine_RejectsOverlappingPaths(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	root := t.TempDir()
+	sourceDir := filepath.Join(root, "source")
+	link := filepath.Join(root, "link")
+
+	g.Expect(os.Mkdir(sourceDir, 0o750)).To(Succeed())
+	g.Expect(os.Symlink(sourceDir, link)).To(Succeed())
+
+	_, err := syncengine.NewEngine(sourceDir, link+"/")
+	g.Expect(err).To(MatchError(config.ErrSameSourceAndDest))
+
+	_, err = syncengine.NewEngine(sourceDir, filepath.Join(link, "backup"))
+	g.Expect(err).To(MatchError(config.ErrDestInsideSource))
+
+	_, err = syncengine.NewEngine(sourceDir, root)
+	g.Expect(err).To(MatchError(config.ErrSourceInsideDest))
+
+	_, err = syncengine.NewMultiSourceEngine([]config.SourceDir{
+		{Name: "other", Path: t.TempDir()},
+		{Name: "source", Path: sourceDir},
+	}, filepath.Join(sourceDir, "backup"))
+	g.Expect(err).To(MatchError(config.ErrDestInsideSource))
+
+	engine, err := syncengine.NewEngine(sourceDir, filepath.Join(root, "dest"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	engine.Close()
+}
@@ -0,0 +1,81 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_OwnerFilter_SyncsOnlySelectedOwner verifies that only the selected user's files are
+// synced, and other users' files at the destination are not deleted as orphans.
+func TestEngine_OwnerFilter_SyncsOnlySelectedOwner(t *testing.T) {
+	t.Parallel()
+
+	if os.Geteuid() != 0 {
+		t.Skip("changing file ownership requires root")
+	}
+
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	const otherUID = 12345
+
+	createTestFile(t, sourceDir, "root.txt", "root's file")
+	createTestFile(t, sourceDir, "user.txt", "user's file")
+	createTestFile(t, destDir, "root-only-at-dest.txt", "keep me")
+	g.Expect(os.Chown(filepath.Join(sourceDir, "user.txt"), otherUID, otherUID)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	uid := otherUID
+	engine.OwnerFilter = config.OwnerFilter{UID: &uid}
+	engine.Workers = 1
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.GetStatus().OwnerExcludedFiles).To(Equal(1))
+	g.Expect(engine.Sync()).To(Succeed())
+
+	_, err = os.Stat(filepath.Join(destDir, "user.txt"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	_, err = os.Stat(filepath.Join(destDir, "root.txt"))
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+
+	_, err = os.Stat(filepath.Join(destDir, "root-only-at-dest.txt"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+}
+
+// TestEngine_OwnerFilter_MatchesCurrentUser verifies that filtering on the running user's ID
+// selects the files that user created.
+func TestEngine_OwnerFilter_MatchesCurrentUser(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "a")
+	createNestedTestFile(t, sourceDir, "sub/b.txt", "b")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	uid := os.Getuid()
+	engine.OwnerFilter = config.OwnerFilter{UID: &uid}
+	engine.Workers = 1
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.OwnerExcludedFiles).To(BeZero())
+	g.Expect(status.ProcessedFiles).To(Equal(2))
+}
@@ -0,0 +1,196 @@
+package syncengine_test
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestEngine_Analyze_ScansSourceAndDestInParallel verifies that a slow destination scan
+// overlaps the source scan instead of running after it.
+//
+//nolint:paralleltest // Timing-sensitive; runs before the parallel tests compete for CPU
+func TestEngine_Analyze_ScansSourceAndDestInParallel(t *testing.T) {
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	const filesPerSide = 5
+
+	const perEntryDelay = 40 * time.Millisecond
+
+	for i := range filesPerSide {
+		name := fmt.Sprintf("file%d.txt", i)
+		createTestFile(t, sourceDir, name, "content")
+		createTestFile(t, destDir, name, "content")
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.FileOps = fileops.NewDualFileOps(
+		&slowScanFS{FileSystem: filesystem.NewRealFileSystem(), delay: perEntryDelay},
+		&slowScanFS{FileSystem: filesystem.NewRealFileSystem(), delay: perEntryDelay},
+	)
+
+	start := time.Now()
+	err = engine.Analyze()
+	elapsed := time.Since(start)
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	// Sequential scans would take at least two sides' worth of delay
+	sequential := 2 * filesPerSide * perEntryDelay
+	g.Expect(elapsed).To(BeNumerically("<", sequential*3/4))
+}
+
+// TestEngine_Analyze_CancelStopsScans verifies that cancelling during a slow scan
+// aborts the analysis without waiting for the walk to finish.
+//
+//nolint:paralleltest // Timing-sensitive; runs before the parallel tests compete for CPU
+func TestEngine_Analyze_CancelStopsScans(t *testing.T) {
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	const fileCount = 50
+
+	const perEntryDelay = 20 * time.Millisecond
+
+	for i := range fileCount {
+		createTestFile(t, sourceDir, fmt.Sprintf("file%d.txt", i), "content")
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.FileOps = fileops.NewDualFileOps(
+		&slowScanFS{FileSystem: filesystem.NewRealFileSystem(), delay: perEntryDelay},
+		filesystem.NewRealFileSystem(),
+	)
+
+	go func() {
+		time.Sleep(5 * perEntryDelay)
+		engine.Cancel()
+	}()
+
+	start := time.Now()
+	err = engine.Analyze()
+	elapsed := time.Since(start)
+
+	g.Expect(errors.Is(err, syncengine.ErrAnalysisCancelled)).To(BeTrue(), "got %v", err)
+	g.Expect(elapsed).To(BeNumerically("<", fileCount*perEntryDelay/2))
+}
+
+// TestEngine_Analyze_CancelStopsCount verifies that cancelling while counting files for the
+// monotonic-count check aborts the analysis without waiting for the count to finish.
+//
+//nolint:paralleltest // Timing-sensitive; runs before the parallel tests compete for CPU
+func TestEngine_Analyze_CancelStopsCount(t *testing.T) {
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	const fileCount = 50
+
+	const perEntryDelay = 20 * time.Millisecond
+
+	for i := range fileCount {
+		createTestFile(t, sourceDir, fmt.Sprintf("file%d.txt", i), "content")
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.MonotonicCount
+	engine.FileOps = fileops.NewDualFileOps(
+		&slowScanFS{FileSystem: filesystem.NewRealFileSystem(), delay: perEntryDelay},
+		filesystem.NewRealFileSystem(),
+	)
+
+	go func() {
+		time.Sleep(5 * perEntryDelay)
+		engine.Cancel()
+	}()
+
+	start := time.Now()
+	err = engine.Analyze()
+	elapsed := time.Since(start)
+
+	g.Expect(errors.Is(err, syncengine.ErrAnalysisCancelled)).To(BeTrue(), "got %v", err)
+	g.Expect(elapsed).To(BeNumerically("<", fileCount*perEntryDelay/2))
+}
+
+// TestEngine_Analyze_FailedScanStopsTheOther verifies that a source scan failing stops a slow
+// destination scan, and that the failure is reported rather than a cancellation.
+//
+//nolint:paralleltest // Timing-sensitive; runs before the parallel tests compete for CPU
+func TestEngine_Analyze_FailedScanStopsTheOther(t *testing.T) {
+	g := NewWithT(t)
+
+	sourceDir := filepath.Join(t.TempDir(), "missing")
+	destDir := t.TempDir()
+
+	const fileCount = 50
+
+	const perEntryDelay = 20 * time.Millisecond
+
+	for i := range fileCount {
+		createTestFile(t, destDir, fmt.Sprintf("file%d.txt", i), "content")
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.FileOps = fileops.NewDualFileOps(
+		filesystem.NewRealFileSystem(),
+		&slowScanFS{FileSystem: filesystem.NewRealFileSystem(), delay: perEntryDelay},
+	)
+
+	start := time.Now()
+	err = engine.Analyze()
+	elapsed := time.Since(start)
+
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("failed to scan source"))
+	g.Expect(errors.Is(err, syncengine.ErrCancelled)).To(BeFalse(), "got %v", err)
+	g.Expect(elapsed).To(BeNumerically("<", fileCount*perEntryDelay/2))
+}
+
+// slowScanFS delays every scanned entry to simulate a slow (e.g. network) filesystem.
+type slowScanFS struct {
+	filesystem.FileSystem
+
+	delay time.Duration
+}
+
+func (s *slowScanFS) Scan(path string) filesystem.FileScanner {
+	return &slowScanner{FileScanner: s.FileSystem.Scan(path), delay: s.delay}
+}
+
+type slowScanner struct {
+	filesystem.FileScanner
+
+	delay time.Duration
+}
+
+func (s *slowScanner) Next() (filesystem.FileInfo, bool) {
+	time.Sleep(s.delay)
+
+	return s.FileScanner.Next()
+}
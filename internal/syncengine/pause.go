@@ -0,0 +1,111 @@
+package syncengine
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// IsPaused reports whether Pause is holding workers between files.
+func (e *Engine) IsPaused() bool {
+	e.pauseMu.Lock()
+	defer e.pauseMu.Unlock()
+
+	return e.paused
+}
+
+// Pause stops workers from starting new files until Unpause, e.g. to free bandwidth for a while.
+// Copies in flight are finished rather than suspended mid-file, and workers wait between files
+// without exiting, so the sync carries on with the same workers once resumed. Cancel still
+// stops a paused sync. Pausing a paused engine does nothing.
+func (e *Engine) Pause() {
+	e.pauseMu.Lock()
+
+	if e.paused {
+		e.pauseMu.Unlock()
+		return
+	}
+
+	e.paused = true
+	e.pausedAt = e.TimeProvider.Now()
+
+	e.Status.mu.Lock()
+	e.Status.Paused = true
+	e.Status.mu.Unlock()
+
+	e.pauseMu.Unlock()
+
+	e.logToFile("Paused", "active", atomic.LoadInt32(&e.Status.ActiveWorkers))
+	e.notifyStatusUpdate()
+}
+
+// Unpause lets workers paused by Pause start new files again. The rate samples from before the
+// pause are dropped, since the gap between them and the next would read as a slowdown.
+// Unpausing an engine that isn't paused does nothing.
+func (e *Engine) Unpause() {
+	e.pauseMu.Lock()
+
+	if !e.paused {
+		e.pauseMu.Unlock()
+		return
+	}
+
+	e.paused = false
+	atomic.AddInt32(&e.pauses, 1)
+
+	e.Status.mu.Lock()
+	e.Status.Paused = false
+	e.Status.Workers.RecentSamples = nil
+	e.Status.mu.Unlock()
+
+	e.pauseGate().Broadcast()
+	pausedFor := e.TimeProvider.Now().Sub(e.pausedAt)
+	e.pauseMu.Unlock()
+
+	e.logToFile("Resumed", "paused_for", pausedFor)
+	e.notifyStatusUpdate()
+}
+
+// pauseGate returns the condition paused workers wait on, creating it on first use.
+// Must be called with pauseMu held.
+func (e *Engine) pauseGate() *sync.Cond {
+	if e.pauseCond == nil {
+		e.pauseCond = sync.NewCond(&e.pauseMu)
+	}
+
+	return e.pauseCond
+}
+
+// wakePausedWorkers wakes workers waiting out a pause, so they see a cancellation.
+func (e *Engine) wakePausedWorkers() {
+	e.pauseMu.Lock()
+	defer e.pauseMu.Unlock()
+
+	e.pauseGate().Broadcast()
+}
+
+// waitWhilePaused blocks a worker between files while the engine is paused, counting it in
+// Status.PausedWorkers meanwhile. It reports false if the sync was cancelled, so the worker
+// should stop.
+func (e *Engine) waitWhilePaused() bool {
+	e.pauseMu.Lock()
+	defer e.pauseMu.Unlock()
+
+	if e.paused && e.checkCancellation() == nil {
+		e.addPausedWorkers(1)
+
+		for e.paused && e.checkCancellation() == nil {
+			e.pauseGate().Wait()
+		}
+
+		e.addPausedWorkers(-1)
+	}
+
+	return e.checkCancellation() == nil
+}
+
+// addPausedWorkers adjusts Status.PausedWorkers by delta.
+func (e *Engine) addPausedWorkers(delta int) {
+	e.Status.mu.Lock()
+	e.Status.PausedWorkers += delta
+	e.Status.mu.Unlock()
+}
@@ -0,0 +1,103 @@
+package syncengine_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_Pause_FinishesCopyInFlight verifies that pausing mid-copy lets the file in flight
+// finish, then holds the worker between files, still active, until Unpause.
+func TestEngine_Pause_FinishesCopyInFlight(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "first")
+	createTestFile(t, sourceDir, "b.txt", "second")
+	createTestFile(t, sourceDir, "c.txt", "third")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.Workers = 1
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	// Pause as soon as the first file is opened (Pause notifies status callbacks too)
+	var pausing atomic.Bool
+
+	engine.RegisterStatusCallback(func(_ *syncengine.Status) {
+		if pausing.CompareAndSwap(false, true) {
+			engine.Pause()
+		}
+	})
+
+	done := make(chan error, 1)
+
+	go func() { done <- engine.Sync() }()
+
+	g.Eventually(func() int { return engine.GetStatus().PausedWorkers }).WithTimeout(5 * time.Second).
+		Should(Equal(1))
+
+	status := engine.GetStatus()
+	g.Expect(status.Paused).To(BeTrue())
+	g.Expect(status.ProcessedFiles).To(Equal(1))
+	g.Expect(status.ActiveWorkers).To(Equal(int32(1)))
+	g.Consistently(done).WithTimeout(100 * time.Millisecond).ShouldNot(Receive())
+
+	engine.Unpause()
+
+	g.Eventually(done).WithTimeout(5 * time.Second).Should(Receive(BeNil()))
+
+	status = engine.GetStatus()
+	g.Expect(status.Paused).To(BeFalse())
+	g.Expect(status.PausedWorkers).To(BeZero())
+	g.Expect(status.ProcessedFiles).To(Equal(3))
+	g.Expect(readDir(t, destDir)).To(Equal(map[string]string{"a.txt": "first", "b.txt": "second", "c.txt": "third"}))
+}
+
+// TestEngine_Pause_CancelStopsPausedWorkers verifies that cancelling a paused sync stops its
+// waiting workers rather than leaving them paused, without starting another file.
+func TestEngine_Pause_CancelStopsPausedWorkers(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "first")
+	createTestFile(t, sourceDir, "b.txt", "second")
+
+	engine, err := syncengine.NewEngine(sourceDir, t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.Workers = 2
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	engine.Pause()
+
+	done := make(chan error, 1)
+
+	go func() { done <- engine.Sync() }()
+
+	g.Eventually(func() int { return engine.GetStatus().PausedWorkers }).WithTimeout(5 * time.Second).
+		Should(Equal(2))
+
+	engine.Cancel()
+
+	g.Eventually(done).WithTimeout(5 * time.Second).Should(Receive())
+
+	status := engine.GetStatus()
+	g.Expect(status.ProcessedFiles).To(BeZero())
+	g.Expect(status.PausedWorkers).To(BeZero())
+}
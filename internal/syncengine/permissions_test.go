@@ -0,0 +1,87 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_PreservePermissions_FixesPermissionOnlyDrift verifies that a chmod on an
+// otherwise unchanged source file reaches the destination without copying content.
+func TestEngine_PreservePermissions_FixesPermissionOnlyDrift(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "script.sh", "#!/bin/sh\n")
+	createTestFile(t, destDir, "script.sh", "#!/bin/sh\n")
+
+	srcPath := filepath.Join(sourceDir, "script.sh")
+	dstPath := filepath.Join(destDir, "script.sh")
+
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	g.Expect(os.Chtimes(srcPath, modTime, modTime)).To(Succeed())
+	g.Expect(os.Chtimes(dstPath, modTime, modTime)).To(Succeed())
+	g.Expect(os.Chmod(srcPath, 0o755)).To(Succeed())
+	g.Expect(os.Chmod(dstPath, 0o644)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.PreservePermissions = true
+
+	err = engine.Analyze()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(engine.GetStatus().TotalFiles).To(BeZero())
+	g.Expect(engine.PlanEntries()).To(ConsistOf(
+		syncengine.PlanEntry{RelativePath: "script.sh", Action: syncengine.ActionMetadata},
+	))
+
+	err = engine.Sync()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	status := engine.GetStatus()
+	g.Expect(status.MetadataUpdates).To(Equal(1))
+	g.Expect(status.ProcessedFiles).To(BeZero())
+	g.Expect(status.Errors).To(BeEmpty())
+
+	info, err := os.Stat(dstPath)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o755)))
+	g.Expect(info.ModTime()).To(BeTemporally("==", modTime))
+}
+
+// TestEngine_PreservePermissions_CopiesMode verifies that copied files get the source's permission bits.
+func TestEngine_PreservePermissions_CopiesMode(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "private.txt", "secret")
+	g.Expect(os.Chmod(filepath.Join(sourceDir, "private.txt"), 0o600)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.PreservePermissions = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	info, err := os.Stat(filepath.Join(destDir, "private.txt"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+}
@@ -0,0 +1,44 @@
+package syncengine_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_PhaseTimings_RecordsEachPhase verifies that a full analysis and sync
+// record time for every phase that ran, in run order.
+func TestEngine_PhaseTimings_RecordsEachPhase(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "new.txt", "new")
+	createTestFile(t, destDir, "orphan.txt", "orphan")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	var phases []string
+	for _, timing := range engine.GetStatus().PhaseTimings() {
+		phases = append(phases, timing.Phase)
+	}
+
+	g.Expect(phases).To(Equal([]string{
+		syncengine.PhaseScanSource,
+		syncengine.PhaseScanDest,
+		syncengine.PhaseCompare,
+		syncengine.PhaseDelete,
+		syncengine.PhaseCopy,
+	}))
+}
@@ -0,0 +1,238 @@
+package syncengine
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// FileAction is what the sync plan will do with a file.
+type FileAction string
+
+// FileAction values.
+const (
+	ActionCreate    FileAction = "create"    // File is missing from the destination
+	ActionOverwrite FileAction = "overwrite" // Destination copy differs and will be replaced
+	ActionDelete    FileAction = "delete"    // Destination file doesn't exist in the source
+	ActionMetadata  FileAction = "metadata"  // Content matches; only permissions are updated
+)
+
+// Orphan age buckets, by how recently the destination file was last modified. Recent orphans
+// were written after the source stopped having them, which suggests the wrong source or filter.
+const (
+	OrphanAgeDay   = "last day"
+	OrphanAgeWeek  = "last week"
+	OrphanAgeMonth = "last month"
+	OrphanAgeOlder = "older"
+)
+
+// File size buckets for the plan's size histogram, a power of ten apart, from under 1KB to over 1GB.
+const (
+	SizeUnder1KB = "<1KB"
+	Size1KB      = "1-10KB"
+	Size10KB     = "10-100KB"
+	Size100KB    = "100KB-1MB"
+	Size1MB      = "1-10MB"
+	Size10MB     = "10-100MB"
+	Size100MB    = "100MB-1GB"
+	SizeOver1GB  = ">1GB"
+)
+
+// rsync --itemize-changes strings for entries that aren't described column by column.
+const (
+	itemizeNewFile     = ">f+++++++++" // File created at the destination
+	itemizeNewDir      = "cd+++++++++" // Directory created at the destination
+	itemizeDeleting    = "*deleting"   // File or directory removed from the destination
+	itemizePermissions = ".f...p....." // Only the permission bits changed
+	itemizeTimeOnly    = ".f..t......" // Content already matched; only the modtime was updated
+)
+
+// PlanEntry is a single file in the sync plan, as shown to the user before syncing.
+type PlanEntry struct {
+	RelativePath string
+	Size         int64
+	Action       FileAction
+}
+
+// permissionUpdate is a metadata-only change: the destination copy's content is current
+// but its permission bits differ from the source.
+type permissionUpdate struct {
+	relPath     string
+	destRelPath string
+	mode        os.FileMode
+}
+
+// FormatOrphansByAge lists the non-empty age buckets, newest first (e.g. "2 last day, 40 older").
+func FormatOrphansByAge(byAge map[string]int) string {
+	var parts []string
+
+	for _, bucket := range []string{OrphanAgeDay, OrphanAgeWeek, OrphanAgeMonth, OrphanAgeOlder} {
+		if byAge[bucket] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", byAge[bucket], bucket))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// FormatSizeHistogram lists the non-empty size buckets, smallest first (e.g. "1200 <1KB, 3 >1GB").
+func FormatSizeHistogram(histogram map[string]int) string {
+	var parts []string
+
+	for _, bucket := range []string{
+		SizeUnder1KB, Size1KB, Size10KB, Size100KB, Size1MB, Size10MB, Size100MB, SizeOver1GB,
+	} {
+		if histogram[bucket] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", histogram[bucket], bucket))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// entryKind names what a scanned entry is, for reporting type changes.
+func entryKind(info *fileops.FileInfo) string {
+	switch {
+	case info.IsDir:
+		return "directory"
+	case info.IsSymlink:
+		return "symlink"
+	default:
+		return "file"
+	}
+}
+
+// entryTypeChanged reports whether the destination entry is of a type the source entry can't
+// simply be written over. A source symlink is copied as its target's content or recreated as a
+// link, either of which replaces a regular destination file; a destination symlink is never
+// written through.
+func entryTypeChanged(srcFile, dstFile *fileops.FileInfo) bool {
+	return srcFile.IsDir != dstFile.IsDir || (dstFile.IsSymlink && !srcFile.IsSymlink)
+}
+
+// itemizeChanges returns rsync's --itemize-changes flags for a file the plan copies: ">f" then
+// one column each for checksum, size, time, permissions, owner, group, unused, ACL and xattr,
+// with "." for unchanged and "+" throughout for a new file. contentCompared marks the checksum
+// column, for change types that found the difference by reading content.
+func itemizeChanges(srcFile, dstFile *fileops.FileInfo, contentCompared, comparePermissions bool) string {
+	if dstFile == nil || entryTypeChanged(srcFile, dstFile) {
+		return itemizeNewFile
+	}
+
+	flags := []byte(">f.........")
+
+	if contentCompared {
+		flags[2] = 'c'
+	}
+
+	if srcFile.Size != dstFile.Size {
+		flags[3] = 's'
+	}
+
+	if !srcFile.ModTime.Equal(dstFile.ModTime) {
+		flags[4] = 't'
+	}
+
+	if comparePermissions && srcFile.Mode != dstFile.Mode {
+		flags[5] = 'p'
+	}
+
+	return string(flags)
+}
+
+// sizeBucket returns the size histogram bucket of a file of size bytes.
+func sizeBucket(size int64) string {
+	const (
+		kib = 1024
+		mib = 1024 * kib
+		gib = 1024 * mib
+	)
+
+	switch {
+	case size < kib:
+		return SizeUnder1KB
+	case size < 10*kib:
+		return Size1KB
+	case size < 100*kib:
+		return Size10KB
+	case size < mib:
+		return Size100KB
+	case size < 10*mib:
+		return Size1MB
+	case size < 100*mib:
+		return Size10MB
+	case size < gib:
+		return Size100MB
+	default:
+		return SizeOver1GB
+	}
+}
+
+// sizeHistogram counts the files to sync by size bucket.
+func sizeHistogram(files []*FileToSync) map[string]int {
+	histogram := make(map[string]int)
+
+	for _, file := range files {
+		histogram[sizeBucket(file.Size)]++
+	}
+
+	return histogram
+}
+
+// orphanAge returns the age bucket of a destination file modified at modTime.
+func orphanAge(modTime, now time.Time) string {
+	const day = 24 * time.Hour
+
+	age := now.Sub(modTime)
+
+	switch {
+	case age < day:
+		return OrphanAgeDay
+	case age < 7*day:
+		return OrphanAgeWeek
+	case age < 30*day:
+		return OrphanAgeMonth
+	default:
+		return OrphanAgeOlder
+	}
+}
+
+// orphansByAge counts the destination files that don't exist in source, by age bucket.
+func orphansByAge(sourceFiles, destFiles map[string]*fileops.FileInfo, now time.Time) map[string]int {
+	byAge := make(map[string]int)
+
+	for relPath, dstFile := range destFiles {
+		if dstFile.IsDir {
+			continue
+		}
+
+		if _, exists := sourceFiles[relPath]; !exists {
+			byAge[orphanAge(dstFile.ModTime, now)]++
+		}
+	}
+
+	return byAge
+}
+
+// orphanedFileEntries returns the destination files that don't exist in source, sorted by path.
+func orphanedFileEntries(sourceFiles, destFiles map[string]*fileops.FileInfo) []PlanEntry {
+	var entries []PlanEntry
+
+	for relPath, dstFile := range destFiles {
+		if dstFile.IsDir {
+			continue
+		}
+
+		if _, exists := sourceFiles[relPath]; !exists {
+			entries = append(entries, PlanEntry{RelativePath: relPath, Size: dstFile.Size, Action: ActionDelete})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].RelativePath < entries[j].RelativePath })
+
+	return entries
+}
@@ -0,0 +1,128 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_PlanEntries_CategorizesActions verifies that the full plan lists new files as
+// creates, changed files as overwrites and orphans as deletes.
+func TestEngine_PlanEntries_CategorizesActions(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "new.txt", "new")
+	createTestFile(t, sourceDir, "changed.txt", "changed content")
+	createTestFile(t, sourceDir, "same.txt", "same")
+	createTestFile(t, destDir, "changed.txt", "old")
+	createTestFile(t, destDir, "same.txt", "same")
+	createNestedTestFile(t, destDir, "gone/orphan.txt", "orphan")
+
+	// Files written one after the other needn't get the same modtime
+	modTime := time.Now().Add(-time.Hour)
+	g.Expect(os.Chtimes(filepath.Join(sourceDir, "same.txt"), modTime, modTime)).To(Succeed())
+	g.Expect(os.Chtimes(filepath.Join(destDir, "same.txt"), modTime, modTime)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+
+	err = engine.Analyze()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(engine.PlanEntries()).To(ConsistOf(
+		syncengine.PlanEntry{RelativePath: "new.txt", Size: 3, Action: syncengine.ActionCreate},
+		syncengine.PlanEntry{RelativePath: "changed.txt", Size: 15, Action: syncengine.ActionOverwrite},
+		syncengine.PlanEntry{RelativePath: "gone/orphan.txt", Size: 6, Action: syncengine.ActionDelete},
+	))
+}
+
+// TestEngine_Analyze_CountsOrphansByAge verifies that orphaned destination files are bucketed by
+// how recently they were modified.
+func TestEngine_Analyze_CountsOrphansByAge(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "kept.txt", "kept")
+	createTestFile(t, destDir, "kept.txt", "kept")
+
+	now := time.Now()
+
+	for name, age := range map[string]time.Duration{
+		"today.txt":     time.Hour,
+		"this-week.txt": 3 * 24 * time.Hour,
+		"old-1.txt":     90 * 24 * time.Hour,
+		"old-2.txt":     400 * 24 * time.Hour,
+	} {
+		createTestFile(t, destDir, name, "orphan")
+
+		modTime := now.Add(-age)
+		g.Expect(os.Chtimes(filepath.Join(destDir, name), modTime, modTime)).To(Succeed())
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.GetStatus().OrphansByAge).To(Equal(map[string]int{
+		syncengine.OrphanAgeDay:   1,
+		syncengine.OrphanAgeWeek:  1,
+		syncengine.OrphanAgeOlder: 2,
+	}))
+}
+
+// TestEngine_Analyze_CountsFilesToSyncBySize verifies that the files to sync are bucketed by
+// size on power-of-ten boundaries, and that files already in sync aren't counted.
+func TestEngine_Analyze_CountsFilesToSyncBySize(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "synced.txt", "synced")
+	createTestFile(t, destDir, "synced.txt", "synced")
+
+	for name, size := range map[string]int64{
+		"tiny.txt":   10,
+		"small.txt":  1023,
+		"exact.txt":  1024,
+		"medium.bin": 200 * 1024,
+		"huge.bin":   2 << 30, // Sparse, so it costs no disk space
+	} {
+		createTestFile(t, sourceDir, name, "")
+		g.Expect(os.Truncate(filepath.Join(sourceDir, name), size)).To(Succeed())
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	histogram := engine.GetStatus().SizeHistogram
+	g.Expect(histogram).To(Equal(map[string]int{
+		syncengine.SizeUnder1KB: 2,
+		syncengine.Size1KB:      1,
+		syncengine.Size100KB:    1,
+		syncengine.SizeOver1GB:  1,
+	}))
+	g.Expect(syncengine.FormatSizeHistogram(histogram)).To(Equal("2 <1KB, 1 1-10KB, 1 100KB-1MB, 1 >1GB"))
+}
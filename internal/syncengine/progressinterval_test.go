@@ -0,0 +1,41 @@
+package syncengine //nolint:testpackage // Testing private methods
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Gomega convention
+)
+
+// TestNotifyAnalysisProgress_ThrottledByProgressInterval verifies that per-file analysis progress
+// notifies status callbacks at most once per ProgressInterval, and on every call when it's 0.
+func TestNotifyAnalysisProgress_ThrottledByProgressInterval(t *testing.T) {
+	t.Parallel()
+
+	gomega := NewWithT(t)
+
+	clock := &fixedTimeProvider{now: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	engine := &Engine{TimeProvider: clock, Status: &Status{}, ProgressInterval: time.Second}
+
+	notified := 0
+
+	engine.RegisterStatusCallback(func(*Status) { notified++ })
+
+	engine.notifyAnalysisProgress()
+	engine.notifyAnalysisProgress()
+	gomega.Expect(notified).To(Equal(1))
+
+	clock.now = clock.now.Add(999 * time.Millisecond)
+	engine.notifyAnalysisProgress()
+	gomega.Expect(notified).To(Equal(1))
+
+	clock.now = clock.now.Add(time.Millisecond)
+	engine.notifyAnalysisProgress()
+	gomega.Expect(notified).To(Equal(2))
+
+	engine.ProgressInterval = 0
+
+	engine.notifyAnalysisProgress()
+	engine.notifyAnalysisProgress()
+	gomega.Expect(notified).To(Equal(4))
+}
@@ -0,0 +1,57 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_ProtectDestEdits_KeepsNewerResizedDestFiles verifies that a destination file both
+// newer and a different size is kept and reported, while one that is only newer is still
+// overwritten, since that can be clock skew.
+func TestEngine_ProtectDestEdits_KeepsNewerResizedDestFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	old := time.Now().Add(-time.Hour)
+	newer := time.Now()
+
+	for _, name := range []string{"edited.txt", "skewed.txt"} {
+		createTestFile(t, sourceDir, name, "source")
+		g.Expect(os.Chtimes(filepath.Join(sourceDir, name), old, old)).To(Succeed())
+	}
+
+	createTestFile(t, destDir, "edited.txt", "source, edited at the destination")
+	createTestFile(t, destDir, "skewed.txt", "SOURCE")
+
+	for _, name := range []string{"edited.txt", "skewed.txt"} {
+		g.Expect(os.Chtimes(filepath.Join(destDir, name), newer, newer)).To(Succeed())
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.ProtectDestEdits = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.GetStatus().ProtectedDestEdits).To(Equal([]string{"edited.txt"}))
+	g.Expect(engine.Sync()).To(Succeed())
+
+	data, err := os.ReadFile(filepath.Join(destDir, "edited.txt"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal("source, edited at the destination"))
+
+	data, err = os.ReadFile(filepath.Join(destDir, "skewed.txt"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal("source"))
+}
@@ -0,0 +1,58 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_QuickContent_DetectsSampledChanges verifies that quick-content comparison catches
+// appends and same-size edits at the end of a file even when modtimes match, and misses edits
+// confined to the unsampled regions.
+func TestEngine_QuickContent_DetectsSampledChanges(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	// 100 bytes sampled 8 at a time: head [0,8), middle [46,54), tail [92,100)
+	original := strings.Repeat("0123456789", 10)
+
+	createTestFile(t, destDir, "same.bin", original)
+	createTestFile(t, sourceDir, "same.bin", original)
+	createTestFile(t, destDir, "appended.bin", original)
+	createTestFile(t, sourceDir, "appended.bin", original+"more")
+	createTestFile(t, destDir, "tail.bin", original)
+	createTestFile(t, sourceDir, "tail.bin", original[:99]+"X")
+	createTestFile(t, destDir, "unsampled.bin", original)
+	createTestFile(t, sourceDir, "unsampled.bin", original[:20]+"X"+original[21:])
+
+	// Matching modtimes so only content can tell the files apart
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, name := range []string{"same.bin", "appended.bin", "tail.bin", "unsampled.bin"} {
+		g.Expect(os.Chtimes(filepath.Join(sourceDir, name), modTime, modTime)).To(Succeed())
+		g.Expect(os.Chtimes(filepath.Join(destDir, name), modTime, modTime)).To(Succeed())
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.QuickContent
+	engine.SampleSize = 8
+
+	err = engine.Analyze()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(engine.PlanEntries()).To(ConsistOf(
+		syncengine.PlanEntry{RelativePath: "appended.bin", Size: 104, Action: syncengine.ActionOverwrite},
+		syncengine.PlanEntry{RelativePath: "tail.bin", Size: 100, Action: syncengine.ActionOverwrite},
+	))
+}
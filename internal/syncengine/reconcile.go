@@ -0,0 +1,181 @@
+package syncengine
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"sync"
+
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// ErrReconcileNotSupported reports a Reconcile on a destination that isn't a mirror of the source.
+var ErrReconcileNotSupported = errors.New("destination is a content-addressed store, not a mirror")
+
+// ReconcileReport is the result of Reconcile: the source files the destination doesn't mirror.
+type ReconcileReport struct {
+	SourceFiles    int      // Source files checked
+	DestFiles      int      // Files found at the destination
+	Hashed         int      // Files hashed because their sizes matched but their modtimes didn't
+	Missing        []string // In the source but not at the destination
+	SizeMismatches []string // At the destination with a different size
+	HashMismatches []string // Same size but different content (ReconcileHashes only)
+}
+
+// Clean reports whether the destination mirrors every source file checked.
+func (r *ReconcileReport) Clean() bool {
+	return len(r.Missing) == 0 && len(r.SizeMismatches) == 0 && len(r.HashMismatches) == 0
+}
+
+// Problems returns how many source files the destination doesn't mirror.
+func (r *ReconcileReport) Problems() int {
+	return len(r.Missing) + len(r.SizeMismatches) + len(r.HashMismatches)
+}
+
+// Reconcile scans both trees again and checks that the destination mirrors the source, as a
+// verification pass after Sync. Source files are filtered as analysis filters them. Presence
+// and size are checked first, so a matching tree costs only the two scans; with
+// ReconcileHashes, files whose sizes match but whose modtimes don't are also hashed, since a
+// faithful copy keeps its source's modtime. The report is also kept in Status.Reconcile.
+//
+// Files the sync deliberately left alone, such as those outside a retention window or ones
+// that failed, are reported too, since the destination doesn't mirror them.
+func (e *Engine) Reconcile() (*ReconcileReport, error) {
+	if e.CAStore {
+		return nil, ErrReconcileNotSupported
+	}
+
+	defer e.timePhase(PhaseReconcile)()
+
+	e.FileOps.CancelChan = e.cancelChan
+
+	var sourceFiles, destFiles map[string]*fileops.FileInfo
+	var sourceErr, destErr error
+	var wg sync.WaitGroup
+	wg.Add(2) //nolint:mnd // Two parallel scans
+
+	go func() {
+		defer wg.Done()
+		sourceFiles, sourceErr = e.FileOps.ScanDirectory(e.SourcePath)
+	}()
+
+	go func() {
+		defer wg.Done()
+		destFiles, destErr = e.FileOps.ScanDestDirectoryWithProgress(e.DestPath, nil)
+	}()
+
+	wg.Wait()
+
+	if sourceErr != nil {
+		return nil, fmt.Errorf("failed to scan source: %w", sourceErr)
+	}
+
+	// A missing destination is reported as every file missing
+	if destErr != nil && !errors.Is(destErr, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to scan destination: %w", destErr)
+	}
+
+	if e.FilePattern != "" {
+		sourceFiles = e.applyFileFilter(sourceFiles)
+	}
+
+	if len(e.ExcludePatterns) > 0 {
+		sourceFiles = e.applyExcludeFilter(sourceFiles)
+	}
+
+	if e.OwnerFilter.Active() {
+		sourceFiles = e.applyOwnerFilter(sourceFiles)
+	}
+
+	report := &ReconcileReport{}
+
+	for _, dstFile := range destFiles {
+		if !dstFile.IsDir {
+			report.DestFiles++
+		}
+	}
+
+	var suspicious []string
+
+	for relPath, srcFile := range sourceFiles {
+		if srcFile.IsDir {
+			continue
+		}
+
+		report.SourceFiles++
+
+		dstRelPath := relPath
+		if destName, ok := e.destNames[relPath]; ok {
+			dstRelPath = destName
+		}
+
+		dstFile := destFiles[dstRelPath]
+
+		switch {
+		case dstFile == nil || dstFile.IsDir:
+			report.Missing = append(report.Missing, relPath)
+		case e.preservesLink(srcFile):
+			// A preserved link is checked by its target, not by what it points to
+			if !dstFile.IsSymlink || dstFile.LinkTarget != srcFile.LinkTarget {
+				report.HashMismatches = append(report.HashMismatches, relPath)
+			}
+		case dstFile.Size != srcFile.Size:
+			report.SizeMismatches = append(report.SizeMismatches, relPath)
+		case e.ReconcileHashes && !dstFile.ModTime.Equal(srcFile.ModTime):
+			suspicious = append(suspicious, relPath)
+		}
+	}
+
+	sort.Strings(suspicious)
+
+	for _, relPath := range suspicious {
+		dstRelPath := relPath
+		if destName, ok := e.destNames[relPath]; ok {
+			dstRelPath = destName
+		}
+
+		match, err := e.hashesMatch(sourceFiles[relPath].Path, destFiles[dstRelPath].Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash %s: %w", relPath, err)
+		}
+
+		report.Hashed++
+
+		if !match {
+			report.HashMismatches = append(report.HashMismatches, relPath)
+		}
+	}
+
+	sort.Strings(report.Missing)
+	sort.Strings(report.SizeMismatches)
+	sort.Strings(report.HashMismatches)
+
+	e.Status.mu.Lock()
+	e.Status.Reconcile = report
+	e.Status.mu.Unlock()
+
+	if report.Clean() {
+		e.logToFile("Reconcile: destination mirrors the source", "files", report.SourceFiles)
+	} else {
+		e.logWarn("Reconcile: destination doesn't mirror the source", "missing", len(report.Missing),
+			"size_mismatches", len(report.SizeMismatches), "hash_mismatches", len(report.HashMismatches))
+	}
+
+	return report, nil
+}
+
+// hashesMatch reports whether a source file and a destination file have the same content.
+func (e *Engine) hashesMatch(srcPath, dstPath string) (bool, error) {
+	srcHash, err := e.FileOps.ComputeSourceHash(srcPath, e.cancelChan)
+	if err != nil {
+		return false, err //nolint:wrapcheck // Wrapped by Reconcile with the relative path
+	}
+
+	dstHash, err := e.FileOps.ComputeDestHash(dstPath, e.cancelChan)
+	if err != nil {
+		return false, err //nolint:wrapcheck // Wrapped by Reconcile with the relative path
+	}
+
+	return srcHash == dstHash, nil
+}
@@ -0,0 +1,80 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+func TestEngine_ReconcileAfterSync_ReportsCleanMirror(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "alpha")
+	createNestedTestFile(t, sourceDir, "sub/b.txt", "bravo")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ReconcileAfterSync = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	report := engine.GetStatus().Reconcile
+	g.Expect(report).NotTo(BeNil())
+	g.Expect(report.Clean()).To(BeTrue())
+	g.Expect(report.SourceFiles).To(Equal(2))
+	g.Expect(report.DestFiles).To(Equal(2))
+	g.Expect(report.Hashed).To(Equal(0))
+}
+
+// TestEngine_Reconcile_FindsDifferences verifies that missing files and size mismatches are
+// found by size alone, and that only files with matching sizes but different modtimes are
+// hashed.
+func TestEngine_Reconcile_FindsDifferences(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	for _, name := range []string{"kept.txt", "gone.txt", "short.txt", "flipped.txt", "touched.txt"} {
+		createTestFile(t, sourceDir, name, "contents of "+name)
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	later := time.Now().Add(time.Hour)
+
+	g.Expect(os.Remove(filepath.Join(destDir, "gone.txt"))).To(Succeed())
+	createTestFile(t, destDir, "short.txt", "short")
+	createTestFile(t, destDir, "flipped.txt", "CONTENTS OF FLIPPED.TXT")
+	g.Expect(os.Chtimes(filepath.Join(destDir, "flipped.txt"), later, later)).To(Succeed())
+	g.Expect(os.Chtimes(filepath.Join(destDir, "touched.txt"), later, later)).To(Succeed())
+
+	engine.ReconcileHashes = true
+
+	report, err := engine.Reconcile()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(report.Clean()).To(BeFalse())
+	g.Expect(report.SourceFiles).To(Equal(5))
+	g.Expect(report.Missing).To(Equal([]string{"gone.txt"}))
+	g.Expect(report.SizeMismatches).To(Equal([]string{"short.txt"}))
+	g.Expect(report.HashMismatches).To(Equal([]string{"flipped.txt"}))
+	g.Expect(report.Hashed).To(Equal(2))
+	g.Expect(engine.GetStatus().Reconcile).To(Equal(report))
+}
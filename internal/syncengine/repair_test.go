@@ -0,0 +1,78 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_RepairMode_RecopiesOnlyCorruptedFiles verifies that a repair pass re-copies
+// files whose content differs, and ignores metadata-only differences, missing files and orphans.
+func TestEngine_RepairMode_RecopiesOnlyCorruptedFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "good.txt", "content")
+	createTestFile(t, sourceDir, "corrupt.txt", "content")
+	createTestFile(t, sourceDir, "touched.txt", "content")
+	createTestFile(t, sourceDir, "missing.txt", "content")
+
+	createTestFile(t, destDir, "good.txt", "content")
+	createTestFile(t, destDir, "corrupt.txt", "c0ntent")
+	createTestFile(t, destDir, "touched.txt", "content")
+	createTestFile(t, destDir, "orphan.txt", "content")
+
+	// Metadata-only difference must not trigger a copy
+	oldTime := time.Now().Add(-24 * time.Hour)
+	g.Expect(os.Chtimes(filepath.Join(destDir, "touched.txt"), oldTime, oldTime)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.RepairMode = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.TotalFiles).To(Equal(1))
+	g.Expect(status.FilesToDelete).To(BeZero())
+
+	g.Expect(engine.Sync()).To(Succeed())
+
+	data, err := os.ReadFile(filepath.Join(destDir, "corrupt.txt"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal("content"))
+
+	g.Expect(engine.GetStatus().RepairedFiles).To(Equal(1))
+	g.Expect(filepath.Join(destDir, "missing.txt")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "orphan.txt")).To(BeAnExistingFile())
+}
+
+// TestEngine_RepairMode_HonorsCancellation verifies that a cancelled repair pass stops verifying.
+func TestEngine_RepairMode_HonorsCancellation(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "file.txt", "content")
+	createTestFile(t, destDir, "file.txt", "content")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.RepairMode = true
+	engine.Cancel()
+
+	err = engine.Analyze()
+	g.Expect(err).To(MatchError(syncengine.ErrAnalysisCancelled))
+}
@@ -0,0 +1,182 @@
+package syncengine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Exported constants.
+const (
+	// ResumeSaveInterval is the minimum time between resume state writes during sync
+	ResumeSaveInterval = 1 * time.Second
+)
+
+// DefaultResumeStatePath returns the resume state file location for a source/dest pair.
+// The path is stable across runs so an interrupted sync can be found again.
+func DefaultResumeStatePath(source, dest string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	sum := sha256.Sum256([]byte(source + "\x00" + dest))
+
+	return filepath.Join(dir, "glowsync", "resume-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// resumeFile is a single planned file in the persisted resume state.
+type resumeFile struct {
+	RelativePath     string     `json:"relative_path"`
+	DestRelativePath string     `json:"dest_relative_path,omitempty"` // Set when the name was sanitized
+	Action           FileAction `json:"action,omitempty"`
+	Size             int64      `json:"size"`
+}
+
+// resumeDeletion is a destination file or directory the persisted plan deletes.
+type resumeDeletion struct {
+	RelativePath string `json:"relative_path"`
+	Size         int64  `json:"size,omitempty"`
+	IsDir        bool   `json:"is_dir,omitempty"`
+}
+
+// resumeState is the persisted in-progress plan for an interrupted sync.
+type resumeState struct {
+	SourcePath    string           `json:"source_path"`
+	DestPath      string           `json:"dest_path"`
+	Files         []resumeFile     `json:"files"`               // Files in plan order
+	Deletions     []resumeDeletion `json:"deletions,omitempty"` // Orphans to delete, sorted by path
+	LastCompleted int              `json:"last_completed"`      // Index of the completed prefix's last file (-1 = none)
+}
+
+// resumeTracker records sync progress so the plan can be resumed after an interruption.
+// Workers finish out of order, so only the contiguous completed prefix is trusted.
+type resumeTracker struct {
+	mu        sync.Mutex
+	path      string
+	clock     TimeProvider
+	state     resumeState
+	index     map[string]int
+	completed []bool
+	lastSave  time.Time
+}
+
+// markComplete records a completed file and periodically persists the resume point.
+func (t *resumeTracker) markComplete(relPath string) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	idx, ok := t.index[relPath]
+	if !ok {
+		return
+	}
+
+	t.completed[idx] = true
+	for t.state.LastCompleted+1 < len(t.completed) && t.completed[t.state.LastCompleted+1] {
+		t.state.LastCompleted++
+	}
+
+	if t.clock.Now().Sub(t.lastSave) >= ResumeSaveInterval {
+		_ = t.saveLocked()
+	}
+}
+
+// save persists the current resume state.
+func (t *resumeTracker) save() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.saveLocked()
+}
+
+// saveLocked writes the state file. Must be called with t.mu held.
+func (t *resumeTracker) saveLocked() error {
+	t.lastSave = t.clock.Now()
+
+	data, err := json.Marshal(t.state)
+	if err != nil {
+		return fmt.Errorf("failed to encode resume state: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(t.path), 0o750) //nolint:mnd // Standard directory permissions
+	if err != nil {
+		return fmt.Errorf("failed to create resume state directory: %w", err)
+	}
+
+	// Write to a temp file and rename so an interruption never leaves a torn state file
+	tmpPath := t.path + ".tmp"
+
+	err = os.WriteFile(tmpPath, data, 0o600) //nolint:mnd // Owner-only permissions
+	if err != nil {
+		return fmt.Errorf("failed to write resume state: %w", err)
+	}
+
+	err = os.Rename(tmpPath, t.path)
+	if err != nil {
+		return fmt.Errorf("failed to save resume state: %w", err)
+	}
+
+	return nil
+}
+
+// loadResumeState reads a resume state file, returning nil if none exists.
+func loadResumeState(path string) (*resumeState, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is derived from source/dest or set by caller
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil //nolint:nilnil // No saved state is not an error
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resume state: %w", err)
+	}
+
+	var state resumeState
+
+	err = json.Unmarshal(data, &state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse resume state %s: %w", path, err)
+	}
+
+	return &state, nil
+}
+
+// newResumeTracker creates a tracker for the given plan, timing its saves by clock.
+//
+//nolint:lll // Signature carries the whole plan
+func newResumeTracker(path, source, dest string, files []*FileToSync, deletions []resumeDeletion, clock TimeProvider) *resumeTracker {
+	tracker := &resumeTracker{
+		path:  path,
+		clock: clock,
+		state: resumeState{
+			SourcePath:    source,
+			DestPath:      dest,
+			Files:         make([]resumeFile, len(files)),
+			Deletions:     deletions,
+			LastCompleted: -1,
+		},
+		index:     make(map[string]int, len(files)),
+		completed: make([]bool, len(files)),
+	}
+
+	for i, file := range files {
+		tracker.state.Files[i] = resumeFile{
+			RelativePath:     file.RelativePath,
+			DestRelativePath: file.DestRelativePath,
+			Action:           file.Action,
+			Size:             file.Size,
+		}
+		tracker.index[file.RelativePath] = i
+	}
+
+	return tracker
+}
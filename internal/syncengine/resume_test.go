@@ -0,0 +1,154 @@
+package syncengine_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_Resume_SkipsVerifiedDoneFiles verifies that resuming skips files the saved
+// plan marks as done only when they are actually present in the destination.
+func TestEngine_Resume_SkipsVerifiedDoneFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	names := []string{"a.txt", "b.txt", "c.txt", "d.txt", "e.txt"}
+	for _, name := range names {
+		createTestFile(t, sourceDir, name, "content")
+	}
+
+	// a and b were copied before the interruption; c was marked done but has gone missing
+	createTestFile(t, destDir, "a.txt", "content")
+	createTestFile(t, destDir, "b.txt", "content")
+
+	files := make([]map[string]any, 0, len(names))
+	for _, name := range names {
+		files = append(files, map[string]any{"relative_path": name, "size": len("content")})
+	}
+
+	statePath := filepath.Join(t.TempDir(), "resume.json")
+	data, err := json.Marshal(map[string]any{
+		"source_path":    sourceDir,
+		"dest_path":      destDir,
+		"files":          files,
+		"last_completed": 2,
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(os.WriteFile(statePath, data, 0o600)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.Resume = true
+	engine.ResumeStatePath = statePath
+
+	err = engine.Analyze()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	status := engine.GetStatus()
+	g.Expect(status.ResumeSkippedFiles).To(Equal(2))
+	g.Expect(status.TotalFiles).To(Equal(3))
+
+	err = engine.Sync()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	for _, name := range names {
+		g.Expect(filepath.Join(destDir, name)).To(BeAnExistingFile())
+	}
+
+	// A completed sync leaves nothing to resume
+	g.Expect(statePath).NotTo(BeAnExistingFile())
+}
+
+// TestEngine_Resume_NoSavedStateRunsFullAnalysis verifies that --resume without a saved
+// plan falls back to a normal analysis.
+func TestEngine_Resume_NoSavedStateRunsFullAnalysis(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "content")
+	createTestFile(t, sourceDir, "b.txt", "content")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.Resume = true
+	engine.ResumeStatePath = filepath.Join(t.TempDir(), "resume.json")
+
+	err = engine.Analyze()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	status := engine.GetStatus()
+	g.Expect(status.ResumeSkippedFiles).To(BeZero())
+	g.Expect(status.TotalFiles).To(Equal(2))
+}
+
+// TestEngine_Resume_ReplaysPlannedDeletions verifies that a sync interrupted before its
+// deletions saves them with the plan, and resuming it deletes the orphans still present and
+// still missing from the source.
+func TestEngine_Resume_ReplaysPlannedDeletions(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	statePath := filepath.Join(t.TempDir(), "resume.json")
+
+	createTestFile(t, sourceDir, "keep.txt", "content")
+	createTestFile(t, destDir, "old1.txt", "orphan")
+	createTestFile(t, destDir, "old2.txt", "orphan")
+	createTestFile(t, destDir, "restored.txt", "orphan")
+	g.Expect(os.Mkdir(filepath.Join(destDir, "olddir"), 0o750)).To(Succeed())
+	createTestFile(t, destDir, filepath.Join("olddir", "old3.txt"), "orphan")
+
+	// The unconfirmed deletions stop the first sync after its plan is saved
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.Resume = true
+	engine.ResumeStatePath = statePath
+	engine.MaxDeleteWithoutConfirm = 1
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).NotTo(Succeed())
+	g.Expect(statePath).To(BeAnExistingFile())
+
+	// Before the rerun, one orphan went away on its own and another came back to the source
+	g.Expect(os.Remove(filepath.Join(destDir, "old2.txt"))).To(Succeed())
+	createTestFile(t, sourceDir, "restored.txt", "orphan")
+
+	resumed, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	resumed.ChangeType = config.FluctuatingCount
+	resumed.Resume = true
+	resumed.ResumeStatePath = statePath
+
+	g.Expect(resumed.Analyze()).To(Succeed())
+
+	status := resumed.GetStatus()
+	g.Expect(status.FilesToDelete).To(Equal(2))
+
+	g.Expect(resumed.Sync()).To(Succeed())
+
+	g.Expect(filepath.Join(destDir, "keep.txt")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "restored.txt")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "old1.txt")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "olddir")).NotTo(BeAnExistingFile())
+	g.Expect(statePath).NotTo(BeAnExistingFile())
+}
@@ -0,0 +1,46 @@
+package syncengine
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// olderThanNewestPerDir returns the files that aren't among the newest keep files in their
+// directory, sorted by path. Ties in modtime are broken by path so the choice is stable.
+func olderThanNewestPerDir(files map[string]*fileops.FileInfo, keep int) []string {
+	byDir := make(map[string][]string)
+
+	for relPath, file := range files {
+		if file.IsDir {
+			continue
+		}
+
+		dir := filepath.Dir(relPath)
+		byDir[dir] = append(byDir[dir], relPath)
+	}
+
+	excluded := make([]string, 0)
+
+	for _, relPaths := range byDir {
+		if len(relPaths) <= keep {
+			continue
+		}
+
+		sort.Slice(relPaths, func(i, j int) bool {
+			iTime, jTime := files[relPaths[i]].ModTime, files[relPaths[j]].ModTime
+			if !iTime.Equal(jTime) {
+				return iTime.After(jTime)
+			}
+
+			return relPaths[i] < relPaths[j]
+		})
+
+		excluded = append(excluded, relPaths[keep:]...)
+	}
+
+	sort.Strings(excluded)
+
+	return excluded
+}
@@ -0,0 +1,97 @@
+package syncengine_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// createRetentionSource creates five files in the root, oldest first, plus two in a subdirectory.
+func createRetentionSource(t *testing.T, dir string) {
+	t.Helper()
+
+	base := time.Now().Add(-time.Hour)
+
+	for i := range 5 {
+		name := fmt.Sprintf("backup%d.tar", i)
+		createTestFile(t, dir, name, "content")
+
+		modTime := base.Add(time.Duration(i) * time.Minute)
+		err := os.Chtimes(filepath.Join(dir, name), modTime, modTime)
+		if err != nil {
+			t.Fatalf("failed to set modtime: %v", err)
+		}
+	}
+
+	createNestedTestFile(t, dir, "sub/a.tar", "content")
+	createNestedTestFile(t, dir, "sub/b.tar", "content")
+}
+
+// TestEngine_KeepNewest_KeepsExcludedDestFiles verifies that only the newest N files per
+// directory are synced and that older copies already at the destination are not treated as orphans.
+func TestEngine_KeepNewest_KeepsExcludedDestFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createRetentionSource(t, sourceDir)
+	createTestFile(t, destDir, "backup0.tar", "content")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.KeepNewest = 2
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.RetentionExcludedFiles).To(Equal(3))
+	g.Expect(status.TotalFiles).To(Equal(4))
+	g.Expect(status.FilesOnlyInDest).To(BeZero())
+
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(filepath.Join(destDir, "backup4.tar")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "backup3.tar")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "backup2.tar")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "sub", "a.tar")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "backup0.tar")).To(BeAnExistingFile())
+}
+
+// TestEngine_KeepNewest_PruneOlderDeletesExcludedDestFiles verifies that PruneOlder deletes
+// destination copies of files outside the retention window.
+func TestEngine_KeepNewest_PruneOlderDeletesExcludedDestFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createRetentionSource(t, sourceDir)
+	createTestFile(t, destDir, "backup0.tar", "content")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.KeepNewest = 2
+	engine.PruneOlder = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.GetStatus().FilesOnlyInDest).To(Equal(1))
+
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(filepath.Join(destDir, "backup0.tar")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "backup4.tar")).To(BeAnExistingFile())
+}
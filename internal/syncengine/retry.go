@@ -0,0 +1,90 @@
+package syncengine
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// DefaultRetryBackoff is the wait before the first retry of a failed copy when RetryBackoff
+// isn't set.
+const DefaultRetryBackoff = time.Second
+
+// copyWithRetries copies srcPath to dstPath, retrying a failed copy up to MaxRetries times
+// with a backoff that starts at RetryBackoff and doubles after each retry. A cancelled copy
+// isn't retried, and neither is one whose source vanished. With ResumeFromManifest, each
+// attempt carries on from the partial copy the one before left (see resumeOffset). With Move,
+// each attempt moves the file instead (content-addressed stores are always copied into).
+func (e *Engine) copyWithRetries(
+	fileToSync *FileToSync, srcPath, dstPath string, onDataComplete func(),
+) (*fileops.CopyStats, error) {
+	backoff := e.RetryBackoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+
+	copyFile := e.FileOps.ResumeCopyFileWithStats
+	if e.Move && e.caIndex == nil {
+		copyFile = e.FileOps.Move
+	}
+
+	for attempt := 1; ; attempt++ {
+		offset := e.resumeOffset(fileToSync, srcPath, dstPath)
+		progressCallback := e.createProgressCallback(fileToSync, offset)
+
+		stats, err := copyFile(srcPath, dstPath, offset, progressCallback, e.cancelChan, onDataComplete)
+		if err == nil || attempt > e.MaxRetries || !retryable(err) {
+			return stats, err
+		}
+
+		// The next attempt counts its bytes from where it starts, so they mustn't be counted twice
+		atomic.AddInt64(&e.Status.TransferredBytes, -fileToSync.Transferred)
+		fileToSync.Transferred = 0
+
+		e.logWarn("Retrying", "file", fileToSync.RelativePath, "backoff", backoff, "retry", attempt,
+			"max_retries", e.MaxRetries, "error", err)
+
+		select {
+		case <-e.cancelChan:
+			return stats, fileops.ErrCopyCancelled
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// resumeOffset returns where a copy can carry on from: the size of the partial copy an earlier
+// attempt or run left, if ResumeFromManifest kept one, and otherwise 0. With VerifyResumed, a
+// partial copy that doesn't match the start of the source is copied again from scratch.
+func (e *Engine) resumeOffset(fileToSync *FileToSync, srcPath, dstPath string) int64 {
+	if !e.ResumeFromManifest {
+		return 0
+	}
+
+	offset, ok := e.FileOps.PartialCopySize(dstPath)
+	if !ok || offset == 0 || offset > fileToSync.Size {
+		return 0
+	}
+
+	if e.VerifyResumed {
+		match, err := e.FileOps.PartialCopyMatches(srcPath, dstPath, offset)
+		if err != nil || !match {
+			e.logToFile("Copying from the start: its partial copy doesn't match the source",
+				"file", fileToSync.RelativePath)
+
+			return 0
+		}
+	}
+
+	e.logToFile("Resuming", "file", fileToSync.RelativePath, "bytes", offset, "size", fileToSync.Size)
+
+	return offset
+}
+
+// retryable reports whether a failed copy might succeed if tried again.
+func retryable(err error) bool {
+	return !errors.Is(err, ErrCancelled) && !errors.Is(err, fileops.ErrSourceVanished)
+}
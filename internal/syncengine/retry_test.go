@@ -0,0 +1,141 @@
+package syncengine_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+func TestEngine_MaxRetries_RetriedCopySucceeds(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "flaky.txt", "eventually")
+	createTestFile(t, sourceDir, "fine.txt", "first time")
+
+	destFS := &flakyCreateFS{RealFileSystem: filesystem.NewRealFileSystem(), name: "flaky.txt", failures: 2}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.FileOps = fileops.NewDualFileOps(filesystem.NewRealFileSystem(), destFS)
+	engine.MaxRetries = 2
+	engine.RetryBackoff = time.Millisecond
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.FailedFiles).To(Equal(0))
+	g.Expect(status.ProcessedFiles).To(Equal(2))
+	g.Expect(status.TransferredBytes).To(Equal(int64(len("eventually") + len("first time"))))
+	g.Expect(readFile(t, destDir, "flaky.txt")).To(Equal("eventually"))
+	g.Expect(destFS.attempts()).To(Equal(3))
+}
+
+func TestEngine_MaxRetries_GivesUp(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "flaky.txt", "never")
+
+	destFS := &flakyCreateFS{RealFileSystem: filesystem.NewRealFileSystem(), name: "flaky.txt", failures: 5}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.FileOps = fileops.NewDualFileOps(filesystem.NewRealFileSystem(), destFS)
+	engine.MaxRetries = 2
+	engine.RetryBackoff = time.Millisecond
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).NotTo(Succeed())
+
+	g.Expect(engine.GetStatus().FailedFiles).To(Equal(1))
+	g.Expect(destFS.attempts()).To(Equal(3))
+}
+
+// TestEngine_MaxRetries_CancelStopsRetrying verifies that cancelling during the backoff ends
+// the retries and records the file as cancelled rather than failed.
+func TestEngine_MaxRetries_CancelStopsRetrying(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "flaky.txt", "never")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	destFS := &flakyCreateFS{
+		RealFileSystem: filesystem.NewRealFileSystem(), name: "flaky.txt", failures: 5, onFail: engine.Cancel,
+	}
+
+	engine.FileOps = fileops.NewDualFileOps(filesystem.NewRealFileSystem(), destFS)
+	engine.MaxRetries = 3
+	engine.RetryBackoff = time.Hour
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	_ = engine.Sync()
+
+	status := engine.GetStatus()
+	g.Expect(status.FailedFiles).To(Equal(0))
+	g.Expect(status.CancelledFiles).To(Equal(1))
+	g.Expect(destFS.attempts()).To(Equal(1))
+}
+
+// flakyCreateFS is a destination filesystem that fails its first few attempts to create files
+// with a given name, including their copies' temp files.
+type flakyCreateFS struct {
+	*filesystem.RealFileSystem
+
+	name     string
+	failures int
+	onFail   func()
+
+	mu    sync.Mutex
+	tries int
+}
+
+func (f *flakyCreateFS) Create(path string) (filesystem.File, error) {
+	if strings.TrimSuffix(filepath.Base(path), fileops.TempSuffix) == f.name {
+		f.mu.Lock()
+		f.tries++
+		fail := f.tries <= f.failures
+		f.mu.Unlock()
+
+		if fail {
+			if f.onFail != nil {
+				f.onFail()
+			}
+
+			return nil, errors.New("connection reset") //nolint:err113 // Test error
+		}
+	}
+
+	return f.RealFileSystem.Create(path)
+}
+
+func (f *flakyCreateFS) attempts() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.tries
+}
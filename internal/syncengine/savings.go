@@ -0,0 +1,89 @@
+package syncengine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+	"github.com/joe/copy-files/pkg/formatters"
+)
+
+// estimateSavings works out what deduplication and preserving sparse files would save on the
+// source tree: duplicates are found by hashing files that share a size, and sparseness from
+// each file's allocated blocks. Sparse savings only count the copy of each content that would
+// be stored, so the two add up to the difference between storage with both on and with both
+// off. Nothing is written.
+func (e *Engine) estimateSavings(sourceFiles map[string]*fileops.FileInfo) error {
+	e.logAnalysis("Estimating storage savings (hashing same-size files and reading allocations)...")
+
+	bySize := make(map[int64][]string)
+
+	var storageWithout int64
+
+	for relPath, info := range sourceFiles {
+		if !info.IsDir {
+			bySize[info.Size] = append(bySize[info.Size], relPath)
+			storageWithout += info.Size
+		}
+	}
+
+	var dedupSavings, sparseSavings, storageWith int64
+
+	for size, relPaths := range bySize {
+		// Sorted so the same copy of each duplicate set is the one counted as stored
+		sort.Strings(relPaths)
+
+		seen := make(map[string]bool)
+
+		for _, relPath := range relPaths {
+			err := e.checkCancellation()
+			if err != nil {
+				return err
+			}
+
+			path := sourceFiles[relPath].Path
+
+			// A file with no same-size peer can't be a duplicate, so isn't worth hashing
+			if len(relPaths) > 1 && size > 0 {
+				hash, hashErr := e.FileOps.ComputeSourceHash(path, e.cancelChan)
+				if hashErr != nil {
+					return fmt.Errorf("failed to hash %s: %w", relPath, hashErr)
+				}
+
+				if seen[hash] {
+					dedupSavings += size
+					continue
+				}
+
+				seen[hash] = true
+			}
+
+			stored := size
+
+			info, err := e.FileOps.Stat(path)
+			if err == nil {
+				if allocated, ok := filesystem.AllocatedSize(info); ok && allocated < size {
+					stored = allocated
+				}
+			}
+
+			sparseSavings += size - stored
+			storageWith += stored
+		}
+	}
+
+	e.Status.mu.Lock()
+	e.Status.EstimatedDedupSavings = dedupSavings
+	e.Status.EstimatedSparseSavings = sparseSavings
+	e.Status.EstimatedStorageWithout = storageWithout
+	e.Status.EstimatedStorageWith = storageWith
+	e.Status.mu.Unlock()
+
+	e.logAnalysis(fmt.Sprintf("Estimated savings: %s from deduplication, %s from sparse files; "+
+		"the destination would use %s with both, %s without",
+		formatters.FormatBytes(dedupSavings), formatters.FormatBytes(sparseSavings),
+		formatters.FormatBytes(storageWith), formatters.FormatBytes(storageWithout)))
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestEngine_EstimateSavings_CountsDuplicatesAndHoles verifies that duplicate content is counted
+// once, a sparse file's holes are counted as savings, and the estimates add up.
+func TestEngine_EstimateSavings_CountsDuplicatesAndHoles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "same content")
+	createNestedTestFile(t, sourceDir, "copies/a.txt", "same content")
+	createTestFile(t, sourceDir, "b.txt", "diff content") // Same size, different content
+
+	// A 4MB file with nothing written is all hole on filesystems that support sparse files
+	const sparseSize = 4 * 1024 * 1024
+
+	sparse, err := os.Create(filepath.Join(sourceDir, "sparse.img"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(sparse.Truncate(sparseSize)).To(Succeed())
+	g.Expect(sparse.Close()).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.EstimateSavings = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.EstimatedDedupSavings).To(Equal(int64(len("same content"))))
+	g.Expect(status.EstimatedStorageWithout).To(Equal(int64(3*len("same content") + sparseSize)))
+	g.Expect(status.EstimatedStorageWith).To(Equal(
+		status.EstimatedStorageWithout - status.EstimatedDedupSavings - status.EstimatedSparseSavings))
+
+	info, err := os.Stat(filepath.Join(sourceDir, "sparse.img"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	if allocated, ok := filesystem.AllocatedSize(info); ok && allocated < sparseSize {
+		g.Expect(status.EstimatedSparseSavings).To(Equal(int64(sparseSize) - allocated))
+	}
+}
@@ -0,0 +1,264 @@
+package syncengine_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// recordingPool is a ResizablePool that records the sizes it is asked for.
+type recordingPool struct {
+	mu    sync.Mutex
+	sizes []int
+}
+
+func (p *recordingPool) ResizePool(targetSize int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sizes = append(p.sizes, targetSize)
+}
+
+func (p *recordingPool) PoolSize() int       { return 0 }
+func (p *recordingPool) PoolTargetSize() int { return 0 }
+func (p *recordingPool) PoolMinSize() int    { return 1 }
+func (p *recordingPool) PoolMaxSize() int    { return 0 }
+
+func (p *recordingPool) resizes() []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.sizes
+}
+
+// TestMakeScalingDecision_Bands verifies the 0.90/1.10 bands: a drop of more than 10% removes a
+// worker without signalling workerControl, and anything else adds one.
+func TestMakeScalingDecision_Bands(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		ratio       float64
+		workers     int
+		wantDesired int
+		wantSignals int // Workers started through workerControl
+	}{
+		{name: "drop below band removes a worker", ratio: 0.85, workers: 5, wantDesired: 4},
+		{name: "small drop is stable and adds a worker", ratio: 0.95, workers: 3, wantDesired: 4, wantSignals: 1},
+		{name: "small gain is stable and adds a worker", ratio: 1.05, workers: 3, wantDesired: 4, wantSignals: 1},
+		{name: "improvement adds a worker", ratio: 1.15, workers: 2, wantDesired: 3, wantSignals: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			engine, err := syncengine.NewEngine(t.TempDir(), t.TempDir())
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			pool := &recordingPool{}
+			engine.SetSourceResizable(pool)
+			engine.SetDesiredWorkers(tc.workers)
+
+			workerControl := make(chan bool, 1)
+
+			engine.MakeScalingDecision(1000000, 1000000*tc.ratio, tc.workers, 10, workerControl)
+
+			g.Expect(engine.GetDesiredWorkers()).To(BeEquivalentTo(tc.wantDesired))
+			g.Expect(pool.resizes()).To(Equal([]int{tc.wantDesired}))
+			g.Expect(workerControl).To(HaveLen(tc.wantSignals))
+		})
+	}
+}
+
+// TestEvaluateAndScale_ColdStart verifies that with fewer than two rate samples an evaluation
+// leaves the workers alone and only moves the baseline time on, and that the next evaluation
+// with enough samples scales from there.
+func TestEvaluateAndScale_ColdStart(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine, err := syncengine.NewEngine(t.TempDir(), t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	pool := &recordingPool{}
+	engine.SetSourceResizable(pool)
+	engine.SetDesiredWorkers(2)
+
+	workerControl := make(chan bool, 1)
+	lastCheck := time.Now().Add(-10 * time.Second)
+	state := &syncengine.AdaptiveScalingState{LastThroughput: 1 << 20, LastAdjustment: 1, LastCheckTime: lastCheck}
+
+	engine.Status.Workers.RecentSamples = []syncengine.RateSample{
+		{Timestamp: time.Now(), BytesTransferred: 1 << 20, ActiveWorkers: 2},
+	}
+
+	engine.EvaluateAndScale(state, 0, 2, 0, 10, workerControl)
+
+	g.Expect(engine.GetDesiredWorkers()).To(BeEquivalentTo(2))
+	g.Expect(pool.resizes()).To(BeEmpty())
+	g.Expect(workerControl).To(BeEmpty())
+	g.Expect(state.LastCheckTime).To(BeTemporally(">", lastCheck))
+	g.Expect(state.LastThroughput).To(BeEquivalentTo(1 << 20))
+
+	// Twice the throughput from the rolling window: hill climbing keeps adding workers
+	now := time.Now()
+	engine.Status.Workers.RecentSamples = []syncengine.RateSample{
+		{Timestamp: now.Add(-time.Second), BytesTransferred: 1 << 20, ActiveWorkers: 2},
+		{Timestamp: now, BytesTransferred: 1 << 20, ActiveWorkers: 2},
+	}
+	state.LastCheckTime = now.Add(-10 * time.Second)
+
+	engine.EvaluateAndScale(state, 0, 2, 0, 10, workerControl)
+
+	g.Expect(engine.GetDesiredWorkers()).To(BeEquivalentTo(3))
+	g.Expect(state.LastThroughput).To(BeNumerically("~", 2<<20, 1))
+}
+
+// TestSyncAdaptive_WorkerBounds verifies that adaptive scaling starts at the floor and that the
+// bounds used are reported, with the ceiling capped at the number of files.
+func TestSyncAdaptive_WorkerBounds(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name              string
+		files, minW, maxW int
+		wantMin, wantMax  int
+	}{
+		{name: "configured bounds", files: 6, minW: 2, maxW: 3, wantMin: 2, wantMax: 3},
+		{name: "ceiling capped at file count", files: 2, minW: 0, maxW: 10, wantMin: 1, wantMax: 2},
+		{name: "floor capped at file count", files: 3, minW: 5, maxW: 10, wantMin: 3, wantMax: 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			sourceDir := t.TempDir()
+			destDir := t.TempDir()
+
+			for i := range tc.files {
+				createTestFile(t, sourceDir, fmt.Sprintf("file%d.txt", i), "content")
+			}
+
+			engine, err := syncengine.NewEngine(sourceDir, destDir)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			engine.AdaptiveMode = true
+			engine.MinAdaptiveWorkers = tc.minW
+			engine.MaxAdaptiveWorkers = tc.maxW
+
+			g.Expect(engine.Analyze()).To(Succeed())
+			g.Expect(engine.Sync()).To(Succeed())
+
+			status := engine.GetStatus()
+			g.Expect(status.MinAdaptiveWorkers).To(Equal(tc.wantMin))
+			g.Expect(status.MaxAdaptiveWorkers).To(Equal(tc.wantMax))
+			g.Expect(status.MaxWorkers).To(BeNumerically(">=", tc.wantMin))
+			g.Expect(status.MaxWorkers).To(BeNumerically("<=", tc.wantMax))
+		})
+	}
+}
+
+// TestSync_SamplesCarryWorkerIDs verifies that every rate sample of a sync is tagged
+// with the id of the worker that took it, from 1 up to the number of workers started.
+func TestSync_SamplesCarryWorkerIDs(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	for i := range 6 {
+		createTestFile(t, sourceDir, fmt.Sprintf("file%d.txt", i), strings.Repeat("x", 10_000))
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.Workers = 3
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.Workers.RecentSamples).ShouldNot(BeEmpty())
+
+	for _, sample := range status.Workers.RecentSamples {
+		g.Expect(sample.WorkerID).To(BeNumerically(">=", 1))
+		g.Expect(sample.WorkerID).To(BeNumerically("<=", status.MaxWorkers))
+	}
+}
+
+// TestMakeScalingDecision_StopsAtMinAdaptiveWorkers verifies that a speed drop doesn't take
+// the worker count below MinAdaptiveWorkers.
+func TestMakeScalingDecision_StopsAtMinAdaptiveWorkers(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine, err := syncengine.NewEngine(t.TempDir(), t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.MinAdaptiveWorkers = 3
+	engine.SetDesiredWorkers(3)
+
+	workerControl := make(chan bool, 1)
+
+	engine.MakeScalingDecision(1000000, 500000, 3, 10, workerControl)
+
+	g.Expect(engine.GetDesiredWorkers()).To(BeEquivalentTo(3))
+	g.Expect(workerControl).To(BeEmpty())
+}
+
+// TestWorker_ScaleDownFinishesFileInFlight verifies that a worker told to leave while copying
+// finishes its file first, and that ActiveWorkers follows the workers as they exit.
+func TestWorker_ScaleDownFinishesFileInFlight(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	const size = 256 * 1024
+
+	for i := range 3 {
+		createTestFile(t, sourceDir, fmt.Sprintf("file%d.bin", i), strings.Repeat(string(rune('a'+i)), size))
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	// Two workers sharing a throttle, so both files are mid-copy for a while
+	engine.AdaptiveMode = true
+	engine.MinAdaptiveWorkers = 2
+	engine.MaxBytesPerSecond = size
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	syncDone := make(chan error, 1)
+	go func() { syncDone <- engine.Sync() }()
+
+	g.Eventually(func() int { return len(engine.GetStatus().CurrentFiles) }, 5*time.Second, 10*time.Millisecond).
+		Should(Equal(2))
+
+	engine.SetDesiredWorkers(1)
+
+	g.Eventually(syncDone, 30*time.Second).Should(Receive(BeNil()))
+
+	for i := range 3 {
+		name := fmt.Sprintf("file%d.bin", i)
+		g.Expect(readFile(t, destDir, name)).To(Equal(strings.Repeat(string(rune('a'+i)), size)), name)
+	}
+
+	status := engine.GetStatus()
+	g.Expect(status.ProcessedFiles).To(Equal(3))
+	g.Expect(status.ActiveWorkers).To(BeEquivalentTo(0))
+
+	for _, file := range engine.Status.FilesToSync {
+		g.Expect(file.Status).To(Equal("complete"), file.RelativePath)
+	}
+}
@@ -0,0 +1,238 @@
+package syncengine
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WriteScript writes the analyzed plan as a POSIX shell script of rm, rmdir, mkdir, cp and chmod
+// commands, in the order Sync would apply them, instead of performing it. Like Sync, the script first
+// removes the temp files interrupted copies left. With TrashDir, files
+// are moved to the trash with mv instead of removed, and with Move, files are moved rather than
+// copied with mv too. Every path is quoted.
+// Returns the number of commands written.
+func (e *Engine) WriteScript(w io.Writer) (int, error) {
+	if e.CAStore {
+		return 0, fmt.Errorf("%w: content-addressed stores are written by glowsync itself", ErrScriptNotSupported)
+	}
+
+	if len(e.Sources) > 0 {
+		return 0, fmt.Errorf("%w: a script copies from a single source", ErrScriptNotSupported)
+	}
+
+	script := &scriptWriter{}
+
+	e.writeScriptHeader(script)
+
+	if len(e.staleTempFiles) > 0 {
+		script.comment("Remove temp files left by interrupted copies")
+
+		for _, relPath := range e.staleTempFiles {
+			script.command("rm -f %s", destArg(relPath))
+		}
+	}
+
+	e.planMu.Lock()
+	deletions := append([]PlanEntry(nil), e.plannedDeletions...)
+	e.planMu.Unlock()
+
+	if len(deletions) > 0 && e.TrashDir != "" {
+		script.comment("Move files that are not in the source to the trash")
+
+		for _, entry := range deletions {
+			dir := path.Dir(filepath.ToSlash(entry.RelativePath))
+			script.command("mkdir -p %s && mv %s %s", trashArg(dir), destArg(entry.RelativePath), trashArg(entry.RelativePath))
+		}
+	} else if len(deletions) > 0 {
+		script.comment("Delete files that are not in the source")
+
+		for _, entry := range deletions {
+			script.command("rm -f %s", destArg(entry.RelativePath))
+		}
+	}
+
+	if sourceFiles, destFiles := e.orphanListings(); sourceFiles != nil && destFiles != nil && e.deletesOrphans() {
+		dirs := e.collectDirectoriesToDelete(sourceFiles, destFiles)
+		sort.SliceStable(dirs, func(i, j int) bool {
+			if dirs[i].depth != dirs[j].depth {
+				return dirs[i].depth > dirs[j].depth
+			}
+
+			return dirs[i].relPath < dirs[j].relPath
+		})
+
+		if len(dirs) > 0 {
+			script.comment("Delete directories that are not in the source, deepest first")
+
+			for _, dir := range dirs {
+				if e.TrashDir != "" {
+					script.command("mkdir -p %s && rmdir %s", trashArg(filepath.ToSlash(dir.relPath)), destArg(dir.relPath))
+				} else {
+					script.command("rmdir %s", destArg(dir.relPath))
+				}
+			}
+		}
+	}
+
+	if len(e.typeChangedPaths) > 0 {
+		script.comment("Remove destination entries whose type differs from the source")
+
+		for _, relPath := range e.typeChangedPaths {
+			script.command("rm -rf %s", destArg(relPath))
+		}
+	}
+
+	if len(e.dirsToCreate) > 0 {
+		script.comment("Create source directories missing from the destination, including empty ones")
+
+		for _, dir := range sortedDirsToCreate(e.dirsToCreate) {
+			script.command("mkdir -p %s", destArg(dir.destRelPath))
+		}
+	}
+
+	e.Status.mu.RLock()
+	files := append([]*FileToSync(nil), e.Status.FilesToSync...)
+	e.Status.mu.RUnlock()
+
+	if len(files) > 0 {
+		// mv keeps modification times, and copies and removes across filesystems itself
+		verb, command := "Copy", "cp -p"
+		if e.Move {
+			verb, command = "Move", "mv"
+		}
+
+		script.comment(verb + " new and changed files, keeping their modification times")
+
+		for _, dir := range parentDirs(files) {
+			script.command("mkdir -p %s", destArg(dir))
+		}
+
+		for _, file := range files {
+			script.command(command+" %s %s", sourceArg(file.RelativePath), destArg(file.destPath()))
+		}
+	}
+
+	if len(e.permissionUpdates) > 0 {
+		script.comment("Update permissions of files whose content already matches")
+
+		for _, update := range e.permissionUpdates {
+			script.command("chmod %o %s", update.mode.Perm(), destArg(update.destRelPath))
+		}
+	}
+
+	_, err := io.WriteString(w, script.String())
+	if err != nil {
+		return 0, fmt.Errorf("failed to write script: %w", err)
+	}
+
+	return script.commands, nil
+}
+
+// writeScriptHeader notes where the script came from and what it was generated with, then sets
+// the source and destination roots every command refers to.
+func (e *Engine) writeScriptHeader(script *scriptWriter) {
+	changeType := e.ChangeType
+
+	script.line("#!/bin/sh")
+	script.line("# Generated by glowsync on " + e.TimeProvider.Now().Format(time.RFC3339) + ". Review it before running.")
+	script.line("#")
+	script.line("# Source:         " + e.originalSourcePath())
+	script.line("# Destination:    " + e.DestPath)
+	script.line("# Type of change: " + changeType.String())
+
+	if e.FilePattern != "" {
+		script.line("# Filter:         " + e.FilePattern)
+	}
+
+	if len(e.ExcludePatterns) > 0 {
+		script.line("# Exclude:        " + strings.Join(e.ExcludePatterns, ", "))
+	}
+
+	e.Status.mu.RLock()
+	script.line(fmt.Sprintf("# Plan:           %d files to copy (%d bytes), %d permission updates, %d files to delete",
+		len(e.Status.FilesToSync), e.Status.TotalBytes, len(e.permissionUpdates), e.Status.FilesToDelete))
+	e.Status.mu.RUnlock()
+
+	script.line("")
+	script.line("set -eu")
+	script.line("")
+	// A snapshot is gone by the time the script runs, so it copies from the live source
+	script.line("src=" + shellQuote(e.originalSourcePath()))
+	script.line("dst=" + shellQuote(e.DestPath))
+
+	if e.TrashDir != "" {
+		script.line("trash=" + shellQuote(filepath.Join(e.TrashDir, e.TimeProvider.Now().Format(trashRunDirFormat))))
+	}
+}
+
+// scriptWriter accumulates a shell script and counts its commands.
+type scriptWriter struct {
+	strings.Builder
+
+	commands int
+}
+
+func (s *scriptWriter) command(format string, args ...any) {
+	s.line(fmt.Sprintf(format, args...))
+	s.commands++
+}
+
+func (s *scriptWriter) comment(text string) {
+	s.line("")
+	s.line("# " + text)
+}
+
+func (s *scriptWriter) line(text string) {
+	s.WriteString(text)
+	s.WriteString("\n")
+}
+
+// destArg returns a shell word for relPath under the script's $dst.
+func destArg(relPath string) string {
+	return `"$dst"/` + shellQuote(filepath.ToSlash(relPath))
+}
+
+// parentDirs returns the distinct destination directories the files are copied into, sorted.
+func parentDirs(files []*FileToSync) []string {
+	seen := make(map[string]bool)
+
+	var dirs []string
+
+	for _, file := range files {
+		dir := path.Dir(filepath.ToSlash(file.destPath()))
+		if dir == "." || seen[dir] {
+			continue
+		}
+
+		seen[dir] = true
+		dirs = append(dirs, dir)
+	}
+
+	sort.Strings(dirs)
+
+	return dirs
+}
+
+// shellQuote quotes s as a single shell word, whatever characters it contains.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// sourceArg returns a shell word for relPath under the script's $src.
+func sourceArg(relPath string) string {
+	return `"$src"/` + shellQuote(filepath.ToSlash(relPath))
+}
+
+// trashArg quotes a slash-separated path relative to the script's trash folder.
+func trashArg(relPath string) string {
+	if relPath == "." {
+		return `"$trash"`
+	}
+
+	return `"$trash"/` + shellQuote(relPath)
+}
@@ -0,0 +1,84 @@
+package syncengine_test
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_WriteScript_ReproducesSync verifies that running the generated script brings the
+// destination in sync, including paths that need quoting, while writing it changes nothing.
+func TestEngine_WriteScript_ReproducesSync(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	shell, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh to run the script with")
+	}
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "plain.txt", "plain")
+	createNestedTestFile(t, sourceDir, "it's a dir/$HOME `x`.txt", "quoted")
+	createTestFile(t, destDir, "orphan.txt", "orphan")
+	createNestedTestFile(t, destDir, "old/gone.txt", "gone")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	var script bytes.Buffer
+
+	commands, err := engine.WriteScript(&script)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(commands).To(BeNumerically(">=", 5))
+	g.Expect(script.String()).To(HavePrefix("#!/bin/sh\n# Generated by glowsync"))
+	g.Expect(filepath.Join(destDir, "orphan.txt")).To(BeAnExistingFile())
+
+	scriptPath := filepath.Join(t.TempDir(), "sync.sh")
+	g.Expect(os.WriteFile(scriptPath, script.Bytes(), 0o600)).To(Succeed())
+
+	output, err := exec.Command(shell, scriptPath).CombinedOutput() //nolint:gosec // Test-generated script
+	g.Expect(err).ShouldNot(HaveOccurred(), string(output))
+
+	data, err := os.ReadFile(filepath.Join(destDir, "it's a dir", "$HOME `x`.txt"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal("quoted"))
+	g.Expect(filepath.Join(destDir, "plain.txt")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "orphan.txt")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "old")).NotTo(BeADirectory())
+
+	rerun, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rerun.ChangeType = config.Content
+
+	g.Expect(rerun.Analyze()).To(Succeed())
+	g.Expect(rerun.PlanEntries()).To(BeEmpty())
+}
+
+// TestEngine_WriteScript_RefusesCAStore verifies that a content-addressed plan isn't scripted.
+func TestEngine_WriteScript_RefusesCAStore(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine, err := syncengine.NewEngine(t.TempDir(), t.TempDir())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.CAStore = true
+
+	_, err = engine.WriteScript(&bytes.Buffer{})
+	g.Expect(err).To(MatchError(syncengine.ErrScriptNotSupported))
+}
@@ -0,0 +1,76 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_MinFileSize_LeavesSmallFilesAlone verifies that files below MinFileSize are left
+// out of the plan and counted, and that neither their destination copies nor small
+// destination-only files are deleted as orphans, while large orphans still are.
+func TestEngine_MinFileSize_LeavesSmallFilesAlone(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "video.mp4", strings.Repeat("v", 2000))
+	createTestFile(t, sourceDir, "video.xmp", "new sidecar")
+	createTestFile(t, destDir, "video.xmp", "old sidecar")
+	createTestFile(t, destDir, "notes.txt", "small orphan")
+	createTestFile(t, destDir, "old.mp4", strings.Repeat("o", 2000))
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.MinFileSize = 1000
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	g.Expect(engine.Status.FilesToSync).To(HaveLen(1))
+	g.Expect(engine.Status.FilesToSync[0].RelativePath).To(Equal("video.mp4"))
+	g.Expect(engine.GetStatus().SizeSkippedFiles).To(Equal(1))
+
+	g.Expect(engine.Sync()).To(Succeed())
+
+	sidecar, err := os.ReadFile(filepath.Join(destDir, "video.xmp"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(sidecar)).To(Equal("old sidecar"))
+
+	g.Expect(filepath.Join(destDir, "notes.txt")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "old.mp4")).ToNot(BeAnExistingFile())
+}
+
+// TestEngine_MaxFileSize_IsInclusive verifies that MaxFileSize keeps files of exactly that size
+// and leaves out larger ones.
+func TestEngine_MaxFileSize_IsInclusive(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "exact.txt", strings.Repeat("x", 100))
+	createTestFile(t, sourceDir, "over.txt", strings.Repeat("x", 101))
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.FluctuatingCount
+	engine.MaxFileSize = 100
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	g.Expect(engine.Status.FilesToSync).To(HaveLen(1))
+	g.Expect(engine.Status.FilesToSync[0].RelativePath).To(Equal("exact.txt"))
+	g.Expect(engine.GetStatus().SizeSkippedFiles).To(Equal(1))
+}
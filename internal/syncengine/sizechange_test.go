@@ -0,0 +1,65 @@
+package syncengine_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_SourceSizeChangedSinceAnalysis verifies that a file that grew or shrank between
+// Analyze and Sync is copied in full at its new size, and that the file's size, the total and
+// the bytes transferred all follow it, so progress never passes 100%.
+func TestEngine_SourceSizeChangedSinceAnalysis(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		name    string
+		content string
+	}{
+		{"grown", "hello, and a good deal more"},
+		{"shrunk", "hi"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			sourceDir := t.TempDir()
+			destDir := t.TempDir()
+
+			createTestFile(t, sourceDir, "changing.txt", "hello")
+			createTestFile(t, sourceDir, "steady.txt", "steady")
+
+			engine, err := syncengine.NewEngine(sourceDir, destDir)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			engine.ChangeType = config.FluctuatingCount
+			engine.VerifyTotals = true
+
+			g.Expect(engine.Analyze()).To(Succeed())
+			g.Expect(engine.GetStatus().TotalBytes).To(Equal(int64(11)))
+
+			createTestFile(t, sourceDir, "changing.txt", test.content)
+
+			g.Expect(engine.Sync()).To(Succeed())
+
+			status := engine.GetStatus()
+			total := int64(len(test.content) + len("steady"))
+
+			g.Expect(status.Errors).To(BeEmpty())
+			g.Expect(status.TotalBytes).To(Equal(total))
+			g.Expect(status.TransferredBytes).To(Equal(total))
+			g.Expect(status.TotalsMismatch).To(BeFalse())
+
+			for _, file := range status.FilesToSync {
+				g.Expect(file.Transferred).To(Equal(file.Size), file.RelativePath)
+			}
+
+			g.Expect(readDir(t, destDir)).To(Equal(map[string]string{
+				"changing.txt": test.content, "steady.txt": "steady",
+			}))
+		})
+	}
+}
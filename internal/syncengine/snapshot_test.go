@@ -0,0 +1,35 @@
+package syncengine_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestEngine_SnapshotSource_FailsClearlyWithoutSnapshots verifies that a source that can't be
+// snapshotted stops the analysis instead of silently syncing the live tree.
+func TestEngine_SnapshotSource_FailsClearlyWithoutSnapshots(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "file.txt", "content")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	// Any source filesystem but the local one is refused before a snapshot is attempted
+	engine.FileOps = fileops.NewDualFileOps(
+		failingOpenFS{filesystem.NewRealFileSystem()}, filesystem.NewRealFileSystem())
+	engine.SnapshotSource = true
+
+	g.Expect(engine.Analyze()).To(MatchError(syncengine.ErrSnapshotNotSupported))
+	g.Expect(engine.SourcePath).To(Equal(sourceDir))
+	g.Expect(engine.PlanEntries()).To(BeEmpty())
+}
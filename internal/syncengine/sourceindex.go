@@ -0,0 +1,167 @@
+package syncengine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// Exported constants.
+const (
+	// SourceIndexVersion is the current source index file format version
+	SourceIndexVersion = 1
+)
+
+// sourceIndexEntry is one scanned source entry. Absolute paths aren't stored, so an index
+// taken through a snapshot still applies to the live source.
+type sourceIndexEntry struct {
+	Size      int64       `json:"size"`
+	ModTime   time.Time   `json:"mod_time"`
+	Mode      os.FileMode `json:"mode"`
+	Hash      string      `json:"hash,omitempty"`
+	IsDir     bool        `json:"is_dir,omitempty"`
+	IsSymlink bool        `json:"is_symlink,omitempty"`
+	UID       int         `json:"uid,omitempty"`
+	GID       int         `json:"gid,omitempty"`
+	HasOwner  bool        `json:"has_owner,omitempty"`
+}
+
+// sourceIndexFile is the on-disk source index format. It records the unfiltered scan, so one
+// index serves runs with different filters.
+type sourceIndexFile struct {
+	Version     int                         `json:"version"`
+	Source      string                      `json:"source"`
+	MaxDepth    int                         `json:"max_depth"`
+	RootModTime time.Time                   `json:"root_mod_time"` // Source root's modtime when scanned
+	ScannedAt   time.Time                   `json:"scanned_at"`
+	Files       map[string]sourceIndexEntry `json:"files"` // Relative path -> entry
+}
+
+// sourceFiles rebuilds the scan the index records, with paths under root.
+func (index *sourceIndexFile) sourceFiles(root string) map[string]*fileops.FileInfo {
+	files := make(map[string]*fileops.FileInfo, len(index.Files))
+
+	for relPath, entry := range index.Files {
+		files[relPath] = &fileops.FileInfo{
+			Path:         filepath.Join(root, relPath),
+			RelativePath: relPath,
+			Size:         entry.Size,
+			ModTime:      entry.ModTime,
+			Mode:         entry.Mode,
+			Hash:         entry.Hash,
+			IsDir:        entry.IsDir,
+			IsSymlink:    entry.IsSymlink,
+			UID:          entry.UID,
+			GID:          entry.GID,
+			HasOwner:     entry.HasOwner,
+		}
+	}
+
+	return files
+}
+
+// sourceIndexStaleReason explains why an index no longer describes the source, or returns ""
+// if it can be used.
+func sourceIndexStaleReason(index *sourceIndexFile, source string, maxDepth int, rootModTime, now time.Time,
+	maxAge time.Duration,
+) string {
+	switch {
+	case index.Version != SourceIndexVersion:
+		return fmt.Sprintf("index version %d is not %d", index.Version, SourceIndexVersion)
+	case index.Source != source:
+		return "it indexes " + index.Source
+	case index.MaxDepth != maxDepth:
+		return fmt.Sprintf("it was scanned with depth limit %d", index.MaxDepth)
+	case !index.RootModTime.Equal(rootModTime):
+		return "the source root changed since it was scanned"
+	case maxAge > 0 && now.Sub(index.ScannedAt) > maxAge:
+		return fmt.Sprintf("it is older than %s", maxAge)
+	}
+
+	return ""
+}
+
+// loadSourceIndexFile reads a source index, returning nil if there is none yet.
+func loadSourceIndexFile(path string) (*sourceIndexFile, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is the user's chosen index file
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil //nolint:nilnil // No index yet is a valid outcome
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source index: %w", err)
+	}
+
+	var index sourceIndexFile
+
+	err = json.Unmarshal(data, &index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse source index %s: %w", path, err)
+	}
+
+	return &index, nil
+}
+
+// newSourceIndexFile records a scan of source, keyed by relative path.
+func newSourceIndexFile(source string, maxDepth int, rootModTime, scannedAt time.Time,
+	files map[string]*fileops.FileInfo,
+) *sourceIndexFile {
+	index := &sourceIndexFile{
+		Version:     SourceIndexVersion,
+		Source:      source,
+		MaxDepth:    maxDepth,
+		RootModTime: rootModTime,
+		ScannedAt:   scannedAt,
+		Files:       make(map[string]sourceIndexEntry, len(files)),
+	}
+
+	for relPath, info := range files {
+		index.Files[relPath] = sourceIndexEntry{
+			Size:      info.Size,
+			ModTime:   info.ModTime,
+			Mode:      info.Mode,
+			Hash:      info.Hash,
+			IsDir:     info.IsDir,
+			IsSymlink: info.IsSymlink,
+			UID:       info.UID,
+			GID:       info.GID,
+			HasOwner:  info.HasOwner,
+		}
+	}
+
+	return index
+}
+
+// saveSourceIndexFile writes a source index for later runs to load instead of scanning.
+func saveSourceIndexFile(path string, index *sourceIndexFile) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to encode source index: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o750) //nolint:mnd // Standard directory permissions
+	if err != nil {
+		return fmt.Errorf("failed to create source index directory: %w", err)
+	}
+
+	// Write to a temp file and rename so a run loading the index never sees a torn one
+	tmpPath := path + ".tmp"
+
+	err = os.WriteFile(tmpPath, data, 0o600) //nolint:mnd // Owner-only permissions
+	if err != nil {
+		return fmt.Errorf("failed to write source index: %w", err)
+	}
+
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return fmt.Errorf("failed to save source index: %w", err)
+	}
+
+	return nil
+}
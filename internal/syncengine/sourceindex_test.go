@@ -0,0 +1,73 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_SourceIndex_ReusedUntilSourceChanges verifies that a saved scan stands in for the
+// source walk on a later run, and that a changed directory or a refresh forces a rescan.
+func TestEngine_SourceIndex_ReusedUntilSourceChanges(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	indexPath := filepath.Join(t.TempDir(), "source.idx")
+
+	createTestFile(t, sourceDir, "a.txt", "a")
+	createNestedTestFile(t, sourceDir, "sub/b.txt", "b")
+
+	analyze := func(refresh bool) *syncengine.Status {
+		engine, err := syncengine.NewEngine(sourceDir, t.TempDir())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		engine.ChangeType = config.Content
+		engine.SourceIndexPath = indexPath
+		engine.RefreshSourceIndex = refresh
+
+		g.Expect(engine.Analyze()).To(Succeed())
+
+		return engine.GetStatus()
+	}
+
+	status := analyze(false)
+	g.Expect(status.SourceIndexUsed).To(BeFalse())
+	g.Expect(status.SourceIndexSaved).To(BeTrue())
+	g.Expect(indexPath).To(BeAnExistingFile())
+
+	status = analyze(false)
+	g.Expect(status.SourceIndexUsed).To(BeTrue())
+	g.Expect(status.TotalFiles).To(Equal(2))
+
+	status = analyze(true)
+	g.Expect(status.SourceIndexUsed).To(BeFalse())
+
+	// A file added below the root changes its directory's modtime
+	createNestedTestFile(t, sourceDir, "sub/c.txt", "c")
+
+	future := time.Now().Add(time.Minute)
+	g.Expect(os.Chtimes(filepath.Join(sourceDir, "sub"), future, future)).To(Succeed())
+
+	status = analyze(false)
+	g.Expect(status.SourceIndexUsed).To(BeFalse())
+	g.Expect(status.TotalFiles).To(Equal(3))
+}
+
+// TestEngine_BuildSourceIndex_RequiresPath verifies that a scan-only run needs somewhere to save.
+func TestEngine_BuildSourceIndex_RequiresPath(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine, err := syncengine.NewEngine(t.TempDir(), "")
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	_, err = engine.BuildSourceIndex()
+	g.Expect(err).To(MatchError(syncengine.ErrNoSourceIndex))
+}
@@ -0,0 +1,93 @@
+package syncengine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// NewEngineFromConfig creates an engine for cfg's destination and its source, or its sources
+// when --source was given more than once, looking SFTP passwords up in cfg.PasswordKeychain.
+// ApplyConfig applies the rest of cfg.
+func NewEngineFromConfig(cfg *config.Config) (*Engine, error) {
+	sources := cfg.SourceDirs()
+	if sources != nil {
+		return newMultiSourceEngine(sources, cfg.DestPath, cfg.PasswordKeychain)
+	}
+
+	return newPairEngine(cfg.SourcePath, cfg.DestPath, cfg.PasswordKeychain)
+}
+
+// NewMultiSourceEngine creates an engine syncing several sources into one destination, each into
+// the folder named by its SourceDir.Name, so their paths can't collide. The sources are scanned
+// as one tree (see filesystem.UnionFileSystem), and only what is in none of them is deleted from
+// the destination: anything outside their folders, and whatever is missing from a source inside
+// its own. Sources can be local or remote, like NewEngine's, and none may overlap the
+// destination.
+func NewMultiSourceEngine(sources []config.SourceDir, dest string) (*Engine, error) {
+	return newMultiSourceEngine(sources, dest, nil)
+}
+
+// newMultiSourceEngine is NewMultiSourceEngine with SFTP passwords looked up in keychain.
+func newMultiSourceEngine(sources []config.SourceDir, dest string, keychain filesystem.Keychain) (*Engine, error) {
+	for _, source := range sources {
+		err := config.CheckPathOverlap(source.Path, dest)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // The config error already names both paths
+		}
+	}
+
+	var closers []func()
+
+	closeAll := func() {
+		for _, closer := range closers {
+			if closer != nil {
+				closer()
+			}
+		}
+	}
+
+	members := make([]filesystem.UnionMember, 0, len(sources))
+
+	for _, source := range sources {
+		sourceFS, sourcePath, closer, err := filesystem.CreateFileSystem(source.Path, keychain)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("failed to create source filesystem for %s: %w", source.Name, err)
+		}
+
+		closers = append(closers, closer)
+		members = append(members, filesystem.UnionMember{Name: source.Name, FS: sourceFS, Root: sourcePath})
+	}
+
+	union, err := filesystem.NewUnionFileSystem(members)
+	if err != nil {
+		closeAll()
+		return nil, fmt.Errorf("failed to create filesystems: %w", err)
+	}
+
+	destFS, destPath, closer, err := filesystem.CreateFileSystem(dest, keychain)
+	if err != nil {
+		closeAll()
+		return nil, fmt.Errorf("failed to create destination filesystem: %w", err)
+	}
+
+	closers = append(closers, closer)
+
+	engine := newEngine(union, destFS, filesystem.UnionRoot, destPath, closeAll)
+	engine.Sources = sources
+
+	return engine, nil
+}
+
+// sourcesKey names a set of sources in place of a source path, e.g. to keep per-source state.
+func sourcesKey(sources []config.SourceDir) string {
+	names := make([]string, 0, len(sources))
+	for _, source := range sources {
+		names = append(names, source.Name+"="+source.Path)
+	}
+
+	return strings.Join(names, ", ")
+}
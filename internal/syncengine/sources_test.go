@@ -0,0 +1,64 @@
+package syncengine_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_MultiSource_SyncsEachSourceIntoItsFolder verifies that several sources are synced
+// into their own folders at one destination, and that only what is in none of them is deleted.
+func TestEngine_MultiSource_SyncsEachSourceIntoItsFolder(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	photosDir := t.TempDir()
+	docsDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, photosDir, "a.jpg", "photo")
+	createNestedTestFile(t, docsDir, "notes/b.txt", "doc")
+	createTestFile(t, docsDir, "same.txt", "docs copy")
+	createTestFile(t, photosDir, "same.txt", "photos copy")
+
+	createNestedTestFile(t, destDir, "photos/gone.jpg", "deleted from the source")
+	createTestFile(t, destDir, "stray.txt", "in no source")
+
+	engine, err := syncengine.NewMultiSourceEngine([]config.SourceDir{
+		{Name: "photos", Path: photosDir},
+		{Name: "docs", Path: docsDir},
+	}, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	defer engine.Close()
+
+	engine.ChangeType = config.FluctuatingCount
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.GetStatus().FilesToDelete).To(Equal(2))
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(readFile(t, destDir, "photos/a.jpg")).To(Equal("photo"))
+	g.Expect(readFile(t, destDir, "photos/same.txt")).To(Equal("photos copy"))
+	g.Expect(readFile(t, destDir, "docs/notes/b.txt")).To(Equal("doc"))
+	g.Expect(readFile(t, destDir, "docs/same.txt")).To(Equal("docs copy"))
+	g.Expect(filepath.Join(destDir, "photos", "gone.jpg")).ShouldNot(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "stray.txt")).ShouldNot(BeAnExistingFile())
+}
+
+// TestNewMultiSourceEngine_RejectsSharedFolder verifies that two sources can't sync into the same
+// folder.
+func TestNewMultiSourceEngine_RejectsSharedFolder(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	_, err := syncengine.NewMultiSourceEngine([]config.SourceDir{
+		{Name: "backup", Path: t.TempDir()},
+		{Name: "backup", Path: t.TempDir()},
+	}, t.TempDir())
+	g.Expect(err).Should(HaveOccurred())
+}
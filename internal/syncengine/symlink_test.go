@@ -0,0 +1,132 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_SymlinkFollow_CopiesTargets verifies that followed links are copied as their
+// targets' contents, linked directories included, and that a link back to an ancestor
+// directory doesn't make the scan loop.
+func TestEngine_SymlinkFollow_CopiesTargets(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	outside := t.TempDir()
+
+	createTestFile(t, outside, "shared.txt", "shared content")
+	createNestedTestFile(t, sourceDir, "docs/readme.txt", "readme")
+	mustSymlink(t, outside, filepath.Join(sourceDir, "linked"))
+	mustSymlink(t, filepath.Join(outside, "shared.txt"), filepath.Join(sourceDir, "shared.txt"))
+	mustSymlink(t, sourceDir, filepath.Join(sourceDir, "docs", "loop"))
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.SymlinkMode = config.SymlinkFollow
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(readFile(t, destDir, "linked/shared.txt")).To(Equal("shared content"))
+	g.Expect(readFile(t, destDir, "shared.txt")).To(Equal("shared content"))
+	g.Expect(readFile(t, destDir, "docs/readme.txt")).To(Equal("readme"))
+
+	info, err := os.Lstat(filepath.Join(destDir, "shared.txt"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(info.Mode().IsRegular()).To(BeTrue())
+	g.Expect(filepath.Join(destDir, "docs", "loop")).NotTo(BeAnExistingFile())
+}
+
+// TestEngine_SymlinkPreserve_RecreatesLinks verifies that preserved links are recreated as
+// links to the same targets, left alone when they still match, and repointed when the source
+// link changes.
+func TestEngine_SymlinkPreserve_RecreatesLinks(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "v1.txt", "version 1")
+	createTestFile(t, sourceDir, "v2.txt", "version 2")
+	mustSymlink(t, "v1.txt", filepath.Join(sourceDir, "current"))
+
+	sync := func() *syncengine.Status {
+		engine, err := syncengine.NewEngine(sourceDir, destDir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		engine.SymlinkMode = config.SymlinkPreserve
+		engine.ChangeType = config.Content
+
+		g.Expect(engine.Analyze()).To(Succeed())
+		g.Expect(engine.Sync()).To(Succeed())
+
+		return engine.GetStatus()
+	}
+
+	status := sync()
+	g.Expect(status.PreservedSymlinks).To(Equal(1))
+	g.Expect(os.Readlink(filepath.Join(destDir, "current"))).To(Equal("v1.txt"))
+
+	status = sync()
+	g.Expect(status.PreservedSymlinks).To(Equal(0))
+	g.Expect(status.FilesToSync).To(BeEmpty())
+
+	g.Expect(os.Remove(filepath.Join(sourceDir, "current"))).To(Succeed())
+	mustSymlink(t, "v2.txt", filepath.Join(sourceDir, "current"))
+
+	status = sync()
+	g.Expect(status.PreservedSymlinks).To(Equal(1))
+	g.Expect(os.Readlink(filepath.Join(destDir, "current"))).To(Equal("v2.txt"))
+	g.Expect(readFile(t, destDir, "v1.txt")).To(Equal("version 1"))
+}
+
+// TestEngine_SymlinkSkip_LeavesLinksAlone verifies that skipped source links aren't copied and
+// that links only at the destination aren't deleted as orphans.
+func TestEngine_SymlinkSkip_LeavesLinksAlone(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "data.txt", "data")
+	mustSymlink(t, "data.txt", filepath.Join(sourceDir, "source-link"))
+	createTestFile(t, destDir, "orphan.txt", "orphan")
+	mustSymlink(t, "orphan.txt", filepath.Join(destDir, "dest-link"))
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.SymlinkMode = config.SymlinkSkip
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(readFile(t, destDir, "data.txt")).To(Equal("data"))
+	g.Expect(filepath.Join(destDir, "source-link")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "orphan.txt")).NotTo(BeAnExistingFile())
+
+	_, err = os.Lstat(filepath.Join(destDir, "dest-link"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+}
+
+// mustSymlink creates a link at path pointing at target, skipping the test where links can't
+// be created.
+func mustSymlink(t *testing.T, target, path string) {
+	t.Helper()
+
+	err := os.Symlink(target, path)
+	if err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+}
@@ -6,11 +6,17 @@ package syncengine
 //go:generate impgen --target syncengine.Engine.CloseLog
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io/fs"
+	"log/slog"
+	"maps"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -18,6 +24,7 @@ import (
 	"time"
 
 	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/pkg/bloom"
 	"github.com/joe/copy-files/pkg/fileops"
 	"github.com/joe/copy-files/pkg/filesystem"
 	"github.com/joe/copy-files/pkg/formatters"
@@ -25,22 +32,28 @@ import (
 
 // Exported constants.
 const (
-	// AdaptiveScalingHighThreshold is the threshold above which we consider removing workers (110%)
+	// AdaptiveScalingHighThreshold is the per-worker speed ratio at or above which speed counts as improved (110%)
 	AdaptiveScalingHighThreshold = 1.10
 	// AdaptiveScalingIdleThreshold is the threshold for considering a worker idle (60%)
 	AdaptiveScalingIdleThreshold = 0.60
-	// AdaptiveScalingLowThreshold is the threshold below which we consider adding workers (90%)
+	// AdaptiveScalingLowThreshold is the per-worker speed ratio below which a worker is removed (90%)
 	AdaptiveScalingLowThreshold = 0.90
 	// AdaptiveScalingMinIdleTime is the minimum time a worker must be idle before removal (20 seconds)
 	AdaptiveScalingMinIdleTime = 20
 	// BytesPerKilobyte is the number of bytes in a kilobyte
 	BytesPerKilobyte = 1024
+	// ClockSkewProbeFile is written to a remote source or destination root, and removed, to read its clock
+	ClockSkewProbeFile = ".glowsync-clock-probe"
+	// LoadSampleInterval is how often the load average is read when MaxLoadAverage is set
+	LoadSampleInterval = 5 * time.Second
 	// LogSampleLimit is the maximum number of items to show in log samples
 	LogSampleLimit = 10
 	// LogSampleSize is the number of sample items to log when showing examples
 	LogSampleSize = 5
 	// MaxErrorsBeforeAbort is the maximum number of errors before we stop the sync
 	MaxErrorsBeforeAbort = 10
+	// MinEstimateSamples is how many speed samples the completion estimate needs before it's shown
+	MinEstimateSamples = 5
 	// PercentageScale is the scale factor for converting ratios to percentages
 	PercentageScale = 100
 	// RecentlyCompletedLimit is the maximum number of recently completed files to track
@@ -49,15 +62,54 @@ const (
 	WorkerChannelBufferSize = 100
 )
 
+// Phase names recorded in Status.PhaseDurations.
+const (
+	PhaseScanSource = "scan source"      // Counting and scanning the source tree
+	PhaseScanDest   = "scan destination" // Counting and scanning the destination tree
+	PhaseCompare    = "compare"          // Deciding which files need syncing
+	PhaseDelete     = "delete"           // Removing files and directories missing from the source
+	PhaseCopy       = "copy"             // Copying files
+	PhaseReconcile  = "reconcile"        // Checking the destination against the source after syncing
+)
+
 // Exported variables.
 var (
-	ErrAnalysisCancelled = errors.New("analysis cancelled")
-	ErrDeleteFailed      = errors.New("delete failed")
-	ErrFilesFailed       = errors.New("file(s) failed to sync")
-	ErrSyncAborted       = errors.New("sync aborted")
-	ErrTooManyErrors     = errors.New("too many errors, aborting sync")
+	ErrAnalysisCancelled  = fmt.Errorf("analysis %w", ErrCancelled)
+	ErrCaseConflict       = errors.New("source paths differ only in case")
+	ErrDeadlineReached    = errors.New("deadline reached")
+	ErrDeleteFailed       = errors.New("delete failed")
+	ErrDeleteNotConfirmed = errors.New("deletions not confirmed")
+	ErrDestNotMounted     = errors.New("destination is not mounted")
+	ErrFilesFailed        = errors.New("file(s) failed to sync")
+	ErrNoSourceIndex      = errors.New("no source index path set")
+	ErrSyncAborted        = errors.New("sync aborted")
+	ErrSyncCancelled      = fmt.Errorf("sync %w", ErrCancelled)
+	ErrTooManyErrors      = errors.New("too many errors, aborting sync")
+	ErrVerifyFailed       = errors.New("post-copy verification failed")
+
+	// ErrScriptNotSupported reports a plan WriteScript can't express as shell commands
+	ErrScriptNotSupported = errors.New("plan can't be written as a shell script")
+
+	// ErrSnapshotNotSupported reports a SnapshotSource run whose source can't be snapshotted
+	ErrSnapshotNotSupported = filesystem.ErrSnapshotNotSupported
+
+	// ErrSnapshotNested reports a SnapshotSource run refused because the source has another
+	// filesystem below it, whose files the snapshot would leave out and a mirror would delete
+	ErrSnapshotNested = filesystem.ErrSnapshotNested
+
+	// ErrXattrNotSupported reports an XattrCompare run on a platform or path without xattrs
+	ErrXattrNotSupported = fileops.ErrXattrNotSupported
+
+	// Failure categories from file operations, for classifying errors with errors.Is
+	ErrCancelled      = fileops.ErrCancelled
+	ErrDestFull       = fileops.ErrDestFull
+	ErrPermission     = fileops.ErrPermission
+	ErrSourceVanished = fileops.ErrSourceVanished
 )
 
+// CopyError reports a failed copy with the path involved and the failure category
+type CopyError = fileops.CopyError
+
 // AdaptiveScalingState holds the state for hill climbing adaptive scaling algorithm
 type AdaptiveScalingState struct {
 	LastThroughput float64   // Total system throughput in bytes/sec
@@ -69,54 +121,368 @@ type AdaptiveScalingState struct {
 type Engine struct {
 	SourcePath      string
 	DestPath        string
-	FilePattern     string // Optional file pattern filter (e.g., "*.mov")
+	FilePattern     string    // Optional file pattern filter (e.g., "*.mov")
+	ExcludePatterns []string  // Optional patterns of files left out of the sync (e.g., "**/node_modules/**")
+	MinFileSize     int64     // Only sync files of at least this many bytes (0 = no minimum)
+	MaxFileSize     int64     // Only sync files of at most this many bytes (0 = no maximum)
+	ModifiedAfter   time.Time // Only sync files modified at or after this time (zero = no limit)
+	ModifiedBefore  time.Time // Only sync files modified before this time (zero = no limit)
 	Status          *Status
 	Workers         int               // Number of concurrent workers (default: 4, 0 = adaptive)
 	AdaptiveMode    bool              // Enable adaptive concurrency scaling
 	ChangeType      config.ChangeType // Type of changes expected (default: MonotonicCount)
 	Verbose         bool              // Enable verbose progress logging
-	FileOps         *fileops.FileOps  // File operations (for dependency injection)
-	TimeProvider    TimeProvider      // Time provider (for dependency injection)
-	emitter         EventEmitter      // Event emitter for TUI communication (optional)
+	LogLevel        config.LogLevel   // Least severe records EnableFileLogging's log gets (default: info)
+	LogFormat       config.LogFormat  // How the log's records are written: text (default) or JSON
+	MaxDepth        int               // Maximum directory depth to scan below the roots (0 = unlimited)
+	Resume          bool              // Persist the sync plan and continue an interrupted one if found
+	ResumeStatePath string            // Resume state file (default: per source/dest file in the user cache dir)
+	DestFSType      config.DestFSType // Destination filename rules to enforce (default: detect)
+	SanitizeNames   bool              // Map names illegal on the destination to safe ones instead of skipping them
+	KeepNewest      int               // Only sync the newest N files in each source directory (0 = all)
+	PruneOlder      bool              // Delete destination copies of files KeepNewest leaves out
+	RepairMode      bool              // Only re-copy destination files whose hash differs from source
+	Preallocate     bool              // Reserve each destination file's full size before copying (Linux only)
+	MaxOpsPerSecond int               // Cap on filesystem operations per second across all workers (0 = unlimited)
+	MaxWritesPerDir int               // Cap on workers writing into any one destination directory at once (0 = unlimited)
+	SampleSize      int64             // Bytes per sampled region for QuickContent comparison (0 = default)
+	ModTimeSlack    time.Duration     // Modtime difference ModTimeNewer ignores, for coarse filesystem timestamps
+	CopyBufferSize  int               // Bytes each copy reads and writes at a time (0 = fileops.DefaultCopyBufferSize)
+
+	// Hash used to compare and verify file contents (default: SHA-256). The hash cache records
+	// which algorithm made its hashes, so switching rehashes everything once. CAStore always
+	// uses SHA-256, as it names stored content by hash.
+	HashAlgorithm config.HashAlgorithm
+
+	// Copy permission bits, and fix destination files whose permissions drifted even if content matches
+	PreservePermissions bool
+
+	// Store each unique content once under objects/ and map source paths to it in index.json
+	CAStore bool
+
+	// Order to copy files in (default: as analysis found them)
+	Order config.ProcessOrder
+
+	// Leave out source files whose content matches the same path under this local directory
+	BaselineDir string
+
+	// After syncing, check that the bytes counted as transferred add up to the completed files' sizes
+	VerifyTotals bool
+
+	// Only sync source files with this owner; other users' files are left alone at the destination
+	OwnerFilter config.OwnerFilter
+
+	// Compare each run's statistics with the previous run's, kept at RunHistoryPath (default: per source/dest)
+	CompareRuns    bool
+	RunHistoryPath string
+
+	// Scale adaptive workers down while the 1-minute load average is above this (0 = ignore load)
+	MaxLoadAverage float64
+	LoadSampler    func() (float64, error) // Reads the load average (default: fileops.LoadAverage)
+
+	// Refuse to analyze unless the destination is a mount point (local destinations only),
+	// and unless this file exists relative to the destination root, so an empty mount point
+	// that a share failed to mount over is neither filled nor mirrored onto
+	RequireMount bool
+	MountMarker  string
+
+	// Error categories that are recorded but don't count toward MaxErrorsBeforeAbort, so expected
+	// failures (e.g. permission denied on a few system files) don't abort a large sync
+	SkippableErrors []fileops.ErrorCategory
+
+	// Read the source from a read-only btrfs, ZFS or LVM snapshot taken when analysis starts, so
+	// files changing during the run can't leave an inconsistent copy. The snapshot is removed
+	// when Sync returns, when Analyze fails, or on Close.
+	SnapshotSource bool
+
+	// Load the source listing from this index file while it's fresh instead of scanning, and save
+	// each fresh scan to it, so a source synced to many destinations is walked once. An index is
+	// stale once any source directory's modtime changes, or when older than SourceIndexMaxAge
+	// (0 = no limit); RefreshSourceIndex always rescans.
+	SourceIndexPath    string
+	RefreshSourceIndex bool
+	SourceIndexMaxAge  time.Duration
+
+	// Track files that fail run after run at FailureHistoryPath (default: per source/dest). A file
+	// that failed in KnownFailureRuns consecutive runs is a known persistent failure: its errors
+	// are logged in one line and listed apart from new ones, and SkipKnownFailures leaves it out
+	// of the plan. 0 = don't track, unless SkipKnownFailures is set (then DefaultKnownFailureRuns).
+	KnownFailureRuns   int
+	SkipKnownFailures  bool
+	FailureHistoryPath string
+
+	// Decide whether a file needs a sync by this extended attribute (e.g. "user.asset_version")
+	// instead of ChangeType: sync when the values differ, or with XattrNewerOnly only when the
+	// source's version is higher. XattrMissing decides files where either copy lacks it. The
+	// attribute is copied along with each file. Local source and destination on Linux only.
+	XattrCompare   string
+	XattrNewerOnly bool
+	XattrMissing   config.MissingXattr
+
+	// Warn when the destination's clock differs from the source's by more than this, where either is
+	// remote (0 = don't check)
+	ClockSkewThreshold time.Duration
+
+	// Stop starting new files at this time, deferring the rest to a later run (zero = no deadline)
+	Deadline time.Time
+
+	// Send files smaller than BatchThreshold to remote destinations in tar batches unpacked remotely
+	BatchSmallFiles bool
+	BatchThreshold  int64 // 0 = DefaultBatchThreshold
+
+	// Clone files instead of copying them when the destination shares a copy-on-write filesystem (Linux only)
+	Reflink config.ReflinkMode
+
+	// Compare against a Bloom filter of destination path+hash keys instead of scanning the destination
+	DestHashBloom string
+	// After a successful sync, write a Bloom filter of the destination for DestHashBloom to use
+	WriteDestHashBloom string
+	// False-positive rate of the filter WriteDestHashBloom writes (0 = bloom.DefaultFalsePositiveRate)
+	BloomFalsePositiveRate float64
+
+	// After syncing, analyze and sync again until an analysis finds nothing to do, for sources that
+	// change during the run, giving up with a warning after this many sync passes (0 = sync once)
+	ConvergeIterations int
+
+	// Keep destination files that are newer than the source and a different size, which suggests
+	// they were edited at the destination rather than just skewed by clocks, and report them
+	// instead of overwriting them
+	ProtectDestEdits bool
+
+	// What to do with source files whose paths differ only in case, which a case-insensitive
+	// destination can't hold apart (default: don't check)
+	CaseConflictPolicy config.CaseConflict
+
+	// Copy every source file straight into the destination root under its name alone, dropping
+	// its subdirectories. Orphans are never deleted, as the destination may consolidate several
+	// sources (see deletesOrphans).
+	Flatten bool
+
+	// What to do with source files Flatten gives the same name (default: number the later ones)
+	CollisionPolicy config.CollisionPolicy
+
+	// During analysis, also estimate what deduplication and preserving sparse files would save on
+	// the source. Every file that shares its size with another is hashed, so this is slow.
+	EstimateSavings bool
+
+	// Keep a manifest of finished copies (ManifestFile) at the destination root while syncing,
+	// so an interrupted sync's next run skips comparing what was already copied and carries on
+	// half-written files from where their copies stopped. Failed and cancelled copies keep their
+	// temp files for this, as do retries within a run.
+	ResumeFromManifest bool
+
+	// Before carrying on a half-written file (ResumeFromManifest), hash what was already copied
+	// and compare it with the same bytes of the source, copying from scratch if they differ.
+	VerifyResumed bool
+
+	// After each copy, hash the destination file and compare it with the source's hash, taken
+	// while the copy streamed it. A mismatch fails the file and removes the bad copy.
+	VerifyAfterCopy bool
+
+	// Move each synced file instead of copying it: renamed when the source and destination are
+	// both local, otherwise copied and removed from the source once the copy is confirmed (see
+	// fileops.FileOps.Move). Destination files missing from the source are never deleted, as the
+	// files earlier runs moved are among them.
+	Move bool
+
+	// Cap on bytes per second copied across all workers (0 = unlimited). Like MaxOpsPerSecond,
+	// one limiter is shared by every worker, however many adaptive scaling runs.
+	MaxBytesPerSecond int64
+
+	// How symbolic links are scanned and copied: follow (the default) copies their targets,
+	// preserve recreates them as links, and skip leaves them out of both scans
+	SymlinkMode config.SymlinkMode
+
+	// After a complete sync, run Reconcile to check that the destination mirrors the source;
+	// ReconcileHashes also hashes files whose sizes match but whose modtimes don't
+	ReconcileAfterSync bool
+	ReconcileHashes    bool
+
+	// Write copies straight to their destination files instead of to temp files renamed into
+	// place when complete (fileops.TempSuffix), so an interrupted copy leaves a truncated file
+	InPlace bool
+
+	// Retry a failed copy up to MaxRetries times before recording it as failed, waiting
+	// RetryBackoff (default: DefaultRetryBackoff) before the first retry and twice as long
+	// before each one after it. Cancelled copies aren't retried.
+	MaxRetries   int
+	RetryBackoff time.Duration
+
+	// Delete destination files and directories missing from the source (default: true). When
+	// false the sync only adds and updates, for destinations that hold other files too.
+	DeleteOrphans bool
+
+	// Stop before deleting more than this many orphaned files until ConfirmDeletions is called
+	// (0 = no limit). Analysis still plans the deletions, so they can be previewed first.
+	MaxDeleteWithoutConfirm int
+
+	// Move orphans into a timestamped folder under TrashDir, a path on the destination's
+	// filesystem, instead of deleting them, keeping their paths relative to the destination
+	TrashDir string
+
+	// Reuse source file hashes from earlier runs, cached at HashCachePath (default: per source),
+	// while a file's size and modtime are unchanged. A source file rewritten with its size and
+	// modtime kept is only caught without the cache.
+	HashCache     bool
+	HashCachePath string
+
+	// Bounds on the adaptive worker count. Adaptive scaling starts at MinAdaptiveWorkers and never
+	// scales below it (0 = 1), nor above MaxAdaptiveWorkers (0 = DefaultMaxAdaptiveWorkers) or the
+	// number of files to copy. A fixed worker count ignores both.
+	MinAdaptiveWorkers int
+	MaxAdaptiveWorkers int
+
+	// Leave runs of zeros in copied files as holes in the destination instead of writing them,
+	// for disk images and other sparse files. Destinations that can't seek write them in full.
+	Sparse bool
+
+	// Create source directories at the destination even when nothing is copied into them, such
+	// as empty ones, instead of only making the parents of copied files. Ignored when flattening.
+	PreserveEmptyDirs bool
+
+	// The sources NewMultiSourceEngine merged, each synced into its own folder at the
+	// destination, with SourcePath filesystem.UnionRoot (nil = the one source at SourcePath)
+	Sources []config.SourceDir
+
+	// Least time between progress updates while copying, and between status callbacks for each
+	// file scanned, counted or hashed during analysis (default: 100ms; 0 = every callback).
+	// Phase changes and finished files always notify at once.
+	ProgressInterval time.Duration
+
+	FileOps         *fileops.FileOps // File operations (for dependency injection)
+	TimeProvider    TimeProvider     // Time provider (for dependency injection)
+	emitter         EventEmitter     // Event emitter for TUI communication (optional)
 	statusCallbacks []func(*Status)
 	mu              sync.RWMutex
-	cancelChan      chan struct{} // Channel to signal cancellation
-	cancelOnce      sync.Once     // Ensure Cancel() is only called once
-	logFile         *os.File      // Optional log file for debugging
-	logMu           sync.Mutex    // Mutex for log file writes
-	closeFunc       func()        // Function to close SFTP connections (if any)
-	desiredWorkers  int32         // Target worker count for adaptive scaling (atomic)
+	cancelChan      <-chan struct{}             // Closed on cancellation (the internal context's Done)
+	cancel          context.CancelFunc          // Cancels the internal context; see Cancel
+	logFile         *os.File                    // Optional log file for debugging
+	logger          atomic.Pointer[slog.Logger] // Writes to logFile; nil until EnableFileLogging
+	analysisLogFile *os.File                    // Optional log file for analysis decisions only
+	analysisLogMu   sync.Mutex                  // Mutex for analysis log file writes
+	itemizeFile     *os.File                    // Optional rsync --itemize-changes style record of changes
+	itemizeMu       sync.Mutex                  // Mutex for itemize file writes
+	closeFunc       func()                      // Function to close SFTP connections (if any)
+	desiredWorkers  int32                       // Target worker count for adaptive scaling (atomic)
+	nextWorkerID    int32                       // Last worker id handed out (atomic)
+	pauseMu         sync.Mutex                  // Guards paused, pausedAt and pauseCond
+	pauseCond       *sync.Cond                  // Paused workers wait on it; see pauseGate
+	paused          bool                        // Workers wait between files until Unpause
+	pausedAt        time.Time                   // When the current pause began
+	pauses          int32                       // Pauses ended so far (atomic), so scaling can skip them
+	deletesOK       atomic.Bool                 // ConfirmDeletions was called; see MaxDeleteWithoutConfirm
 	sourceResizable filesystem.ResizablePool
 	destResizable   filesystem.ResizablePool
+	resume          *resumeTracker    // Tracks completed files when Resume is enabled
+	destNames       map[string]string // Source path -> sanitized destination path
 
 	// File maps from analysis phase (stored for deletion during sync)
 	analysisSourceFiles map[string]*fileops.FileInfo
 	analysisDestFiles   map[string]*fileops.FileInfo
+	resumedOrphans      map[string]*fileops.FileInfo // Orphans a resumed plan still deletes
+
+	// Files the plan will delete (for PlanEntries)
+	planMu           sync.Mutex
+	plannedDeletions []PlanEntry
+
+	// Destination files whose content is current but permissions differ
+	permissionUpdates []permissionUpdate
+
+	// Source directories missing at the destination (PreserveEmptyDirs)
+	dirsToCreate []dirToCreate
+
+	// Path -> content index when CAStore is enabled
+	caIndex *caStoreIndex
+
+	// When notifyAnalysisProgress last notified (Unix nanoseconds)
+	lastAnalysisNotify atomic.Int64
+
+	// Source files OwnerFilter excluded, whose destination copies must not be deleted
+	ownerExcluded map[string]bool
+
+	// Destination entries whose type differs from the source, removed before syncing
+	typeChangedPaths []string
+
+	// Temp files interrupted copies left at the destination, removed before syncing
+	staleTempFiles []string
+
+	// Source snapshot being read (SnapshotSource only), and SourcePath before it pointed there
+	snapshot       *filesystem.Snapshot
+	liveSourcePath string
+
+	// 1 while the latest load average sample is above MaxLoadAverage (atomic)
+	overloaded int32
+
+	// Per-path failure counts from earlier runs (nil = not tracking failures)
+	failures *failureHistory
+
+	// Per-directory write slots for sync workers (nil = MaxWritesPerDir unset)
+	dirWrites *dirWriteLimiter
+
+	// Copy states from the destination's resume manifest (nil = ResumeFromManifest unset)
+	manifest *syncManifest
+
+	// This run's folder under TrashDir, set when its first orphan is trashed
+	trashRunDir string
+
+	// Source hashes from earlier runs, loaded when the first one is needed (nil = none needed yet)
+	hashCache     *hashCache
+	hashCacheOnce sync.Once
+
+	// Destination file hashes learned this run by comparing and copying (WriteDestHashBloom only)
+	destHashes     *hashCache
+	destHashesOnce sync.Once
+	destListed     bool // Analysis accounted for every destination file, so none need scanning
 }
 
 // NewEngine creates a new sync engine.
-// Supports both local paths and SFTP URLs (sftp://user@host:port/path).
-// Returns (*Engine, error) where error indicates filesystem setup failure.
+// Supports local paths, SFTP URLs (sftp://user@host:port/path) and object store URLs
+// (s3://bucket/prefix, gs://bucket/prefix).
+// Returns (*Engine, error) where error indicates filesystem setup failure, or that the
+// destination is the source or inside it or the other way around (see config.CheckPathOverlap).
 func NewEngine(source, dest string) (*Engine, error) {
+	return newPairEngine(source, dest, nil)
+}
+
+// newPairEngine is NewEngine with SFTP passwords looked up in keychain.
+func newPairEngine(source, dest string, keychain filesystem.Keychain) (*Engine, error) {
+	err := config.CheckPathOverlap(source, dest)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // The config error already names both paths
+	}
+
 	// Create filesystems for source and destination
 	// Returns: sourceFS, destFS, srcPath, dstPath, closer, err
-	sourceFS, destFS, srcPath, dstPath, closer, err := filesystem.CreateFileSystemPair(source, dest)
+	sourceFS, destFS, srcPath, dstPath, closer, err := filesystem.CreateFileSystemPair(source, dest, keychain)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create filesystems: %w", err)
 	}
 
+	return newEngine(sourceFS, destFS, srcPath, dstPath, closer), nil
+}
+
+// newEngine creates an engine syncing srcPath on sourceFS to dstPath on destFS, calling closer
+// (if set) on Close.
+func newEngine(sourceFS, destFS filesystem.FileSystem, srcPath, dstPath string, closer func()) *Engine {
+	ctx, cancel := context.WithCancel(context.Background())
+
 	engine := &Engine{
-		SourcePath:   srcPath,
-		DestPath:     dstPath,
-		TimeProvider: &RealTimeProvider{},
-		Workers:      config.DefaultMaxWorkers,                 // Default to 4 concurrent workers
-		ChangeType:   config.MonotonicCount,                    // Default to monotonic count
-		FileOps:      fileops.NewDualFileOps(sourceFS, destFS), // Support cross-filesystem operations
+		SourcePath:       srcPath,
+		DestPath:         dstPath,
+		TimeProvider:     &RealTimeProvider{},
+		LoadSampler:      fileops.LoadAverage,
+		Workers:          config.DefaultMaxWorkers, // Default to 4 concurrent workers
+		ChangeType:       config.MonotonicCount,    // Default to monotonic count
+		DeleteOrphans:    true,                     // Default to mirroring the source
+		ModTimeSlack:     config.DefaultModTimeSlack,
+		ProgressInterval: config.DefaultProgressInterval,
+		FileOps:          fileops.NewDualFileOps(sourceFS, destFS), // Support cross-filesystem operations
 		Status: &Status{
 			StartTime: time.Now(),
 		},
 		statusCallbacks: make([]func(*Status), 0),
-		cancelChan:      make(chan struct{}),
+		cancelChan:      ctx.Done(),
+		cancel:          cancel,
 		closeFunc:       closer, // Store closer to clean up SFTP connections
 	}
 
@@ -128,7 +494,7 @@ func NewEngine(source, dest string) (*Engine, error) {
 		engine.destResizable = resizable
 	}
 
-	return engine, nil
+	return engine
 }
 
 // SetEventEmitter sets the event emitter for TUI communication.
@@ -151,67 +517,178 @@ func (e *Engine) emit(event Event) {
 }
 
 // Analyze scans source and destination to determine what needs to be synced
-func (e *Engine) Analyze() error {
+func (e *Engine) Analyze() (err error) {
 	e.logAnalysis("Starting analysis...")
 
-	err := e.checkCancellation()
+	// Apply depth limit to both scans so deep paths are neither synced nor treated as orphans
+	e.FileOps.MaxDepth = e.MaxDepth
+	// Let Cancel stop both scans mid-walk rather than after they finish
+	e.FileOps.CancelChan = e.cancelChan
+	// One limiter for analysis and every sync worker, so scaling up can't exceed the cap
+	e.FileOps.OpLimiter = fileops.NewOpLimiter(e.MaxOpsPerSecond)
+	e.FileOps.Symlinks = fileops.SymlinkMode(e.SymlinkMode)
+	e.FileOps.HashAlgorithm = e.hashAlgorithm()
+	if e.MaxDepth > 0 {
+		e.logAnalysis(fmt.Sprintf("Limiting scan depth to %d levels", e.MaxDepth))
+	}
+
+	switch e.SymlinkMode {
+	case config.SymlinkPreserve:
+		e.logAnalysis("Preserving symlinks: they're recreated at the destination as links")
+	case config.SymlinkSkip:
+		e.logAnalysis("Skipping symlinks: they're left out of both scans")
+	case config.SymlinkFollow:
+	}
+
+	if e.MaxOpsPerSecond > 0 {
+		e.logAnalysis(fmt.Sprintf("Limiting filesystem operations to %d per second", e.MaxOpsPerSecond))
+	}
+
+	switch e.FileOps.HashAlgorithm {
+	case fileops.HashBLAKE3:
+		e.logAnalysis("Hashing file contents with BLAKE3")
+	case fileops.HashXXHash:
+		e.logAnalysis("Hashing file contents with xxHash, which is fast but not cryptographic")
+	case fileops.HashSHA256:
+	}
+
+	err = e.checkCancellation()
+	if err != nil {
+		return err
+	}
+
+	// An unmounted destination has to stop the run before anything is compared or deleted
+	err = e.checkDestMounted()
+	if err != nil {
+		return err
+	}
+
+	err = e.checkXattrCompare()
+	if err != nil {
+		return err
+	}
+
+	if e.SnapshotSource {
+		err = e.snapshotSource()
+		if err != nil {
+			return err
+		}
+
+		// A failed analysis is never followed by the Sync that would remove the snapshot
+		defer func() {
+			if err != nil {
+				e.releaseSnapshot()
+			}
+		}()
+	}
+
+	if e.ClockSkewThreshold > 0 {
+		e.checkClockSkew()
+	}
+
+	// The store's index records what's done, so it needs neither resume state nor a dest scan
+	if e.CAStore {
+		return e.analyzeCAStore()
+	}
+
+	e.destListed = false
+
+	// Continue an interrupted sync's plan instead of re-comparing everything
+	resumed, err := e.tryResume()
 	if err != nil {
 		return err
 	}
 
+	if resumed {
+		return nil
+	}
+
+	// A destination too large to scan is checked against its hash filter instead
+	if e.DestHashBloom != "" {
+		return e.analyzeWithDestBloom()
+	}
+
+	if e.RepairMode {
+		e.logAnalysis("Repair mode: verifying destination files by hash, ignoring size and modtime")
+	}
+
 	// Try monotonic-count optimization if applicable
 	optimized, err := e.tryMonotonicCountOptimization()
+	if errors.Is(err, ErrCancelled) {
+		return ErrAnalysisCancelled
+	}
+
 	if err != nil {
 		return err
 	}
 
 	if optimized {
+		e.compareWithPreviousRun()
 		return nil
 	}
 
-	// Scan source and destination directories in parallel
-	var sourceFiles, destFiles map[string]*fileops.FileInfo
-	var sourceErr, destErr error
-	var wg sync.WaitGroup
-	wg.Add(2) //nolint:mnd // Two parallel scans
+	sourceFiles, destFiles, err := e.scanTrees()
+	if err != nil {
+		return err
+	}
 
-	// Emit ScanStarted for both immediately
-	e.emit(ScanStarted{Target: "source"})
-	e.emit(ScanStarted{Target: "dest"})
+	err = e.checkCancellation()
+	if err != nil {
+		return err
+	}
 
-	go func() {
-		defer wg.Done()
-		sourceFiles, sourceErr = e.scanSourceDirectory()
-		if sourceErr == nil {
-			e.emit(ScanComplete{Target: "source", Count: len(sourceFiles)})
-		}
-	}()
+	// The resume manifest belongs to the destination, not to the sync, and says which copies an
+	// earlier run didn't finish can be carried on
+	if e.ResumeFromManifest {
+		delete(destFiles, ManifestFile)
 
-	go func() {
-		defer wg.Done()
-		destFiles, destErr = e.scanDestinationDirectory()
-		if destErr == nil {
-			e.emit(ScanComplete{Target: "dest", Count: len(destFiles)})
+		err = e.loadManifest()
+		if err != nil {
+			return err
 		}
-	}()
+	}
 
-	wg.Wait()
+	// Copies an earlier run didn't finish are neither content nor orphans
+	e.planStaleTempFileRemoval(sourceFiles, destFiles)
+
+	e.logSamplePaths(sourceFiles, destFiles)
 
-	// Check for errors after both complete
-	if sourceErr != nil {
-		return sourceErr
+	// Catch names the destination can't store before they fail mid-sync
+	e.applyNameRules(sourceFiles, destFiles)
+
+	err = e.applyCaseConflictPolicy(sourceFiles, destFiles)
+	if err != nil {
+		return err
 	}
-	if destErr != nil {
-		return destErr
+
+	// Every name has to be settled before a flattened sync picks its destination names
+	e.applyFlatten(sourceFiles, destFiles)
+
+	// Drop files outside the retention window before comparing
+	e.applyRetentionPolicy(sourceFiles, destFiles)
+
+	// Other owners' files at the destination aren't orphans, just outside the filter
+	e.keepOtherOwnersAtDest(sourceFiles, destFiles)
+
+	// Excluded files at the destination aren't orphans either
+	e.keepExcludedAtDest(destFiles)
+	e.keepOutOfRangeAtDest(sourceFiles, destFiles)
+	e.keepSkippedLinksAtDest(sourceFiles, destFiles)
+
+	// The mount marker belongs to the destination, not to the sync
+	if e.MountMarker != "" {
+		delete(destFiles, filepath.Clean(e.MountMarker))
 	}
 
-	err = e.checkCancellation()
+	// As does a trash inside it, or every run would trash the last run's trash
+	e.keepTrashAtDest(destFiles)
+
+	// Drop files the baseline already provides
+	err = e.applyBaseline(sourceFiles, destFiles)
 	if err != nil {
 		return err
 	}
 
-	e.logSamplePaths(sourceFiles, destFiles)
-
 	// Compare files and determine which need sync
 	e.emit(CompareStarted{})
 	err = e.compareAndPlanSync(sourceFiles, destFiles)
@@ -219,60 +696,210 @@ func (e *Engine) Analyze() error {
 		return err
 	}
 
-	// Store file maps for deletion during sync phase
-	e.analysisSourceFiles = sourceFiles
-	e.analysisDestFiles = destFiles
+	if e.EstimateSavings {
+		err = e.estimateSavings(sourceFiles)
+		if err != nil {
+			return err
+		}
+	}
 
-	// Count orphaned items (for plan display) but don't delete yet - deletion happens during sync
-	e.countOrphanedItemsForPlan(sourceFiles, destFiles)
+	// A repair pass only fixes existing files, it never deletes
+	if !e.RepairMode {
+		// Store file maps for deletion during sync phase
+		e.analysisSourceFiles = sourceFiles
+		e.analysisDestFiles = destFiles
+		e.destListed = true
+
+		// Count orphaned items (for plan display) but don't delete yet - deletion happens during sync
+		if e.deletesOrphans() {
+			e.countOrphanedItemsForPlan(sourceFiles, destFiles)
+		} else {
+			e.keepOrphans(sourceFiles, destFiles)
+		}
+	}
 
 	e.finalizeAnalysis()
 
 	// Emit compare complete with sync plan
-	e.Status.mu.RLock()
-	plan := &SyncPlan{
-		FilesToCopy:       len(e.Status.FilesToSync),
-		FilesToDelete:     e.Status.FilesOnlyInDest,
-		BytesToCopy:       e.Status.TotalBytes,
-		FilesInBoth:       e.Status.FilesInBoth,
-		FilesOnlyInSource: e.Status.FilesOnlyInSource,
-		FilesOnlyInDest:   e.Status.FilesOnlyInDest,
-		BytesInBoth:       e.Status.BytesInBoth,
-		BytesOnlyInSource: e.Status.BytesOnlyInSource,
-		BytesOnlyInDest:   e.Status.BytesOnlyInDest,
-	}
-	e.Status.mu.RUnlock()
-	e.emit(CompareComplete{Plan: plan})
+	e.emitSyncPlan()
 
 	return nil
 }
 
-// Cancel stops the sync operation gracefully
+// ApplyConfig sets the engine's options from the command-line configuration.
+// Paths are not changed; they are fixed when the engine is created.
+func (e *Engine) ApplyConfig(cfg *config.Config) {
+	e.FilePattern = cfg.FilePattern
+	e.ExcludePatterns = cfg.Exclude
+	e.MinFileSize = int64(cfg.MinSize)
+	e.MaxFileSize = int64(cfg.MaxSize)
+	e.ModifiedAfter = cfg.NewerThan.Time
+	e.ModifiedBefore = cfg.OlderThan.Time
+	e.Workers = cfg.Workers
+	e.AdaptiveMode = cfg.AdaptiveMode
+	e.ChangeType = cfg.TypeOfChange
+	e.Verbose = cfg.Verbose
+	e.LogLevel = cfg.LogLevel
+	e.LogFormat = cfg.LogFormat
+	e.MaxDepth = cfg.MaxDepth
+	e.Resume = cfg.Resume
+	e.DestFSType = cfg.DestFS
+	e.SanitizeNames = cfg.SanitizeNames
+	e.KeepNewest = cfg.KeepNewest
+	e.PruneOlder = cfg.PruneOlder
+	e.RepairMode = cfg.Repair
+	e.Preallocate = cfg.Preallocate
+	e.PreservePermissions = cfg.PreservePermissions
+	e.MaxOpsPerSecond = cfg.MaxOpsPerSecond
+	e.CAStore = cfg.CAStore
+	e.SampleSize = cfg.SampleSize
+	e.ModTimeSlack = cfg.ModTimeSlack
+	e.HashAlgorithm = cfg.ChecksumAlgo
+	e.DestHashBloom = cfg.DestHashBloom
+	e.WriteDestHashBloom = cfg.WriteDestHashBloom
+	e.BloomFalsePositiveRate = cfg.BloomFPR
+	e.Reflink = cfg.Reflink
+	e.BatchSmallFiles = cfg.BatchSmallFiles
+	e.BatchThreshold = cfg.BatchThreshold
+	e.Deadline = cfg.DeadlineFrom(time.Now())
+	e.Order = cfg.Order
+	e.ClockSkewThreshold = cfg.ClockSkewThreshold
+	e.CompareRuns = cfg.CompareRuns
+	e.BaselineDir = cfg.BaselineDir
+	e.VerifyTotals = cfg.VerifyTotals
+	e.OwnerFilter = config.OwnerFilter{UID: cfg.OwnerUID, GID: cfg.OwnerGID}
+	e.MaxLoadAverage = cfg.MaxLoad
+	e.RequireMount = cfg.RequireMount
+	e.MountMarker = cfg.MountMarker
+	e.SkippableErrors = cfg.SkipErrors
+	e.SnapshotSource = cfg.SnapshotSource
+	e.SourceIndexPath = cfg.SourceIndex
+	e.RefreshSourceIndex = cfg.RefreshIndex
+	e.SourceIndexMaxAge = cfg.SourceIndexMaxAge
+	e.KnownFailureRuns = cfg.KnownFailures
+	e.SkipKnownFailures = cfg.SkipKnownFailures
+	e.MaxWritesPerDir = cfg.MaxWritesPerDir
+	e.ConvergeIterations = cfg.Converge
+	e.ProtectDestEdits = cfg.ProtectDestEdits
+	e.CaseConflictPolicy = cfg.CaseConflicts
+	e.Flatten = cfg.Flatten
+	e.CollisionPolicy = cfg.OnCollision
+	e.EstimateSavings = cfg.EstimateSavings
+	e.ResumeFromManifest = cfg.ResumeManifest
+	e.VerifyResumed = cfg.VerifyResumed
+	e.VerifyAfterCopy = cfg.VerifyAfterCopy
+	e.Move = cfg.Move
+	e.MaxBytesPerSecond = int64(cfg.MaxRate)
+	e.SymlinkMode = cfg.Symlinks
+	e.ReconcileAfterSync = cfg.Reconcile
+	e.ReconcileHashes = cfg.ReconcileHashes
+	e.InPlace = cfg.InPlace
+	e.MaxRetries = cfg.MaxRetries
+	e.RetryBackoff = cfg.RetryDelay
+	e.DeleteOrphans = !cfg.NoDelete
+	e.MaxDeleteWithoutConfirm = cfg.ConfirmDeletesOver
+	e.TrashDir = cfg.TrashDir
+	e.HashCache = !cfg.NoCache
+	e.MinAdaptiveWorkers = cfg.MinWorkers
+	e.MaxAdaptiveWorkers = cfg.MaxWorkers
+	e.Sparse = cfg.Sparse
+	e.CopyBufferSize = int(cfg.CopyBufferSize)
+	e.PreserveEmptyDirs = cfg.PreserveEmptyDirs
+	e.ProgressInterval = cfg.ProgressInterval
+	e.XattrCompare = cfg.XattrCompare
+	e.XattrNewerOnly = cfg.XattrNewer
+	e.XattrMissing = cfg.XattrMissing
+}
+
+// BuildSourceIndex scans the source and saves the listing to SourceIndexPath without
+// looking at any destination, returning the number of entries indexed.
+func (e *Engine) BuildSourceIndex() (int, error) {
+	if e.SourceIndexPath == "" {
+		return 0, ErrNoSourceIndex
+	}
+
+	e.FileOps.MaxDepth = e.MaxDepth
+	e.FileOps.CancelChan = e.cancelChan
+
+	sourceFiles, err := e.scanSourceWithProgress()
+	if err != nil {
+		return 0, err
+	}
+
+	err = e.saveSourceIndex(sourceFiles)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(sourceFiles), nil
+}
+
+// Cancel stops the sync operation gracefully, waking workers waiting out a Pause so they stop too
 func (e *Engine) Cancel() {
-	e.cancelOnce.Do(func() {
-		close(e.cancelChan)
-	})
+	e.cancel()
+	e.wakePausedWorkers()
 }
 
 // Close cleans up resources, including SFTP connections if any.
 // Should be called when done with the engine.
 func (e *Engine) Close() {
+	e.releaseSnapshot()
 	e.CloseLog()
 	if e.closeFunc != nil {
 		e.closeFunc()
 	}
 }
 
-// CloseLog closes the log file if open
+// CloseLog closes the debug log and analysis log files if open
 func (e *Engine) CloseLog() {
 	if e.logFile != nil {
-		e.logToFile(fmt.Sprintf("\n=== Sync Log Ended: %s ===", time.Now().Format(time.RFC3339)))
+		e.logToFile("Sync log ended")
+		e.logger.Store(nil)
 		_ = e.logFile.Close()
 		e.logFile = nil
 	}
+
+	e.analysisLogMu.Lock()
+	defer e.analysisLogMu.Unlock()
+
+	if e.analysisLogFile != nil {
+		_ = e.analysisLogFile.Close()
+		e.analysisLogFile = nil
+	}
+
+	e.itemizeMu.Lock()
+	defer e.itemizeMu.Unlock()
+
+	if e.itemizeFile != nil {
+		_ = e.itemizeFile.Close()
+		e.itemizeFile = nil
+	}
+}
+
+// EnableAnalysisLogging writes every analysis log entry to its own file.
+// Unlike Status.AnalysisLog, which only retains the most recent entries, the
+// file captures the full history of comparison and plan decisions.
+func (e *Engine) EnableAnalysisLogging(logPath string) error {
+	f, err := os.Create(logPath)
+	if err != nil {
+		return fmt.Errorf("failed to create analysis log file: %w", err)
+	}
+
+	e.analysisLogMu.Lock()
+	defer e.analysisLogMu.Unlock()
+
+	// A second call replaces the log, which would otherwise leak the first one's handle
+	if e.analysisLogFile != nil {
+		_ = e.analysisLogFile.Close()
+	}
+
+	e.analysisLogFile = f
+
+	return nil
 }
 
-// EnableFileLogging enables logging to a file for debugging
+// EnableFileLogging enables logging to a file for debugging, writing structured records at
+// LogLevel and above in LogFormat.
 func (e *Engine) EnableFileLogging(logPath string) error {
 	f, err := os.Create(logPath)
 	if err != nil {
@@ -280,48 +907,58 @@ func (e *Engine) EnableFileLogging(logPath string) error {
 	}
 
 	e.logFile = f
-	e.logToFile(fmt.Sprintf("=== Sync Log Started: %s ===", time.Now().Format(time.RFC3339)))
-	e.logToFile("Source: " + e.SourcePath)
-	e.logToFile("Destination: " + e.DestPath)
-	e.logToFile(fmt.Sprintf("Workers: %d, Adaptive: %v, ChangeType: %v", e.Workers, e.AdaptiveMode, e.ChangeType))
-	e.logToFile("")
+	e.logger.Store(newLogger(f, e.LogLevel, e.LogFormat))
+	e.logToFile("Sync log started", "source", e.SourcePath, "dest", e.DestPath,
+		"workers", e.Workers, "adaptive", e.AdaptiveMode, "change_type", e.ChangeType)
+
+	return nil
+}
+
+// EnableItemizeLog writes one line per destination change to a file, in the format of
+// rsync --itemize-changes (">f.st...... path"), for tools built around rsync's output.
+func (e *Engine) EnableItemizeLog(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create itemize file: %w", err)
+	}
+
+	e.itemizeMu.Lock()
+	e.itemizeFile = f
+	e.itemizeMu.Unlock()
 
 	return nil
 }
 
-// EvaluateAndScale evaluates current performance and decides whether to add workers
+// EvaluateAndScale evaluates current performance and decides whether to add workers.
+// Until the rolling window holds at least two rate samples there is no rate to compare, so the
+// evaluation only moves state.LastCheckTime on and leaves the worker count alone.
 //
-//nolint:lll,revive // Long function signature with many parameters, currentProcessedFiles reserved for future use
+//nolint:lll,revive // Long function signature with many parameters, currentProcessedFiles and currentBytes kept for callers
 func (e *Engine) EvaluateAndScale(state *AdaptiveScalingState, currentProcessedFiles, currentWorkers int, currentBytes int64, maxWorkers int, workerControl chan bool) {
 	now := e.TimeProvider.Now()
 
 	// Calculate current total throughput using rolling window metrics (hill climbing algorithm)
-	if !state.LastCheckTime.IsZero() { //nolint:nestif // Nested conditions required for hill climbing algorithm
+	if !state.LastCheckTime.IsZero() {
 		elapsed := now.Sub(state.LastCheckTime).Seconds()
 
 		if elapsed > 0 {
-			var currentThroughput float64
-
-			// Try to use smoothed total throughput from rolling window
-			workerMetrics := e.Status.calculateWorkerMetrics()
+			workerMetrics := e.Status.CalculateWorkerMetrics()
 
 			// Need at least 2 samples for meaningful comparison
-			if len(e.Status.Workers.RecentSamples) >= 2 { //nolint:mnd // Minimum samples needed
-				// Use smoothed total rate from rolling window
-				currentThroughput = workerMetrics.TotalRate
-
-				//nolint:lll // Log message with multiple formatted values
-				e.logToFile(fmt.Sprintf("HillClimbing: Evaluation at %.1fs - %d workers, total throughput: %.2f MB/s (prev: %.2f MB/s) [%d samples]",
-					elapsed, currentWorkers, currentThroughput/BytesPerKilobyte/BytesPerKilobyte, state.LastThroughput/BytesPerKilobyte/BytesPerKilobyte, len(e.Status.Workers.RecentSamples)))
-			} else {
-				// Fall back to raw point-to-point calculation when insufficient samples
-				currentThroughput = float64(currentBytes) / elapsed
+			if len(workerMetrics.RecentSamples) < 2 { //nolint:mnd // Minimum samples needed
+				state.LastCheckTime = now
+				e.logDebug("HillClimbing: evaluation holding until there are more rate samples", "elapsed", elapsed,
+					"active", currentWorkers, "samples", len(workerMetrics.RecentSamples))
 
-				//nolint:lll // Log message with multiple formatted values
-				e.logToFile(fmt.Sprintf("HillClimbing: Evaluation at %.1fs - %d workers, total throughput: %.2f MB/s (prev: %.2f MB/s) [raw - need more samples]",
-					elapsed, currentWorkers, currentThroughput/BytesPerKilobyte/BytesPerKilobyte, state.LastThroughput/BytesPerKilobyte/BytesPerKilobyte))
+				return
 			}
 
+			// Use smoothed total rate from rolling window
+			currentThroughput := workerMetrics.TotalRate
+
+			e.logDebug("HillClimbing: evaluation", "elapsed", elapsed, "active", currentWorkers,
+				"throughput", currentThroughput, "last_throughput", state.LastThroughput, "samples", len(workerMetrics.RecentSamples))
+
 			// Make scaling decision using hill climbing algorithm based on total throughput
 			newState := e.HillClimbingScalingDecision(state, currentThroughput, currentWorkers, maxWorkers, workerControl)
 
@@ -331,7 +968,7 @@ func (e *Engine) EvaluateAndScale(state *AdaptiveScalingState, currentProcessedF
 	} else {
 		// First check - initialize state and let hill climbing handle it on next evaluation
 		state.LastCheckTime = now
-		e.logToFile(fmt.Sprintf("HillClimbing: Initial baseline - starting with %d workers", currentWorkers))
+		e.logDebug("HillClimbing: initial baseline", "active", currentWorkers)
 	}
 }
 
@@ -359,11 +996,13 @@ func (e *Engine) GetStatus() *Status {
 		BytesPerSecond:     e.Status.BytesPerSecond,
 		EstimatedTimeLeft:  e.Status.EstimatedTimeLeft,
 		CompletionTime:     e.Status.CompletionTime,
+		EstimateSamples:    e.Status.EstimateSamples,
 		TotalFilesInSource: e.Status.TotalFilesInSource,
 		TotalFilesInDest:   e.Status.TotalFilesInDest,
 		TotalBytesInSource: e.Status.TotalBytesInSource,
 		AlreadySyncedFiles: e.Status.AlreadySyncedFiles,
 		AlreadySyncedBytes: e.Status.AlreadySyncedBytes,
+		ResumeSkippedFiles: e.Status.ResumeSkippedFiles,
 		AnalysisPhase:      e.Status.AnalysisPhase,
 		ScannedFiles:       e.Status.ScannedFiles,
 		TotalFilesToScan:   e.Status.TotalFilesToScan,
@@ -383,6 +1022,13 @@ func (e *Engine) GetStatus() *Status {
 	status.CurrentFiles = make([]string, len(e.Status.CurrentFiles))
 	copy(status.CurrentFiles, e.Status.CurrentFiles)
 
+	// Copy the rate samples, which addRateSample prunes in place
+	status.Workers.RecentSamples = make([]RateSample, len(e.Status.Workers.RecentSamples))
+	copy(status.Workers.RecentSamples, e.Status.Workers.RecentSamples)
+
+	// Copy the per-worker rates, which the next ComputeProgressMetrics replaces
+	status.PerWorkerBytesPerSecond = slices.Clone(e.Status.PerWorkerBytesPerSecond)
+
 	// Copy Errors slice (usually small)
 	status.Errors = make([]FileError, len(e.Status.Errors))
 	copy(status.Errors, e.Status.Errors)
@@ -395,6 +1041,64 @@ func (e *Engine) GetStatus() *Status {
 	status.AnalysisLog = make([]string, len(e.Status.AnalysisLog))
 	copy(status.AnalysisLog, e.Status.AnalysisLog)
 
+	status.RetentionExcludedFiles = e.Status.RetentionExcludedFiles
+	status.BaselineExcludedFiles = e.Status.BaselineExcludedFiles
+	status.OwnerExcludedFiles = e.Status.OwnerExcludedFiles
+	status.SizeSkippedFiles = e.Status.SizeSkippedFiles
+	status.ModTimeSkippedFiles = e.Status.ModTimeSkippedFiles
+	status.MovedFiles = e.Status.MovedFiles
+	status.RenamedFiles = e.Status.RenamedFiles
+	status.SkippedErrors = e.Status.SkippedErrors
+	status.SourceSnapshot = e.Status.SourceSnapshot
+	status.SourceIndexUsed = e.Status.SourceIndexUsed
+	status.SourceIndexScannedAt = e.Status.SourceIndexScannedAt
+	status.SourceIndexSaved = e.Status.SourceIndexSaved
+	status.LoadAverage = e.Status.LoadAverage
+	status.LoadThrottled = e.Status.LoadThrottled
+	status.LoadAverageUnsupported = e.Status.LoadAverageUnsupported
+	status.Paused = e.Status.Paused
+	status.PausedWorkers = e.Status.PausedWorkers
+	status.ExpectedTransferredBytes = e.Status.ExpectedTransferredBytes
+	status.TotalsMismatch = e.Status.TotalsMismatch
+	status.RepairedFiles = e.Status.RepairedFiles
+	status.MetadataUpdates = e.Status.MetadataUpdates
+	status.DedupedFiles = e.Status.DedupedFiles
+	status.ReflinkedFiles = e.Status.ReflinkedFiles
+	status.SparseBytes = e.Status.SparseBytes
+	status.DirsToCreate = e.Status.DirsToCreate
+	status.DirsCreated = e.Status.DirsCreated
+	status.BatchedFiles = e.Status.BatchedFiles
+	status.Batches = e.Status.Batches
+	status.Deadline = e.Status.Deadline
+	status.DeferredFiles = e.Status.DeferredFiles
+
+	status.PhaseDurations = make(map[string]time.Duration, len(e.Status.PhaseDurations))
+	maps.Copy(status.PhaseDurations, e.Status.PhaseDurations)
+	status.PreviousRun = e.Status.PreviousRun // Replaced, never modified, so sharing is safe
+	status.RunDeltaWarning = e.Status.RunDeltaWarning
+	status.ClockSkew = e.Status.ClockSkew
+	status.ClockSkewMeasured = e.Status.ClockSkewMeasured
+	status.ExcessiveClockSkew = e.Status.ExcessiveClockSkew
+	status.FreeInodes = e.Status.FreeInodes
+	status.InodesNeeded = e.Status.InodesNeeded
+	status.InsufficientInodes = e.Status.InsufficientInodes
+
+	// Copy filename check results
+	status.IllegalNames = make([]string, len(e.Status.IllegalNames))
+	copy(status.IllegalNames, e.Status.IllegalNames)
+	status.KnownFailuresSkipped = make([]string, len(e.Status.KnownFailuresSkipped))
+	copy(status.KnownFailuresSkipped, e.Status.KnownFailuresSkipped)
+	status.SanitizedNames = make([]SanitizedName, len(e.Status.SanitizedNames))
+	copy(status.SanitizedNames, e.Status.SanitizedNames)
+	status.CaseConflicts = make([]CaseConflict, len(e.Status.CaseConflicts))
+	copy(status.CaseConflicts, e.Status.CaseConflicts)
+	status.FlattenCollisions = make([]FlattenCollision, len(e.Status.FlattenCollisions))
+	copy(status.FlattenCollisions, e.Status.FlattenCollisions)
+	status.TypeChanges = make([]string, len(e.Status.TypeChanges))
+	copy(status.TypeChanges, e.Status.TypeChanges)
+	status.ProtectedDestEdits = make([]string, len(e.Status.ProtectedDestEdits))
+	copy(status.ProtectedDestEdits, e.Status.ProtectedDestEdits)
+
 	// Copy RecentlyCompleted slice
 	status.RecentlyCompleted = make([]string, len(e.Status.RecentlyCompleted))
 	copy(status.RecentlyCompleted, e.Status.RecentlyCompleted)
@@ -421,8 +1125,33 @@ func (e *Engine) GetStatus() *Status {
 
 	// Copy deletion progress tracking fields
 	status.FilesToDelete = e.Status.FilesToDelete
+	status.DeletionConfirmationRequired = e.Status.DeletionConfirmationRequired
 	status.FilesDeleted = e.Status.FilesDeleted
 	status.BytesToDelete = e.Status.BytesToDelete
+	status.OrphansByAge = e.Status.OrphansByAge // Replaced, never modified, so sharing is safe
+	status.SizeHistogram = e.Status.SizeHistogram
+	status.ConvergePasses = slices.Clone(e.Status.ConvergePasses)
+	status.EstimatedDedupSavings = e.Status.EstimatedDedupSavings
+	status.EstimatedSparseSavings = e.Status.EstimatedSparseSavings
+	status.EstimatedStorageWith = e.Status.EstimatedStorageWith
+	status.ManifestSkippedFiles = e.Status.ManifestSkippedFiles
+	status.ManifestRecopiedFiles = e.Status.ManifestRecopiedFiles
+	status.ResumedFiles = e.Status.ResumedFiles
+	status.ResumedBytes = e.Status.ResumedBytes
+	status.VerifiedFiles = e.Status.VerifiedFiles
+	status.PreservedSymlinks = e.Status.PreservedSymlinks
+	status.CachedHashes = e.Status.CachedHashes
+	status.FilesTrashed = e.Status.FilesTrashed
+	status.TrashPath = e.Status.TrashPath
+	status.DeletionDisabled = e.Status.DeletionDisabled
+	status.OrphansKept = e.Status.OrphansKept
+	status.OrphanBytesKept = e.Status.OrphanBytesKept
+	status.MinAdaptiveWorkers = e.Status.MinAdaptiveWorkers
+	status.MaxAdaptiveWorkers = e.Status.MaxAdaptiveWorkers
+	status.Reconcile = e.Status.Reconcile // Replaced, never modified, so sharing is safe
+	status.Verify = e.Status.Verify       // Likewise
+	status.EstimatedStorageWithout = e.Status.EstimatedStorageWithout
+	status.NotConverged = e.Status.NotConverged
 	status.BytesDeleted = e.Status.BytesDeleted
 	status.DeletionComplete = e.Status.DeletionComplete
 	status.DeletionErrors = e.Status.DeletionErrors
@@ -498,107 +1227,98 @@ func (e *Engine) HillClimbingScalingDecision(
 		atomic.StoreInt32(&e.desiredWorkers, int32(currentWorkers)) //nolint:gosec // Small value, no overflow risk
 	}
 
-	// Determine adjustment direction
-	var adjustment int
+	// Determine adjustment direction, and why, for the scaling event logged below
+	var (
+		adjustment int
+		ratio      float64 // Throughput against the last evaluation's (0 on the first)
+		reason     string
+	)
 
 	if state.LastThroughput == 0 {
 		// First measurement - optimistically add worker
 		adjustment = 1
-		e.logToFile(fmt.Sprintf("HillClimbing: First measurement (%.2f MB/s), optimistically adding worker",
-			currentThroughput/BytesPerKilobyte/BytesPerKilobyte))
+		reason = "first measurement, optimistically adding worker"
 	} else {
 		// Calculate throughput ratio
-		throughputRatio := currentThroughput / state.LastThroughput
+		ratio = currentThroughput / state.LastThroughput
 
 		// Get current desired to check boundaries
 		currentDesired := int(atomic.LoadInt32(&e.desiredWorkers))
 
-		if throughputRatio > improvementThreshold {
+		if ratio > improvementThreshold {
 			// Throughput improved >5% - continue in same direction
 			// Special case: if last adjustment was 0 (stayed at boundary), use random perturbation
 			if state.LastAdjustment == 0 {
 				adjustment = rand.Intn(2)*2 - 1 //nolint:gosec,mnd // Random perturbation for hill climbing (non-crypto use)
-				e.logToFile(fmt.Sprintf("HillClimbing: Throughput improved (+%.1f%%), last was boundary hold, random %+d",
-					(throughputRatio-1)*PercentageScale, adjustment))
+				reason = "throughput improved, last was boundary hold, random perturbation"
 			} else {
 				adjustment = state.LastAdjustment
-				e.logToFile(fmt.Sprintf("HillClimbing: Throughput improved (+%.1f%%), continuing direction %+d",
-					(throughputRatio-1)*PercentageScale, adjustment))
+				reason = "throughput improved, continuing direction"
 			}
-		} else if throughputRatio < degradationThreshold {
+		} else if ratio < degradationThreshold {
 			// Throughput degraded >5% - normally reverse direction
 			// Special case: if we're at min/max boundary and were heading towards it,
 			// don't reverse (to avoid immediate oscillation at boundaries)
-			if (currentDesired == 1 && state.LastAdjustment == -1) ||
+			if (currentDesired == e.minAdaptiveWorkers(maxWorkers) && state.LastAdjustment == -1) ||
 				(currentDesired == maxWorkers && state.LastAdjustment == 1) {
 				// At boundary, don't reverse - stay put
 				adjustment = 0
-				e.logToFile(fmt.Sprintf("HillClimbing: Throughput degraded (%.1f%%) at boundary (%d workers), staying put",
-					(throughputRatio-1)*PercentageScale, currentDesired))
+				reason = "throughput degraded at boundary, staying put"
 			} else {
 				// Normal case: reverse direction
 				// Special case: if last adjustment was 0 (stayed at boundary), use random perturbation
 				if state.LastAdjustment == 0 {
 					adjustment = rand.Intn(2)*2 - 1 //nolint:gosec,mnd // Random perturbation for hill climbing (non-crypto use)
-					e.logToFile(fmt.Sprintf("HillClimbing: Throughput degraded (%.1f%%), last was boundary hold, random %+d",
-						(throughputRatio-1)*PercentageScale, adjustment))
+					reason = "throughput degraded, last was boundary hold, random perturbation"
 				} else {
 					adjustment = -state.LastAdjustment
-					e.logToFile(fmt.Sprintf("HillClimbing: Throughput degraded (%.1f%%), reversing direction to %+d",
-						(throughputRatio-1)*PercentageScale, adjustment))
+					reason = "throughput degraded, reversing direction"
 				}
 			}
 		} else {
 			// Throughput flat (±5%) - random perturbation
 			// Use simple random: rand.Intn(2) gives 0 or 1, multiply by 2 gives 0 or 2, subtract 1 gives -1 or 1
 			adjustment = rand.Intn(2)*2 - 1 //nolint:gosec,mnd // Non-crypto random perturbation for hill climbing
-			e.logToFile(fmt.Sprintf("HillClimbing: Throughput flat (%.1f%%), random perturbation %+d",
-				(throughputRatio-1)*PercentageScale, adjustment))
+			reason = "throughput flat, random perturbation"
 		}
 	}
 
+	// A busy system comes first: shed a worker whatever the throughput says
+	if atomic.LoadInt32(&e.overloaded) == 1 {
+		adjustment = -1
+		reason = fmt.Sprintf("load average above %.2f, removing worker", e.MaxLoadAverage)
+	}
+
+	before := int(atomic.LoadInt32(&e.desiredWorkers))
+	after := before
+
 	// Execute adjustment with bounds checking
 	if adjustment != 0 {
-		// Get current desired workers
-		currentDesired := atomic.LoadInt32(&e.desiredWorkers)
-
 		// Calculate new desired with bounds
-		newDesired := int(currentDesired) + adjustment
-		if newDesired < 1 {
-			newDesired = 1
-		} else if newDesired > maxWorkers {
-			newDesired = maxWorkers
-		}
+		minWorkers := e.minAdaptiveWorkers(maxWorkers)
+		after = min(max(before+adjustment, minWorkers), maxWorkers)
 
 		// Only apply if within bounds
-		if newDesired == int(currentDesired) {
+		if after == before {
 			// Hit a bound, no change
-			e.logToFile(fmt.Sprintf("HillClimbing: Bounded at %d workers (min: 1, max: %d)", newDesired, maxWorkers))
+			reason += fmt.Sprintf(", bounded (min: %d, max: %d)", minWorkers, maxWorkers)
 			adjustment = 0 // No actual adjustment made
 		} else {
 			// Apply the adjustment
-			atomic.StoreInt32(&e.desiredWorkers, int32(newDesired)) //nolint:gosec // Small value, no overflow risk
-			e.resizePools(newDesired)
-
-			if adjustment > 0 {
-				// Add worker - but only if actual count is below target
-				// (Workers may not have exited yet from previous removal)
-				if currentWorkers < newDesired {
-					workerControl <- true
-					e.logToFile(fmt.Sprintf("HillClimbing: Adding worker (desired: %d -> %d, active: %d)",
-						currentDesired, newDesired, currentWorkers))
-				} else {
-					e.logToFile(fmt.Sprintf("HillClimbing: Target %d -> %d, %d workers active (waiting)",
-						currentDesired, newDesired, currentWorkers))
-				}
-			} else {
-				// Remove worker (workers will self-exit when they notice)
-				e.logToFile(fmt.Sprintf("HillClimbing: Removing worker (desired: %d -> %d, active: %d)",
-					currentDesired, newDesired, currentWorkers))
+			atomic.StoreInt32(&e.desiredWorkers, int32(after)) //nolint:gosec // Small value, no overflow risk
+			e.resizePools(after)
+
+			// Add worker - but only if actual count is below target (workers may not have exited
+			// yet from a previous removal); removed workers self-exit when they notice
+			if adjustment > 0 && currentWorkers < after {
+				workerControl <- true
 			}
 		}
 	}
 
+	e.logScaling("HillClimbing: "+reason, ratio, before, after, "adjustment", adjustment,
+		"active", currentWorkers, "throughput", currentThroughput)
+
 	// Return updated state
 	return &AdaptiveScalingState{
 		LastThroughput: currentThroughput,
@@ -607,28 +1327,36 @@ func (e *Engine) HillClimbingScalingDecision(
 	}
 }
 
-// LogVerbose logs verbose progress information (only when Verbose is enabled)
-func (e *Engine) LogVerbose(message string) {
+// LogVerbose logs verbose progress information at debug level (only when Verbose is enabled).
+// args are slog key/value pairs.
+func (e *Engine) LogVerbose(message string, args ...any) {
 	if !e.Verbose {
 		return
 	}
 
-	e.logToFile(message)
+	e.logDebug(message, args...)
 }
 
-// MakeScalingDecision decides whether to add workers based on per-worker speed comparison
+// MakeScalingDecision decides whether to add workers based on per-worker speed comparison.
+// A drop below AdaptiveScalingLowThreshold removes a worker; anything else adds one, with
+// ratios under AdaptiveScalingHighThreshold counted as stable, so smoothed rates that drift a
+// few percent don't flip the decision between evaluations. The speeds are meant to be
+// WorkerMetrics.PerWorkerRate values from successive Status.CalculateWorkerMetrics calls;
+// EvaluateAndScale itself hill-climbs on the smoothed WorkerMetrics.TotalRate instead.
 //
 //nolint:lll // Long function signature with many parameters
 func (e *Engine) MakeScalingDecision(lastPerWorkerSpeed, currentPerWorkerSpeed float64, currentWorkers, maxWorkers int, workerControl chan bool) {
+	overloaded := atomic.LoadInt32(&e.overloaded) == 1
+
 	// First measurement - add a worker to test
-	if lastPerWorkerSpeed == 0 {
+	if lastPerWorkerSpeed == 0 && !overloaded {
 		if currentWorkers < maxWorkers {
 			newDesired := atomic.AddInt32(&e.desiredWorkers, 1)
 			e.resizePools(int(newDesired))
 			workerControl <- true
 
-			e.logToFile(fmt.Sprintf("Adaptive: First measurement complete, adding worker (%d -> %d)",
-				currentWorkers, currentWorkers+1))
+			e.logScaling("Adaptive: first measurement complete, adding worker", 0, currentWorkers, currentWorkers+1,
+				"per_worker_rate", currentPerWorkerSpeed)
 		}
 
 		return
@@ -636,19 +1364,24 @@ func (e *Engine) MakeScalingDecision(lastPerWorkerSpeed, currentPerWorkerSpeed f
 
 	speedRatio := currentPerWorkerSpeed / lastPerWorkerSpeed
 
-	// Per-worker speed decreased - remove a worker
-	if speedRatio < AdaptiveScalingLowThreshold {
+	// Per-worker speed decreased, or the system is busy - remove a worker
+	if overloaded || speedRatio < AdaptiveScalingLowThreshold {
 		// Decrement desired worker count (workers will self-terminate)
 		newDesired := atomic.AddInt32(&e.desiredWorkers, -1)
-		if newDesired < 1 {
-			// Don't go below 1 worker
-			atomic.StoreInt32(&e.desiredWorkers, 1)
-			newDesired = 1
+		if minWorkers := int32(e.minAdaptiveWorkers(maxWorkers)); newDesired < minWorkers { //nolint:gosec // Small value, no overflow risk
+			// Don't go below the floor
+			atomic.StoreInt32(&e.desiredWorkers, minWorkers)
+			newDesired = minWorkers
 		}
 		e.resizePools(int(newDesired))
 
-		e.logToFile(fmt.Sprintf("Adaptive: ↓ Per-worker speed decreased (-%.1f%%), removing worker (%d -> %d)",
-			(1-speedRatio)*PercentageScale, currentWorkers, newDesired))
+		reason := fmt.Sprintf("Adaptive: ↓ per-worker speed below -%.0f%%, removing worker",
+			(1-AdaptiveScalingLowThreshold)*PercentageScale)
+		if overloaded {
+			reason = fmt.Sprintf("Adaptive: ↓ load average above %.2f, removing worker", e.MaxLoadAverage)
+		}
+
+		e.logScaling(reason, speedRatio, currentWorkers, int(newDesired), "per_worker_rate", currentPerWorkerSpeed)
 
 		return
 	}
@@ -662,13 +1395,36 @@ func (e *Engine) MakeScalingDecision(lastPerWorkerSpeed, currentPerWorkerSpeed f
 	e.resizePools(int(newDesired))
 	workerControl <- true
 
-	if speedRatio >= AdaptiveScalingHighThreshold {
-		e.logToFile(fmt.Sprintf("Adaptive: ↑ Per-worker speed improved (+%.1f%%), adding worker (%d -> %d)",
-			(speedRatio-1)*PercentageScale, currentWorkers, currentWorkers+1))
-	} else {
-		e.logToFile(fmt.Sprintf("Adaptive: → Per-worker speed stable, adding worker to test (%d -> %d)",
-			currentWorkers, currentWorkers+1))
+	reason := "Adaptive: ↑ per-worker speed improved, adding worker"
+	if speedRatio < AdaptiveScalingHighThreshold {
+		reason = fmt.Sprintf("Adaptive: → per-worker speed stable (within -%.0f%%/+%.0f%%), adding worker to test",
+			(1-AdaptiveScalingLowThreshold)*PercentageScale, (AdaptiveScalingHighThreshold-1)*PercentageScale)
+	}
+
+	e.logScaling(reason, speedRatio, currentWorkers, currentWorkers+1, "per_worker_rate", currentPerWorkerSpeed)
+}
+
+// PlanEntries returns every file in the sync plan: files to create or overwrite,
+// then metadata-only updates, then files to delete.
+// Unlike GetStatus, the full plan is returned so it can be browsed before syncing.
+func (e *Engine) PlanEntries() []PlanEntry {
+	e.Status.mu.RLock()
+	entries := make([]PlanEntry, 0, len(e.Status.FilesToSync))
+
+	for _, file := range e.Status.FilesToSync {
+		entries = append(entries, PlanEntry{RelativePath: file.RelativePath, Size: file.Size, Action: file.Action})
 	}
+	e.Status.mu.RUnlock()
+
+	for _, update := range e.permissionUpdates {
+		entries = append(entries, PlanEntry{RelativePath: update.relPath, Action: ActionMetadata})
+	}
+
+	e.planMu.Lock()
+	entries = append(entries, e.plannedDeletions...)
+	e.planMu.Unlock()
+
+	return entries
 }
 
 // RegisterStatusCallback registers a callback for status updates
@@ -681,61 +1437,863 @@ func (e *Engine) RegisterStatusCallback(callback func(*Status)) {
 
 // Sync performs the actual synchronization using parallel workers
 func (e *Engine) Sync() error {
-	if e.AdaptiveMode {
-		return e.syncAdaptive()
+	defer e.releaseSnapshot()
+
+	e.FileOps.Preallocate = e.Preallocate
+	e.FileOps.Reflink = fileops.ReflinkMode(e.Reflink)
+	e.FileOps.PreservePermissions = e.PreservePermissions
+	e.FileOps.CopyXattr = e.XattrCompare
+	// The store names content by its hash, and the hash filter records it
+	e.FileOps.HashOnCopy = e.VerifyAfterCopy || e.CAStore || e.WriteDestHashBloom != ""
+	e.FileOps.InPlace = e.InPlace
+	e.FileOps.Sparse = e.Sparse
+	e.FileOps.CopyBufferSize = e.CopyBufferSize
+	e.FileOps.KeepPartial = e.ResumeFromManifest
+	e.FileOps.ByteLimiter = fileops.NewByteLimiter(e.MaxBytesPerSecond)
+	e.dirWrites = newDirWriteLimiter(e.MaxWritesPerDir)
+
+	if e.MaxBytesPerSecond > 0 {
+		e.logToFile("Limiting transfers", "bytes_per_second", e.MaxBytesPerSecond)
 	}
 
-	return e.syncFixed()
-}
+	if e.MaxWritesPerDir > 0 {
+		e.logToFile("Limiting writes per destination directory", "max_writes", e.MaxWritesPerDir)
+	}
 
-// applyFileFilter applies the file pattern filter to the given files
-func (e *Engine) applyFileFilter(files map[string]*fileops.FileInfo) map[string]*fileops.FileInfo {
-	filter := NewGlobFilter(e.FilePattern)
-	filtered := make(map[string]*fileops.FileInfo)
+	e.Status.mu.Lock()
+	e.Status.Deadline = e.Deadline
+	e.Status.mu.Unlock()
 
-	for relativePath, info := range files {
-		if filter.ShouldInclude(relativePath) {
-			filtered[relativePath] = info
+	err := e.syncPass()
+	if err == nil {
+		err = e.converge()
+	}
+
+	if e.VerifyTotals {
+		e.verifyTransferTotals()
+	}
+
+	// Checking an interrupted sync would only list what it didn't get to
+	if err == nil && e.ReconcileAfterSync && e.checkCancellation() == nil {
+		_, reconcileErr := e.Reconcile()
+		if reconcileErr != nil {
+			e.logWarn("Reconcile failed", "error", reconcileErr)
 		}
 	}
 
-	return filtered
-}
+	e.recordFailureHistory()
+	e.saveHashCache()
 
-func (e *Engine) checkCancellation() error {
-	select {
-	case <-e.cancelChan:
-		return ErrAnalysisCancelled
-	default:
-		return nil
+	// Save whatever was stored, even after a failure, so the next run doesn't redo it
+	indexErr := e.saveCAStoreIndex()
+	if err == nil {
+		err = indexErr
 	}
-}
 
-func (e *Engine) collectDirectoriesToDelete(sourceFiles, destFiles map[string]*fileops.FileInfo) []dirToDelete {
-	var dirsToRemove []dirToDelete
+	// Only a complete sync leaves a destination the filter can vouch for
+	if err == nil && e.WriteDestHashBloom != "" && e.checkCancellation() == nil {
+		err = e.writeDestHashBloom()
+	}
 
-	for relPath, dstFile := range destFiles {
-		if dstFile.IsDir {
-			if _, exists := sourceFiles[relPath]; !exists {
-				// Count depth by number of path separators
-				depth := strings.Count(relPath, string(filepath.Separator))
-				dirsToRemove = append(dirsToRemove, dirToDelete{relPath: relPath, depth: depth})
-			}
-		}
+	// Interrupted runs would make a misleading baseline for the next comparison
+	if err == nil && e.CompareRuns && e.checkCancellation() == nil {
+		e.saveRunHistory()
 	}
 
-	// Sort by depth descending (deepest first)
-	sort.Slice(dirsToRemove, func(i, j int) bool {
-		return dirsToRemove[i].depth > dirsToRemove[j].depth
-	})
+	return err
+}
 
-	return dirsToRemove
+// abortErrorCount returns how many errors count toward MaxErrorsBeforeAbort.
+// Callers must hold Status.mu.
+func (e *Engine) abortErrorCount() int {
+	return len(e.Status.Errors) - e.Status.SkippedErrors
 }
 
-func (e *Engine) collectErrors(errors chan error) (*[]error, *sync.Mutex, *sync.WaitGroup) {
-	allErrors := make([]error, 0)
+// analyzeCAStore plans a content-addressed sync: source files whose size or modtime differ
+// from the destination index are hashed and stored during sync; the rest are already synced.
+func (e *Engine) analyzeCAStore() error {
+	e.logAnalysis("Content-addressed store: comparing source against " + CAStoreIndexFile)
 
-	var (
+	index, err := loadCAStoreIndex(e.FileOps, filepath.Join(e.DestPath, CAStoreIndexFile))
+	if err != nil {
+		return err
+	}
+
+	e.emit(ScanStarted{Target: "source"})
+
+	sourceFiles, err := e.scanSourceDirectory()
+	if errors.Is(err, ErrCancelled) {
+		return ErrAnalysisCancelled
+	}
+
+	if err != nil {
+		return err
+	}
+
+	e.emit(ScanComplete{Target: "source", Count: len(sourceFiles)})
+
+	paths := make([]string, 0, len(sourceFiles))
+
+	for relPath, srcFile := range sourceFiles {
+		if !srcFile.IsDir {
+			paths = append(paths, relPath)
+		}
+	}
+
+	sort.Strings(paths)
+
+	filesToSync := make([]*FileToSync, 0)
+	alreadySynced := 0
+
+	var totalBytes, bytesToCopy, syncedBytes int64
+
+	for _, relPath := range paths {
+		srcFile := sourceFiles[relPath]
+		totalBytes += srcFile.Size
+
+		entry, indexed := index.files[relPath]
+		if indexed && entry.Size == srcFile.Size && entry.ModTime.Equal(srcFile.ModTime) {
+			alreadySynced++
+			syncedBytes += srcFile.Size
+
+			continue
+		}
+
+		action := ActionCreate
+		if indexed {
+			action = ActionOverwrite
+		}
+
+		index.pending[relPath] = caStoreEntry{Size: srcFile.Size, ModTime: srcFile.ModTime}
+		filesToSync = append(filesToSync, &FileToSync{
+			RelativePath: relPath,
+			Action:       action,
+			Size:         srcFile.Size,
+			ModTime:      srcFile.ModTime,
+			Status:       "pending",
+		})
+		bytesToCopy += srcFile.Size
+	}
+
+	// Forget paths removed from the source; their objects stay in case other paths share them
+	for relPath := range index.files {
+		if srcFile, exists := sourceFiles[relPath]; !exists || srcFile.IsDir {
+			delete(index.files, relPath)
+			index.dirty = true
+		}
+	}
+
+	e.caIndex = index
+
+	e.Status.mu.Lock()
+	e.Status.FilesToSync = filesToSync
+	e.Status.TotalBytes = bytesToCopy
+	e.Status.TotalFilesInSource = len(paths)
+	e.Status.TotalBytesInSource = totalBytes
+	e.Status.AlreadySyncedFiles = alreadySynced
+	e.Status.AlreadySyncedBytes = syncedBytes
+	e.Status.mu.Unlock()
+
+	e.logAnalysis(fmt.Sprintf("%d files unchanged since last stored, %d to hash and store", alreadySynced, len(filesToSync)))
+
+	e.finalizeAnalysis()
+	e.emitSyncPlan()
+
+	return nil
+}
+
+// analyzeWithDestBloom plans a sync against a Bloom filter of the destination's path and content
+// hashes instead of a destination scan. Every source file is hashed; files the filter probably
+// holds are skipped (a false positive wrongly skips a changed file) and the rest are copied.
+// Without a destination listing, nothing is deleted.
+func (e *Engine) analyzeWithDestBloom() error {
+	filter, err := loadDestBloom(e.DestHashBloom)
+	if err != nil {
+		return err
+	}
+
+	e.logAnalysis(fmt.Sprintf("Comparing against destination hash filter %s (%d files) instead of scanning destination",
+		e.DestHashBloom, filter.Len()))
+
+	e.emit(ScanStarted{Target: "source"})
+
+	sourceFiles, err := e.scanSourceDirectory()
+	if errors.Is(err, ErrCancelled) {
+		return ErrAnalysisCancelled
+	}
+
+	if err != nil {
+		return err
+	}
+
+	e.emit(ScanComplete{Target: "source", Count: len(sourceFiles)})
+	e.emit(CompareStarted{})
+
+	defer e.timePhase(PhaseCompare)()
+
+	paths := make([]string, 0, len(sourceFiles))
+
+	for relPath, srcFile := range sourceFiles {
+		if !srcFile.IsDir {
+			paths = append(paths, relPath)
+		}
+	}
+
+	sort.Strings(paths)
+
+	e.initializeComparisonStatus()
+	e.Status.mu.Lock()
+	e.Status.AnalysisPhase = "comparing"
+	e.Status.TotalFilesToScan = len(paths)
+	e.Status.mu.Unlock()
+
+	for i, relPath := range paths {
+		hash, hashErr := e.FileOps.ComputeSourceHash(filepath.Join(e.SourcePath, relPath), e.cancelChan)
+		if errors.Is(hashErr, ErrCancelled) {
+			return ErrAnalysisCancelled
+		}
+
+		// A file that can't be hashed is copied, which surfaces the read error during sync
+		needsSync := hashErr != nil || !filter.Test(destBloomKey(relPath, hash))
+		if hashErr != nil {
+			e.logAnalysis(fmt.Sprintf("  ⚠ Failed to hash %s: %v", relPath, hashErr))
+		}
+
+		if !needsSync {
+			e.recordDestHash(relPath, sourceFiles[relPath].Size, sourceFiles[relPath].ModTime, hash)
+		}
+
+		e.updateStatusForFile(relPath, sourceFiles[relPath], ActionCreate, "", needsSync, i+1)
+		e.notifyAnalysisProgress()
+	}
+
+	e.Status.mu.RLock()
+	skipped, toCopy := e.Status.AlreadySyncedFiles, len(e.Status.FilesToSync)
+	e.Status.mu.RUnlock()
+
+	e.logAnalysis(fmt.Sprintf("%d files probably present in destination, %d to copy", skipped, toCopy))
+
+	// Every source file was hashed above, so the ones not copied are known to be present
+	e.destListed = true
+
+	e.finalizeAnalysis()
+	e.emitSyncPlan()
+
+	return nil
+}
+
+// applyBaseline leaves out source files whose content is identical to the same path under
+// BaselineDir, for overlays synced on top of a base image that provides those files. Only files
+// whose baseline copy has the same size are hashed. Destination copies of excluded files are
+// kept, since the baseline is what provides them.
+func (e *Engine) applyBaseline(sourceFiles, destFiles map[string]*fileops.FileInfo) error {
+	if e.BaselineDir == "" {
+		return nil
+	}
+
+	e.logAnalysis("Comparing source against baseline " + e.BaselineDir)
+
+	var excluded []string
+
+	for relPath, srcFile := range sourceFiles {
+		if srcFile.IsDir {
+			continue
+		}
+
+		err := e.checkCancellation()
+		if err != nil {
+			return err
+		}
+
+		identical, err := e.matchesBaseline(relPath, srcFile)
+		if err != nil {
+			e.logAnalysis(fmt.Sprintf("  ! Baseline check failed for %s, syncing it: %v", relPath, err))
+			continue
+		}
+
+		if identical {
+			excluded = append(excluded, relPath)
+		}
+	}
+
+	for _, relPath := range excluded {
+		delete(sourceFiles, relPath)
+		delete(destFiles, relPath)
+	}
+
+	e.Status.mu.Lock()
+	e.Status.BaselineExcludedFiles = len(excluded)
+	e.Status.mu.Unlock()
+
+	e.logAnalysis(fmt.Sprintf("Baseline: excluded %d files identical to %s", len(excluded), e.BaselineDir))
+
+	return nil
+}
+
+// applyFailureHistory loads which files failed in recent runs, so their errors can be told apart
+// from new ones, and leaves known persistent failures out of the plan if SkipKnownFailures is set.
+func (e *Engine) applyFailureHistory() {
+	runs := e.knownFailureRuns()
+	if runs <= 0 {
+		return
+	}
+
+	history, err := loadFailureHistory(e.failureHistoryPath())
+	if err != nil {
+		e.logAnalysis(fmt.Sprintf("Not tracking persistent failures: %v", err))
+		return
+	}
+
+	e.failures = history
+
+	if !e.SkipKnownFailures {
+		return
+	}
+
+	var skipped []string
+
+	e.Status.mu.Lock()
+	kept := e.Status.FilesToSync[:0]
+
+	for _, file := range e.Status.FilesToSync {
+		if history.known(file.RelativePath, runs) {
+			skipped = append(skipped, file.RelativePath)
+			e.Status.TotalBytes -= file.Size
+
+			continue
+		}
+
+		kept = append(kept, file)
+	}
+
+	e.Status.FilesToSync = kept
+	e.Status.KnownFailuresSkipped = skipped
+	e.Status.mu.Unlock()
+
+	for _, relPath := range skipped {
+		record := history.Files[relPath]
+		e.logAnalysis(fmt.Sprintf("  Skipping known persistent failure: %s (failed %d runs in a row: %s)",
+			relPath, record.Runs, record.LastError))
+	}
+}
+
+// applyFileFilter applies the file pattern filter to the given files
+func (e *Engine) applyFileFilter(files map[string]*fileops.FileInfo) map[string]*fileops.FileInfo {
+	filter := NewGlobFilter(e.FilePattern)
+	filtered := make(map[string]*fileops.FileInfo)
+
+	for relativePath, info := range files {
+		if filter.ShouldInclude(relativePath) {
+			filtered[relativePath] = info
+		}
+	}
+
+	return filtered
+}
+
+// applyExcludeFilter drops the files matching any exclude pattern, after the include pattern
+// has been applied. Matching is the same as for FilePattern.
+func (e *Engine) applyExcludeFilter(files map[string]*fileops.FileInfo) map[string]*fileops.FileInfo {
+	filtered := make(map[string]*fileops.FileInfo)
+
+	for relativePath, info := range files {
+		if !e.excluded(relativePath) {
+			filtered[relativePath] = info
+		}
+	}
+
+	return filtered
+}
+
+// excluded reports whether relPath matches any exclude pattern.
+func (e *Engine) excluded(relPath string) bool {
+	for _, pattern := range e.ExcludePatterns {
+		if NewGlobFilter(pattern).ShouldInclude(relPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sizeFiltered reports whether MinFileSize or MaxFileSize limits the files synced.
+func (e *Engine) sizeFiltered() bool {
+	return e.MinFileSize > 0 || e.MaxFileSize > 0
+}
+
+// sizeInRange reports whether a file of size bytes is within MinFileSize and MaxFileSize.
+func (e *Engine) sizeInRange(size int64) bool {
+	return size >= e.MinFileSize && (e.MaxFileSize == 0 || size <= e.MaxFileSize)
+}
+
+// sizeRangeDescription describes the files the size range leaves out, e.g. "smaller than 100 MB".
+func (e *Engine) sizeRangeDescription() string {
+	switch {
+	case e.MinFileSize > 0 && e.MaxFileSize > 0:
+		return fmt.Sprintf("outside %s to %s",
+			formatters.FormatBytes(e.MinFileSize), formatters.FormatBytes(e.MaxFileSize))
+	case e.MinFileSize > 0:
+		return "smaller than " + formatters.FormatBytes(e.MinFileSize)
+	default:
+		return "larger than " + formatters.FormatBytes(e.MaxFileSize)
+	}
+}
+
+// modTimeFiltered reports whether ModifiedAfter or ModifiedBefore limits the files synced.
+func (e *Engine) modTimeFiltered() bool {
+	return !e.ModifiedAfter.IsZero() || !e.ModifiedBefore.IsZero()
+}
+
+// modTimeInWindow reports whether a file modified at modTime is within ModifiedAfter and
+// ModifiedBefore.
+func (e *Engine) modTimeInWindow(modTime time.Time) bool {
+	return (e.ModifiedAfter.IsZero() || !modTime.Before(e.ModifiedAfter)) &&
+		(e.ModifiedBefore.IsZero() || modTime.Before(e.ModifiedBefore))
+}
+
+// modTimeWindowDescription describes the files the modification time window leaves out, e.g.
+// "modified before 2024-01-31 00:00".
+func (e *Engine) modTimeWindowDescription() string {
+	const layout = "2006-01-02 15:04"
+
+	switch {
+	case !e.ModifiedAfter.IsZero() && !e.ModifiedBefore.IsZero():
+		return fmt.Sprintf("modified outside %s to %s",
+			e.ModifiedAfter.Format(layout), e.ModifiedBefore.Format(layout))
+	case !e.ModifiedAfter.IsZero():
+		return "modified before " + e.ModifiedAfter.Format(layout)
+	default:
+		return "modified at or after " + e.ModifiedBefore.Format(layout)
+	}
+}
+
+// applyCaseConflictPolicy resolves source files whose paths differ only in case, which a
+// case-insensitive destination would store as one file, so the outcome doesn't depend on which
+// copy lands last. Files left out keep their destination copy rather than becoming orphans.
+func (e *Engine) applyCaseConflictPolicy(sourceFiles, destFiles map[string]*fileops.FileInfo) error {
+	e.Status.mu.Lock()
+	e.Status.CaseConflicts = nil
+	e.Status.mu.Unlock()
+
+	if e.CaseConflictPolicy == config.CaseConflictOff {
+		return nil
+	}
+
+	groups := caseConflictGroups(sourceFiles)
+	if len(groups) == 0 {
+		return nil
+	}
+
+	if e.CaseConflictPolicy == config.CaseConflictError {
+		listed := make([]string, 0, len(groups))
+		for _, paths := range groups {
+			listed = append(listed, strings.Join(paths, ", "))
+		}
+
+		return fmt.Errorf("%w: %s", ErrCaseConflict, strings.Join(listed, "; "))
+	}
+
+	// The destination may hold a file under any of its spellings
+	destSpellings := make(map[string][]string, len(destFiles))
+	for relPath := range destFiles {
+		folded := strings.ToLower(relPath)
+		destSpellings[folded] = append(destSpellings[folded], relPath)
+	}
+
+	conflicts := make([]CaseConflict, 0, len(groups))
+
+	for _, paths := range groups {
+		conflict := CaseConflict{Paths: paths}
+		if e.CaseConflictPolicy == config.CaseConflictFirstBySort {
+			conflict.Kept = paths[0]
+		}
+
+		for _, relPath := range paths {
+			if relPath != conflict.Kept {
+				delete(sourceFiles, relPath)
+			}
+		}
+
+		for _, relPath := range destSpellings[strings.ToLower(paths[0])] {
+			if relPath != conflict.Kept {
+				delete(destFiles, relPath)
+			}
+		}
+
+		conflicts = append(conflicts, conflict)
+		e.logAnalysis("  ! Case conflict: " + conflict.String())
+	}
+
+	e.Status.mu.Lock()
+	e.Status.CaseConflicts = conflicts
+	e.Status.mu.Unlock()
+
+	e.logAnalysis(fmt.Sprintf("⚠ %d sets of source files differ only in case (--case-conflicts %s)",
+		len(conflicts), e.CaseConflictPolicy))
+
+	return nil
+}
+
+// applyNameRules checks source paths against the destination filesystem's filename rules.
+// Illegal paths are mapped to sanitized names if SanitizeNames is set, otherwise they are
+// reported and left out of the sync. Dest entries stored under a sanitized name are re-keyed
+// by their source path so they compare against their source and aren't treated as orphans.
+//
+//nolint:cyclop // Each illegal path is either sanitized, rejected with its parent, or reported
+func (e *Engine) applyNameRules(sourceFiles, destFiles map[string]*fileops.FileInfo) {
+	e.destNames = nil
+
+	fsType := e.resolveDestFSType()
+	if fsType == config.DestFSAuto {
+		return
+	}
+
+	e.logAnalysis(fmt.Sprintf("Checking names against %s filename rules", fsType))
+
+	// Sorted so parents are handled before children and collisions resolve consistently
+	relPaths := make([]string, 0, len(sourceFiles))
+	for relPath := range sourceFiles {
+		relPaths = append(relPaths, relPath)
+	}
+
+	sort.Strings(relPaths)
+
+	illegalNames := make([]string, 0)
+	sanitizedNames := make([]SanitizedName, 0)
+	destNames := make(map[string]string)
+	claimed := make(map[string]bool)
+	rejectedDirs := make([]string, 0)
+
+	for _, relPath := range relPaths {
+		reason := illegalNameReason(relPath, fsType)
+		if reason == "" {
+			continue
+		}
+
+		srcFile := sourceFiles[relPath]
+
+		if e.SanitizeNames && !hasPathPrefix(relPath, rejectedDirs) {
+			destPath := sanitizeName(relPath, fsType)
+			_, taken := sourceFiles[destPath]
+
+			if illegalNameReason(destPath, fsType) == "" && !taken && !claimed[destPath] {
+				claimed[destPath] = true
+				destNames[relPath] = destPath
+
+				if !srcFile.IsDir {
+					sanitizedNames = append(sanitizedNames, SanitizedName{SourcePath: relPath, DestPath: destPath})
+				}
+
+				continue
+			}
+		}
+
+		delete(sourceFiles, relPath)
+
+		if srcFile.IsDir {
+			rejectedDirs = append(rejectedDirs, relPath)
+			continue
+		}
+
+		illegalNames = append(illegalNames, relPath)
+		e.logAnalysis(fmt.Sprintf("  ✗ Skipping %s: %s on %s", relPath, reason, fsType))
+	}
+
+	for srcPath, destPath := range destNames {
+		if dstFile, ok := destFiles[destPath]; ok {
+			delete(destFiles, destPath)
+			destFiles[srcPath] = dstFile
+		}
+	}
+
+	e.destNames = destNames
+
+	e.Status.mu.Lock()
+	e.Status.IllegalNames = illegalNames
+	e.Status.SanitizedNames = sanitizedNames
+	e.Status.mu.Unlock()
+
+	if len(sanitizedNames) > 0 {
+		e.logAnalysis(fmt.Sprintf("Renamed %d files to names %s can store", len(sanitizedNames), fsType))
+	}
+
+	if len(illegalNames) > 0 {
+		e.logAnalysis(fmt.Sprintf("⚠ %d files have names %s can't store and will be skipped (use --sanitize-names to rename them)",
+			len(illegalNames), fsType))
+	}
+}
+
+// applyOwnerFilter keeps the source files OwnerFilter selects, plus every directory so selected
+// files deeper down still have their parents. Excluded paths are remembered so their destination
+// copies aren't deleted as orphans.
+func (e *Engine) applyOwnerFilter(files map[string]*fileops.FileInfo) map[string]*fileops.FileInfo {
+	filtered := make(map[string]*fileops.FileInfo, len(files))
+	e.ownerExcluded = make(map[string]bool)
+
+	for relPath, info := range files {
+		if info.IsDir || e.OwnerFilter.Matches(info.UID, info.GID, info.HasOwner) {
+			filtered[relPath] = info
+		} else {
+			e.ownerExcluded[relPath] = true
+		}
+	}
+
+	e.Status.mu.Lock()
+	e.Status.OwnerExcludedFiles = len(e.ownerExcluded)
+	e.Status.mu.Unlock()
+
+	e.logAnalysis(fmt.Sprintf("Owner filter: excluded %d files owned by other users", len(e.ownerExcluded)))
+
+	return filtered
+}
+
+// applyPermissionUpdates sets the source permissions on destination files whose content is
+// already current. Failures are recorded as file errors rather than stopping the sync.
+func (e *Engine) applyPermissionUpdates() error {
+	if len(e.permissionUpdates) == 0 {
+		return nil
+	}
+
+	e.logToFile("Updating permissions", "files", len(e.permissionUpdates))
+
+	for _, update := range e.permissionUpdates {
+		err := e.checkCancellation()
+		if err != nil {
+			return err
+		}
+
+		dstPath := filepath.Join(e.DestPath, update.destRelPath)
+
+		err = e.FileOps.ChmodDest(dstPath, update.mode)
+
+		e.Status.mu.Lock()
+		if err != nil {
+			e.recordError(update.relPath, fmt.Errorf("failed to update permissions: %w", err))
+		} else {
+			e.Status.MetadataUpdates++
+		}
+		e.Status.mu.Unlock()
+
+		if err == nil {
+			e.itemize(itemizePermissions, update.destRelPath)
+		}
+	}
+
+	e.notifyStatusUpdate()
+
+	return nil
+}
+
+// applyRetentionPolicy leaves all but the newest KeepNewest files in each source directory
+// out of the sync. Their destination copies are intentional exclusions: they're kept unless
+// PruneOlder is set, in which case they're left in destFiles to be deleted as orphans.
+func (e *Engine) applyRetentionPolicy(sourceFiles, destFiles map[string]*fileops.FileInfo) {
+	if e.KeepNewest <= 0 {
+		return
+	}
+
+	excluded := olderThanNewestPerDir(sourceFiles, e.KeepNewest)
+	prunedAtDest := 0
+
+	for _, relPath := range excluded {
+		delete(sourceFiles, relPath)
+
+		if _, inDest := destFiles[relPath]; !inDest {
+			continue
+		}
+
+		if e.PruneOlder {
+			prunedAtDest++
+		} else {
+			delete(destFiles, relPath)
+		}
+	}
+
+	e.Status.mu.Lock()
+	e.Status.RetentionExcludedFiles = len(excluded)
+	e.Status.mu.Unlock()
+
+	e.logAnalysis(fmt.Sprintf("Retention: keeping newest %d files per directory, excluded %d older files",
+		e.KeepNewest, len(excluded)))
+
+	if prunedAtDest > 0 {
+		e.logAnalysis(fmt.Sprintf("Retention: %d excluded files will be deleted from destination", prunedAtDest))
+	}
+}
+
+func (e *Engine) checkCancellation() error {
+	select {
+	case <-e.cancelChan:
+		return ErrAnalysisCancelled
+	default:
+		return nil
+	}
+}
+
+// checkClockSkew measures how far the destination's clock is from the source's. Each remote end's
+// clock is read by writing a probe file there and comparing the modtime it stamps on it with the local
+// time of the write; a local end, or one that can't be probed, is taken to keep the local time.
+// Copies carry their source's modtime, so skew doesn't change which files compare equal, but it makes
+// destination-stamped times (new directories, files written by other tools) look older or newer than
+// the source's.
+func (e *Engine) checkClockSkew() {
+	var (
+		sourceOffset, destOffset time.Duration
+		measured                 bool
+	)
+
+	if _, ok := e.FileOps.SourceFS.(*filesystem.RealFileSystem); !ok {
+		offset, err := e.probeClock(filepath.Join(e.SourcePath, ClockSkewProbeFile),
+			e.FileOps.WriteSourceFile, e.FileOps.Stat, e.FileOps.Remove)
+		if err != nil {
+			e.logAnalysis(fmt.Sprintf("Skipping source clock check: %v", err))
+		} else {
+			sourceOffset, measured = offset, true
+		}
+	}
+
+	if _, ok := e.FileOps.DestFS.(*filesystem.RealFileSystem); !ok {
+		offset, err := e.probeClock(filepath.Join(e.DestPath, ClockSkewProbeFile),
+			e.FileOps.WriteDestFile, e.FileOps.StatDest, e.FileOps.RemoveFromDest)
+		if err != nil {
+			e.logAnalysis(fmt.Sprintf("Skipping destination clock check: %v", err))
+		} else {
+			destOffset, measured = offset, true
+		}
+	}
+
+	if !measured {
+		return // Both ends keep the local time, as far as can be told
+	}
+
+	// Positive skew means the destination's clock is ahead
+	skew := destOffset - sourceOffset
+	excessive := skew.Abs() > e.ClockSkewThreshold
+
+	e.Status.mu.Lock()
+	e.Status.ClockSkew = skew
+	e.Status.ClockSkewMeasured = true
+	e.Status.ExcessiveClockSkew = excessive
+	e.Status.mu.Unlock()
+
+	if excessive {
+		e.logAnalysis(fmt.Sprintf("Warning: destination clock is %s off the source clock (threshold %s)",
+			skew.Round(time.Millisecond), e.ClockSkewThreshold))
+	} else {
+		e.logAnalysis(fmt.Sprintf("Destination clock skew: %s", skew.Round(time.Millisecond)))
+	}
+}
+
+// checkDestMounted fails the analysis if RequireMount or MountMarker show the destination
+// isn't the filesystem it should be.
+func (e *Engine) checkDestMounted() error {
+	if e.RequireMount {
+		if _, ok := e.FileOps.DestFS.(*filesystem.RealFileSystem); !ok {
+			return fmt.Errorf("%w: mount points can only be checked on local destinations", ErrDestNotMounted)
+		}
+
+		mounted, err := filesystem.IsMountPoint(e.DestPath)
+		if err != nil {
+			return fmt.Errorf("failed to check destination mount: %w", err)
+		}
+
+		if !mounted {
+			return fmt.Errorf("%w: %s is not a mount point", ErrDestNotMounted, e.DestPath)
+		}
+
+		e.logAnalysis("Destination is a mount point")
+	}
+
+	if e.MountMarker != "" {
+		_, err := e.FileOps.StatDest(filepath.Join(e.DestPath, e.MountMarker))
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%w: marker file %s not found in %s", ErrDestNotMounted, e.MountMarker, e.DestPath)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to check mount marker: %w", err)
+		}
+
+		e.logAnalysis("Found mount marker " + e.MountMarker)
+	}
+
+	return nil
+}
+
+// checkFreeInodes warns when a local destination can't hold as many new files and directories
+// as the plan creates, which fails with "no space left" even when plenty of bytes are free.
+func (e *Engine) checkFreeInodes() {
+	if _, ok := e.FileOps.DestFS.(*filesystem.RealFileSystem); !ok {
+		return
+	}
+
+	free, err := fileops.FreeInodes(e.DestPath)
+	if err != nil {
+		e.logAnalysis(fmt.Sprintf("Skipping free inode check: %v", err))
+		return
+	}
+
+	// Overwrites reuse the existing file's inode; only new files and their new parents need one
+	needed := 0
+	newDirs := make(map[string]bool)
+
+	e.Status.mu.RLock()
+	for _, file := range e.Status.FilesToSync {
+		if file.Action == ActionOverwrite {
+			continue
+		}
+
+		needed++
+
+		for dir := filepath.Dir(file.destPath()); dir != "." && !newDirs[dir]; dir = filepath.Dir(dir) {
+			if dstDir, exists := e.analysisDestFiles[dir]; exists && dstDir.IsDir {
+				break
+			}
+
+			newDirs[dir] = true
+		}
+	}
+	e.Status.mu.RUnlock()
+
+	needed += len(newDirs)
+
+	e.Status.mu.Lock()
+	e.Status.FreeInodes = free
+	e.Status.InodesNeeded = needed
+	e.Status.InsufficientInodes = int64(needed) > free
+	e.Status.mu.Unlock()
+
+	if int64(needed) > free {
+		e.logAnalysis(fmt.Sprintf("Warning: sync creates %d files and directories but destination has only %d free inodes",
+			needed, free))
+	}
+}
+
+func (e *Engine) collectDirectoriesToDelete(sourceFiles, destFiles map[string]*fileops.FileInfo) []dirToDelete {
+	var dirsToRemove []dirToDelete
+
+	for relPath, dstFile := range destFiles {
+		if dstFile.IsDir {
+			if _, exists := sourceFiles[relPath]; !exists {
+				// Count depth by number of path separators
+				depth := strings.Count(relPath, string(filepath.Separator))
+				dirsToRemove = append(dirsToRemove, dirToDelete{relPath: relPath, depth: depth})
+			}
+		}
+	}
+
+	// Sort by depth descending (deepest first)
+	sort.Slice(dirsToRemove, func(i, j int) bool {
+		return dirsToRemove[i].depth > dirsToRemove[j].depth
+	})
+
+	return dirsToRemove
+}
+
+func (e *Engine) collectErrors(errors chan error) (*[]error, *sync.Mutex, *sync.WaitGroup) {
+	allErrors := make([]error, 0)
+
+	var (
 		errorsMu sync.Mutex
 		errorsWg sync.WaitGroup
 	)
@@ -754,13 +2312,23 @@ func (e *Engine) collectErrors(errors chan error) (*[]error, *sync.Mutex, *sync.
 }
 
 func (e *Engine) compareAndPlanSync(sourceFiles, destFiles map[string]*fileops.FileInfo) error {
+	defer e.timePhase(PhaseCompare)()
+
 	e.initializeComparisonStatus()
 
 	comparedCount := 0
 	needSyncCount := 0
 	alreadySyncedCount := 0
 	filesInBoth := 0
+
+	// Modes that read content find differences size and modtime can't show
+	contentCompared := e.RepairMode || e.ChangeType == config.DeviousContent || e.ChangeType == config.Paranoid ||
+		e.ChangeType == config.QuickContent
 	filesOnlyInSource := 0
+	sizeSkipped := 0
+	modTimeSkipped := 0
+	manifestSkipped := 0
+	manifestRecopied := 0
 	var bytesInBoth int64
 	var bytesOnlyInSource int64
 
@@ -774,11 +2342,35 @@ func (e *Engine) compareAndPlanSync(sourceFiles, destFiles map[string]*fileops.F
 			}
 		}
 
+		dstFile := destFiles[relPath]
+
+		// The destination entry has to go before the source's can take its place
+		if dstFile != nil && entryTypeChanged(srcFile, dstFile) {
+			e.recordTypeChange(relPath, srcFile, dstFile)
+		}
+
 		if srcFile.IsDir {
-			continue // Skip directories
+			e.planDirCreation(relPath, srcFile, dstFile)
+			continue // Directories have no content to compare
 		}
 
-		dstFile := destFiles[relPath]
+		// Files outside the size range stay in sourceFiles, so their destination copies aren't
+		// deleted as orphans either
+		if !e.sizeInRange(srcFile.Size) {
+			sizeSkipped++
+			continue
+		}
+
+		// As do files modified outside the window
+		if !e.modTimeInWindow(srcFile.ModTime) {
+			modTimeSkipped++
+			continue
+		}
+
+		// A repair pass only verifies files that already exist in the destination
+		if e.RepairMode && dstFile == nil {
+			continue
+		}
 
 		// Track comparison counts and bytes
 		if dstFile != nil {
@@ -789,8 +2381,34 @@ func (e *Engine) compareAndPlanSync(sourceFiles, destFiles map[string]*fileops.F
 			bytesOnlyInSource += srcFile.Size
 		}
 
-		// Determine if file needs sync based on ChangeType
-		needsSync := e.determineIfFileNeedsSync(relPath, srcFile, dstFile, comparedCount)
+		// Determine if file needs sync based on ChangeType (or by hash alone when repairing)
+		var needsSync bool
+		if e.preservesLink(srcFile) {
+			needsSync = dstFile == nil || !dstFile.IsSymlink || dstFile.LinkTarget != srcFile.LinkTarget
+		} else if e.RepairMode {
+			var err error
+
+			needsSync, err = e.verifyFileForRepair(relPath, dstFile.RelativePath, comparedCount)
+			if err != nil {
+				return err
+			}
+		} else if manifestNeedsSync, decided := e.manifest.decide(relPath, srcFile, dstFile); decided {
+			needsSync = manifestNeedsSync
+
+			if needsSync {
+				manifestRecopied++
+				e.logAnalysis("  → Partially copied by an interrupted sync - copying again: " + relPath)
+			} else {
+				manifestSkipped++
+			}
+		} else {
+			needsSync = e.determineIfFileNeedsSync(relPath, srcFile, dstFile, comparedCount)
+		}
+
+		protected := needsSync && e.protectsDestEdit(relPath, srcFile, dstFile)
+		if protected {
+			needsSync = false
+		}
 
 		// Update counters
 		if needsSync {
@@ -804,7 +2422,23 @@ func (e *Engine) compareAndPlanSync(sourceFiles, destFiles map[string]*fileops.F
 
 		// Update status
 		comparedCount++
-		e.updateStatusForFile(relPath, srcFile, needsSync, comparedCount)
+		action := ActionCreate
+		if dstFile != nil {
+			action = ActionOverwrite
+		}
+
+		itemize := itemizeChanges(srcFile, dstFile, contentCompared, e.PreservePermissions)
+		e.updateStatusForFile(relPath, srcFile, action, itemize, needsSync, comparedCount)
+
+		// Content is current, but a chmod on the source still has to reach the destination
+		if !needsSync && !protected && e.PreservePermissions && dstFile != nil && srcFile.Mode != dstFile.Mode {
+			e.permissionUpdates = append(e.permissionUpdates, permissionUpdate{
+				relPath:     relPath,
+				destRelPath: dstFile.RelativePath,
+				mode:        srcFile.Mode,
+			})
+			e.logAnalysis(fmt.Sprintf("  ~ Permissions differ: %s (src=%s dst=%s)", relPath, srcFile.Mode, dstFile.Mode))
+		}
 
 		// Log outside the lock
 		if logMsg != "" {
@@ -822,18 +2456,42 @@ func (e *Engine) compareAndPlanSync(sourceFiles, destFiles map[string]*fileops.F
 
 	// Store comparison counts in Status for event emission
 	e.Status.mu.Lock()
+	// Sync in path order so an interrupted run can be resumed from a stable position
+	sort.Slice(e.Status.FilesToSync, func(i, j int) bool {
+		return e.Status.FilesToSync[i].RelativePath < e.Status.FilesToSync[j].RelativePath
+	})
+	sort.Strings(e.Status.TypeChanges)
+	sort.Strings(e.Status.ProtectedDestEdits)
 	e.Status.FilesInBoth = filesInBoth
 	e.Status.FilesOnlyInSource = filesOnlyInSource
 	e.Status.BytesInBoth = bytesInBoth
 	e.Status.BytesOnlyInSource = bytesOnlyInSource
+	e.Status.ManifestSkippedFiles = manifestSkipped
+	e.Status.ManifestRecopiedFiles = manifestRecopied
+	e.Status.SizeSkippedFiles = sizeSkipped
+	e.Status.ModTimeSkippedFiles = modTimeSkipped
 	e.Status.mu.Unlock()
 
+	if e.sizeFiltered() {
+		e.logAnalysis(fmt.Sprintf("Size filter: skipped %d files %s", sizeSkipped, e.sizeRangeDescription()))
+	}
+
+	if e.modTimeFiltered() {
+		e.logAnalysis(fmt.Sprintf("Modification time filter: skipped %d files %s",
+			modTimeSkipped, e.modTimeWindowDescription()))
+	}
+
+	if e.manifest != nil {
+		e.logAnalysis(fmt.Sprintf("Resume manifest: %d files already copied, %d partial files to copy again",
+			manifestSkipped, manifestRecopied))
+	}
+
 	return nil
 }
 
-func (e *Engine) compareFilesByteByByte(relPath string, comparedCount int) bool {
+func (e *Engine) compareFilesByteByByte(relPath, dstRelPath string, comparedCount int) bool {
 	srcPath := filepath.Join(e.SourcePath, relPath)
-	dstPath := filepath.Join(e.DestPath, relPath)
+	dstPath := filepath.Join(e.DestPath, dstRelPath)
 
 	identical, err := e.FileOps.CompareFilesBytes(srcPath, dstPath)
 	if err != nil {
@@ -857,11 +2515,12 @@ func (e *Engine) compareFilesByteByByte(relPath string, comparedCount int) bool
 
 // determineIfFileNeedsSync checks if a file needs to be synced based on the ChangeType mode.
 // Returns true if the file needs sync, false otherwise.
-func (e *Engine) compareFilesWithHash(relPath string, comparedCount int) bool {
-	srcPath := filepath.Join(e.SourcePath, relPath)
-	dstPath := filepath.Join(e.DestPath, relPath)
+func (e *Engine) compareFilesWithHash(
+	relPath string, srcFile, dstFile *fileops.FileInfo, comparedCount int,
+) bool {
+	dstPath := filepath.Join(e.DestPath, dstFile.RelativePath)
 
-	srcHash, err := e.FileOps.ComputeFileHash(srcPath)
+	srcHash, err := e.sourceHash(relPath, srcFile.Size, srcFile.ModTime)
 	if err != nil {
 		e.logAnalysis(fmt.Sprintf("  ⚠ Failed to compute source hash for %s: %v", relPath, err))
 		return true // Assume needs sync if we can't compute hash
@@ -873,6 +2532,8 @@ func (e *Engine) compareFilesWithHash(relPath string, comparedCount int) bool {
 		return true // Assume needs sync if we can't compute hash
 	}
 
+	e.recordDestHash(dstFile.RelativePath, dstFile.Size, dstFile.ModTime, dstHash)
+
 	// Compare hashes
 	needsSync := (srcHash != dstHash)
 
@@ -889,8 +2550,67 @@ func (e *Engine) compareFilesWithHash(relPath string, comparedCount int) bool {
 	return needsSync
 }
 
+// compareFilesWithSamples compares hashes of the start, middle and end of both files.
+// Changes confined to the unsampled parts of large files are missed; that's the speed tradeoff.
+func (e *Engine) compareFilesWithSamples(relPath, dstRelPath string, comparedCount int) bool {
+	srcHash, err := e.FileOps.ComputeSourceSampleHash(filepath.Join(e.SourcePath, relPath), e.SampleSize)
+	if err != nil {
+		e.logAnalysis(fmt.Sprintf("  ⚠ Failed to sample source file %s: %v", relPath, err))
+		return true // Assume needs sync if we can't sample
+	}
+
+	dstHash, err := e.FileOps.ComputeDestSampleHash(filepath.Join(e.DestPath, dstRelPath), e.SampleSize)
+	if err != nil {
+		e.logAnalysis(fmt.Sprintf("  ⚠ Failed to sample dest file %s: %v", relPath, err))
+		return true // Assume needs sync if we can't sample
+	}
+
+	needsSync := srcHash != dstHash
+
+	if comparedCount < LogSampleSize {
+		if needsSync {
+			e.logAnalysis("  → Sample mismatch: " + relPath)
+		} else {
+			e.logAnalysis("  ✓ Sample match: " + relPath)
+		}
+	}
+
+	return needsSync
+}
+
+// compareWithPreviousRun loads the previous run's statistics for the summary to diff against,
+// and flags a change large enough to suggest something is wrong (e.g. an unmounted source).
+func (e *Engine) compareWithPreviousRun() {
+	if !e.CompareRuns {
+		return
+	}
+
+	prev, err := loadRunSummary(e.runHistoryPath())
+	if err != nil {
+		e.logAnalysis(fmt.Sprintf("Skipping comparison with previous run: %v", err))
+		return
+	}
+
+	if prev == nil {
+		e.logAnalysis("No previous run to compare against")
+		return
+	}
+
+	warning := runDeltaWarning(*prev, e.runSummary())
+
+	e.Status.mu.Lock()
+	e.Status.PreviousRun = prev
+	e.Status.RunDeltaWarning = warning
+	e.Status.mu.Unlock()
+
+	if warning != "" {
+		e.logAnalysis("Warning: " + warning)
+	}
+}
+
 func (e *Engine) countAndLogOrphanedItems(sourceFiles, destFiles map[string]*fileops.FileInfo) (int, int) {
 	filesToDelete, dirsToDelete, bytesToDelete := countOrphanedItems(sourceFiles, destFiles)
+	byAge := orphansByAge(sourceFiles, destFiles, e.TimeProvider.Now())
 
 	// Store orphan count and bytes in Status for CompareComplete event and deletion tracking
 	e.Status.mu.Lock()
@@ -898,6 +2618,7 @@ func (e *Engine) countAndLogOrphanedItems(sourceFiles, destFiles map[string]*fil
 	e.Status.BytesOnlyInDest = bytesToDelete
 	e.Status.FilesToDelete = filesToDelete
 	e.Status.BytesToDelete = bytesToDelete
+	e.Status.OrphansByAge = byAge
 	e.Status.FilesDeleted = 0
 	e.Status.BytesDeleted = 0
 	e.Status.CurrentlyDeleting = nil
@@ -905,17 +2626,54 @@ func (e *Engine) countAndLogOrphanedItems(sourceFiles, destFiles map[string]*fil
 	e.Status.DeletionErrors = 0
 	e.Status.mu.Unlock()
 
+	e.planMu.Lock()
+	e.plannedDeletions = orphanedFileEntries(sourceFiles, destFiles)
+	e.planMu.Unlock()
+
+	e.requireDeletionConfirmation(filesToDelete)
+
 	if filesToDelete == 0 && dirsToDelete == 0 {
 		return 0, 0
 	}
 
 	//nolint:lll // Log message with descriptive text
 	e.logAnalysis(fmt.Sprintf("Found %d files and %d directories in destination that don't exist in source", filesToDelete, dirsToDelete))
+
+	if filesToDelete > 0 {
+		e.logAnalysis("Orphaned files by age: " + FormatOrphansByAge(byAge))
+	}
+
 	e.logOrphanedItemsSample(sourceFiles, destFiles)
 
 	return filesToDelete, dirsToDelete
 }
 
+// keepOrphans records the orphans a sync without DeleteOrphans leaves at the destination, and
+// plans no deletions.
+func (e *Engine) keepOrphans(sourceFiles, destFiles map[string]*fileops.FileInfo) {
+	files, dirs, bytes := countOrphanedItems(sourceFiles, destFiles)
+
+	e.Status.mu.Lock()
+	e.Status.AnalysisPhase = "planning"
+	e.Status.DeletionDisabled = true
+	e.Status.OrphansKept = files
+	e.Status.OrphanBytesKept = bytes
+	e.Status.FilesOnlyInDest = 0
+	e.Status.BytesOnlyInDest = 0
+	e.Status.FilesToDelete = 0
+	e.Status.BytesToDelete = 0
+	e.Status.OrphansByAge = nil
+	e.Status.DeletionConfirmationRequired = false
+	e.Status.mu.Unlock()
+
+	e.planMu.Lock()
+	e.plannedDeletions = nil
+	e.planMu.Unlock()
+
+	e.logAnalysis(fmt.Sprintf("Deletion disabled: keeping %d files and %d directories in destination that don't exist in source",
+		files, dirs))
+}
+
 // countOrphanedItemsForPlan counts orphaned items during analysis (for plan display)
 // without actually deleting them. Deletion happens during sync phase.
 func (e *Engine) countOrphanedItemsForPlan(sourceFiles, destFiles map[string]*fileops.FileInfo) {
@@ -927,18 +2685,37 @@ func (e *Engine) countOrphanedItemsForPlan(sourceFiles, destFiles map[string]*fi
 	e.countAndLogOrphanedItems(sourceFiles, destFiles)
 }
 
-// createProgressCallback creates a progress callback for file copying with throttling
+// createProgressCallback creates a progress callback for file copying with throttling, for a
+// copy that starts offset bytes in. The copy reports the size of the source it opened, and a
+// file whose size has changed since analysis is resized to match, so its progress and the ETA
+// follow the file being copied.
 //
 //nolint:funlen // Complex progress tracking logic requires multiple state updates
-func (e *Engine) createProgressCallback(fileToSync *FileToSync) func(int64, int64, string) {
+func (e *Engine) createProgressCallback(fileToSync *FileToSync, offset int64) func(int64, int64, string) {
 	var (
-		previousBytes  int64
+		previousBytes  = offset
 		lastNotifyTime time.Time
 		lastSampleTime time.Time // Zero value means first callback will add sample immediately
 		sampleBytes    int64
+		sized          bool // The copy's size has been checked against the analyzed size
 	)
 
-	return func(bytesTransferred, _ int64, _ string) {
+	// Each copy attempt gets a new callback, and counts its own bytes. A resumed copy starts
+	// with the bytes already copied transferred, but not sampled, as they weren't copied now.
+	fileToSync.sampledBytes = offset
+	fileToSync.Transferred = offset
+	atomic.AddInt64(&e.Status.TransferredBytes, offset)
+
+	return func(bytesTransferred, totalBytes int64, _ string) {
+		// Count the source as it is now, not as analysis found it
+		if !sized {
+			sized = true
+
+			if totalBytes != fileToSync.Size {
+				e.resizeFileToSync(fileToSync, totalBytes)
+			}
+		}
+
 		// Calculate delta without lock
 		delta := bytesTransferred - previousBytes
 		previousBytes = bytesTransferred
@@ -957,9 +2734,9 @@ func (e *Engine) createProgressCallback(fileToSync *FileToSync) func(int64, int6
 			e.notifyStatusUpdate()
 		}
 
-		// Only acquire lock every 100ms to reduce contention
+		// Only acquire lock every ProgressInterval to reduce contention
 		now := time.Now()
-		throttled := now.Sub(lastNotifyTime) < 100*time.Millisecond //nolint:mnd // Throttle interval
+		throttled := now.Sub(lastNotifyTime) < e.ProgressInterval
 
 		// Add rate sample every 1 second during transfer
 		if now.Sub(lastSampleTime) >= 1*time.Second {
@@ -971,6 +2748,7 @@ func (e *Engine) createProgressCallback(fileToSync *FileToSync) func(int64, int6
 				ReadTime:         0, // Not available during transfer
 				WriteTime:        0, // Not available during transfer
 				ActiveWorkers:    int(atomic.LoadInt32(&e.Status.ActiveWorkers)),
+				WorkerID:         fileToSync.workerID,
 			}
 
 			e.Status.mu.Lock()
@@ -978,23 +2756,17 @@ func (e *Engine) createProgressCallback(fileToSync *FileToSync) func(int64, int6
 			e.Status.mu.Unlock()
 
 			lastSampleTime = now
+			fileToSync.sampledBytes += sampleBytes
 			sampleBytes = 0 // Reset for next sample
 		} else {
 			sampleBytes += delta // Accumulate bytes for next sample
 		}
 
-		// Verbose instrumentation: log every progress callback
+		// Verbose instrumentation: log every progress callback (checked first, as this is the
+		// hottest path there is and boxing the fields allocates)
 		if e.Verbose {
-			var percent float64
-			if fileToSync.Size > 0 {
-				percent = float64(bytesTransferred) / float64(fileToSync.Size) * 100 //nolint:mnd // Percentage calculation
-			}
-			throttledStr := "no"
-			if throttled {
-				throttledStr = "yes"
-			}
-			e.LogVerbose(fmt.Sprintf("[PROGRESS] CALLBACK: %s transferred=%d/%d (%.1f%%) throttled=%s",
-				fileToSync.RelativePath, bytesTransferred, fileToSync.Size, percent, throttledStr))
+			e.LogVerbose("Progress", "file", fileToSync.RelativePath, "bytes", bytesTransferred,
+				"size", fileToSync.Size, "worker", fileToSync.workerID, "throttled", throttled)
 		}
 
 		if throttled {
@@ -1020,6 +2792,7 @@ func (e *Engine) createProgressCallback(fileToSync *FileToSync) func(int64, int6
 				remainingBytes := e.Status.TotalBytes - transferredBytes
 				e.Status.EstimatedTimeLeft = time.Duration(float64(remainingBytes)/e.Status.BytesPerSecond) * time.Second
 				e.Status.CompletionTime = time.Now().Add(e.Status.EstimatedTimeLeft)
+				e.Status.EstimateSamples++
 			}
 		}
 
@@ -1028,6 +2801,20 @@ func (e *Engine) createProgressCallback(fileToSync *FileToSync) func(int64, int6
 	}
 }
 
+// deadlineReached reports whether the Deadline has passed, after which no new files are started.
+func (e *Engine) deadlineReached() bool {
+	return !e.Deadline.IsZero() && !e.TimeProvider.Now().Before(e.Deadline)
+}
+
+// deferFile leaves a file unsynced because the deadline passed before it started.
+func (e *Engine) deferFile(fileToSync *FileToSync) {
+	e.Status.mu.Lock()
+	e.Status.DeferredFiles++
+	e.Status.mu.Unlock()
+
+	e.LogVerbose("Deferred past the deadline", "file", fileToSync.RelativePath)
+}
+
 func (e *Engine) deleteDirectory(relPath string, deletedCount int) error {
 	dstPath := filepath.Join(e.DestPath, relPath)
 
@@ -1036,15 +2823,12 @@ func (e *Engine) deleteDirectory(relPath string, deletedCount int) error {
 		e.logAnalysis(fmt.Sprintf("  → Deleting directory: %s (not in source)", relPath))
 	}
 
-	err := e.FileOps.RemoveFromDest(dstPath)
+	err := e.removeOrphan(dstPath, relPath, true)
 	if err != nil {
 		// Track error instead of failing
 		e.Status.mu.Lock()
-		e.Status.Errors = append(e.Status.Errors, FileError{
-			FilePath: relPath,
-			Error:    fmt.Errorf("failed to delete directory: %w", err),
-		})
-		errorCount := len(e.Status.Errors)
+		e.recordError(relPath, fmt.Errorf("failed to delete directory: %w", err))
+		errorCount := e.abortErrorCount()
 		e.Status.mu.Unlock()
 
 		e.logAnalysis(fmt.Sprintf("✗ Error deleting directory %s: %v", relPath, err))
@@ -1057,6 +2841,8 @@ func (e *Engine) deleteDirectory(relPath string, deletedCount int) error {
 		return ErrDeleteFailed // Signal error but continue
 	}
 
+	e.itemize(itemizeDeleting, relPath+"/")
+
 	return nil
 }
 
@@ -1074,7 +2860,7 @@ func (e *Engine) deleteFile(relPath string, fileSize int64, deletedCount int) er
 	e.Status.CurrentlyDeleting = append(e.Status.CurrentlyDeleting, relPath)
 	e.Status.mu.Unlock()
 
-	err := e.FileOps.RemoveFromDest(dstPath)
+	err := e.removeOrphan(dstPath, relPath, false)
 
 	// Remove from currently deleting list
 	e.Status.mu.Lock()
@@ -1090,12 +2876,9 @@ func (e *Engine) deleteFile(relPath string, fileSize int64, deletedCount int) er
 	if err != nil {
 		// Track error instead of failing
 		e.Status.mu.Lock()
-		e.Status.Errors = append(e.Status.Errors, FileError{
-			FilePath: relPath,
-			Error:    fmt.Errorf("failed to delete: %w", err),
-		})
+		e.recordError(relPath, fmt.Errorf("failed to delete: %w", err))
 		e.Status.DeletionErrors++
-		errorCount := len(e.Status.Errors)
+		errorCount := e.abortErrorCount()
 		e.Status.mu.Unlock()
 
 		e.logAnalysis(fmt.Sprintf("✗ Error deleting %s: %v", relPath, err))
@@ -1112,8 +2895,13 @@ func (e *Engine) deleteFile(relPath string, fileSize int64, deletedCount int) er
 	e.Status.mu.Lock()
 	e.Status.FilesDeleted++
 	e.Status.BytesDeleted += fileSize
+	if e.TrashDir != "" {
+		e.Status.FilesTrashed++
+	}
 	e.Status.mu.Unlock()
 
+	e.itemize(itemizeDeleting, relPath)
+
 	return nil
 }
 
@@ -1223,17 +3011,51 @@ func (e *Engine) deleteOrphanedFiles(sourceFiles, destFiles map[string]*fileops.
 
 // Delete directories (in reverse depth order, deepest first)
 
+// originalSourcePath returns the source path the engine was created with, even while reading
+// from a snapshot, so resume state and run history stay tied to the real source.
+func (e *Engine) originalSourcePath() string {
+	if len(e.Sources) > 0 {
+		return sourcesKey(e.Sources)
+	}
+
+	if e.snapshot != nil {
+		return e.liveSourcePath
+	}
+
+	return e.SourcePath
+}
+
+// orphanListings returns the source and destination listings deletion compares: those stored
+// during analysis, or for a resumed plan, the orphans it saved against an empty source.
+func (e *Engine) orphanListings() (map[string]*fileops.FileInfo, map[string]*fileops.FileInfo) {
+	if e.analysisSourceFiles == nil && e.resumedOrphans != nil {
+		return map[string]*fileops.FileInfo{}, e.resumedOrphans
+	}
+
+	return e.analysisSourceFiles, e.analysisDestFiles
+}
+
 // performDeletionsDuringSync deletes orphaned files/directories during sync phase
 // Uses file maps stored during analysis phase.
 func (e *Engine) performDeletionsDuringSync() error {
-	sourceFiles := e.analysisSourceFiles
-	destFiles := e.analysisDestFiles
+	defer e.timePhase(PhaseDelete)()
+
+	sourceFiles, destFiles := e.orphanListings()
 
 	// If no file maps available (shouldn't happen), skip deletion
 	if sourceFiles == nil || destFiles == nil {
 		return nil
 	}
 
+	// Kept orphans aren't in the way of anything, but entries of the wrong type still are
+	if !e.deletesOrphans() {
+		e.Status.mu.Lock()
+		e.Status.DeletionComplete = true
+		e.Status.mu.Unlock()
+
+		return e.removeTypeChangedEntries()
+	}
+
 	// Get file count from status (set during analysis)
 	e.Status.mu.RLock()
 	filesToDelete := e.Status.FilesToDelete
@@ -1255,13 +3077,18 @@ func (e *Engine) performDeletionsDuringSync() error {
 		e.Status.DeletionComplete = true
 		e.Status.mu.Unlock()
 
-		return nil
+		return e.removeTypeChangedEntries()
+	}
+
+	err := e.checkDeletionsConfirmed(filesToDelete)
+	if err != nil {
+		return err
 	}
 
-	e.logToFile(fmt.Sprintf("Starting deletion phase: %d files, %d directories", filesToDelete, dirsToDelete))
+	e.logToFile("Starting deletion phase", "phase", "delete", "files", filesToDelete, "dirs", dirsToDelete)
 
 	// Delete files first (before directories)
-	err := e.deleteOrphanedFiles(sourceFiles, destFiles, filesToDelete)
+	err = e.deleteOrphanedFiles(sourceFiles, destFiles, filesToDelete)
 	if err != nil {
 		return err
 	}
@@ -1272,31 +3099,55 @@ func (e *Engine) performDeletionsDuringSync() error {
 		return err
 	}
 
-	return nil
+	// After the orphans, so a directory being replaced by a file is already empty
+	return e.removeTypeChangedEntries()
 }
 
 func (e *Engine) determineIfFileNeedsSync(relPath string, srcFile, dstFile *fileops.FileInfo, comparedCount int) bool {
+	// Size, modtime and content say nothing useful about a directory or a link
+	if dstFile != nil && entryTypeChanged(srcFile, dstFile) {
+		return true
+	}
+
+	// A version stamped in an extended attribute replaces every other comparison
+	if e.XattrCompare != "" && dstFile != nil {
+		return e.compareXattrs(relPath, dstFile.RelativePath, comparedCount)
+	}
+
 	switch e.ChangeType {
 	case config.Content:
 		// For Content mode, use full comparison (size + modtime)
 		return fileops.FilesNeedSync(srcFile, dstFile)
-	case config.MonotonicCount, config.FluctuatingCount:
-		// For count-based modes, only check if file exists (path comparison)
+	case config.MonotonicCount:
+		// For monotonic-count mode, only check if file exists (path comparison)
 		return dstFile == nil
+	case config.FluctuatingCount:
+		// For fluctuating-count mode, also catch modifications that change a file's size
+		return dstFile == nil || srcFile.Size != dstFile.Size
 	case config.DeviousContent:
 		// For devious-content mode, always compare hashes
 		if dstFile == nil {
 			return true
 		}
 
-		return e.compareFilesWithHash(relPath, comparedCount)
+		return e.compareFilesWithHash(relPath, srcFile, dstFile, comparedCount)
 	case config.Paranoid:
 		// For paranoid mode, perform byte-by-byte comparison
 		if dstFile == nil {
 			return true
 		}
 
-		return e.compareFilesByteByByte(relPath, comparedCount)
+		return e.compareFilesByteByByte(relPath, dstFile.RelativePath, comparedCount)
+	case config.QuickContent:
+		// For quick-content mode, a size change is enough; otherwise compare sampled regions
+		if dstFile == nil || srcFile.Size != dstFile.Size {
+			return true
+		}
+
+		return e.compareFilesWithSamples(relPath, dstFile.RelativePath, comparedCount)
+	case config.ModTimeNewer:
+		// For modtime-newer mode, only a source modtime newer by more than the slack counts
+		return dstFile == nil || srcFile.ModTime.Sub(dstFile.ModTime) > e.ModTimeSlack
 	}
 
 	return false
@@ -1306,6 +3157,11 @@ func (e *Engine) determineIfFileNeedsSync(relPath string, srcFile, dstFile *file
 func (e *Engine) distributeJobs(jobs chan *FileToSync) {
 	go func() {
 		for _, fileToSync := range e.Status.FilesToSync {
+			// Already sent in a batch
+			if fileToSync.Status == fileStatusComplete {
+				continue
+			}
+
 			select {
 			case <-e.cancelChan:
 				close(jobs)
@@ -1318,9 +3174,32 @@ func (e *Engine) distributeJobs(jobs chan *FileToSync) {
 	}()
 }
 
+// emitSyncPlan emits CompareComplete with the plan currently held in Status
+func (e *Engine) emitSyncPlan() {
+	e.Status.mu.RLock()
+	plan := &SyncPlan{
+		FilesToCopy:       len(e.Status.FilesToSync),
+		FilesToDelete:     e.Status.FilesOnlyInDest,
+		BytesToCopy:       e.Status.TotalBytes,
+		FilesInBoth:       e.Status.FilesInBoth,
+		FilesOnlyInSource: e.Status.FilesOnlyInSource,
+		FilesOnlyInDest:   e.Status.FilesOnlyInDest,
+		BytesInBoth:       e.Status.BytesInBoth,
+		BytesOnlyInSource: e.Status.BytesOnlyInSource,
+		BytesOnlyInDest:   e.Status.BytesOnlyInDest,
+	}
+	e.Status.mu.RUnlock()
+	e.emit(CompareComplete{Plan: plan})
+}
+
 func (e *Engine) enqueueFilesForSync(jobs chan *FileToSync) {
 	go func() {
 		for _, fileToSync := range e.Status.FilesToSync {
+			// Already sent in a batch
+			if fileToSync.Status == fileStatusComplete {
+				continue
+			}
+
 			select {
 			case <-e.cancelChan:
 				close(jobs)
@@ -1333,12 +3212,34 @@ func (e *Engine) enqueueFilesForSync(jobs chan *FileToSync) {
 	}()
 }
 
+// failureHistoryPath returns the configured failure history path or the default for this source/dest
+func (e *Engine) failureHistoryPath() string {
+	if e.FailureHistoryPath != "" {
+		return e.FailureHistoryPath
+	}
+
+	return DefaultFailureHistoryPath(e.originalSourcePath(), e.DestPath)
+}
+
 func (e *Engine) finalizeAnalysis() {
+	e.checkFreeInodes()
+	e.applyFailureHistory()
+	e.orderFilesToSync()
+	e.compareWithPreviousRun()
+
 	e.Status.mu.Lock()
 	e.Status.TotalFiles = len(e.Status.FilesToSync)
+	e.Status.SizeHistogram = sizeHistogram(e.Status.FilesToSync)
+	histogram := e.Status.SizeHistogram
 	e.Status.AnalysisPhase = phaseComplete
 	e.Status.mu.Unlock()
 
+	if len(histogram) > 0 {
+		e.logAnalysis("Files to sync by size: " + FormatSizeHistogram(histogram))
+	}
+
+	e.saveHashCache()
+
 	e.logAnalysis("Analysis complete!")
 	e.notifyStatusUpdate()
 }
@@ -1350,7 +3251,7 @@ func (e *Engine) finalizeSyncPhase() {
 	totalFiles := e.Status.TotalFiles
 	e.Status.mu.Unlock()
 
-	e.logToFile(fmt.Sprintf("Sync phase complete: %d / %d files copied", processedFiles, totalFiles))
+	e.logToFile("Sync phase complete", "phase", "sync", "files", processedFiles, "total_files", totalFiles)
 
 	e.Status.mu.Lock()
 	e.Status.FinalizationPhase = phaseComplete
@@ -1358,9 +3259,34 @@ func (e *Engine) finalizeSyncPhase() {
 	e.notifyStatusUpdate()
 }
 
+// finishResumeTracking removes the resume state after a complete sync, or saves the
+// resume point so an interrupted or failed sync can be continued with --resume.
+func (e *Engine) finishResumeTracking(syncErr error) {
+	if e.resume == nil {
+		return
+	}
+
+	if syncErr == nil && e.checkCancellation() == nil {
+		err := os.Remove(e.resume.path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			e.logWarn("Failed to remove resume state", "error", err)
+		}
+
+		return
+	}
+
+	err := e.resume.save()
+	if err != nil {
+		e.logWarn(err.Error())
+		return
+	}
+
+	e.logToFile("Saved resume point - rerun with --resume to continue")
+}
+
 func (e *Engine) handleCopyError(fileToSync *FileToSync, copyErr error) error {
 	// Check if this was a cancellation vs an actual error
-	if errors.Is(copyErr, fileops.ErrCopyCancelled) {
+	if errors.Is(copyErr, ErrCancelled) {
 		fileToSync.Status = "cancelled"
 		e.Status.CancelledFiles++
 		e.Status.CancelledCopies = append(e.Status.CancelledCopies, fileToSync.RelativePath)
@@ -1368,10 +3294,7 @@ func (e *Engine) handleCopyError(fileToSync *FileToSync, copyErr error) error {
 		fileToSync.Status = "error"
 		fileToSync.Error = copyErr
 		e.Status.FailedFiles++
-		e.Status.Errors = append(e.Status.Errors, FileError{
-			FilePath: fileToSync.RelativePath,
-			Error:    copyErr,
-		})
+		e.recordError(fileToSync.RelativePath, copyErr)
 	}
 
 	return fmt.Errorf("failed to copy %s: %w", fileToSync.RelativePath, copyErr)
@@ -1383,14 +3306,16 @@ func (e *Engine) handleCopyResult(fileToSync *FileToSync, stats *fileops.CopySta
 	// Track read/write times for bottleneck detection
 	e.updateBottleneckDetection(stats)
 
-	// Add rolling window sample for metrics calculation
-	if stats != nil {
+	// Add rolling window sample for metrics calculation, with only the bytes the in-transfer
+	// samples haven't already counted. A renamed file copied nothing, so it isn't sampled.
+	if stats != nil && !stats.Renamed {
 		sample := RateSample{
 			Timestamp:        time.Now(),
-			BytesTransferred: stats.BytesCopied,
+			BytesTransferred: max(stats.BytesCopied-fileToSync.sampledBytes, 0),
 			ReadTime:         stats.ReadTime,
 			WriteTime:        stats.WriteTime,
 			ActiveWorkers:    int(atomic.LoadInt32(&e.Status.ActiveWorkers)),
+			WorkerID:         fileToSync.workerID,
 		}
 		e.Status.addRateSample(sample)
 	}
@@ -1407,30 +3332,78 @@ func (e *Engine) handleCopyResult(fileToSync *FileToSync, stats *fileops.CopySta
 
 	e.handleCopySuccess(fileToSync)
 
+	if stats != nil {
+		e.recordDestHash(fileToSync.destPath(), fileToSync.Size, fileToSync.ModTime, stats.SourceHash)
+	}
+
+	if stats != nil && stats.Reflinked {
+		e.Status.ReflinkedFiles++
+	}
+
+	if stats != nil {
+		e.Status.SparseBytes += stats.HoleBytes
+	}
+
+	if stats != nil && stats.ResumedBytes > 0 {
+		e.Status.ResumedFiles++
+		e.Status.ResumedBytes += stats.ResumedBytes
+	}
+
+	if stats != nil && e.Move {
+		e.recordMove(fileToSync, stats)
+	}
+
 	e.Status.mu.Unlock()
+	e.resume.markComplete(fileToSync.RelativePath)
+	e.manifest.markComplete(fileToSync)
 	e.notifyStatusUpdate()
 
 	return nil
 }
 
+// recordMove counts a moved file. A renamed file's bytes are counted as transferred without
+// having been sampled, like the bytes a resumed copy skips, so the rate and ETA stay those of
+// the files actually copied. Must be called with Status.mu held.
+func (e *Engine) recordMove(fileToSync *FileToSync, stats *fileops.CopyStats) {
+	e.Status.MovedFiles++
+
+	if !stats.Renamed {
+		// Move checked the copy's hash itself before removing the source
+		if e.VerifyAfterCopy {
+			e.Status.VerifiedFiles++
+		}
+
+		return
+	}
+
+	e.Status.RenamedFiles++
+	atomic.AddInt64(&e.Status.TransferredBytes, fileToSync.Size-fileToSync.Transferred)
+	fileToSync.Transferred = fileToSync.Size
+}
+
 func (e *Engine) handleCopySuccess(fileToSync *FileToSync) {
 	fileToSync.Status = fileStatusComplete
 	e.Status.ProcessedFiles++
 
+	if e.RepairMode {
+		e.Status.RepairedFiles++
+	}
+
 	// Add to recently completed (keep last 10)
 	e.Status.RecentlyCompleted = append(e.Status.RecentlyCompleted, fileToSync.RelativePath)
 	if len(e.Status.RecentlyCompleted) > RecentlyCompletedLimit {
 		e.Status.RecentlyCompleted = e.Status.RecentlyCompleted[len(e.Status.RecentlyCompleted)-RecentlyCompletedLimit:]
 	}
 
-	// Log first 10 completed files
-	if e.Status.ProcessedFiles <= RecentlyCompletedLimit {
-		e.logToFile(fmt.Sprintf("  ✓ Copied: %s (%s)", fileToSync.RelativePath, formatters.FormatBytes(fileToSync.Size)))
-	}
+	e.logDebug("Copied", "file", fileToSync.RelativePath, "size", fileToSync.Size, "worker", fileToSync.workerID)
+
+	e.itemize(fileToSync.itemizeFlags(), fileToSync.destPath())
 }
 
 // compareAndPlanSync compares source and destination files to determine which need sync
 func (e *Engine) initializeComparisonStatus() {
+	e.dirsToCreate = nil
+
 	e.Status.mu.Lock()
 	e.Status.FilesToSync = make([]*FileToSync, 0)
 	e.Status.TotalBytes = 0
@@ -1438,7 +3411,176 @@ func (e *Engine) initializeComparisonStatus() {
 	e.Status.TotalBytesInSource = 0
 	e.Status.AlreadySyncedFiles = 0
 	e.Status.AlreadySyncedBytes = 0
+	e.Status.ProtectedDestEdits = nil
+	e.Status.DirsToCreate = 0
+	// The source total is known now, so ScannedBytes counts the comparison's way through it
+	e.Status.ScannedBytes = 0
+	e.Status.startAnalysisRate(e.TimeProvider.Now())
+	e.Status.mu.Unlock()
+}
+
+// isResumeFileDone cheaply verifies a file the resume state marks as done by checking
+// that it exists in the destination with the planned size.
+func (e *Engine) isResumeFileDone(file resumeFile) bool {
+	destPath := file.RelativePath
+	if file.DestRelativePath != "" {
+		destPath = file.DestRelativePath
+	}
+
+	info, err := e.FileOps.StatDest(filepath.Join(e.DestPath, destPath))
+	if err != nil {
+		e.logAnalysis(fmt.Sprintf("  → %s missing from destination - will copy again", file.RelativePath))
+		return false
+	}
+
+	if info.Size() != file.Size {
+		e.logAnalysis(fmt.Sprintf("  → %s size differs (%d vs %d) - will copy again",
+			file.RelativePath, info.Size(), file.Size))
+
+		return false
+	}
+
+	return true
+}
+
+// itemize records one destination change in the itemize file, if enabled.
+func (e *Engine) itemize(flags, relPath string) {
+	e.itemizeMu.Lock()
+	defer e.itemizeMu.Unlock()
+
+	if e.itemizeFile != nil {
+		_, _ = fmt.Fprintf(e.itemizeFile, "%-11s %s\n", flags, filepath.ToSlash(relPath))
+	}
+}
+
+// keepOtherOwnersAtDest removes from destFiles what OwnerFilter puts outside this sync, so it
+// isn't deleted as an orphan: copies of excluded source files, and destination-only files whose
+// own owner doesn't match (or can't be read).
+func (e *Engine) keepOtherOwnersAtDest(sourceFiles, destFiles map[string]*fileops.FileInfo) {
+	if !e.OwnerFilter.Active() {
+		return
+	}
+
+	for relPath, dstFile := range destFiles {
+		if _, inSource := sourceFiles[relPath]; inSource || dstFile.IsDir {
+			continue
+		}
+
+		if e.ownerExcluded[relPath] || !e.OwnerFilter.Matches(dstFile.UID, dstFile.GID, dstFile.HasOwner) {
+			delete(destFiles, relPath)
+		}
+	}
+}
+
+// keepOutOfRangeAtDest removes from destFiles the destination-only files outside the size range
+// or the modification time window, so they are left alone like the source files outside them.
+func (e *Engine) keepOutOfRangeAtDest(sourceFiles, destFiles map[string]*fileops.FileInfo) {
+	if !e.sizeFiltered() && !e.modTimeFiltered() {
+		return
+	}
+
+	for relPath, dstFile := range destFiles {
+		if _, inSource := sourceFiles[relPath]; inSource || dstFile.IsDir {
+			continue
+		}
+
+		if !e.sizeInRange(dstFile.Size) || !e.modTimeInWindow(dstFile.ModTime) {
+			delete(destFiles, relPath)
+		}
+	}
+}
+
+// keepExcludedAtDest removes from destFiles everything an exclude pattern matches, so it is
+// never deleted as an orphan.
+func (e *Engine) keepExcludedAtDest(destFiles map[string]*fileops.FileInfo) {
+	if len(e.ExcludePatterns) == 0 {
+		return
+	}
+
+	for relPath := range destFiles {
+		if e.excluded(relPath) {
+			delete(destFiles, relPath)
+		}
+	}
+}
+
+// keepSkippedLinksAtDest removes from destFiles the links SymlinkSkip leaves alone, so they are
+// never deleted as orphans. A link in the way of a source entry is kept in, to be replaced
+// rather than written through.
+func (e *Engine) keepSkippedLinksAtDest(sourceFiles, destFiles map[string]*fileops.FileInfo) {
+	if e.SymlinkMode != config.SymlinkSkip {
+		return
+	}
+
+	for relPath, dstFile := range destFiles {
+		if _, inSource := sourceFiles[relPath]; dstFile.IsSymlink && !inSource {
+			delete(destFiles, relPath)
+		}
+	}
+}
+
+// knownFailureRuns returns how many consecutive failed runs make a file a known persistent
+// failure, or 0 if failures aren't tracked.
+func (e *Engine) knownFailureRuns() int {
+	if e.KnownFailureRuns <= 0 && e.SkipKnownFailures {
+		return DefaultKnownFailureRuns
+	}
+
+	return e.KnownFailureRuns
+}
+
+// loadSourceIndex returns the source listing saved by an earlier scan, or nil if there is none
+// or it no longer describes the source. Directory modtimes catch added, removed and renamed
+// entries; a file rewritten in place is only caught by SourceIndexMaxAge or a refresh.
+func (e *Engine) loadSourceIndex() map[string]*fileops.FileInfo {
+	if e.SourceIndexPath == "" {
+		return nil
+	}
+
+	if e.RefreshSourceIndex {
+		e.logAnalysis("Refreshing source index: scanning source")
+		return nil
+	}
+
+	index, err := loadSourceIndexFile(e.SourceIndexPath)
+	if err != nil {
+		e.logAnalysis(fmt.Sprintf("Warning: %v; scanning source", err))
+		return nil
+	}
+
+	if index == nil {
+		return nil
+	}
+
+	rootInfo, err := e.FileOps.Stat(e.SourcePath)
+	if err != nil {
+		return nil
+	}
+
+	reason := sourceIndexStaleReason(index, e.originalSourcePath(), e.MaxDepth, rootInfo.ModTime(),
+		e.TimeProvider.Now(), e.SourceIndexMaxAge)
+	if reason == "" {
+		reason = e.staleSourceIndexDir(index)
+	}
+
+	if reason != "" {
+		e.logAnalysis(fmt.Sprintf("Source index is stale (%s); scanning source", reason))
+		return nil
+	}
+
+	sourceFiles := index.sourceFiles(e.SourcePath)
+
+	e.Status.mu.Lock()
+	e.Status.SourceIndexUsed = true
+	e.Status.SourceIndexScannedAt = index.ScannedAt
+	e.Status.SourceScannedFiles = len(sourceFiles)
+	e.Status.SourceTotalFiles = len(sourceFiles)
 	e.Status.mu.Unlock()
+
+	e.logAnalysis(fmt.Sprintf("Loaded source index: %d entries scanned at %s",
+		len(sourceFiles), index.ScannedAt.Format(time.RFC3339)))
+
+	return sourceFiles
 }
 
 // logAnalysis adds a message to the analysis log
@@ -1456,8 +3598,9 @@ func (e *Engine) logAnalysis(message string) {
 
 	e.notifyStatusUpdate()
 
-	// Also write to log file if enabled
-	e.logToFile(message)
+	// Also write to log files if enabled
+	e.logToFile(message, "phase", "analysis")
+	e.logToAnalysisFile(message)
 }
 
 func (e *Engine) logComparisonSummary(sourceFiles, destFiles map[string]*fileops.FileInfo) {
@@ -1575,14 +3718,14 @@ func (e *Engine) logSamplePaths(sourceFiles, destFiles map[string]*fileops.FileI
 	}
 }
 
-// logToFile writes a message to the log file (if enabled)
-func (e *Engine) logToFile(message string) {
-	if e.logFile != nil {
-		e.logMu.Lock()
-		defer e.logMu.Unlock()
+// logToAnalysisFile writes a message to the analysis log file (if enabled)
+func (e *Engine) logToAnalysisFile(message string) {
+	e.analysisLogMu.Lock()
+	defer e.analysisLogMu.Unlock()
 
+	if e.analysisLogFile != nil {
 		timestamp := time.Now().Format("15:04:05.000")
-		_, _ = fmt.Fprintf(e.logFile, "[%s] %s\n", timestamp, message)
+		_, _ = fmt.Fprintf(e.analysisLogFile, "[%s] %s\n", timestamp, message)
 	}
 }
 
@@ -1603,6 +3746,57 @@ func (e *Engine) markFileCompleteWithoutCopy(fileToSync *FileToSync) {
 	}
 
 	e.Status.mu.Unlock()
+	e.resume.markComplete(fileToSync.RelativePath)
+	e.manifest.markComplete(fileToSync)
+	e.notifyStatusUpdate()
+}
+
+// matchesBaseline reports whether a source file's content is identical to its baseline copy.
+func (e *Engine) matchesBaseline(relPath string, srcFile *fileops.FileInfo) (bool, error) {
+	baselinePath := filepath.Join(e.BaselineDir, relPath)
+
+	info, err := os.Stat(baselinePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to stat baseline copy: %w", err)
+	}
+
+	if info.IsDir() || info.Size() != srcFile.Size {
+		return false, nil
+	}
+
+	baselineHash, err := fileops.ComputeFileHashWith(baselinePath, e.FileOps.HashAlgorithm)
+	if err != nil {
+		return false, fmt.Errorf("failed to hash baseline copy: %w", err)
+	}
+
+	srcHash, err := e.FileOps.ComputeSourceHash(filepath.Join(e.SourcePath, relPath), e.cancelChan)
+	if err != nil {
+		return false, fmt.Errorf("failed to hash source file: %w", err)
+	}
+
+	return srcHash == baselineHash, nil
+}
+
+// notifyAnalysisProgress notifies status callbacks of analysis progress on one more item,
+// unless they were notified less than ProgressInterval ago. Scans and counts call it for every
+// file, which would otherwise redraw the screen thousands of times a second.
+func (e *Engine) notifyAnalysisProgress() {
+	if e.ProgressInterval <= 0 {
+		e.notifyStatusUpdate()
+		return
+	}
+
+	now := e.TimeProvider.Now().UnixNano()
+
+	last := e.lastAnalysisNotify.Load()
+	if now-last < int64(e.ProgressInterval) || !e.lastAnalysisNotify.CompareAndSwap(last, now) {
+		return
+	}
+
 	e.notifyStatusUpdate()
 }
 
@@ -1618,6 +3812,21 @@ func (e *Engine) notifyStatusUpdate() {
 	}
 }
 
+// orderFilesToSync sorts the copy queue as Order asks. Sorting is stable, so files with equal
+// modtimes (including resumed files, which have none) keep their planned order.
+func (e *Engine) orderFilesToSync() {
+	if e.Order != config.OrderNewestFirst {
+		return
+	}
+
+	e.Status.mu.Lock()
+	defer e.Status.mu.Unlock()
+
+	sort.SliceStable(e.Status.FilesToSync, func(i, j int) bool {
+		return e.Status.FilesToSync[i].ModTime.After(e.Status.FilesToSync[j].ModTime)
+	})
+}
+
 // prepareAlreadySyncedLogMessage prepares a log message for files already synced.
 //
 //nolint:lll // Long function signature with multiple parameters
@@ -1649,13 +3858,45 @@ func (e *Engine) prepareNeedSyncLogMessage(needSyncCount int, relPath string, sr
 		return ""
 	}
 
-	if dstFile == nil {
-		return fmt.Sprintf("  → Need sync: %s (destination missing)", relPath)
+	if dstFile == nil {
+		return fmt.Sprintf("  → Need sync: %s (destination missing)", relPath)
+	}
+
+	reason := formatSyncReason(srcFile, dstFile)
+
+	return fmt.Sprintf("  → Need sync: %s (%s)", relPath, reason)
+}
+
+// preservesLink reports whether a source entry is a link to recreate as a link rather than copy.
+func (e *Engine) preservesLink(srcFile *fileops.FileInfo) bool {
+	return srcFile.IsSymlink && e.SymlinkMode == config.SymlinkPreserve
+}
+
+// probeClock returns how far ahead of the local clock is the clock of the host that stamps the
+// modtime of a probe file written to probePath with write, read back with stat and then removed.
+func (e *Engine) probeClock(probePath string, write func(string, []byte) error,
+	stat func(string) (os.FileInfo, error), remove func(string) error,
+) (time.Duration, error) {
+	before := e.TimeProvider.Now()
+	err := write(probePath, nil)
+	after := e.TimeProvider.Now()
+
+	if err != nil {
+		return 0, err
+	}
+
+	info, err := stat(probePath)
+
+	removeErr := remove(probePath)
+	if removeErr != nil {
+		e.logAnalysis(fmt.Sprintf("Warning: failed to remove clock probe: %v", removeErr))
 	}
 
-	reason := formatSyncReason(srcFile, dstFile)
+	if err != nil {
+		return 0, err
+	}
 
-	return fmt.Sprintf("  → Need sync: %s (%s)", relPath, reason)
+	return info.ModTime().Sub(before.Add(after.Sub(before) / 2)), nil //nolint:mnd // Midpoint of the write
 }
 
 // processFileDeletion handles the deletion of a single orphaned file.
@@ -1693,19 +3934,204 @@ func (e *Engine) processOrphanedFile(relPath string, sourceFiles map[string]*fil
 	return deletedCount, deleteErrorCount, nil
 }
 
+// protectsDestEdit reports whether ProtectDestEdits keeps the destination copy of a file that
+// would otherwise be overwritten: one newer than the source and a different size, as an edit
+// made at the destination would leave it. Protected files are recorded for the summary.
+func (e *Engine) protectsDestEdit(relPath string, srcFile, dstFile *fileops.FileInfo) bool {
+	if !e.ProtectDestEdits || dstFile == nil || entryTypeChanged(srcFile, dstFile) ||
+		!dstFile.ModTime.After(srcFile.ModTime) || dstFile.Size == srcFile.Size {
+		return false
+	}
+
+	e.Status.mu.Lock()
+	e.Status.ProtectedDestEdits = append(e.Status.ProtectedDestEdits, relPath)
+	e.Status.mu.Unlock()
+
+	e.logAnalysis(fmt.Sprintf("  ! Kept destination edit: %s (dest %s, %d bytes; source %s, %d bytes)", relPath,
+		dstFile.ModTime.Format(time.RFC3339), dstFile.Size, srcFile.ModTime.Format(time.RFC3339), srcFile.Size))
+
+	return true
+}
+
+// recordError adds a failed file to Status.Errors, noting whether its category is skippable and
+// whether it's a known persistent failure. Callers must hold Status.mu.
+func (e *Engine) recordError(relPath string, err error) {
+	known := e.failures != nil && e.failures.known(relPath, e.knownFailureRuns())
+	e.Status.Errors = append(e.Status.Errors, FileError{FilePath: relPath, Error: err, KnownFailure: known})
+
+	// A file that fails every run would otherwise bury new problems among the warnings
+	if known {
+		e.logToFile("Known persistent failure", "file", relPath)
+	} else {
+		e.logWarn("Failed", "file", relPath, "error", err)
+	}
+
+	if slices.Contains(e.SkippableErrors, fileops.Categorize(err)) {
+		e.Status.SkippedErrors++
+	}
+}
+
+// recordFailureHistory updates the failure history with this run's errors. Files that synced, or
+// no longer need a sync, are forgotten; skipped, deferred and cancelled ones keep their count.
+func (e *Engine) recordFailureHistory() {
+	if e.failures == nil {
+		return
+	}
+
+	failed := make(map[string]string)
+	kept := make(map[string]bool)
+
+	e.Status.mu.RLock()
+	for _, fileErr := range e.Status.Errors {
+		failed[fileErr.FilePath] = fileErr.Error.Error()
+	}
+
+	for _, relPath := range e.Status.KnownFailuresSkipped {
+		kept[relPath] = true
+	}
+
+	for _, file := range e.Status.FilesToSync {
+		if file.Status != fileStatusComplete {
+			kept[file.RelativePath] = true
+		}
+	}
+	e.Status.mu.RUnlock()
+
+	e.failures.update(failed, kept, e.TimeProvider.Now())
+
+	err := saveFailureHistory(e.failureHistoryPath(), e.failures)
+	if err != nil {
+		e.logWarn(err.Error())
+	}
+}
+
+// recordTypeChange notes a path whose destination entry must be removed before syncing.
+func (e *Engine) recordTypeChange(relPath string, srcFile, dstFile *fileops.FileInfo) {
+	change := fmt.Sprintf("%s (%s → %s)", relPath, entryKind(dstFile), entryKind(srcFile))
+
+	e.typeChangedPaths = append(e.typeChangedPaths, dstFile.RelativePath)
+
+	e.Status.mu.Lock()
+	e.Status.TypeChanges = append(e.Status.TypeChanges, change)
+	e.Status.mu.Unlock()
+
+	e.logAnalysis("  ! Type changed: " + change)
+}
+
+// releaseSnapshot removes the source snapshot, if one was taken, and points SourcePath back at
+// the live source.
+func (e *Engine) releaseSnapshot() {
+	if e.snapshot == nil {
+		return
+	}
+
+	err := e.snapshot.Remove()
+	if err != nil {
+		e.logWarn("Failed to remove source snapshot", "error", err)
+		e.logAnalysis(fmt.Sprintf("Warning: failed to remove source snapshot: %v", err))
+	} else {
+		e.logToFile("Removed source snapshot " + e.SourcePath)
+	}
+
+	e.SourcePath = e.liveSourcePath
+	e.snapshot = nil
+}
+
 func (e *Engine) removeFromCurrentFiles(relativePath string) {
 	for i, f := range e.Status.CurrentFiles {
 		if f == relativePath {
 			e.Status.CurrentFiles = append(e.Status.CurrentFiles[:i], e.Status.CurrentFiles[i+1:]...)
 
 			// Verbose instrumentation: log when file is removed from CurrentFiles
-			e.LogVerbose("[PROGRESS] FILE_END: " + relativePath)
+			e.LogVerbose("File ended", "file", relativePath)
 
 			break
 		}
 	}
 }
 
+// planStaleTempFileRemoval drops the temp files of copies that an earlier run didn't finish,
+// such as one that crashed, from destFiles, and plans removing them before syncing. Those the
+// resume manifest says can be carried on, as their sources haven't changed, are kept. Nothing
+// is removed here, so analyzing without syncing leaves the destination as it was.
+func (e *Engine) planStaleTempFileRemoval(sourceFiles, destFiles map[string]*fileops.FileInfo) {
+	e.staleTempFiles = nil
+	kept := 0
+
+	for relPath, info := range destFiles {
+		if info.IsDir || !strings.HasSuffix(relPath, fileops.TempSuffix) {
+			continue
+		}
+
+		delete(destFiles, relPath)
+
+		if e.manifest.resumable(strings.TrimSuffix(relPath, fileops.TempSuffix), sourceFiles) {
+			kept++
+			continue
+		}
+
+		e.staleTempFiles = append(e.staleTempFiles, relPath)
+	}
+
+	sort.Strings(e.staleTempFiles)
+
+	if len(e.staleTempFiles) > 0 {
+		e.logAnalysis(fmt.Sprintf("Found %d temp files left by interrupted copies", len(e.staleTempFiles)))
+	}
+
+	if kept > 0 {
+		e.logAnalysis(fmt.Sprintf("Kept %d partial copies to resume", kept))
+	}
+}
+
+// removeStaleTempFiles removes the temp files planStaleTempFileRemoval planned to. A file that
+// can't be removed is only logged, since the next run tries again.
+func (e *Engine) removeStaleTempFiles() {
+	removed := 0
+
+	for _, relPath := range e.staleTempFiles {
+		err := e.FileOps.RemoveFromDest(filepath.Join(e.DestPath, relPath))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			e.logWarn("Failed to remove stale temp file", "file", relPath, "error", err)
+			continue
+		}
+
+		removed++
+	}
+
+	e.staleTempFiles = nil
+
+	if removed > 0 {
+		e.logToFile("Removed temp files left by interrupted copies", "files", removed)
+	}
+}
+
+// removeTypeChangedEntries removes destination entries whose type differs from the source,
+// so the copy writes a fresh entry instead of failing on, or writing through, the old one.
+func (e *Engine) removeTypeChangedEntries() error {
+	for _, relPath := range e.typeChangedPaths {
+		err := e.FileOps.RemoveFromDest(filepath.Join(e.DestPath, relPath))
+		if err != nil {
+			e.Status.mu.Lock()
+			e.recordError(relPath, fmt.Errorf("failed to remove entry whose type changed: %w", err))
+			errorCount := e.abortErrorCount()
+			e.Status.mu.Unlock()
+
+			e.logWarn("Failed to remove", "file", relPath, "error", err)
+
+			if errorCount >= MaxErrorsBeforeAbort {
+				return fmt.Errorf("%w (%d)", ErrTooManyErrors, errorCount)
+			}
+
+			continue
+		}
+
+		e.logToFile("Removed destination entry whose type changed", "file", relPath)
+	}
+
+	return nil
+}
+
 // resizePools calls ResizePool on source and dest if they implement ResizablePool
 func (e *Engine) resizePools(targetSize int) {
 	if e.sourceResizable != nil {
@@ -1716,8 +4142,133 @@ func (e *Engine) resizePools(targetSize int) {
 	}
 }
 
+// resolveDestFSType returns the declared destination filesystem type, or detects it for local destinations
+func (e *Engine) resolveDestFSType() config.DestFSType {
+	if e.DestFSType != config.DestFSAuto {
+		return e.DestFSType
+	}
+
+	if _, ok := e.FileOps.DestFS.(*filesystem.RealFileSystem); !ok {
+		return config.DestFSAuto
+	}
+
+	fsType := config.DestFSType(filesystem.DetectFSType(e.DestPath))
+	if fsType != config.DestFSAuto {
+		e.logAnalysis(fmt.Sprintf("Detected destination filesystem: %s", fsType))
+	}
+
+	return fsType
+}
+
+// resumeStatePath returns the configured resume state path or the default for this source/dest
+func (e *Engine) resumeStatePath() string {
+	if e.ResumeStatePath != "" {
+		return e.ResumeStatePath
+	}
+
+	return DefaultResumeStatePath(e.originalSourcePath(), e.DestPath)
+}
+
+// runHistoryPath returns the configured run history path or the default for this source/dest
+func (e *Engine) runHistoryPath() string {
+	if e.RunHistoryPath != "" {
+		return e.RunHistoryPath
+	}
+
+	return DefaultRunHistoryPath(e.originalSourcePath(), e.DestPath)
+}
+
+// runSummary returns this run's statistics as planned by analysis.
+func (e *Engine) runSummary() RunSummary {
+	e.Status.mu.RLock()
+	defer e.Status.mu.RUnlock()
+
+	return RunSummary{
+		Time:          e.Status.StartTime,
+		SourceFiles:   e.Status.TotalFilesInSource,
+		SourceBytes:   e.Status.TotalBytesInSource,
+		FilesToSync:   e.Status.TotalFiles,
+		BytesToSync:   e.Status.TotalBytes,
+		FilesToDelete: e.Status.FilesToDelete,
+	}
+}
+
+// saveCAStoreIndex writes the content-addressed store's index if anything changed.
+func (e *Engine) saveCAStoreIndex() error {
+	if e.caIndex == nil || !e.caIndex.dirty {
+		return nil
+	}
+
+	data, err := e.caIndex.marshal()
+	if err != nil {
+		return err
+	}
+
+	err = e.FileOps.WriteDestFile(filepath.Join(e.DestPath, CAStoreIndexFile), data)
+	if err != nil {
+		return fmt.Errorf("failed to save store index: %w", err)
+	}
+
+	return nil
+}
+
+// saveRunHistory records this run's statistics for the next run to compare against.
+// A failure only loses the comparison, so it's logged rather than failing the sync.
+func (e *Engine) saveRunHistory() {
+	err := saveRunSummary(e.runHistoryPath(), e.runSummary())
+	if err != nil {
+		e.logWarn(err.Error())
+	}
+}
+
+// saveSourceIndex records a source scan at SourceIndexPath for later runs to load.
+func (e *Engine) saveSourceIndex(sourceFiles map[string]*fileops.FileInfo) error {
+	rootInfo, err := e.FileOps.Stat(e.SourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat source for index: %w", err)
+	}
+
+	index := newSourceIndexFile(e.originalSourcePath(), e.MaxDepth, rootInfo.ModTime(), e.TimeProvider.Now(),
+		sourceFiles)
+
+	return saveSourceIndexFile(e.SourceIndexPath, index)
+}
+
+// sampleLoad reads the load average and records whether it's above MaxLoadAverage.
+func (e *Engine) sampleLoad() error {
+	load, err := e.LoadSampler()
+	if err != nil {
+		return fmt.Errorf("failed to sample load average: %w", err)
+	}
+
+	var overloaded int32
+	if load > e.MaxLoadAverage {
+		overloaded = 1
+	}
+
+	// Log only changes, not every sample
+	if atomic.SwapInt32(&e.overloaded, overloaded) != overloaded {
+		if overloaded == 1 {
+			e.logToFile("Load average above the maximum, scaling workers down", "load", load, "max", e.MaxLoadAverage)
+		} else {
+			e.logToFile("Load average back under the maximum", "load", load, "max", e.MaxLoadAverage)
+		}
+	}
+
+	e.Status.mu.Lock()
+	e.Status.LoadAverage = load
+	if overloaded == 1 {
+		e.Status.LoadThrottled = true
+	}
+	e.Status.mu.Unlock()
+
+	return nil
+}
+
 // scanDestinationDirectory scans the destination directory and returns file information.
 func (e *Engine) scanDestinationDirectory() (map[string]*fileops.FileInfo, error) {
+	defer e.timePhase(PhaseScanDest)()
+
 	e.logAnalysis("Scanning destination: " + e.DestPath)
 
 	// Update analysis phase
@@ -1739,6 +4290,7 @@ func (e *Engine) scanDestinationDirectory() (map[string]*fileops.FileInfo, error
 		e.Status.DestTotalFiles = totalCount
 		// Accumulate scanned bytes
 		e.Status.ScannedBytes += fileSize
+		e.Status.addAnalysisSample(e.TimeProvider.Now())
 
 		// Update phase when we transition from counting to scanning
 		if totalCount > 0 && e.Status.AnalysisPhase == phaseCountingDest {
@@ -1747,9 +4299,9 @@ func (e *Engine) scanDestinationDirectory() (map[string]*fileops.FileInfo, error
 
 		e.Status.mu.Unlock()
 
-		e.notifyStatusUpdate()
+		e.notifyAnalysisProgress()
 	})
-	if err != nil && !os.IsNotExist(err) {
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
 		return nil, fmt.Errorf("failed to scan destination: %w", err)
 	}
 
@@ -1775,6 +4327,8 @@ func (e *Engine) scanDestinationDirectory() (map[string]*fileops.FileInfo, error
 //
 //nolint:cyclop,dupl,funlen // Directory scanning logic
 func (e *Engine) scanSourceDirectory() (map[string]*fileops.FileInfo, error) {
+	defer e.timePhase(PhaseScanSource)()
+
 	e.logAnalysis("Scanning source: " + e.SourcePath)
 
 	// Update analysis phase and start time
@@ -1782,74 +4336,226 @@ func (e *Engine) scanSourceDirectory() (map[string]*fileops.FileInfo, error) {
 	e.Status.AnalysisPhase = phaseCountingSource
 	e.Status.SourceScannedFiles = 0
 	e.Status.SourceTotalFiles = 0
+	e.Status.TotalBytesToScan = 0 // Counting again until this scan's total is known
 	if e.Status.AnalysisStartTime.IsZero() {
 		e.Status.AnalysisStartTime = e.TimeProvider.Now()
 	}
+	e.Status.startAnalysisRate(e.TimeProvider.Now())
+	e.Status.mu.Unlock()
+
+	// Notify before accessing (may block on slow/remote filesystems)
+	e.logAnalysis("Accessing source...")
+	e.notifyStatusUpdate()
+
+	// A fresh index from an earlier scan stands in for walking the source again
+	sourceFiles := e.loadSourceIndex()
+	if sourceFiles == nil {
+		var err error
+
+		sourceFiles, err = e.scanSourceWithProgress()
+		if err != nil {
+			return nil, err
+		}
+
+		// An index that can't be saved only costs the next run a rescan
+		if e.SourceIndexPath != "" {
+			err = e.saveSourceIndex(sourceFiles)
+			if err != nil {
+				e.logAnalysis(fmt.Sprintf("Warning: %v", err))
+			} else {
+				e.Status.mu.Lock()
+				e.Status.SourceIndexSaved = true
+				e.Status.mu.Unlock()
+			}
+		}
+	}
+
+	// Apply file pattern filter if specified
+	if e.FilePattern != "" {
+		sourceFiles = e.applyFileFilter(sourceFiles)
+		e.logAnalysis(fmt.Sprintf("After filtering by pattern '%s': %d items remain", e.FilePattern, len(sourceFiles)))
+	}
+
+	if len(e.ExcludePatterns) > 0 {
+		sourceFiles = e.applyExcludeFilter(sourceFiles)
+		e.logAnalysis(fmt.Sprintf("After excluding %s: %d items remain",
+			strings.Join(e.ExcludePatterns, ", "), len(sourceFiles)))
+	}
+
+	if e.OwnerFilter.Active() {
+		sourceFiles = e.applyOwnerFilter(sourceFiles)
+	}
+
+	// Calculate total bytes to scan
+	var totalBytes int64
+	for _, fileInfo := range sourceFiles {
+		if !fileInfo.IsDir {
+			totalBytes += fileInfo.Size
+		}
+	}
+
+	e.Status.mu.Lock()
+	e.Status.TotalBytesToScan = totalBytes
+	e.Status.mu.Unlock()
+
+	// Update TotalFilesInSource so TUI can use it as fallback if polling missed final count
+	e.Status.mu.Lock()
+	e.Status.TotalFilesInSource = len(sourceFiles)
+	e.Status.ScannedFiles = len(sourceFiles) // Ensure final count is visible before phase change
 	e.Status.mu.Unlock()
+	e.notifyStatusUpdate()
+
+	e.logAnalysis(fmt.Sprintf("Source scan complete: %d items found, %s total",
+		len(sourceFiles), formatters.FormatBytes(totalBytes)))
+
+	return sourceFiles, nil
+}
+
+// scanSourceWithProgress walks the source, reporting progress as it goes.
+func (e *Engine) scanSourceWithProgress() (map[string]*fileops.FileInfo, error) {
+	//nolint:lll // Anonymous function with parameters as part of method call
+	sourceFiles, err := e.FileOps.ScanDirectoryWithProgress(e.SourcePath, func(path string, scannedCount int, totalCount int, fileSize int64) {
+		e.Status.mu.Lock()
+		e.Status.SourceScannedFiles = scannedCount
+		e.Status.SourceTotalFiles = totalCount
+		// Accumulate scanned bytes
+		e.Status.ScannedBytes += fileSize
+		e.Status.addAnalysisSample(e.TimeProvider.Now())
+
+		// Update phase when we transition from counting to scanning
+		if totalCount > 0 && e.Status.AnalysisPhase == phaseCountingSource {
+			e.Status.AnalysisPhase = "scanning_source"
+		}
+
+		e.Status.mu.Unlock()
+
+		e.notifyAnalysisProgress()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan source: %w", err)
+	}
+
+	return sourceFiles, nil
+}
+
+// scanTrees scans the source and destination in parallel. A failed scan stops the other, which
+// would only finish a walk whose result is thrown away, and its error is returned rather than
+// the other's cancellation.
+func (e *Engine) scanTrees() (sourceFiles, destFiles map[string]*fileops.FileInfo, err error) {
+	stopChan := make(chan struct{})
+
+	var stopOnce sync.Once
+
+	stop := func() { stopOnce.Do(func() { close(stopChan) }) }
+
+	// Both scans watch stopChan, so Cancel has to close it too
+	scansDone := make(chan struct{})
+
+	go func() {
+		select {
+		case <-e.cancelChan:
+			stop()
+		case <-scansDone:
+		}
+	}()
+
+	e.FileOps.CancelChan = stopChan
+
+	defer func() {
+		close(scansDone)
+		e.FileOps.CancelChan = e.cancelChan
+	}()
+
+	var sourceErr, destErr error
+	var wg sync.WaitGroup
+	wg.Add(2) //nolint:mnd // Two parallel scans
 
-	// Notify before accessing (may block on slow/remote filesystems)
-	e.logAnalysis("Accessing source...")
-	e.notifyStatusUpdate()
+	// Emit ScanStarted for both immediately
+	e.emit(ScanStarted{Target: "source"})
+	e.emit(ScanStarted{Target: "dest"})
 
-	// Scan source directory with progress
-	//nolint:lll // Anonymous function with parameters as part of method call
-	sourceFiles, err := e.FileOps.ScanDirectoryWithProgress(e.SourcePath, func(path string, scannedCount int, totalCount int, fileSize int64) {
-		e.Status.mu.Lock()
-		e.Status.SourceScannedFiles = scannedCount
-		e.Status.SourceTotalFiles = totalCount
-		// Accumulate scanned bytes
-		e.Status.ScannedBytes += fileSize
+	go func() {
+		defer wg.Done()
+		sourceFiles, sourceErr = e.scanSourceDirectory()
+		if sourceErr != nil {
+			stop()
+			return
+		}
 
-		// Calculate analysis rate if we have elapsed time
-		if !e.Status.AnalysisStartTime.IsZero() {
-			elapsed := e.TimeProvider.Now().Sub(e.Status.AnalysisStartTime).Seconds()
-			if elapsed > 0 {
-				e.Status.AnalysisRate = float64(scannedCount) / elapsed
-			}
+		e.emit(ScanComplete{Target: "source", Count: len(sourceFiles)})
+	}()
+
+	go func() {
+		defer wg.Done()
+		destFiles, destErr = e.scanDestinationDirectory()
+		if destErr != nil {
+			stop()
+			return
 		}
 
-		// Update phase when we transition from counting to scanning
-		if totalCount > 0 && e.Status.AnalysisPhase == phaseCountingSource {
-			e.Status.AnalysisPhase = "scanning_source"
+		e.emit(ScanComplete{Target: "dest", Count: len(destFiles)})
+	}()
+
+	wg.Wait()
+
+	for _, scanErr := range []error{sourceErr, destErr} {
+		if scanErr != nil && !errors.Is(scanErr, ErrCancelled) {
+			return nil, nil, scanErr
 		}
+	}
 
-		e.Status.mu.Unlock()
+	// Neither scan failed, so one stopping early means the analysis was cancelled
+	if sourceErr != nil || destErr != nil {
+		return nil, nil, ErrAnalysisCancelled
+	}
 
-		e.notifyStatusUpdate()
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to scan source: %w", err)
+	return sourceFiles, destFiles, nil
+}
+
+// snapshotSource points SourcePath at a read-only snapshot of the source, so every file is read
+// as it was when analysis started.
+func (e *Engine) snapshotSource() error {
+	if e.snapshot != nil {
+		return nil
 	}
 
-	// Apply file pattern filter if specified
-	if e.FilePattern != "" {
-		sourceFiles = e.applyFileFilter(sourceFiles)
-		e.logAnalysis(fmt.Sprintf("After filtering by pattern '%s': %d items remain", e.FilePattern, len(sourceFiles)))
+	if _, ok := e.FileOps.SourceFS.(*filesystem.RealFileSystem); !ok {
+		return fmt.Errorf("%w: only local sources can be snapshotted", ErrSnapshotNotSupported)
 	}
 
-	// Calculate total bytes to scan
-	var totalBytes int64
-	for _, fileInfo := range sourceFiles {
-		if !fileInfo.IsDir {
-			totalBytes += fileInfo.Size
-		}
+	snapshot, err := filesystem.CreateSnapshot(e.SourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot source: %w", err)
 	}
 
-	e.Status.mu.Lock()
-	e.Status.TotalBytesToScan = totalBytes
-	e.Status.mu.Unlock()
+	e.snapshot = snapshot
+	e.liveSourcePath = e.SourcePath
+	e.SourcePath = snapshot.Path
 
-	// Update TotalFilesInSource so TUI can use it as fallback if polling missed final count
 	e.Status.mu.Lock()
-	e.Status.TotalFilesInSource = len(sourceFiles)
-	e.Status.ScannedFiles = len(sourceFiles) // Ensure final count is visible before phase change
+	e.Status.SourceSnapshot = snapshot.Kind
 	e.Status.mu.Unlock()
-	e.notifyStatusUpdate()
 
-	e.logAnalysis(fmt.Sprintf("Source scan complete: %d items found, %s total",
-		len(sourceFiles), formatters.FormatBytes(totalBytes)))
+	e.logAnalysis(fmt.Sprintf("Reading source from a %s snapshot at %s", snapshot.Kind, snapshot.Path))
 
-	return sourceFiles, nil
+	return nil
+}
+
+// staleSourceIndexDir names the first indexed directory whose modtime changed since the scan,
+// or returns "" if none did.
+func (e *Engine) staleSourceIndexDir(index *sourceIndexFile) string {
+	for relPath, entry := range index.Files {
+		if !entry.IsDir {
+			continue
+		}
+
+		info, err := e.FileOps.Stat(filepath.Join(e.SourcePath, relPath))
+		if err != nil || !info.ModTime().Equal(entry.ModTime) {
+			return relPath + " changed since it was scanned"
+		}
+	}
+
+	return ""
 }
 
 // startAdaptiveScaling starts a goroutine that monitors performance and adjusts worker count
@@ -1866,9 +4572,11 @@ func (e *Engine) startAdaptiveScaling(done chan struct{}, jobs chan *FileToSync,
 
 		// Time-based scaling algorithm - continuously dynamic
 		state := &AdaptiveScalingState{}
-		maxWorkers := len(e.Status.FilesToSync) // Cap at total files
+		minWorkers, maxWorkers := e.adaptiveWorkerBounds()
+		seenPauses := atomic.LoadInt32(&e.pauses)
 
-		e.logToFile("HillClimbing: Starting with 1 worker, will adjust based on total system throughput")
+		e.logToFile("HillClimbing: starting, adjusting workers on total system throughput", "active", minWorkers,
+			"min_workers", minWorkers, "max_workers", maxWorkers)
 
 		for {
 			select {
@@ -1887,10 +4595,21 @@ func (e *Engine) startAdaptiveScaling(done chan struct{}, jobs chan *FileToSync,
 					continue
 				}
 
-				const evaluationInterval = 10 * time.Second
+				// A pause stops copies on purpose, so no evaluation may read it as a throughput
+				// collapse: none runs while paused, and the first after a resume waits a full
+				// interval of fresh samples
+				if e.IsPaused() {
+					continue
+				}
+
+				if pauses := atomic.LoadInt32(&e.pauses); pauses != seenPauses {
+					seenPauses = pauses
+					state.LastCheckTime = e.TimeProvider.Now()
+
+					continue
+				}
 
-				// Check if enough time has elapsed since last evaluation
-				if time.Since(state.LastCheckTime) >= evaluationInterval {
+				if e.evaluationDue(state) {
 					e.EvaluateAndScale(state, currentProcessedFiles, currentWorkers, currentBytes, maxWorkers, workerControl)
 				}
 			}
@@ -1898,18 +4617,56 @@ func (e *Engine) startAdaptiveScaling(done chan struct{}, jobs chan *FileToSync,
 	}()
 }
 
+// DefaultMaxAdaptiveWorkers is the most workers adaptive scaling uses when MaxAdaptiveWorkers
+// isn't set: one per CPU, and at least the default fixed worker count.
+func DefaultMaxAdaptiveWorkers() int {
+	return max(runtime.NumCPU(), config.DefaultMaxWorkers)
+}
+
+// adaptiveWorkerBounds returns the fewest and most workers adaptive scaling may use for this
+// sync. There's never more than one worker per file to copy.
+func (e *Engine) adaptiveWorkerBounds() (int, int) {
+	maxWorkers := e.MaxAdaptiveWorkers
+	if maxWorkers <= 0 {
+		maxWorkers = DefaultMaxAdaptiveWorkers()
+	}
+
+	maxWorkers = max(min(maxWorkers, len(e.Status.FilesToSync)), 1)
+
+	return e.minAdaptiveWorkers(maxWorkers), maxWorkers
+}
+
+// minAdaptiveWorkers returns the fewest workers adaptive scaling may scale down to, given the
+// most it may use.
+func (e *Engine) minAdaptiveWorkers(maxWorkers int) int {
+	return max(min(e.MinAdaptiveWorkers, maxWorkers), 1)
+}
+
+// evaluationDue reports whether evaluationInterval has passed since the last adaptive scaling
+// evaluation, by the engine's TimeProvider.
+func (e *Engine) evaluationDue(state *AdaptiveScalingState) bool {
+	return e.TimeProvider.Now().Sub(state.LastCheckTime) >= evaluationInterval
+}
+
 func (e *Engine) startFixedWorkers(numWorkers int, jobs chan *FileToSync, errors chan error) *sync.WaitGroup {
 	var wg sync.WaitGroup //nolint:varnamelen // wg is idiomatic for WaitGroup
 	for range numWorkers {
 		wg.Go(func() {
+			workerID := int(atomic.AddInt32(&e.nextWorkerID, 1))
+
 			for fileToSync := range jobs {
-				// Check for cancellation
-				select {
-				case <-e.cancelChan:
+				// Wait out a pause before starting the file, returning if cancelled meanwhile
+				if !e.waitWhilePaused() {
 					return
-				default:
 				}
 
+				if e.deadlineReached() {
+					e.deferFile(fileToSync)
+					continue
+				}
+
+				fileToSync.workerID = workerID
+
 				err := e.syncFile(fileToSync)
 				if err != nil {
 					// syncFile already updated status and error tracking
@@ -1925,6 +4682,66 @@ func (e *Engine) startFixedWorkers(numWorkers int, jobs chan *FileToSync, errors
 	return &wg
 }
 
+// startLoadSampler samples the load average in the background until done is closed,
+// so adaptive scaling backs off while the system is busy.
+func (e *Engine) startLoadSampler(done chan struct{}) {
+	if e.MaxLoadAverage <= 0 {
+		return
+	}
+
+	err := e.sampleLoad()
+	if errors.Is(err, fileops.ErrLoadAverageNotSupported) {
+		e.Status.mu.Lock()
+		e.Status.LoadAverageUnsupported = true
+		e.Status.mu.Unlock()
+
+		e.logToFile("Max load: this platform doesn't report a load average, ignoring it")
+
+		return
+	}
+
+	if err != nil {
+		e.logWarn(err.Error())
+	}
+
+	go func() {
+		ticker := e.TimeProvider.NewTicker(LoadSampleInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C():
+				err := e.sampleLoad()
+				if err != nil {
+					e.logWarn(err.Error())
+				}
+			}
+		}
+	}()
+}
+
+// startResumeTracking persists the sync plan so the sync can be resumed if interrupted
+func (e *Engine) startResumeTracking() {
+	if !e.Resume {
+		return
+	}
+
+	deletions := e.plannedResumeDeletions()
+
+	e.Status.mu.RLock()
+	e.resume = newResumeTracker(e.resumeStatePath(), e.originalSourcePath(), e.DestPath, e.Status.FilesToSync, deletions,
+		e.TimeProvider)
+	e.Status.mu.RUnlock()
+
+	err := e.resume.save()
+	if err != nil {
+		e.logWarn("Resume disabled", "error", err)
+		e.resume = nil
+	}
+}
+
 // startWorkerControl starts a goroutine that manages adding workers dynamically
 //
 //nolint:lll,varnamelen // Long function signature with channel parameters; wg is idiomatic for WaitGroup
@@ -1934,8 +4751,7 @@ func (e *Engine) startWorkerControl(wg *sync.WaitGroup, jobs <-chan *FileToSync,
 			if add {
 				wg.Add(1)
 
-				go e.worker(wg, jobs, errors)
-
+				// Count the worker before it starts, so it never sees itself as one too many
 				e.Status.mu.Lock()
 
 				newActive := atomic.AddInt32(&e.Status.ActiveWorkers, 1)
@@ -1944,6 +4760,9 @@ func (e *Engine) startWorkerControl(wg *sync.WaitGroup, jobs <-chan *FileToSync,
 				}
 
 				e.Status.mu.Unlock()
+
+				go e.worker(wg, jobs, errors)
+
 				e.notifyStatusUpdate()
 			}
 		}
@@ -1954,20 +4773,31 @@ func (e *Engine) startWorkerControl(wg *sync.WaitGroup, jobs <-chan *FileToSync,
 //
 //nolint:funlen // Complex adaptive scaling logic requires sequential steps
 func (e *Engine) syncAdaptive() error {
-	e.logToFile("Starting sync phase (adaptive mode)...")
+	e.logToFile("Starting sync phase", "phase", "sync", "adaptive", true)
 
 	// Perform deletions first (before copying)
 	if err := e.performDeletionsDuringSync(); err != nil {
 		return err
 	}
 
-	e.logToFile(fmt.Sprintf("Files to sync: %d", len(e.Status.FilesToSync)))
+	if err := e.createPlannedDirs(); err != nil {
+		return err
+	}
+
+	e.logToFile("Files to sync", "phase", "sync", "files", len(e.Status.FilesToSync))
 
 	e.Status.mu.Lock()
-	e.Status.StartTime = time.Now()
+	// Later converge passes keep the first pass's start, since the counters span every pass
+	if len(e.Status.ConvergePasses) <= 1 {
+		e.Status.StartTime = time.Now()
+	}
 	e.Status.AdaptiveMode = true
 	e.Status.mu.Unlock()
 
+	stopTiming := e.timePhase(PhaseCopy)
+
+	e.syncSmallFileBatches()
+
 	// Create channels for work distribution
 	jobs := make(chan *FileToSync, WorkerChannelBufferSize) // Buffered channel for pending work
 	errors := make(chan error, len(e.Status.FilesToSync))
@@ -1987,34 +4817,40 @@ func (e *Engine) syncAdaptive() error {
 	var wg sync.WaitGroup //nolint:varnamelen // wg is idiomatic for WaitGroup
 
 	workerControl := make(chan bool, WorkerChannelBufferSize) // true = add worker, false = remove worker
-	activeWorkers := 0
-
-	// Start with 1 worker for adaptive mode, or all workers for fixed mode
-	startWorkers := initialWorkers
-	if e.AdaptiveMode {
-		startWorkers = 1
-	}
-
-	for range startWorkers {
-		wg.Add(1)
 
-		activeWorkers++
+	// Start at the floor for adaptive mode, or all workers for fixed mode
+	activeWorkers := initialWorkers
+	minWorkers, maxWorkers := 0, 0
 
-		go e.worker(&wg, jobs, errors)
+	if e.AdaptiveMode {
+		minWorkers, maxWorkers = e.adaptiveWorkerBounds()
+		activeWorkers = minWorkers
 	}
 
+	// Set the active and desired counts before any worker starts, so none of them sees itself
+	// as one too many and leaves
 	e.Status.mu.Lock()
 	atomic.StoreInt32(&e.Status.ActiveWorkers, int32(activeWorkers)) //nolint:gosec // Small value, no overflow risk
 	e.Status.MaxWorkers = activeWorkers
+	e.Status.MinAdaptiveWorkers = minWorkers
+	e.Status.MaxAdaptiveWorkers = maxWorkers
 	e.Status.mu.Unlock()
-	e.notifyStatusUpdate()
 
 	// Initialize desired worker count for adaptive scaling
 	atomic.StoreInt32(&e.desiredWorkers, int32(activeWorkers)) //nolint:gosec // Bounded, no overflow
 	e.resizePools(activeWorkers)
 
+	for range activeWorkers {
+		wg.Add(1)
+
+		go e.worker(&wg, jobs, errors)
+	}
+
+	e.notifyStatusUpdate()
+
 	// Start background goroutines for adaptive scaling, worker control, and job distribution
 	e.startAdaptiveScaling(done, jobs, workerControl)
+	e.startLoadSampler(done)
 	e.startWorkerControl(&wg, jobs, errors, workerControl)
 	e.distributeJobs(jobs)
 
@@ -2029,98 +4865,269 @@ func (e *Engine) syncAdaptive() error {
 
 	// Wait for error collector to finish
 	errorsWg.Wait()
+	stopTiming()
+
+	// Record completion time
+	e.Status.mu.Lock()
+	e.Status.EndTime = time.Now()
+	e.Status.mu.Unlock()
+
+	// Check if we hit the error limit
+	e.Status.mu.RLock()
+	errorCount := e.abortErrorCount()
+	e.Status.mu.RUnlock()
+
+	if errorCount >= MaxErrorsBeforeAbort {
+		return fmt.Errorf("%w: too many errors (%d errors, limit is %d)", ErrSyncAborted, errorCount, MaxErrorsBeforeAbort)
+	}
+
+	// Mark finalization as complete
+	e.Status.mu.Lock()
+	e.Status.FinalizationPhase = phaseComplete
+	e.Status.mu.Unlock()
+	e.notifyStatusUpdate()
+
+	// Return combined error if any failures occurred (but didn't hit limit)
+	errorsMu.Lock()
+
+	errorCountFromChannel := len(*allErrors)
+
+	errorsMu.Unlock()
+
+	if errorCountFromChannel > 0 {
+		return fmt.Errorf("%w: %d (see error details in completion screen)", ErrFilesFailed, errorCountFromChannel)
+	}
+
+	return nil
+}
+
+func (e *Engine) syncFile(fileToSync *FileToSync) error {
+	srcPath := filepath.Join(e.SourcePath, fileToSync.RelativePath)
+	dstPath := filepath.Join(e.DestPath, fileToSync.destPath())
+
+	// Wait for a write slot in the destination directory (MaxWritesPerDir). A cancelled wait
+	// leaves the file unsynced; the worker stops at its next cancellation check.
+	release, ok := e.dirWrites.acquire(filepath.Dir(dstPath), e.cancelChan)
+	if !ok {
+		return nil
+	}
+	defer release()
+
+	e.Status.mu.Lock()
+	e.Status.CurrentFile = fileToSync.RelativePath
+	e.Status.CurrentFiles = append(e.Status.CurrentFiles, fileToSync.RelativePath)
+	fileToSync.Status = fileStatusOpening
+	e.Status.mu.Unlock()
+	e.notifyStatusUpdate()
+
+	// Verbose instrumentation: log when file enters opening state
+	e.LogVerbose("File started", "file", fileToSync.RelativePath, "size", fileToSync.Size, "worker", fileToSync.workerID)
+
+	e.manifest.markCopying(fileToSync)
+
+	if e.caIndex != nil {
+		return e.syncFileToCAStore(fileToSync, srcPath)
+	}
+
+	if fileToSync.Symlink {
+		return e.syncSymlink(fileToSync, srcPath, dstPath)
+	}
+
+	// Try hash optimization for Content mode
+	optimized, err := e.tryHashOptimization(fileToSync, srcPath, dstPath)
+	if err != nil {
+		return err
+	}
+
+	if optimized {
+		return nil
+	}
+
+	// Create callback to mark file as finalizing when data transfer completes
+	onDataComplete := func() {
+		e.Status.mu.Lock()
+		fileToSync.Status = fileStatusFinalizing
+		e.Status.mu.Unlock()
+		e.notifyStatusUpdate()
+	}
+
+	// Copy the file with timing stats, retrying transient failures (MaxRetries)
+	stats, err := e.copyWithRetries(fileToSync, srcPath, dstPath, onDataComplete)
+
+	// The copy reads to the end of the file, so one that changed size while copying is counted
+	// at the size copied
+	if err == nil && stats != nil && !stats.Renamed && stats.BytesCopied != fileToSync.Size {
+		e.resizeFileToSync(fileToSync, stats.BytesCopied)
+	}
+
+	// A move has already checked its copy, as the source is gone once it returns
+	if err == nil && e.VerifyAfterCopy && !e.Move {
+		err = e.verifyCopy(srcPath, dstPath, stats.SourceHash)
+	}
+
+	// Update bottleneck detection and handle copy result
+	return e.handleCopyResult(fileToSync, stats, err)
+}
+
+// resizeFileToSync sets a file's Size, moving Status.TotalBytes by the difference.
+func (e *Engine) resizeFileToSync(fileToSync *FileToSync, size int64) {
+	e.Status.mu.Lock()
+	analyzedSize := fileToSync.Size
+	fileToSync.Size = size
+	e.Status.TotalBytes += size - analyzedSize
+	e.Status.mu.Unlock()
+
+	e.logToFile("Source file changed size since analysis", "file", fileToSync.RelativePath,
+		"analyzed_size", analyzedSize, "size", size)
+}
+
+// verifyCopy hashes a just-written destination file and compares it with sourceHash, hashing
+// the source only if the copy didn't (a reflink). A copy that doesn't match is removed, so the
+// next run copies it again rather than trusting its size and modtime.
+func (e *Engine) verifyCopy(srcPath, dstPath, sourceHash string) error {
+	var err error
+
+	if sourceHash == "" {
+		sourceHash, err = e.FileOps.ComputeSourceHash(srcPath, e.cancelChan)
+		if err != nil {
+			return fmt.Errorf("failed to hash %s for verification: %w", srcPath, err)
+		}
+	}
+
+	destHash, err := e.FileOps.ComputeDestHash(dstPath, e.cancelChan)
+	if err != nil {
+		return fmt.Errorf("failed to hash %s for verification: %w", dstPath, err)
+	}
+
+	if destHash != sourceHash {
+		_ = e.FileOps.RemoveFromDest(dstPath)
+
+		return fmt.Errorf("%w: %s (source %s, destination %s)", ErrVerifyFailed, dstPath, sourceHash, destHash)
+	}
 
-	// Record completion time
 	e.Status.mu.Lock()
-	e.Status.EndTime = time.Now()
+	e.Status.VerifiedFiles++
 	e.Status.mu.Unlock()
 
-	// Check if we hit the error limit
-	e.Status.mu.RLock()
-	errorCount := len(e.Status.Errors)
-	e.Status.mu.RUnlock()
+	return nil
+}
 
-	if errorCount >= MaxErrorsBeforeAbort {
-		return fmt.Errorf("%w: too many errors (%d errors, limit is %d)", ErrSyncAborted, errorCount, MaxErrorsBeforeAbort)
+// syncSymlink recreates a source link at the destination, pointing at the same target.
+func (e *Engine) syncSymlink(fileToSync *FileToSync, srcPath, dstPath string) error {
+	err := e.FileOps.CopySymlink(srcPath, dstPath)
+	if err != nil {
+		return e.handleCopyResult(fileToSync, nil, err)
 	}
 
-	// Mark finalization as complete
 	e.Status.mu.Lock()
-	e.Status.FinalizationPhase = phaseComplete
+	e.Status.PreservedSymlinks++
 	e.Status.mu.Unlock()
-	e.notifyStatusUpdate()
 
-	// Return combined error if any failures occurred (but didn't hit limit)
-	errorsMu.Lock()
+	e.markFileCompleteWithoutCopy(fileToSync)
 
-	errorCountFromChannel := len(*allErrors)
+	return nil
+}
 
-	errorsMu.Unlock()
+// syncFileToCAStore stores a file's content under its hash and points the file's index entry
+// at it. The content is hashed as it streams into a temp object, which is then renamed to its
+// hash, so a file that changes mid-copy is still stored under the hash of what was stored.
+func (e *Engine) syncFileToCAStore(fileToSync *FileToSync, srcPath string) error {
+	incomingPath := filepath.Join(e.DestPath, caIncomingPath(fileToSync.RelativePath))
 
-	if errorCountFromChannel > 0 {
-		return fmt.Errorf("%w: %d (see error details in completion screen)", ErrFilesFailed, errorCountFromChannel)
+	onDataComplete := func() {
+		e.Status.mu.Lock()
+		fileToSync.Status = fileStatusFinalizing
+		e.Status.mu.Unlock()
+		e.notifyStatusUpdate()
 	}
 
-	return nil
-}
+	stats, err := e.copyWithRetries(fileToSync, srcPath, incomingPath, onDataComplete)
 
-func (e *Engine) syncFile(fileToSync *FileToSync) error {
-	srcPath := filepath.Join(e.SourcePath, fileToSync.RelativePath)
-	dstPath := filepath.Join(e.DestPath, fileToSync.RelativePath)
+	var hash string
+	if err == nil {
+		hash = stats.SourceHash
+		if e.VerifyAfterCopy {
+			err = e.verifyCopy(srcPath, incomingPath, hash)
+		}
+	}
 
-	e.Status.mu.Lock()
-	e.Status.CurrentFile = fileToSync.RelativePath
-	e.Status.CurrentFiles = append(e.Status.CurrentFiles, fileToSync.RelativePath)
-	fileToSync.Status = fileStatusOpening
-	e.Status.mu.Unlock()
-	e.notifyStatusUpdate()
+	// A reflink isn't hashed as it's made; the clone can't change under us, so hash it instead
+	if err == nil && hash == "" {
+		hash, err = e.FileOps.ComputeDestHash(incomingPath, e.cancelChan)
+	}
 
-	// Verbose instrumentation: log when file enters opening state
-	e.LogVerbose(fmt.Sprintf("[PROGRESS] FILE_START: %s (size=%d)", fileToSync.RelativePath, fileToSync.Size))
+	if err == nil {
+		err = e.storeCAObject(fileToSync, incomingPath, hash)
+	}
 
-	// Try hash optimization for Content mode
-	optimized, err := e.tryHashOptimization(fileToSync, srcPath, dstPath)
 	if err != nil {
-		return err
+		_ = e.FileOps.RemoveFromDest(incomingPath)
 	}
 
-	if optimized {
-		return nil
-	}
+	return e.handleCopyResult(fileToSync, stats, err)
+}
 
-	// Copy the file with timing stats (pass cancel channel for mid-copy cancellation)
-	progressCallback := e.createProgressCallback(fileToSync)
+// storeCAObject renames a complete temp object to its hash, or discards it if that content is
+// already stored, and records it in the index. Objects only ever appear by this rename, so one
+// that exists holds the content its name says.
+func (e *Engine) storeCAObject(fileToSync *FileToSync, incomingPath, hash string) error {
+	objectPath := filepath.Join(e.DestPath, caObjectPath(hash))
+
+	// Identical files synced concurrently wait here, then find the object stored
+	unlock := e.caIndex.lockObject(hash)
+	defer unlock()
+
+	_, err := e.FileOps.StatDest(objectPath)
+	if err == nil {
+		err = e.FileOps.RemoveFromDest(incomingPath)
+		if err != nil {
+			return fmt.Errorf("failed to store %s: %w", fileToSync.RelativePath, err)
+		}
 
-	// Create callback to mark file as finalizing when data transfer completes
-	onDataComplete := func() {
 		e.Status.mu.Lock()
-		fileToSync.Status = fileStatusFinalizing
+		e.Status.DedupedFiles++
 		e.Status.mu.Unlock()
-		e.notifyStatusUpdate()
+
+		e.LogVerbose("Already in the content-addressed store", "file", fileToSync.RelativePath, "hash", hash)
+	} else {
+		err = e.FileOps.RenameDest(incomingPath, objectPath)
+		if err != nil {
+			return fmt.Errorf("failed to store %s: %w", fileToSync.RelativePath, err)
+		}
 	}
 
-	stats, err := e.FileOps.CopyFileWithStats(srcPath, dstPath, progressCallback, e.cancelChan, onDataComplete)
+	e.caIndex.record(fileToSync.RelativePath, hash)
 
-	// Update bottleneck detection and handle copy result
-	return e.handleCopyResult(fileToSync, stats, err)
+	return nil
 }
 
 // syncFixed uses a fixed number of workers
 func (e *Engine) syncFixed() error {
-	e.logToFile("Starting sync phase...")
+	e.logToFile("Starting sync phase", "phase", "sync", "adaptive", false)
 
 	// Perform deletions first (before copying)
 	if err := e.performDeletionsDuringSync(); err != nil {
 		return err
 	}
 
-	e.logToFile(fmt.Sprintf("Files to sync: %d", len(e.Status.FilesToSync)))
+	if err := e.createPlannedDirs(); err != nil {
+		return err
+	}
+
+	e.logToFile("Files to sync", "phase", "sync", "files", len(e.Status.FilesToSync))
 
 	e.Status.mu.Lock()
-	e.Status.StartTime = time.Now()
+	// Later converge passes keep the first pass's start, since the counters span every pass
+	if len(e.Status.ConvergePasses) <= 1 {
+		e.Status.StartTime = time.Now()
+	}
 	e.Status.AdaptiveMode = false
 	e.Status.mu.Unlock()
 
+	stopTiming := e.timePhase(PhaseCopy)
+
+	e.syncSmallFileBatches()
+
 	// Create channels for work distribution
 	jobs := make(chan *FileToSync, len(e.Status.FilesToSync))
 	errors := make(chan error, len(e.Status.FilesToSync))
@@ -2150,6 +5157,7 @@ func (e *Engine) syncFixed() error {
 
 	// Wait for error collector to finish
 	errorsWg.Wait()
+	stopTiming()
 
 	// Finalize sync phase
 	e.finalizeSyncPhase()
@@ -2173,27 +5181,142 @@ func (e *Engine) syncFixed() error {
 	return nil
 }
 
+// syncPass applies the analyzed plan: permission updates, deletions and copies.
+func (e *Engine) syncPass() error {
+	e.removeStaleTempFiles()
+
+	// Metadata-only updates need no workers, so apply them before copying
+	err := e.applyPermissionUpdates()
+	if err != nil {
+		return err
+	}
+
+	e.startResumeTracking()
+
+	if e.AdaptiveMode {
+		err = e.syncAdaptive()
+	} else {
+		err = e.syncFixed()
+	}
+
+	e.Status.mu.RLock()
+	deferred := e.Status.DeferredFiles
+	e.Status.mu.RUnlock()
+
+	if err == nil && deferred > 0 {
+		err = fmt.Errorf("%w: %d files deferred", ErrDeadlineReached, deferred)
+	}
+
+	e.finishResumeTracking(err)
+	e.finishManifest(err)
+
+	return err
+}
+
+// syncSmallFileBatches sends small files in tar batches when BatchSmallFiles is set and the
+// destination can unpack them. Files in a failed batch stay pending for the workers to copy.
+func (e *Engine) syncSmallFileBatches() {
+	// Batches aren't verified or moved file by file, so VerifyAfterCopy and Move send every file
+	// on its own
+	if !e.BatchSmallFiles || e.caIndex != nil || e.VerifyAfterCopy || e.Move {
+		return
+	}
+
+	if _, ok := e.FileOps.DestFS.(filesystem.TarExtractor); !ok {
+		e.logToFile("Batching skipped: destination cannot unpack tar streams")
+		return
+	}
+
+	threshold := e.BatchThreshold
+	if threshold <= 0 {
+		threshold = DefaultBatchThreshold
+	}
+
+	for _, batch := range planBatches(e.Status.FilesToSync, threshold) {
+		if e.checkCancellation() != nil || e.deadlineReached() {
+			return
+		}
+
+		files := make([]fileops.BatchFile, len(batch))
+		for i, fileToSync := range batch {
+			files[i] = fileops.BatchFile{
+				Src:  filepath.Join(e.SourcePath, fileToSync.RelativePath),
+				Name: fileToSync.destPath(),
+			}
+			e.manifest.markCopying(fileToSync)
+		}
+
+		stats, err := e.FileOps.CopyFilesAsTar(files, e.DestPath, e.cancelChan)
+		if err != nil {
+			e.logWarn("Batch failed, copying its files individually", "files", len(batch), "error", err)
+			continue
+		}
+
+		e.Status.mu.Lock()
+		e.updateBottleneckDetection(stats)
+		e.Status.Batches++
+		e.Status.BatchedFiles += len(batch)
+
+		for _, fileToSync := range batch {
+			fileToSync.Transferred = fileToSync.Size
+			atomic.AddInt64(&e.Status.TransferredBytes, fileToSync.Size)
+			e.handleCopySuccess(fileToSync)
+		}
+		e.Status.mu.Unlock()
+
+		for _, fileToSync := range batch {
+			e.resume.markComplete(fileToSync.RelativePath)
+			e.manifest.markComplete(fileToSync)
+		}
+
+		e.notifyStatusUpdate()
+	}
+}
+
+// timePhase starts timing a phase and returns a func that adds the elapsed time to its total.
+func (e *Engine) timePhase(phase string) func() {
+	start := e.TimeProvider.Now()
+
+	return func() {
+		elapsed := e.TimeProvider.Now().Sub(start)
+
+		e.Status.mu.Lock()
+		if e.Status.PhaseDurations == nil {
+			e.Status.PhaseDurations = make(map[string]time.Duration)
+		}
+
+		e.Status.PhaseDurations[phase] += elapsed
+		e.Status.mu.Unlock()
+	}
+}
+
 // syncFile synchronizes a single file
 // tryHashOptimization checks if hashes match in Content mode and just updates modtime if so.
 // Returns true if optimization was applied (no copy needed), false if copy is needed.
 func (e *Engine) tryHashOptimization(fileToSync *FileToSync, srcPath, dstPath string) (bool, error) {
-	// Only applicable in Content mode
-	if e.ChangeType != config.Content {
+	// Only applicable in Content mode, and never when repairing: the hash already differed. An
+	// xattr-compared file is copied even with matching content, so the attribute comes along.
+	if e.ChangeType != config.Content || e.RepairMode || e.XattrCompare != "" {
 		return false, nil
 	}
 
 	// Check if destination file exists
 	_, err := e.FileOps.Stat(dstPath)
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, fs.ErrNotExist) {
 			return false, nil // Destination doesn't exist, need to copy
 		}
 
 		return false, fmt.Errorf("failed to stat destination file: %w", err)
 	}
 
+	srcInfo, err := e.FileOps.Stat(srcPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat source file: %w", err)
+	}
+
 	// Both files exist, compute hashes
-	srcHash, err := e.FileOps.ComputeFileHash(srcPath)
+	srcHash, err := e.sourceHash(fileToSync.RelativePath, srcInfo.Size(), srcInfo.ModTime())
 	if err != nil {
 		return false, fmt.Errorf("failed to compute source hash: %w", err)
 	}
@@ -2212,18 +5335,21 @@ func (e *Engine) tryHashOptimization(fileToSync *FileToSync, srcPath, dstPath st
 	// Hashes match - just update modtime
 	e.logAnalysis(fmt.Sprintf("  ✓ Hashes match for %s - updating modtime only", fileToSync.RelativePath))
 
-	// Get source modtime
-	srcInfo, err := e.FileOps.Stat(srcPath)
-	if err != nil {
-		return false, fmt.Errorf("failed to stat source file: %w", err)
-	}
-
 	// Update destination modtime
 	err = e.FileOps.Chtimes(dstPath, srcInfo.ModTime(), srcInfo.ModTime())
 	if err != nil {
 		return false, fmt.Errorf("failed to update modtime: %w", err)
 	}
 
+	if e.PreservePermissions {
+		err = e.FileOps.ChmodDest(dstPath, srcInfo.Mode().Perm())
+		if err != nil {
+			return false, fmt.Errorf("failed to update permissions: %w", err)
+		}
+	}
+
+	e.itemize(itemizeTimeOnly, fileToSync.destPath())
+
 	// Mark file as complete without copying
 	e.markFileCompleteWithoutCopy(fileToSync)
 
@@ -2235,7 +5361,12 @@ func (e *Engine) tryHashOptimization(fileToSync *FileToSync, srcPath, dstPath st
 //
 //nolint:funlen // Optimization logic includes multiple validation and counting steps
 func (e *Engine) tryMonotonicCountOptimization() (bool, error) {
-	if e.ChangeType != config.MonotonicCount {
+	// Matching counts say nothing about content, permissions, owners or attributes, so repair,
+	// permission, baseline, owner and xattr checks always compare. Nor do they when kept orphans
+	// or a trash inside the destination can make up for missing files.
+	_, trashInDest := e.trashInDest()
+	if e.ChangeType != config.MonotonicCount || e.RepairMode || e.PreservePermissions || e.BaselineDir != "" ||
+		e.OwnerFilter.Active() || e.XattrCompare != "" || !e.deletesOrphans() || trashInDest {
 		return false, nil
 	}
 
@@ -2252,6 +5383,7 @@ func (e *Engine) tryMonotonicCountOptimization() (bool, error) {
 	e.logAnalysis("Accessing source...")
 	e.notifyStatusUpdate()
 
+	stopTiming := e.timePhase(PhaseScanSource)
 	sourceCount, err := e.FileOps.CountFilesWithProgress(e.SourcePath, func(path string, count int) {
 		e.Status.mu.Lock()
 		e.Status.ScannedFiles = count
@@ -2263,83 +5395,244 @@ func (e *Engine) tryMonotonicCountOptimization() (bool, error) {
 			e.logAnalysis(fmt.Sprintf("Counting source files: %d so far...", count))
 		}
 
-		e.notifyStatusUpdate()
+		e.notifyAnalysisProgress()
 	})
+	stopTiming()
+
 	if err != nil {
 		return false, fmt.Errorf("failed to count source files: %w", err)
 	}
 
-	e.logAnalysis(fmt.Sprintf("Source file count: %d", sourceCount))
-	e.emit(ScanComplete{Target: "source", Count: sourceCount})
+	e.logAnalysis(fmt.Sprintf("Source file count: %d", sourceCount))
+	e.emit(ScanComplete{Target: "source", Count: sourceCount})
+
+	// Store source count immediately so TUI can display it
+	e.Status.mu.Lock()
+	e.Status.TotalFilesInSource = sourceCount
+	e.Status.mu.Unlock()
+
+	// Count destination files
+	e.Status.mu.Lock()
+	e.Status.AnalysisPhase = phaseCountingDest
+	e.Status.ScannedFiles = 0
+	e.Status.DestScannedFiles = 0
+	e.Status.DestTotalFiles = 0
+	e.Status.mu.Unlock()
+
+	e.emit(ScanStarted{Target: "dest"})
+	e.logAnalysis("Accessing destination...")
+	e.notifyStatusUpdate()
+
+	stopTiming = e.timePhase(PhaseScanDest)
+	destCount, err := e.FileOps.CountDestFilesWithProgress(e.DestPath, func(path string, count int) {
+		e.Status.mu.Lock()
+		e.Status.ScannedFiles = count
+		e.Status.DestScannedFiles = count // Update dest-specific counter for TUI
+		e.Status.CurrentPath = path
+		e.Status.mu.Unlock()
+
+		if count%10 == 0 {
+			e.logAnalysis(fmt.Sprintf("Counting dest files: %d so far...", count))
+		}
+
+		e.notifyAnalysisProgress()
+	})
+	stopTiming()
+
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, fmt.Errorf("failed to count destination files: %w", err)
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		destCount = 0
+	}
+
+	// The mount marker was checked to exist and has no source counterpart
+	if e.MountMarker != "" && destCount > 0 {
+		destCount--
+	}
+
+	e.logAnalysis(fmt.Sprintf("Destination file count: %d", destCount))
+	e.emit(ScanComplete{Target: "dest", Count: destCount})
+
+	// Store dest count immediately so TUI can display it
+	e.Status.mu.Lock()
+	e.Status.TotalFilesInDest = destCount
+	e.Status.mu.Unlock()
+
+	// Update status with counts (TotalFilesInSource was already set above)
+	e.Status.mu.Lock()
+	e.Status.TotalFilesInSource = sourceCount
+	e.Status.mu.Unlock()
+
+	// If counts match, assume everything is fine (monotonic-count optimization)
+	if sourceCount == destCount {
+		e.logAnalysis("✓ File counts match - assuming directories are in sync (monotonic-count mode)")
+		e.Status.mu.Lock()
+		e.Status.AnalysisPhase = phaseComplete
+		e.Status.TotalFiles = 0
+		e.Status.TotalBytes = 0
+		e.Status.FilesToSync = []*FileToSync{}
+		e.Status.mu.Unlock()
+		e.notifyStatusUpdate()
+
+		return true, nil
+	}
+
+	e.logAnalysis(fmt.Sprintf("✗ File counts differ (%d vs %d) - proceeding with full scan", sourceCount, destCount))
+
+	return false, nil
+}
+
+// tryResume loads the plan of an interrupted sync for this source/dest, if one was saved.
+// Files the saved plan marks as done are verified in the destination before being skipped.
+// Returns true if the saved plan was loaded, false if a full analysis is needed.
+func (e *Engine) tryResume() (bool, error) {
+	if !e.Resume {
+		return false, nil
+	}
+
+	e.resumedOrphans = nil
+
+	state, err := loadResumeState(e.resumeStatePath())
+	if err != nil {
+		return false, err
+	}
+
+	if state == nil || state.SourcePath != e.originalSourcePath() || state.DestPath != e.DestPath {
+		e.logAnalysis("No interrupted sync to resume - running full analysis")
+		return false, nil
+	}
+
+	e.logAnalysis(fmt.Sprintf("Resuming interrupted sync: %d of %d planned files were done",
+		state.LastCompleted+1, len(state.Files)))
+
+	filesToSync := make([]*FileToSync, 0, len(state.Files))
+	skippedFiles := 0
+
+	var planBytes, skippedBytes, bytesToCopy int64
+
+	for i, file := range state.Files {
+		if i%100 == 0 {
+			err = e.checkCancellation()
+			if err != nil {
+				return false, err
+			}
+		}
+
+		planBytes += file.Size
+
+		if i <= state.LastCompleted && e.isResumeFileDone(file) {
+			skippedFiles++
+			skippedBytes += file.Size
+
+			continue
+		}
+
+		filesToSync = append(filesToSync, &FileToSync{
+			RelativePath:     file.RelativePath,
+			DestRelativePath: file.DestRelativePath,
+			Action:           file.Action,
+			Size:             file.Size,
+			Status:           "pending",
+		})
+		bytesToCopy += file.Size
+	}
+
+	e.Status.mu.Lock()
+	e.Status.FilesToSync = filesToSync
+	e.Status.TotalBytes = bytesToCopy
+	e.Status.TotalFilesInSource = len(state.Files)
+	e.Status.TotalBytesInSource = planBytes
+	e.Status.AlreadySyncedFiles = skippedFiles
+	e.Status.AlreadySyncedBytes = skippedBytes
+	e.Status.ResumeSkippedFiles = skippedFiles
+	e.Status.mu.Unlock()
+
+	e.logAnalysis(fmt.Sprintf("Skipped %d files already done, %d left to copy", skippedFiles, len(filesToSync)))
+
+	err = e.resumeDeletions(state.Deletions)
+	if err != nil {
+		return false, err
+	}
+
+	e.finalizeAnalysis()
+	e.emitSyncPlan()
+
+	return true, nil
+}
+
+// resumeDeletions plans the saved plan's deletions that are still at the destination and still
+// missing from the source; those gone were deleted before the interruption, and those back in
+// the source were restored since.
+func (e *Engine) resumeDeletions(deletions []resumeDeletion) error {
+	if len(deletions) == 0 || !e.deletesOrphans() {
+		return nil
+	}
+
+	orphans := make(map[string]*fileops.FileInfo, len(deletions))
 
-	// Store source count immediately so TUI can display it
-	e.Status.mu.Lock()
-	e.Status.TotalFilesInSource = sourceCount
-	e.Status.mu.Unlock()
+	for i, deletion := range deletions {
+		if i%100 == 0 {
+			err := e.checkCancellation()
+			if err != nil {
+				return err
+			}
+		}
 
-	// Count destination files
-	e.Status.mu.Lock()
-	e.Status.AnalysisPhase = phaseCountingDest
-	e.Status.ScannedFiles = 0
-	e.Status.DestScannedFiles = 0
-	e.Status.DestTotalFiles = 0
-	e.Status.mu.Unlock()
+		info, err := e.FileOps.StatDest(filepath.Join(e.DestPath, deletion.RelativePath))
+		if err != nil {
+			continue
+		}
 
-	e.emit(ScanStarted{Target: "dest"})
-	e.logAnalysis("Accessing destination...")
-	e.notifyStatusUpdate()
+		// Anything but a source that's certainly missing it keeps the destination's copy
+		_, err = e.FileOps.Stat(filepath.Join(e.SourcePath, deletion.RelativePath))
+		if err == nil {
+			e.logAnalysis(fmt.Sprintf("  → %s is in the source again - keeping it", deletion.RelativePath))
 
-	destCount, err := e.FileOps.CountDestFilesWithProgress(e.DestPath, func(path string, count int) {
-		e.Status.mu.Lock()
-		e.Status.ScannedFiles = count
-		e.Status.DestScannedFiles = count // Update dest-specific counter for TUI
-		e.Status.CurrentPath = path
-		e.Status.mu.Unlock()
+			continue
+		}
 
-		if count%10 == 0 {
-			e.logAnalysis(fmt.Sprintf("Counting dest files: %d so far...", count))
+		if !errors.Is(err, os.ErrNotExist) {
+			e.logWarn("Failed to check planned deletion in source", "file", deletion.RelativePath, "error", err)
+
+			continue
 		}
 
-		e.notifyStatusUpdate()
-	})
-	if err != nil && !os.IsNotExist(err) {
-		return false, fmt.Errorf("failed to count destination files: %w", err)
+		orphans[deletion.RelativePath] = &fileops.FileInfo{
+			RelativePath: deletion.RelativePath,
+			Size:         info.Size(),
+			ModTime:      info.ModTime(),
+			IsDir:        info.IsDir(),
+		}
 	}
 
-	if os.IsNotExist(err) {
-		destCount = 0
-	}
+	e.logAnalysis(fmt.Sprintf("%d of %d planned deletions left to do", len(orphans), len(deletions)))
 
-	e.logAnalysis(fmt.Sprintf("Destination file count: %d", destCount))
-	e.emit(ScanComplete{Target: "dest", Count: destCount})
+	e.resumedOrphans = orphans
+	e.countOrphanedItemsForPlan(map[string]*fileops.FileInfo{}, orphans)
 
-	// Store dest count immediately so TUI can display it
-	e.Status.mu.Lock()
-	e.Status.TotalFilesInDest = destCount
-	e.Status.mu.Unlock()
+	return nil
+}
 
-	// Update status with counts (TotalFilesInSource was already set above)
-	e.Status.mu.Lock()
-	e.Status.TotalFilesInSource = sourceCount
-	e.Status.mu.Unlock()
+// plannedResumeDeletions lists the orphans the plan deletes, for the resume state.
+func (e *Engine) plannedResumeDeletions() []resumeDeletion {
+	sourceFiles, destFiles := e.orphanListings()
+	if destFiles == nil || !e.deletesOrphans() {
+		return nil
+	}
 
-	// If counts match, assume everything is fine (monotonic-count optimization)
-	if sourceCount == destCount {
-		e.logAnalysis("✓ File counts match - assuming directories are in sync (monotonic-count mode)")
-		e.Status.mu.Lock()
-		e.Status.AnalysisPhase = phaseComplete
-		e.Status.TotalFiles = 0
-		e.Status.TotalBytes = 0
-		e.Status.FilesToSync = []*FileToSync{}
-		e.Status.mu.Unlock()
-		e.notifyStatusUpdate()
+	var deletions []resumeDeletion
 
-		return true, nil
+	for relPath, dstFile := range destFiles {
+		if _, exists := sourceFiles[relPath]; !exists {
+			deletions = append(deletions, resumeDeletion{RelativePath: relPath, Size: dstFile.Size, IsDir: dstFile.IsDir})
+		}
 	}
 
-	e.logAnalysis(fmt.Sprintf("✗ File counts differ (%d vs %d) - proceeding with full scan", sourceCount, destCount))
+	sort.Slice(deletions, func(i, j int) bool { return deletions[i].RelativePath < deletions[j].RelativePath })
 
-	return false, nil
+	return deletions
 }
 
 // handleCopyResult processes the result of a file copy operation
@@ -2379,7 +5672,8 @@ func (e *Engine) updateDeletionStatus(checkedCount int, relPath string) {
 	e.Status.mu.Unlock()
 }
 
-func (e *Engine) updateStatusForFile(relPath string, srcFile *fileops.FileInfo, needsSync bool, comparedCount int) {
+//nolint:lll // Signature carries the per-file comparison result
+func (e *Engine) updateStatusForFile(relPath string, srcFile *fileops.FileInfo, action FileAction, itemize string, needsSync bool, comparedCount int) {
 	e.Status.mu.Lock()
 	defer e.Status.mu.Unlock()
 
@@ -2389,9 +5683,14 @@ func (e *Engine) updateStatusForFile(relPath string, srcFile *fileops.FileInfo,
 
 	if needsSync {
 		fileToSync := &FileToSync{
-			RelativePath: relPath,
-			Size:         srcFile.Size,
-			Status:       "pending",
+			RelativePath:     relPath,
+			DestRelativePath: e.destNames[relPath],
+			Action:           action,
+			Size:             srcFile.Size,
+			ModTime:          srcFile.ModTime,
+			Status:           "pending",
+			Itemize:          itemize,
+			Symlink:          e.preservesLink(srcFile),
 		}
 		e.Status.FilesToSync = append(e.Status.FilesToSync, fileToSync)
 		e.Status.TotalBytes += srcFile.Size
@@ -2402,15 +5701,115 @@ func (e *Engine) updateStatusForFile(relPath string, srcFile *fileops.FileInfo,
 	}
 
 	// Update progress
+	if !srcFile.IsDir {
+		e.Status.ScannedBytes += srcFile.Size
+	}
+
+	e.Status.addAnalysisSample(e.TimeProvider.Now())
 	e.Status.ScannedFiles = comparedCount
 	e.Status.CurrentPath = relPath
 }
 
+// verifyFileForRepair compares source and destination hashes, honoring cancellation mid-file.
+// Returns true if the destination copy is corrupted and needs to be re-copied.
+func (e *Engine) verifyFileForRepair(relPath, dstRelPath string, comparedCount int) (bool, error) {
+	err := e.checkCancellation()
+	if err != nil {
+		return false, err
+	}
+
+	srcHash, err := e.FileOps.ComputeSourceHash(filepath.Join(e.SourcePath, relPath), e.cancelChan)
+	if errors.Is(err, ErrCancelled) {
+		return false, ErrAnalysisCancelled
+	}
+
+	if err != nil {
+		e.logAnalysis(fmt.Sprintf("  ⚠ Failed to compute source hash for %s: %v", relPath, err))
+		return false, nil // Can't repair from an unreadable source
+	}
+
+	dstHash, err := e.FileOps.ComputeDestHash(filepath.Join(e.DestPath, dstRelPath), e.cancelChan)
+	if errors.Is(err, ErrCancelled) {
+		return false, ErrAnalysisCancelled
+	}
+
+	if err != nil {
+		e.logAnalysis(fmt.Sprintf("  → Unreadable in destination, will repair: %s (%v)", relPath, err))
+		return true, nil
+	}
+
+	if srcHash != dstHash {
+		e.logAnalysis("  → Corrupted, will repair: " + relPath)
+		return true, nil
+	}
+
+	if comparedCount < LogSampleSize {
+		e.logAnalysis("  ✓ Verified: " + relPath)
+	}
+
+	return false, nil
+}
+
+// verifyTransferTotals checks the transferred byte counter against the plan: completed files
+// should account for exactly their size, and files that failed or were cancelled for the bytes
+// they got through. A mismatch means a copy was truncated or the accounting has a bug.
+func (e *Engine) verifyTransferTotals() {
+	e.Status.mu.Lock()
+
+	var expected int64
+
+	for _, fileToSync := range e.Status.FilesToSync {
+		if fileToSync.Status == fileStatusComplete {
+			expected += fileToSync.Size
+		} else {
+			expected += fileToSync.Transferred
+		}
+	}
+
+	actual := atomic.LoadInt64(&e.Status.TransferredBytes)
+	e.Status.ExpectedTransferredBytes = expected
+	e.Status.TotalsMismatch = actual != expected
+	e.Status.mu.Unlock()
+
+	if actual != expected {
+		e.logWarn("Transferred bytes don't match what the completed plan accounts for",
+			"bytes", actual, "expected", expected, "difference", actual-expected)
+	}
+}
+
 // worker is a worker goroutine that processes files from the jobs channel
 func (e *Engine) worker(wg *sync.WaitGroup, jobs <-chan *FileToSync, errors chan<- error) {
 	defer wg.Done()
 
-	for fileToSync := range jobs {
+	// The id stays with the worker for every file it copies, so its rate can be followed
+	workerID := int(atomic.AddInt32(&e.nextWorkerID, 1))
+
+	// Workers leaving for any other reason than a scale-down still count as active until here
+	scaledDown := false
+
+	defer func() {
+		if !scaledDown {
+			atomic.AddInt32(&e.Status.ActiveWorkers, -1)
+		}
+	}()
+
+	for {
+		// Wait out a pause between files, so a file in flight is always finished first
+		if !e.waitWhilePaused() {
+			return
+		}
+
+		// Scale down only between files, so a file in flight is always finished first
+		if e.leaveForScaleDown() {
+			scaledDown = true
+			return
+		}
+
+		fileToSync, ok := <-jobs
+		if !ok {
+			return
+		}
+
 		// Check for cancellation
 		select {
 		case <-e.cancelChan:
@@ -2418,6 +5817,13 @@ func (e *Engine) worker(wg *sync.WaitGroup, jobs <-chan *FileToSync, errors chan
 		default:
 		}
 
+		if e.deadlineReached() {
+			e.deferFile(fileToSync)
+			continue
+		}
+
+		fileToSync.workerID = workerID
+
 		err := e.syncFile(fileToSync)
 		if err != nil {
 			// syncFile already updated status and error tracking
@@ -2428,7 +5834,7 @@ func (e *Engine) worker(wg *sync.WaitGroup, jobs <-chan *FileToSync, errors chan
 
 			// Check if we've hit the error limit (only count actual errors, not cancellations)
 			e.Status.mu.RLock()
-			errorCount := len(e.Status.Errors)
+			errorCount := e.abortErrorCount()
 			e.Status.mu.RUnlock()
 
 			if errorCount >= MaxErrorsBeforeAbort {
@@ -2436,43 +5842,135 @@ func (e *Engine) worker(wg *sync.WaitGroup, jobs <-chan *FileToSync, errors chan
 				return
 			}
 		}
+	}
+}
 
-		// Check if we should scale down (CAS-based worker removal)
-		// This prevents stampede - only one worker wins the CAS and exits
-		for {
-			currentActive := atomic.LoadInt32(&e.Status.ActiveWorkers)
-			desired := atomic.LoadInt32(&e.desiredWorkers)
+// leaveForScaleDown reports whether this worker should exit because there are more active
+// workers than desired, decrementing ActiveWorkers for it if so.
+// The CAS means only as many workers leave as the count is over, without a stampede.
+func (e *Engine) leaveForScaleDown() bool {
+	for {
+		currentActive := atomic.LoadInt32(&e.Status.ActiveWorkers)
+		desired := atomic.LoadInt32(&e.desiredWorkers)
+
+		if currentActive <= desired {
+			// At or below target, keep working
+			return false
+		}
 
-			if currentActive <= desired {
-				// At or below target, keep working
-				break
-			}
+		// Try to atomically decrement activeWorkers
+		if atomic.CompareAndSwapInt32(&e.Status.ActiveWorkers, currentActive, currentActive-1) {
+			// Success: We won the race to decrement, so this worker exits
+			e.logDebug("Worker exiting", "workers_before", currentActive, "workers_after", currentActive-1)
+			return true
+		}
+		// CAS failed: Another worker already decremented, retry the check
+	}
+}
 
-			// Try to atomically decrement activeWorkers
-			if atomic.CompareAndSwapInt32(&e.Status.ActiveWorkers, currentActive, currentActive-1) {
-				// Success: We won the race to decrement
-				// This worker should exit
-				e.logToFile(fmt.Sprintf("Worker exiting: scaled down %d -> %d", currentActive, currentActive-1))
-				return
+// writeDestHashBloom writes a Bloom filter of the destination for later runs' DestHashBloom,
+// sized for the current file count. Files are keyed by the hashes this run learned comparing and
+// copying them, or recorded beside the filter last time if they haven't changed since; only
+// files neither covers are hashed.
+func (e *Engine) writeDestHashBloom() error {
+	e.logToFile("Writing destination hash filter: " + e.WriteDestHashBloom)
+
+	hashesPath := destBloomHashesPath(e.WriteDestHashBloom)
+
+	previous, err := loadHashCache(hashesPath, e.FileOps.HashAlgorithm)
+	if err != nil {
+		e.logWarn("Hashing the whole destination for its hash filter", "error", err)
+	}
+
+	files, err := e.destFilesAfterSync()
+	if err != nil {
+		return err
+	}
+
+	hashes := &hashCache{Algorithm: e.FileOps.HashAlgorithm, Files: make(map[string]hashCacheEntry, len(files))}
+	filter := bloom.New(len(files), e.BloomFalsePositiveRate)
+	hashed := 0
+
+	for relPath, file := range files {
+		hash, ok := e.destHashes.lookup(relPath, file.Size, file.ModTime)
+		if !ok {
+			hash, ok = previous.lookup(relPath, file.Size, file.ModTime)
+		}
+
+		if !ok {
+			hash, err = e.FileOps.ComputeDestHash(filepath.Join(e.DestPath, relPath), e.cancelChan)
+			if err != nil {
+				return fmt.Errorf("failed to hash %s for hash filter: %w", relPath, err)
 			}
-			// CAS failed: Another worker already decremented, retry the check
+
+			hashed++
 		}
+
+		hashes.store(relPath, file.Size, file.ModTime, hash)
+		filter.Add(destBloomKey(relPath, hash))
+	}
+
+	e.logToFile("Destination hash filter", "files", len(files), "hashed", hashed)
+
+	err = saveDestBloom(e.WriteDestHashBloom, filter)
+	if err != nil {
+		return err
 	}
+
+	// Losing them only costs hashing the destination again next time
+	err = hashes.save(hashesPath, nil)
+	if err != nil {
+		e.logWarn(err.Error())
+	}
+
+	return nil
 }
 
 // FileError represents an error that occurred while syncing a file
 type FileError struct {
-	FilePath string
-	Error    error
+	FilePath     string
+	Error        error
+	KnownFailure bool // The path also failed in the previous KnownFailureRuns runs
 }
 
 // FileToSync represents a file that needs to be synchronized
 type FileToSync struct {
-	RelativePath string
-	Size         int64
-	Transferred  int64
-	Status       string // "pending", "copying", "complete", "error"
-	Error        error
+	RelativePath     string
+	DestRelativePath string // Destination path if it differs from RelativePath (sanitized name)
+	Action           FileAction
+	Size             int64
+	ModTime          time.Time // Source modification time (zero when resumed from a saved plan)
+	Transferred      int64
+	Status           string // "pending", "copying", "complete", "error"
+	Error            error
+	Itemize          string // rsync --itemize-changes flags, e.g. ">f.st......" (empty = from Action)
+	Symlink          bool   // Recreated as a link rather than copied (SymlinkPreserve)
+
+	sampledBytes int64 // Bytes of the current copy attempt already counted in rate samples
+	workerID     int   // The worker copying the file, for its rate samples
+}
+
+// destPath returns the file's path relative to the destination root
+func (f *FileToSync) destPath() string {
+	if f.DestRelativePath != "" {
+		return f.DestRelativePath
+	}
+
+	return f.RelativePath
+}
+
+// itemizeFlags returns the file's rsync --itemize-changes flags. Plans that weren't compared
+// file by file (resumed, or checked against a hash filter) only know the action.
+func (f *FileToSync) itemizeFlags() string {
+	if f.Itemize != "" {
+		return f.Itemize
+	}
+
+	if f.Action == ActionOverwrite {
+		return ">f........."
+	}
+
+	return itemizeNewFile
 }
 
 // Status represents the current status of synchronization
@@ -2493,6 +5991,7 @@ type Status struct {
 	BytesPerSecond    float64
 	EstimatedTimeLeft time.Duration
 	CompletionTime    time.Time // Estimated completion time
+	EstimateSamples   int       // Speed samples behind CompletionTime (see CompletionEstimate)
 	FilesToSync       []*FileToSync
 	Errors            []FileError // All errors encountered during sync (excluding cancellations)
 	CancelledCopies   []string    // Files that were cancelled during copy
@@ -2503,6 +6002,136 @@ type Status struct {
 	TotalBytesInSource int64 // Total bytes in source
 	AlreadySyncedFiles int   // Files that were already up-to-date
 	AlreadySyncedBytes int64 // Bytes that were already up-to-date
+	ResumeSkippedFiles int   // Files skipped as already done when resuming an interrupted sync
+
+	// Resume manifest
+	ManifestSkippedFiles  int   // Files the manifest showed were already copied, so weren't compared
+	ManifestRecopiedFiles int   // Files the manifest showed were left half-written, copied again
+	ResumedFiles          int   // Half-written files carried on from their partial copies
+	ResumedBytes          int64 // Bytes of those files that earlier attempts had already copied
+
+	// Repair mode
+	RepairedFiles int // Corrupted destination files re-copied by a repair pass
+
+	// Post-copy verification (VerifyAfterCopy only); failures are in Errors, matching ErrVerifyFailed
+	VerifiedFiles int // Copies whose destination hash matched the source
+
+	// Move mode
+	MovedFiles   int // Files moved to the destination, removing them from the source
+	RenamedFiles int // Moved files renamed into place, as both sides were local, rather than copied
+
+	// Symlink preservation
+	PreservedSymlinks int // Source links recreated as links at the destination (SymlinkPreserve)
+
+	// Post-sync integrity check (nil = Reconcile hasn't run)
+	Reconcile *ReconcileReport
+
+	// Read-only check of an existing mirror (nil = Verify hasn't run)
+	Verify *VerifyReport
+
+	// Permission preservation
+	MetadataUpdates int // Destination files whose permissions were fixed without copying content
+
+	// Content-addressed store
+	DedupedFiles int // Files whose content was already stored under the same hash
+
+	// Reflink copies
+	ReflinkedFiles int // Files cloned (copy-on-write) instead of copied
+
+	// Sparse copies
+	SparseBytes int64 // Zero bytes left as holes instead of written
+
+	// Directory preservation (PreserveEmptyDirs)
+	DirsToCreate int // Source directories missing at the destination
+	DirsCreated  int // Of those, created so far
+
+	// Deadline (zero = none): files not started by then are deferred to a later run
+	Deadline      time.Time
+	DeferredFiles int
+
+	// Small-file batching
+	BatchedFiles int // Files sent in tar batches rather than individually
+	Batches      int // Tar batches sent
+
+	// Wall-clock time spent in each phase (Phase* keys); parallel scans overlap
+	PhaseDurations map[string]time.Duration
+
+	// Retention policy
+	RetentionExcludedFiles int // Source files left out by the keep-newest retention policy
+
+	// Baseline comparison
+	BaselineExcludedFiles int // Source files left out because the baseline has identical content
+
+	// Owner filter
+	OwnerExcludedFiles int // Source files left out because another user owns them
+	SizeSkippedFiles   int // Source files left out for being outside MinFileSize and MaxFileSize
+	// Source files left out for being modified outside ModifiedAfter and ModifiedBefore
+	ModTimeSkippedFiles int
+
+	// Snapshot the source was read from (SnapshotSource only): btrfs, zfs or lvm
+	SourceSnapshot string
+
+	// Source listing loaded from SourceIndexPath instead of scanned, and when that scan happened;
+	// SourceIndexSaved means the source was scanned and the index rewritten
+	SourceIndexUsed      bool
+	SourceIndexScannedAt time.Time
+	SourceIndexSaved     bool
+
+	// Errors in SkippableErrors categories, included in Errors but not counted toward the abort limit
+	SkippedErrors int
+
+	// Known persistent failures left out of the plan (SkipKnownFailures only)
+	KnownFailuresSkipped []string
+
+	// Load-based throttling (MaxLoadAverage only)
+	LoadAverage            float64 // Latest 1-minute load average sample
+	LoadThrottled          bool    // The load average exceeded MaxLoadAverage, so workers were scaled down
+	LoadAverageUnsupported bool    // This platform reports no load average, so MaxLoadAverage had no effect
+
+	// Transfer totals check (VerifyTotals only)
+	ExpectedTransferredBytes int64 // Bytes the completed plan accounts for
+	TotalsMismatch           bool  // TransferredBytes differs from ExpectedTransferredBytes
+
+	// Comparison with the previous run (CompareRuns only; nil = no previous run)
+	PreviousRun     *RunSummary
+	RunDeltaWarning string // A change since the previous run that suggests something is wrong
+
+	// Clock check (remote sources or destinations with ClockSkewThreshold set only)
+	ClockSkew          time.Duration // Destination clock minus source clock, as measured by probe files
+	ClockSkewMeasured  bool          // ClockSkew holds a measurement
+	ExcessiveClockSkew bool          // ClockSkew exceeds the configured threshold
+
+	// Destination inode check (local destinations that report an inode table only)
+	FreeInodes         int64 // Files and directories the destination can still create
+	InodesNeeded       int   // New files and directories the plan creates
+	InsufficientInodes bool  // The plan needs more inodes than are free
+
+	// Destination filename checks
+	IllegalNames   []string        // Source files whose names the destination can't store (skipped)
+	SanitizedNames []SanitizedName // Source files renamed to names the destination can store
+	CaseConflicts  []CaseConflict  // Source files whose paths differ only in case (CaseConflictPolicy)
+
+	FlattenCollisions []FlattenCollision // Source files Flatten gave the same name (CollisionPolicy)
+
+	// Paths that are a different type in source and destination, e.g. "data (directory → file)"
+	TypeChanges []string
+
+	// Destination files kept because they look edited at the destination (ProtectDestEdits)
+	ProtectedDestEdits []string
+
+	// Source hashes reused from the hash cache instead of hashing the file (HashCache only)
+	CachedHashes int
+
+	// Orphans moved to the trash (TrashDir) rather than deleted, and the run's trash folder.
+	// FilesDeleted counts trashed files too.
+	FilesTrashed int
+	TrashPath    string
+
+	// Orphan deletion turned off (DeleteOrphans false): destination files missing from the
+	// source that are kept instead of deleted
+	DeletionDisabled bool
+	OrphansKept      int
+	OrphanBytesKept  int64
 
 	// Comparison counts (for TUI display)
 	FilesInBoth       int   // Files that exist in both source and dest
@@ -2521,6 +6150,28 @@ type Status struct {
 	DeletionComplete  bool     // Whether deletion phase is complete
 	DeletionErrors    int      // Number of deletion errors
 
+	// More files to delete than MaxDeleteWithoutConfirm allows, and ConfirmDeletions not yet
+	// called: the sync stops before deleting anything
+	DeletionConfirmationRequired bool
+
+	// Orphaned files to delete by how recently they were modified (OrphanAge* keys)
+	OrphansByAge map[string]int
+
+	// Files to sync by size (Size* keys), to show whether the plan is many small files or a few big ones
+	SizeHistogram map[string]int
+
+	// Storage savings estimate (EstimateSavings only): bytes deduplication and preserving sparse
+	// files would save, and what the destination would use with both on and with both off
+	EstimatedDedupSavings   int64
+	EstimatedSparseSavings  int64
+	EstimatedStorageWith    int64
+	EstimatedStorageWithout int64
+
+	// Plan of each converge pass's analysis, first pass first (ConvergeIterations only), and whether
+	// the last pass still found work when the cap was reached
+	ConvergePasses []ConvergePass
+	NotConverged   bool
+
 	// Analysis progress
 	//nolint:lll // Inline comment listing all possible phase values
 	AnalysisPhase    string   // "counting_source", "scanning_source", "counting_dest", "scanning_dest", "comparing", "planning", "complete"
@@ -2536,15 +6187,22 @@ type Status struct {
 	DestTotalFiles     int // Total files in dest (0 if still counting)
 
 	// Analysis progress tracking for time estimation
-	ScannedBytes      int64     // Bytes scanned so far
+	ScannedBytes      int64     // Bytes scanned so far; once TotalBytesToScan is known, bytes compared
 	TotalBytesToScan  int64     // Total bytes to scan (0 if unknown)
 	AnalysisStartTime time.Time // When analysis started
-	AnalysisRate      float64   // Items per second (rolling)
+	AnalysisRate      float64   // ScannedBytes per second over the last AnalysisRateWindow
 
 	// Concurrency tracking
-	ActiveWorkers int32 // Current number of active workers (atomic)
-	MaxWorkers    int   // Maximum workers reached
-	AdaptiveMode  bool  // Whether adaptive concurrency is enabled
+	ActiveWorkers      int32 // Current number of active workers (atomic)
+	MaxWorkers         int   // Maximum workers reached
+	AdaptiveMode       bool  // Whether adaptive concurrency is enabled
+	MinAdaptiveWorkers int   // Fewest workers adaptive scaling may use for this sync (adaptive only)
+	MaxAdaptiveWorkers int   // Most workers adaptive scaling may use for this sync (adaptive only)
+
+	// Pause (see Engine.Pause): paused workers stay active, waiting between files; the rest of
+	// ActiveWorkers are finishing the files they had started
+	Paused        bool
+	PausedWorkers int // Workers waiting for Unpause
 
 	// Performance tracking (for bottleneck detection)
 	TotalReadTime  time.Duration // Total time spent reading from source
@@ -2555,9 +6213,14 @@ type Status struct {
 	Progress ProgressMetrics // Pre-computed progress percentages
 	Workers  WorkerMetrics   // Pre-computed worker performance metrics
 
+	// Each worker's rate over the rolling window, by worker id (pre-computed with Workers)
+	PerWorkerBytesPerSecond []WorkerRate
+
 	// Cleanup/finalization status
 	FinalizationPhase string // "updating_cache", "complete", or empty
 
+	analysisSamples []analysisSample // ScannedBytes over the last AnalysisRateWindow
+
 	mu sync.RWMutex
 }
 
@@ -2594,22 +6257,17 @@ func (s *Status) CalculateAnalysisProgress() ProgressMetrics {
 		bytesPercent = (float64(s.ScannedBytes) / float64(s.TotalBytesToScan)) * 100
 	}
 
-	// Calculate time-based percentage
+	// Calculate time-based percentage from the bytes left at the recent rate, which follows
+	// slowdowns (e.g. large files to hash) rather than averaging them into the whole analysis
 	timePercent := 0.0
 	estimatedTimeRemaining := time.Duration(0)
 
 	if !s.AnalysisStartTime.IsZero() {
 		elapsed := time.Since(s.AnalysisStartTime).Seconds()
-		if elapsed > 0 && s.AnalysisRate > 0 && s.TotalFilesToScan > 0 {
-			// Estimate total time based on rate
-			estimatedTotal := float64(s.TotalFilesToScan) / s.AnalysisRate
-			if estimatedTotal > 0 {
-				timePercent = (elapsed / estimatedTotal) * 100
-				remainingSeconds := estimatedTotal - elapsed
-				if remainingSeconds > 0 {
-					estimatedTimeRemaining = time.Duration(remainingSeconds) * time.Second
-				}
-			}
+		if elapsed > 0 && s.AnalysisRate > 0 {
+			remainingSeconds := max(0, float64(s.TotalBytesToScan-s.ScannedBytes)/s.AnalysisRate)
+			timePercent = (elapsed / (elapsed + remainingSeconds)) * 100
+			estimatedTimeRemaining = time.Duration(remainingSeconds * float64(time.Second))
 		}
 	}
 
@@ -2635,23 +6293,46 @@ func (s *Status) CalculateAnalysisProgress() ProgressMetrics {
 	}
 }
 
+// CompletionEstimate returns the estimated wall-clock completion time, and false while too few
+// speed samples have accumulated for it to be stable.
+func (s *Status) CompletionEstimate() (time.Time, bool) {
+	if s.EstimateSamples < MinEstimateSamples || s.CompletionTime.IsZero() {
+		return time.Time{}, false
+	}
+
+	return s.CompletionTime, true
+}
+
 // ComputeProgressMetrics calculates all progress metrics and updates the
 // Progress and Workers fields in the Status struct.
 // Must be called with the Status mutex already locked.
 func (s *Status) ComputeProgressMetrics() {
 	s.Progress = s.calculateProgressMetrics()
 	s.Workers = s.calculateWorkerMetrics()
+	s.PerWorkerBytesPerSecond = calculatePerWorkerRates(s.Workers.RecentSamples)
 }
 
-// addRateSample adds a new sample to the rolling window, keeping only samples from the last 10 seconds.
-// Must be called with the Status mutex already locked.
-func (s *Status) addRateSample(sample RateSample) {
-	const windowDuration = 10 * time.Second // Keep samples from last 10 seconds
+// PhaseTimings returns the recorded phase durations in the order the phases run,
+// skipping phases that didn't run.
+func (s *Status) PhaseTimings() []PhaseTiming {
+	timings := make([]PhaseTiming, 0, len(s.PhaseDurations))
+
+	for _, phase := range []string{PhaseScanSource, PhaseScanDest, PhaseCompare, PhaseDelete, PhaseCopy, PhaseReconcile} {
+		if duration, ok := s.PhaseDurations[phase]; ok {
+			timings = append(timings, PhaseTiming{Phase: phase, Duration: duration})
+		}
+	}
+
+	return timings
+}
 
+// addRateSample adds a new sample to the rolling window, keeping only samples from the last
+// RateSampleWindow. Must be called with the Status mutex already locked.
+func (s *Status) addRateSample(sample RateSample) {
 	s.Workers.RecentSamples = append(s.Workers.RecentSamples, sample)
 
-	// Prune samples older than windowDuration
-	cutoffTime := sample.Timestamp.Add(-windowDuration)
+	// Prune samples older than RateSampleWindow
+	cutoffTime := sample.Timestamp.Add(-RateSampleWindow)
 	filtered := s.Workers.RecentSamples[:0] // Reuse underlying array
 	for _, samp := range s.Workers.RecentSamples {
 		if !samp.Timestamp.Before(cutoffTime) {
@@ -2765,6 +6446,37 @@ func (s *Status) calculateRates(metrics *WorkerMetrics, totalBytes int64, totalD
 	}
 }
 
+// calculatePerWorkerRates calculates each worker's transfer rate from the samples, over the same
+// span as the total rate so the rates add up to it, ordered by worker id. Workers with no samples
+// in the window, and samples not taken by a worker, are left out.
+func calculatePerWorkerRates(samples []RateSample) []WorkerRate {
+	if len(samples) < 2 { //nolint:mnd // Two samples are needed for a span
+		return nil
+	}
+
+	duration := samples[len(samples)-1].Timestamp.Sub(samples[0].Timestamp)
+	if duration <= 0 {
+		return nil
+	}
+
+	workerBytes := make(map[int]int64)
+
+	for _, sample := range samples {
+		if sample.WorkerID > 0 {
+			workerBytes[sample.WorkerID] += sample.BytesTransferred
+		}
+	}
+
+	rates := make([]WorkerRate, 0, len(workerBytes))
+	for workerID, bytes := range workerBytes {
+		rates = append(rates, WorkerRate{WorkerID: workerID, BytesPerSecond: float64(bytes) / duration.Seconds()})
+	}
+
+	slices.SortFunc(rates, func(a, b WorkerRate) int { return a.WorkerID - b.WorkerID })
+
+	return rates
+}
+
 // calculateRollingWindowMetrics calculates worker metrics from recent samples.
 func (s *Status) calculateRollingWindowMetrics(metrics *WorkerMetrics) {
 	var totalBytes int64
@@ -2794,7 +6506,18 @@ func (s *Status) calculateRollingWindowMetrics(metrics *WorkerMetrics) {
 	s.calculateRates(metrics, totalBytes, totalDuration)
 }
 
+// CalculateWorkerMetrics computes worker performance metrics from the rate samples in the
+// last RateSampleWindow, falling back to cumulative totals before the first sample. It is safe
+// to call while a sync is adding samples; the returned RecentSamples is a copy.
+func (s *Status) CalculateWorkerMetrics() WorkerMetrics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.calculateWorkerMetrics()
+}
+
 // calculateWorkerMetrics computes worker performance metrics using rolling window.
+// Must be called with the Status mutex already locked.
 func (s *Status) calculateWorkerMetrics() WorkerMetrics {
 	metrics := WorkerMetrics{}
 
@@ -2817,6 +6540,10 @@ func (s *Status) calculateWorkerMetrics() WorkerMetrics {
 
 // unexported constants.
 const (
+	// evaluationInterval is the time between adaptive scaling evaluations, so each one sees a
+	// full RateSampleWindow of samples however few files completed
+	evaluationInterval = 10 * time.Second
+
 	fileStatusComplete   = "complete"
 	fileStatusCopying    = "copying"
 	fileStatusError      = "error"
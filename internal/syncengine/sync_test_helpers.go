@@ -26,3 +26,8 @@ func (e *Engine) SetDesiredWorkers(count int) {
 func (e *Engine) SetSourceResizable(pool filesystem.ResizablePool) {
 	e.sourceResizable = pool
 }
+
+// SampleLoad takes one load average sample, as the background sampler does (test helper).
+func (e *Engine) SampleLoad() error {
+	return e.sampleLoad()
+}
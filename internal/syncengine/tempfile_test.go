@@ -0,0 +1,44 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// TestEngine_Sync_RemovesStaleTempFiles verifies that a temp file left by a crashed copy is
+// removed by the sync rather than planned as an orphan, doesn't make the destination's file
+// count match the source's, and is left in place by analysis alone.
+func TestEngine_Sync_RemovesStaleTempFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "report.pdf", "full report")
+	createTestFile(t, destDir, "report.pdf"+fileops.TempSuffix, "full re")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.TotalFiles).To(Equal(1))
+	g.Expect(status.FilesToDelete).To(Equal(0))
+	g.Expect(filepath.Join(destDir, "report.pdf"+fileops.TempSuffix)).To(BeARegularFile())
+
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(readFile(t, destDir, "report.pdf")).To(Equal("full report"))
+
+	entries, err := os.ReadDir(destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(entries).To(HaveLen(1))
+}
@@ -0,0 +1,63 @@
+package syncengine
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// trashRunDirFormat names each run's folder under TrashDir by when its first orphan was trashed.
+const trashRunDirFormat = "20060102-150405"
+
+// removeOrphan deletes an orphaned destination file or directory, or moves it to this run's
+// trash folder when TrashDir is set.
+func (e *Engine) removeOrphan(dstPath, relPath string, isDir bool) error {
+	if e.TrashDir == "" {
+		return e.FileOps.RemoveFromDest(dstPath) //nolint:wrapcheck // Wrapped with the relative path by the caller
+	}
+
+	if e.trashRunDir == "" {
+		e.trashRunDir = filepath.Join(e.TrashDir, e.TimeProvider.Now().Format(trashRunDirFormat))
+
+		e.Status.mu.Lock()
+		e.Status.TrashPath = e.trashRunDir
+		e.Status.mu.Unlock()
+
+		e.logToFile("Moving orphans to the trash instead of deleting them", "dir", e.trashRunDir)
+	}
+
+	//nolint:wrapcheck // Wrapped with the relative path by the caller
+	return e.FileOps.TrashDest(dstPath, filepath.Join(e.trashRunDir, relPath), isDir)
+}
+
+// keepTrashAtDest removes a TrashDir inside the destination, and everything in it, from
+// destFiles, so trashed files are neither orphans nor trashed again.
+func (e *Engine) keepTrashAtDest(destFiles map[string]*fileops.FileInfo) {
+	rel, inDest := e.trashInDest()
+	if !inDest {
+		return
+	}
+
+	prefix := rel + string(filepath.Separator)
+
+	for relPath := range destFiles {
+		if relPath == rel || strings.HasPrefix(relPath, prefix) {
+			delete(destFiles, relPath)
+		}
+	}
+}
+
+// trashInDest returns TrashDir's path relative to the destination, and whether it is inside it.
+func (e *Engine) trashInDest() (string, bool) {
+	if e.TrashDir == "" {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(filepath.Clean(e.DestPath), filepath.Clean(e.TrashDir))
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	return rel, true
+}
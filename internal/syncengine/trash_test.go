@@ -0,0 +1,124 @@
+package syncengine_test
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_TrashDir_MovesOrphans verifies that orphaned files and directories are moved into
+// a timestamped folder under TrashDir, keeping their relative paths.
+func TestEngine_TrashDir_MovesOrphans(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	trashDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "kept.txt", "kept")
+	createTestFile(t, destDir, "kept.txt", "kept")
+	createTestFile(t, destDir, "orphan.txt", "orphan")
+	createNestedTestFile(t, destDir, "old/deep/gone.txt", "gone")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.TrashDir = trashDir
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	g.Expect(filepath.Join(destDir, "orphan.txt")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "old")).NotTo(BeADirectory())
+
+	status := engine.GetStatus()
+	g.Expect(status.FilesDeleted).To(Equal(2))
+	g.Expect(status.FilesTrashed).To(Equal(2))
+	g.Expect(filepath.Dir(status.TrashPath)).To(Equal(trashDir))
+
+	g.Expect(readFile(t, status.TrashPath, "orphan.txt")).To(Equal("orphan"))
+	g.Expect(readFile(t, status.TrashPath, "old/deep/gone.txt")).To(Equal("gone"))
+}
+
+// TestEngine_TrashDir_InsideDestIsLeftAlone verifies that a trash inside the destination isn't
+// treated as orphans by later runs.
+func TestEngine_TrashDir_InsideDestIsLeftAlone(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	trashDir := filepath.Join(destDir, ".trash")
+
+	createTestFile(t, sourceDir, "kept.txt", "kept")
+	createTestFile(t, destDir, "orphan.txt", "orphan")
+
+	for range 2 {
+		engine, err := syncengine.NewEngine(sourceDir, destDir)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		engine.TrashDir = trashDir
+
+		g.Expect(engine.Analyze()).To(Succeed())
+		g.Expect(engine.Sync()).To(Succeed())
+	}
+
+	runs, err := os.ReadDir(trashDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(runs).To(HaveLen(1))
+	g.Expect(readFile(t, filepath.Join(trashDir, runs[0].Name()), "orphan.txt")).To(Equal("orphan"))
+}
+
+func TestEngine_WriteScript_MovesOrphansToTrash(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	shell, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh to run the script with")
+	}
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	trashDir := t.TempDir()
+
+	createTestFile(t, destDir, "orphan.txt", "orphan")
+	createNestedTestFile(t, destDir, "old/gone.txt", "gone")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.TrashDir = trashDir
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	var script bytes.Buffer
+
+	_, err = engine.WriteScript(&script)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	scriptPath := filepath.Join(t.TempDir(), "sync.sh")
+	g.Expect(os.WriteFile(scriptPath, script.Bytes(), 0o600)).To(Succeed())
+
+	output, err := exec.Command(shell, scriptPath).CombinedOutput() //nolint:gosec // Test-generated script
+	g.Expect(err).ShouldNot(HaveOccurred(), string(output))
+
+	g.Expect(filepath.Join(destDir, "orphan.txt")).NotTo(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "old")).NotTo(BeADirectory())
+
+	runs, err := os.ReadDir(trashDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(runs).To(HaveLen(1))
+	g.Expect(readFile(t, filepath.Join(trashDir, runs[0].Name()), "orphan.txt")).To(Equal("orphan"))
+	g.Expect(readFile(t, filepath.Join(trashDir, runs[0].Name()), "old/gone.txt")).To(Equal("gone"))
+}
@@ -0,0 +1,58 @@
+package syncengine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// TreeDigest returns one SHA-256 digest of every file under root, so two trees (or one tree at
+// two times) can be compared by a single value. Each file's content hash is folded in with its
+// slash-separated relative path, in sorted path order, so the digest doesn't depend on scan
+// order or platform. Directories only count through the files in them. root is read through
+// the destination filesystem when it is DestPath, and through the source filesystem otherwise.
+// Contents are hashed with HashAlgorithm, so only digests made with the same one compare.
+func (e *Engine) TreeDigest(root string) (string, error) {
+	e.FileOps.CancelChan = e.cancelChan
+	e.FileOps.HashAlgorithm = e.hashAlgorithm()
+
+	scan := e.FileOps.ScanDirectoryWithProgress
+	hashFile := e.FileOps.ComputeSourceHash
+
+	if root == e.DestPath {
+		scan = e.FileOps.ScanDestDirectoryWithProgress
+		hashFile = e.FileOps.ComputeDestHash
+	}
+
+	files, err := scan(root, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+
+	paths := make([]string, 0, len(files))
+
+	for relPath, info := range files {
+		if !info.IsDir {
+			paths = append(paths, relPath)
+		}
+	}
+
+	// Sorting on the slash form keeps the order the same on every platform
+	sort.Slice(paths, func(i, j int) bool { return filepath.ToSlash(paths[i]) < filepath.ToSlash(paths[j]) })
+
+	digest := sha256.New()
+
+	for _, relPath := range paths {
+		fileHash, hashErr := hashFile(files[relPath].Path, e.cancelChan)
+		if hashErr != nil {
+			return "", fmt.Errorf("failed to hash %s: %w", relPath, hashErr)
+		}
+
+		// Paths can't contain NUL, so the fold is unambiguous
+		_, _ = fmt.Fprintf(digest, "%s\x00%s\n", filepath.ToSlash(relPath), fileHash)
+	}
+
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
@@ -0,0 +1,79 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_TreeDigest_MatchesAfterSync verifies that a synced destination has the source's
+// digest, and that changing one file's content changes it.
+func TestEngine_TreeDigest_MatchesAfterSync(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "alpha")
+	createNestedTestFile(t, sourceDir, "sub/b.txt", "beta")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	sourceDigest, err := engine.TreeDigest(sourceDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	destDigest, err := engine.TreeDigest(destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(destDigest).To(Equal(sourceDigest))
+	g.Expect(destDigest).To(HaveLen(64))
+
+	g.Expect(os.WriteFile(filepath.Join(destDir, "sub", "b.txt"), []byte("BETA"), 0o600)).To(Succeed())
+
+	destDigest, err = engine.TreeDigest(destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(destDigest).NotTo(Equal(sourceDigest))
+}
+
+// TestEngine_TreeDigest_DependsOnPathsNotCreationOrder verifies that trees with the same files
+// created in a different order share a digest, while moving content to another path changes it.
+func TestEngine_TreeDigest_DependsOnPathsNotCreationOrder(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	first := t.TempDir()
+	second := t.TempDir()
+	renamed := t.TempDir()
+
+	createTestFile(t, first, "a.txt", "alpha")
+	createNestedTestFile(t, first, "z/b.txt", "beta")
+
+	createNestedTestFile(t, second, "z/b.txt", "beta")
+	createTestFile(t, second, "a.txt", "alpha")
+
+	createTestFile(t, renamed, "a.txt", "alpha")
+	createNestedTestFile(t, renamed, "y/b.txt", "beta")
+
+	digests := make([]string, 0, 3)
+
+	for _, root := range []string{first, second, renamed} {
+		engine, err := syncengine.NewEngine(root, t.TempDir())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		digest, err := engine.TreeDigest(root)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		digests = append(digests, digest)
+	}
+
+	g.Expect(digests[1]).To(Equal(digests[0]))
+	g.Expect(digests[2]).NotTo(Equal(digests[0]))
+}
@@ -0,0 +1,87 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// TestEngine_TypeChange_ReplacesDestinationEntries verifies that a destination directory where the
+// source now has a file, and a destination file where the source now has a directory, are removed
+// and replaced cleanly.
+func TestEngine_TypeChange_ReplacesDestinationEntries(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "data", "now a file")
+	createNestedTestFile(t, destDir, "data/old.txt", "was in a directory")
+	createNestedTestFile(t, sourceDir, "logs/today.txt", "now a directory")
+	createTestFile(t, destDir, "logs", "was a file")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.Workers = 1
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.GetStatus().TypeChanges).To(Equal([]string{
+		"data (directory → file)",
+		"logs (file → directory)",
+	}))
+
+	g.Expect(engine.Sync()).To(Succeed())
+	g.Expect(engine.GetStatus().Errors).To(BeEmpty())
+
+	data, err := os.ReadFile(filepath.Join(destDir, "data"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal("now a file"))
+
+	data, err = os.ReadFile(filepath.Join(destDir, "logs", "today.txt"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal("now a directory"))
+}
+
+// TestEngine_TypeChange_DoesNotWriteThroughDestinationSymlink verifies that a symlink at the
+// destination is replaced by a regular file rather than having its target overwritten.
+func TestEngine_TypeChange_DoesNotWriteThroughDestinationSymlink(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+	target := filepath.Join(t.TempDir(), "target.txt")
+
+	createTestFile(t, sourceDir, "notes.txt", "source content")
+	g.Expect(os.WriteFile(target, []byte("outside the destination"), 0o600)).To(Succeed())
+
+	err := os.Symlink(target, filepath.Join(destDir, "notes.txt"))
+	if err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.GetStatus().TypeChanges).To(Equal([]string{"notes.txt (symlink → file)"}))
+	g.Expect(engine.Sync()).To(Succeed())
+
+	info, err := os.Lstat(filepath.Join(destDir, "notes.txt"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(info.Mode().IsRegular()).To(BeTrue())
+
+	data, err := os.ReadFile(target)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal("outside the destination"))
+}
@@ -0,0 +1,107 @@
+package syncengine_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestEngine_VerifyAfterCopy_PassesIntactCopies verifies that good copies are counted as
+// verified.
+func TestEngine_VerifyAfterCopy_PassesIntactCopies(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "a.txt", "alpha")
+	createTestFile(t, sourceDir, "b.txt", "bravo")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.VerifyAfterCopy = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.VerifiedFiles).To(Equal(2))
+	g.Expect(status.FailedFiles).To(Equal(0))
+}
+
+// TestEngine_VerifyAfterCopy_FailsCorruptedCopies verifies that a copy whose bytes changed on
+// the way fails with ErrVerifyFailed and is removed from the destination.
+func TestEngine_VerifyAfterCopy_FailsCorruptedCopies(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "good.txt", "intact")
+	createTestFile(t, sourceDir, "bad.txt", "corrupted in transit")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.FileOps = fileops.NewDualFileOps(filesystem.NewRealFileSystem(),
+		&corruptingFS{RealFileSystem: filesystem.NewRealFileSystem(), name: "bad.txt"})
+	engine.VerifyAfterCopy = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).NotTo(Succeed())
+
+	status := engine.GetStatus()
+	g.Expect(status.VerifiedFiles).To(Equal(1))
+	g.Expect(status.FailedFiles).To(Equal(1))
+	g.Expect(status.Errors).To(HaveLen(1))
+	g.Expect(status.Errors[0].FilePath).To(Equal("bad.txt"))
+	g.Expect(errors.Is(status.Errors[0].Error, syncengine.ErrVerifyFailed)).To(BeTrue())
+
+	g.Expect(filepath.Join(destDir, "good.txt")).To(BeAnExistingFile())
+	g.Expect(filepath.Join(destDir, "bad.txt")).NotTo(BeAnExistingFile())
+}
+
+// corruptingFS is a destination filesystem that flips the first byte written to files with a
+// given name (or their copies' temp files), like a bad disk or link.
+type corruptingFS struct {
+	*filesystem.RealFileSystem
+
+	name string
+}
+
+func (f *corruptingFS) Create(path string) (filesystem.File, error) {
+	file, err := f.RealFileSystem.Create(path)
+	if err != nil || strings.TrimSuffix(filepath.Base(path), fileops.TempSuffix) != f.name {
+		return file, err
+	}
+
+	return &corruptingFile{File: file}, nil
+}
+
+// corruptingFile flips the first byte written through it.
+type corruptingFile struct {
+	filesystem.File
+
+	written bool
+}
+
+func (f *corruptingFile) Write(data []byte) (int, error) {
+	if !f.written && len(data) > 0 {
+		f.written = true
+		corrupted := append([]byte{data[0] ^ 0xff}, data[1:]...)
+
+		return f.File.Write(corrupted)
+	}
+
+	return f.File.Write(data)
+}
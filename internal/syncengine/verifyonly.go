@@ -0,0 +1,78 @@
+package syncengine
+
+import "sort"
+
+// VerifyReport is the result of Verify: where the destination doesn't mirror the source.
+type VerifyReport struct {
+	SourceFiles int      // Source files scanned
+	Missing     []string // In the source but not at the destination
+	Mismatched  []string // At the destination but different by ChangeType, or in permissions
+	Extra       []string // At the destination but not in the source
+}
+
+// Clean reports whether the destination mirrors the source.
+func (r *VerifyReport) Clean() bool {
+	return r.Problems() == 0
+}
+
+// Problems returns how many files are missing, mismatched or extra.
+func (r *VerifyReport) Problems() int {
+	return len(r.Missing) + len(r.Mismatched) + len(r.Extra)
+}
+
+// Verify checks that the destination still mirrors the source without copying or deleting
+// anything: it analyzes both trees as Analyze does, with the same filters and ChangeType, and
+// reports what a sync would change instead of syncing. Destination files missing from the
+// source are reported as extra even when DeleteOrphans is off, though not when flattening or
+// moving, which never delete. A saved resume plan is ignored, since it describes an earlier
+// run rather than the trees as they are now. The report is also kept in Status.Verify.
+func (e *Engine) Verify() (*VerifyReport, error) {
+	if e.CAStore {
+		return nil, ErrReconcileNotSupported
+	}
+
+	resume, deleteOrphans := e.Resume, e.DeleteOrphans
+	e.Resume, e.DeleteOrphans = false, true
+
+	defer func() { e.Resume, e.DeleteOrphans = resume, deleteOrphans }()
+
+	// No Sync follows to remove a snapshot of the source
+	defer e.releaseSnapshot()
+
+	err := e.Analyze()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &VerifyReport{SourceFiles: e.GetStatus().TotalFilesInSource}
+
+	for _, entry := range e.PlanEntries() {
+		switch entry.Action {
+		case ActionCreate:
+			report.Missing = append(report.Missing, entry.RelativePath)
+		case ActionOverwrite, ActionMetadata:
+			report.Mismatched = append(report.Mismatched, entry.RelativePath)
+		case ActionDelete:
+			report.Extra = append(report.Extra, entry.RelativePath)
+		}
+	}
+
+	// Files to sync are in copy order
+	sort.Strings(report.Missing)
+	sort.Strings(report.Mismatched)
+
+	e.Status.mu.Lock()
+	e.Status.Verify = report
+	e.Status.mu.Unlock()
+
+	if report.Clean() {
+		e.logToFile("Verify: destination mirrors the source", "files", report.SourceFiles)
+	} else {
+		e.logWarn("Verify: destination doesn't mirror the source", "missing", len(report.Missing),
+			"mismatched", len(report.Mismatched), "extra", len(report.Extra))
+	}
+
+	e.notifyStatusUpdate()
+
+	return report, nil
+}
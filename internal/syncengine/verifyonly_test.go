@@ -0,0 +1,141 @@
+package syncengine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// TestEngine_Verify_ReportsDifferencesWithoutChangingAnything verifies that missing, differing
+// and extra files are reported, and neither tree is changed.
+func TestEngine_Verify_ReportsDifferencesWithoutChangingAnything(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "same.txt", "same")
+	createTestFile(t, destDir, "same.txt", "same")
+	createTestFile(t, sourceDir, "changed.txt", "new content")
+	createTestFile(t, destDir, "changed.txt", "old")
+	createTestFile(t, sourceDir, "missing.txt", "missing")
+	createTestFile(t, destDir, "extra.txt", "extra")
+
+	// Files written one after the other needn't get the same modtime
+	modTime := time.Now().Add(-time.Hour)
+	g.Expect(os.Chtimes(filepath.Join(sourceDir, "same.txt"), modTime, modTime)).To(Succeed())
+	g.Expect(os.Chtimes(filepath.Join(destDir, "same.txt"), modTime, modTime)).To(Succeed())
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+
+	report, err := engine.Verify()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(report.Clean()).To(BeFalse())
+	g.Expect(report.Problems()).To(Equal(3))
+	g.Expect(report.SourceFiles).To(Equal(3))
+	g.Expect(report.Missing).To(Equal([]string{"missing.txt"}))
+	g.Expect(report.Mismatched).To(Equal([]string{"changed.txt"}))
+	g.Expect(report.Extra).To(Equal([]string{"extra.txt"}))
+	g.Expect(engine.GetStatus().Verify).To(Equal(report))
+
+	g.Expect(readDir(t, destDir)).To(Equal(map[string]string{
+		"same.txt": "same", "changed.txt": "old", "extra.txt": "extra",
+	}))
+}
+
+// TestEngine_Verify_CleanMirror verifies that a destination matching the source passes.
+func TestEngine_Verify_CleanMirror(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createNestedTestFile(t, sourceDir, "dir/a.txt", "alpha")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.Sync()).To(Succeed())
+
+	verifier, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	verifier.ChangeType = config.Content
+
+	report, err := verifier.Verify()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(report.Clean()).To(BeTrue())
+}
+
+// TestEngine_Verify_HonorsFiltersAndNoDelete verifies that excluded files are neither missing
+// nor extra, and that extras are reported even with deletion turned off, which Verify leaves
+// as it was.
+func TestEngine_Verify_HonorsFiltersAndNoDelete(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "skip.log", "source log")
+	createTestFile(t, destDir, "old.log", "dest log")
+	createTestFile(t, destDir, "orphan.txt", "orphan")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.ExcludePatterns = []string{"*.log"}
+	engine.DeleteOrphans = false
+
+	report, err := engine.Verify()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(report.Missing).To(BeEmpty())
+	g.Expect(report.Mismatched).To(BeEmpty())
+	g.Expect(report.Extra).To(Equal([]string{"orphan.txt"}))
+	g.Expect(engine.DeleteOrphans).To(BeFalse())
+	g.Expect(filepath.Join(destDir, "orphan.txt")).To(BeAnExistingFile())
+
+	_, err = os.Stat(filepath.Join(destDir, "skip.log"))
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+}
+
+// TestEngine_Verify_LeavesStaleTempFiles verifies that a temp file an interrupted copy left at
+// the destination is neither reported nor removed, as removing it is up to a sync.
+func TestEngine_Verify_LeavesStaleTempFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "report.pdf", "full report")
+	createTestFile(t, destDir, "report.pdf"+fileops.TempSuffix, "full re")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	report, err := engine.Verify()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(report.Missing).To(Equal([]string{"report.pdf"}))
+	g.Expect(report.Extra).To(BeEmpty())
+	g.Expect(readFile(t, destDir, "report.pdf"+fileops.TempSuffix)).To(Equal("full re"))
+}
@@ -0,0 +1,102 @@
+package syncengine
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// checkXattrCompare refuses XattrCompare where extended attributes can't be read: remote paths
+// and platforms other than Linux.
+func (e *Engine) checkXattrCompare() error {
+	if e.XattrCompare == "" {
+		return nil
+	}
+
+	_, localSource := e.FileOps.SourceFS.(*filesystem.RealFileSystem)
+	_, localDest := e.FileOps.DestFS.(*filesystem.RealFileSystem)
+
+	if !localSource || !localDest {
+		return fmt.Errorf("%w: comparing by %s needs a local source and destination", ErrXattrNotSupported,
+			e.XattrCompare)
+	}
+
+	_, err := fileops.GetXattr(e.SourcePath, e.XattrCompare)
+	if errors.Is(err, ErrXattrNotSupported) {
+		return fmt.Errorf("%w: %s", err, e.SourcePath)
+	}
+
+	e.logAnalysis("Comparing files by extended attribute " + e.XattrCompare)
+
+	return nil
+}
+
+// compareXattrs decides whether a file needs a sync from the XattrCompare attribute on both
+// copies, in place of ChangeType.
+func (e *Engine) compareXattrs(relPath, dstRelPath string, comparedCount int) bool {
+	srcValue, srcErr := fileops.GetXattr(filepath.Join(e.SourcePath, relPath), e.XattrCompare)
+	dstValue, dstErr := fileops.GetXattr(filepath.Join(e.DestPath, dstRelPath), e.XattrCompare)
+
+	if errors.Is(srcErr, fileops.ErrXattrNotFound) || errors.Is(dstErr, fileops.ErrXattrNotFound) {
+		needsSync := e.XattrMissing != config.MissingXattrSkip
+
+		if comparedCount < LogSampleSize {
+			e.logAnalysis(fmt.Sprintf("  → No %s on both copies of %s (sync=%v)", e.XattrCompare, relPath, needsSync))
+		}
+
+		return needsSync
+	}
+
+	for _, err := range []error{srcErr, dstErr} {
+		if err != nil {
+			e.logAnalysis(fmt.Sprintf("  ⚠ Failed to read %s for %s: %v", e.XattrCompare, relPath, err))
+			return true // Assume needs sync if we can't read the version
+		}
+	}
+
+	needsSync := srcValue != dstValue
+	if e.XattrNewerOnly {
+		needsSync = compareVersions(srcValue, dstValue) > 0
+	}
+
+	if comparedCount < LogSampleSize {
+		if needsSync {
+			e.logAnalysis(fmt.Sprintf("  → %s differs: %s (src=%s dst=%s)", e.XattrCompare, relPath, srcValue, dstValue))
+		} else {
+			e.logAnalysis(fmt.Sprintf("  ✓ %s current: %s (%s)", e.XattrCompare, relPath, dstValue))
+		}
+	}
+
+	return needsSync
+}
+
+// compareVersions orders two version strings, returning -1, 0 or 1. Dot-separated segments
+// are compared numerically when both are numbers (so "1.10" is newer than "1.9") and as
+// strings otherwise; a version that runs out of segments first is older.
+func compareVersions(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+
+	for i := range min(len(aParts), len(bParts)) {
+		aNum, aErr := strconv.ParseUint(aParts[i], 10, 64)
+		bNum, bErr := strconv.ParseUint(bParts[i], 10, 64)
+
+		order := strings.Compare(aParts[i], bParts[i])
+		if aErr == nil && bErr == nil {
+			order = cmp.Compare(aNum, bNum)
+		}
+
+		if order != 0 {
+			return order
+		}
+	}
+
+	return cmp.Compare(len(aParts), len(bParts))
+}
@@ -0,0 +1,108 @@
+package syncengine_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/config"
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+// TestEngine_XattrCompare_SyncsDifferingVersions verifies that files are picked by their version
+// attribute alone, even when their content matches, and that the synced copies carry the
+// source's version so a re-run has nothing to do.
+func TestEngine_XattrCompare_SyncsDifferingVersions(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	for _, name := range []string{"bumped.psd", "same.psd", "unversioned.psd"} {
+		createTestFile(t, sourceDir, name, "layers")
+		createTestFile(t, destDir, name, "layers")
+	}
+
+	setVersion(t, filepath.Join(sourceDir, "bumped.psd"), "2")
+	setVersion(t, filepath.Join(destDir, "bumped.psd"), "1")
+	setVersion(t, filepath.Join(sourceDir, "same.psd"), "7")
+	setVersion(t, filepath.Join(destDir, "same.psd"), "7")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.ChangeType = config.Content
+	engine.XattrCompare = "user.asset_version"
+	engine.XattrMissing = config.MissingXattrSkip
+
+	g.Expect(engine.Analyze()).To(Succeed())
+	g.Expect(engine.PlanEntries()).To(ConsistOf(
+		syncengine.PlanEntry{RelativePath: "bumped.psd", Size: 6, Action: syncengine.ActionOverwrite},
+	))
+	g.Expect(engine.Sync()).To(Succeed())
+	g.Expect(engine.GetStatus().Errors).To(BeEmpty())
+	g.Expect(fileops.GetXattr(filepath.Join(destDir, "bumped.psd"), "user.asset_version")).To(Equal("2"))
+
+	rerun, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rerun.XattrCompare = "user.asset_version"
+	rerun.XattrMissing = config.MissingXattrSkip
+
+	g.Expect(rerun.Analyze()).To(Succeed())
+	g.Expect(rerun.GetStatus().TotalFiles).To(BeZero())
+}
+
+// TestEngine_XattrCompare_NewerOnly verifies that only a higher source version syncs, with
+// numeric version segments compared as numbers, and that missing versions sync by default.
+func TestEngine_XattrCompare_NewerOnly(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := t.TempDir()
+
+	for _, name := range []string{"newer.psd", "older.psd", "unversioned.psd"} {
+		createTestFile(t, sourceDir, name, "layers")
+		createTestFile(t, destDir, name, "layers")
+	}
+
+	setVersion(t, filepath.Join(sourceDir, "newer.psd"), "1.10")
+	setVersion(t, filepath.Join(destDir, "newer.psd"), "1.9")
+	setVersion(t, filepath.Join(sourceDir, "older.psd"), "1.2")
+	setVersion(t, filepath.Join(destDir, "older.psd"), "1.3")
+
+	engine, err := syncengine.NewEngine(sourceDir, destDir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine.XattrCompare = "user.asset_version"
+	engine.XattrNewerOnly = true
+
+	g.Expect(engine.Analyze()).To(Succeed())
+
+	var planned []string
+	for _, entry := range engine.PlanEntries() {
+		planned = append(planned, entry.RelativePath)
+	}
+
+	g.Expect(planned).To(ConsistOf("newer.psd", "unversioned.psd"))
+}
+
+// setVersion stamps path with a user.asset_version attribute, skipping the test where the
+// filesystem can't store one.
+func setVersion(t *testing.T, path, version string) {
+	t.Helper()
+
+	err := fileops.SetXattr(path, "user.asset_version", version)
+	if errors.Is(err, fileops.ErrXattrNotSupported) {
+		t.Skip("extended attributes not supported here")
+	}
+
+	if err != nil {
+		t.Fatalf("failed to set version on %s: %v", path, err)
+	}
+}
@@ -16,6 +16,8 @@ type AppModel struct {
 	logPath       string
 	width         int
 	height        int
+	finalState    string // Set once the run reaches the summary: "complete", "cancelled", "error" or "verified"
+	finalErr      error  // Why the run failed, if finalState is "error"
 }
 
 // NewAppModel creates a new app model with UnifiedScreen
@@ -42,6 +44,11 @@ func (a AppModel) CurrentScreen() tea.Model {
 	return a.currentScreen
 }
 
+// Engine returns the run's engine, or nil if the run ended before one was created
+func (a AppModel) Engine() *syncengine.Engine {
+	return a.engine
+}
+
 // Init implements tea.Model
 func (a AppModel) Init() tea.Cmd {
 	return a.currentScreen.Init()
@@ -52,6 +59,17 @@ func (a AppModel) LogPath() string {
 	return a.logPath
 }
 
+// Outcome returns how the run ended: "complete", "cancelled", "error" with its cause, or
+// "verified" once a --verify check finishes, whatever it found.
+// A run quit before reaching the summary counts as cancelled.
+func (a AppModel) Outcome() (string, error) {
+	if a.finalState == "" {
+		return shared.StateCancelled, nil
+	}
+
+	return a.finalState, a.finalErr
+}
+
 // Update implements tea.Model
 func (a AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Capture window size
@@ -60,14 +78,24 @@ func (a AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.height = windowMsg.Height
 	}
 
-	// Track engine and logPath from transitions (for LogPath() getter)
+	// Track engine, logPath and outcome from transitions (for the LogPath, Engine and Outcome getters)
 	switch msg := msg.(type) {
+	case shared.EngineInitializedMsg:
+		a.engine = msg.Engine
+	case shared.TransitionToSummaryMsg:
+		a.finalState = msg.FinalState
+		a.finalErr = msg.Err
 	case shared.TransitionToConfirmationMsg:
 		a.engine = msg.Engine
 		a.logPath = msg.LogPath
 	case shared.TransitionToSyncMsg:
 		a.engine = msg.Engine
 		a.logPath = msg.LogPath
+	case shared.TransitionToVerifySummaryMsg:
+		a.engine = msg.Engine
+		a.logPath = msg.LogPath
+		a.finalState = shared.StateVerified
+		a.finalErr = nil
 	}
 
 	// Delegate everything to the unified screen
@@ -187,6 +187,59 @@ func TestAppModelTransitionToSummary(t *testing.T) {
 	g.Expect(unifiedScreen.Phase()).Should(Equal(tui.PhaseSummary), "Expected summary phase after TransitionToSummaryMsg")
 }
 
+func TestAppModelOutcome(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	model := tui.NewAppModel(&config.Config{InteractiveMode: true})
+
+	// Quitting before the summary counts as cancelled
+	state, err := model.Outcome()
+	g.Expect(state).Should(Equal(shared.StateCancelled))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	updatedModel, _ := model.Update(shared.EngineInitializedMsg{Engine: engine})
+	updatedModel, _ = updatedModel.Update(shared.TransitionToSummaryMsg{
+		FinalState: shared.StateError,
+		Err:        syncengine.ErrSyncAborted,
+	})
+
+	appModel, ok := updatedModel.(tui.AppModel)
+	g.Expect(ok).Should(BeTrue(), "Expected updatedModel to be AppModel")
+
+	state, err = appModel.Outcome()
+	g.Expect(state).Should(Equal(shared.StateError))
+	g.Expect(err).Should(MatchError(syncengine.ErrSyncAborted))
+	g.Expect(appModel.Engine()).Should(BeIdenticalTo(engine))
+}
+
+func TestAppModelTransitionToVerifySummary(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	model := tui.NewAppModel(&config.Config{InteractiveMode: true})
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.Verify = &syncengine.VerifyReport{SourceFiles: 2, Extra: []string{"orphan.txt"}}
+
+	updatedModel, _ := model.Update(shared.TransitionToVerifySummaryMsg{Engine: engine, LogPath: "/tmp/verify.log"})
+
+	appModel, ok := updatedModel.(tui.AppModel)
+	g.Expect(ok).Should(BeTrue(), "Expected updatedModel to be AppModel")
+
+	state, err := appModel.Outcome()
+	g.Expect(state).Should(Equal(shared.StateVerified))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(appModel.Engine()).Should(BeIdenticalTo(engine))
+	g.Expect(appModel.LogPath()).Should(Equal("/tmp/verify.log"))
+
+	unifiedScreen, isUnifiedScreen := appModel.CurrentScreen().(*tui.UnifiedScreen)
+	g.Expect(isUnifiedScreen).Should(BeTrue(), "Expected UnifiedScreen")
+	g.Expect(unifiedScreen.Phase()).Should(Equal(tui.PhaseSummary))
+	g.Expect(appModel.View()).Should(ContainSubstring("Verification failed: 1 difference from the source"))
+}
+
 func TestAppModelTransitionToSync(t *testing.T) {
 	t.Parallel()
 
@@ -1,7 +1,10 @@
 package screens
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -9,12 +12,24 @@ import (
 	"github.com/joe/copy-files/internal/tui/shared"
 )
 
+// Exported constants.
+const (
+	// ConfirmationHelpText is the key help shown while waiting for confirmation
+	ConfirmationHelpText = "Ready to sync? Press Enter to start • t for tree view • Esc to cancel"
+)
+
 // ConfirmationScreen displays analysis results and asks for confirmation before sync
 type ConfirmationScreen struct {
-	engine  *syncengine.Engine
-	logPath string
-	width   int
-	height  int
+	engine   *syncengine.Engine
+	logPath  string
+	width    int
+	height   int
+	tree     *shared.PlanTree // Built on first toggle
+	showTree bool
+
+	// With Move, or more files to delete than MaxDeleteWithoutConfirm allows, the first Enter
+	// only arms the sync and a second starts it, as both are hard to undo
+	armed bool
 }
 
 // NewConfirmationScreen creates a new confirmation screen
@@ -37,6 +52,10 @@ func (s ConfirmationScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		s.width = msg.Width
 		s.height = msg.Height
 
+		if s.tree != nil {
+			s.tree.SetHeight(s.planTreeHeight())
+		}
+
 		return s, nil
 	case tea.KeyMsg:
 		return s.handleKeyMsg(msg)
@@ -53,7 +72,7 @@ func (s ConfirmationScreen) View() string {
 	builder.WriteString("\n\n")
 	builder.WriteString(s.RenderContent())
 	builder.WriteString("\n")
-	builder.WriteString(shared.RenderDim("Ready to sync? Press Enter to start • Esc to cancel"))
+	builder.WriteString(shared.RenderDim(ConfirmationHelpText))
 	return shared.RenderBox(builder.String(), s.width, s.height)
 }
 
@@ -75,9 +94,21 @@ func (s ConfirmationScreen) RenderContent() string {
 		builder.WriteString("\n")
 	}
 
+	if len(s.engine.ExcludePatterns) > 0 {
+		builder.WriteString(shared.RenderLabel("Excluding: "))
+		builder.WriteString(strings.Join(s.engine.ExcludePatterns, ", "))
+		builder.WriteString("\n")
+	}
+
+	// Directories --preserve-empty-dirs creates aren't files, so they aren't in the file counts
+	if status.DirsToCreate > 0 {
+		builder.WriteString(shared.RenderLabel("Directories to create: "))
+		builder.WriteString(fmt.Sprintf("%d\n", status.DirsToCreate))
+	}
+
 	// Empty state handling - context-aware messages
-	// Only show "already synced" if there are no files to copy AND no files to delete
-	if status.TotalFiles == 0 && status.FilesToDelete == 0 {
+	// Only show "already synced" if there is nothing to copy, delete or create
+	if status.TotalFiles == 0 && status.FilesToDelete == 0 && status.DirsToCreate == 0 {
 		if s.engine.FilePattern != "" {
 			// Filter applied but no matches
 			builder.WriteString(shared.RenderEmptyListPlaceholder("No files match your filter"))
@@ -101,6 +132,99 @@ func (s ConfirmationScreen) RenderContent() string {
 		builder.WriteString(errorList)
 	}
 
+	// Running out of inodes fails the sync even with bytes free, so warn before it starts
+	if status.InsufficientInodes {
+		builder.WriteString(shared.RenderWarning(fmt.Sprintf(
+			"⚠ Destination has %d free inodes but this sync creates %d files and directories",
+			status.FreeInodes, status.InodesNeeded)))
+		builder.WriteString("\n")
+	}
+
+	// A sudden change from the previous run often means a source that isn't mounted
+	if status.RunDeltaWarning != "" {
+		builder.WriteString(shared.RenderWarning("⚠ " + status.RunDeltaWarning))
+		builder.WriteString("\n")
+	}
+
+	// Modtimes the destination stamps are off by the skew, which misleads anything comparing them
+	if status.ExcessiveClockSkew {
+		builder.WriteString(shared.RenderWarning(fmt.Sprintf(
+			"⚠ Destination clock is %s off the source's; check NTP on both hosts",
+			status.ClockSkew.Round(time.Millisecond))))
+		builder.WriteString("\n")
+	}
+
+	// Many small files favor more workers; a few huge ones favor bigger buffers
+	if len(status.SizeHistogram) > 0 {
+		builder.WriteString(shared.RenderLabel("Files to sync by size: "))
+		builder.WriteString(syncengine.FormatSizeHistogram(status.SizeHistogram))
+		builder.WriteString("\n")
+	}
+
+	// What --estimate-savings found, to decide whether dedup and sparse handling are worth it
+	if status.EstimatedStorageWithout > 0 {
+		builder.WriteString(shared.RenderLabel("Estimated savings: "))
+		builder.WriteString(fmt.Sprintf("%s from deduplication, %s from sparse files\n",
+			shared.FormatBytes(status.EstimatedDedupSavings), shared.FormatBytes(status.EstimatedSparseSavings)))
+		builder.WriteString(shared.RenderLabel("Destination storage: "))
+		builder.WriteString(fmt.Sprintf("%s with both, %s without\n",
+			shared.FormatBytes(status.EstimatedStorageWith), shared.FormatBytes(status.EstimatedStorageWithout)))
+	}
+
+	// Moving removes source files, which is hard to undo, so it is confirmed separately
+	if s.engine.Move {
+		builder.WriteString(shared.RenderWarning(fmt.Sprintf(
+			"⚠ Moving: %d files (%s) will be removed from the source once each is at the destination",
+			status.TotalFiles, shared.FormatBytes(status.TotalBytes))))
+		builder.WriteString("\n")
+
+		if s.armed {
+			builder.WriteString(shared.RenderWarning("Press Enter again to move them, or Esc to cancel"))
+			builder.WriteString("\n")
+		}
+	}
+
+	// Said before the sync starts, so nobody expects the destination to end up a mirror
+	if status.DeletionDisabled {
+		builder.WriteString(shared.RenderLabel("Deletion disabled: "))
+		builder.WriteString(fmt.Sprintf("%d destination files not in the source will be kept (%s)\n",
+			status.OrphansKept, shared.FormatBytes(status.OrphanBytesKept)))
+	}
+
+	// Deleting more than the limit waits for a second Enter (or --yes, which skips this screen)
+	if status.DeletionConfirmationRequired {
+		builder.WriteString(shared.RenderWarning(fmt.Sprintf("⚠ About to delete %s files (%s) — confirm?",
+			formatCount(status.FilesToDelete), shared.FormatBytes(status.BytesToDelete))))
+		builder.WriteString("\n")
+
+		if s.armed {
+			builder.WriteString(shared.RenderWarning("Press Enter again to delete them, or Esc to cancel"))
+			builder.WriteString("\n")
+		}
+	}
+
+	// Orphans modified recently suggest the wrong source or filter rather than upstream deletions
+	if status.FilesToDelete > 0 {
+		builder.WriteString(shared.RenderLabel("Files to delete by age: "))
+		builder.WriteString(syncengine.FormatOrphansByAge(status.OrphansByAge))
+		builder.WriteString("\n")
+
+		recent := status.OrphansByAge[syncengine.OrphanAgeDay] + status.OrphansByAge[syncengine.OrphanAgeWeek]
+		if recent > 0 {
+			builder.WriteString(shared.RenderWarning(fmt.Sprintf(
+				"⚠ %d of the files to delete were modified in the last week; check the source and filter", recent)))
+			builder.WriteString("\n")
+		}
+	}
+
+	if s.showTree && s.tree != nil {
+		builder.WriteString(shared.RenderLabel("Sync plan:"))
+		builder.WriteString("\n")
+		builder.WriteString(s.tree.View())
+		builder.WriteString(shared.RenderDim("↑/↓ to move • →/← to expand/collapse • Space to toggle"))
+		builder.WriteString("\n")
+	}
+
 	// Note: Help text removed - shown by unified screen based on active phase
 	return builder.String()
 }
@@ -109,10 +233,24 @@ func (s ConfirmationScreen) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	//nolint:exhaustive // Default case handles all other keys
 	switch msg.Type {
 	case tea.KeyCtrlC:
-		// Emergency exit - quit immediately
+		// Emergency exit - quit immediately, releasing any source snapshot
+		s.engine.Close()
+
 		return s, tea.Quit
 
 	case tea.KeyEnter:
+		confirmDeletions := s.engine.GetStatus().DeletionConfirmationRequired
+
+		if (s.engine.Move || confirmDeletions) && !s.armed {
+			s.armed = true
+
+			return s, nil
+		}
+
+		if confirmDeletions {
+			s.engine.ConfirmDeletions()
+		}
+
 		// Confirm and proceed to sync
 		return s, func() tea.Msg {
 			return shared.ConfirmSyncMsg{
@@ -122,13 +260,65 @@ func (s ConfirmationScreen) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.KeyEsc:
-		// Cancel and return to input screen
+		// Cancel and return to input screen; this engine won't sync, so release what it holds
+		s.engine.Close()
+
 		return s, func() tea.Msg {
 			return shared.TransitionToInputMsg{}
 		}
 
 	default:
-		// Ignore other keys
+		if msg.String() == "t" {
+			return s.toggleTree(), nil
+		}
+
+		if s.showTree && s.tree != nil {
+			s.tree.Update(msg)
+		}
+
 		return s, nil
 	}
 }
+
+// planTreeHeight returns how many tree rows fit below the other sections.
+func (s ConfirmationScreen) planTreeHeight() int {
+	if s.height == 0 {
+		return shared.DefaultPlanTreeHeight
+	}
+
+	return max(minPlanTreeHeight, s.height-planTreeReservedLines)
+}
+
+// toggleTree shows or hides the plan tree, building it from the engine's plan the first time.
+func (s ConfirmationScreen) toggleTree() ConfirmationScreen {
+	if s.tree == nil {
+		s.tree = shared.NewPlanTree(s.engine.PlanEntries())
+		s.tree.SetHeight(s.planTreeHeight())
+	}
+
+	s.showTree = !s.showTree
+
+	return s
+}
+
+// formatCount formats a count with thousands separators (e.g., "1,234"), so a large deletion
+// reads at a glance.
+func formatCount(count int) string {
+	digits := strconv.Itoa(count)
+
+	for i := len(digits) - thousandsGroup; i > 0; i -= thousandsGroup {
+		digits = digits[:i] + "," + digits[i:]
+	}
+
+	return digits
+}
+
+// unexported constants.
+const (
+	// minPlanTreeHeight is the fewest tree rows shown on small terminals
+	minPlanTreeHeight = 5
+	// planTreeReservedLines leaves room for the timeline and the other sections around the tree
+	planTreeReservedLines = 30
+	// thousandsGroup is how many digits formatCount puts between separators
+	thousandsGroup = 3
+)
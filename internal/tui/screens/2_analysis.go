@@ -137,12 +137,16 @@ func (s AnalysisScreen) calculateCopyingSectionLines(activeFiles int, status *sy
 	if activeFiles > 0 {
 		lines = 1  // Header
 		lines++    // Progress bar
-		lines += 3 // Files, Bytes, Time lines
+		lines += 4 // Files, Bytes, Time, Started/finishes lines
 		lines++    // Blank line
 		// Speed line (if present)
 		if status.Workers.TotalRate > 0 {
 			lines++
 		}
+		// Per-worker speed line (if present)
+		if len(status.PerWorkerBytesPerSecond) > 1 {
+			lines++
+		}
 		lines++    // Blank line after stats
 		lines++    // "Currently Copying" header
 		lines += min(activeFiles, 5)
@@ -245,6 +249,16 @@ func (s AnalysisScreen) getAnalysisPhaseText() string {
 	return s.getPhaseDisplayText(s.status.AnalysisPhase) + "..."
 }
 
+// formatAnalysisETA renders the analysis estimate coarsely, e.g. "~2m remaining": it follows a
+// rate that swings with file sizes, so seconds would only flicker past the first minute.
+func formatAnalysisETA(remaining time.Duration) string {
+	if remaining < time.Minute {
+		return fmt.Sprintf("~%s remaining", shared.FormatDuration(remaining))
+	}
+
+	return fmt.Sprintf("~%s remaining", strings.TrimSuffix(shared.FormatDuration(remaining.Round(time.Minute)), " 0s"))
+}
+
 // getPhaseDisplayText returns the display text for a phase without trailing ellipsis.
 func (s AnalysisScreen) getPhaseDisplayText(phase string) string {
 	switch phase {
@@ -268,7 +282,9 @@ func (s AnalysisScreen) getPhaseDisplayText(phase string) string {
 func (s AnalysisScreen) handleAnalysisComplete() (tea.Model, tea.Cmd) {
 	// Check if confirmation should be skipped
 	if s.config.SkipConfirmation {
-		// Skip confirmation and go directly to sync
+		// Skip confirmation and go directly to sync; --yes confirms a large deletion too
+		s.engine.ConfirmDeletions()
+
 		return s, func() tea.Msg {
 			return shared.TransitionToSyncMsg{
 				Engine:  s.engine,
@@ -319,6 +335,16 @@ func (s AnalysisScreen) handleEngineInitialized(msg shared.EngineInitializedMsg)
 			return nil
 		},
 		func() tea.Msg {
+			// --verify only reports how the destination differs, so there's nothing to confirm
+			if s.config.Verify {
+				_, err := engine.Verify()
+				if err != nil {
+					return shared.ErrorMsg{Err: err}
+				}
+
+				return shared.TransitionToVerifySummaryMsg{Engine: engine, LogPath: s.logPath}
+			}
+
 			err := engine.Analyze()
 			if err != nil {
 				return shared.ErrorMsg{Err: err}
@@ -469,13 +495,28 @@ func (s AnalysisScreen) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.
 
 func (s AnalysisScreen) initializeEngine() tea.Cmd {
 	return func() tea.Msg {
-		engine, err := syncengine.NewEngine(s.config.SourcePath, s.config.DestPath)
+		engine, err := syncengine.NewEngineFromConfig(s.config)
 		if err != nil {
 			return shared.ErrorMsg{Err: fmt.Errorf("failed to initialize engine: %w", err)}
 		}
 
-		engine.FilePattern = s.config.FilePattern
-		engine.Verbose = s.config.Verbose
+		engine.ApplyConfig(s.config)
+
+		if s.config.AnalysisLogPath != "" {
+			err = engine.EnableAnalysisLogging(s.config.AnalysisLogPath)
+			if err != nil {
+				engine.Close()
+				return shared.ErrorMsg{Err: err}
+			}
+		}
+
+		if s.config.Itemize != "" {
+			err = engine.EnableItemizeLog(s.config.Itemize)
+			if err != nil {
+				engine.Close()
+				return shared.ErrorMsg{Err: err}
+			}
+		}
 
 		return shared.EngineInitializedMsg{
 			Engine: engine,
@@ -569,6 +610,12 @@ func (s AnalysisScreen) renderAnalyzingContent() string {
 		builder.WriteString(s.spinner.View())
 		builder.WriteString(" ")
 		builder.WriteString(phaseText)
+
+		progress := s.status.CalculateAnalysisProgress()
+		if !progress.IsCounting && progress.EstimatedTimeRemaining > 0 {
+			builder.WriteString(shared.RenderDim(" " + formatAnalysisETA(progress.EstimatedTimeRemaining)))
+		}
+
 		builder.WriteString("\n")
 	}
 
@@ -804,6 +851,9 @@ func (s AnalysisScreen) renderInBothLine(builder *strings.Builder) {
 // Indent for items under Source/Dest sections.
 const sectionIndent = "  "
 
+// maxWorkerRatesShown is how many workers the per-worker speed breakdown lists.
+const maxWorkerRatesShown = 8
+
 // renderCopyingSection renders the full copying progress section during live sync.
 func (s AnalysisScreen) renderCopyingSection(builder *strings.Builder) {
 	// Show remaining count with transition arrow
@@ -888,6 +938,27 @@ func (s AnalysisScreen) renderSyncProgress(builder *strings.Builder) {
 		shared.FormatDuration(elapsed),
 		shared.FormatDuration(totalEstimated),
 		s.liveStatus.Progress.TimePercent*shared.ProgressPercentageScale)
+
+	if !s.liveStatus.Deadline.IsZero() {
+		fmt.Fprintf(builder, " • deadline %s (%s left)",
+			s.liveStatus.Deadline.Format("15:04"),
+			shared.FormatDuration(max(0, time.Until(s.liveStatus.Deadline))))
+	}
+
+	builder.WriteString("\n")
+
+	// Wall-clock start and projected finish, for knowing when to check back on long runs
+	now := time.Now()
+	finish := "calculating…"
+
+	if completion, ok := s.liveStatus.CompletionEstimate(); ok {
+		finish = "~" + shared.FormatClockTime(completion, now)
+	}
+
+	builder.WriteString(sectionIndent)
+	fmt.Fprintf(builder, "Started %s • finishes %s",
+		shared.FormatClockTime(s.liveStatus.StartTime, now), finish)
+
 	builder.WriteString("\n\n")
 }
 
@@ -913,6 +984,30 @@ func (s AnalysisScreen) renderSyncStatistics(builder *strings.Builder) {
 			shared.FormatRate(s.liveStatus.Workers.TotalRate))
 		builder.WriteString("\n")
 	}
+
+	s.renderWorkerRates(builder)
+	builder.WriteString("\n")
+}
+
+// renderWorkerRates renders each worker's recent speed, so a stalled worker stands out from the
+// average. With a single worker it would only repeat the total, so it's left out.
+func (s AnalysisScreen) renderWorkerRates(builder *strings.Builder) {
+	rates := s.liveStatus.PerWorkerBytesPerSecond
+	if len(rates) < 2 { //nolint:mnd // A breakdown needs at least two workers
+		return
+	}
+
+	parts := make([]string, 0, min(len(rates), maxWorkerRatesShown)+1)
+	for _, rate := range rates[:min(len(rates), maxWorkerRatesShown)] {
+		parts = append(parts, fmt.Sprintf("#%d %s", rate.WorkerID, shared.FormatRate(rate.BytesPerSecond)))
+	}
+
+	if len(rates) > maxWorkerRatesShown {
+		parts = append(parts, fmt.Sprintf("+%d more", len(rates)-maxWorkerRatesShown))
+	}
+
+	builder.WriteString(sectionIndent)
+	builder.WriteString(shared.RenderDim("Per worker: " + strings.Join(parts, " • ")))
 	builder.WriteString("\n")
 }
 
@@ -1,6 +1,7 @@
 package screens
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -13,6 +14,12 @@ import (
 	"github.com/joe/copy-files/internal/tui/shared"
 )
 
+// Exported constants.
+const (
+	// SyncHelpText is the key help shown while syncing
+	SyncHelpText = "p to pause or resume • Esc or q to cancel • Ctrl+C to exit immediately"
+)
+
 // SyncScreen handles the file synchronization process
 type SyncScreen struct {
 	engine          *syncengine.Engine
@@ -126,7 +133,6 @@ func (s SyncScreen) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// Handle other keys by string
-	//nolint:gocritic // Single case switch is intentional for extensibility
 	switch msg.String() {
 	case "q":
 		// Cancel the sync gracefully
@@ -135,6 +141,20 @@ func (s SyncScreen) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			s.engine.Cancel()
 		}
 
+		return s, nil
+
+	case "p":
+		// Toggle the pause; files already started finish either way
+		if s.engine != nil && !s.cancelled {
+			if s.engine.IsPaused() {
+				s.engine.Unpause()
+			} else {
+				s.engine.Pause()
+			}
+
+			s.status = s.engine.GetStatus()
+		}
+
 		return s, nil
 	}
 
@@ -215,8 +235,8 @@ func (s SyncScreen) handleTick() (tea.Model, tea.Cmd) {
 						}
 					}
 				}
-				s.engine.LogVerbose(fmt.Sprintf("[PROGRESS] UI_POLL: files_copying=%d [%s]",
-					len(s.status.CurrentFiles), strings.Join(fileStatuses, ", ")))
+				s.engine.LogVerbose("[PROGRESS] UI_POLL", "files_copying", len(s.status.CurrentFiles),
+					"files", strings.Join(fileStatuses, ", "))
 			}
 		}
 	}
@@ -347,7 +367,7 @@ func (s SyncScreen) renderSyncingView() string {
 	builder.WriteString("\n\n")
 	builder.WriteString(s.renderSyncingContent())
 	builder.WriteString("\n")
-	builder.WriteString(shared.RenderDim("Esc or q to cancel • Ctrl+C to exit immediately"))
+	builder.WriteString(shared.RenderDim(SyncHelpText))
 	return shared.RenderBox(builder.String(), s.width, s.height)
 }
 
@@ -373,6 +393,13 @@ func (s SyncScreen) renderSyncingContent() string {
 		return builder.String()
 	}
 
+	if s.status.Paused {
+		builder.WriteString(shared.RenderWarning(fmt.Sprintf(
+			"⏸ Paused: %d of %d workers waiting, the rest finishing the files they started. Press p to resume",
+			s.status.PausedWorkers, s.status.ActiveWorkers)))
+		builder.WriteString("\n\n")
+	}
+
 	// Show "Starting sync..." until actual sync activity begins
 	// This prevents a confusing jump to high progress (e.g., 79%) from already-synced files
 	// Include deletion activity - deletion IS sync activity
@@ -392,6 +419,13 @@ func (s SyncScreen) renderSyncingContent() string {
 	// Note: Copying section (progress bars, workers, files) now shown in analysis screen
 	// with live-updating counts
 
+	// Later converge passes show which pass is running and how the plan has shrunk
+	if passes := s.status.ConvergePasses; len(passes) > 1 {
+		builder.WriteString(shared.RenderLabel("Converge pass: "))
+		builder.WriteString(fmt.Sprintf("%d (files to sync: %s)", len(passes), syncengine.FormatConvergePasses(passes)))
+		builder.WriteString("\n\n")
+	}
+
 	// Errors only - all other sync info is now in the analysis section
 	s.renderSyncingErrors(&builder)
 
@@ -406,7 +440,9 @@ func (s SyncScreen) renderSyncingContent() string {
 func (s SyncScreen) startSync() tea.Cmd {
 	return func() tea.Msg {
 		err := s.engine.Sync()
-		if err != nil {
+
+		// Files deferred by the deadline are reported on the summary, not as a failure
+		if err != nil && !errors.Is(err, syncengine.ErrDeadlineReached) {
 			s.engine.CloseLog()
 			return shared.ErrorMsg{Err: err}
 		}
@@ -1,6 +1,7 @@
 package screens
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -8,13 +9,13 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/joe/copy-files/internal/syncengine"
 	"github.com/joe/copy-files/internal/tui/shared"
-	"github.com/joe/copy-files/pkg/errors"
+	pkgerrors "github.com/joe/copy-files/pkg/errors"
 )
 
 // SummaryScreen displays the final results
 type SummaryScreen struct {
 	status     *syncengine.Status
-	finalState string // "complete", "cancelled", "error"
+	finalState string // "complete", "cancelled", "error", "verified"
 	err        error
 	width      int
 	height     int
@@ -86,6 +87,8 @@ func (s SummaryScreen) View() string {
 		return s.renderCancelledView()
 	case shared.StateError:
 		return s.renderErrorView()
+	case shared.StateVerified:
+		return s.renderVerifiedView()
 	default:
 		return shared.RenderBox("Unknown state", s.width, s.height)
 	}
@@ -101,6 +104,8 @@ func (s SummaryScreen) RenderContent() string {
 		return s.renderCancelledContent()
 	case shared.StateError:
 		return s.renderErrorContent()
+	case shared.StateVerified:
+		return s.renderVerifiedContent()
 	default:
 		return "Unknown state"
 	}
@@ -182,6 +187,10 @@ func (s SummaryScreen) renderCompleteContent() string {
 
 	// Show errors if any (important feedback)
 	if s.status != nil {
+		s.renderCompleteDetails(&builder)
+		s.renderPhaseTimings(&builder)
+		s.renderRunDelta(&builder)
+		s.renderReconcile(&builder)
 		s.renderCompleteErrors(&builder)
 	}
 
@@ -201,6 +210,14 @@ func (s SummaryScreen) renderCompleteTitle(builder *strings.Builder) {
 		return
 	}
 
+	if s.status != nil && s.status.DeferredFiles > 0 {
+		builder.WriteString(shared.RenderWarning(fmt.Sprintf(
+			"⏱ Deadline reached: synchronized %d files, deferred %d to the next run",
+			s.status.ProcessedFiles, s.status.DeferredFiles)))
+
+		return
+	}
+
 	// Show celebratory success message with stats if files were synced
 	if s.status != nil && s.status.ProcessedFiles > 0 {
 		elapsed := time.Since(s.status.StartTime)
@@ -245,22 +262,560 @@ func (s SummaryScreen) renderCompleteTitle(builder *strings.Builder) {
 	builder.WriteString(shared.RenderSuccess(shared.SuccessSymbol() + " All files already up-to-date"))
 }
 
+// renderCompleteDetails adds secondary facts about the run below the title.
+func (s SummaryScreen) renderCompleteDetails(builder *strings.Builder) {
+	if !s.status.EndTime.IsZero() {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Started %s, finished %s",
+			shared.FormatClockTime(s.status.StartTime, s.status.EndTime),
+			shared.FormatClockTime(s.status.EndTime, s.status.EndTime))))
+	}
+
+	// Each one is a conflict only the user can resolve, so they come first
+	if len(s.status.ProtectedDestEdits) > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderWarning(fmt.Sprintf(
+			"⚠ Kept %d destination files that look edited there (newer than the source, different size):",
+			len(s.status.ProtectedDestEdits))))
+
+		for i, relPath := range s.status.ProtectedDestEdits {
+			if i == summaryNameListLimit {
+				builder.WriteString(fmt.Sprintf("\n  ... and %d more", len(s.status.ProtectedDestEdits)-i))
+				break
+			}
+
+			builder.WriteString("\n  " + relPath)
+		}
+	}
+
+	if s.status.ResumeSkippedFiles > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Resumed: skipped %d files already done", s.status.ResumeSkippedFiles)))
+	}
+
+	if s.status.ManifestSkippedFiles > 0 || s.status.ManifestRecopiedFiles > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Resume manifest: skipped %d files already copied, recopied %d partial files",
+			s.status.ManifestSkippedFiles, s.status.ManifestRecopiedFiles)))
+	}
+
+	if s.status.ResumedFiles > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Resumed %d partial copies, skipping %s already copied",
+			s.status.ResumedFiles, shared.FormatBytes(s.status.ResumedBytes))))
+	}
+
+	if s.status.CachedHashes > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Hash cache: reused %d source hashes from earlier runs", s.status.CachedHashes)))
+	}
+
+	if s.status.VerifiedFiles > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Verified %d copies against the source by hash", s.status.VerifiedFiles)))
+	}
+
+	if s.status.MovedFiles > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Moved %d files out of the source (%d renamed, %d copied then removed)",
+			s.status.MovedFiles, s.status.RenamedFiles, s.status.MovedFiles-s.status.RenamedFiles)))
+	}
+
+	if s.status.RepairedFiles > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Repaired %d corrupted files", s.status.RepairedFiles)))
+	}
+
+	if s.status.PreservedSymlinks > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Recreated %d symlinks as links", s.status.PreservedSymlinks)))
+	}
+
+	if s.status.DirsCreated > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Created %d directories", s.status.DirsCreated)))
+	}
+
+	if s.status.MetadataUpdates > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Updated permissions on %d files", s.status.MetadataUpdates)))
+	}
+
+	if s.status.DedupedFiles > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Deduplicated %d files already in the store", s.status.DedupedFiles)))
+	}
+
+	if s.status.ReflinkedFiles > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Cloned %d files with reflinks instead of copying", s.status.ReflinkedFiles)))
+	}
+
+	if s.status.SparseBytes > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Left %s of zeros as holes instead of writing them", shared.FormatBytes(s.status.SparseBytes))))
+	}
+
+	if s.status.BatchedFiles > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Sent %d small files in %d batches and %d files individually",
+			s.status.BatchedFiles, s.status.Batches, s.status.ProcessedFiles-s.status.BatchedFiles)))
+	}
+
+	if s.status.NotConverged {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderWarning(fmt.Sprintf(
+			"⚠ Not converged after %d passes (files to sync: %s); the source changes faster than it syncs",
+			len(s.status.ConvergePasses)-1, syncengine.FormatConvergePasses(s.status.ConvergePasses))))
+	} else if len(s.status.ConvergePasses) > 1 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Converged after %d passes (files to sync: %s)",
+			len(s.status.ConvergePasses)-1, syncengine.FormatConvergePasses(s.status.ConvergePasses))))
+	}
+
+	// A totals mismatch means a truncated copy or an accounting bug, so it can't be easy to miss
+	if s.status.TotalsMismatch {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderError(fmt.Sprintf(
+			"⚠ Transferred %s but the copied files add up to %s; check the destination and the debug log",
+			shared.FormatBytes(s.status.TransferredBytes), shared.FormatBytes(s.status.ExpectedTransferredBytes))))
+	}
+
+	if s.status.MaxAdaptiveWorkers > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Adaptive workers: peaked at %d, allowed %d to %d",
+			s.status.MaxWorkers, s.status.MinAdaptiveWorkers, s.status.MaxAdaptiveWorkers)))
+	}
+
+	if s.status.LoadAverageUnsupported {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderWarning("⚠ --max-load had no effect: this platform doesn't report a load average"))
+	} else if s.status.LoadThrottled {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim("Scaled workers down while the system load average was high"))
+	}
+
+	if s.status.SourceSnapshot != "" {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf(
+			"Read the source from a %s snapshot taken before analysis", s.status.SourceSnapshot)))
+	}
+
+	if s.status.SourceIndexUsed {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf(
+			"Listed the source from the index scanned at %s instead of scanning it again",
+			shared.FormatClockTime(s.status.SourceIndexScannedAt, s.status.StartTime))))
+	} else if s.status.SourceIndexSaved {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim("Scanned the source fresh and saved the listing to the source index"))
+	}
+
+	if s.status.ExcessiveClockSkew {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderWarning(fmt.Sprintf("⚠ Destination clock is %s off the source's",
+			s.status.ClockSkew.Round(time.Millisecond))))
+	} else if s.status.ClockSkewMeasured {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Destination clock skew: %s", s.status.ClockSkew.Round(time.Millisecond))))
+	}
+
+	if s.status.FilesTrashed > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Trash: moved %d orphaned files to %s, deleted %d",
+			s.status.FilesTrashed, s.status.TrashPath, s.status.FilesDeleted-s.status.FilesTrashed)))
+	}
+
+	if s.status.DeletionDisabled {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Deletion disabled: kept %d destination files not in the source",
+			s.status.OrphansKept)))
+	}
+
+	if s.status.RetentionExcludedFiles > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Retention: left out %d older files", s.status.RetentionExcludedFiles)))
+	}
+
+	if s.status.OwnerExcludedFiles > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Owner filter: left out %d files owned by other users",
+			s.status.OwnerExcludedFiles)))
+	}
+
+	if s.status.SizeSkippedFiles > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Size filter: left out %d files outside --min-size/--max-size",
+			s.status.SizeSkippedFiles)))
+	}
+
+	if s.status.ModTimeSkippedFiles > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf(
+			"Modification time filter: left out %d files outside --newer-than/--older-than", s.status.ModTimeSkippedFiles)))
+	}
+
+	if s.status.BaselineExcludedFiles > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("Baseline: left out %d files identical to the baseline",
+			s.status.BaselineExcludedFiles)))
+	}
+
+	if len(s.status.SanitizedNames) > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderLabel(fmt.Sprintf("Renamed for destination (%d):", len(s.status.SanitizedNames))))
+
+		for i, rename := range s.status.SanitizedNames {
+			if i == summaryNameListLimit {
+				builder.WriteString(fmt.Sprintf("\n  ... and %d more", len(s.status.SanitizedNames)-i))
+				break
+			}
+
+			builder.WriteString(fmt.Sprintf("\n  %s → %s", rename.SourcePath, rename.DestPath))
+		}
+	}
+
+	if len(s.status.CaseConflicts) > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderWarning(fmt.Sprintf("Source paths that differ only in case (%d):",
+			len(s.status.CaseConflicts))))
+
+		for i, conflict := range s.status.CaseConflicts {
+			if i == summaryNameListLimit {
+				builder.WriteString(fmt.Sprintf("\n  ... and %d more", len(s.status.CaseConflicts)-i))
+				break
+			}
+
+			builder.WriteString("\n  " + conflict.String())
+		}
+	}
+
+	if len(s.status.FlattenCollisions) > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderLabel(fmt.Sprintf("Flattened names shared by several source files (%d):",
+			len(s.status.FlattenCollisions))))
+
+		for i, collision := range s.status.FlattenCollisions {
+			if i == summaryNameListLimit {
+				builder.WriteString(fmt.Sprintf("\n  ... and %d more", len(s.status.FlattenCollisions)-i))
+				break
+			}
+
+			builder.WriteString("\n  " + collision.String())
+		}
+	}
+
+	if len(s.status.TypeChanges) > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderWarning(fmt.Sprintf("Replaced %d destination entries whose type changed:",
+			len(s.status.TypeChanges))))
+
+		for i, change := range s.status.TypeChanges {
+			if i == summaryNameListLimit {
+				builder.WriteString(fmt.Sprintf("\n  ... and %d more", len(s.status.TypeChanges)-i))
+				break
+			}
+
+			builder.WriteString("\n  " + change)
+		}
+	}
+
+	if len(s.status.IllegalNames) > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderWarning(fmt.Sprintf(
+			"Skipped %d files with names the destination can't store (use --sanitize-names):",
+			len(s.status.IllegalNames))))
+
+		for i, name := range s.status.IllegalNames {
+			if i == summaryNameListLimit {
+				builder.WriteString(fmt.Sprintf("\n  ... and %d more", len(s.status.IllegalNames)-i))
+				break
+			}
+
+			builder.WriteString("\n  " + name)
+		}
+	}
+
+	if len(s.status.KnownFailuresSkipped) > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf(
+			"Skipped %d known persistent failures (--skip-known-failures):", len(s.status.KnownFailuresSkipped))))
+
+		for i, relPath := range s.status.KnownFailuresSkipped {
+			if i == summaryNameListLimit {
+				builder.WriteString(fmt.Sprintf("\n  ... and %d more", len(s.status.KnownFailuresSkipped)-i))
+				break
+			}
+
+			builder.WriteString("\n  " + relPath)
+		}
+	}
+}
+
 func (s SummaryScreen) renderCompleteErrors(builder *strings.Builder) {
 	// Show error details if any
 	if len(s.status.Errors) == 0 {
 		return
 	}
 
+	// Files that failed in earlier runs too are listed apart, so new problems stand out, and so
+	// are copies that failed verification, since their data was corrupted rather than not sent
+	var newErrors, knownErrors, verifyErrors []syncengine.FileError
+
+	for _, fileErr := range s.status.Errors {
+		switch {
+		case errors.Is(fileErr.Error, syncengine.ErrVerifyFailed):
+			verifyErrors = append(verifyErrors, fileErr)
+		case fileErr.KnownFailure:
+			knownErrors = append(knownErrors, fileErr)
+		default:
+			newErrors = append(newErrors, fileErr)
+		}
+	}
+
+	if len(verifyErrors) > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderError(fmt.Sprintf(
+			"Post-copy verification failed (%d) - the copies didn't match the source and were removed:", len(verifyErrors))))
+		builder.WriteString("\n")
+		builder.WriteString(shared.RenderErrorList(shared.ErrorListConfig{
+			Errors:  verifyErrors,
+			Context: shared.ContextComplete,
+		}))
+	}
+
+	if len(newErrors) > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderError("Errors:"))
+		builder.WriteString("\n")
+
+		// Use shared helper with complete state context (10 error limit)
+		errorList := shared.RenderErrorList(shared.ErrorListConfig{
+			Errors:  newErrors,
+			Context: shared.ContextComplete,
+		})
+		builder.WriteString(errorList)
+	}
+
+	if len(knownErrors) > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderWarning("Known persistent failures (also failed in earlier runs):"))
+		builder.WriteString("\n")
+		builder.WriteString(shared.RenderErrorList(shared.ErrorListConfig{
+			Errors:  knownErrors,
+			Context: shared.ContextComplete,
+		}))
+	}
+
+	if s.status.SkippedErrors > 0 {
+		builder.WriteString("\n")
+		builder.WriteString(shared.RenderDim(fmt.Sprintf(
+			"%d of these were in skippable categories and didn't count toward the abort limit", s.status.SkippedErrors)))
+	}
+}
+
+// renderPhaseTimings shows where the run's time went, marking the longest phase.
+func (s SummaryScreen) renderPhaseTimings(builder *strings.Builder) {
+	timings := s.status.PhaseTimings()
+	if len(timings) == 0 {
+		return
+	}
+
+	longest := 0
+
+	for i, timing := range timings {
+		if timing.Duration > timings[longest].Duration {
+			longest = i
+		}
+	}
+
 	builder.WriteString("\n\n")
-	builder.WriteString(shared.RenderError("Errors:"))
+	builder.WriteString(shared.RenderLabel("Time by phase:"))
+
+	for i, timing := range timings {
+		line := fmt.Sprintf("\n  %-18s %s", timing.Phase, shared.FormatDuration(timing.Duration))
+		if i == longest {
+			builder.WriteString(line + shared.RenderDim("  ← longest"))
+			continue
+		}
+
+		builder.WriteString(shared.RenderDim(line))
+	}
+}
+
+// renderRunDelta compares this run's numbers with the previous run's (--compare-runs).
+func (s SummaryScreen) renderRunDelta(builder *strings.Builder) {
+	prev := s.status.PreviousRun
+	if prev == nil {
+		return
+	}
+
+	builder.WriteString("\n\n")
+	builder.WriteString(shared.RenderLabel(fmt.Sprintf("Compared with last run (%s):", prev.Time.Format("2006-01-02 15:04"))))
+
+	counts := []struct {
+		label     string
+		prev, cur int
+	}{
+		{"source files", prev.SourceFiles, s.status.TotalFilesInSource},
+		{"files to sync", prev.FilesToSync, s.status.TotalFiles},
+		{"files to delete", prev.FilesToDelete, s.status.FilesToDelete},
+	}
+
+	for _, count := range counts {
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("\n  %-18s %d → %d (%+d)",
+			count.label, count.prev, count.cur, count.cur-count.prev)))
+	}
+
+	// Count-only analysis doesn't measure source size, so only compare sizes both runs measured
+	if prev.SourceBytes > 0 && s.status.TotalBytesInSource > 0 {
+		delta := s.status.TotalBytesInSource - prev.SourceBytes
+		sign := "+"
+
+		if delta < 0 {
+			sign = "-"
+			delta = -delta
+		}
+
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("\n  %-18s %s → %s (%s%s)", "source size",
+			shared.FormatBytes(prev.SourceBytes), shared.FormatBytes(s.status.TotalBytesInSource), sign, shared.FormatBytes(delta))))
+	}
+
+	if s.status.RunDeltaWarning != "" {
+		builder.WriteString("\n")
+		builder.WriteString(shared.RenderWarning("⚠ " + s.status.RunDeltaWarning))
+	}
+}
+
+// renderReconcile shows the post-sync integrity check (--reconcile), listing the source files
+// the destination doesn't mirror.
+func (s SummaryScreen) renderReconcile(builder *strings.Builder) {
+	report := s.status.Reconcile
+	if report == nil {
+		return
+	}
+
+	builder.WriteString("\n\n")
+	builder.WriteString(shared.RenderLabel("Verification:"))
+
+	if report.Clean() {
+		builder.WriteString("\n  ")
+		builder.WriteString(shared.RenderSuccess(fmt.Sprintf("%s Destination mirrors all %d source files",
+			shared.SuccessSymbol(), report.SourceFiles)))
+	} else {
+		builder.WriteString("\n  ")
+		builder.WriteString(shared.RenderError(fmt.Sprintf("⚠ Destination doesn't mirror %d of %d source files",
+			report.Problems(), report.SourceFiles)))
+	}
+
+	if report.Hashed > 0 {
+		builder.WriteString(shared.RenderDim(fmt.Sprintf("\n  Hashed %d files whose modtimes differed", report.Hashed)))
+	}
+
+	for _, group := range []struct {
+		label string
+		paths []string
+	}{
+		{"Missing", report.Missing},
+		{"Size mismatch", report.SizeMismatches},
+		{"Hash mismatch", report.HashMismatches},
+	} {
+		renderPathGroup(builder, group.label, group.paths)
+	}
+}
+
+// renderPathGroup lists up to summaryNameListLimit of paths under label, skipping an empty group.
+func renderPathGroup(builder *strings.Builder, label string, paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+
+	builder.WriteString(fmt.Sprintf("\n  %s (%d):", label, len(paths)))
+
+	for i, relPath := range paths {
+		if i == summaryNameListLimit {
+			builder.WriteString(fmt.Sprintf("\n    ... and %d more", len(paths)-i))
+			break
+		}
+
+		builder.WriteString("\n    " + relPath)
+	}
+}
+
+// ============================================================================
+// Rendering - Verified
+// ============================================================================
+
+func (s SummaryScreen) renderVerifiedView() string {
+	// Timeline header + content + help text + box wrapper (standalone mode)
+	timeline := "done"
+	if report := s.verifyReport(); report != nil && !report.Clean() {
+		timeline = "done_error"
+	}
+
+	var builder strings.Builder
+	builder.WriteString(shared.RenderTimeline(timeline))
+	builder.WriteString("\n\n")
+	builder.WriteString(s.renderVerifiedContent())
 	builder.WriteString("\n")
+	builder.WriteString(shared.RenderSubtitle("Enter or q to exit • Esc for new session"))
+	return shared.RenderBox(builder.String(), s.width, s.height)
+}
 
-	// Use shared helper with complete state context (10 error limit)
-	errorList := shared.RenderErrorList(shared.ErrorListConfig{
-		Errors:  s.status.Errors,
-		Context: shared.ContextComplete,
-	})
-	builder.WriteString(errorList)
+// renderVerifiedContent returns the result of a --verify check, listing where the destination
+// doesn't mirror the source, without timeline or box.
+func (s SummaryScreen) renderVerifiedContent() string {
+	var builder strings.Builder
+
+	report := s.verifyReport()
+
+	switch {
+	case report == nil:
+		builder.WriteString(shared.RenderWarning("⚠ Verification didn't run"))
+	case report.Clean():
+		builder.WriteString(shared.RenderSuccess(fmt.Sprintf(
+			"%s Verified: destination mirrors the source (%d files checked)",
+			shared.SuccessSymbol(), report.SourceFiles)))
+	default:
+		differencesWord := "differences"
+		if report.Problems() == 1 {
+			differencesWord = "difference"
+		}
+
+		builder.WriteString(shared.RenderError(fmt.Sprintf(
+			"⚠ Verification failed: %d %s from the source (%d files checked)",
+			report.Problems(), differencesWord, report.SourceFiles)))
+	}
+
+	if report != nil && !report.Clean() {
+		builder.WriteString("\n")
+		renderPathGroup(&builder, "Missing", report.Missing)
+		renderPathGroup(&builder, "Different", report.Mismatched)
+		renderPathGroup(&builder, "Extra", report.Extra)
+	}
+
+	if s.status != nil {
+		s.renderPhaseTimings(&builder)
+	}
+
+	builder.WriteString("\n\n")
+	builder.WriteString(shared.RenderDim("Nothing was copied or deleted."))
+
+	if s.logPath != "" {
+		builder.WriteString("\n\n")
+		builder.WriteString(shared.RenderDim("Debug log saved to: " + shared.MakePathClickable(s.logPath)))
+	}
+
+	return builder.String()
+}
+
+// verifyReport returns the --verify check's report, or nil if it didn't run.
+func (s SummaryScreen) verifyReport() *syncengine.VerifyReport {
+	if s.status == nil {
+		return nil
+	}
+
+	return s.status.Verify
 }
 
 // ============================================================================
@@ -286,7 +841,7 @@ func (s SummaryScreen) renderErrorContent() string {
 	builder.WriteString("\n\n")
 
 	// Create enricher for actionable error messages
-	enricher := errors.NewEnricher()
+	enricher := pkgerrors.NewEnricher()
 
 	if s.err != nil {
 		builder.WriteString(shared.RenderLabel("Error:"))
@@ -298,7 +853,7 @@ func (s SummaryScreen) renderErrorContent() string {
 		builder.WriteString(fmt.Sprintf("%v\n", enrichedErr))
 
 		// Show suggestions if available
-		suggestions := errors.FormatSuggestions(enrichedErr)
+		suggestions := pkgerrors.FormatSuggestions(enrichedErr)
 		if suggestions != "" {
 			builder.WriteString(suggestions)
 			builder.WriteString("\n")
@@ -339,3 +894,9 @@ func (s SummaryScreen) renderErrorContent() string {
 
 	return builder.String()
 }
+
+// unexported constants.
+const (
+	// summaryNameListLimit is the maximum number of renamed/skipped names to list
+	summaryNameListLimit = 10
+)
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/progress"
 	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
 
+	"github.com/joe/copy-files/internal/config"
 	"github.com/joe/copy-files/internal/syncengine"
 )
 
@@ -408,6 +409,36 @@ func TestRenderProcessingProgress_ProgressBar(t *testing.T) {
 	g.Expect(result).ShouldNot(BeEmpty())
 }
 
+// TestRenderSyncStatistics_PerWorkerRates verifies that the live statistics list each worker's
+// speed, capped at maxWorkerRatesShown, and leave the breakdown out for a single worker.
+func TestRenderSyncStatistics_PerWorkerRates(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	rates := make([]syncengine.WorkerRate, 0, maxWorkerRatesShown+2)
+	for id := 1; id <= maxWorkerRatesShown+2; id++ {
+		rates = append(rates, syncengine.WorkerRate{WorkerID: id, BytesPerSecond: float64(id) * 1024 * 1024})
+	}
+
+	screen := AnalysisScreen{
+		liveStatus: &syncengine.Status{ActiveWorkers: int32(len(rates)), PerWorkerBytesPerSecond: rates},
+	}
+
+	var builder strings.Builder
+	screen.renderSyncStatistics(&builder)
+	result := builder.String()
+
+	g.Expect(result).Should(ContainSubstring("Per worker: #1 1.0 MB/s • #2 2.0 MB/s"))
+	g.Expect(result).Should(ContainSubstring("#8 8.0 MB/s • +2 more"))
+	g.Expect(result).ShouldNot(ContainSubstring("#9 "))
+
+	screen.liveStatus.PerWorkerBytesPerSecond = rates[:1]
+
+	builder.Reset()
+	screen.renderSyncStatistics(&builder)
+	g.Expect(builder.String()).ShouldNot(ContainSubstring("Per worker"))
+}
+
 // newTestProgressBar creates a progress bar for testing
 func newTestProgressBar() progress.Model {
 	prog := progress.New(progress.WithDefaultGradient())
@@ -415,3 +446,32 @@ func newTestProgressBar() progress.Model {
 
 	return prog
 }
+
+func TestFormatAnalysisETA(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(formatAnalysisETA(45 * time.Second)).To(Equal("~45s remaining"))
+	g.Expect(formatAnalysisETA(2*time.Minute + 10*time.Second)).To(Equal("~2m remaining"))
+	g.Expect(formatAnalysisETA(time.Hour + 5*time.Minute + 40*time.Second)).To(Equal("~1h 6m remaining"))
+}
+
+func TestRenderAnalyzingContent_ComparingShowsETA(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	screen := NewAnalysisScreen(&config.Config{SourcePath: "/src", DestPath: "/dst"})
+	screen.status = &syncengine.Status{
+		AnalysisPhase:     "comparing",
+		ScannedBytes:      20 * 1024 * 1024,
+		TotalBytesToScan:  140 * 1024 * 1024,
+		AnalysisStartTime: time.Now().Add(-10 * time.Second),
+		AnalysisRate:      1024 * 1024,
+	}
+
+	g.Expect(screen.renderAnalyzingContent()).To(ContainSubstring("Comparing files... ~2m remaining"))
+
+	// No estimate until the total is known
+	screen.status.TotalBytesToScan = 0
+	g.Expect(screen.renderAnalyzingContent()).NotTo(ContainSubstring("remaining"))
+}
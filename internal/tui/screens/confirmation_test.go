@@ -227,6 +227,98 @@ func TestConfirmationScreen_View_WithErrors(t *testing.T) {
 	g.Expect(output).Should(ContainSubstring("error 2"), "Should show second error")
 }
 
+func TestConfirmationScreen_View_InsufficientInodes(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/test/source", "/test/dest")
+	engine.Status.FreeInodes = 100
+	engine.Status.InodesNeeded = 5000
+	engine.Status.InsufficientInodes = true
+
+	screen := screens.NewConfirmationScreen(engine, "/tmp/test-debug.log")
+
+	g.Expect(screen.View()).Should(ContainSubstring("Destination has 100 free inodes but this sync creates 5000"))
+}
+
+func TestConfirmationScreen_View_OrphansByAge(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/test/source", "/test/dest")
+	engine.Status.FilesToDelete = 42
+	engine.Status.OrphansByAge = map[string]int{syncengine.OrphanAgeDay: 2, syncengine.OrphanAgeOlder: 40}
+
+	output := screens.NewConfirmationScreen(engine, "/tmp/test-debug.log").View()
+
+	g.Expect(output).Should(ContainSubstring("Files to delete by age: 2 last day, 40 older"))
+	g.Expect(output).Should(ContainSubstring("2 of the files to delete were modified in the last week"))
+
+	// Only old orphans are expected upstream deletions, so there's nothing to warn about
+	engine.Status.OrphansByAge = map[string]int{syncengine.OrphanAgeOlder: 42}
+	g.Expect(screens.NewConfirmationScreen(engine, "").View()).ShouldNot(ContainSubstring("modified in the last week"))
+}
+
+func TestConfirmationScreen_View_SizeHistogram(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/test/source", "/test/dest")
+	engine.Status.TotalFiles = 1203
+	engine.Status.SizeHistogram = map[string]int{syncengine.SizeUnder1KB: 1200, syncengine.SizeOver1GB: 3}
+
+	output := screens.NewConfirmationScreen(engine, "/tmp/test-debug.log").View()
+
+	g.Expect(output).Should(ContainSubstring("Files to sync by size: 1200 <1KB, 3 >1GB"))
+}
+
+func TestConfirmationScreen_Move_NeedsSecondEnter(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/test/source", "/test/dest")
+	engine.Move = true
+	engine.Status.TotalFiles = 12
+	engine.Status.TotalBytes = 2 << 20
+
+	screen := screens.NewConfirmationScreen(engine, "/tmp/test-debug.log")
+	g.Expect(screen.View()).Should(ContainSubstring("Moving: 12 files (2.0 MB) will be removed from the source"))
+
+	// The first Enter only arms the move
+	model, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	g.Expect(cmd).Should(BeNil())
+	g.Expect(model.View()).Should(ContainSubstring("Press Enter again to move them"))
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	g.Expect(cmd).ShouldNot(BeNil())
+	g.Expect(cmd()).Should(BeAssignableToTypeOf(shared.ConfirmSyncMsg{}))
+}
+
+func TestConfirmationScreen_LargeDeletion_NeedsSecondEnter(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/test/source", "/test/dest")
+	engine.Status.FilesToDelete = 1234
+	engine.Status.BytesToDelete = 3 << 20
+	engine.Status.DeletionConfirmationRequired = true
+
+	screen := screens.NewConfirmationScreen(engine, "/tmp/test-debug.log")
+	g.Expect(screen.View()).Should(ContainSubstring("About to delete 1,234 files (3.0 MB) — confirm?"))
+
+	// The first Enter only arms the sync
+	model, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	g.Expect(cmd).Should(BeNil())
+	g.Expect(model.View()).Should(ContainSubstring("Press Enter again to delete them"))
+	g.Expect(engine.GetStatus().DeletionConfirmationRequired).Should(BeTrue())
+
+	// The second confirms the deletions and starts it
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	g.Expect(cmd).ShouldNot(BeNil())
+	g.Expect(cmd()).Should(BeAssignableToTypeOf(shared.ConfirmSyncMsg{}))
+	g.Expect(engine.GetStatus().DeletionConfirmationRequired).Should(BeFalse())
+}
+
 func TestNewConfirmationScreen(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
@@ -242,6 +334,37 @@ func TestNewConfirmationScreen(t *testing.T) {
 	g.Expect(screen).ShouldNot(BeNil(), "Expected screen to be created")
 }
 
+func TestConfirmationScreen_TreeViewToggle(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/test/source", "/test/dest")
+	engine.Status.TotalFiles = 2
+	engine.Status.FilesToSync = []*syncengine.FileToSync{
+		{RelativePath: "docs/a.txt", Action: syncengine.ActionCreate},
+		{RelativePath: "docs/b.txt", Action: syncengine.ActionOverwrite},
+	}
+
+	var model tea.Model = screens.NewConfirmationScreen(engine, "")
+	g.Expect(model.View()).ShouldNot(ContainSubstring("docs/"))
+
+	// t shows the tree, collapsed
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	output := model.View()
+	g.Expect(output).Should(ContainSubstring("Sync plan:"))
+	g.Expect(output).Should(ContainSubstring("docs/"))
+	g.Expect(output).Should(ContainSubstring("1 create, 1 overwrite"))
+	g.Expect(output).ShouldNot(ContainSubstring("a.txt"))
+
+	// Arrow keys navigate the tree
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	g.Expect(model.View()).Should(ContainSubstring("b.txt [overwrite]"))
+
+	// t again hides it
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	g.Expect(model.View()).ShouldNot(ContainSubstring("Sync plan:"))
+}
+
 // mustNewEngine creates a new engine and fails the test if there's an error
 func mustNewEngine(t *testing.T, source, dest string) *syncengine.Engine {
 	t.Helper()
@@ -252,3 +375,23 @@ func mustNewEngine(t *testing.T, source, dest string) *syncengine.Engine {
 
 	return engine
 }
+
+func TestConfirmationScreen_View_EstimatedSavings(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/test/source", "/test/dest")
+	engine.Status.TotalFiles = 10
+
+	g.Expect(screens.NewConfirmationScreen(engine, "").View()).ShouldNot(ContainSubstring("Estimated savings"))
+
+	engine.Status.EstimatedDedupSavings = 2 * 1024 * 1024
+	engine.Status.EstimatedSparseSavings = 1024 * 1024
+	engine.Status.EstimatedStorageWith = 7 * 1024 * 1024
+	engine.Status.EstimatedStorageWithout = 10 * 1024 * 1024
+
+	output := screens.NewConfirmationScreen(engine, "").View()
+
+	g.Expect(output).Should(ContainSubstring("Estimated savings: 2.0 MB from deduplication, 1.0 MB from sparse files"))
+	g.Expect(output).Should(ContainSubstring("Destination storage: 7.0 MB with both, 10.0 MB without"))
+}
@@ -2,6 +2,8 @@
 package screens_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -162,6 +164,30 @@ func TestEnterWithEmptyDestShowsError(t *testing.T) {
 	g.Expect(view).Should(ContainSubstring("destination path is required"), "Should show dest error message")
 }
 
+// TestEnterWithDestInsideSourceShowsError verifies that pressing Enter with a destination inside
+// the source shows the overlap instead of starting analysis
+func TestEnterWithDestInsideSourceShowsError(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	sourceDir := t.TempDir()
+	destDir := filepath.Join(sourceDir, "backup")
+	g.Expect(os.Mkdir(destDir, 0o750)).To(Succeed())
+
+	screen := screens.NewInputScreen(&config.Config{})
+
+	updatedModel, _ := screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(sourceDir)})
+	updatedModel, _ = updatedModel.Update(tea.KeyMsg{Type: tea.KeyDown})
+	updatedModel, _ = updatedModel.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(destDir)})
+
+	updatedModel, cmd := updatedModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	g.Expect(cmd).Should(BeNil(), "Enter with the destination inside the source should not trigger transition")
+
+	inputScreen, ok := updatedModel.(screens.InputScreen)
+	g.Expect(ok).Should(BeTrue())
+	g.Expect(inputScreen.View()).Should(ContainSubstring("destination is inside the source"))
+}
+
 // TestEnterWithEmptySourceShowsError verifies that pressing Enter with empty source
 // shows a validation error and focuses the source field
 func TestEnterWithEmptySourceShowsError(t *testing.T) {
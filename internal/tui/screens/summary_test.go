@@ -145,6 +145,29 @@ func TestSummaryScreenDisplaysMultipleSuggestionsFormatted(t *testing.T) {
 		"Should display multiple suggestions")
 }
 
+func TestSummaryScreenDisplaysPhaseTimings(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.PhaseDurations = map[string]time.Duration{
+		syncengine.PhaseScanSource: 2 * time.Second,
+		syncengine.PhaseScanDest:   90 * time.Second,
+		syncengine.PhaseCopy:       30 * time.Second,
+	}
+
+	screen := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "")
+	view := screen.View()
+
+	g.Expect(view).Should(ContainSubstring("Time by phase:"))
+	g.Expect(view).Should(MatchRegexp(`scan destination\s+1m 30s\s+← longest`))
+	g.Expect(view).Should(ContainSubstring("copy"))
+	g.Expect(view).ShouldNot(ContainSubstring("compare"))
+
+	// Phases are listed in the order they run
+	g.Expect(strings.Index(view, "scan source")).Should(BeNumerically("<", strings.Index(view, "scan destination")))
+}
+
 func TestSummaryScreenEscReturnsToInput(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
@@ -471,3 +494,482 @@ func TestSummaryScreenViewWithRecentlyCompleted(t *testing.T) {
 	view := screen.View()
 	g.Expect(view).Should(ContainSubstring("All files already up-to-date"))
 }
+
+func TestSummaryScreenReportsDeferredFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 3
+	engine.Status.DeferredFiles = 7
+
+	screen := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "")
+	view := screen.View()
+
+	g.Expect(view).Should(ContainSubstring("Deadline reached"))
+	g.Expect(view).Should(ContainSubstring("deferred 7 to the next run"))
+}
+
+func TestSummaryScreenWarnsAboutClockSkew(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 3
+	engine.Status.ClockSkew = -90 * time.Second
+	engine.Status.ClockSkewMeasured = true
+	engine.Status.ExcessiveClockSkew = true
+
+	screen := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "")
+	view := screen.View()
+
+	g.Expect(view).Should(ContainSubstring("Destination clock is -1m30s off"))
+}
+
+func TestSummaryScreenComparesWithPreviousRun(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 3
+	engine.Status.TotalFiles = 3
+	engine.Status.TotalFilesInSource = 120
+	engine.Status.PreviousRun = &syncengine.RunSummary{SourceFiles: 100, FilesToSync: 5}
+	engine.Status.RunDeltaWarning = "something changed"
+
+	screen := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "")
+	view := screen.View()
+
+	g.Expect(view).Should(ContainSubstring("Compared with last run"))
+	g.Expect(view).Should(ContainSubstring("100 → 120 (+20)"))
+	g.Expect(view).Should(ContainSubstring("5 → 3 (-2)"))
+	g.Expect(view).Should(ContainSubstring("something changed"))
+}
+
+func TestSummaryScreenShowsStartAndFinishTimes(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 3
+	engine.Status.StartTime = time.Date(2024, 3, 5, 22, 10, 0, 0, time.Local)
+	engine.Status.EndTime = time.Date(2024, 3, 6, 3, 38, 0, 0, time.Local)
+
+	screen := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "")
+	view := screen.View()
+
+	g.Expect(view).Should(ContainSubstring("Started Tue 22:10, finished 03:38"))
+}
+
+func TestSummaryScreenFlagsTotalsMismatch(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 1
+	engine.Status.TransferredBytes = 5
+	engine.Status.ExpectedTransferredBytes = 15
+	engine.Status.TotalsMismatch = true
+
+	screen := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "")
+	view := screen.View()
+
+	g.Expect(view).Should(ContainSubstring("Transferred 5 B but the copied files add up to 15 B"))
+}
+
+func TestSummaryScreenListsTypeChanges(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 1
+	engine.Status.TypeChanges = []string{"data (directory → file)"}
+
+	screen := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "")
+	view := screen.View()
+
+	g.Expect(view).Should(ContainSubstring("Replaced 1 destination entries whose type changed"))
+	g.Expect(view).Should(ContainSubstring("data (directory → file)"))
+}
+
+func TestSummaryScreenWarnsLoadAverageUnsupported(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 1
+	engine.Status.LoadAverageUnsupported = true
+
+	screen := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "")
+	view := screen.View()
+
+	g.Expect(view).Should(ContainSubstring("--max-load had no effect"))
+}
+
+func TestSummaryScreenNotesSkippedErrors(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 1
+	engine.Status.FailedFiles = 2
+	engine.Status.Errors = []syncengine.FileError{
+		{FilePath: "a.txt", Error: syncengine.ErrPermission},
+		{FilePath: "b.txt", Error: syncengine.ErrPermission},
+	}
+	engine.Status.SkippedErrors = 2
+
+	screen := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "")
+	view := screen.View()
+
+	g.Expect(view).Should(ContainSubstring("2 of these were in skippable categories"))
+}
+
+func TestSummaryScreenNotesSourceSnapshot(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 1
+	engine.Status.SourceSnapshot = "btrfs"
+
+	screen := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "")
+	view := screen.View()
+
+	g.Expect(view).Should(ContainSubstring("Read the source from a btrfs snapshot"))
+}
+
+func TestSummaryScreenNotesSourceIndex(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 1
+	engine.Status.SourceIndexUsed = true
+	engine.Status.StartTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.Local)
+	engine.Status.SourceIndexScannedAt = time.Date(2024, 5, 1, 9, 30, 0, 0, time.Local)
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Listed the source from the index scanned at 09:30"))
+
+	engine.Status.SourceIndexUsed = false
+	engine.Status.SourceIndexSaved = true
+
+	view = screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Scanned the source fresh and saved the listing"))
+}
+
+func TestSummaryScreenReportsConvergePasses(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 1
+	engine.Status.ConvergePasses = []syncengine.ConvergePass{{FilesToSync: 1000}, {FilesToSync: 12}, {}}
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Converged after 2 passes (files to sync: 1000 → 12 → 0)"))
+
+	engine.Status.ConvergePasses = []syncengine.ConvergePass{{FilesToSync: 1000}, {FilesToSync: 900}, {FilesToSync: 950}}
+	engine.Status.NotConverged = true
+
+	view = screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Not converged after 2 passes"))
+	g.Expect(view).ShouldNot(ContainSubstring("Converged after"))
+}
+
+func TestSummaryScreenListsProtectedDestEdits(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 1
+	engine.Status.ProtectedDestEdits = []string{"notes.txt"}
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Kept 1 destination files that look edited there"))
+	g.Expect(view).Should(ContainSubstring("notes.txt"))
+}
+
+func TestSummaryScreenShowsSizeSkippedFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 1
+	engine.Status.SizeSkippedFiles = 12
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Size filter: left out 12 files"))
+}
+
+func TestSummaryScreenShowsModTimeSkippedFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 1
+	engine.Status.ModTimeSkippedFiles = 5
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Modification time filter: left out 5 files"))
+}
+
+func TestSummaryScreenListsCaseConflicts(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 1
+	engine.Status.CaseConflicts = []syncengine.CaseConflict{
+		{Paths: []string{"README.md", "Readme.md"}, Kept: "README.md"},
+		{Paths: []string{"a.txt", "A.txt"}},
+	}
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Source paths that differ only in case (2)"))
+	g.Expect(view).Should(ContainSubstring("README.md, Readme.md → kept README.md"))
+	g.Expect(view).Should(ContainSubstring("a.txt, A.txt → skipped all"))
+}
+
+func TestSummaryScreenListsFlattenCollisions(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 2
+	engine.Status.FlattenCollisions = []syncengine.FlattenCollision{{
+		Name:  "IMG_1.jpg",
+		Paths: []string{"2023/IMG_1.jpg", "2024/IMG_1.jpg"},
+		Kept: []syncengine.SanitizedName{
+			{SourcePath: "2023/IMG_1.jpg", DestPath: "IMG_1.jpg"},
+			{SourcePath: "2024/IMG_1.jpg", DestPath: "IMG_1-1.jpg"},
+		},
+	}}
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Flattened names shared by several source files (1)"))
+	g.Expect(view).Should(ContainSubstring("2024/IMG_1.jpg as IMG_1-1.jpg"))
+}
+
+func TestSummaryScreenListsKnownFailuresApart(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 1
+	engine.Status.Errors = []syncengine.FileError{
+		{FilePath: "new.txt", Error: errors.New("permission denied")},
+		{FilePath: "pagefile.sys", Error: errors.New("file is locked"), KnownFailure: true},
+	}
+	engine.Status.KnownFailuresSkipped = []string{"hiberfil.sys"}
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Known persistent failures"))
+	g.Expect(view).Should(ContainSubstring("Skipped 1 known persistent failures"))
+	g.Expect(view).Should(ContainSubstring("hiberfil.sys"))
+}
+
+func TestSummaryScreenShowsResumeManifest(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 1
+	engine.Status.ManifestSkippedFiles = 40
+	engine.Status.ManifestRecopiedFiles = 2
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Resume manifest: skipped 40 files already copied, recopied 2 partial files"))
+}
+
+func TestSummaryScreenListsVerificationFailuresApart(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 2
+	engine.Status.VerifiedFiles = 2
+	engine.Status.Errors = []syncengine.FileError{
+		{FilePath: "photo.raw", Error: syncengine.ErrVerifyFailed},
+	}
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Verified 2 copies against the source by hash"))
+	g.Expect(view).Should(ContainSubstring("Post-copy verification failed (1)"))
+	g.Expect(view).Should(ContainSubstring("photo.raw"))
+}
+
+func TestSummaryScreenShowsPreservedSymlinks(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 3
+	engine.Status.PreservedSymlinks = 3
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Recreated 3 symlinks as links"))
+}
+
+func TestSummaryScreenShowsReconcileReport(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 3
+	engine.Status.Reconcile = &syncengine.ReconcileReport{
+		SourceFiles:    10,
+		Missing:        []string{"gone.txt"},
+		HashMismatches: []string{"changed.txt"},
+	}
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Verification:"))
+	g.Expect(view).Should(ContainSubstring("Destination doesn't mirror 2 of 10 source files"))
+	g.Expect(view).Should(ContainSubstring("Missing (1):"))
+	g.Expect(view).Should(ContainSubstring("gone.txt"))
+	g.Expect(view).Should(ContainSubstring("Hash mismatch (1):"))
+	g.Expect(view).ShouldNot(ContainSubstring("Size mismatch"))
+}
+
+func TestSummaryScreenShowsDeletionDisabled(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ProcessedFiles = 1
+	engine.Status.DeletionDisabled = true
+	engine.Status.OrphansKept = 4
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Deletion disabled: kept 4 destination files not in the source"))
+}
+
+func TestSummaryScreenShowsTrashedFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.FilesDeleted = 3
+	engine.Status.FilesTrashed = 2
+	engine.Status.TrashPath = "/trash/20260102-030405"
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Trash: moved 2 orphaned files to /trash/20260102-030405, deleted 1"))
+}
+
+func TestSummaryScreenShowsCachedHashes(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.CachedHashes = 12
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Hash cache: reused 12 source hashes from earlier runs"))
+}
+
+func TestSummaryScreenShowsAdaptiveWorkerBounds(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.MaxWorkers = 6
+	engine.Status.MinAdaptiveWorkers = 2
+	engine.Status.MaxAdaptiveWorkers = 8
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Adaptive workers: peaked at 6, allowed 2 to 8"))
+}
+
+func TestSummaryScreenShowsSparseBytes(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.SparseBytes = 3 << 20
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Left 3.0 MB of zeros as holes"))
+}
+
+func TestSummaryScreenShowsResumedCopies(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.ResumedFiles = 2
+	engine.Status.ResumedBytes = 5 << 20
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Resumed 2 partial copies, skipping 5.0 MB already copied"))
+}
+
+func TestSummaryScreenShowsMovedFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.MovedFiles = 5
+	engine.Status.RenamedFiles = 3
+
+	view := screens.NewSummaryScreen(engine, shared.StateComplete, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Moved 5 files out of the source (3 renamed, 2 copied then removed)"))
+}
+
+func TestSummaryScreenShowsVerifyReport(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.Verify = &syncengine.VerifyReport{
+		SourceFiles: 10,
+		Missing:     []string{"gone.txt"},
+		Mismatched:  []string{"changed.txt", "chmod.txt"},
+	}
+
+	view := screens.NewSummaryScreen(engine, shared.StateVerified, nil, "").View()
+
+	g.Expect(view).Should(ContainSubstring("Verification failed: 3 differences from the source (10 files checked)"))
+	g.Expect(view).Should(ContainSubstring("Missing (1):"))
+	g.Expect(view).Should(ContainSubstring("gone.txt"))
+	g.Expect(view).Should(ContainSubstring("Different (2):"))
+	g.Expect(view).ShouldNot(ContainSubstring("Extra"))
+	g.Expect(view).Should(ContainSubstring("Nothing was copied or deleted"))
+	g.Expect(view).ShouldNot(ContainSubstring("Sync Complete"))
+}
+
+func TestSummaryScreenShowsCleanVerify(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	engine.Status.Verify = &syncengine.VerifyReport{SourceFiles: 4}
+
+	content := screens.NewSummaryScreen(engine, shared.StateVerified, nil, "").RenderContent()
+
+	g.Expect(content).Should(ContainSubstring("Verified: destination mirrors the source (4 files checked)"))
+	g.Expect(content).ShouldNot(ContainSubstring("Missing"))
+}
@@ -140,6 +140,30 @@ func TestSyncScreenNew(t *testing.T) {
 	g.Expect(cmd).ShouldNot(BeNil())
 }
 
+func TestSyncScreenPKeyTogglesPause(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	engine := mustNewEngine(t, "/source", "/dest")
+	screen := screens.NewSyncScreen(engine)
+
+	pKey := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}}
+
+	updatedModel, _ := screen.Update(pKey)
+	g.Expect(engine.IsPaused()).To(BeTrue())
+
+	paused, ok := updatedModel.(screens.SyncScreen)
+	g.Expect(ok).Should(BeTrue())
+	g.Expect(paused.RenderContent()).To(ContainSubstring("Paused"))
+
+	updatedModel, _ = paused.Update(pKey)
+	g.Expect(engine.IsPaused()).To(BeFalse())
+
+	resumed, ok := updatedModel.(screens.SyncScreen)
+	g.Expect(ok).Should(BeTrue())
+	g.Expect(resumed.RenderContent()).NotTo(ContainSubstring("Paused"))
+}
+
 func TestSyncScreenRenderCancellationProgress(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
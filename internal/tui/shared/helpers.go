@@ -23,6 +23,19 @@ func FormatBytes(bytes int64) string {
 	return formatters.FormatBytes(bytes)
 }
 
+// FormatClockTime formats a wall-clock time for display as "15:04", adding the weekday
+// ("Tue 03:40") when it falls on a different day than now.
+func FormatClockTime(t, now time.Time) string {
+	t = t.Local()
+	now = now.Local()
+
+	if t.YearDay() != now.YearDay() || t.Year() != now.Year() {
+		return t.Format("Mon 15:04")
+	}
+
+	return t.Format("15:04")
+}
+
 // FormatDuration formats duration into human-readable format (e.g., "2m 30s")
 func FormatDuration(duration time.Duration) string {
 	duration = duration.Round(time.Second)
@@ -2,6 +2,7 @@ package shared_test
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
 
@@ -18,6 +19,16 @@ func TestFormatBytes(t *testing.T) {
 	g.Expect(shared.FormatBytes(1024 * 1024)).Should(Equal("1.0 MB"))
 }
 
+func TestFormatClockTime(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	now := time.Date(2024, 3, 5, 22, 10, 0, 0, time.Local)
+
+	g.Expect(shared.FormatClockTime(now.Add(time.Hour), now)).Should(Equal("23:10"))
+	g.Expect(shared.FormatClockTime(now.Add(5*time.Hour+30*time.Minute), now)).Should(Equal("Wed 03:40"))
+}
+
 func TestFormatDuration(t *testing.T) {
 	t.Parallel()
 	g := NewWithT(t)
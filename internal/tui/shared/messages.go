@@ -64,10 +64,16 @@ type TransitionToInputMsg struct{}
 
 // TransitionToSummaryMsg is sent by SyncScreen or AnalysisScreen when done
 type TransitionToSummaryMsg struct {
-	FinalState string // "complete", "cancelled", "error"
+	FinalState string // "complete", "cancelled", "error", "verified"
 	Err        error  // only set if FinalState is "error"
 }
 
+// TransitionToVerifySummaryMsg is sent by AnalysisScreen when a --verify check finishes
+type TransitionToVerifySummaryMsg struct {
+	Engine  *syncengine.Engine
+	LogPath string
+}
+
 // TransitionToSyncMsg is sent by AnalysisScreen when analysis completes
 type TransitionToSyncMsg struct {
 	Engine  *syncengine.Engine
@@ -0,0 +1,320 @@
+package shared
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/joe/copy-files/internal/syncengine"
+)
+
+// Exported constants.
+const (
+	// DefaultPlanTreeHeight is the number of rows shown when no height has been set
+	DefaultPlanTreeHeight = 15
+)
+
+// PlanTree is a collapsible directory tree of the sync plan.
+// Directories show per-action file counts and expand to show individual files.
+// Only the rows inside the visible window are rendered, so large plans stay fast.
+type PlanTree struct {
+	root   *planTreeNode
+	rows   []*planTreeNode // Visible rows in display order (children of expanded directories)
+	cursor int             // Index into rows of the selected row
+	offset int             // Index into rows of the first rendered row
+	height int             // Number of rows to render
+}
+
+// NewPlanTree builds a tree from the plan's relative paths.
+// Top-level entries are shown with every directory collapsed.
+func NewPlanTree(entries []syncengine.PlanEntry) *PlanTree {
+	root := &planTreeNode{isDir: true, expanded: true, depth: -1}
+
+	for _, entry := range entries {
+		root.add(entry)
+	}
+
+	root.sortChildren()
+
+	tree := &PlanTree{root: root, height: DefaultPlanTreeHeight}
+	tree.rebuildRows()
+
+	return tree
+}
+
+// Len returns the number of currently visible rows.
+func (t *PlanTree) Len() int {
+	return len(t.rows)
+}
+
+// SetHeight sets how many rows View renders.
+func (t *PlanTree) SetHeight(height int) {
+	t.height = max(1, height)
+	t.scrollToCursor()
+}
+
+// Update handles navigation keys and reports whether the key was used.
+// Up/down (or k/j) move, pgup/pgdown page, right (or l) expands, left (or h) collapses
+// or jumps to the parent directory, and space toggles the selected directory.
+func (t *PlanTree) Update(msg tea.KeyMsg) bool {
+	if len(t.rows) == 0 {
+		return false
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		t.moveCursor(-1)
+	case "down", "j":
+		t.moveCursor(1)
+	case "pgup":
+		t.moveCursor(-t.height)
+	case "pgdown":
+		t.moveCursor(t.height)
+	case "home":
+		t.moveCursor(-len(t.rows))
+	case "end":
+		t.moveCursor(len(t.rows))
+	case "right", "l":
+		t.setExpanded(t.rows[t.cursor], true)
+	case "left", "h":
+		t.collapseOrSelectParent()
+	case " ":
+		node := t.rows[t.cursor]
+		t.setExpanded(node, !node.expanded)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// View renders the visible window of the tree, one row per line.
+func (t *PlanTree) View() string {
+	if len(t.rows) == 0 {
+		return RenderEmptyListPlaceholder("Nothing to do")
+	}
+
+	end := min(t.offset+t.height, len(t.rows))
+
+	var builder strings.Builder
+
+	for i := t.offset; i < end; i++ {
+		line := t.rows[i].render()
+		if i == t.cursor {
+			line = LabelStyle().Render("> ") + line
+		} else {
+			line = "  " + line
+		}
+
+		builder.WriteString(line)
+		builder.WriteString("\n")
+	}
+
+	if len(t.rows) > t.height {
+		builder.WriteString(RenderDim(fmt.Sprintf("(%d-%d of %d)", t.offset+1, end, len(t.rows))))
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+// collapseOrSelectParent collapses the selected directory, or moves to its parent.
+func (t *PlanTree) collapseOrSelectParent() {
+	node := t.rows[t.cursor]
+	if node.isDir && node.expanded {
+		t.setExpanded(node, false)
+		return
+	}
+
+	if node.parent == t.root {
+		return
+	}
+
+	for i, row := range t.rows {
+		if row == node.parent {
+			t.cursor = i
+			t.scrollToCursor()
+
+			return
+		}
+	}
+}
+
+func (t *PlanTree) moveCursor(delta int) {
+	t.cursor = max(0, min(len(t.rows)-1, t.cursor+delta))
+	t.scrollToCursor()
+}
+
+// rebuildRows flattens the expanded part of the tree into display rows.
+func (t *PlanTree) rebuildRows() {
+	t.rows = t.rows[:0]
+	t.root.appendVisible(&t.rows)
+}
+
+func (t *PlanTree) scrollToCursor() {
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	}
+
+	if t.cursor >= t.offset+t.height {
+		t.offset = t.cursor - t.height + 1
+	}
+
+	t.offset = max(0, min(t.offset, len(t.rows)-t.height))
+}
+
+func (t *PlanTree) setExpanded(node *planTreeNode, expanded bool) {
+	if !node.isDir || node.expanded == expanded {
+		return
+	}
+
+	node.expanded = expanded
+	t.rebuildRows()
+	t.scrollToCursor()
+}
+
+// planTreeNode is a directory or file in the plan tree.
+type planTreeNode struct {
+	name     string
+	isDir    bool
+	expanded bool
+	depth    int
+	parent   *planTreeNode
+	children []*planTreeNode
+	byName   map[string]*planTreeNode // Child lookup while building
+
+	action syncengine.FileAction // Files only
+	size   int64
+
+	// Directory totals across all descendants
+	creates    int
+	overwrites int
+	deletes    int
+	metadata   int
+}
+
+// add inserts a plan entry under n, creating intermediate directories as needed.
+func (n *planTreeNode) add(entry syncengine.PlanEntry) {
+	parts := strings.Split(filepath.ToSlash(entry.RelativePath), "/")
+	node := n
+
+	for i, part := range parts {
+		node.count(entry.Action)
+		node.size += entry.Size
+
+		child, ok := node.byName[part]
+		if !ok {
+			child = &planTreeNode{name: part, isDir: i < len(parts)-1, depth: node.depth + 1, parent: node}
+			if node.byName == nil {
+				node.byName = make(map[string]*planTreeNode)
+			}
+
+			node.byName[part] = child
+			node.children = append(node.children, child)
+		}
+
+		// A path can be a file on one side and a directory on the other
+		if i < len(parts)-1 {
+			child.isDir = true
+		}
+
+		node = child
+	}
+
+	node.action = entry.Action
+	node.size = entry.Size
+}
+
+// appendVisible appends n's children (and the children of expanded directories) to rows.
+func (n *planTreeNode) appendVisible(rows *[]*planTreeNode) {
+	for _, child := range n.children {
+		*rows = append(*rows, child)
+		if child.isDir && child.expanded {
+			child.appendVisible(rows)
+		}
+	}
+}
+
+func (n *planTreeNode) count(action syncengine.FileAction) {
+	switch action {
+	case syncengine.ActionCreate:
+		n.creates++
+	case syncengine.ActionOverwrite:
+		n.overwrites++
+	case syncengine.ActionDelete:
+		n.deletes++
+	case syncengine.ActionMetadata:
+		n.metadata++
+	}
+}
+
+// render returns the row text: indentation, fold indicator, name and action or counts.
+func (n *planTreeNode) render() string {
+	indent := strings.Repeat("  ", n.depth)
+
+	if !n.isDir {
+		return indent + "  " + n.name + " " + renderPlanAction(n.action) + " " + RenderDim(FormatBytes(n.size))
+	}
+
+	indicator := "▸ "
+	if n.expanded {
+		indicator = "▾ "
+	}
+
+	var counts []string
+	if n.creates > 0 {
+		counts = append(counts, fmt.Sprintf("%d create", n.creates))
+	}
+
+	if n.overwrites > 0 {
+		counts = append(counts, fmt.Sprintf("%d overwrite", n.overwrites))
+	}
+
+	if n.deletes > 0 {
+		counts = append(counts, fmt.Sprintf("%d delete", n.deletes))
+	}
+
+	if n.metadata > 0 {
+		counts = append(counts, fmt.Sprintf("%d metadata", n.metadata))
+	}
+
+	return indent + indicator + RenderLabel(n.name+"/") + " " +
+		RenderDim(fmt.Sprintf("(%s, %s)", strings.Join(counts, ", "), FormatBytes(n.size)))
+}
+
+// sortChildren orders directories before files, then by name, throughout the tree.
+func (n *planTreeNode) sortChildren() {
+	n.byName = nil
+
+	sort.Slice(n.children, func(i, j int) bool {
+		a, b := n.children[i], n.children[j]
+		if a.isDir != b.isDir {
+			return a.isDir
+		}
+
+		return a.name < b.name
+	})
+
+	for _, child := range n.children {
+		child.sortChildren()
+	}
+}
+
+// renderPlanAction renders a file's action label, styled by how destructive it is.
+func renderPlanAction(action syncengine.FileAction) string {
+	label := "[" + string(action) + "]"
+
+	switch action {
+	case syncengine.ActionDelete:
+		return RenderError(label)
+	case syncengine.ActionOverwrite:
+		return RenderWarning(label)
+	case syncengine.ActionCreate:
+		return RenderSuccess(label)
+	}
+
+	return RenderDim(label)
+}
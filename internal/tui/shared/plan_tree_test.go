@@ -0,0 +1,101 @@
+package shared_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/internal/syncengine"
+	"github.com/joe/copy-files/internal/tui/shared"
+)
+
+func TestPlanTree_CollapsedDirectoriesShowCounts(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	tree := shared.NewPlanTree([]syncengine.PlanEntry{
+		{RelativePath: "photos/a.jpg", Size: 10, Action: syncengine.ActionCreate},
+		{RelativePath: "photos/b.jpg", Size: 10, Action: syncengine.ActionOverwrite},
+		{RelativePath: "photos/old.jpg", Size: 10, Action: syncengine.ActionDelete},
+		{RelativePath: "readme.txt", Size: 5, Action: syncengine.ActionCreate},
+	})
+
+	// Directories first, collapsed
+	g.Expect(tree.Len()).To(Equal(2))
+
+	view := tree.View()
+	g.Expect(view).To(ContainSubstring("▸ photos/"))
+	g.Expect(view).To(ContainSubstring("1 create, 1 overwrite, 1 delete"))
+	g.Expect(view).To(ContainSubstring("readme.txt [create]"))
+	g.Expect(view).NotTo(ContainSubstring("a.jpg"))
+}
+
+func TestPlanTree_ExpandAndCollapse(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	tree := shared.NewPlanTree([]syncengine.PlanEntry{
+		{RelativePath: "dir/sub/deep.txt", Action: syncengine.ActionCreate},
+		{RelativePath: "dir/file.txt", Action: syncengine.ActionDelete},
+	})
+
+	g.Expect(tree.Update(tea.KeyMsg{Type: tea.KeyRight})).To(BeTrue())
+	g.Expect(tree.Len()).To(Equal(3)) // dir, dir/sub, dir/file.txt
+	g.Expect(tree.View()).To(ContainSubstring("▾ dir/"))
+	g.Expect(tree.View()).To(ContainSubstring("file.txt [delete]"))
+
+	// Move to file.txt, then left jumps back to its parent directory
+	tree.Update(tea.KeyMsg{Type: tea.KeyDown})
+	tree.Update(tea.KeyMsg{Type: tea.KeyDown})
+	tree.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	g.Expect(tree.View()).To(ContainSubstring("> ▾ dir/"))
+
+	// Left on an expanded directory collapses it
+	tree.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	g.Expect(tree.Len()).To(Equal(1))
+
+	// Space toggles
+	tree.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	g.Expect(tree.Len()).To(Equal(3))
+}
+
+func TestPlanTree_RendersOnlyVisibleWindow(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	entries := make([]syncengine.PlanEntry, 0, 5000)
+	for i := range 5000 {
+		entries = append(entries, syncengine.PlanEntry{
+			RelativePath: fmt.Sprintf("file%05d.txt", i),
+			Action:       syncengine.ActionCreate,
+		})
+	}
+
+	tree := shared.NewPlanTree(entries)
+	tree.SetHeight(10)
+
+	view := tree.View()
+	g.Expect(strings.Count(view, "[create]")).To(Equal(10))
+	g.Expect(view).To(ContainSubstring("(1-10 of 5000)"))
+
+	// Scrolling past the window moves it with the cursor
+	tree.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	view = tree.View()
+	g.Expect(view).To(ContainSubstring("file04999.txt"))
+	g.Expect(view).To(ContainSubstring("(4991-5000 of 5000)"))
+}
+
+func TestPlanTree_IgnoresOtherKeys(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	tree := shared.NewPlanTree([]syncengine.PlanEntry{{RelativePath: "a.txt", Action: syncengine.ActionCreate}})
+	g.Expect(tree.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})).To(BeFalse())
+
+	empty := shared.NewPlanTree(nil)
+	g.Expect(empty.Update(tea.KeyMsg{Type: tea.KeyDown})).To(BeFalse())
+	g.Expect(empty.View()).To(ContainSubstring("Nothing to do"))
+}
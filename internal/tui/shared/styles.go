@@ -42,6 +42,7 @@ const (
 	StateDestination = "destination" // StateDestination indicates destination is the bottleneck
 	StateError       = "error"       // StateError indicates an error occurred
 	StateSource      = "source"      // StateSource indicates source is the bottleneck
+	StateVerified    = "verified"    // StateVerified indicates a --verify check finished
 	// StatusUpdateThrottleMs is the minimum interval between status updates in milliseconds
 	// Set to 100ms for smooth progress updates with minimal CPU overhead
 	StatusUpdateThrottleMs = 100
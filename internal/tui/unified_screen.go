@@ -118,6 +118,11 @@ func (u *UnifiedScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return u.transitionToSync(msg)
 	case shared.TransitionToSummaryMsg:
 		return u.transitionToSummary(msg)
+	case shared.TransitionToVerifySummaryMsg:
+		u.engine = msg.Engine
+		u.logPath = msg.LogPath
+
+		return u.transitionToSummary(shared.TransitionToSummaryMsg{FinalState: shared.StateVerified})
 	case shared.ConfirmSyncMsg:
 		return u.transitionToSync(shared.TransitionToSyncMsg(msg))
 	case shared.TransitionToInputMsg:
@@ -188,9 +193,9 @@ func (u *UnifiedScreen) renderHelpText() string {
 	case PhaseScan, PhaseCompare:
 		return shared.RenderDim("Esc to go back • Ctrl+C to exit")
 	case PhaseConfirm:
-		return shared.RenderDim("Ready to sync? Press Enter to start • Esc to cancel")
+		return shared.RenderDim(screens.ConfirmationHelpText)
 	case PhaseSync:
-		return shared.RenderDim("Esc or q to cancel • Ctrl+C to exit immediately")
+		return shared.RenderDim(screens.SyncHelpText)
 	case PhaseSummary:
 		return shared.RenderDim("Enter or q to exit • Esc for new session")
 	default:
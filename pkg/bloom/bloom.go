@@ -0,0 +1,158 @@
+// Package bloom provides a serializable Bloom filter for compact, probabilistic set membership.
+package bloom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+)
+
+// Exported constants.
+const (
+	// DefaultFalsePositiveRate is the false-positive rate used when none is given (1%)
+	DefaultFalsePositiveRate = 0.01
+)
+
+// Exported variables.
+var (
+	ErrInvalidFilter = errors.New("invalid bloom filter")
+)
+
+// Filter is a Bloom filter: Test never reports false for an added key, but may report true
+// for a key that was never added, at roughly the rate the filter was sized for.
+type Filter struct {
+	bits   []uint64
+	m      uint64 // Number of bits
+	k      uint32 // Number of hash functions
+	length uint64 // Number of keys added
+}
+
+// New returns a filter sized to hold n keys with the given false-positive rate.
+// Rates outside (0, 1) use DefaultFalsePositiveRate.
+func New(n int, falsePositiveRate float64) *Filter {
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = DefaultFalsePositiveRate
+	}
+
+	n = max(n, 1)
+
+	// Optimal sizes: m = -n ln(p) / ln(2)^2, k = m/n ln(2)
+	bitCount := math.Ceil(-float64(n) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashCount := max(1, math.Round(bitCount/float64(n)*math.Ln2))
+	m := max(uint64(bitCount), wordBits)
+
+	return &Filter{
+		bits: make([]uint64, (m+wordBits-1)/wordBits),
+		m:    m,
+		k:    uint32(hashCount),
+	}
+}
+
+// Add inserts a key.
+func (f *Filter) Add(key []byte) {
+	h1, h2 := hashes(key)
+
+	for i := range uint64(f.k) {
+		bit := (h1 + i*h2) % f.m
+		f.bits[bit/wordBits] |= 1 << (bit % wordBits)
+	}
+
+	f.length++
+}
+
+// Len returns how many keys were added.
+func (f *Filter) Len() int {
+	return int(f.length) //nolint:gosec // Key counts fit in int
+}
+
+// Test reports whether key is probably in the set. False means it definitely isn't.
+func (f *Filter) Test(key []byte) bool {
+	h1, h2 := hashes(key)
+
+	for i := range uint64(f.k) {
+		bit := (h1 + i*h2) % f.m
+		if f.bits[bit/wordBits]&(1<<(bit%wordBits)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MarshalBinary encodes the filter for storage.
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString(magic)
+
+	// Writes to a bytes.Buffer never fail
+	_ = binary.Write(&buf, binary.LittleEndian, header{M: f.m, K: f.k, Length: f.length})
+	_ = binary.Write(&buf, binary.LittleEndian, f.bits)
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a filter written by MarshalBinary.
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	reader := bytes.NewReader(data)
+
+	prefix := make([]byte, len(magic))
+
+	_, err := io.ReadFull(reader, prefix)
+	if err != nil || string(prefix) != magic {
+		return fmt.Errorf("%w: missing header", ErrInvalidFilter)
+	}
+
+	var head header
+
+	err = binary.Read(reader, binary.LittleEndian, &head)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+	}
+
+	words := (head.M + wordBits - 1) / wordBits
+	if head.M == 0 || head.K == 0 || uint64(reader.Len()) != words*wordBytes {
+		return fmt.Errorf("%w: size mismatch", ErrInvalidFilter)
+	}
+
+	bits := make([]uint64, words)
+
+	err = binary.Read(reader, binary.LittleEndian, bits)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+	}
+
+	*f = Filter{bits: bits, m: head.M, k: head.K, length: head.Length}
+
+	return nil
+}
+
+// unexported constants.
+const (
+	magic     = "GSBLOOM1"
+	wordBits  = 64
+	wordBytes = 8
+)
+
+// header is the fixed-size part of the encoding, after the magic string.
+type header struct {
+	M      uint64
+	K      uint32
+	Length uint64
+}
+
+// hashes derives the two base hashes for double hashing (Kirsch-Mitzenmacher).
+func hashes(key []byte) (uint64, uint64) {
+	hasher := fnv.New128a()
+	_, _ = hasher.Write(key) // hash.Write never returns an error
+	sum := hasher.Sum(nil)
+
+	h1 := binary.LittleEndian.Uint64(sum[:8])
+	h2 := binary.LittleEndian.Uint64(sum[8:]) | 1 // Odd, so probes don't cycle early
+
+	return h1, h2
+}
@@ -0,0 +1,69 @@
+package bloom_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/bloom"
+)
+
+func TestFilter_NoFalseNegatives(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	filter := bloom.New(1000, 0.01)
+	for i := range 1000 {
+		filter.Add(fmt.Appendf(nil, "key-%d", i))
+	}
+
+	g.Expect(filter.Len()).To(Equal(1000))
+
+	for i := range 1000 {
+		g.Expect(filter.Test(fmt.Appendf(nil, "key-%d", i))).To(BeTrue())
+	}
+}
+
+func TestFilter_FalsePositiveRateNearTarget(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	filter := bloom.New(10000, 0.01)
+	for i := range 10000 {
+		filter.Add(fmt.Appendf(nil, "present-%d", i))
+	}
+
+	falsePositives := 0
+
+	for i := range 10000 {
+		if filter.Test(fmt.Appendf(nil, "absent-%d", i)) {
+			falsePositives++
+		}
+	}
+
+	// 1% target; allow generous slack for the sample
+	g.Expect(falsePositives).To(BeNumerically("<", 200))
+}
+
+func TestFilter_MarshalRoundTrip(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	filter := bloom.New(10, 0.001)
+	filter.Add([]byte("a"))
+	filter.Add([]byte("b"))
+
+	data, err := filter.MarshalBinary()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	var decoded bloom.Filter
+
+	g.Expect(decoded.UnmarshalBinary(data)).To(Succeed())
+	g.Expect(decoded.Len()).To(Equal(2))
+	g.Expect(decoded.Test([]byte("a"))).To(BeTrue())
+	g.Expect(decoded.Test([]byte("b"))).To(BeTrue())
+
+	g.Expect(decoded.UnmarshalBinary([]byte("not a filter"))).To(MatchError(bloom.ErrInvalidFilter))
+	g.Expect(decoded.UnmarshalBinary(data[:len(data)-1])).To(MatchError(bloom.ErrInvalidFilter))
+}
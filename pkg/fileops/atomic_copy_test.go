@@ -0,0 +1,194 @@
+package fileops_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+func TestFileOps_CopyFileWithStats_RenamesTempIntoPlace(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "file.txt")
+	dst := filepath.Join(dir, "out", "file.txt")
+	g.Expect(os.WriteFile(src, []byte("new contents"), 0o600)).To(Succeed())
+	g.Expect(os.MkdirAll(filepath.Dir(dst), 0o750)).To(Succeed())
+	g.Expect(os.WriteFile(dst, []byte("old"), 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+
+	var sawTemp bool
+
+	progress := func(_, _ int64, _ string) {
+		_, err := os.Stat(dst + fileops.TempSuffix)
+		sawTemp = sawTemp || err == nil
+	}
+
+	_, err := ops.CopyFileWithStats(src, dst, progress, nil, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(sawTemp).To(BeTrue(), "the copy should be written to the temp file")
+	g.Expect(os.ReadFile(dst)).To(Equal([]byte("new contents")))
+	g.Expect(dst + fileops.TempSuffix).NotTo(BeAnExistingFile())
+}
+
+// TestFileOps_CopyFileWithStats_InterruptedCopyKeepsOldFile verifies that a cancelled copy
+// removes its temp file and leaves the previous destination file untouched.
+func TestFileOps_CopyFileWithStats_InterruptedCopyKeepsOldFile(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "big.bin")
+	dst := filepath.Join(dir, "dst.bin")
+	g.Expect(os.WriteFile(src, bytes.Repeat([]byte("x"), 4*fileops.BufferSize), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(dst, []byte("old"), 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+
+	cancelChan := make(chan struct{})
+
+	var once sync.Once
+
+	progress := func(_, _ int64, _ string) {
+		once.Do(func() { close(cancelChan) })
+	}
+
+	_, err := ops.CopyFileWithStats(src, dst, progress, cancelChan, nil)
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(os.ReadFile(dst)).To(Equal([]byte("old")))
+	g.Expect(dst + fileops.TempSuffix).NotTo(BeAnExistingFile())
+}
+
+func TestFileOps_CopyFileWithStats_InPlace(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "file.txt")
+	dst := filepath.Join(dir, "copy.txt")
+	g.Expect(os.WriteFile(src, []byte("contents"), 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+	ops.InPlace = true
+
+	var sawTemp bool
+
+	progress := func(_, _ int64, _ string) {
+		_, err := os.Stat(dst + fileops.TempSuffix)
+		sawTemp = sawTemp || err == nil
+	}
+
+	_, err := ops.CopyFileWithStats(src, dst, progress, nil, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(sawTemp).To(BeFalse())
+	g.Expect(os.ReadFile(dst)).To(Equal([]byte("contents")))
+}
+
+// TestFileOps_WriteDestFile_FailedWriteKeepsOldFile verifies that a file that can't be written
+// in full leaves the one it was replacing intact, and that a write that succeeds leaves no
+// temp file behind.
+func TestFileOps_WriteDestFile_FailedWriteKeepsOldFile(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	path := filepath.Join(t.TempDir(), "index.json")
+	g.Expect(os.WriteFile(path, []byte("old"), 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+
+	// A directory in the temp file's place makes creating it fail
+	g.Expect(os.Mkdir(path+fileops.TempSuffix, 0o750)).To(Succeed())
+	g.Expect(ops.WriteDestFile(path, []byte("new"))).NotTo(Succeed())
+	g.Expect(os.ReadFile(path)).To(Equal([]byte("old")))
+
+	g.Expect(os.Remove(path + fileops.TempSuffix)).To(Succeed())
+	g.Expect(ops.WriteDestFile(path, []byte("new"))).To(Succeed())
+	g.Expect(os.ReadFile(path)).To(Equal([]byte("new")))
+	g.Expect(path + fileops.TempSuffix).NotTo(BeAnExistingFile())
+}
+
+// TestFileOps_CopyFileWithStats_PublishOnCloseDest verifies that a destination whose files
+// appear on close gets the modification time with the contents, and that a failed copy is
+// aborted rather than closed into place.
+func TestFileOps_CopyFileWithStats_PublishOnCloseDest(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	g.Expect(os.WriteFile(src, []byte("content"), 0o600)).To(Succeed())
+
+	modTime := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	g.Expect(os.Chtimes(src, modTime, modTime)).To(Succeed())
+
+	dstFS := &publishOnCloseFS{FileSystem: filesystem.NewRealFileSystem()}
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), dstFS)
+
+	_, err := ops.CopyFileWithStats(src, filepath.Join(dir, "ok.txt"), nil, nil, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(dstFS.file.modTime.Equal(modTime)).To(BeTrue())
+	g.Expect(dstFS.file.aborted).To(BeFalse())
+	g.Expect(dstFS.chtimes).To(BeZero(), "the time went with the contents")
+
+	// The source vanishes after the first read
+	ops = fileops.NewDualFileOps(vanishingFS{FileSystem: filesystem.NewRealFileSystem()}, dstFS)
+
+	_, err = ops.CopyFileWithStats(src, filepath.Join(dir, "failed.txt"), nil, nil, nil)
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(dstFS.file.aborted).To(BeTrue())
+}
+
+// publishOnCloseFS hands out files that record their modification time and whether they were
+// aborted, as an object store's do, and counts Chtimes calls. It can't rename, so copies are
+// written in place.
+type publishOnCloseFS struct {
+	filesystem.FileSystem
+
+	file    *publishOnCloseFile
+	chtimes int
+}
+
+func (p *publishOnCloseFS) Chtimes(path string, atime, mtime time.Time) error {
+	p.chtimes++
+
+	return p.FileSystem.Chtimes(path, atime, mtime) //nolint:wrapcheck // Test wrapper
+}
+
+func (p *publishOnCloseFS) Create(path string) (filesystem.File, error) {
+	file, err := p.FileSystem.Create(path)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Test wrapper
+	}
+
+	p.file = &publishOnCloseFile{File: file}
+
+	return p.file, nil
+}
+
+type publishOnCloseFile struct {
+	filesystem.File
+
+	modTime time.Time
+	aborted bool
+}
+
+func (f *publishOnCloseFile) Abort() {
+	f.aborted = true
+}
+
+func (f *publishOnCloseFile) SetModTime(mtime time.Time) {
+	f.modTime = mtime
+}
@@ -0,0 +1,40 @@
+package fileops
+
+import "sync"
+
+// copyBufferPools holds a *sync.Pool of copy buffers for each buffer size in use, shared by
+// every FileOps so workers reuse one another's buffers.
+var copyBufferPools sync.Map
+
+// copyBufferSize returns the size of the buffers copies borrow.
+func (fo *FileOps) copyBufferSize() int {
+	if fo.CopyBufferSize > 0 {
+		return fo.CopyBufferSize
+	}
+
+	return DefaultCopyBufferSize
+}
+
+// getCopyBuffer borrows a copy buffer of fo's size. It must be given back with putCopyBuffer
+// once nothing refers to it, and not used after.
+func (fo *FileOps) getCopyBuffer() *[]byte {
+	size := fo.copyBufferSize()
+
+	pool, ok := copyBufferPools.Load(size)
+	if !ok {
+		pool, _ = copyBufferPools.LoadOrStore(size, &sync.Pool{New: func() any {
+			buf := make([]byte, size)
+			return &buf
+		}})
+	}
+
+	return pool.(*sync.Pool).Get().(*[]byte) //nolint:forcetypeassert // The pool only holds *[]byte
+}
+
+// putCopyBuffer returns a buffer borrowed with getCopyBuffer to its pool.
+func putCopyBuffer(buf *[]byte) {
+	pool, ok := copyBufferPools.Load(len(*buf))
+	if ok {
+		pool.(*sync.Pool).Put(buf) //nolint:forcetypeassert // copyBufferPools only holds *sync.Pool
+	}
+}
@@ -0,0 +1,94 @@
+package fileops_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestFileOps_CopyBufferSize_ConcurrentCopies verifies that concurrent copies sharing pooled
+// buffers, smaller than the files so each copy reuses its buffer many times, don't corrupt
+// one another's contents.
+func TestFileOps_CopyBufferSize_ConcurrentCopies(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+	ops.CopyBufferSize = 1000
+
+	const files = 16
+
+	contents := make([][]byte, files)
+
+	for i := range files {
+		contents[i] = bytes.Repeat([]byte{byte('a' + i)}, 10_000+i*333)
+		g.Expect(os.WriteFile(filepath.Join(dir, fmt.Sprintf("src%d", i)), contents[i], 0o600)).To(Succeed())
+	}
+
+	var wg sync.WaitGroup
+
+	errs := make([]error, files)
+
+	for i := range files {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			src := filepath.Join(dir, fmt.Sprintf("src%d", i))
+			_, errs[i] = ops.CopyFileWithStats(src, filepath.Join(dir, fmt.Sprintf("dst%d", i)), nil, nil, nil)
+		}()
+	}
+
+	wg.Wait()
+
+	for i := range files {
+		g.Expect(errs[i]).ShouldNot(HaveOccurred())
+
+		copied, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("dst%d", i)))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(copied).To(Equal(contents[i]))
+	}
+}
+
+// BenchmarkFileOps_CopyFileWithStats_SmallFiles copies 10,000 small files, reporting
+// allocations, which pooled copy buffers keep from growing by a buffer per file.
+func BenchmarkFileOps_CopyFileWithStats_SmallFiles(b *testing.B) {
+	const files = 10_000
+
+	sourceDir := b.TempDir()
+	content := bytes.Repeat([]byte("x"), 512)
+
+	for i := range files {
+		if err := os.WriteFile(filepath.Join(sourceDir, fmt.Sprintf("file%d", i)), content, 0o600); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+
+	b.ReportAllocs()
+
+	for range b.N {
+		destDir := b.TempDir()
+
+		for i := range files {
+			name := fmt.Sprintf("file%d", i)
+
+			_, err := ops.CopyFileWithStats(filepath.Join(sourceDir, name), filepath.Join(destDir, name), nil, nil, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package fileops
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// ByteLimiter caps the aggregate rate of bytes copied, as a token bucket holding up to one
+// second's worth of bytes. One limiter is shared by every worker, so adding or removing
+// workers never changes the aggregate rate.
+// A nil *ByteLimiter imposes no limit.
+type ByteLimiter struct {
+	mu     sync.Mutex
+	rate   float64   // Bytes per second
+	tokens float64   // Bytes available now; negative when callers have reserved ahead
+	last   time.Time // When tokens was last refilled
+}
+
+// NewByteLimiter returns a limiter allowing bytesPerSecond bytes per second,
+// or nil (unlimited) if bytesPerSecond is not positive.
+func NewByteLimiter(bytesPerSecond int64) *ByteLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+
+	return &ByteLimiter{rate: float64(bytesPerSecond), tokens: float64(bytesPerSecond), last: time.Now()}
+}
+
+// Wait blocks until n more bytes may be copied.
+// Returns ErrCancelled if cancelChan is closed first.
+func (l *ByteLimiter) Wait(n int, cancelChan <-chan struct{}) error {
+	if l == nil || n <= 0 {
+		return nil
+	}
+
+	// Take the bytes under the lock, going into debt if there aren't enough, then sleep the debt
+	// off outside it, so no worker holds the lock while waiting and waiters are served in order
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.rate, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens -= float64(n)
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-cancelChan:
+		return ErrCancelled
+	}
+}
+
+// limitedReader waits on a ByteLimiter for the bytes of each read.
+type limitedReader struct {
+	reader     io.Reader
+	limiter    *ByteLimiter
+	cancelChan <-chan struct{}
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) { //nolint:varnamelen // p is idiomatic for byte slice
+	n, err := r.reader.Read(p)
+
+	waitErr := r.limiter.Wait(n, r.cancelChan)
+	if waitErr != nil {
+		return n, waitErr
+	}
+
+	return n, err //nolint:wrapcheck // Passed through unchanged, like the reader's own errors
+}
@@ -0,0 +1,64 @@
+package fileops_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+func TestByteLimiter_CapsAggregateRate(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	limiter := fileops.NewByteLimiter(100_000) // The first 100KB are a free burst
+
+	const workers = 8
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 5 {
+				_ = limiter.Wait(3_750, nil)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// More workers don't raise the aggregate rate: 150KB is the 100KB burst, then 50KB at 100KB/s
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 450*time.Millisecond))
+}
+
+func TestByteLimiter_NilIsUnlimited(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	limiter := fileops.NewByteLimiter(0)
+	g.Expect(limiter).To(BeNil())
+	g.Expect(limiter.Wait(1<<30, nil)).To(Succeed())
+}
+
+func TestByteLimiter_Cancelled(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	limiter := fileops.NewByteLimiter(1000)
+	g.Expect(limiter.Wait(1000, nil)).To(Succeed()) // Use up the burst
+
+	cancelChan := make(chan struct{})
+	close(cancelChan)
+
+	err := limiter.Wait(1_000_000, cancelChan)
+	g.Expect(errors.Is(err, fileops.ErrCancelled)).To(BeTrue())
+}
@@ -0,0 +1,176 @@
+package fileops
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"syscall"
+)
+
+// Exported variables.
+var (
+	ErrCancelled               = errors.New("cancelled")
+	ErrCannotResume            = errors.New("can't resume partial copy")
+	ErrChmodNotSupported       = errors.New("destination filesystem does not support changing permissions")
+	ErrCopyCancelled           = fmt.Errorf("copy %w", ErrCancelled)
+	ErrDestFull                = errors.New("destination is full")
+	ErrFileLocked              = errors.New("file is locked")
+	ErrFreeInodesNotSupported  = errors.New("filesystem does not report free inodes")
+	ErrIO                      = errors.New("input/output error")
+	ErrLoadAverageNotSupported = errors.New("platform does not report a load average")
+	ErrMoveUnconfirmed         = errors.New("moved copy doesn't match its source, which was kept")
+	ErrPermission              = errors.New("permission denied")
+	ErrReflinkNotSupported     = errors.New("reflink not supported between these files")
+	ErrSourceVanished          = errors.New("source file vanished")
+	ErrSymlinkNotSupported     = errors.New("filesystem does not support symbolic links")
+	ErrTarExtractNotSupported  = errors.New("destination filesystem cannot unpack tar streams")
+	ErrXattrNotFound           = errors.New("extended attribute not set")
+	ErrXattrNotSupported       = errors.New("extended attributes not supported")
+)
+
+// ErrorCategory classifies why a file operation failed
+type ErrorCategory int
+
+// ErrorCategory values.
+const (
+	// CategoryUnknown - failure that doesn't fit a more specific category
+	CategoryUnknown ErrorCategory = iota
+	// CategoryCancelled - the operation was cancelled
+	CategoryCancelled
+	// CategorySourceVanished - the source file was removed before it could be read
+	CategorySourceVanished
+	// CategoryDestFull - the destination ran out of space
+	CategoryDestFull
+	// CategoryPermission - access to the source or destination was denied
+	CategoryPermission
+	// CategoryIO - the device reported a read or write failure
+	CategoryIO
+	// CategoryLocked - the file is busy or locked by another process
+	CategoryLocked
+)
+
+// String returns the string representation of ErrorCategory
+func (c ErrorCategory) String() string {
+	switch c {
+	case CategoryCancelled:
+		return "cancelled"
+	case CategorySourceVanished:
+		return "source vanished"
+	case CategoryDestFull:
+		return "destination full"
+	case CategoryPermission:
+		return "permission denied"
+	case CategoryIO:
+		return "I/O error"
+	case CategoryLocked:
+		return "locked"
+	case CategoryUnknown:
+		return "unknown"
+	default:
+		return "unknown"
+	}
+}
+
+// sentinel returns the exported error matching the category, or nil for CategoryUnknown
+func (c ErrorCategory) sentinel() error {
+	switch c {
+	case CategoryCancelled:
+		return ErrCancelled
+	case CategorySourceVanished:
+		return ErrSourceVanished
+	case CategoryDestFull:
+		return ErrDestFull
+	case CategoryPermission:
+		return ErrPermission
+	case CategoryIO:
+		return ErrIO
+	case CategoryLocked:
+		return ErrFileLocked
+	case CategoryUnknown:
+		return nil
+	default:
+		return nil
+	}
+}
+
+// CopyError reports a failed copy with the path involved and the failure category.
+// It matches the category's sentinel with errors.Is (e.g. errors.Is(err, ErrDestFull))
+// and still unwraps to the underlying error.
+type CopyError struct {
+	Path     string
+	Category ErrorCategory
+	Err      error
+}
+
+// Error returns the underlying error message
+func (e *CopyError) Error() string {
+	return e.Err.Error()
+}
+
+// Is reports whether target is the sentinel error for this error's category
+func (e *CopyError) Is(target error) bool {
+	sentinel := e.Category.sentinel()
+
+	return sentinel != nil && target == sentinel
+}
+
+// Unwrap returns the underlying error
+func (e *CopyError) Unwrap() error {
+	return e.Err
+}
+
+// Categorize returns the failure category of err: a CopyError's own category, or the
+// category of the underlying error for failures outside a copy (deletes, chmods).
+func Categorize(err error) ErrorCategory {
+	var copyErr *CopyError
+	if errors.As(err, &copyErr) {
+		return copyErr.Category
+	}
+
+	return categorize(err, false)
+}
+
+// categorize determines the failure category of err.
+// A missing file only means the source vanished if the source side failed.
+func categorize(err error, sourceSide bool) ErrorCategory {
+	switch {
+	case errors.Is(err, ErrCancelled):
+		return CategoryCancelled
+	case sourceSide && errors.Is(err, fs.ErrNotExist):
+		return CategorySourceVanished
+	case errors.Is(err, syscall.ENOSPC):
+		return CategoryDestFull
+	case errors.Is(err, fs.ErrPermission):
+		return CategoryPermission
+	case errors.Is(err, syscall.EIO):
+		return CategoryIO
+	case errors.Is(err, syscall.EBUSY), errors.Is(err, syscall.ETXTBSY):
+		return CategoryLocked
+	default:
+		return CategoryUnknown
+	}
+}
+
+// newDestError wraps a failure on the destination side of a copy
+func newDestError(path string, err error) *CopyError {
+	return &CopyError{Path: path, Category: categorize(err, false), Err: err}
+}
+
+// newSourceError wraps a failure on the source side of a copy
+func newSourceError(path string, err error) *CopyError {
+	return &CopyError{Path: path, Category: categorize(err, true), Err: err}
+}
+
+// newCopyError wraps a failure copying src's contents to dst: on the source side if reading src
+// failed, which the copy loops return as source CopyErrors, and on the destination side
+// otherwise.
+func newCopyError(src, dst string, err error) *CopyError {
+	wrapped := fmt.Errorf("failed to copy %s to %s: %w", src, dst, err)
+
+	var sourceErr *CopyError
+	if errors.As(err, &sourceErr) {
+		return &CopyError{Path: sourceErr.Path, Category: sourceErr.Category, Err: wrapped}
+	}
+
+	return newDestError(dst, wrapped)
+}
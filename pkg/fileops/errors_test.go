@@ -0,0 +1,129 @@
+package fileops_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+func TestCopyFileWithStats_SourceVanished(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "missing.txt")
+
+	ops := fileops.NewRealFileOps()
+	_, err := ops.CopyFileWithStats(src, filepath.Join(dir, "dst.txt"), nil, nil, nil)
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(errors.Is(err, fileops.ErrSourceVanished)).To(BeTrue())
+	g.Expect(errors.Is(err, os.ErrNotExist)).To(BeTrue(), "the underlying error must still unwrap")
+
+	var copyErr *fileops.CopyError
+	g.Expect(errors.As(err, &copyErr)).To(BeTrue())
+	g.Expect(copyErr.Path).To(Equal(src))
+	g.Expect(copyErr.Category).To(Equal(fileops.CategorySourceVanished))
+}
+
+func TestCopyFileWithStats_Cancelled(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	g.Expect(os.WriteFile(src, []byte("content"), 0o600)).To(Succeed())
+
+	cancelChan := make(chan struct{})
+	close(cancelChan)
+
+	dst := filepath.Join(dir, "dst.txt")
+
+	ops := fileops.NewRealFileOps()
+	_, err := ops.CopyFileWithStats(src, dst, nil, cancelChan, nil)
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(errors.Is(err, fileops.ErrCancelled)).To(BeTrue())
+	g.Expect(errors.Is(err, fileops.ErrCopyCancelled)).To(BeTrue())
+	g.Expect(errors.Is(err, fileops.ErrSourceVanished)).To(BeFalse())
+
+	var copyErr *fileops.CopyError
+	g.Expect(errors.As(err, &copyErr)).To(BeTrue())
+	g.Expect(copyErr.Path).To(Equal(dst))
+	g.Expect(copyErr.Category).To(Equal(fileops.CategoryCancelled))
+}
+
+func TestCopyFileWithStats_SourceVanishesMidCopy(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	g.Expect(os.WriteFile(src, []byte("content"), 0o600)).To(Succeed())
+
+	dst := filepath.Join(dir, "dst.txt")
+
+	// The first read succeeds, then the file is gone, as when it's deleted from a share mid-copy
+	ops := fileops.NewDualFileOps(vanishingFS{FileSystem: filesystem.NewRealFileSystem()}, filesystem.NewRealFileSystem())
+	_, err := ops.CopyFileWithStats(src, dst, nil, nil, nil)
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(errors.Is(err, fileops.ErrSourceVanished)).To(BeTrue())
+	g.Expect(errors.Is(err, fs.ErrNotExist)).To(BeTrue(), "the underlying error must still unwrap")
+
+	var copyErr *fileops.CopyError
+	g.Expect(errors.As(err, &copyErr)).To(BeTrue())
+	g.Expect(copyErr.Path).To(Equal(src))
+	g.Expect(copyErr.Category).To(Equal(fileops.CategorySourceVanished))
+	g.Expect(dst).NotTo(BeAnExistingFile())
+}
+
+func TestCategorize_UsesCopyErrorCategory(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	copyErr := &fileops.CopyError{Path: "a.txt", Category: fileops.CategoryDestFull, Err: errors.New("write failed")}
+	g.Expect(fileops.Categorize(fmt.Errorf("failed to copy: %w", copyErr))).To(Equal(fileops.CategoryDestFull))
+
+	// Errors from outside a copy are categorized from the underlying error
+	g.Expect(fileops.Categorize(&fs.PathError{Op: "remove", Err: syscall.EACCES})).To(Equal(fileops.CategoryPermission))
+	g.Expect(fileops.Categorize(errors.New("boom"))).To(Equal(fileops.CategoryUnknown))
+}
+
+// vanishingFS opens files that are deleted after their first read.
+type vanishingFS struct {
+	filesystem.FileSystem
+}
+
+func (v vanishingFS) Open(path string) (filesystem.File, error) {
+	file, err := v.FileSystem.Open(path)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Passes the wrapped filesystem's error through unchanged
+	}
+
+	return &vanishingFile{File: file, path: path}, nil
+}
+
+// vanishingFile fails every read after the first with fs.ErrNotExist.
+type vanishingFile struct {
+	filesystem.File
+
+	path string
+	read bool
+}
+
+func (f *vanishingFile) Read(p []byte) (int, error) {
+	if f.read {
+		return 0, &fs.PathError{Op: "read", Path: f.path, Err: fs.ErrNotExist}
+	}
+
+	f.read = true
+
+	return f.File.Read(p) //nolint:wrapcheck // Passes the wrapped file's result through unchanged
+}
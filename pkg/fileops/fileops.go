@@ -2,7 +2,7 @@
 package fileops
 
 import (
-	"crypto/sha256"
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -10,26 +10,45 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/joe/copy-files/pkg/filesystem"
 )
 
 // Exported constants.
 const (
-	// BufferSize is the size of the buffer used for file copy operations (64KB)
+	// BufferSize is the size of the buffer hashing and the os.File copy helpers read with (64KB)
 	BufferSize = 64 * 1024
+	// CompareChunkSize is how much of each file byte-by-byte comparison reads at a time (1MB),
+	// large enough that reading two files in turn doesn't lose much to seeking between them
+	CompareChunkSize = 1024 * 1024
+	// DefaultCopyBufferSize is how much of a file each copy reads and writes at a time (64KB),
+	// unless FileOps.CopyBufferSize says otherwise
+	DefaultCopyBufferSize = BufferSize
 	// DefaultDirPermissions is the default permission mode for created directories
 	DefaultDirPermissions = 0o750
+	// DefaultSampleSize is how many bytes quick-content comparison reads from each sampled region (64KB)
+	DefaultSampleSize = 64 * 1024
+	// TempSuffix is added to a destination file's name while CopyFileWithStats writes it, unless
+	// InPlace is set
+	TempSuffix = ".glowsync.tmp"
 )
 
-// Exported variables.
-var (
-	ErrCopyCancelled = errors.New("copy cancelled")
-)
+// BatchFile is one file in a batch sent by CopyFilesAsTar
+type BatchFile struct {
+	Src  string // Source path
+	Name string // Path relative to the batch's destination directory
+}
 
 // CopyStats contains timing information about a copy operation
 type CopyStats struct {
-	BytesCopied int64
-	ReadTime    time.Duration
-	WriteTime   time.Duration
+	BytesCopied  int64
+	ReadTime     time.Duration
+	WriteTime    time.Duration
+	Reflinked    bool   // Contents were cloned (copy-on-write) rather than copied
+	SourceHash   string // Hash of the contents copied, hex encoded (FileOps.HashOnCopy only)
+	HoleBytes    int64  // Zero bytes left as holes instead of written (FileOps.Sparse only)
+	ResumedBytes int64  // Bytes an earlier attempt had already copied (ResumeCopyFileWithStats only)
+	Renamed      bool   // The source was renamed into place, so nothing was copied (Move only)
 }
 
 // CountProgressCallback is called during file counting to report progress
@@ -42,19 +61,69 @@ type FileInfo struct {
 	RelativePath string
 	Size         int64
 	ModTime      time.Time
+	Mode         os.FileMode // Permission bits
 	Hash         string
 	IsDir        bool
+	IsSymlink    bool   // The link itself; links the scan followed are described as their targets
+	LinkTarget   string // Where the link points (SymlinkPreserve scans only)
+	UID          int    // Owner user ID, valid if HasOwner
+	GID          int    // Owner group ID, valid if HasOwner
+	HasOwner     bool   // Whether the scan could read the owner
 }
 
 // ProgressCallback is called during file operations to report progress
 type ProgressCallback func(bytesTransferred int64, totalBytes int64, currentFile string)
 
+// ReflinkMode controls whether copies clone the source's extents instead of copying its bytes
+type ReflinkMode string
+
+// ReflinkMode values.
+const (
+	// ReflinkNever - always copy bytes
+	ReflinkNever ReflinkMode = "never"
+	// ReflinkAuto - clone when source and destination share a copy-on-write filesystem, otherwise copy
+	ReflinkAuto ReflinkMode = "auto"
+	// ReflinkAlways - clone or fail
+	ReflinkAlways ReflinkMode = "always"
+)
+
+// SymlinkMode controls how scans and copies treat symbolic links
+type SymlinkMode string
+
+// SymlinkMode values.
+const (
+	// SymlinkFollow - scan source links as their targets, walking linked directories, and copy
+	// their targets' contents
+	SymlinkFollow SymlinkMode = "follow"
+	// SymlinkPreserve - copy links as links, pointing at the same target
+	SymlinkPreserve SymlinkMode = "preserve"
+	// SymlinkSkip - leave links out of the source scan
+	SymlinkSkip SymlinkMode = "skip"
+)
+
 // ScanProgressCallback is called during directory scanning to report progress
 // Parameters: currentPath, scannedCount, totalCount (0 if unknown), fileSize
 type ScanProgressCallback func(path string, scannedCount int, totalCount int, fileSize int64)
 
+// CompareFilesBytes reports whether two files hold the same bytes. Files of different sizes
+// differ without being read, and the rest are compared a chunk at a time, stopping at the first
+// chunk that differs.
 func CompareFilesBytes(path1, path2 string) (bool, error) {
-	// Open both files
+	// Quick size check, before opening either file
+	info1, err := os.Stat(path1)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat file %s: %w", path1, err)
+	}
+
+	info2, err := os.Stat(path2)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat file %s: %w", path2, err)
+	}
+
+	if info1.Size() != info2.Size() {
+		return false, nil
+	}
+
 	file1, err := os.Open(path1) // #nosec G304 - file path is controlled by caller
 	if err != nil {
 		return false, fmt.Errorf("failed to open file %s: %w", path1, err)
@@ -73,24 +142,7 @@ func CompareFilesBytes(path1, path2 string) (bool, error) {
 		_ = file2.Close()
 	}()
 
-	// Get file sizes
-	info1, err := file1.Stat()
-	if err != nil {
-		return false, fmt.Errorf("failed to stat file %s: %w", path1, err)
-	}
-
-	info2, err := file2.Stat()
-	if err != nil {
-		return false, fmt.Errorf("failed to stat file %s: %w", path2, err)
-	}
-
-	// Quick size check
-	if info1.Size() != info2.Size() {
-		return false, nil
-	}
-
-	// Compare byte-by-byte
-	identical, err := compareOSFileContents(file1, file2)
+	identical, err := compareContents(file1, file2, info1.Size())
 	if err != nil {
 		return false, fmt.Errorf("failed to compare %s and %s: %w", path1, path2, err)
 	}
@@ -100,6 +152,11 @@ func CompareFilesBytes(path1, path2 string) (bool, error) {
 
 // ComputeFileHash computes SHA256 hash of a file
 func ComputeFileHash(filePath string) (string, error) {
+	return ComputeFileHashWith(filePath, HashSHA256)
+}
+
+// ComputeFileHashWith computes a file's hash with the given algorithm
+func ComputeFileHashWith(filePath string, algorithm HashAlgorithm) (string, error) {
 	file, err := os.Open(filePath) // #nosec G304 - file path is controlled by caller
 	if err != nil {
 		return "", fmt.Errorf("failed to open file %s: %w", filePath, err)
@@ -109,7 +166,7 @@ func ComputeFileHash(filePath string) (string, error) {
 		_ = file.Close()
 	}()
 
-	hash := sha256.New()
+	hash := algorithm.newHash()
 
 	_, err = io.Copy(hash, file)
 	if err != nil {
@@ -179,7 +236,7 @@ func CopyFileWithStats(src, dst string, progress ProgressCallback, cancelChan <-
 
 	sourceFile, err := os.Open(src) // #nosec G304 - file path is controlled by caller
 	if err != nil {
-		return stats, fmt.Errorf("failed to open source file %s: %w", src, err)
+		return stats, newSourceError(src, fmt.Errorf("failed to open source file %s: %w", src, err))
 	}
 
 	defer func() {
@@ -189,7 +246,7 @@ func CopyFileWithStats(src, dst string, progress ProgressCallback, cancelChan <-
 	// Get source file info
 	sourceInfo, err := sourceFile.Stat()
 	if err != nil {
-		return stats, fmt.Errorf("failed to stat source file %s: %w", src, err)
+		return stats, newSourceError(src, fmt.Errorf("failed to stat source file %s: %w", src, err))
 	}
 
 	// Create destination directory if it doesn't exist
@@ -197,13 +254,13 @@ func CopyFileWithStats(src, dst string, progress ProgressCallback, cancelChan <-
 
 	err = os.MkdirAll(dstDir, DefaultDirPermissions)
 	if err != nil {
-		return stats, fmt.Errorf("failed to create destination directory %s: %w", dstDir, err)
+		return stats, newDestError(dstDir, fmt.Errorf("failed to create destination directory %s: %w", dstDir, err))
 	}
 
 	// Create destination file
 	destFile, err := os.Create(dst) // #nosec G304 - file path is controlled by caller
 	if err != nil {
-		return stats, fmt.Errorf("failed to create destination file %s: %w", dst, err)
+		return stats, newDestError(dst, fmt.Errorf("failed to create destination file %s: %w", dst, err))
 	}
 
 	// Track whether copy completed successfully
@@ -220,7 +277,7 @@ func CopyFileWithStats(src, dst string, progress ProgressCallback, cancelChan <-
 	// Copy with progress tracking and timing
 	written, err := osCopyLoopWithStats(sourceFile, destFile, stats, sourceInfo.Size(), src, progress, cancelChan)
 	if err != nil {
-		return stats, fmt.Errorf("failed to copy %s to %s: %w", src, dst, err)
+		return stats, newCopyError(src, dst, err)
 	}
 
 	stats.BytesCopied = written
@@ -234,13 +291,13 @@ func CopyFileWithStats(src, dst string, progress ProgressCallback, cancelChan <-
 	// This is important for network filesystems like SMB
 	err = destFile.Close()
 	if err != nil {
-		return stats, fmt.Errorf("failed to close destination file %s: %w", dst, err)
+		return stats, newDestError(dst, fmt.Errorf("failed to close destination file %s: %w", dst, err))
 	}
 
 	// Preserve modification time
 	err = os.Chtimes(dst, sourceInfo.ModTime(), sourceInfo.ModTime())
 	if err != nil {
-		return stats, fmt.Errorf("failed to preserve modification time for %s: %w", dst, err)
+		return stats, newDestError(dst, fmt.Errorf("failed to preserve modification time for %s: %w", dst, err))
 	}
 
 	// Mark copy as completed successfully
@@ -349,12 +406,19 @@ func ScanDirectoryWithProgress(rootPath string, progressCallback ScanProgressCal
 			return nil
 		}
 
+		uid, gid, hasOwner := filesystem.FileOwner(info)
+
 		fileInfo := &FileInfo{
 			Path:         path,
 			RelativePath: relPath,
 			Size:         info.Size(),
 			ModTime:      info.ModTime(),
+			Mode:         info.Mode().Perm(),
 			IsDir:        info.IsDir(),
+			IsSymlink:    info.Mode()&os.ModeSymlink != 0,
+			UID:          uid,
+			GID:          gid,
+			HasOwner:     hasOwner,
 		}
 
 		files[relPath] = fileInfo
@@ -388,52 +452,32 @@ func checkCancellation(cancelChan <-chan struct{}) error {
 	}
 }
 
-// CompareFilesBytes performs byte-by-byte comparison of two files
-// Returns true if files are identical, false if they differ
-// checkReadErrors checks for read errors (excluding EOF).
-func checkReadErrors(err1, err2 error) error {
-	if err1 != nil && !errors.Is(err1, io.EOF) {
-		return err1
-	}
-
-	if err2 != nil && !errors.Is(err2, io.EOF) {
-		return err2
-	}
-
-	return nil
-}
-
-// compareOSFileContents performs byte-by-byte comparison of two open os.File instances.
-func compareOSFileContents(file1, file2 *os.File) (bool, error) {
-	buf1 := make([]byte, BufferSize)
-	buf2 := make([]byte, BufferSize)
+// compareContents reports whether r1 and r2, both size bytes long, hold the same bytes. They are
+// read in lockstep a chunk at a time, stopping at the first chunk that differs, so files that
+// differ early aren't read to the end. Each chunk is filled completely before comparing, so a
+// reader returning short reads (as SFTP does) still lines up with the other.
+func compareContents(r1, r2 io.Reader, size int64) (bool, error) {
+	chunkSize := int(min(CompareChunkSize, max(size, 1)))
+	buf1 := make([]byte, chunkSize)
+	buf2 := make([]byte, chunkSize)
 
 	for {
-		n1, err1 := file1.Read(buf1) //nolint:varnamelen // n1/n2 are idiomatic for bytes read
-		n2, err2 := file2.Read(buf2) //nolint:varnamelen // n1/n2 are idiomatic for bytes read
-
-		// Check for read errors
-		err := checkReadErrors(err1, err2)
-		if err != nil {
-			return false, fmt.Errorf("failed to read file for comparison: %w", err)
+		n1, err := io.ReadFull(r1, buf1) //nolint:varnamelen // n1/n2 are idiomatic for bytes read
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return false, fmt.Errorf("failed to read from first file: %w", err)
 		}
 
-		// Compare bytes read
-		if n1 != n2 {
-			return false, nil
+		n2, err := io.ReadFull(r2, buf2) //nolint:varnamelen // n1/n2 are idiomatic for bytes read
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return false, fmt.Errorf("failed to read from second file: %w", err)
 		}
 
-		// Compare buffer contents
-		if n1 > 0 {
-			for i := range n1 {
-				if buf1[i] != buf2[i] {
-					return false, nil
-				}
-			}
+		if n1 != n2 || !bytes.Equal(buf1[:n1], buf2[:n2]) {
+			return false, nil
 		}
 
-		// Check if we've reached EOF on both files
-		if err1 == io.EOF && err2 == io.EOF {
+		// A chunk that isn't full is the end of both
+		if n1 < chunkSize {
 			return true, nil
 		}
 	}
@@ -489,7 +533,7 @@ func osCopyLoopWithStats(sourceFile, destFile *os.File, stats *CopyStats, source
 		}
 
 		if err != nil {
-			return written, fmt.Errorf("failed to read from source: %w", err)
+			return written, newSourceError(srcPath, fmt.Errorf("failed to read from source: %w", err))
 		}
 	}
 
@@ -528,7 +572,7 @@ func osSimpleCopyLoop(sourceFile, destFile *os.File, sourceSize int64, srcPath s
 		}
 
 		if err != nil {
-			return written, fmt.Errorf("failed to read from source: %w", err)
+			return written, newSourceError(srcPath, fmt.Errorf("failed to read from source: %w", err))
 		}
 	}
 
@@ -3,13 +3,14 @@ package fileops
 //go:generate impgen --target fileops.CopyFileWithStats
 
 import (
-	"crypto/sha256"
+	"archive/tar"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/joe/copy-files/pkg/filesystem"
@@ -24,6 +25,73 @@ type FileOps struct {
 	// Dual filesystem support (optional)
 	SourceFS filesystem.FileSystem // Source filesystem for copy operations
 	DestFS   filesystem.FileSystem // Destination filesystem for copy operations
+
+	// MaxDepth limits how deep scans and counts descend below the root (0 = unlimited).
+	// Entries deeper than the limit are treated as not present.
+	MaxDepth int
+
+	// CancelChan stops scans and counts early with ErrCancelled when closed (optional).
+	CancelChan <-chan struct{}
+
+	// Preallocate reserves each destination file's full size before copying (Linux only).
+	// This reduces fragmentation of large files on ext4 and XFS; elsewhere it is a no-op.
+	Preallocate bool
+
+	// Reflink clones destination files from their sources on copy-on-write filesystems (Linux only).
+	// The zero value behaves like ReflinkNever.
+	Reflink ReflinkMode
+
+	// PreservePermissions copies each source file's permission bits to its destination copy.
+	PreservePermissions bool
+
+	// CopyXattr copies this extended attribute from each source file to its destination copy
+	// (local files on Linux only). Source files without it are copied without it.
+	CopyXattr string
+
+	// OpLimiter caps filesystem operations per second across all callers (nil = unlimited).
+	// Every open, create, mkdir, stat, chtimes, chmod, rename and remove waits for it; reads and
+	// writes of file contents and directory scans don't, so it limits requests, not bandwidth.
+	OpLimiter *OpLimiter
+
+	// ByteLimiter caps the bytes per second copied across all callers (nil = unlimited).
+	// Copies and batches wait for it as they stream file contents; hashing and comparing don't.
+	ByteLimiter *ByteLimiter
+
+	// Symlinks decides how scans and copies treat symbolic links. The zero value behaves like
+	// SymlinkFollow. Destination scans never follow links, so nothing is deleted through one.
+	Symlinks SymlinkMode
+
+	// HashOnCopy hashes each source file's contents as CopyFileWithStats streams them, into
+	// CopyStats.SourceHash, so the copy can be verified without reading the source again.
+	// Reflinked copies aren't streamed, so they have no SourceHash.
+	HashOnCopy bool
+
+	// HashAlgorithm is the hash every Compute*Hash method and HashOnCopy use. The zero value
+	// behaves like HashSHA256. Hashes from different algorithms never match, so anything that
+	// keeps hashes between runs has to record which algorithm made them.
+	HashAlgorithm HashAlgorithm
+
+	// InPlace makes CopyFileWithStats write straight to the destination file. Otherwise each copy
+	// is written to a sibling temp file (name + TempSuffix), flushed to disk and renamed into
+	// place once complete, so an interrupted copy never leaves a truncated destination file.
+	// Destinations that can't rename are always written in place.
+	InPlace bool
+
+	// KeepPartial keeps the temp file of a copy that fails or is cancelled, instead of removing
+	// it, so ResumeCopyFileWithStats can carry on from it later. Copies written in place are still
+	// removed.
+	KeepPartial bool
+
+	// Sparse leaves runs of zeros in copied files as holes: all-zero blocks are seeked past
+	// instead of written, and counted in CopyStats.HoleBytes. Destination files that can't seek
+	// and truncate (e.g. SFTP) are written in full. Preallocate is skipped, as it would fill the
+	// holes; reflinked copies keep whatever holes the source has.
+	Sparse bool
+
+	// CopyBufferSize is how many bytes copies and batches read and write at a time (0 =
+	// DefaultCopyBufferSize). Buffers are pooled and reused across files and workers rather than
+	// allocated per file, so a larger size costs memory per concurrent copy, not per file.
+	CopyBufferSize int
 }
 
 // NewDualFileOps creates a new FileOps instance with separate source and destination filesystems.
@@ -45,9 +113,35 @@ func NewRealFileOps() *FileOps {
 	return &FileOps{FS: filesystem.NewRealFileSystem()}
 }
 
+// ChmodDest changes the permission bits of a destination file.
+// Returns ErrChmodNotSupported if the destination filesystem has no notion of permissions.
+func (fo *FileOps) ChmodDest(path string, mode os.FileMode) error {
+	chmoder, ok := fo.getDestFS().(filesystem.Chmoder)
+	if !ok {
+		return ErrChmodNotSupported
+	}
+
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return fmt.Errorf("failed to change mode for %s: %w", path, err)
+	}
+
+	err = chmoder.Chmod(path, mode)
+	if err != nil {
+		return fmt.Errorf("failed to change mode for %s: %w", path, err)
+	}
+
+	return nil
+}
+
 // Chtimes changes the access and modification times of a file
 func (fo *FileOps) Chtimes(path string, atime, mtime time.Time) error {
-	err := fo.FS.Chtimes(path, atime, mtime)
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return fmt.Errorf("failed to change times for %s: %w", path, err)
+	}
+
+	err = fo.FS.Chtimes(path, atime, mtime)
 	if err != nil {
 		return fmt.Errorf("failed to change times for %s: %w", path, err)
 	}
@@ -55,53 +149,90 @@ func (fo *FileOps) Chtimes(path string, atime, mtime time.Time) error {
 	return nil
 }
 
-func (fo *FileOps) CompareFilesBytes(path1, path2 string) (bool, error) {
-	// Open both files
-	file1, err := fo.FS.Open(path1)
+// CompareFilesBytes reports whether a source file and a destination file hold the same bytes.
+// Files of different sizes differ without being read, and the rest are compared a chunk at a
+// time, stopping at the first chunk that differs.
+func (fo *FileOps) CompareFilesBytes(srcPath, dstPath string) (bool, error) {
+	// Quick size check, before opening either file
+	srcInfo, err := fo.Stat(srcPath)
 	if err != nil {
-		return false, fmt.Errorf("failed to open file %s: %w", path1, err)
+		return false, err
+	}
+
+	dstInfo, err := fo.StatDest(dstPath)
+	if err != nil {
+		return false, err
+	}
+
+	if srcInfo.Size() != dstInfo.Size() {
+		return false, nil
+	}
+
+	err = fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return false, fmt.Errorf("failed to open file %s: %w", srcPath, err)
+	}
+
+	srcFile, err := fo.getSourceFS().Open(srcPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to open file %s: %w", srcPath, err)
 	}
 
 	defer func() {
-		_ = file1.Close()
+		_ = srcFile.Close()
 	}()
 
-	file2, err := fo.FS.Open(path2)
+	err = fo.OpLimiter.Wait(fo.CancelChan)
 	if err != nil {
-		return false, fmt.Errorf("failed to open file %s: %w", path2, err)
+		return false, fmt.Errorf("failed to open file %s: %w", dstPath, err)
+	}
+
+	dstFile, err := fo.getDestFS().Open(dstPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to open file %s: %w", dstPath, err)
 	}
 
 	defer func() {
-		_ = file2.Close()
+		_ = dstFile.Close()
 	}()
 
-	// Get file sizes
-	info1, err := file1.Stat()
+	identical, err := compareContents(srcFile, dstFile, srcInfo.Size())
 	if err != nil {
-		return false, fmt.Errorf("failed to stat file %s: %w", path1, err)
+		return false, fmt.Errorf("failed to compare %s and %s: %w", srcPath, dstPath, err)
 	}
 
-	info2, err := file2.Stat()
+	return identical, nil
+}
+
+// ComputeDestHash computes the HashAlgorithm hash of a file on the destination filesystem.
+// Returns ErrCopyCancelled if cancelChan is closed before hashing finishes.
+func (fo *FileOps) ComputeDestHash(filePath string, cancelChan <-chan struct{}) (string, error) {
+	err := fo.OpLimiter.Wait(cancelChan)
 	if err != nil {
-		return false, fmt.Errorf("failed to stat file %s: %w", path2, err)
+		return "", fmt.Errorf("failed to open file %s: %w", filePath, err)
 	}
 
-	// Quick size check
-	if info1.Size() != info2.Size() {
-		return false, nil
-	}
+	return hashFileFS(fo.getDestFS(), filePath, fo.HashAlgorithm, cancelChan)
+}
 
-	// Compare byte-by-byte
-	identical, err := fo.compareFileContents(file1, file2)
+// ComputeDestSampleHash computes a hash of a destination file's size and sampled regions.
+// See ComputeSourceSampleHash.
+func (fo *FileOps) ComputeDestSampleHash(filePath string, sampleSize int64) (string, error) {
+	err := fo.OpLimiter.Wait(fo.CancelChan)
 	if err != nil {
-		return false, fmt.Errorf("failed to compare %s and %s: %w", path1, path2, err)
+		return "", fmt.Errorf("failed to open file %s: %w", filePath, err)
 	}
 
-	return identical, nil
+	return sampleHashFS(fo.getDestFS(), filePath, fo.HashAlgorithm, sampleSize)
 }
 
-// ComputeFileHash computes SHA256 hash of a file.
+// ComputeFileHash computes the HashAlgorithm hash of a file.
 func (fo *FileOps) ComputeFileHash(filePath string) (string, error) {
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+
 	file, err := fo.FS.Open(filePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to open file %s: %w", filePath, err)
@@ -111,7 +242,7 @@ func (fo *FileOps) ComputeFileHash(filePath string) (string, error) {
 		_ = file.Close()
 	}()
 
-	hash := sha256.New()
+	hash := fo.HashAlgorithm.newHash()
 
 	_, err = io.Copy(hash, file)
 	if err != nil {
@@ -121,11 +252,39 @@ func (fo *FileOps) ComputeFileHash(filePath string) (string, error) {
 	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
+// ComputeSourceHash computes the HashAlgorithm hash of a file on the source filesystem.
+// Returns ErrCopyCancelled if cancelChan is closed before hashing finishes.
+func (fo *FileOps) ComputeSourceHash(filePath string, cancelChan <-chan struct{}) (string, error) {
+	err := fo.OpLimiter.Wait(cancelChan)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+
+	return hashFileFS(fo.getSourceFS(), filePath, fo.HashAlgorithm, cancelChan)
+}
+
+// ComputeSourceSampleHash computes a HashAlgorithm hash of a source file's size and its first, middle
+// and last sampleSize bytes (the whole file if it is smaller than three samples).
+// Two files with equal sample hashes can still differ in the unsampled parts.
+func (fo *FileOps) ComputeSourceSampleHash(filePath string, sampleSize int64) (string, error) {
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+
+	return sampleHashFS(fo.getSourceFS(), filePath, fo.HashAlgorithm, sampleSize)
+}
+
 func (fo *FileOps) CopyFile(src, dst string, progress ProgressCallback) (int64, error) {
 	// Get source and destination filesystems
 	srcFS := fo.getSourceFS()
 	dstFS := fo.getDestFS()
 
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open source file %s: %w", src, err)
+	}
+
 	sourceFile, err := srcFS.Open(src)
 	if err != nil {
 		return 0, fmt.Errorf("failed to open source file %s: %w", src, err)
@@ -144,18 +303,34 @@ func (fo *FileOps) CopyFile(src, dst string, progress ProgressCallback) (int64,
 	// Create destination directory if it doesn't exist
 	dstDir := filepath.Dir(dst)
 
-	err = dstFS.MkdirAll(dstDir, DefaultDirPermissions)
+	err = fo.OpLimiter.Wait(fo.CancelChan)
+	if err == nil {
+		err = dstFS.MkdirAll(dstDir, DefaultDirPermissions)
+	}
+
 	if err != nil {
 		return 0, fmt.Errorf("failed to create destination directory %s: %w", dstDir, err)
 	}
 
 	// Create destination file
+	err = fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create destination file %s: %w", dst, err)
+	}
+
 	destFile, err := dstFS.Create(dst)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create destination file %s: %w", dst, err)
 	}
 
+	stamped := setModTime(destFile, sourceInfo.ModTime())
+	copied := false
+
 	defer func() {
+		if !copied {
+			abortFile(destFile)
+		}
+
 		_ = destFile.Close()
 	}()
 
@@ -165,8 +340,16 @@ func (fo *FileOps) CopyFile(src, dst string, progress ProgressCallback) (int64,
 		return written, fmt.Errorf("failed to copy %s to %s: %w", src, dst, err)
 	}
 
+	copied = true
+
 	// Preserve modification time
-	err = dstFS.Chtimes(dst, sourceInfo.ModTime(), sourceInfo.ModTime())
+	if !stamped {
+		err = fo.OpLimiter.Wait(fo.CancelChan)
+		if err == nil {
+			err = dstFS.Chtimes(dst, sourceInfo.ModTime(), sourceInfo.ModTime())
+		}
+	}
+
 	if err != nil {
 		return written, fmt.Errorf("failed to preserve modification time for %s: %w", dst, err)
 	}
@@ -178,17 +361,32 @@ func (fo *FileOps) CopyFile(src, dst string, progress ProgressCallback) (int64,
 // If cancelChan is provided and closed, the copy will be aborted.
 // If onDataComplete is provided, it will be called after data transfer but before file close/chtimes.
 //
-//nolint:lll,funlen // Long function signature with channel parameter; function handles file copy with finalization callback
+//nolint:lll // Long function signature with channel parameter
 func (fo *FileOps) CopyFileWithStats(src, dst string, progress ProgressCallback, cancelChan <-chan struct{}, onDataComplete func()) (*CopyStats, error) {
+	return fo.ResumeCopyFileWithStats(src, dst, 0, progress, cancelChan, onDataComplete)
+}
+
+// ResumeCopyFileWithStats is CopyFileWithStats carrying on from the partial copy that an earlier
+// attempt left in dst's temp file (see KeepPartial and PartialCopySize): the first offset bytes
+// of src are skipped, the rest appended to the temp file, and progress counts up from offset.
+// The temp file must still be offset bytes long. An offset of 0 copies from the start.
+//
+//nolint:lll,funlen // Long function signature with channel parameter; function handles file copy with finalization callback
+func (fo *FileOps) ResumeCopyFileWithStats(src, dst string, offset int64, progress ProgressCallback, cancelChan <-chan struct{}, onDataComplete func()) (*CopyStats, error) {
 	stats := &CopyStats{}
 
 	// Get source and destination filesystems
 	srcFS := fo.getSourceFS()
 	dstFS := fo.getDestFS()
 
+	err := fo.OpLimiter.Wait(cancelChan)
+	if err != nil {
+		return stats, newSourceError(src, fmt.Errorf("failed to open source file %s: %w", src, err))
+	}
+
 	sourceFile, err := srcFS.Open(src)
 	if err != nil {
-		return stats, fmt.Errorf("failed to open source file %s: %w", src, err)
+		return stats, newSourceError(src, fmt.Errorf("failed to open source file %s: %w", src, err))
 	}
 
 	defer func() {
@@ -198,38 +396,74 @@ func (fo *FileOps) CopyFileWithStats(src, dst string, progress ProgressCallback,
 	// Get source file info
 	sourceInfo, err := sourceFile.Stat()
 	if err != nil {
-		return stats, fmt.Errorf("failed to stat source file %s: %w", src, err)
+		return stats, newSourceError(src, fmt.Errorf("failed to stat source file %s: %w", src, err))
 	}
 
 	// Create destination directory if it doesn't exist
 	dstDir := filepath.Dir(dst)
 
-	err = dstFS.MkdirAll(dstDir, DefaultDirPermissions)
+	err = fo.OpLimiter.Wait(cancelChan)
+	if err == nil {
+		err = dstFS.MkdirAll(dstDir, DefaultDirPermissions)
+	}
+
 	if err != nil {
-		return stats, fmt.Errorf("failed to create destination directory %s: %w", dstDir, err)
+		return stats, newDestError(dstDir, fmt.Errorf("failed to create destination directory %s: %w", dstDir, err))
+	}
+
+	// Write to a temp file beside dst when it can be renamed into place, so dst is only ever
+	// replaced by a complete copy
+	renamer, atomic := dstFS.(filesystem.Renamer)
+	atomic = atomic && !fo.InPlace
+
+	writePath := dst
+	if atomic {
+		writePath = dst + TempSuffix
+	}
+
+	if offset > 0 && !atomic {
+		return stats, newDestError(dst, fmt.Errorf("%w: %s is written in place", ErrCannotResume, dst))
 	}
 
 	// Create destination file
-	destFile, err := dstFS.Create(dst)
+	err = fo.OpLimiter.Wait(cancelChan)
+	if err != nil {
+		return stats, newDestError(dst, fmt.Errorf("failed to create destination file %s: %w", dst, err))
+	}
+
+	var destFile filesystem.File
+	if offset > 0 {
+		destFile, err = openPartial(dstFS, writePath, offset)
+	} else {
+		destFile, err = dstFS.Create(writePath)
+	}
+
 	if err != nil {
-		return stats, fmt.Errorf("failed to create destination file %s: %w", dst, err)
+		return stats, newDestError(dst, fmt.Errorf("failed to create destination file %s: %w", writePath, err))
 	}
 
+	stamped := setModTime(destFile, sourceInfo.ModTime())
+
 	// Track whether copy completed successfully
 	copyCompleted := false
 
 	defer func() {
-		_ = destFile.Close()
-		// If copy was cancelled or failed, delete the partial file
 		if !copyCompleted {
-			_ = dstFS.Remove(dst)
+			abortFile(destFile)
+		}
+
+		_ = destFile.Close()
+		// If copy was cancelled or failed, delete the partial file, unless it's a temp file
+		// being kept to resume from
+		if !copyCompleted && (!atomic || !fo.KeepPartial) {
+			_ = fo.OpLimiter.Wait(nil)
+			_ = dstFS.Remove(writePath)
 		}
 	}()
 
-	// Copy with progress tracking and timing
-	written, err := fo.copyLoop(sourceFile, destFile, stats, sourceInfo.Size(), src, progress, cancelChan)
+	written, err := fo.copyContents(sourceFile, destFile, stats, sourceInfo.Size(), src, offset, progress, cancelChan)
 	if err != nil {
-		return stats, fmt.Errorf("failed to copy %s to %s: %w", src, dst, err)
+		return stats, newCopyError(src, dst, err)
 	}
 
 	stats.BytesCopied = written
@@ -239,17 +473,57 @@ func (fo *FileOps) CopyFileWithStats(src, dst string, progress ProgressCallback,
 		onDataComplete()
 	}
 
+	// The rename must not land before the data it names, or a crash could still leave a
+	// truncated dst
+	if atomic {
+		err = syncFile(destFile)
+		if err != nil {
+			return stats, newDestError(dst, fmt.Errorf("failed to flush destination file %s: %w", writePath, err))
+		}
+	}
+
 	// Close the file before setting modification time
 	// This is important for network filesystems like SMB
 	err = destFile.Close()
 	if err != nil {
-		return stats, fmt.Errorf("failed to close destination file %s: %w", dst, err)
+		return stats, newDestError(dst, fmt.Errorf("failed to close destination file %s: %w", writePath, err))
 	}
 
 	// Preserve modification time
-	err = dstFS.Chtimes(dst, sourceInfo.ModTime(), sourceInfo.ModTime())
-	if err != nil {
-		return stats, fmt.Errorf("failed to preserve modification time for %s: %w", dst, err)
+	if !stamped {
+		err = fo.OpLimiter.Wait(cancelChan)
+		if err == nil {
+			err = dstFS.Chtimes(writePath, sourceInfo.ModTime(), sourceInfo.ModTime())
+		}
+
+		if err != nil {
+			return stats, newDestError(dst, fmt.Errorf("failed to preserve modification time for %s: %w", dst, err))
+		}
+	}
+
+	if fo.PreservePermissions {
+		err = fo.ChmodDest(writePath, sourceInfo.Mode().Perm())
+		if err != nil {
+			return stats, newDestError(dst, fmt.Errorf("failed to preserve permissions for %s: %w", dst, err))
+		}
+	}
+
+	if fo.CopyXattr != "" {
+		err = copyXattr(src, writePath, fo.CopyXattr)
+		if err != nil {
+			return stats, newDestError(dst, fmt.Errorf("failed to copy %s to %s: %w", fo.CopyXattr, dst, err))
+		}
+	}
+
+	if atomic {
+		err = fo.OpLimiter.Wait(cancelChan)
+		if err == nil {
+			err = renamer.Rename(writePath, dst)
+		}
+
+		if err != nil {
+			return stats, newDestError(dst, fmt.Errorf("failed to move copy into place at %s: %w", dst, err))
+		}
 	}
 
 	// Mark copy as completed successfully
@@ -258,11 +532,141 @@ func (fo *FileOps) CopyFileWithStats(src, dst string, progress ProgressCallback,
 	return stats, nil
 }
 
+// setModTime records mtime with file's contents, if its filesystem can, reporting whether it did
+// so the copy can skip a separate Chtimes.
+func setModTime(file filesystem.File, mtime time.Time) bool {
+	setter, ok := file.(filesystem.ModTimeSetter)
+	if ok {
+		setter.SetModTime(mtime)
+	}
+
+	return ok
+}
+
+// abortFile discards a failed copy's file before it is closed, if closing would publish it.
+func abortFile(file filesystem.File) {
+	if aborter, ok := file.(filesystem.Aborter); ok {
+		aborter.Abort()
+	}
+}
+
+// syncFile flushes file to stable storage, if its filesystem can.
+func syncFile(file filesystem.File) error {
+	syncer, ok := file.(interface{ Sync() error })
+	if !ok {
+		return nil
+	}
+
+	err := syncer.Sync()
+	if errors.Is(err, errors.ErrUnsupported) {
+		return nil
+	}
+
+	return err //nolint:wrapcheck // Wrapped by the caller with the file's path
+}
+
+// CopyFilesAsTar sends files to dstDir as a single tar stream that the destination unpacks in one
+// operation, replacing existing files. Parent directories are created as needed and modification
+// times are preserved. Returns ErrTarExtractNotSupported if the destination can't unpack tar streams.
+// A failed batch may leave some of its files written.
+func (fo *FileOps) CopyFilesAsTar(files []BatchFile, dstDir string, cancelChan <-chan struct{}) (*CopyStats, error) {
+	stats := &CopyStats{}
+
+	extractor, ok := fo.getDestFS().(filesystem.TarExtractor)
+	if !ok {
+		return stats, ErrTarExtractNotSupported
+	}
+
+	err := fo.OpLimiter.Wait(cancelChan)
+	if err != nil {
+		return stats, fmt.Errorf("failed to send batch to %s: %w", dstDir, err)
+	}
+
+	reader, writer := io.Pipe()
+	writeErr := make(chan error, 1)
+
+	go func() {
+		err := fo.writeTarBatch(writer, files, stats, cancelChan)
+		_ = writer.CloseWithError(err)
+		writeErr <- err
+	}()
+
+	err = extractor.ExtractTar(dstDir, reader)
+
+	// Unblock the writer if the destination stopped reading early
+	_ = reader.CloseWithError(io.ErrClosedPipe)
+
+	// The writer's error names the file that failed, so prefer it
+	if werr := <-writeErr; werr != nil {
+		return stats, werr
+	}
+
+	if err != nil {
+		return stats, newDestError(dstDir, fmt.Errorf("failed to unpack batch in %s: %w", dstDir, err))
+	}
+
+	return stats, nil
+}
+
+// CopySymlink recreates the symbolic link src at dst, pointing at the same target, replacing
+// any file or link already at dst. The target is copied as written, so relative targets resolve
+// against dst's directory. Returns ErrSymlinkNotSupported if either filesystem lacks links.
+func (fo *FileOps) CopySymlink(src, dst string) error {
+	dstFS := fo.getDestFS()
+
+	linker, ok := dstFS.(filesystem.Symlinker)
+	if !ok {
+		return ErrSymlinkNotSupported
+	}
+
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return fmt.Errorf("failed to read link %s: %w", src, err)
+	}
+
+	target, err := readlink(fo.getSourceFS(), src)
+	if err != nil {
+		return err
+	}
+
+	dstDir := filepath.Dir(dst)
+
+	err = fo.OpLimiter.Wait(fo.CancelChan)
+	if err == nil {
+		err = dstFS.MkdirAll(dstDir, DefaultDirPermissions)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to create destination directory %s: %w", dstDir, err)
+	}
+
+	// Like ln -sf: the link replaces the old entry, which is never written through
+	err = fo.OpLimiter.Wait(fo.CancelChan)
+	if err == nil {
+		err = dstFS.Remove(dst)
+	}
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to replace %s: %w", dst, err)
+	}
+
+	_ = fo.OpLimiter.Wait(nil) // The old entry is gone, so the link must follow
+
+	err = linker.Symlink(target, dst)
+	if err != nil {
+		return fmt.Errorf("failed to link %s to %s: %w", dst, target, err)
+	}
+
+	return nil
+}
+
 // CountDestFilesWithProgress counts destination files with progress reporting.
 // Used for dual-filesystem operations where source and dest are different.
+// Temp files of unfinished copies (TempSuffix) aren't counted, so one can't stand in for the
+// file it was meant to become.
 func (fo *FileOps) CountDestFilesWithProgress(rootPath string, progressCallback CountProgressCallback) (int, error) {
-	fs := fo.getDestFS()
-	return fo.countFilesWithProgressFS(fs, rootPath, progressCallback)
+	return fo.countFilesWithProgressFS(&tempSkippingScanner{FileScanner: fo.getDestFS().Scan(rootPath)}, rootPath,
+		progressCallback)
 }
 
 // CountFiles quickly counts the total number of files/directories in a path.
@@ -273,15 +677,58 @@ func (fo *FileOps) CountFiles(rootPath string) (int, error) {
 // CountFilesWithProgress counts files with progress reporting.
 // Uses fo.FS for single-filesystem operations, or fo.getSourceFS() for dual-filesystem.
 func (fo *FileOps) CountFilesWithProgress(rootPath string, progressCallback CountProgressCallback) (int, error) {
-	fs := fo.getSourceFS()
-	return fo.countFilesWithProgressFS(fs, rootPath, progressCallback)
+	return fo.countFilesWithProgressFS(fo.scanSource(rootPath), rootPath, progressCallback)
+}
+
+// MkdirAllDest creates a directory on the destination filesystem, along with any parents it needs.
+func (fo *FileOps) MkdirAllDest(path string, perm os.FileMode) error {
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", path, err)
+	}
+
+	err = fo.getDestFS().MkdirAll(path, perm)
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// ReadDestFile reads a whole file from the destination filesystem.
+func (fo *FileOps) ReadDestFile(path string) ([]byte, error) {
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	file, err := fo.getDestFS().Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return data, nil
 }
 
 // Remove removes a file or empty directory.
 // Uses fo.FS for single-filesystem operations, or fo.getSourceFS() for dual-filesystem.
 func (fo *FileOps) Remove(path string) error {
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+
 	fs := fo.getSourceFS()
-	err := fs.Remove(path)
+	err = fs.Remove(path)
 	if err != nil {
 		return fmt.Errorf("failed to remove %s: %w", path, err)
 	}
@@ -292,8 +739,13 @@ func (fo *FileOps) Remove(path string) error {
 // RemoveFromDest removes a file or empty directory from the destination filesystem.
 // Used for dual-filesystem operations where source and dest are different.
 func (fo *FileOps) RemoveFromDest(path string) error {
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+
 	fs := fo.getDestFS()
-	err := fs.Remove(path)
+	err = fs.Remove(path)
 	if err != nil {
 		return fmt.Errorf("failed to remove %s: %w", path, err)
 	}
@@ -301,13 +753,37 @@ func (fo *FileOps) RemoveFromDest(path string) error {
 	return nil
 }
 
+// RenameDest moves a destination file to newPath, creating newPath's parent directories and
+// replacing any file there. A file that can't be renamed is copied and removed instead; see
+// TrashDest.
+func (fo *FileOps) RenameDest(path, newPath string) error {
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return fmt.Errorf("failed to rename %s: %w", path, err)
+	}
+
+	dstFS := fo.getDestFS()
+
+	err = dstFS.MkdirAll(filepath.Dir(newPath), DefaultDirPermissions)
+	if err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(newPath), err)
+	}
+
+	err = fo.moveWithinDest(path, newPath)
+	if err != nil {
+		return fmt.Errorf("failed to rename %s to %s: %w", path, newPath, err)
+	}
+
+	return nil
+}
+
 // ScanDestDirectoryWithProgress recursively scans destination directory with progress reporting.
 // Used for dual-filesystem operations where source and dest are different.
 //
 //nolint:lll // Long function signature with callback parameter
 func (fo *FileOps) ScanDestDirectoryWithProgress(rootPath string, progressCallback ScanProgressCallback) (map[string]*FileInfo, error) {
 	fs := fo.getDestFS()
-	return fo.scanDirectoryWithProgressFS(fs, rootPath, progressCallback)
+	return fo.scanDirectoryWithProgressFS(fs, fs.Scan(rootPath), rootPath, progressCallback)
 }
 
 // ScanDirectory recursively scans a directory and returns file information.
@@ -320,13 +796,17 @@ func (fo *FileOps) ScanDirectory(rootPath string) (map[string]*FileInfo, error)
 //
 //nolint:lll // Long function signature with callback parameter
 func (fo *FileOps) ScanDirectoryWithProgress(rootPath string, progressCallback ScanProgressCallback) (map[string]*FileInfo, error) {
-	fs := fo.getSourceFS()
-	return fo.scanDirectoryWithProgressFS(fs, rootPath, progressCallback)
+	return fo.scanDirectoryWithProgressFS(fo.getSourceFS(), fo.scanSource(rootPath), rootPath, progressCallback)
 }
 
-// Stat returns file information
+// Stat returns file information from the source filesystem
 func (fo *FileOps) Stat(path string) (os.FileInfo, error) {
-	info, err := fo.FS.Stat(path)
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	info, err := fo.getSourceFS().Stat(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
 	}
@@ -334,49 +814,114 @@ func (fo *FileOps) Stat(path string) (os.FileInfo, error) {
 	return info, nil
 }
 
-// compareFileContents performs byte-by-byte comparison of two open files.
-func (fo *FileOps) compareFileContents(file1, file2 filesystem.File) (bool, error) {
-	buf1 := make([]byte, BufferSize)
-	buf2 := make([]byte, BufferSize)
-
-	for {
-		//nolint:varnamelen // n1/n2 are idiomatic for bytes read
-		n1, err1 := file1.Read(buf1)
-		n2, err2 := file2.Read(buf2)
-
-		if n1 != n2 {
-			return false, nil
-		}
+// StatDest returns file information from the destination filesystem.
+// Used for dual-filesystem operations where source and dest are different.
+func (fo *FileOps) StatDest(path string) (os.FileInfo, error) {
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
 
-		if n1 > 0 && !compareByteBuffers(buf1, buf2, n1) {
-			return false, nil
-		}
+	info, err := fo.getDestFS().Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
 
-		if errors.Is(err1, io.EOF) && errors.Is(err2, io.EOF) {
-			return true, nil
-		}
+	return info, nil
+}
 
-		if err1 != nil {
-			return false, fmt.Errorf("failed to read from first file: %w", err1)
-		}
+// WriteDestFile creates or replaces a file on the destination filesystem, creating parent directories.
+// Where the destination can rename, the data goes to a temp file beside path that then replaces it,
+// so a crash or full disk leaves the previous file intact rather than truncated.
+// It ignores CancelChan so state can still be saved after a sync is cancelled.
+func (fo *FileOps) WriteDestFile(path string, data []byte) error {
+	return fo.writeFile(fo.getDestFS(), path, data)
+}
 
-		if err2 != nil {
-			return false, fmt.Errorf("failed to read from second file: %w", err2)
-		}
-	}
+// WriteSourceFile is WriteDestFile for the source filesystem. Syncs never write to the source;
+// it is only for probes such as the clock skew check.
+func (fo *FileOps) WriteSourceFile(path string, data []byte) error {
+	return fo.writeFile(fo.getSourceFS(), path, data)
 }
 
-// copyLoop performs the actual file copy with progress tracking and timing.
+// copyContents fills destFile from sourceFile, cloning it when Reflink allows and falling back
+// to a byte copy (preallocated or sparse if requested) when ReflinkAuto can't clone.
 //
 //nolint:lll // Long function signature with many parameters including channel
-func (fo *FileOps) copyLoop(sourceFile filesystem.File, destFile filesystem.File, stats *CopyStats, sourceSize int64, srcPath string, progress ProgressCallback, cancelChan <-chan struct{}) (int64, error) {
-	var written int64
+func (fo *FileOps) copyContents(sourceFile filesystem.File, destFile filesystem.File, stats *CopyStats, sourceSize int64, srcPath string, offset int64, progress ProgressCallback, cancelChan <-chan struct{}) (int64, error) {
+	// A resumed copy appends to what's there, so it can't be cloned or preallocated afresh
+	if offset > 0 {
+		return fo.copyLoop(sourceFile, destFile, stats, sourceSize, srcPath, offset, progress, cancelChan)
+	}
+
+	if fo.Reflink == ReflinkAuto || fo.Reflink == ReflinkAlways {
+		err := reflink(sourceFile, destFile)
+		if err == nil {
+			stats.Reflinked = true
+
+			if progress != nil {
+				progress(sourceSize, sourceSize, srcPath)
+			}
+
+			return sourceSize, nil
+		}
+
+		if fo.Reflink == ReflinkAlways || !errors.Is(err, ErrReflinkNotSupported) {
+			return 0, fmt.Errorf("failed to reflink: %w", err)
+		}
+	}
+
+	if fo.Preallocate && !fo.Sparse {
+		err := preallocate(destFile, sourceSize)
+		if err != nil {
+			return 0, fmt.Errorf("failed to preallocate destination file: %w", err)
+		}
+	}
 
-	buf := make([]byte, BufferSize) // 32KB buffer
+	return fo.copyLoop(sourceFile, destFile, stats, sourceSize, srcPath, 0, progress, cancelChan)
+}
+
+// copyLoop performs the actual file copy with progress tracking and timing. A resumed copy
+// (offset > 0) first skips the offset bytes destFile already has, and is never sparse, as
+// appending can't leave holes.
+//
+//nolint:lll // Long function signature with many parameters including channel
+func (fo *FileOps) copyLoop(sourceFile filesystem.File, destFile filesystem.File, stats *CopyStats, sourceSize int64, srcPath string, offset int64, progress ProgressCallback, cancelChan <-chan struct{}) (int64, error) {
+	pooled := fo.getCopyBuffer()
+	defer putCopyBuffer(pooled)
+
+	buf := *pooled
+
+	hasher := fo.HashAlgorithm.newHash() // Only fed when HashOnCopy is set
+
+	sparse, isSparse := fo.sparseDest(destFile)
+
+	written := offset
+
+	if offset > 0 {
+		isSparse = false
+
+		var hashPrefix io.Writer
+		if fo.HashOnCopy {
+			hashPrefix = hasher
+		}
+
+		err := skipSource(sourceFile, offset, hashPrefix)
+		if err != nil {
+			return 0, newSourceError(srcPath, err)
+		}
+
+		stats.ResumedBytes = offset
+
+		if progress != nil {
+			progress(written, sourceSize, srcPath)
+		}
+	}
 
 	var (
 		nr, nw int //nolint:varnamelen // nr/nw are idiomatic for bytes read/written
 		err    error
+		inHole bool // The copy so far ends in a hole (Sparse only)
 	)
 
 	for {
@@ -391,7 +936,17 @@ func (fo *FileOps) copyLoop(sourceFile filesystem.File, destFile filesystem.File
 		stats.ReadTime += time.Since(readStart)
 
 		if nr > 0 {
-			nw, err = writeBufferWithTiming(destFile, buf, nr, stats)
+			limitErr := fo.ByteLimiter.Wait(nr, cancelChan)
+			if limitErr != nil {
+				return written, limitErr
+			}
+
+			if isSparse {
+				nw, inHole, err = writeSparse(destFile, sparse, buf[:nr], stats)
+			} else {
+				nw, err = writeBufferWithTiming(destFile, buf, nr, stats)
+			}
+
 			if err != nil {
 				return written, fmt.Errorf("failed to write to destination: %w", err)
 			}
@@ -400,6 +955,10 @@ func (fo *FileOps) copyLoop(sourceFile filesystem.File, destFile filesystem.File
 				return written, fmt.Errorf("short write: %w", io.ErrShortWrite)
 			}
 
+			if fo.HashOnCopy {
+				_, _ = hasher.Write(buf[:nr]) // hash.Write never returns an error
+			}
+
 			written += int64(nw)
 
 			if progress != nil {
@@ -412,19 +971,41 @@ func (fo *FileOps) copyLoop(sourceFile filesystem.File, destFile filesystem.File
 		}
 
 		if err != nil {
-			return written, fmt.Errorf("failed to read from source: %w", err)
+			return written, newSourceError(srcPath, fmt.Errorf("failed to read from source: %w", err))
+		}
+	}
+
+	if inHole {
+		err = finishSparse(sparse, written)
+		if err != nil {
+			return written, err
 		}
 	}
 
+	if fo.HashOnCopy {
+		stats.SourceHash = hex.EncodeToString(hasher.Sum(nil))
+	}
+
 	return written, nil
 }
 
-// countFilesWithProgressFS counts files using the specified filesystem.
-func (fo *FileOps) countFilesWithProgressFS(fs filesystem.FileSystem, rootPath string, progressCallback CountProgressCallback) (int, error) { //nolint:lll // Function signature with long parameter names
-	scanner := fs.Scan(rootPath)
+// countFilesWithProgressFS counts the files a scan yields, stopping the scan if cancelled.
+func (fo *FileOps) countFilesWithProgressFS(scanner filesystem.FileScanner, rootPath string, progressCallback CountProgressCallback) (int, error) { //nolint:lll // Function signature with long parameter names
+	defer stopScan(scanner)
+	limitScanDepth(scanner, fo.MaxDepth)
+
 	count := 0
 
 	for info, ok := scanner.Next(); ok; info, ok = scanner.Next() {
+		if fo.scanCancelled() {
+			return count, fmt.Errorf("failed to count files in %s: %w", rootPath, ErrCancelled)
+		}
+
+		// Scanners that can't stop descending yield what's below the limit too
+		if exceedsMaxDepth(info.RelativePath, fo.MaxDepth) {
+			continue
+		}
+
 		count++
 
 		// Report progress every 10 files to avoid spam
@@ -460,21 +1041,74 @@ func (fo *FileOps) getSourceFS() filesystem.FileSystem {
 	return fo.FS
 }
 
-// scanDirectoryWithProgressFS scans a directory using the specified filesystem.
-func (fo *FileOps) scanDirectoryWithProgressFS(fs filesystem.FileSystem, rootPath string, progressCallback ScanProgressCallback) (map[string]*FileInfo, error) { //nolint:lll // Function signature with long parameter and return types
+// scanCancelled reports whether CancelChan has been closed.
+func (fo *FileOps) scanCancelled() bool {
+	select {
+	case <-fo.CancelChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// limitScanDepth limits scanner to maxDepth levels if it can be limited, so directories below
+// the limit are never read.
+func limitScanDepth(scanner filesystem.FileScanner, maxDepth int) {
+	if limiter, ok := scanner.(filesystem.DepthLimiter); ok && maxDepth > 0 {
+		limiter.LimitDepth(maxDepth)
+	}
+}
+
+// stopScan stops scanner if it can be stopped, so a scan left unfinished lets go of what it holds.
+func stopScan(scanner filesystem.FileScanner) {
+	if stopper, ok := scanner.(filesystem.ScanStopper); ok {
+		stopper.Stop()
+	}
+}
+
+// scanDirectoryWithProgressFS collects what a scan of fs yields, stopping the scan if cancelled.
+func (fo *FileOps) scanDirectoryWithProgressFS(fs filesystem.FileSystem, scanner filesystem.FileScanner, rootPath string, progressCallback ScanProgressCallback) (map[string]*FileInfo, error) { //nolint:lll // Function signature with long parameter and return types
+	defer stopScan(scanner)
+	limitScanDepth(scanner, fo.MaxDepth)
+
 	files := make(map[string]*FileInfo)
 	fileCount := 0
 
-	scanner := fs.Scan(rootPath)
 	for info, ok := scanner.Next(); ok; info, ok = scanner.Next() {
+		if fo.scanCancelled() {
+			return files, fmt.Errorf("failed to scan directory %s: %w", rootPath, ErrCancelled)
+		}
+
+		// Scanners that can't stop descending yield what's below the limit too
+		if exceedsMaxDepth(info.RelativePath, fo.MaxDepth) {
+			continue
+		}
+
 		path := filepath.Join(rootPath, info.RelativePath)
 
+		var linkTarget string
+
+		if info.IsSymlink && fo.Symlinks == SymlinkPreserve {
+			var err error
+
+			linkTarget, err = readlink(fs, path)
+			if err != nil {
+				return files, fmt.Errorf("failed to scan directory %s: %w", rootPath, err)
+			}
+		}
+
 		fileInfo := &FileInfo{
 			Path:         path,
 			RelativePath: info.RelativePath,
 			Size:         info.Size,
 			ModTime:      info.ModTime,
+			Mode:         info.Mode,
 			IsDir:        info.IsDir,
+			IsSymlink:    info.IsSymlink,
+			LinkTarget:   linkTarget,
+			UID:          info.UID,
+			GID:          info.GID,
+			HasOwner:     info.HasOwner,
 		}
 
 		files[info.RelativePath] = fileInfo
@@ -500,6 +1134,28 @@ func (fo *FileOps) scanDirectoryWithProgressFS(fs filesystem.FileSystem, rootPat
 	return files, nil
 }
 
+// scanSource starts a scan of the source, following links unless they're preserved or
+// skipped. Filesystems that can't follow links are scanned without following them. Snapshots
+// an interrupted --snapshot-source run left in the source are left out.
+func (fo *FileOps) scanSource(rootPath string) filesystem.FileScanner {
+	fs := fo.getSourceFS()
+
+	switch fo.Symlinks {
+	case SymlinkSkip:
+		return &snapshotSkippingScanner{FileScanner: &linkSkippingScanner{FileScanner: fs.Scan(rootPath)}}
+	case SymlinkPreserve:
+		return &snapshotSkippingScanner{FileScanner: fs.Scan(rootPath)}
+	case SymlinkFollow:
+	}
+
+	follower, ok := fs.(filesystem.SymlinkFollower)
+	if !ok {
+		return &snapshotSkippingScanner{FileScanner: fs.Scan(rootPath)}
+	}
+
+	return &snapshotSkippingScanner{FileScanner: follower.ScanFollowingSymlinks(rootPath)}
+}
+
 // CopyFile copies a file from src to dst with progress reporting.
 // simpleCopyLoop performs a basic file copy with progress tracking.
 //
@@ -507,11 +1163,19 @@ func (fo *FileOps) scanDirectoryWithProgressFS(fs filesystem.FileSystem, rootPat
 func (fo *FileOps) simpleCopyLoop(sourceFile filesystem.File, destFile filesystem.File, sourceSize int64, srcPath string, progress ProgressCallback) (int64, error) {
 	var written int64
 
-	buf := make([]byte, BufferSize) // 32KB buffer
+	pooled := fo.getCopyBuffer()
+	defer putCopyBuffer(pooled)
+
+	buf := *pooled
 
 	for {
 		nr, err := sourceFile.Read(buf) //nolint:varnamelen // nr is idiomatic for bytes read
 		if nr > 0 {
+			limitErr := fo.ByteLimiter.Wait(nr, fo.CancelChan)
+			if limitErr != nil {
+				return written, limitErr
+			}
+
 			nw, err := destFile.Write(buf[0:nr]) //nolint:varnamelen // nw is idiomatic for bytes written
 			if err != nil {
 				return written, fmt.Errorf("failed to write to destination: %w", err)
@@ -540,15 +1204,294 @@ func (fo *FileOps) simpleCopyLoop(sourceFile filesystem.File, destFile filesyste
 	return written, nil
 }
 
-// compareByteBuffers compares two byte buffers up to n bytes.
-func compareByteBuffers(buf1, buf2 []byte, n int) bool {
-	for i := range n {
-		if buf1[i] != buf2[i] {
-			return false
+// writeFile creates or replaces a file on dstFS; see WriteDestFile.
+func (fo *FileOps) writeFile(dstFS filesystem.FileSystem, path string, data []byte) error {
+
+	err := fo.OpLimiter.Wait(nil)
+	if err == nil {
+		err = dstFS.MkdirAll(filepath.Dir(path), DefaultDirPermissions)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+
+	renamer, atomic := dstFS.(filesystem.Renamer)
+
+	writePath := path
+	if atomic {
+		writePath = path + TempSuffix
+	}
+
+	_ = fo.OpLimiter.Wait(nil)
+
+	file, err := dstFS.Create(writePath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", writePath, err)
+	}
+
+	_, err = file.Write(data)
+	if err == nil && atomic {
+		err = syncFile(file)
+	}
+
+	if err != nil {
+		_ = file.Close()
+		_ = fo.OpLimiter.Wait(nil)
+		_ = dstFS.Remove(writePath)
+
+		return fmt.Errorf("failed to write %s: %w", writePath, err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		_ = fo.OpLimiter.Wait(nil)
+		_ = dstFS.Remove(writePath)
+
+		return fmt.Errorf("failed to close %s: %w", writePath, err)
+	}
+
+	if !atomic {
+		return nil
+	}
+
+	_ = fo.OpLimiter.Wait(nil)
+
+	err = renamer.Rename(writePath, path)
+	if err != nil {
+		_ = fo.OpLimiter.Wait(nil)
+		_ = dstFS.Remove(writePath)
+
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// writeTarBatch writes files to w as a tar archive, recording the bytes sent in stats.
+func (fo *FileOps) writeTarBatch(w io.Writer, files []BatchFile, stats *CopyStats, cancelChan <-chan struct{}) error {
+	tarWriter := tar.NewWriter(w)
+
+	for _, file := range files {
+		err := checkCancellation(cancelChan)
+		if err != nil {
+			return err
+		}
+
+		written, err := fo.writeTarEntry(tarWriter, file, cancelChan)
+		stats.BytesCopied += written
+
+		if err != nil {
+			return newSourceError(file.Src, err)
+		}
+	}
+
+	err := tarWriter.Close()
+	if err != nil {
+		return fmt.Errorf("failed to finish tar stream: %w", err)
+	}
+
+	return nil
+}
+
+// writeTarEntry adds one source file to a tar archive.
+func (fo *FileOps) writeTarEntry(tarWriter *tar.Writer, file BatchFile, cancelChan <-chan struct{}) (int64, error) {
+	err := fo.OpLimiter.Wait(cancelChan)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open source file %s: %w", file.Src, err)
+	}
+
+	sourceFile, err := fo.getSourceFS().Open(file.Src)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open source file %s: %w", file.Src, err)
+	}
+
+	defer func() {
+		_ = sourceFile.Close()
+	}()
+
+	info, err := sourceFile.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat source file %s: %w", file.Src, err)
+	}
+
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     filepath.ToSlash(file.Name),
+		Size:     info.Size(),
+		Mode:     int64(info.Mode().Perm()),
+		ModTime:  info.ModTime(),
+	}
+
+	err = tarWriter.WriteHeader(header)
+	if err != nil {
+		return 0, fmt.Errorf("failed to write tar header for %s: %w", file.Src, err)
+	}
+
+	// The header fixed the size, so a file that shrank mid-read fails rather than padding
+	reader := &limitedReader{reader: sourceFile, limiter: fo.ByteLimiter, cancelChan: cancelChan}
+
+	pooled := fo.getCopyBuffer()
+	defer putCopyBuffer(pooled)
+
+	written, err := io.CopyBuffer(tarWriter, io.LimitReader(reader, info.Size()), *pooled)
+	if err == nil && written < info.Size() {
+		err = io.ErrUnexpectedEOF
+	}
+
+	if err != nil {
+		return written, fmt.Errorf("failed to copy %s into batch: %w", file.Src, err)
+	}
+
+	return written, nil
+}
+
+// exceedsMaxDepth reports whether a relative path is deeper than maxDepth path components.
+// A maxDepth of 0 or less means unlimited depth.
+func exceedsMaxDepth(relPath string, maxDepth int) bool {
+	if maxDepth <= 0 {
+		return false
+	}
+
+	depth := strings.Count(filepath.ToSlash(relPath), "/") + 1
+
+	return depth > maxDepth
+}
+
+// hashFileFS computes a file's hash with algorithm, checking for cancellation between reads.
+func hashFileFS(
+	fs filesystem.FileSystem, filePath string, algorithm HashAlgorithm, cancelChan <-chan struct{},
+) (string, error) {
+	file, err := fs.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	hash := algorithm.newHash()
+	buf := make([]byte, BufferSize)
+
+	for {
+		err = checkCancellation(cancelChan)
+		if err != nil {
+			return "", err
+		}
+
+		n, readErr := file.Read(buf)
+		if n > 0 {
+			_, _ = hash.Write(buf[:n]) // hash.Write never returns an error
+		}
+
+		if errors.Is(readErr, io.EOF) {
+			break
 		}
+
+		if readErr != nil {
+			return "", fmt.Errorf("failed to read file %s for hashing: %w", filePath, readErr)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// readlink returns the target of a link on fs.
+// Returns ErrSymlinkNotSupported if fs lacks links.
+func readlink(fs filesystem.FileSystem, path string) (string, error) {
+	linker, ok := fs.(filesystem.Symlinker)
+	if !ok {
+		return "", ErrSymlinkNotSupported
+	}
+
+	target, err := linker.Readlink(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read link %s: %w", path, err)
 	}
 
-	return true
+	return target, nil
+}
+
+// sampleHashFS hashes a file's size and its head, middle and tail samples.
+// Files that support random access are read only at the samples; others are read through.
+func sampleHashFS(
+	fs filesystem.FileSystem, filePath string, algorithm HashAlgorithm, sampleSize int64,
+) (string, error) {
+	file, err := fs.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", fmt.Errorf("failed to stat file %s: %w", filePath, err)
+	}
+
+	if sampleSize <= 0 {
+		sampleSize = DefaultSampleSize
+	}
+
+	size := info.Size()
+	hash := algorithm.newHash()
+	_, _ = fmt.Fprintf(hash, "%d\n", size) // hash.Write never returns an error
+
+	// Small files are hashed whole; the samples would cover most of them anyway
+	offsets := []int64{0}
+	length := size
+
+	if size > 3*sampleSize { //nolint:mnd // Head, middle and tail samples
+		offsets = []int64{0, (size - sampleSize) / 2, size - sampleSize} //nolint:mnd // Middle of the file
+		length = sampleSize
+	}
+
+	reader, ok := file.(io.ReaderAt)
+	if !ok {
+		// Sequential files: read through, keeping only the sampled ranges
+		reader = &sequentialReaderAt{reader: file}
+	}
+
+	for _, offset := range offsets {
+		_, err = io.Copy(hash, io.NewSectionReader(reader, offset, length))
+		if err != nil {
+			return "", fmt.Errorf("failed to read file %s for sampling: %w", filePath, err)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// sequentialReaderAt adapts a reader to io.ReaderAt for reads at increasing offsets,
+// discarding the bytes skipped between them.
+type sequentialReaderAt struct {
+	reader io.Reader
+	pos    int64
+}
+
+func (r *sequentialReaderAt) ReadAt(p []byte, off int64) (int, error) { //nolint:varnamelen // p is idiomatic for byte slice
+	if off < r.pos {
+		return 0, fmt.Errorf("%w: read at %d after %d", errors.ErrUnsupported, off, r.pos)
+	}
+
+	skipped, err := io.CopyN(io.Discard, r.reader, off-r.pos)
+	r.pos += skipped
+
+	if err != nil {
+		return 0, err //nolint:wrapcheck // Wrapped by sampleHashFS
+	}
+
+	n, err := io.ReadFull(r.reader, p)
+	r.pos += int64(n)
+
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+
+	return n, err //nolint:wrapcheck // Wrapped by sampleHashFS
 }
 
 // writeBufferWithTiming writes a buffer to a file and tracks the write time.
@@ -559,3 +1502,94 @@ func writeBufferWithTiming(destFile filesystem.File, buf []byte, nr int, stats *
 
 	return nw, err //nolint:wrapcheck // Error is from io.Writer interface, context is clear
 }
+
+// linkSkippingScanner leaves links out of a scan (SymlinkSkip).
+type linkSkippingScanner struct {
+	filesystem.FileScanner
+}
+
+func (s *linkSkippingScanner) Next() (filesystem.FileInfo, bool) {
+	for {
+		info, ok := s.FileScanner.Next()
+		if !ok || !info.IsSymlink {
+			return info, ok
+		}
+	}
+}
+
+func (s *linkSkippingScanner) LimitDepth(maxDepth int) {
+	limitScanDepth(s.FileScanner, maxDepth)
+}
+
+func (s *linkSkippingScanner) Stop() {
+	stopScan(s.FileScanner)
+}
+
+// snapshotSkippingScanner leaves snapshot directories (filesystem.IsSnapshotDir), and
+// everything in them, out of a scan.
+type snapshotSkippingScanner struct {
+	filesystem.FileScanner
+
+	skipped []string // Snapshot directories seen so far, each with a trailing separator
+}
+
+func (s *snapshotSkippingScanner) Next() (filesystem.FileInfo, bool) {
+	for {
+		info, ok := s.FileScanner.Next()
+		if !ok {
+			return info, ok
+		}
+
+		if s.inSkipped(info.RelativePath) {
+			continue
+		}
+
+		if info.IsDir && filesystem.IsSnapshotDir(filepath.Base(info.RelativePath)) {
+			s.skipped = append(s.skipped, info.RelativePath+string(filepath.Separator))
+			continue
+		}
+
+		return info, ok
+	}
+}
+
+func (s *snapshotSkippingScanner) LimitDepth(maxDepth int) {
+	limitScanDepth(s.FileScanner, maxDepth)
+}
+
+func (s *snapshotSkippingScanner) Stop() {
+	stopScan(s.FileScanner)
+}
+
+// inSkipped reports whether relPath is inside a snapshot directory already skipped.
+func (s *snapshotSkippingScanner) inSkipped(relPath string) bool {
+	for _, dir := range s.skipped {
+		if strings.HasPrefix(relPath, dir) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tempSkippingScanner leaves the temp files of unfinished copies (TempSuffix) out of a scan.
+type tempSkippingScanner struct {
+	filesystem.FileScanner
+}
+
+func (s *tempSkippingScanner) Next() (filesystem.FileInfo, bool) {
+	for {
+		info, ok := s.FileScanner.Next()
+		if !ok || info.IsDir || !strings.HasSuffix(info.RelativePath, TempSuffix) {
+			return info, ok
+		}
+	}
+}
+
+func (s *tempSkippingScanner) LimitDepth(maxDepth int) {
+	limitScanDepth(s.FileScanner, maxDepth)
+}
+
+func (s *tempSkippingScanner) Stop() {
+	stopScan(s.FileScanner)
+}
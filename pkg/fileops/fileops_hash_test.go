@@ -0,0 +1,112 @@
+package fileops_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+func TestFileOps_ComputeSourceAndDestHash(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+	g.Expect(os.WriteFile(path, []byte("hello world"), 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+
+	expected, err := ops.ComputeFileHash(path)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	srcHash, err := ops.ComputeSourceHash(path, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(srcHash).To(Equal(expected))
+
+	dstHash, err := ops.ComputeDestHash(path, make(chan struct{}))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(dstHash).To(Equal(expected))
+}
+
+func TestFileOps_HashAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, []byte("abc"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		algorithm fileops.HashAlgorithm
+		want      string
+	}{
+		{algorithm: "", want: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{algorithm: fileops.HashSHA256, want: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{algorithm: fileops.HashBLAKE3, want: "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85"},
+		{algorithm: fileops.HashXXHash, want: "44bc2cf5ad770999"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.algorithm), func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+			ops.HashAlgorithm = tt.algorithm
+
+			hash, err := ops.ComputeSourceHash(path, nil)
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(hash).To(Equal(tt.want))
+
+			hash, err = ops.ComputeFileHash(path)
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(hash).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestFileOps_ComputeSourceHash_Cancelled(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	g.Expect(os.WriteFile(path, []byte("hello world"), 0o600)).To(Succeed())
+
+	cancelChan := make(chan struct{})
+	close(cancelChan)
+
+	ops := fileops.NewRealFileOps()
+
+	_, err := ops.ComputeSourceHash(path, cancelChan)
+	g.Expect(err).To(MatchError(fileops.ErrCopyCancelled))
+}
+
+func TestFileOps_CopyFileWithStats_HashOnCopy(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "file.txt")
+	dst := filepath.Join(dir, "out", "file.txt")
+	g.Expect(os.WriteFile(src, []byte("hello world"), 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+
+	stats, err := ops.CopyFileWithStats(src, dst, nil, nil, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(stats.SourceHash).To(BeEmpty())
+
+	ops.HashOnCopy = true
+
+	stats, err = ops.CopyFileWithStats(src, dst, nil, nil, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	expected, err := ops.ComputeSourceHash(src, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(stats.SourceHash).To(Equal(expected))
+}
@@ -2,8 +2,15 @@
 package fileops
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"strings"
+	"syscall"
 	"testing"
+	"testing/iotest"
 
 	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
 )
@@ -137,3 +144,108 @@ func TestOsCopyLoopWithStatsCancel(t *testing.T) {
 	g.Expect(err).Should(HaveOccurred())
 	g.Expect(err.Error()).Should(ContainSubstring("copy cancelled"))
 }
+
+// TestExceedsMaxDepth verifies depth counting relative to the scan root.
+func TestExceedsMaxDepth(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(exceedsMaxDepth("a/b/c/file.txt", 0)).Should(BeFalse(), "0 means unlimited")
+	g.Expect(exceedsMaxDepth("file.txt", 1)).Should(BeFalse())
+	g.Expect(exceedsMaxDepth("a/file.txt", 1)).Should(BeTrue())
+	g.Expect(exceedsMaxDepth("a/b", 2)).Should(BeFalse())
+	g.Expect(exceedsMaxDepth("a/b/c", 2)).Should(BeTrue())
+}
+
+// TestCategorize verifies that failures map to the category callers match with errors.Is.
+func TestCategorize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		sourceSide bool
+		expected   ErrorCategory
+	}{
+		{"cancelled", fmt.Errorf("copy: %w", ErrCopyCancelled), false, CategoryCancelled},
+		{"source missing", &fs.PathError{Op: "open", Err: syscall.ENOENT}, true, CategorySourceVanished},
+		{"dest missing", &fs.PathError{Op: "open", Err: syscall.ENOENT}, false, CategoryUnknown},
+		{"disk full", &fs.PathError{Op: "write", Err: syscall.ENOSPC}, false, CategoryDestFull},
+		{"permission", &fs.PathError{Op: "open", Err: syscall.EACCES}, true, CategoryPermission},
+		{"i/o", &fs.PathError{Op: "read", Err: syscall.EIO}, true, CategoryIO},
+		{"busy", &fs.PathError{Op: "remove", Err: syscall.EBUSY}, false, CategoryLocked},
+		{"other", errors.New("boom"), false, CategoryUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			g.Expect(categorize(tt.err, tt.sourceSide)).To(Equal(tt.expected))
+		})
+	}
+}
+
+// TestCompareContents_StopsAtFirstDifference verifies that files differing in their first chunk
+// are each read no further than that chunk.
+func TestCompareContents_StopsAtFirstDifference(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	const size = 64 * CompareChunkSize
+
+	first := &countingReader{reader: io.LimitReader(zeroReader{}, size)}
+	second := &countingReader{reader: io.MultiReader(strings.NewReader("x"), io.LimitReader(zeroReader{}, size-1))}
+
+	identical, err := compareContents(first, second, size)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(identical).Should(BeFalse())
+	g.Expect(first.read).Should(Equal(int64(CompareChunkSize)))
+	g.Expect(second.read).Should(Equal(int64(CompareChunkSize)))
+}
+
+// TestCompareContents_IdenticalReadsInLockstep verifies that identical files are read to the end
+// and still match when one reader returns short reads, as SFTP does.
+func TestCompareContents_IdenticalReadsInLockstep(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	const size = 3*CompareChunkSize + 100
+
+	first := &countingReader{reader: io.LimitReader(zeroReader{}, size)}
+	second := &countingReader{reader: iotest.HalfReader(io.LimitReader(zeroReader{}, size))}
+
+	identical, err := compareContents(first, second, size)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(identical).Should(BeTrue())
+	g.Expect(first.read).Should(Equal(int64(size)))
+	g.Expect(second.read).Should(Equal(int64(size)))
+
+	// A file that turns out longer than its stated size still differs
+	identical, err = compareContents(strings.NewReader("abc"), strings.NewReader("abcd"), 3)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(identical).Should(BeFalse())
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	reader io.Reader
+	read   int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+
+	return n, err //nolint:wrapcheck // Passes the wrapped reader's EOF through unchanged
+}
+
+// zeroReader reads an endless run of zeros.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+
+	return len(p), nil
+}
@@ -0,0 +1,37 @@
+//go:build linux
+
+package fileops
+
+import (
+	"fmt"
+	"path/filepath"
+	"syscall"
+)
+
+// FreeInodes returns how many more files and directories can be created on the filesystem
+// holding path. A path that does not exist yet is resolved against its nearest existing parent.
+// Filesystems that allocate inodes dynamically (btrfs, most network filesystems) report no
+// inode table and return ErrFreeInodesNotSupported.
+func FreeInodes(path string) (int64, error) {
+	var stat syscall.Statfs_t
+
+	for {
+		err := syscall.Statfs(path, &stat)
+		if err == nil {
+			break
+		}
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			return 0, fmt.Errorf("failed to stat filesystem for %s: %w", path, err)
+		}
+
+		path = parent
+	}
+
+	if stat.Files == 0 {
+		return 0, ErrFreeInodesNotSupported
+	}
+
+	return int64(stat.Ffree), nil //nolint:gosec // Free inode counts fit comfortably in int64
+}
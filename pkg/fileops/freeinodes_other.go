@@ -0,0 +1,8 @@
+//go:build !linux
+
+package fileops
+
+// FreeInodes is not supported on platforms without statfs(2) inode counts.
+func FreeInodes(_ string) (int64, error) {
+	return 0, ErrFreeInodesNotSupported
+}
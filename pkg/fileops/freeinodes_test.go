@@ -0,0 +1,30 @@
+package fileops_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+func TestFreeInodes_ResolvesMissingPathToExistingParent(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+
+	free, err := fileops.FreeInodes(dir)
+	if errors.Is(err, fileops.ErrFreeInodesNotSupported) {
+		t.Skip("temp filesystem does not report inodes")
+	}
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(free).To(BeNumerically(">", 0))
+
+	// A destination that doesn't exist yet is checked on the filesystem it will be created on
+	_, err = fileops.FreeInodes(filepath.Join(dir, "not", "created", "yet"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+}
@@ -0,0 +1,40 @@
+package fileops
+
+import (
+	"crypto/sha256"
+	"hash"
+
+	"github.com/cespare/xxhash/v2"
+	"lukechampine.com/blake3"
+)
+
+// HashAlgorithm selects the hash FileOps computes file hashes with
+type HashAlgorithm string
+
+// HashAlgorithm values.
+const (
+	// HashSHA256 - SHA-256, the default (and what the zero value uses)
+	HashSHA256 HashAlgorithm = "sha256"
+	// HashBLAKE3 - BLAKE3 with a 256-bit digest: cryptographic like SHA-256, and several times
+	// faster on most CPUs
+	HashBLAKE3 HashAlgorithm = "blake3"
+	// HashXXHash - 64-bit xxHash, the fastest. It is NOT cryptographic: accidental collisions are
+	// vanishingly rare, but files can be crafted to collide, so only use it on trusted sources.
+	HashXXHash HashAlgorithm = "xxhash"
+)
+
+// blake3Size is the BLAKE3 digest size in bytes, the same as SHA-256's.
+const blake3Size = 32
+
+// newHash returns a new hasher for the algorithm.
+func (a HashAlgorithm) newHash() hash.Hash {
+	switch a {
+	case HashBLAKE3:
+		return blake3.New(blake3Size, nil)
+	case HashXXHash:
+		return xxhash.New()
+	case HashSHA256:
+	}
+
+	return sha256.New()
+}
@@ -0,0 +1,31 @@
+//go:build darwin
+
+package fileops
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// LoadAverage returns the system's 1-minute load average, as reported by sysctl vm.loadavg
+// ("{ 1.52 1.63 1.70 }").
+func LoadAverage() (float64, error) {
+	out, err := exec.Command("sysctl", "-n", "vm.loadavg").Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read load average: %w", err)
+	}
+
+	fields := strings.Fields(strings.Trim(strings.TrimSpace(string(out)), "{}"))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("failed to parse load average: %q", out)
+	}
+
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse load average: %w", err)
+	}
+
+	return load, nil
+}
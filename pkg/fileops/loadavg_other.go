@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package fileops
+
+// LoadAverage is not supported on platforms without a load average.
+func LoadAverage() (float64, error) {
+	return 0, ErrLoadAverageNotSupported
+}
@@ -0,0 +1,23 @@
+package fileops_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+)
+
+func TestLoadAverage_ReportsNonNegativeLoad(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	load, err := fileops.LoadAverage()
+	if errors.Is(err, fileops.ErrLoadAverageNotSupported) {
+		t.Skip("platform does not report a load average")
+	}
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(load).To(BeNumerically(">=", 0))
+}
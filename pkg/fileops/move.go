@@ -0,0 +1,112 @@
+package fileops
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// Move moves src to dst. When the source and destination are both local, src is renamed, which
+// takes no time however big it is: CopyStats.Renamed is set and progress isn't called, as nothing
+// was copied. Otherwise, or if the rename fails (e.g. across devices), src is copied as
+// ResumeCopyFileWithStats copies it, from offset, and only removed once dst is confirmed: the
+// same size as src and, with HashOnCopy, hashing the same. A copy that fails the check is removed
+// and src kept, with ErrMoveUnconfirmed.
+//
+//nolint:lll // Long function signature with channel parameter, matching ResumeCopyFileWithStats
+func (fo *FileOps) Move(src, dst string, offset int64, progress ProgressCallback, cancelChan <-chan struct{}, onDataComplete func()) (*CopyStats, error) {
+	if fo.renameLocal(src, dst) {
+		// A partial copy an earlier attempt kept is no longer needed
+		if offset > 0 {
+			_ = fo.OpLimiter.Wait(nil)
+			_ = fo.getDestFS().Remove(dst + TempSuffix)
+		}
+
+		return &CopyStats{Renamed: true}, nil
+	}
+
+	stats, err := fo.ResumeCopyFileWithStats(src, dst, offset, progress, cancelChan, onDataComplete)
+	if err != nil {
+		return stats, err
+	}
+
+	err = fo.confirmMove(src, dst, stats, cancelChan)
+	if err != nil {
+		return stats, err
+	}
+
+	err = fo.Remove(src)
+	if err != nil {
+		return stats, newSourceError(src, fmt.Errorf("copied to %s but %w", dst, err))
+	}
+
+	return stats, nil
+}
+
+// confirmMove checks that a copy made for Move matches its source, removing it if it doesn't.
+// Copies that weren't hashed as they streamed (reflinks) are hashed here.
+func (fo *FileOps) confirmMove(src, dst string, stats *CopyStats, cancelChan <-chan struct{}) error {
+	srcInfo, err := fo.Stat(src)
+	if err != nil {
+		return newSourceError(src, err)
+	}
+
+	dstInfo, err := fo.StatDest(dst)
+	if err != nil {
+		return newDestError(dst, err)
+	}
+
+	mismatch := ""
+
+	if dstInfo.Size() != srcInfo.Size() {
+		mismatch = fmt.Sprintf("%d bytes, source %d", dstInfo.Size(), srcInfo.Size())
+	} else if fo.HashOnCopy {
+		sourceHash := stats.SourceHash
+		if sourceHash == "" {
+			sourceHash, err = fo.ComputeSourceHash(src, cancelChan)
+			if err != nil {
+				return newSourceError(src, err)
+			}
+		}
+
+		destHash, err := fo.ComputeDestHash(dst, cancelChan)
+		if err != nil {
+			return newDestError(dst, err)
+		}
+
+		if destHash != sourceHash {
+			mismatch = fmt.Sprintf("hash %s, source %s", destHash, sourceHash)
+		}
+	}
+
+	if mismatch == "" {
+		return nil
+	}
+
+	_ = fo.RemoveFromDest(dst)
+
+	return fmt.Errorf("%w: %s (%s)", ErrMoveUnconfirmed, dst, mismatch)
+}
+
+// renameLocal renames src to dst when both sides are the local filesystem, creating dst's parent
+// directories, and reports whether it did. Any rename failure is left to the copy to report, as
+// it falls back to one.
+func (fo *FileOps) renameLocal(src, dst string) bool {
+	_, srcLocal := fo.getSourceFS().(*filesystem.RealFileSystem)
+	dstFS, dstLocal := fo.getDestFS().(*filesystem.RealFileSystem)
+
+	if !srcLocal || !dstLocal {
+		return false
+	}
+
+	if fo.OpLimiter.Wait(fo.CancelChan) != nil {
+		return false
+	}
+
+	if dstFS.MkdirAll(filepath.Dir(dst), DefaultDirPermissions) != nil {
+		return false
+	}
+
+	return dstFS.Rename(src, dst) == nil
+}
@@ -0,0 +1,103 @@
+package fileops_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestFileOps_Move_RenamesLocalFiles verifies that a move between local paths renames the file,
+// creating the destination's parent directories, without reporting progress.
+func TestFileOps_Move_RenamesLocalFiles(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "out", "sub", "dst.txt")
+	g.Expect(os.WriteFile(src, []byte("move me"), 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+
+	progressed := false
+	progress := func(_, _ int64, _ string) { progressed = true }
+
+	stats, err := ops.Move(src, dst, 0, progress, nil, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(stats.Renamed).To(BeTrue())
+	g.Expect(stats.BytesCopied).To(BeZero())
+	g.Expect(progressed).To(BeFalse())
+
+	g.Expect(src).NotTo(BeAnExistingFile())
+	g.Expect(os.ReadFile(dst)).To(Equal([]byte("move me")))
+}
+
+// TestFileOps_Move_CopiesAcrossFilesystems verifies that a file that can't be renamed is copied,
+// with progress, and the source removed once the copy's hash matches.
+func TestFileOps_Move_CopiesAcrossFilesystems(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "big.bin")
+	dst := filepath.Join(dir, "out", "big.bin")
+
+	content := resumeContent()
+	g.Expect(os.WriteFile(src, content, 0o600)).To(Succeed())
+
+	// Its renames fail, so the copy is written in place too
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), crossDeviceFS{filesystem.NewRealFileSystem()})
+	ops.InPlace = true
+	ops.HashOnCopy = true
+
+	var reported int64
+
+	progress := func(bytesWritten, _ int64, _ string) { reported = bytesWritten }
+
+	stats, err := ops.Move(src, dst, 0, progress, nil, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(stats.Renamed).To(BeFalse())
+	g.Expect(stats.BytesCopied).To(Equal(int64(len(content))))
+	g.Expect(reported).To(Equal(int64(len(content))))
+
+	g.Expect(src).NotTo(BeAnExistingFile())
+	g.Expect(os.ReadFile(dst)).To(Equal(content))
+}
+
+// TestFileOps_Move_KeepsSourceWhenCopyDiffers verifies that a source that changes as it is copied
+// is kept, and its copy removed.
+func TestFileOps_Move_KeepsSourceWhenCopyDiffers(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "growing.log")
+	dst := filepath.Join(dir, "out", "growing.log")
+
+	content := resumeContent()
+	g.Expect(os.WriteFile(src, content, 0o600)).To(Succeed())
+
+	// Its renames fail, so the copy is written in place too
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), crossDeviceFS{filesystem.NewRealFileSystem()})
+	ops.InPlace = true
+
+	// Grows once its contents have been copied, like a log still being written
+	appendMore := func() {
+		file, err := os.OpenFile(src, os.O_APPEND|os.O_WRONLY, 0o600)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		_, err = file.WriteString("more")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(file.Close()).To(Succeed())
+	}
+
+	_, err := ops.Move(src, dst, 0, nil, nil, appendMore)
+	g.Expect(err).Should(MatchError(fileops.ErrMoveUnconfirmed))
+
+	g.Expect(src).To(BeAnExistingFile())
+	g.Expect(dst).NotTo(BeAnExistingFile())
+}
@@ -0,0 +1,61 @@
+package fileops
+
+import (
+	"sync"
+	"time"
+)
+
+// OpLimiter caps the rate of filesystem operations (opens, creates, stats, deletes, ...).
+// One limiter is shared by every worker, so adding workers never raises the aggregate rate.
+// Operations are spaced evenly rather than allowed in bursts, so a per-second request quota
+// is never exceeded in any one-second window.
+// A nil *OpLimiter imposes no limit.
+type OpLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // Time between operations
+	next     time.Time     // Earliest time the next operation may start
+}
+
+// NewOpLimiter returns a limiter allowing opsPerSecond operations per second,
+// or nil (unlimited) if opsPerSecond is not positive.
+func NewOpLimiter(opsPerSecond int) *OpLimiter {
+	if opsPerSecond <= 0 {
+		return nil
+	}
+
+	return &OpLimiter{interval: time.Second / time.Duration(opsPerSecond)}
+}
+
+// Wait blocks until another operation is allowed.
+// Returns ErrCancelled if cancelChan is closed first.
+func (l *OpLimiter) Wait(cancelChan <-chan struct{}) error {
+	if l == nil {
+		return nil
+	}
+
+	// Reserve the next slot under the lock, then sleep outside it so waiters queue in order
+	l.mu.Lock()
+	now := time.Now()
+
+	if l.next.Before(now) {
+		l.next = now
+	}
+
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-cancelChan:
+		return ErrCancelled
+	}
+}
@@ -0,0 +1,174 @@
+package fileops_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+func TestOpLimiter_SpacesOperations(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	limiter := fileops.NewOpLimiter(50) // One op every 20ms
+
+	start := time.Now()
+
+	for range 11 {
+		g.Expect(limiter.Wait(nil)).To(Succeed())
+	}
+
+	// The first op is immediate; the other ten wait their turn
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 200*time.Millisecond))
+}
+
+func TestOpLimiter_SharedAcrossWorkers(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	limiter := fileops.NewOpLimiter(100) // One op every 10ms
+
+	const workers = 8
+
+	const opsPerWorker = 5
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range opsPerWorker {
+				_ = limiter.Wait(nil)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// More workers don't raise the aggregate rate: 40 ops still take 39 intervals
+	g.Expect(time.Since(start)).To(BeNumerically(">=", (workers*opsPerWorker-1)*10*time.Millisecond))
+}
+
+func TestOpLimiter_LimitsDestinationChanges(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	srcDir := t.TempDir()
+	dstDir := t.TempDir()
+
+	g.Expect(os.WriteFile(filepath.Join(srcDir, "a.txt"), []byte("a"), 0o600)).To(Succeed())
+	g.Expect(os.Symlink("a.txt", filepath.Join(srcDir, "link"))).To(Succeed())
+
+	dstFS := &countingDestFS{RealFileSystem: filesystem.NewRealFileSystem()}
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), dstFS)
+	ops.OpLimiter = fileops.NewOpLimiter(100) // One op every 10ms
+
+	start := time.Now()
+
+	_, err := ops.CopyFile(filepath.Join(srcDir, "a.txt"), filepath.Join(dstDir, "a.txt"), nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ops.CopySymlink(filepath.Join(srcDir, "link"), filepath.Join(dstDir, "link"))).To(Succeed())
+	g.Expect(ops.WriteDestFile(filepath.Join(dstDir, "state.json"), []byte("{}"))).To(Succeed())
+	g.Expect(ops.RenameDest(filepath.Join(dstDir, "state.json"), filepath.Join(dstDir, "old", "state.json"))).To(Succeed())
+	g.Expect(ops.TrashDest(filepath.Join(dstDir, "a.txt"), filepath.Join(dstDir, "trash", "a.txt"), false)).To(Succeed())
+	g.Expect(ops.TrashDest(filepath.Join(dstDir, "old"), filepath.Join(dstDir, "trash", "old"), true)).
+		Should(HaveOccurred(), "old still holds state.json")
+
+	// Every change waited its turn: the first was immediate, the rest at least 10ms apart
+	changes := dstFS.count()
+	g.Expect(changes).To(BeNumerically(">=", 10))
+	g.Expect(time.Since(start)).To(BeNumerically(">=", time.Duration(changes-1)*10*time.Millisecond))
+}
+
+func TestOpLimiter_Cancelled(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	limiter := fileops.NewOpLimiter(1)
+	g.Expect(limiter.Wait(nil)).To(Succeed())
+
+	cancelChan := make(chan struct{})
+	close(cancelChan)
+
+	err := limiter.Wait(cancelChan)
+	g.Expect(errors.Is(err, fileops.ErrCancelled)).To(BeTrue())
+}
+
+func TestOpLimiter_NilIsUnlimited(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	limiter := fileops.NewOpLimiter(0)
+	g.Expect(limiter).To(BeNil())
+	g.Expect(limiter.Wait(nil)).To(Succeed())
+}
+
+// countingDestFS counts the changes made to a real filesystem.
+type countingDestFS struct {
+	*filesystem.RealFileSystem
+
+	mu      sync.Mutex
+	changes int
+}
+
+func (c *countingDestFS) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.changes
+}
+
+func (c *countingDestFS) changed() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.changes++
+}
+
+func (c *countingDestFS) Chtimes(path string, atime, mtime time.Time) error {
+	c.changed()
+
+	return c.RealFileSystem.Chtimes(path, atime, mtime) //nolint:wrapcheck // Test wrapper
+}
+
+func (c *countingDestFS) Create(path string) (filesystem.File, error) {
+	c.changed()
+
+	return c.RealFileSystem.Create(path) //nolint:wrapcheck // Test wrapper
+}
+
+func (c *countingDestFS) MkdirAll(path string, perm os.FileMode) error {
+	c.changed()
+
+	return c.RealFileSystem.MkdirAll(path, perm) //nolint:wrapcheck // Test wrapper
+}
+
+func (c *countingDestFS) Remove(path string) error {
+	c.changed()
+
+	return c.RealFileSystem.Remove(path) //nolint:wrapcheck // Test wrapper
+}
+
+func (c *countingDestFS) Rename(oldPath, newPath string) error {
+	c.changed()
+
+	return c.RealFileSystem.Rename(oldPath, newPath) //nolint:wrapcheck // Test wrapper
+}
+
+func (c *countingDestFS) Symlink(target, path string) error {
+	c.changed()
+
+	return c.RealFileSystem.Symlink(target, path) //nolint:wrapcheck // Test wrapper
+}
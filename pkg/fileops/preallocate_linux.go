@@ -0,0 +1,36 @@
+//go:build linux
+
+package fileops
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// fallocKeepSize is FALLOC_FL_KEEP_SIZE: reserve blocks without changing the file size,
+// so a source that shrinks mid-copy doesn't leave trailing zeros.
+const fallocKeepSize = 0x01
+
+// preallocate reserves size bytes for file with fallocate(2) so the filesystem can lay it out
+// contiguously. Files without a descriptor (e.g. SFTP) and filesystems that don't support
+// fallocate are left alone.
+func preallocate(file filesystem.File, size int64) error {
+	fdFile, ok := file.(interface{ Fd() uintptr })
+	if !ok || size <= 0 {
+		return nil
+	}
+
+	err := syscall.Fallocate(int(fdFile.Fd()), fallocKeepSize, 0, size) //nolint:gosec // File descriptors fit in int
+	if errors.Is(err, syscall.EOPNOTSUPP) || errors.Is(err, syscall.ENOSYS) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to preallocate %d bytes: %w", size, err)
+	}
+
+	return nil
+}
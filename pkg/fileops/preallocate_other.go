@@ -0,0 +1,10 @@
+//go:build !linux
+
+package fileops
+
+import "github.com/joe/copy-files/pkg/filesystem"
+
+// preallocate is a no-op on platforms without fallocate(2).
+func preallocate(_ filesystem.File, _ int64) error {
+	return nil
+}
@@ -0,0 +1,58 @@
+package fileops_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+func TestFileOps_CopyFileWithStats_Preallocate(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "large.bin")
+	dst := filepath.Join(dir, "out", "large.bin")
+
+	// Several copy buffers' worth of non-repeating data
+	content := bytes.Repeat([]byte("0123456789abcdef"), 1<<18) // 4 MiB
+	content = append(content, []byte("tail")...)
+	g.Expect(os.WriteFile(src, content, 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+	ops.Preallocate = true
+
+	stats, err := ops.CopyFileWithStats(src, dst, nil, nil, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(stats.BytesCopied).To(Equal(int64(len(content))))
+
+	copied, err := os.ReadFile(dst)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(copied).To(Equal(content))
+}
+
+func TestFileOps_CopyFileWithStats_PreallocateEmptyFile(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "empty")
+	dst := filepath.Join(dir, "copy")
+	g.Expect(os.WriteFile(src, nil, 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+	ops.Preallocate = true
+
+	_, err := ops.CopyFileWithStats(src, dst, nil, nil, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	info, err := os.Stat(dst)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(info.Size()).To(BeZero())
+}
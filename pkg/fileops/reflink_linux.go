@@ -0,0 +1,40 @@
+//go:build linux
+
+package fileops
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// ficlone is the FICLONE ioctl: share the source's extents with the destination (copy-on-write).
+const ficlone = 0x40049409
+
+// reflink clones src's contents into dst with the FICLONE ioctl, as cp --reflink does.
+// Files without a descriptor (e.g. SFTP), files on different filesystems, and filesystems
+// without copy-on-write support (anything but btrfs, XFS with reflink, bcachefs, ...) return
+// ErrReflinkNotSupported.
+func reflink(src, dst filesystem.File) error {
+	srcFd, srcOK := src.(interface{ Fd() uintptr })
+	dstFd, dstOK := dst.(interface{ Fd() uintptr })
+
+	if !srcOK || !dstOK {
+		return ErrReflinkNotSupported
+	}
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, dstFd.Fd(), ficlone, srcFd.Fd())
+	if errno == 0 {
+		return nil
+	}
+
+	switch {
+	case errors.Is(errno, syscall.EXDEV), errors.Is(errno, syscall.EOPNOTSUPP), errors.Is(errno, syscall.EINVAL),
+		errors.Is(errno, syscall.ENOTTY), errors.Is(errno, syscall.ENOSYS):
+		return fmt.Errorf("%w: %w", ErrReflinkNotSupported, errno)
+	default:
+		return fmt.Errorf("failed to clone: %w", errno)
+	}
+}
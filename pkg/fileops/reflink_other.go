@@ -0,0 +1,10 @@
+//go:build !linux
+
+package fileops
+
+import "github.com/joe/copy-files/pkg/filesystem"
+
+// reflink is not supported on platforms without the FICLONE ioctl.
+func reflink(_, _ filesystem.File) error {
+	return ErrReflinkNotSupported
+}
@@ -0,0 +1,69 @@
+package fileops_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestFileOps_CopyFileWithStats_ReflinkAuto verifies that auto clones where the filesystem
+// allows and otherwise falls back to a normal copy, with identical results either way.
+func TestFileOps_CopyFileWithStats_ReflinkAuto(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "out", "dst.txt")
+	g.Expect(os.WriteFile(src, []byte("clone me"), 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+	ops.Reflink = fileops.ReflinkAuto
+
+	var reported int64
+
+	stats, err := ops.CopyFileWithStats(src, dst, func(transferred, _ int64, _ string) { reported = transferred }, nil, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(stats.BytesCopied).To(Equal(int64(len("clone me"))))
+	g.Expect(reported).To(Equal(stats.BytesCopied))
+
+	copied, err := os.ReadFile(dst)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(copied)).To(Equal("clone me"))
+}
+
+// TestFileOps_CopyFileWithStats_ReflinkAlways verifies that always either clones or fails
+// without leaving a partial destination file.
+func TestFileOps_CopyFileWithStats_ReflinkAlways(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "dst.txt")
+	g.Expect(os.WriteFile(src, []byte("clone me"), 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+	ops.Reflink = fileops.ReflinkAlways
+
+	stats, err := ops.CopyFileWithStats(src, dst, nil, nil, nil)
+	if errors.Is(err, fileops.ErrReflinkNotSupported) {
+		_, statErr := os.Stat(dst)
+		g.Expect(os.IsNotExist(statErr)).To(BeTrue())
+
+		return
+	}
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(stats.Reflinked).To(BeTrue())
+
+	copied, err := os.ReadFile(dst)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(string(copied)).To(Equal("clone me"))
+}
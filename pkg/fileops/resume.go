@@ -0,0 +1,124 @@
+package fileops
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// PartialCopySize returns the size of the partial copy of dst that an interrupted copy left in
+// its temp file (see KeepPartial), if there is one ResumeCopyFileWithStats can carry on from.
+// Destinations written in place or that can't append have none.
+func (fo *FileOps) PartialCopySize(dst string) (int64, bool) {
+	dstFS := fo.getDestFS()
+
+	_, canRename := dstFS.(filesystem.Renamer)
+	_, canAppend := dstFS.(filesystem.Appender)
+
+	if fo.InPlace || !canRename || !canAppend {
+		return 0, false
+	}
+
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return 0, false
+	}
+
+	info, err := dstFS.Stat(dst + TempSuffix)
+	if err != nil || info.IsDir() {
+		return 0, false
+	}
+
+	return info.Size(), true
+}
+
+// PartialCopyMatches reports whether the first n bytes of dst's partial copy are the same as the
+// first n bytes of src, by hashing both. A source that has been rewritten since the partial copy
+// was made fails the check, so it can be copied from the start instead.
+func (fo *FileOps) PartialCopyMatches(src, dst string, n int64) (bool, error) {
+	srcHash, err := hashPrefixFS(fo.getSourceFS(), src, fo.HashAlgorithm, n)
+	if err != nil {
+		return false, newSourceError(src, err)
+	}
+
+	dstHash, err := hashPrefixFS(fo.getDestFS(), dst+TempSuffix, fo.HashAlgorithm, n)
+	if err != nil {
+		return false, newDestError(dst, err)
+	}
+
+	return bytes.Equal(srcHash, dstHash), nil
+}
+
+// hashPrefixFS returns the hash of the first n bytes of a file.
+func hashPrefixFS(fs filesystem.FileSystem, filePath string, algorithm HashAlgorithm, n int64) ([]byte, error) {
+	file, err := fs.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	hasher := algorithm.newHash()
+
+	_, err = io.CopyN(hasher, file, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the first %d bytes of %s: %w", n, filePath, err)
+	}
+
+	return hasher.Sum(nil), nil
+}
+
+// openPartial reopens the temp file a resumed copy appends to, checking it is still offset bytes
+// long.
+func openPartial(dstFS filesystem.FileSystem, writePath string, offset int64) (filesystem.File, error) {
+	appender, ok := dstFS.(filesystem.Appender)
+	if !ok {
+		return nil, fmt.Errorf("%w: destination can't append", ErrCannotResume)
+	}
+
+	destFile, err := appender.OpenAppend(writePath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCannotResume, err)
+	}
+
+	info, err := destFile.Stat()
+	if err == nil && info.Size() != offset {
+		err = fmt.Errorf("%w: %s is %d bytes, not %d", ErrCannotResume, writePath, info.Size(), offset)
+	}
+
+	if err != nil {
+		_ = destFile.Close()
+
+		return nil, fmt.Errorf("failed to check partial copy: %w", err)
+	}
+
+	return destFile, nil
+}
+
+// skipSource moves sourceFile past the first offset bytes, which a resumed copy already has. It
+// seeks when it can; otherwise, or when hasher is set, it reads through them, into hasher.
+func skipSource(sourceFile filesystem.File, offset int64, hasher io.Writer) error {
+	if seeker, ok := sourceFile.(io.Seeker); ok && hasher == nil {
+		_, err := seeker.Seek(offset, io.SeekStart)
+		if err != nil {
+			return fmt.Errorf("failed to seek past the %d bytes already copied: %w", offset, err)
+		}
+
+		return nil
+	}
+
+	if hasher == nil {
+		hasher = io.Discard
+	}
+
+	_, err := io.CopyN(hasher, sourceFile, offset)
+	if err != nil {
+		return fmt.Errorf("failed to read past the %d bytes already copied: %w", offset, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package fileops_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestFileOps_ResumeCopyFileWithStats verifies that a cancelled copy keeps its partial temp file
+// with KeepPartial, and that resuming from its size appends the rest, with progress and the
+// hash covering the whole file.
+func TestFileOps_ResumeCopyFileWithStats(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "big.bin")
+	dst := filepath.Join(dir, "out", "big.bin")
+
+	content := resumeContent()
+	g.Expect(os.WriteFile(src, content, 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+	ops.KeepPartial = true
+	ops.HashOnCopy = true
+
+	// Cancel the first copy partway through
+	cancelChan := make(chan struct{})
+
+	cancelAfterChunk := func(bytesWritten, _ int64, _ string) {
+		if bytesWritten >= 3*fileops.BufferSize {
+			select {
+			case <-cancelChan:
+			default:
+				close(cancelChan)
+			}
+		}
+	}
+
+	_, err := ops.CopyFileWithStats(src, dst, cancelAfterChunk, cancelChan, nil)
+	g.Expect(err).Should(MatchError(fileops.ErrCopyCancelled))
+
+	offset, ok := ops.PartialCopySize(dst)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(offset).To(BeNumerically(">", 0))
+	g.Expect(offset).To(BeNumerically("<", len(content)))
+
+	match, err := ops.PartialCopyMatches(src, dst, offset)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(match).To(BeTrue())
+
+	var progressed []int64
+
+	progress := func(bytesWritten, _ int64, _ string) { progressed = append(progressed, bytesWritten) }
+
+	stats, err := ops.ResumeCopyFileWithStats(src, dst, offset, progress, nil, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(stats.ResumedBytes).To(Equal(offset))
+	g.Expect(stats.BytesCopied).To(Equal(int64(len(content))))
+	g.Expect(progressed[0]).To(Equal(offset))
+	g.Expect(progressed[len(progressed)-1]).To(Equal(int64(len(content))))
+
+	sum := sha256.Sum256(content)
+	g.Expect(stats.SourceHash).To(Equal(hex.EncodeToString(sum[:])))
+
+	copied, err := os.ReadFile(dst)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(copied).To(Equal(content))
+
+	_, ok = ops.PartialCopySize(dst)
+	g.Expect(ok).To(BeFalse())
+}
+
+// TestFileOps_PartialCopyMatches_DetectsChangedSource verifies that a partial copy whose bytes
+// differ from the source's is reported as not matching.
+func TestFileOps_PartialCopyMatches_DetectsChangedSource(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "big.bin")
+	dst := filepath.Join(dir, "big-copy.bin")
+
+	content := resumeContent()
+	g.Expect(os.WriteFile(src, content, 0o600)).To(Succeed())
+
+	partial := bytes.Clone(content[:1000])
+	partial[500] ^= 0xff
+	g.Expect(os.WriteFile(dst+fileops.TempSuffix, partial, 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+
+	match, err := ops.PartialCopyMatches(src, dst, int64(len(partial)))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(match).To(BeFalse())
+}
+
+// TestFileOps_ResumeCopyFileWithStats_RejectsWrongOffset verifies that a partial copy that isn't
+// the size the resume expects isn't appended to.
+func TestFileOps_ResumeCopyFileWithStats_RejectsWrongOffset(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "big.bin")
+	dst := filepath.Join(dir, "big-copy.bin")
+
+	content := resumeContent()
+	g.Expect(os.WriteFile(src, content, 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(dst+fileops.TempSuffix, content[:1000], 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+
+	_, err := ops.ResumeCopyFileWithStats(src, dst, 2000, nil, nil, nil)
+	g.Expect(err).Should(MatchError(fileops.ErrCannotResume))
+
+	_, err = os.Stat(dst)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+}
+
+// resumeContent returns 1MB of non-repeating bytes, so a misplaced resume shows up as a mismatch.
+func resumeContent() []byte {
+	content := make([]byte, 1<<20)
+	for i := range content {
+		content[i] = byte(i*7 + i/251)
+	}
+
+	return content
+}
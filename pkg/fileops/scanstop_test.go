@@ -0,0 +1,106 @@
+package fileops_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestFileOps_ScanDirectory_SkipsLeftoverSnapshots verifies that a btrfs snapshot an
+// interrupted --snapshot-source run left in the source is neither scanned nor counted.
+func TestFileOps_ScanDirectory_SkipsLeftoverSnapshots(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	root := t.TempDir()
+	snapshotDir := filepath.Join(root, ".glowsync-20240506-070809-4242")
+
+	g.Expect(os.MkdirAll(filepath.Join(snapshotDir, "docs"), 0o750)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(snapshotDir, "docs", "a.txt"), []byte("a"), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(root, "a.txt"), []byte("a"), 0o600)).To(Succeed())
+
+	ops := fileops.NewFileOps(filesystem.NewRealFileSystem())
+
+	files, err := ops.ScanDirectory(root)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(files).To(HaveLen(1))
+	g.Expect(files).To(HaveKey("a.txt"))
+
+	g.Expect(ops.CountFiles(root)).To(Equal(1))
+}
+
+// TestFileOps_CountFilesWithProgress_CancelStopsScan verifies that a cancelled count fails with
+// ErrCancelled and stops the scan it abandons.
+func TestFileOps_CountFilesWithProgress_CancelStopsScan(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		g.Expect(os.WriteFile(filepath.Join(dir, name), []byte("content"), 0o600)).To(Succeed())
+	}
+
+	fs := &stopRecordingFS{FileSystem: filesystem.NewRealFileSystem()}
+	ops := fileops.NewDualFileOps(fs, filesystem.NewRealFileSystem())
+
+	cancelChan := make(chan struct{})
+	close(cancelChan)
+
+	ops.CancelChan = cancelChan
+
+	_, err := ops.CountFilesWithProgress(dir, nil)
+	g.Expect(errors.Is(err, fileops.ErrCancelled)).To(BeTrue(), "got %v", err)
+	g.Expect(fs.scanner.stopped).To(BeTrue())
+}
+
+// TestFileOps_ScanDirectoryWithProgress_StopsFinishedScan verifies that a scan is stopped once
+// read to the end too, which a scanner that's already done ignores.
+func TestFileOps_ScanDirectoryWithProgress_StopsFinishedScan(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "a.txt"), []byte("content"), 0o600)).To(Succeed())
+
+	fs := &stopRecordingFS{FileSystem: filesystem.NewRealFileSystem()}
+	ops := fileops.NewDualFileOps(fs, filesystem.NewRealFileSystem())
+
+	files, err := ops.ScanDirectoryWithProgress(dir, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(files).To(HaveKey("a.txt"))
+	g.Expect(fs.scanner.stopped).To(BeTrue())
+}
+
+// stopRecordingFS wraps a FileSystem, recording whether its scan is stopped.
+type stopRecordingFS struct {
+	filesystem.FileSystem
+
+	scanner *stopRecordingScanner
+}
+
+func (f *stopRecordingFS) Scan(path string) filesystem.FileScanner {
+	f.scanner = &stopRecordingScanner{FileScanner: f.FileSystem.Scan(path)}
+
+	return f.scanner
+}
+
+type stopRecordingScanner struct {
+	filesystem.FileScanner
+
+	stopped bool
+}
+
+func (s *stopRecordingScanner) Stop() {
+	s.stopped = true
+
+	if stopper, ok := s.FileScanner.(filesystem.ScanStopper); ok {
+		stopper.Stop()
+	}
+}
@@ -0,0 +1,101 @@
+package fileops
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// sparseBlockSize is the run of zeros Sparse leaves as a hole: a common filesystem block size,
+// so holes line up with blocks the filesystem can leave unallocated.
+const sparseBlockSize = 4096
+
+// zeroBlock is compared against each block of a sparse copy to find the all-zero ones.
+var zeroBlock = make([]byte, sparseBlockSize)
+
+// sparseFile is a destination file that can skip over holes and set its final size.
+type sparseFile interface {
+	io.Seeker
+	Truncate(size int64) error
+}
+
+// sparseDest returns destFile as a sparseFile when Sparse is set and it can seek and truncate.
+func (fo *FileOps) sparseDest(destFile filesystem.File) (sparseFile, bool) {
+	if !fo.Sparse {
+		return nil, false
+	}
+
+	sparse, ok := destFile.(sparseFile)
+
+	return sparse, ok
+}
+
+// writeSparse writes buf to destFile block by block, seeking past all-zero blocks instead of
+// writing them. It returns the bytes written or skipped, and whether buf ended in a hole, which
+// the caller has to close with finishSparse once the copy is complete.
+func writeSparse(destFile filesystem.File, sparse sparseFile, buf []byte, stats *CopyStats) (int, bool, error) {
+	writeStart := time.Now()
+	defer func() { stats.WriteTime += time.Since(writeStart) }()
+
+	var (
+		done   int
+		inHole bool
+	)
+
+	for done < len(buf) {
+		end := min(done+sparseBlockSize, len(buf))
+		block := buf[done:end]
+
+		if bytes.Equal(block, zeroBlock[:len(block)]) {
+			_, err := sparse.Seek(int64(len(block)), io.SeekCurrent)
+			if err != nil {
+				return done, inHole, fmt.Errorf("failed to skip hole: %w", err)
+			}
+
+			stats.HoleBytes += int64(len(block))
+			done = end
+			inHole = true
+
+			continue
+		}
+
+		// Write this block and every non-zero block after it in one call
+		for end < len(buf) {
+			next := buf[end:min(end+sparseBlockSize, len(buf))]
+			if bytes.Equal(next, zeroBlock[:len(next)]) {
+				break
+			}
+
+			end += len(next)
+		}
+
+		chunk := buf[done:end]
+		nw, err := destFile.Write(chunk)
+		done += nw
+		inHole = false
+
+		if err != nil {
+			return done, inHole, err //nolint:wrapcheck // Wrapped by copyLoop like dense writes
+		}
+
+		if nw != len(chunk) {
+			return done, inHole, io.ErrShortWrite
+		}
+	}
+
+	return done, inHole, nil
+}
+
+// finishSparse extends a sparse copy that ended in a hole to its full size: seeking past the
+// end doesn't grow a file until something is written there.
+func finishSparse(sparse sparseFile, size int64) error {
+	err := sparse.Truncate(size)
+	if err != nil {
+		return fmt.Errorf("failed to extend destination past trailing hole: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package fileops_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestFileOps_CopyFileWithStats_Sparse verifies that zero runs, including one at the end of the
+// file, are left as holes while the copy, its progress and its hash still cover every byte.
+func TestFileOps_CopyFileWithStats_Sparse(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "disk.img")
+	dst := filepath.Join(dir, "out", "disk.img")
+
+	content := sparseContent()
+	g.Expect(os.WriteFile(src, content, 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+	ops.Sparse = true
+	ops.HashOnCopy = true
+
+	var lastProgress int64
+
+	progress := func(bytesWritten, _ int64, _ string) { lastProgress = bytesWritten }
+
+	stats, err := ops.CopyFileWithStats(src, dst, progress, nil, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(stats.BytesCopied).To(Equal(int64(len(content))))
+	g.Expect(stats.HoleBytes).To(Equal(int64(4<<20 - 4096)))
+	g.Expect(lastProgress).To(Equal(int64(len(content))))
+
+	sum := sha256.Sum256(content)
+	g.Expect(stats.SourceHash).To(Equal(hex.EncodeToString(sum[:])))
+
+	copied, err := os.ReadFile(dst)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(copied).To(Equal(content))
+
+	info, err := os.Stat(dst)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	if allocated, ok := filesystem.AllocatedSize(info); ok {
+		g.Expect(allocated).To(BeNumerically("<", len(content)))
+	}
+}
+
+// TestFileOps_CopyFileWithStats_SparseFallsBackToDense verifies that a destination that can't
+// seek gets every byte written.
+func TestFileOps_CopyFileWithStats_SparseFallsBackToDense(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "disk.img")
+	dst := filepath.Join(dir, "out", "disk.img")
+
+	content := sparseContent()
+	g.Expect(os.WriteFile(src, content, 0o600)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), streamOnlyFS{filesystem.NewRealFileSystem()})
+	ops.Sparse = true
+
+	stats, err := ops.CopyFileWithStats(src, dst, nil, nil, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(stats.BytesCopied).To(Equal(int64(len(content))))
+	g.Expect(stats.HoleBytes).To(BeZero())
+
+	copied, err := os.ReadFile(dst)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(copied).To(Equal(content))
+}
+
+// sparseContent is data, a 2 MiB zero run, a short write padded with zeros to the next MiB, a
+// block of data, and a 1 MiB zero run at the end.
+func sparseContent() []byte {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 1<<12)
+	content = append(content, make([]byte, 2<<20)...)
+	content = append(content, []byte("unaligned data in the middle")...)
+	content = append(content, make([]byte, 1<<20-len("unaligned data in the middle"))...)
+	content = append(content, bytes.Repeat([]byte{0xff}, 1<<12)...)
+
+	return append(content, make([]byte, 1<<20)...)
+}
+
+// streamOnlyFS is a filesystem whose files can only be read and written in order, like SFTP's.
+type streamOnlyFS struct {
+	*filesystem.RealFileSystem
+}
+
+func (fs streamOnlyFS) Create(path string) (filesystem.File, error) {
+	file, err := fs.RealFileSystem.Create(path)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Test double
+	}
+
+	return streamOnlyFile{file}, nil
+}
+
+// streamOnlyFile hides everything but the filesystem.File methods of the file it wraps.
+type streamOnlyFile struct {
+	filesystem.File
+}
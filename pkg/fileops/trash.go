@@ -0,0 +1,135 @@
+package fileops
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TrashDest moves the destination file at path to trashPath on the destination filesystem,
+// creating trashPath's parent directories. When the file can't be renamed there, it is copied,
+// keeping its modtime and permissions, and then removed: a rename across filesystems fails
+// differently on every platform and SFTP server, so any rename failure falls back to the copy.
+//
+// A directory (isDir) is recreated at trashPath and then removed, so it has to be empty, as an
+// orphaned directory is once its orphaned files have been trashed.
+func (fo *FileOps) TrashDest(path, trashPath string, isDir bool) error {
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return fmt.Errorf("failed to trash %s: %w", path, err)
+	}
+
+	dstFS := fo.getDestFS()
+
+	dir := filepath.Dir(trashPath)
+	if isDir {
+		dir = trashPath
+	}
+
+	err = dstFS.MkdirAll(dir, DefaultDirPermissions)
+	if err != nil {
+		return fmt.Errorf("failed to create trash directory %s: %w", dir, err)
+	}
+
+	if !isDir {
+		err = fo.moveWithinDest(path, trashPath)
+		if err != nil {
+			return fmt.Errorf("failed to trash %s: %w", path, err)
+		}
+
+		return nil
+	}
+
+	err = fo.OpLimiter.Wait(fo.CancelChan)
+	if err == nil {
+		err = dstFS.Remove(path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to trash %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// moveWithinDest renames the destination file path to newPath, or copies it there and removes it
+// if it can't be renamed. Each filesystem call waits its turn with OpLimiter; once the first has
+// gone ahead, the rest don't stop for cancellation, so a move is never left half done.
+func (fo *FileOps) moveWithinDest(path, newPath string) error {
+	dstFS := fo.getDestFS()
+
+	err := fo.OpLimiter.Wait(fo.CancelChan)
+	if err != nil {
+		return err
+	}
+
+	if renamer, ok := dstFS.(filesystem.Renamer); ok {
+		if renamer.Rename(path, newPath) == nil {
+			return nil
+		}
+
+		_ = fo.OpLimiter.Wait(nil)
+	}
+
+	info, err := dstFS.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat: %w", err)
+	}
+
+	_ = fo.OpLimiter.Wait(nil)
+
+	src, err := dstFS.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	_ = fo.OpLimiter.Wait(nil)
+
+	dst, err := dstFS.Create(newPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", newPath, err)
+	}
+
+	stamped := setModTime(dst, info.ModTime())
+
+	_, err = io.Copy(dst, src)
+	if err != nil {
+		abortFile(dst)
+		_ = dst.Close()
+		_ = fo.OpLimiter.Wait(nil)
+		_ = dstFS.Remove(newPath)
+
+		return fmt.Errorf("failed to copy to %s: %w", newPath, err)
+	}
+
+	err = dst.Close()
+	if err != nil {
+		_ = fo.OpLimiter.Wait(nil)
+		_ = dstFS.Remove(newPath)
+
+		return fmt.Errorf("failed to close %s: %w", newPath, err)
+	}
+
+	// Best effort: the trashed copy's content is what matters
+	if !stamped {
+		_ = fo.OpLimiter.Wait(nil)
+		_ = dstFS.Chtimes(newPath, info.ModTime(), info.ModTime())
+	}
+
+	if chmoder, ok := dstFS.(filesystem.Chmoder); ok {
+		_ = fo.OpLimiter.Wait(nil)
+		_ = chmoder.Chmod(newPath, info.Mode().Perm())
+	}
+
+	_ = fo.OpLimiter.Wait(nil)
+
+	err = dstFS.Remove(path)
+	if err != nil {
+		return fmt.Errorf("failed to remove after copying to %s: %w", newPath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package fileops_test
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestFileOps_TrashDest_CopiesAcrossFilesystems verifies that a file that can't be renamed into
+// the trash is copied there, keeping its modtime, and removed.
+func TestFileOps_TrashDest_CopiesAcrossFilesystems(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dest", "orphan.txt")
+	trashPath := filepath.Join(dir, "trash", "run", "sub", "orphan.txt")
+	modTime := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	g.Expect(os.MkdirAll(filepath.Dir(path), 0o750)).To(Succeed())
+	g.Expect(os.WriteFile(path, []byte("orphan"), 0o600)).To(Succeed())
+	g.Expect(os.Chtimes(path, modTime, modTime)).To(Succeed())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), crossDeviceFS{filesystem.NewRealFileSystem()})
+
+	g.Expect(ops.TrashDest(path, trashPath, false)).To(Succeed())
+
+	g.Expect(path).NotTo(BeAnExistingFile())
+	g.Expect(os.ReadFile(trashPath)).To(Equal([]byte("orphan")))
+
+	info, err := os.Stat(trashPath)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(info.ModTime().Equal(modTime)).To(BeTrue())
+}
+
+// crossDeviceFS is a filesystem whose renames fail as they do across filesystems.
+type crossDeviceFS struct {
+	*filesystem.RealFileSystem
+}
+
+func (crossDeviceFS) Rename(oldpath, newpath string) error {
+	return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: syscall.EXDEV}
+}
@@ -0,0 +1,25 @@
+package fileops
+
+import (
+	"errors"
+)
+
+// copyXattr copies the extended attribute name from src to dst. A src without it leaves dst
+// without it too, so a rewritten destination file never keeps a stale value.
+func copyXattr(src, dst, name string) error {
+	value, err := GetXattr(src, name)
+	if errors.Is(err, ErrXattrNotFound) {
+		err = RemoveXattr(dst, name)
+		if errors.Is(err, ErrXattrNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return SetXattr(dst, name, value)
+}
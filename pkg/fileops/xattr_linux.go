@@ -0,0 +1,67 @@
+//go:build linux
+
+package fileops
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+)
+
+// GetXattr returns the value of the extended attribute name (e.g. "user.asset_version") on the
+// local file at path. Returns ErrXattrNotFound if the file doesn't have it, and
+// ErrXattrNotSupported if its filesystem has no extended attributes.
+func GetXattr(path, name string) (string, error) {
+	for {
+		size, err := syscall.Getxattr(path, name, nil)
+		if err != nil {
+			return "", xattrError(path, name, err)
+		}
+
+		buf := make([]byte, size)
+
+		size, err = syscall.Getxattr(path, name, buf)
+		// The value grew between the two calls; ask for its size again
+		if errors.Is(err, syscall.ERANGE) {
+			continue
+		}
+
+		if err != nil {
+			return "", xattrError(path, name, err)
+		}
+
+		return string(buf[:size]), nil
+	}
+}
+
+// SetXattr sets the extended attribute name on the local file at path.
+func SetXattr(path, name, value string) error {
+	err := syscall.Setxattr(path, name, []byte(value), 0)
+	if err != nil {
+		return xattrError(path, name, err)
+	}
+
+	return nil
+}
+
+// RemoveXattr removes the extended attribute name from the local file at path.
+func RemoveXattr(path, name string) error {
+	err := syscall.Removexattr(path, name)
+	if err != nil {
+		return xattrError(path, name, err)
+	}
+
+	return nil
+}
+
+// xattrError maps an xattr syscall failure to the package's sentinel errors.
+func xattrError(path, name string, err error) error {
+	switch {
+	case errors.Is(err, syscall.ENODATA):
+		return fmt.Errorf("%w: %s on %s", ErrXattrNotFound, name, path)
+	case errors.Is(err, syscall.ENOTSUP):
+		return fmt.Errorf("%w: %s", ErrXattrNotSupported, path)
+	}
+
+	return fmt.Errorf("failed to access %s on %s: %w", name, path, err)
+}
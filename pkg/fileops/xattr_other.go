@@ -0,0 +1,18 @@
+//go:build !linux
+
+package fileops
+
+// GetXattr is only implemented on Linux.
+func GetXattr(_, _ string) (string, error) {
+	return "", ErrXattrNotSupported
+}
+
+// RemoveXattr is only implemented on Linux.
+func RemoveXattr(_, _ string) error {
+	return ErrXattrNotSupported
+}
+
+// SetXattr is only implemented on Linux.
+func SetXattr(_, _, _ string) error {
+	return ErrXattrNotSupported
+}
@@ -0,0 +1,47 @@
+package fileops_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/fileops"
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestFileOps_CopyFileWithStats_CopyXattr verifies that the named attribute is copied with the
+// file, and that a source without it clears a stale value left on the destination.
+func TestFileOps_CopyFileWithStats_CopyXattr(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "dst.txt")
+	g.Expect(os.WriteFile(src, []byte("versioned"), 0o600)).To(Succeed())
+
+	err := fileops.SetXattr(src, "user.asset_version", "1.2")
+	if errors.Is(err, fileops.ErrXattrNotSupported) {
+		t.Skip("extended attributes not supported here")
+	}
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	ops := fileops.NewDualFileOps(filesystem.NewRealFileSystem(), filesystem.NewRealFileSystem())
+	ops.CopyXattr = "user.asset_version"
+
+	_, err = ops.CopyFileWithStats(src, dst, nil, nil, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(fileops.GetXattr(dst, "user.asset_version")).To(Equal("1.2"))
+
+	g.Expect(fileops.RemoveXattr(src, "user.asset_version")).To(Succeed())
+
+	_, err = ops.CopyFileWithStats(src, dst, nil, nil, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	_, err = fileops.GetXattr(dst, "user.asset_version")
+	g.Expect(err).To(MatchError(fileops.ErrXattrNotFound))
+}
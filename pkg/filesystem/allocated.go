@@ -0,0 +1,10 @@
+package filesystem
+
+import "os"
+
+// AllocatedSize returns the bytes of storage a local file actually occupies, which is less
+// than its size for a sparse file. ok is false where the allocation isn't known (e.g. SFTP or
+// Windows).
+func AllocatedSize(info os.FileInfo) (allocated int64, ok bool) {
+	return localAllocatedSize(info)
+}
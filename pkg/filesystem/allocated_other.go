@@ -0,0 +1,10 @@
+//go:build !unix
+
+package filesystem
+
+import "os"
+
+// localAllocatedSize reports no allocation on platforms whose stat doesn't count blocks.
+func localAllocatedSize(_ os.FileInfo) (allocated int64, ok bool) {
+	return 0, false
+}
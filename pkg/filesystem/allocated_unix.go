@@ -0,0 +1,21 @@
+//go:build unix
+
+package filesystem
+
+import (
+	"os"
+	"syscall"
+)
+
+// statBlockSize is the unit of stat(2)'s st_blocks, regardless of the filesystem's block size.
+const statBlockSize = 512
+
+// localAllocatedSize reads the allocated blocks from the stat(2) result behind info.
+func localAllocatedSize(info os.FileInfo) (allocated int64, ok bool) {
+	stat, isStat := info.Sys().(*syscall.Stat_t)
+	if !isStat {
+		return 0, false
+	}
+
+	return stat.Blocks * statBlockSize, true
+}
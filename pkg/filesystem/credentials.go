@@ -0,0 +1,146 @@
+package filesystem
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// Exported constants.
+const (
+	// CredentialsEnvPrefix and CredentialsEnvSuffix surround the host in password variables,
+	// e.g. GLOWSYNC_BACKUP_EXAMPLE_COM_PASSWORD for backup.example.com
+	CredentialsEnvPrefix = "GLOWSYNC_"
+	CredentialsEnvSuffix = "_PASSWORD"
+)
+
+// Exported variables.
+var (
+	ErrInsecureCredentialsFile = errors.New("credentials file is readable by other users")
+)
+
+// Credential is a secret for authenticating to a remote host, resolved outside the URL
+// so it never appears on the command line.
+type Credential struct {
+	Password string
+}
+
+// CredentialsEnvVar returns the environment variable holding the password for host:
+// the host upper-cased with every character other than a letter or digit replaced by '_'.
+func CredentialsEnvVar(host string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+
+		return '_'
+	}, host)
+
+	return CredentialsEnvPrefix + name + CredentialsEnvSuffix
+}
+
+// CredentialsFilePath returns the default secrets file, ~/.config/glowsync/credentials.
+func CredentialsFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".config", "glowsync", "credentials"), nil
+}
+
+// ResolveCredential looks up the password for user@host, first in the host's environment
+// variable (see CredentialsEnvVar) and then in the secrets file at path. Returns nil if
+// neither has one, so key-based authentication can be used alone.
+//
+// The secrets file has one "host password" or "user@host password" entry per line; a
+// user@host entry wins over a host entry, and blank lines and lines starting with # are
+// ignored. The file must not be accessible by group or others (mode 0600), or
+// ErrInsecureCredentialsFile is returned.
+func ResolveCredential(user, host, path string) (*Credential, error) {
+	return ResolveCredentialWithKeychain(user, host, path, nil)
+}
+
+// ResolveCredentialWithKeychain is ResolveCredential with keychain consulted between the
+// environment and the secrets file. A nil keychain is skipped.
+func ResolveCredentialWithKeychain(user, host, path string, keychain Keychain) (*Credential, error) {
+	if password, ok := os.LookupEnv(CredentialsEnvVar(host)); ok {
+		return &Credential{Password: password}, nil
+	}
+
+	if keychain != nil {
+		password, found, err := keychain.Lookup(host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keychain: %w", err)
+		}
+
+		if found {
+			return &Credential{Password: password}, nil
+		}
+	}
+
+	file, err := os.Open(path) //nolint:gosec // Path is the user's own secrets file
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil //nolint:nilnil // No credential is a valid outcome
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open credentials file: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat credentials file: %w", err)
+	}
+
+	if info.Mode().Perm()&0o077 != 0 {
+		return nil, fmt.Errorf("%w: %s has mode %04o (run chmod 600 on it)", ErrInsecureCredentialsFile, path, info.Mode().Perm())
+	}
+
+	return findCredential(file, user, host, path)
+}
+
+// findCredential scans a secrets file for user@host, falling back to a host-only entry.
+func findCredential(file *os.File, user, host, path string) (*Credential, error) {
+	var hostOnly *Credential
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, password, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+
+		password = strings.TrimSpace(password)
+
+		switch key {
+		case user + "@" + host:
+			return &Credential{Password: password}, nil
+		case host:
+			if hostOnly == nil {
+				hostOnly = &Credential{Password: password}
+			}
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file %s: %w", path, err)
+	}
+
+	return hostOnly, nil
+}
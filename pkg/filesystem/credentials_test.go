@@ -0,0 +1,169 @@
+//nolint:varnamelen // Test files use idiomatic short variable names (t, etc.)
+package filesystem_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+// TestCredentialsEnvVar tests that hosts map to upper-case, underscore-separated variable names.
+func TestCredentialsEnvVar(t *testing.T) {
+	t.Parallel()
+
+	got := filesystem.CredentialsEnvVar("backup-1.example.com")
+	if got != "GLOWSYNC_BACKUP_1_EXAMPLE_COM_PASSWORD" {
+		t.Errorf("CredentialsEnvVar = %q, want %q", got, "GLOWSYNC_BACKUP_1_EXAMPLE_COM_PASSWORD")
+	}
+}
+
+// TestResolveCredential_File tests lookups in the secrets file, preferring user@host entries.
+func TestResolveCredential_File(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "credentials")
+	content := "# comment\n\nfiles.test hostpass\nalice@files.test alicepass\n"
+
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write credentials file: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		user string
+		host string
+		want string
+	}{
+		{name: "user@host entry", user: "alice", host: "files.test", want: "alicepass"},
+		{name: "host entry", user: "bob", host: "files.test", want: "hostpass"},
+		{name: "unknown host", user: "alice", host: "other.test", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cred, err := filesystem.ResolveCredential(tt.user, tt.host, path)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			got := ""
+			if cred != nil {
+				got = cred.Password
+			}
+
+			if got != tt.want {
+				t.Errorf("Password = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestResolveCredential_EnvOverridesFile tests that the environment variable wins over the file.
+func TestResolveCredential_EnvOverridesFile(t *testing.T) {
+	t.Setenv(filesystem.CredentialsEnvVar("env.test"), "fromenv")
+
+	path := filepath.Join(t.TempDir(), "credentials")
+
+	err := os.WriteFile(path, []byte("env.test fromfile\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write credentials file: %v", err)
+	}
+
+	cred, err := filesystem.ResolveCredential("user", "env.test", path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cred == nil || cred.Password != "fromenv" {
+		t.Errorf("Credential = %+v, want password %q", cred, "fromenv")
+	}
+}
+
+// TestResolveCredential_RejectsReadableFile tests that a file readable by others is refused.
+func TestResolveCredential_RejectsReadableFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "credentials")
+
+	err := os.WriteFile(path, []byte("open.test secret\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write credentials file: %v", err)
+	}
+
+	err = os.Chmod(path, 0o644) //nolint:gosec // Deliberately insecure for the test
+	if err != nil {
+		t.Fatalf("Failed to chmod credentials file: %v", err)
+	}
+
+	_, err = filesystem.ResolveCredential("user", "open.test", path)
+	if !errors.Is(err, filesystem.ErrInsecureCredentialsFile) {
+		t.Errorf("err = %v, want ErrInsecureCredentialsFile", err)
+	}
+}
+
+// TestResolveCredential_MissingFile tests that no file means no credential and no error.
+func TestResolveCredential_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	cred, err := filesystem.ResolveCredential("user", "missing.test", filepath.Join(t.TempDir(), "credentials"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cred != nil {
+		t.Errorf("Credential = %+v, want nil", cred)
+	}
+}
+
+// TestResolveCredentialWithKeychain_KeychainBeforeFile tests that a stored keychain password
+// wins over the file, and a host missing from the keychain falls through to the file.
+func TestResolveCredentialWithKeychain_KeychainBeforeFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "credentials")
+
+	err := os.WriteFile(path, []byte("stored.test fromfile\nfile.test fromfile\n"), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to write credentials file: %v", err)
+	}
+
+	keychain := fakeKeychain{"stored.test": "fromkeychain"}
+
+	tests := []struct {
+		host string
+		want string
+	}{
+		{host: "stored.test", want: "fromkeychain"},
+		{host: "file.test", want: "fromfile"},
+	}
+
+	for _, tt := range tests {
+		cred, err := filesystem.ResolveCredentialWithKeychain("user", tt.host, path, keychain)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if cred == nil || cred.Password != tt.want {
+			t.Errorf("%s: Credential = %+v, want password %q", tt.host, cred, tt.want)
+		}
+	}
+}
+
+// fakeKeychain is an in-memory keychain of host -> password.
+type fakeKeychain map[string]string
+
+func (k fakeKeychain) Lookup(host string) (string, bool, error) {
+	password, ok := k[host]
+	return password, ok, nil
+}
+
+func (k fakeKeychain) Store(host, password string) error {
+	k[host] = password
+	return nil
+}
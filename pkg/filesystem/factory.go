@@ -8,8 +8,15 @@ import (
 // Returns (filesystem, basePath, closer, error).
 // - filesystem: The FileSystem to use for operations
 // - basePath: The actual path to use with the filesystem (stripped of URL prefix)
-// - closer: A function to call when done (closes SFTP connections), or nil for local
-func CreateFileSystem(pathStr string) (FileSystem, string, func(), error) {
+// - closer: A function to call when done (closes SFTP and object store connections), or nil for local
+//
+// SFTP passwords are looked up in keychain after the environment (see
+// ResolveCredentialWithKeychain); nil skips it.
+func CreateFileSystem(pathStr string, keychain Keychain) (FileSystem, string, func(), error) {
+	if IsObjectStoreURL(pathStr) {
+		return createObjectStoreFileSystem(pathStr)
+	}
+
 	parsed, err := ParsePath(pathStr)
 	if err != nil {
 		return nil, "", nil, err
@@ -20,8 +27,19 @@ func CreateFileSystem(pathStr string) (FileSystem, string, func(), error) {
 		return NewRealFileSystem(), parsed.LocalPath, nil, nil
 	}
 
-	// SFTP filesystem
-	conn, err := Connect(parsed.Host, parsed.Port, parsed.User)
+	// SFTP filesystem. Passwords come from the environment, the keychain or the credentials
+	// file, never from the URL, so they don't leak into shell history or process listings.
+	credsPath, err := CredentialsFilePath()
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	cred, err := ResolveCredentialWithKeychain(parsed.User, parsed.Host, credsPath, keychain)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to resolve credentials for %s: %w", parsed.Host, err)
+	}
+
+	conn, err := ConnectWithCredential(parsed.Host, parsed.Port, parsed.User, cred)
 	if err != nil {
 		return nil, "", nil, fmt.Errorf("failed to connect to %s@%s:%d: %w",
 			parsed.User, parsed.Host, parsed.Port, err)
@@ -33,6 +51,10 @@ func CreateFileSystem(pathStr string) (FileSystem, string, func(), error) {
 		return nil, "", nil, fmt.Errorf("failed to create SFTP filesystem: %w", err)
 	}
 
+	if confirmer, ok := keychain.(KeychainConfirmer); ok && cred != nil {
+		confirmer.Confirm(parsed.Host)
+	}
+
 	closer := func() {
 		_ = fs.Close()
 		_ = conn.Close()
@@ -41,10 +63,32 @@ func CreateFileSystem(pathStr string) (FileSystem, string, func(), error) {
 	return fs, parsed.Path, closer, nil
 }
 
-// CreateFileSystemPair creates filesystems for source and destination paths.
+// createObjectStoreFileSystem creates a FileSystem for an s3:// or gs:// URL, with credentials
+// from the environment (see ObjectStoreConfigFromEnv).
+func createObjectStoreFileSystem(rawURL string) (FileSystem, string, func(), error) {
+	parsed, err := ParseObjectStoreURL(rawURL)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	cfg, err := ObjectStoreConfigFromEnv(parsed)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	fs, err := NewObjectStoreFileSystem(*cfg) //nolint:varnamelen // fs is idiomatic for filesystem variable
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to create object store filesystem: %w", err)
+	}
+
+	return fs, parsed.Prefix, func() { _ = fs.Close() }, nil
+}
+
+// CreateFileSystemPair creates filesystems for source and destination paths, with SFTP
+// passwords looked up in keychain as for CreateFileSystem.
 // Returns (sourceFS, destFS, sourcePath, destPath, closer, error).
 // The closer function should be called when done to clean up any connections.
-func CreateFileSystemPair(sourcePath, destPath string) (
+func CreateFileSystemPair(sourcePath, destPath string, keychain Keychain) (
 	sourceFS FileSystem,
 	destFS FileSystem,
 	srcPath string,
@@ -55,13 +99,13 @@ func CreateFileSystemPair(sourcePath, destPath string) (
 	var srcCloser, dstCloser func()
 
 	// Create source filesystem
-	sourceFS, srcPath, srcCloser, err = CreateFileSystem(sourcePath)
+	sourceFS, srcPath, srcCloser, err = CreateFileSystem(sourcePath, keychain)
 	if err != nil {
 		return nil, nil, "", "", nil, fmt.Errorf("failed to create source filesystem: %w", err)
 	}
 
 	// Create destination filesystem
-	destFS, dstPath, dstCloser, err = CreateFileSystem(destPath)
+	destFS, dstPath, dstCloser, err = CreateFileSystem(destPath, keychain)
 	if err != nil {
 		// Clean up source if destination fails
 		if srcCloser != nil {
@@ -37,6 +37,55 @@ type FileSystem interface {
 	Stat(path string) (os.FileInfo, error)
 }
 
+// Aborter is implemented by files whose contents only appear once they are closed, so a write
+// that failed part way can be discarded instead of published. Close after Abort returns an error.
+type Aborter interface {
+	Abort()
+}
+
+// Appender is implemented by filesystems that can reopen an existing file to write more to its
+// end, so a copy that was cut off can carry on from where it stopped.
+type Appender interface {
+	OpenAppend(path string) (File, error)
+}
+
+// Chmoder is implemented by filesystems that can change permission bits.
+// It is separate from FileSystem so implementations without permissions don't need it.
+type Chmoder interface {
+	Chmod(path string, mode os.FileMode) error
+}
+
+// ModTimeSetter is implemented by files that can record their modification time with their
+// contents, sparing a separate Chtimes. SetModTime must be called before the first Write.
+type ModTimeSetter interface {
+	SetModTime(mtime time.Time)
+}
+
+// Renamer is implemented by filesystems that can move a file to a new path in one operation,
+// replacing any file already there.
+type Renamer interface {
+	Rename(oldpath, newpath string) error
+}
+
+// SymlinkFollower is implemented by filesystems that can scan through symbolic links, yielding
+// each link as its target (IsSymlink unset) and walking linked directories. Scan never follows
+// links.
+type SymlinkFollower interface {
+	ScanFollowingSymlinks(path string) FileScanner
+}
+
+// Symlinker is implemented by filesystems that can read and create symbolic links.
+type Symlinker interface {
+	Readlink(path string) (string, error)
+	Symlink(target, path string) error
+}
+
+// TarExtractor is implemented by filesystems that can unpack a tar stream in one operation.
+// Remote filesystems use it to send many small files without a round trip per file.
+type TarExtractor interface {
+	ExtractTar(dir string, r io.Reader) error
+}
+
 // RealFileSystem implements FileSystem using actual os/filepath functions.
 type RealFileSystem struct{}
 
@@ -45,6 +94,16 @@ func NewRealFileSystem() *RealFileSystem {
 	return &RealFileSystem{}
 }
 
+// Chmod changes the permission bits of a file.
+func (fs *RealFileSystem) Chmod(path string, mode os.FileMode) error {
+	err := os.Chmod(path, mode)
+	if err != nil {
+		return fmt.Errorf("failed to change mode for %s: %w", path, err)
+	}
+
+	return nil
+}
+
 // Chtimes changes the access and modification times of a file.
 func (fs *RealFileSystem) Chtimes(path string, atime, mtime time.Time) error {
 	err := os.Chtimes(path, atime, mtime)
@@ -85,6 +144,26 @@ func (fs *RealFileSystem) Open(path string) (File, error) {
 	return file, nil
 }
 
+// OpenAppend opens an existing file for writing at its end.
+func (fs *RealFileSystem) OpenAppend(path string) (File, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s for appending: %w", path, err)
+	}
+
+	return file, nil
+}
+
+// Readlink returns the target of a symbolic link.
+func (fs *RealFileSystem) Readlink(path string) (string, error) {
+	target, err := os.Readlink(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read link %s: %w", path, err)
+	}
+
+	return target, nil
+}
+
 // Remove removes a file or empty directory.
 func (fs *RealFileSystem) Remove(path string) error {
 	err := os.Remove(path)
@@ -95,11 +174,31 @@ func (fs *RealFileSystem) Remove(path string) error {
 	return nil
 }
 
+// Rename moves a file, replacing any file at newpath. Both paths must be on the same
+// filesystem for the move to be atomic.
+func (fs *RealFileSystem) Rename(oldpath, newpath string) error {
+	err := os.Rename(oldpath, newpath)
+	if err != nil {
+		return fmt.Errorf("failed to rename %s to %s: %w", oldpath, newpath, err)
+	}
+
+	return nil
+}
+
 // Scan returns an iterator over all files in a directory tree.
 func (fs *RealFileSystem) Scan(path string) FileScanner {
 	return newRealFileScanner(path)
 }
 
+// ScanFollowingSymlinks returns an iterator over all files in a directory tree, following
+// symbolic links. Links back to a directory being walked are left out, so loops end.
+func (fs *RealFileSystem) ScanFollowingSymlinks(path string) FileScanner {
+	scanner := newRealFileScanner(path)
+	scanner.followLinks = true
+
+	return scanner
+}
+
 // Stat returns file information.
 func (fs *RealFileSystem) Stat(path string) (os.FileInfo, error) {
 	info, err := os.Stat(path)
@@ -109,3 +208,13 @@ func (fs *RealFileSystem) Stat(path string) (os.FileInfo, error) {
 
 	return info, nil
 }
+
+// Symlink creates a symbolic link at path pointing at target.
+func (fs *RealFileSystem) Symlink(target, path string) error {
+	err := os.Symlink(target, path)
+	if err != nil {
+		return fmt.Errorf("failed to create link %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+//go:build linux
+
+package filesystem
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// Filesystem magic numbers reported by statfs(2).
+const (
+	ext4SuperMagic  = 0xEF53
+	exfatSuperMagic = 0x2011BAB0
+	fuseSuperMagic  = 0x65735546
+	msdosSuperMagic = 0x4d44
+	ntfsSuperMagic  = 0x5346544e
+)
+
+// DetectFSType returns the type of the local filesystem holding path ("vfat", "ntfs",
+// "ext4"), or "" if it is not one with known filename restrictions.
+// A path that does not exist yet is resolved against its nearest existing parent.
+// FUSE filesystems are identified from /proc/self/mountinfo (see fuseFSType).
+func DetectFSType(path string) string {
+	var stat syscall.Statfs_t
+
+	for {
+		err := syscall.Statfs(path, &stat)
+		if err == nil {
+			break
+		}
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			return ""
+		}
+
+		path = parent
+	}
+
+	switch int64(stat.Type) { //nolint:unconvert // Type is int32 on some architectures
+	case msdosSuperMagic, exfatSuperMagic:
+		return "vfat"
+	case ntfsSuperMagic:
+		return "ntfs"
+	case ext4SuperMagic:
+		return "ext4"
+	case fuseSuperMagic:
+		return detectFUSEType(path)
+	default:
+		return ""
+	}
+}
+
+// detectFUSEType looks up the FUSE filesystem holding path in /proc/self/mountinfo.
+func detectFUSEType(path string) string {
+	dev, err := deviceOf(path)
+	if err != nil {
+		return ""
+	}
+
+	mountinfo, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return ""
+	}
+
+	defer func() { _ = mountinfo.Close() }()
+
+	return fuseFSType(mountinfo, dev)
+}
+
+// fuseFSType returns the type of the FUSE filesystem mounted from device dev, as listed in
+// mountinfo (proc(5)). ntfs-3g, the usual way Linux mounts NTFS, lists itself as plain fuseblk,
+// which is taken to be NTFS; drivers that give a subtype, such as fuseblk.ntfs-3g or
+// fuseblk.exfat, are identified by it.
+func fuseFSType(mountinfo io.Reader, dev uint64) string {
+	want := deviceNumber(dev)
+
+	lines := bufio.NewScanner(mountinfo)
+	for lines.Scan() {
+		fields := strings.Fields(lines.Text())
+
+		// The filesystem type follows the "-" that ends the optional fields
+		sep := -1
+
+		for i := 6; i < len(fields); i++ {
+			if fields[i] == "-" {
+				sep = i
+				break
+			}
+		}
+
+		if len(fields) < 3 || fields[2] != want || sep < 0 || sep+1 >= len(fields) {
+			continue
+		}
+
+		fsType, subtype, _ := strings.Cut(fields[sep+1], ".")
+
+		switch {
+		case fsType != "fuse" && fsType != "fuseblk":
+			return ""
+		case strings.HasPrefix(subtype, "ntfs") || (fsType == "fuseblk" && subtype == ""):
+			return "ntfs"
+		case subtype == "exfat":
+			return "vfat"
+		default:
+			return ""
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,60 @@
+//nolint:testpackage // Tests the unexported mountinfo parsing
+package filesystem
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+)
+
+// TestFuseFSType_IdentifiesWindowsFilesystems verifies that an ntfs-3g mount, which lists
+// itself as plain fuseblk, is detected as NTFS, that subtypes name the filesystem, and that
+// other FUSE filesystems and other devices' mounts aren't taken for them.
+func TestFuseFSType_IdentifiesWindowsFilesystems(t *testing.T) {
+	t.Parallel()
+
+	const dev = 8<<8 | 17 // 8:17, /dev/sdb1
+
+	tests := []struct {
+		name      string
+		mountinfo string
+		want      string
+	}{
+		{
+			name:      "ntfs-3g",
+			mountinfo: "36 25 8:17 / /mnt/usb rw,relatime shared:1 - fuseblk /dev/sdb1 rw,user_id=0,group_id=0,allow_other,blksize=4096",
+			want:      "ntfs",
+		},
+		{
+			name:      "ntfs3 subtype",
+			mountinfo: "36 25 8:17 / /mnt/usb rw,relatime - fuseblk.ntfs3 /dev/sdb1 rw",
+			want:      "ntfs",
+		},
+		{
+			name:      "exfat-fuse",
+			mountinfo: "36 25 8:17 / /mnt/usb rw,relatime - fuseblk.exfat /dev/sdb1 rw",
+			want:      "vfat",
+		},
+		{
+			name:      "sshfs",
+			mountinfo: "36 25 8:17 / /mnt/remote rw,relatime - fuse.sshfs host:/ rw",
+			want:      "",
+		},
+		{
+			name:      "other device",
+			mountinfo: "36 25 8:18 / /mnt/usb rw,relatime - fuseblk /dev/sdb2 rw",
+			want:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			mountinfo := "22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw\n" + tt.mountinfo + "\n"
+			g.Expect(fuseFSType(strings.NewReader(mountinfo), dev)).To(Equal(tt.want))
+		})
+	}
+}
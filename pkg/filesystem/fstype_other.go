@@ -0,0 +1,8 @@
+//go:build !linux
+
+package filesystem
+
+// DetectFSType returns "" on platforms where filesystem type detection is not supported.
+func DetectFSType(_ string) string {
+	return ""
+}
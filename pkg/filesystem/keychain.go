@@ -0,0 +1,36 @@
+package filesystem
+
+import (
+	"errors"
+)
+
+// Exported constants.
+const (
+	// KeychainService is the service name glowsync's passwords are stored under in the system
+	// keychain, with the host as the account
+	KeychainService = "glowsync"
+)
+
+// Exported variables.
+var (
+	ErrKeychainNotSupported = errors.New("no system keychain available")
+)
+
+// Keychain is a store of remote passwords keyed by host, such as the macOS keychain or a
+// Secret Service provider like GNOME Keyring on Linux.
+type Keychain interface {
+	// Lookup returns the password stored for host; found is false if there is none.
+	Lookup(host string) (password string, found bool, err error)
+	// Store saves password for host, replacing any password already stored for it.
+	Store(host, password string) error
+}
+
+// KeychainConfirmer is a Keychain that is told when a password it returned let
+// CreateFileSystem connect, e.g. to save a password typed in this run only once it's known to
+// work.
+type KeychainConfirmer interface {
+	Keychain
+
+	// Confirm reports that the password Lookup returned for host was accepted.
+	Confirm(host string)
+}
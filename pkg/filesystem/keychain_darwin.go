@@ -0,0 +1,58 @@
+//go:build darwin
+
+package filesystem
+
+import (
+	"fmt"
+	"strings"
+)
+
+// securityItemNotFound is the exit code security uses when no keychain item matches.
+const securityItemNotFound = 44
+
+// SystemKeychain returns the macOS login keychain, accessed through the security tool.
+func SystemKeychain() (Keychain, error) {
+	err := findKeychainTool("security")
+	if err != nil {
+		return nil, err
+	}
+
+	return &macKeychain{run: runKeychainCommand}, nil
+}
+
+// macKeychain stores passwords as generic passwords in the login keychain.
+type macKeychain struct {
+	run keychainRunner
+}
+
+func (k *macKeychain) Lookup(host string) (string, bool, error) {
+	out, code, err := k.run("", "security", "find-generic-password", "-s", KeychainService, "-a", host, "-w")
+	if err != nil {
+		return "", false, err
+	}
+
+	switch code {
+	case 0:
+		return strings.TrimSuffix(out, "\n"), true, nil
+	case securityItemNotFound:
+		return "", false, nil
+	default:
+		return "", false, fmt.Errorf("security find-generic-password exited with status %d", code) //nolint:err113 // Exit status is only reported
+	}
+}
+
+// Store passes the password as an argument, since security only reads it from a terminal
+// otherwise, so it's briefly visible in process listings while it's stored.
+func (k *macKeychain) Store(host, password string) error {
+	_, code, err := k.run("", "security", "add-generic-password", "-U",
+		"-s", KeychainService, "-a", host, "-l", KeychainService+": "+host, "-w", password)
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		return fmt.Errorf("security add-generic-password exited with status %d", code) //nolint:err113 // Exit status is only reported
+	}
+
+	return nil
+}
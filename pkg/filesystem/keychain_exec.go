@@ -0,0 +1,43 @@
+//go:build darwin || linux
+
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// keychainRunner runs a keychain tool with stdin as its input, returning its output and exit
+// code. err is only set if the tool couldn't be run at all.
+type keychainRunner func(stdin, name string, args ...string) (stdout string, exitCode int, err error)
+
+// runKeychainCommand runs a keychain tool for real.
+func runKeychainCommand(stdin, name string, args ...string) (string, int, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+
+	out, err := cmd.Output()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return string(out), exitErr.ExitCode(), nil
+	}
+
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to run %s: %w", name, err)
+	}
+
+	return string(out), 0, nil
+}
+
+// findKeychainTool returns ErrKeychainNotSupported if tool isn't installed.
+func findKeychainTool(tool string) error {
+	_, err := exec.LookPath(tool)
+	if err != nil {
+		return fmt.Errorf("%w: %s not found", ErrKeychainNotSupported, tool)
+	}
+
+	return nil
+}
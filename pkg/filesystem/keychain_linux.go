@@ -0,0 +1,55 @@
+//go:build linux
+
+package filesystem
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SystemKeychain returns the desktop's Secret Service (GNOME Keyring, KWallet and others),
+// accessed through libsecret's secret-tool over D-Bus. Headless machines usually have no
+// Secret Service running, so Lookup finds nothing there and Store fails.
+func SystemKeychain() (Keychain, error) {
+	err := findKeychainTool("secret-tool")
+	if err != nil {
+		return nil, err
+	}
+
+	return &secretServiceKeychain{run: runKeychainCommand}, nil
+}
+
+// secretServiceKeychain stores passwords with service and host attributes.
+type secretServiceKeychain struct {
+	run keychainRunner
+}
+
+func (k *secretServiceKeychain) Lookup(host string) (string, bool, error) {
+	out, code, err := k.run("", "secret-tool", "lookup", "service", KeychainService, "host", host)
+	if err != nil {
+		return "", false, err
+	}
+
+	// secret-tool exits 1 both when nothing matches and when the Secret Service can't be
+	// reached, so either way there's no password to use
+	if code != 0 {
+		return "", false, nil
+	}
+
+	return strings.TrimSuffix(out, "\n"), true, nil
+}
+
+// Store reads the password from stdin, so it never appears in process listings.
+func (k *secretServiceKeychain) Store(host, password string) error {
+	_, code, err := k.run(password, "secret-tool", "store", "--label", KeychainService+": "+host,
+		"service", KeychainService, "host", host)
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		return fmt.Errorf("secret-tool store exited with status %d", code) //nolint:err113 // Exit status is only reported
+	}
+
+	return nil
+}
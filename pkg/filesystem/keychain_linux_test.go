@@ -0,0 +1,63 @@
+//nolint:testpackage // Tests the secret-tool calls through an unexported runner
+package filesystem
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSecretServiceKeychain tests the secret-tool calls: lookups by service and host, exit
+// status 1 as no password, and storing through stdin rather than arguments.
+func TestSecretServiceKeychain(t *testing.T) {
+	t.Parallel()
+
+	stored := map[string]string{"known.test": "s3cret"}
+
+	var storeArgs []string
+
+	keychain := &secretServiceKeychain{run: func(stdin, name string, args ...string) (string, int, error) {
+		if name != "secret-tool" {
+			t.Fatalf("ran %s, want secret-tool", name)
+		}
+
+		host := args[len(args)-1]
+
+		if args[0] == "store" {
+			storeArgs = args
+			stored[host] = stdin
+
+			return "", 0, nil
+		}
+
+		password, ok := stored[host]
+		if !ok {
+			return "", 1, nil
+		}
+
+		return password + "\n", 0, nil
+	}}
+
+	password, found, err := keychain.Lookup("known.test")
+	if err != nil || !found || password != "s3cret" {
+		t.Errorf("Lookup(known.test) = %q, %v, %v; want %q, true, nil", password, found, err, "s3cret")
+	}
+
+	_, found, err = keychain.Lookup("new.test")
+	if err != nil || found {
+		t.Errorf("Lookup(new.test) found = %v, err = %v; want false, nil", found, err)
+	}
+
+	err = keychain.Store("new.test", "p@ss")
+	if err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	if strings.Contains(strings.Join(storeArgs, " "), "p@ss") {
+		t.Errorf("Store passed the password as an argument: %v", storeArgs)
+	}
+
+	password, found, _ = keychain.Lookup("new.test")
+	if !found || password != "p@ss" {
+		t.Errorf("Lookup after Store = %q, %v; want %q, true", password, found, "p@ss")
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !darwin && !linux
+
+package filesystem
+
+// SystemKeychain is not supported on this platform.
+func SystemKeychain() (Keychain, error) {
+	return nil, ErrKeychainNotSupported
+}
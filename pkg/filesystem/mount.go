@@ -0,0 +1,8 @@
+package filesystem
+
+import "errors"
+
+// Exported variables.
+var (
+	ErrMountCheckNotSupported = errors.New("mount points can't be detected on this platform")
+)
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package filesystem
+
+// IsMountPoint is not supported on platforms without device numbers in stat(2).
+func IsMountPoint(_ string) (bool, error) {
+	return false, ErrMountCheckNotSupported
+}
@@ -0,0 +1,35 @@
+package filesystem_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+func TestIsMountPoint_RootIsMountedSubdirectoryIsNot(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	mounted, err := filesystem.IsMountPoint(string(filepath.Separator))
+	if errors.Is(err, filesystem.ErrMountCheckNotSupported) {
+		t.Skip("mount points can't be detected on this platform")
+	}
+
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(mounted).To(BeTrue())
+
+	dir := filepath.Join(t.TempDir(), "unmounted")
+	g.Expect(os.Mkdir(dir, 0o755)).To(Succeed())
+
+	mounted, err = filesystem.IsMountPoint(dir)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(mounted).To(BeFalse())
+
+	_, err = filesystem.IsMountPoint(filepath.Join(dir, "missing"))
+	g.Expect(err).Should(HaveOccurred())
+}
@@ -0,0 +1,28 @@
+//go:build unix
+
+package filesystem
+
+import (
+	"fmt"
+	"path/filepath"
+	"syscall"
+)
+
+// IsMountPoint reports whether path is the root of a mounted filesystem: its device differs
+// from its parent's, or it is the root directory. A network share that failed to mount leaves
+// an ordinary directory on its parent's device, which this reports as false.
+func IsMountPoint(path string) (bool, error) {
+	var stat, parentStat syscall.Stat_t
+
+	err := syscall.Stat(path, &stat)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	err = syscall.Stat(filepath.Join(path, ".."), &parentStat)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat parent of %s: %w", path, err)
+	}
+
+	return stat.Dev != parentStat.Dev || stat.Ino == parentStat.Ino, nil
+}
@@ -0,0 +1,792 @@
+package filesystem
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Exported constants.
+const (
+	// ObjectStoreMtimeHeader holds an object's modification time, as Unix seconds with a
+	// fractional part (the convention rclone uses), since object stores only record upload times.
+	ObjectStoreMtimeHeader = "X-Amz-Meta-Mtime"
+
+	// DefaultObjectPartSize is the size of each part of a multipart upload, doubled every
+	// objectPartsPerDoubling parts so objects far larger than 10,000 parts still fit.
+	DefaultObjectPartSize = 16 << 20
+)
+
+// Exported variables.
+var (
+	ErrObjectStoreCredentials = errors.New("object store credentials not set")
+	ErrObjectStoreURL         = errors.New("invalid object store URL")
+)
+
+// objectStoreRequestError is a request the object store answered with an error status.
+type objectStoreRequestError struct {
+	Status  int
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func (e *objectStoreRequestError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("object store returned %d", e.Status)
+	}
+
+	return fmt.Sprintf("object store returned %d %s: %s", e.Status, e.Code, e.Message)
+}
+
+// unexported constants.
+const (
+	// objectPartsPerDoubling is how many parts are uploaded at each part size before it doubles.
+	objectPartsPerDoubling = 1000
+
+	// maxObjectCopySize is the largest object a single copy request may copy; larger objects are
+	// copied in parts of this size.
+	maxObjectCopySize = 5 << 30
+
+	// objectDirMode and objectFileMode are the permissions reported for prefixes and objects,
+	// which have none of their own.
+	objectDirMode  = os.ModeDir | 0o755
+	objectFileMode = 0o644
+)
+
+// ObjectStoreConfig is where an object store filesystem keeps its objects and how it signs in.
+type ObjectStoreConfig struct {
+	Endpoint        string // Base URL of the S3-compatible API, e.g. https://s3.us-east-1.amazonaws.com
+	Bucket          string
+	Region          string // Signing region ("auto" for Google Cloud Storage)
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // Optional, for temporary AWS credentials
+
+	// PartSize is the size of the first parts of a multipart upload (0 = DefaultObjectPartSize).
+	// Objects smaller than one part are uploaded in a single request.
+	PartSize int64
+
+	// Pool bounds the requests in flight at once (nil = DefaultPoolConfig()).
+	Pool *PoolConfig
+
+	// Client sends the requests (nil = a client whose transport keeps Pool.MaxSize connections).
+	Client *http.Client
+}
+
+// ObjectStoreURL is a parsed s3://bucket/prefix or gs://bucket/prefix URL.
+type ObjectStoreURL struct {
+	Scheme string // "s3" or "gs"
+	Bucket string
+	Prefix string // Key prefix the tree lives under, without slashes at either end ("." = whole bucket)
+}
+
+// IsObjectStoreURL reports whether path is an s3:// or gs:// URL.
+func IsObjectStoreURL(path string) bool {
+	return strings.HasPrefix(path, "s3://") || strings.HasPrefix(path, "gs://")
+}
+
+// ParseObjectStoreURL parses an s3://bucket/prefix or gs://bucket/prefix URL.
+func ParseObjectStoreURL(rawURL string) (*ObjectStoreURL, error) {
+	u, err := url.Parse(rawURL) //nolint:varnamelen // u is idiomatic for URL
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrObjectStoreURL, err)
+	}
+
+	if u.Scheme != "s3" && u.Scheme != "gs" {
+		return nil, fmt.Errorf("%w: expected s3:// or gs://, got %s://", ErrObjectStoreURL, u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("%w: must include a bucket (%s://bucket/prefix)", ErrObjectStoreURL, u.Scheme)
+	}
+
+	prefix := strings.Trim(u.Path, "/")
+	if prefix == "" {
+		prefix = "."
+	}
+
+	return &ObjectStoreURL{Scheme: u.Scheme, Bucket: u.Host, Prefix: prefix}, nil
+}
+
+// ObjectStoreConfigFromEnv builds the configuration for an object store URL from the
+// environment. s3:// uses AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN,
+// AWS_REGION (default us-east-1) and AWS_ENDPOINT_URL for S3-compatible stores. gs:// uses
+// Google Cloud Storage's XML API with the HMAC key in GLOWSYNC_GS_ACCESS_KEY_ID and
+// GLOWSYNC_GS_SECRET_ACCESS_KEY.
+func ObjectStoreConfigFromEnv(parsed *ObjectStoreURL) (*ObjectStoreConfig, error) {
+	cfg := &ObjectStoreConfig{Bucket: parsed.Bucket}
+
+	if parsed.Scheme == "gs" {
+		cfg.Endpoint = "https://storage.googleapis.com"
+		cfg.Region = "auto"
+		cfg.AccessKeyID = os.Getenv(CredentialsEnvPrefix + "GS_ACCESS_KEY_ID")
+		cfg.SecretAccessKey = os.Getenv(CredentialsEnvPrefix + "GS_SECRET_ACCESS_KEY")
+	} else {
+		cfg.Region = firstNonEmpty(os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"), "us-east-1")
+		cfg.Endpoint = firstNonEmpty(os.Getenv("AWS_ENDPOINT_URL"), "https://s3."+cfg.Region+".amazonaws.com")
+		cfg.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		cfg.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		cfg.SessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	}
+
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("%w for %s://%s", ErrObjectStoreCredentials, parsed.Scheme, parsed.Bucket)
+	}
+
+	return cfg, nil
+}
+
+// ObjectStoreFileSystem implements FileSystem over an S3-compatible object store (Amazon S3,
+// Google Cloud Storage's XML API, MinIO...). Each file is an object keyed by its slash-separated
+// path, and directories are the prefixes shared by those keys, so they can't exist empty:
+// MkdirAll does nothing and removing a directory only succeeds once its files are gone.
+//
+// Object stores only record when an object was uploaded, so a file's modification time is kept
+// in ObjectStoreMtimeHeader metadata, sent with the upload (see ModTimeSetter) or set by Chtimes.
+// Objects without it report their upload time.
+type ObjectStoreFileSystem struct {
+	cfg    ObjectStoreConfig
+	client *http.Client
+	signer *sigV4Signer
+	pool   *requestPool
+}
+
+// NewObjectStoreFileSystem creates a filesystem over the bucket cfg describes.
+func NewObjectStoreFileSystem(cfg ObjectStoreConfig) (*ObjectStoreFileSystem, error) {
+	if cfg.Pool == nil {
+		cfg.Pool = DefaultPoolConfig()
+	}
+
+	if cfg.PartSize <= 0 {
+		cfg.PartSize = DefaultObjectPartSize
+	}
+
+	if cfg.Pool.MinSize <= 0 || cfg.Pool.InitialSize < cfg.Pool.MinSize || cfg.Pool.InitialSize > cfg.Pool.MaxSize {
+		return nil, fmt.Errorf("invalid pool sizes: initial %d, min %d, max %d", //nolint:err113 // Validation error with actual values
+			cfg.Pool.InitialSize, cfg.Pool.MinSize, cfg.Pool.MaxSize)
+	}
+
+	_, err := url.Parse(cfg.Endpoint)
+	if err != nil || cfg.Bucket == "" {
+		return nil, fmt.Errorf("%w: endpoint %q, bucket %q", ErrObjectStoreURL, cfg.Endpoint, cfg.Bucket)
+	}
+
+	client := cfg.Client
+	if client == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert // Always *http.Transport
+		transport.MaxIdleConnsPerHost = cfg.Pool.MaxSize
+		client = &http.Client{Transport: transport}
+	}
+
+	return &ObjectStoreFileSystem{
+		cfg:    cfg,
+		client: client,
+		signer: &sigV4Signer{
+			accessKeyID:     cfg.AccessKeyID,
+			secretAccessKey: cfg.SecretAccessKey,
+			sessionToken:    cfg.SessionToken,
+			region:          cfg.Region,
+		},
+		pool: newRequestPool(cfg.Pool.InitialSize, cfg.Pool.MinSize, cfg.Pool.MaxSize),
+	}, nil
+}
+
+// Chtimes records mtime in an existing object's metadata by copying the object onto itself,
+// unless it already has that mtime, e.g. from its upload. Prefixes have no metadata to change,
+// so for a directory it does nothing.
+func (fs *ObjectStoreFileSystem) Chtimes(path string, _, mtime time.Time) error {
+	key := objectKey(path)
+
+	head, err := fs.head(key)
+	if errors.Is(err, os.ErrNotExist) {
+		isDir, dirErr := fs.isPrefix(key)
+		if dirErr == nil && isDir {
+			return nil
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to change times for %s: %w", path, err)
+	}
+
+	if head.modTime.Equal(mtime) {
+		return nil
+	}
+
+	err = fs.copyObject(key, key, head.size, formatObjectMtime(mtime))
+	if err != nil {
+		return fmt.Errorf("failed to change times for %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Close closes the idle connections to the object store.
+func (fs *ObjectStoreFileSystem) Close() error {
+	fs.client.CloseIdleConnections()
+
+	return nil
+}
+
+// Create starts writing an object, which appears once the file is closed. Contents are buffered
+// in memory a part at a time, and files larger than a part are sent as a multipart upload.
+func (fs *ObjectStoreFileSystem) Create(path string) (File, error) {
+	return &objectWriter{fs: fs, key: objectKey(path)}, nil
+}
+
+// MkdirAll does nothing: prefixes exist while objects under them do.
+func (fs *ObjectStoreFileSystem) MkdirAll(string, os.FileMode) error {
+	return nil
+}
+
+// Open starts reading an object.
+func (fs *ObjectStoreFileSystem) Open(path string) (File, error) {
+	key := objectKey(path)
+
+	fs.pool.acquire()
+
+	resp, err := fs.do(http.MethodGet, key, nil, nil, nil)
+	if err != nil {
+		fs.pool.release()
+
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	info := objectInfoFromHeader(key, resp.Header)
+
+	return &objectReader{body: resp.Body, info: info, release: fs.pool.release}, nil
+}
+
+// PoolMaxSize returns the most requests allowed in flight at once.
+func (fs *ObjectStoreFileSystem) PoolMaxSize() int {
+	return fs.pool.maxSize
+}
+
+// PoolMinSize returns the fewest requests allowed in flight at once.
+func (fs *ObjectStoreFileSystem) PoolMinSize() int {
+	return fs.pool.minSize
+}
+
+// PoolSize returns the number of requests in flight, each on its own connection.
+func (fs *ObjectStoreFileSystem) PoolSize() int {
+	return fs.pool.inUse()
+}
+
+// PoolTargetSize returns how many requests are currently allowed in flight at once.
+func (fs *ObjectStoreFileSystem) PoolTargetSize() int {
+	return fs.pool.targetSize()
+}
+
+// Remove deletes an object. A prefix (directory) has nothing to delete, but is only removed
+// once it's empty, so removing one that still has objects under it fails.
+func (fs *ObjectStoreFileSystem) Remove(path string) error {
+	key := objectKey(path)
+
+	_, err := fs.head(key)
+	if errors.Is(err, os.ErrNotExist) {
+		isDir, dirErr := fs.isPrefix(key)
+		if dirErr != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, dirErr)
+		}
+
+		if isDir {
+			return fmt.Errorf("failed to remove %s: directory not empty", path) //nolint:err113 // Matches os.Remove on a non-empty directory
+		}
+
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+
+	err = fs.send(http.MethodDelete, key, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// ResizePool sets how many requests may be in flight at once, clamped to the pool's bounds, so
+// adaptive scaling sizes the connections to the bucket along with its workers.
+func (fs *ObjectStoreFileSystem) ResizePool(targetSize int) {
+	fs.pool.resize(targetSize)
+}
+
+// Scan returns an iterator over the objects under path, and the prefixes (directories) between
+// them. Listing doesn't return metadata, so each object's modification time costs a request.
+func (fs *ObjectStoreFileSystem) Scan(path string) FileScanner {
+	return &objectScanner{fs: fs, prefix: objectDirPrefix(objectKey(path)), seenDirs: make(map[string]bool)}
+}
+
+// Stat returns an object's size and modification time, or a directory for a prefix with
+// objects under it. The bucket root always exists.
+func (fs *ObjectStoreFileSystem) Stat(path string) (os.FileInfo, error) {
+	key := objectKey(path)
+	if key == "" {
+		return &objectInfo{name: ".", mode: objectDirMode}, nil
+	}
+
+	info, err := fs.head(key)
+	if errors.Is(err, os.ErrNotExist) {
+		isDir, dirErr := fs.isPrefix(key)
+		if dirErr == nil && isDir {
+			return &objectInfo{name: objectBase(key), mode: objectDirMode}, nil
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	return info, nil
+}
+
+// head fetches an object's size and modification time.
+func (fs *ObjectStoreFileSystem) head(key string) (*objectInfo, error) {
+	fs.pool.acquire()
+	defer fs.pool.release()
+
+	resp, err := fs.do(http.MethodHead, key, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = resp.Body.Close()
+
+	return objectInfoFromHeader(key, resp.Header), nil
+}
+
+// isPrefix reports whether any object has a key under key + "/".
+func (fs *ObjectStoreFileSystem) isPrefix(key string) (bool, error) {
+	result, err := fs.list(objectDirPrefix(key), "", 1)
+	if err != nil {
+		return false, err
+	}
+
+	return len(result.Contents) > 0, nil
+}
+
+// objectListing is one page of a ListObjectsV2 response.
+type objectListing struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// list fetches a page of the keys under prefix, after the page token names (empty = first page).
+func (fs *ObjectStoreFileSystem) list(prefix, token string, maxKeys int) (*objectListing, error) {
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	if token != "" {
+		query.Set("continuation-token", token)
+	}
+
+	if maxKeys > 0 {
+		query.Set("max-keys", strconv.Itoa(maxKeys))
+	}
+
+	var listing objectListing
+
+	err := fs.sendXML(http.MethodGet, "", query, nil, nil, &listing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", prefix, err)
+	}
+
+	return &listing, nil
+}
+
+// copyObject copies src to dst, replacing its metadata with mtime. Objects too large for a
+// single copy request are copied in parts.
+func (fs *ObjectStoreFileSystem) copyObject(src, dst string, size int64, mtime string) error {
+	header := http.Header{
+		"X-Amz-Copy-Source":    {"/" + fs.cfg.Bucket + "/" + uriEncode(src, false)},
+		ObjectStoreMtimeHeader: {mtime},
+	}
+
+	if size <= maxObjectCopySize {
+		header.Set("X-Amz-Metadata-Directive", "REPLACE")
+
+		// A copy can fail after its 200 status is sent, with the error in the body
+		var result struct {
+			XMLName xml.Name
+			objectStoreRequestError
+		}
+
+		err := fs.sendXML(http.MethodPut, dst, nil, header, nil, &result)
+		if err != nil {
+			return err
+		}
+
+		if result.XMLName.Local == "Error" {
+			return &objectStoreRequestError{Status: http.StatusOK, Code: result.Code, Message: result.Message}
+		}
+
+		return nil
+	}
+
+	upload, err := fs.startUpload(dst, http.Header{ObjectStoreMtimeHeader: {mtime}})
+	if err != nil {
+		return err
+	}
+
+	for start := int64(0); start < size; start += maxObjectCopySize {
+		end := min(start+maxObjectCopySize, size) - 1
+		partHeader := http.Header{
+			"X-Amz-Copy-Source":       header["X-Amz-Copy-Source"],
+			"X-Amz-Copy-Source-Range": {fmt.Sprintf("bytes=%d-%d", start, end)},
+		}
+
+		var result struct {
+			ETag string `xml:"ETag"`
+		}
+
+		err = fs.sendXML(http.MethodPut, dst, upload.partQuery(len(upload.parts)+1), partHeader, nil, &result)
+		if err != nil {
+			upload.abort()
+
+			return err
+		}
+
+		upload.parts = append(upload.parts, result.ETag)
+	}
+
+	return upload.complete()
+}
+
+// multipartUpload is an upload in progress, with the ETag of each part sent so far.
+type multipartUpload struct {
+	fs    *ObjectStoreFileSystem
+	key   string
+	id    string
+	parts []string
+}
+
+// startUpload begins a multipart upload of key with the given metadata headers.
+func (fs *ObjectStoreFileSystem) startUpload(key string, header http.Header) (*multipartUpload, error) {
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+
+	err := fs.sendXML(http.MethodPost, key, url.Values{"uploads": {""}}, header, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start upload: %w", err)
+	}
+
+	return &multipartUpload{fs: fs, key: key, id: result.UploadID}, nil
+}
+
+// partQuery addresses part number partNumber of the upload.
+func (u *multipartUpload) partQuery(partNumber int) url.Values {
+	return url.Values{"partNumber": {strconv.Itoa(partNumber)}, "uploadId": {u.id}}
+}
+
+// uploadPart sends the next part of the upload.
+func (u *multipartUpload) uploadPart(data []byte) error {
+	resp, err := u.fs.request(http.MethodPut, u.key, u.partQuery(len(u.parts)+1), nil, data)
+	if err != nil {
+		return fmt.Errorf("failed to upload part %d: %w", len(u.parts)+1, err)
+	}
+
+	_ = resp.Body.Close()
+
+	u.parts = append(u.parts, resp.Header.Get("ETag"))
+
+	return nil
+}
+
+// complete assembles the uploaded parts into the object.
+func (u *multipartUpload) complete() error {
+	type part struct {
+		PartNumber int    `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	}
+
+	body := struct {
+		XMLName xml.Name `xml:"CompleteMultipartUpload"`
+		Parts   []part   `xml:"Part"`
+	}{}
+
+	for i, etag := range u.parts {
+		body.Parts = append(body.Parts, part{PartNumber: i + 1, ETag: etag})
+	}
+
+	data, err := xml.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode upload parts: %w", err)
+	}
+
+	var result struct {
+		XMLName xml.Name
+		objectStoreRequestError
+	}
+
+	err = u.fs.sendXML(http.MethodPost, u.key, url.Values{"uploadId": {u.id}}, nil, data, &result)
+	if err == nil && result.XMLName.Local == "Error" {
+		err = &objectStoreRequestError{Status: http.StatusOK, Code: result.Code, Message: result.Message}
+	}
+
+	if err != nil {
+		u.abort()
+
+		return fmt.Errorf("failed to complete upload: %w", err)
+	}
+
+	return nil
+}
+
+// abort discards the upload's parts; best effort, since stores also expire abandoned uploads.
+func (u *multipartUpload) abort() {
+	_ = u.fs.send(http.MethodDelete, u.key, url.Values{"uploadId": {u.id}}, nil, nil)
+}
+
+// send makes a request, waiting for room in the pool, and discards the response.
+func (fs *ObjectStoreFileSystem) send(method, key string, query url.Values, header http.Header, body []byte) error {
+	resp, err := fs.request(method, key, query, header, body)
+	if err != nil {
+		return err
+	}
+
+	_ = resp.Body.Close()
+
+	return nil
+}
+
+// sendXML makes a request, waiting for room in the pool, and decodes its XML response into v.
+//
+//nolint:lll // Request parameters plus the result
+func (fs *ObjectStoreFileSystem) sendXML(method, key string, query url.Values, header http.Header, body []byte, v any) error {
+	resp, err := fs.request(method, key, query, header, body)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	err = xml.NewDecoder(resp.Body).Decode(v)
+	if err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// request makes a request, waiting for room in the pool. The caller closes the response body.
+//
+//nolint:lll // Request parameters
+func (fs *ObjectStoreFileSystem) request(method, key string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	fs.pool.acquire()
+	defer fs.pool.release()
+
+	return fs.do(method, key, query, header, body)
+}
+
+// do signs and sends a request for key (empty = the bucket), turning error statuses into
+// errors: 404 wraps os.ErrNotExist. The caller holds a pool slot and closes the response body.
+//
+//nolint:lll // Request parameters
+func (fs *ObjectStoreFileSystem) do(method, key string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	target := strings.TrimSuffix(fs.cfg.Endpoint, "/") + "/" + uriEncode(fs.cfg.Bucket, false)
+	if key != "" {
+		target += "/" + uriEncode(key, false)
+	}
+
+	if len(query) > 0 {
+		target += "?" + canonicalQuery(query)
+	}
+
+	req, err := http.NewRequest(method, target, bytes.NewReader(body)) //nolint:noctx // Cancellation stops between requests
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	req.ContentLength = int64(len(body))
+	fs.signer.sign(req, body, time.Now())
+
+	resp, err := fs.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusMultipleChoices {
+		return resp, nil
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	reqErr := &objectStoreRequestError{Status: resp.StatusCode}
+	_ = xml.NewDecoder(resp.Body).Decode(reqErr) // HEAD errors have no body
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %w", os.ErrNotExist, reqErr)
+	}
+
+	return nil, reqErr
+}
+
+// objectKey turns a filesystem path into an object key: slash-separated, with no leading
+// slash, and empty for the bucket root.
+func objectKey(path string) string {
+	key := strings.Trim(filepath.ToSlash(filepath.Clean(path)), "/")
+	if key == "." {
+		return ""
+	}
+
+	return key
+}
+
+// objectDirPrefix is the prefix shared by keys under the directory key.
+func objectDirPrefix(key string) string {
+	if key == "" {
+		return ""
+	}
+
+	return key + "/"
+}
+
+// objectBase is the last element of a key.
+func objectBase(key string) string {
+	return path.Base(key)
+}
+
+// formatObjectMtime formats a modification time for ObjectStoreMtimeHeader.
+func formatObjectMtime(mtime time.Time) string {
+	return fmt.Sprintf("%d.%09d", mtime.Unix(), mtime.Nanosecond())
+}
+
+// parseObjectMtime parses an ObjectStoreMtimeHeader value.
+func parseObjectMtime(value string) (time.Time, bool) {
+	secStr, fracStr, _ := strings.Cut(value, ".")
+
+	sec, err := strconv.ParseInt(secStr, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var nsec int64
+
+	if fracStr != "" {
+		fracStr = (fracStr + "000000000")[:9]
+
+		nsec, err = strconv.ParseInt(fracStr, 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+	}
+
+	return time.Unix(sec, nsec), true
+}
+
+// objectInfoFromHeader builds an object's info from a GET or HEAD response.
+func objectInfoFromHeader(key string, header http.Header) *objectInfo {
+	size, _ := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+
+	modTime, ok := parseObjectMtime(header.Get(ObjectStoreMtimeHeader))
+	if !ok {
+		modTime, _ = http.ParseTime(header.Get("Last-Modified"))
+	}
+
+	return &objectInfo{name: objectBase(key), size: size, modTime: modTime, mode: objectFileMode}
+}
+
+// objectInfo implements os.FileInfo for an object or prefix.
+type objectInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	mode    os.FileMode
+}
+
+func (i *objectInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i *objectInfo) ModTime() time.Time { return i.modTime }
+func (i *objectInfo) Mode() os.FileMode  { return i.mode }
+func (i *objectInfo) Name() string       { return i.name }
+func (i *objectInfo) Size() int64        { return i.size }
+func (i *objectInfo) Sys() any           { return nil }
+
+// firstNonEmpty returns the first of values that isn't empty.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// requestPool bounds the requests in flight at once, like the SFTP client pool bounds
+// connections: the transport opens a connection for each request up to the target.
+type requestPool struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	active  int
+	target  int
+	minSize int
+	maxSize int
+}
+
+func newRequestPool(initial, minSize, maxSize int) *requestPool {
+	pool := &requestPool{target: initial, minSize: minSize, maxSize: maxSize}
+	pool.cond = sync.NewCond(&pool.mu)
+
+	return pool
+}
+
+// acquire waits until fewer than the target number of requests are in flight.
+func (p *requestPool) acquire() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for p.active >= p.target {
+		p.cond.Wait()
+	}
+
+	p.active++
+}
+
+// release ends a request started with acquire.
+func (p *requestPool) release() {
+	p.mu.Lock()
+	p.active--
+	p.mu.Unlock()
+
+	p.cond.Signal()
+}
+
+// resize sets the target, clamped to [minSize, maxSize]. Requests above a lowered target
+// finish; new ones wait until enough have.
+func (p *requestPool) resize(targetSize int) {
+	p.mu.Lock()
+	p.target = max(min(targetSize, p.maxSize), p.minSize)
+	p.mu.Unlock()
+
+	p.cond.Broadcast()
+}
+
+func (p *requestPool) inUse() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.active
+}
+
+func (p *requestPool) targetSize() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.target
+}
@@ -0,0 +1,310 @@
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errObjectReadOnly and errObjectWriteOnly are returned for the wrong direction on an object,
+// which is either being downloaded or uploaded, and errObjectAborted for an abandoned upload.
+var (
+	errObjectReadOnly  = errors.New("object opened for reading")
+	errObjectWriteOnly = errors.New("object opened for writing")
+	errObjectAborted   = errors.New("object upload aborted")
+)
+
+// objectReader streams an object's contents from a GET request, holding its pool slot until
+// it is closed.
+type objectReader struct {
+	body      io.ReadCloser
+	info      *objectInfo
+	release   func()
+	closeOnce sync.Once
+}
+
+func (r *objectReader) Close() error {
+	var err error
+
+	r.closeOnce.Do(func() {
+		err = r.body.Close()
+		r.release()
+	})
+
+	return err //nolint:wrapcheck // Closing a response body
+}
+
+func (r *objectReader) Read(p []byte) (int, error) {
+	return r.body.Read(p) //nolint:wrapcheck // Reading a response body
+}
+
+func (r *objectReader) Stat() (os.FileInfo, error) {
+	return r.info, nil
+}
+
+func (r *objectReader) Write([]byte) (int, error) {
+	return 0, errObjectReadOnly
+}
+
+// objectWriter buffers an object's contents and uploads them: in one request if they fit in a
+// part, and otherwise as a multipart upload, sending each part as it fills.
+type objectWriter struct {
+	fs      *ObjectStoreFileSystem
+	key     string
+	header  http.Header // Metadata sent with the upload
+	buf     []byte
+	upload  *multipartUpload
+	written int64
+	err     error // First failure; the upload is abandoned and every later call returns it
+	closed  bool
+}
+
+// Abort discards what was written instead of uploading it, so a failed copy never appears.
+func (w *objectWriter) Abort() {
+	if w.closed {
+		return
+	}
+
+	w.closed = true
+	w.buf = nil
+
+	if w.err != nil {
+		return // The upload was already abandoned
+	}
+
+	if w.upload != nil {
+		w.upload.abort()
+	}
+
+	w.err = fmt.Errorf("failed to upload %s: %w", w.key, errObjectAborted)
+}
+
+// Close uploads what's left and completes the object.
+func (w *objectWriter) Close() error {
+	if w.closed {
+		return w.err
+	}
+
+	w.closed = true
+
+	if w.err != nil {
+		return w.err
+	}
+
+	if w.upload == nil {
+		err := w.fs.send(http.MethodPut, w.key, nil, w.header, w.buf)
+		if err != nil {
+			w.err = fmt.Errorf("failed to upload %s: %w", w.key, err)
+		}
+
+		return w.err
+	}
+
+	if len(w.buf) > 0 {
+		err := w.upload.uploadPart(w.buf)
+		if err != nil {
+			w.upload.abort()
+			w.err = fmt.Errorf("failed to upload %s: %w", w.key, err)
+
+			return w.err
+		}
+	}
+
+	err := w.upload.complete()
+	if err != nil {
+		w.err = fmt.Errorf("failed to upload %s: %w", w.key, err)
+	}
+
+	return w.err
+}
+
+func (w *objectWriter) Read([]byte) (int, error) {
+	return 0, errObjectWriteOnly
+}
+
+// SetModTime sends mtime as ObjectStoreMtimeHeader metadata with the upload.
+func (w *objectWriter) SetModTime(mtime time.Time) {
+	w.header = http.Header{ObjectStoreMtimeHeader: {formatObjectMtime(mtime)}}
+}
+
+func (w *objectWriter) Stat() (os.FileInfo, error) {
+	return &objectInfo{name: objectBase(w.key), size: w.written, modTime: time.Now(), mode: objectFileMode}, nil
+}
+
+// Write buffers p, sending a part of a multipart upload each time a part's worth has built up.
+func (w *objectWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+
+	w.buf = append(w.buf, p...)
+	w.written += int64(len(p))
+
+	for partSize := w.partSize(); int64(len(w.buf)) >= partSize; partSize = w.partSize() {
+		err := w.sendPart(w.buf[:partSize])
+		if err != nil {
+			w.err = fmt.Errorf("failed to upload %s: %w", w.key, err)
+
+			return 0, w.err
+		}
+
+		w.buf = append(w.buf[:0], w.buf[partSize:]...)
+	}
+
+	return len(p), nil
+}
+
+// partSize is the size of the next part: PartSize, doubled after every objectPartsPerDoubling
+// parts.
+func (w *objectWriter) partSize() int64 {
+	parts := 0
+	if w.upload != nil {
+		parts = len(w.upload.parts)
+	}
+
+	return w.fs.cfg.PartSize << (parts / objectPartsPerDoubling)
+}
+
+// sendPart sends data as the next part, starting the multipart upload with the first one.
+func (w *objectWriter) sendPart(data []byte) error {
+	if w.upload == nil {
+		upload, err := w.fs.startUpload(w.key, w.header)
+		if err != nil {
+			return err
+		}
+
+		w.upload = upload
+	}
+
+	err := w.upload.uploadPart(data)
+	if err != nil {
+		w.upload.abort()
+
+		return err
+	}
+
+	return nil
+}
+
+// objectScanner lists the objects under a prefix a page at a time, yielding each object and,
+// before it, any directories (shared prefixes) it is the first to reveal.
+type objectScanner struct {
+	fs       *ObjectStoreFileSystem
+	prefix   string
+	listing  *objectListing
+	pos      int
+	pending  []FileInfo
+	seenDirs map[string]bool
+	err      error
+}
+
+// Err returns any error that occurred during scanning.
+func (s *objectScanner) Err() error {
+	return s.err
+}
+
+// Next returns the next file or directory.
+func (s *objectScanner) Next() (FileInfo, bool) {
+	for {
+		if len(s.pending) > 0 {
+			info := s.pending[0]
+			s.pending = s.pending[1:]
+
+			return info, true
+		}
+
+		if s.err != nil {
+			return FileInfo{}, false
+		}
+
+		if s.listing != nil && s.pos < len(s.listing.Contents) {
+			s.queue(s.pos)
+			s.pos++
+
+			continue
+		}
+
+		if s.listing != nil && !s.listing.IsTruncated {
+			return FileInfo{}, false
+		}
+
+		token := ""
+		if s.listing != nil {
+			token = s.listing.NextContinuationToken
+		}
+
+		listing, err := s.fs.list(s.prefix, token, 0)
+		if err != nil {
+			s.err = fmt.Errorf("error scanning object store: %w", err)
+
+			continue
+		}
+
+		s.listing = listing
+		s.pos = 0
+	}
+}
+
+// queue adds the listing's object at index, and the directories above it not yet yielded, to
+// pending. Keys ending in a slash are directory markers some tools create.
+func (s *objectScanner) queue(index int) {
+	object := s.listing.Contents[index]
+	relPath := strings.TrimPrefix(object.Key, s.prefix)
+
+	if relPath == "" {
+		return
+	}
+
+	if strings.HasSuffix(relPath, "/") {
+		s.queueDirs(strings.TrimSuffix(relPath, "/"))
+
+		return
+	}
+
+	s.queueDirs(path.Dir(relPath))
+
+	modTime := object.LastModified
+
+	head, err := s.fs.head(object.Key)
+	if errors.Is(err, os.ErrNotExist) {
+		return // Deleted since it was listed
+	}
+
+	if err != nil {
+		s.err = fmt.Errorf("error scanning object store: %w", err)
+
+		return
+	}
+
+	if !head.modTime.IsZero() {
+		modTime = head.modTime
+	}
+
+	s.pending = append(s.pending, FileInfo{
+		RelativePath: relPath,
+		Size:         object.Size,
+		ModTime:      modTime,
+		Mode:         objectFileMode,
+	})
+}
+
+// queueDirs adds dir and each directory above it that hasn't been yielded yet, outermost first.
+func (s *objectScanner) queueDirs(dir string) {
+	if dir == "." || dir == "" || s.seenDirs[dir] {
+		return
+	}
+
+	s.queueDirs(path.Dir(dir))
+	s.seenDirs[dir] = true
+	s.pending = append(s.pending, FileInfo{RelativePath: dir, Mode: objectDirMode.Perm(), IsDir: true})
+}
@@ -0,0 +1,471 @@
+package filesystem_test
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+func TestParseObjectStoreURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url     string
+		want    filesystem.ObjectStoreURL
+		wantErr bool
+	}{
+		{url: "s3://bucket/backups/photos", want: filesystem.ObjectStoreURL{Scheme: "s3", Bucket: "bucket", Prefix: "backups/photos"}},
+		{url: "gs://bucket/backups/", want: filesystem.ObjectStoreURL{Scheme: "gs", Bucket: "bucket", Prefix: "backups"}},
+		{url: "s3://bucket", want: filesystem.ObjectStoreURL{Scheme: "s3", Bucket: "bucket", Prefix: "."}},
+		{url: "s3:///prefix", wantErr: true},
+		{url: "ftp://bucket/prefix", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			parsed, err := filesystem.ParseObjectStoreURL(tt.url)
+			if tt.wantErr {
+				g.Expect(err).To(MatchError(filesystem.ErrObjectStoreURL))
+
+				return
+			}
+
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(*parsed).To(Equal(tt.want))
+		})
+	}
+}
+
+// TestObjectStoreFileSystem_RoundTrip verifies writing, retiming, reading, scanning and removing
+// objects, with prefixes standing in for directories.
+func TestObjectStoreFileSystem_RoundTrip(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	store := newFakeObjectStore(t, 1000)
+	objectFS := store.fileSystem(t, 0)
+	modTime := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)
+
+	g.Expect(objectFS.MkdirAll("backups/dir", 0o755)).To(Succeed())
+
+	file, err := objectFS.Create("backups/dir/a.txt")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(io.WriteString(file, "hello, ")).To(Equal(7))
+	g.Expect(io.WriteString(file, "bucket")).To(Equal(6))
+	g.Expect(file.Close()).To(Succeed())
+	g.Expect(objectFS.Chtimes("backups/dir/a.txt", modTime, modTime)).To(Succeed())
+
+	info, err := objectFS.Stat("backups/dir/a.txt")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(info.Size()).To(Equal(int64(13)))
+	g.Expect(info.ModTime().Equal(modTime)).To(BeTrue())
+
+	reader, err := objectFS.Open("backups/dir/a.txt")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(io.ReadAll(reader)).To(Equal([]byte("hello, bucket")))
+	g.Expect(reader.Close()).To(Succeed())
+
+	info, err = objectFS.Stat("backups/dir")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(info.IsDir()).To(BeTrue())
+
+	scanned := scanAll(t, objectFS.Scan("backups"))
+	g.Expect(scanned).To(HaveLen(2))
+	g.Expect(scanned[0].RelativePath).To(Equal("dir"))
+	g.Expect(scanned[0].IsDir).To(BeTrue())
+	g.Expect(scanned[1].RelativePath).To(Equal("dir/a.txt"))
+	g.Expect(scanned[1].Size).To(Equal(int64(13)))
+	g.Expect(scanned[1].ModTime.Equal(modTime)).To(BeTrue())
+
+	g.Expect(objectFS.Remove("backups/dir")).NotTo(Succeed())
+	g.Expect(objectFS.Remove("backups/dir/a.txt")).To(Succeed())
+	g.Expect(objectFS.Remove("backups/dir")).To(MatchError(os.ErrNotExist))
+
+	_, err = objectFS.Stat("backups/dir/a.txt")
+	g.Expect(errors.Is(err, os.ErrNotExist)).To(BeTrue())
+}
+
+// TestObjectStoreFileSystem_MultipartUpload verifies that a file larger than a part is sent as
+// a multipart upload and reassembled in order.
+func TestObjectStoreFileSystem_MultipartUpload(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	store := newFakeObjectStore(t, 1000)
+	objectFS := store.fileSystem(t, 5)
+
+	file, err := objectFS.Create("big.bin")
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	for _, chunk := range []string{"abc", "defghij", "klmnopqrstuvw"} {
+		_, err = io.WriteString(file, chunk)
+		g.Expect(err).ShouldNot(HaveOccurred())
+	}
+
+	g.Expect(file.Close()).To(Succeed())
+
+	g.Expect(store.object("big.bin")).To(Equal("abcdefghijklmnopqrstuvw"))
+	g.Expect(store.partsUploaded()).To(Equal(5))
+}
+
+// TestObjectStoreFileSystem_UploadsModTime verifies that a modification time set before writing
+// is sent with the upload, whole or multipart, so Chtimes has nothing left to copy.
+func TestObjectStoreFileSystem_UploadsModTime(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	store := newFakeObjectStore(t, 1000)
+	objectFS := store.fileSystem(t, 5)
+	modTime := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)
+
+	for _, contents := range []string{"abc", "abcdefghijklmnopqrstuvw"} {
+		file, err := objectFS.Create("file.txt")
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		setter, ok := file.(filesystem.ModTimeSetter)
+		g.Expect(ok).To(BeTrue())
+		setter.SetModTime(modTime)
+
+		g.Expect(io.WriteString(file, contents)).To(Equal(len(contents)))
+		g.Expect(file.Close()).To(Succeed())
+
+		info, err := objectFS.Stat("file.txt")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(info.ModTime().Equal(modTime)).To(BeTrue())
+
+		g.Expect(objectFS.Chtimes("file.txt", modTime, modTime)).To(Succeed())
+		g.Expect(store.copiesMade()).To(BeZero())
+	}
+
+	// A different time still has to be copied onto the existing object
+	g.Expect(objectFS.Chtimes("file.txt", modTime.Add(time.Hour), modTime.Add(time.Hour))).To(Succeed())
+	g.Expect(store.copiesMade()).To(Equal(1))
+}
+
+// TestObjectStoreFileSystem_AbortDiscardsUpload verifies that an aborted file is never
+// published, whether it was still buffered or already partly sent as a multipart upload.
+func TestObjectStoreFileSystem_AbortDiscardsUpload(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	store := newFakeObjectStore(t, 1000)
+	objectFS := store.fileSystem(t, 5)
+
+	for _, contents := range []string{"abc", "abcdefghijklmnopqrstuvw"} {
+		file, err := objectFS.Create("partial.txt")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(io.WriteString(file, contents)).To(Equal(len(contents)))
+
+		aborter, ok := file.(filesystem.Aborter)
+		g.Expect(ok).To(BeTrue())
+		aborter.Abort()
+
+		g.Expect(file.Close()).NotTo(Succeed())
+
+		_, err = objectFS.Stat("partial.txt")
+		g.Expect(errors.Is(err, os.ErrNotExist)).To(BeTrue())
+		g.Expect(store.uploadsInProgress()).To(BeZero())
+	}
+}
+
+// TestObjectStoreFileSystem_ScanPages verifies that a scan follows the listing across pages.
+func TestObjectStoreFileSystem_ScanPages(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	store := newFakeObjectStore(t, 2)
+	objectFS := store.fileSystem(t, 0)
+
+	for i := range 5 {
+		store.put(fmt.Sprintf("root/f%d.txt", i), "x")
+	}
+
+	store.put("other/g.txt", "x")
+
+	var paths []string
+	for _, info := range scanAll(t, objectFS.Scan("root")) {
+		paths = append(paths, info.RelativePath)
+	}
+
+	g.Expect(paths).To(Equal([]string{"f0.txt", "f1.txt", "f2.txt", "f3.txt", "f4.txt"}))
+}
+
+func TestObjectStoreFileSystem_ResizePoolClampsToBounds(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	objectFS := newFakeObjectStore(t, 1000).fileSystem(t, 0)
+
+	var pool filesystem.ResizablePool = objectFS
+
+	pool.ResizePool(100)
+	g.Expect(pool.PoolTargetSize()).To(Equal(pool.PoolMaxSize()))
+
+	pool.ResizePool(0)
+	g.Expect(pool.PoolTargetSize()).To(Equal(pool.PoolMinSize()))
+	g.Expect(pool.PoolSize()).To(BeZero())
+}
+
+func scanAll(t *testing.T, scanner filesystem.FileScanner) []filesystem.FileInfo {
+	t.Helper()
+
+	var infos []filesystem.FileInfo
+	for info, ok := scanner.Next(); ok; info, ok = scanner.Next() {
+		infos = append(infos, info)
+	}
+
+	if err := scanner.Err(); err != nil { //nolint:noinlineerr // Test helper
+		t.Fatalf("scan failed: %v", err)
+	}
+
+	return infos
+}
+
+// fakeObjectStore is an in-memory S3-compatible server with just enough of the API for
+// ObjectStoreFileSystem: object GET/HEAD/PUT/DELETE, copies, ListObjectsV2 and multipart uploads.
+type fakeObjectStore struct {
+	server   *httptest.Server
+	pageSize int
+
+	mu      sync.Mutex
+	objects map[string]fakeObject
+	uploads map[string]map[int][]byte
+	mtimes  map[string]string // Upload ID -> metadata it was started with
+	parts   int
+	copies  int
+}
+
+type fakeObject struct {
+	data  []byte
+	mtime string
+}
+
+func newFakeObjectStore(t *testing.T, pageSize int) *fakeObjectStore {
+	t.Helper()
+
+	store := &fakeObjectStore{
+		pageSize: pageSize,
+		objects:  make(map[string]fakeObject),
+		uploads:  make(map[string]map[int][]byte),
+		mtimes:   make(map[string]string),
+	}
+	store.server = httptest.NewServer(http.HandlerFunc(store.serve))
+	t.Cleanup(store.server.Close)
+
+	return store
+}
+
+func (s *fakeObjectStore) fileSystem(t *testing.T, partSize int64) *filesystem.ObjectStoreFileSystem {
+	t.Helper()
+
+	objectFS, err := filesystem.NewObjectStoreFileSystem(filesystem.ObjectStoreConfig{
+		Endpoint:        s.server.URL,
+		Bucket:          "bucket",
+		Region:          "us-east-1",
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+		PartSize:        partSize,
+	})
+	if err != nil {
+		t.Fatalf("failed to create object store filesystem: %v", err)
+	}
+
+	return objectFS
+}
+
+func (s *fakeObjectStore) put(key, data string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[key] = fakeObject{data: []byte(data)}
+}
+
+func (s *fakeObjectStore) object(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return string(s.objects[key].data)
+}
+
+func (s *fakeObjectStore) copiesMade() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.copies
+}
+
+func (s *fakeObjectStore) uploadsInProgress() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.uploads)
+}
+
+func (s *fakeObjectStore) partsUploaded() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.parts
+}
+
+//nolint:cyclop,funlen // One handler for the whole fake API
+func (s *fakeObjectStore) serve(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+		w.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/bucket")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	key = strings.TrimPrefix(key, "/")
+
+	query := r.URL.Query()
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		s.list(w, query.Get("prefix"), query.Get("continuation-token"), query.Get("max-keys"))
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		id := strconv.Itoa(len(s.uploads) + 1)
+		s.uploads[id] = make(map[int][]byte)
+		s.mtimes[id] = r.Header.Get(filesystem.ObjectStoreMtimeHeader)
+		writeXML(w, struct {
+			XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+			UploadID string   `xml:"UploadId"`
+		}{UploadID: id})
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		part, _ := strconv.Atoi(query.Get("partNumber"))
+		s.uploads[query.Get("uploadId")][part] = body
+		s.parts++
+		w.Header().Set("ETag", fmt.Sprintf("%q", "etag-"+query.Get("partNumber")))
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		parts := s.uploads[query.Get("uploadId")]
+
+		var data []byte
+		for i := 1; i <= len(parts); i++ {
+			data = append(data, parts[i]...)
+		}
+
+		s.objects[key] = fakeObject{data: data, mtime: s.mtimes[query.Get("uploadId")]}
+		delete(s.uploads, query.Get("uploadId"))
+		writeXML(w, struct {
+			XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+		}{})
+	case r.Method == http.MethodDelete && query.Has("uploadId"):
+		delete(s.uploads, query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		src, found := s.objects[strings.TrimPrefix(r.Header.Get("X-Amz-Copy-Source"), "/bucket/")]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		s.objects[key] = fakeObject{data: src.data, mtime: r.Header.Get(filesystem.ObjectStoreMtimeHeader)}
+		s.copies++
+		writeXML(w, struct {
+			XMLName xml.Name `xml:"CopyObjectResult"`
+		}{})
+	case r.Method == http.MethodPut:
+		s.objects[key] = fakeObject{data: body, mtime: r.Header.Get(filesystem.ObjectStoreMtimeHeader)}
+	case r.Method == http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		object, found := s.objects[key]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(object.data)))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+
+		if object.mtime != "" {
+			w.Header().Set(filesystem.ObjectStoreMtimeHeader, object.mtime)
+		}
+
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(object.data)
+		}
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+// list answers ListObjectsV2 in pages of pageSize keys, continuing after the key in token.
+func (s *fakeObjectStore) list(w http.ResponseWriter, prefix, token, maxKeys string) {
+	var keys []string
+
+	for key := range s.objects {
+		if strings.HasPrefix(key, prefix) && key > token {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	limit := s.pageSize
+	if n, err := strconv.Atoi(maxKeys); err == nil && n < limit { //nolint:noinlineerr // Optional parameter
+		limit = n
+	}
+
+	type content struct {
+		Key          string    `xml:"Key"`
+		Size         int       `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	}
+
+	result := struct {
+		XMLName               xml.Name  `xml:"ListBucketResult"`
+		Contents              []content `xml:"Contents"`
+		IsTruncated           bool      `xml:"IsTruncated"`
+		NextContinuationToken string    `xml:"NextContinuationToken,omitempty"`
+	}{}
+
+	for i, key := range keys {
+		if i == limit {
+			result.IsTruncated = true
+			result.NextContinuationToken = keys[i-1]
+
+			break
+		}
+
+		result.Contents = append(result.Contents, content{Key: key, Size: len(s.objects[key].data), LastModified: time.Now().UTC()})
+	}
+
+	writeXML(w, result)
+}
+
+func writeXML(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,17 @@
+package filesystem
+
+import (
+	"os"
+
+	"github.com/pkg/sftp"
+)
+
+// FileOwner returns the numeric user and group IDs that own a file, from either a local
+// stat or an SFTP one. ok is false where ownership isn't available (e.g. Windows).
+func FileOwner(info os.FileInfo) (uid, gid int, ok bool) {
+	if stat, isSFTP := info.Sys().(*sftp.FileStat); isSFTP {
+		return int(stat.UID), int(stat.GID), true
+	}
+
+	return localFileOwner(info)
+}
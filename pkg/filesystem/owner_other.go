@@ -0,0 +1,10 @@
+//go:build !unix
+
+package filesystem
+
+import "os"
+
+// localFileOwner reports no owner on platforms without numeric user and group IDs.
+func localFileOwner(_ os.FileInfo) (uid, gid int, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package filesystem
+
+import (
+	"os"
+	"syscall"
+)
+
+// localFileOwner reads the owner from the stat(2) result behind info.
+func localFileOwner(info os.FileInfo) (uid, gid int, ok bool) {
+	stat, isStat := info.Sys().(*syscall.Stat_t)
+	if !isStat {
+		return 0, 0, false
+	}
+
+	return int(stat.Uid), int(stat.Gid), true
+}
@@ -117,6 +117,24 @@ func (f *PooledSFTPFile) Read(p []byte) (int, error) { //nolint:varnamelen // p
 	return f.file.Read(p) //nolint:wrapcheck // Interface method, caller handles wrapping
 }
 
+// ReadAt reads len(p) bytes at offset off, if the underlying file supports random access.
+// Returns fs.ErrClosed if the file has been closed.
+func (f *PooledSFTPFile) ReadAt(p []byte, off int64) (int, error) { //nolint:varnamelen // p is idiomatic for byte slice
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return 0, fs.ErrClosed
+	}
+	f.mu.Unlock()
+
+	readerAt, ok := f.file.(io.ReaderAt)
+	if !ok {
+		return 0, errors.ErrUnsupported
+	}
+
+	return readerAt.ReadAt(p, off) //nolint:wrapcheck // Interface method, caller handles wrapping
+}
+
 // Stat returns file information for the underlying file.
 // Returns fs.ErrClosed if the file has been closed.
 func (f *PooledSFTPFile) Stat() (os.FileInfo, error) {
@@ -130,6 +148,31 @@ func (f *PooledSFTPFile) Stat() (os.FileInfo, error) {
 	return f.file.Stat() //nolint:wrapcheck // Interface method, caller handles wrapping
 }
 
+// Sync flushes the underlying file to stable storage. Returns errors.ErrUnsupported if the
+// file or the server can't.
+func (f *PooledSFTPFile) Sync() error {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return fs.ErrClosed
+	}
+	f.mu.Unlock()
+
+	syncer, ok := f.file.(interface{ Sync() error })
+	if !ok {
+		return errors.ErrUnsupported
+	}
+
+	err := syncer.Sync()
+
+	var statusErr *sftp.StatusError
+	if errors.As(err, &statusErr) && statusErr.FxCode() == sftp.ErrSSHFxOpUnsupported {
+		return errors.ErrUnsupported
+	}
+
+	return err //nolint:wrapcheck // Interface method, caller handles wrapping
+}
+
 // Write writes len(p) bytes from p to the underlying file.
 // Returns fs.ErrClosed if the file has been closed.
 func (f *PooledSFTPFile) Write(p []byte) (int, error) { //nolint:varnamelen // p is idiomatic for byte slice
@@ -1,19 +1,27 @@
 package filesystem
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 )
 
-// realFileScanner implements FileScanner using filepath.Walk with progressive yielding.
+// errScanStopped ends a walk whose scanner was stopped.
+var errScanStopped = errors.New("scan stopped")
+
+// realFileScanner implements FileScanner using filepath.WalkDir with progressive yielding.
 type realFileScanner struct {
-	root    string
-	fileCh  chan FileInfo
-	errCh   chan error
-	err     error
-	started bool
-	done    bool
+	root        string
+	followLinks bool // Yield links as their targets and walk linked directories
+	maxDepth    int  // Levels below root to descend (0 = unlimited)
+	fileCh      chan FileInfo
+	errCh       chan error
+	stop        chan struct{} // Closed by Stop, ending the walk
+	err         error
+	started     bool
+	done        bool
 }
 
 // Err returns any error that occurred during scanning.
@@ -60,6 +68,59 @@ func (s *realFileScanner) Next() (FileInfo, bool) {
 	}
 }
 
+// LimitDepth stops the walk entering directories maxDepth levels below the root.
+func (s *realFileScanner) LimitDepth(maxDepth int) {
+	s.maxDepth = maxDepth
+}
+
+// enters reports whether the walk descends into the directory at path.
+func (s *realFileScanner) enters(path string) bool {
+	relPath, err := filepath.Rel(s.root, path)
+
+	return err != nil || !atDepthLimit(relPath, s.maxDepth)
+}
+
+// Stop ends the walk, so its goroutine doesn't wait forever for a consumer that has stopped
+// calling Next.
+func (s *realFileScanner) Stop() {
+	if s.done {
+		return
+	}
+
+	s.done = true
+
+	close(s.stop)
+}
+
+// send yields one entry to the consumer, or fails with errScanStopped once the scanner is stopped.
+func (s *realFileScanner) send(path string, info os.FileInfo, isSymlink bool) error {
+	// Get relative path
+	relPath, err := filepath.Rel(s.root, path)
+	if err != nil {
+		return fmt.Errorf("failed to get relative path for %s: %w", path, err)
+	}
+
+	uid, gid, hasOwner := FileOwner(info)
+
+	// Send file info to channel (yields immediately)
+	select {
+	case s.fileCh <- FileInfo{
+		RelativePath: relPath,
+		Size:         info.Size(),
+		ModTime:      info.ModTime(),
+		Mode:         info.Mode().Perm(),
+		IsDir:        info.IsDir(),
+		IsSymlink:    isSymlink,
+		UID:          uid,
+		GID:          gid,
+		HasOwner:     hasOwner,
+	}:
+		return nil
+	case <-s.stop:
+		return errScanStopped
+	}
+}
+
 // startWalking begins the directory walk in a background goroutine.
 func (s *realFileScanner) startWalking() {
 	s.fileCh = make(chan FileInfo)
@@ -68,42 +129,114 @@ func (s *realFileScanner) startWalking() {
 	go func() {
 		defer close(s.fileCh)
 
-		walkErr := filepath.Walk(s.root, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
+		var walkErr error
+		if s.followLinks {
+			walkErr = s.walkFollowingLinks()
+		} else {
+			walkErr = filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+
+				// Skip the root directory itself
+				if path == s.root {
+					return nil
+				}
+
+				info, err := entry.Info()
+				if err != nil {
+					return fmt.Errorf("failed to stat %s: %w", path, err)
+				}
+
+				err = s.send(path, info, info.Mode()&os.ModeSymlink != 0)
+				if err == nil && info.IsDir() && !s.enters(path) {
+					return filepath.SkipDir
+				}
+
 				return err
+			})
+		}
+
+		if walkErr != nil {
+			s.errCh <- walkErr
+		}
+	}()
+}
+
+// walkFollowingLinks walks the tree in the same order as filepath.WalkDir, but through symbolic
+// links.
+func (s *realFileScanner) walkFollowingLinks() error {
+	realRoot, err := filepath.EvalSymlinks(s.root)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", s.root, err)
+	}
+
+	return s.walkDirFollowingLinks(s.root, realRoot, map[string]bool{realRoot: true})
+}
+
+// walkDirFollowingLinks yields dir's entries, descending into directories, linked or not.
+// A followed link is yielded as its target, not as a link; one whose target is missing is
+// yielded as the link itself, and fails to copy. realDir is dir with every link resolved, and
+// ancestors holds the resolved path of each directory being walked: a link to one of them
+// would walk it again forever, so it's left out.
+func (s *realFileScanner) walkDirFollowingLinks(dir, realDir string, ancestors map[string]bool) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		realPath := filepath.Join(realDir, entry.Name())
+
+		info, err := os.Lstat(path)
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+
+		isSymlink := info.Mode()&os.ModeSymlink != 0
+		if isSymlink {
+			targetInfo, statErr := os.Stat(path)
+			if statErr == nil {
+				info = targetInfo
+				isSymlink = false
 			}
 
-			// Get relative path
-			relPath, err := filepath.Rel(s.root, path)
+			realPath, err = filepath.EvalSymlinks(path)
 			if err != nil {
-				return fmt.Errorf("failed to get relative path for %s: %w", path, err)
+				realPath = path
 			}
+		}
 
-			// Skip the root directory itself
-			if relPath == "." {
-				return nil
-			}
+		if info.IsDir() && ancestors[realPath] {
+			continue
+		}
 
-			// Send file info to channel (yields immediately)
-			s.fileCh <- FileInfo{
-				RelativePath: relPath,
-				Size:         info.Size(),
-				ModTime:      info.ModTime(),
-				IsDir:        info.IsDir(),
-			}
+		err = s.send(path, info, isSymlink)
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() || !s.enters(path) {
+			continue
+		}
 
-			return nil
-		})
+		ancestors[realPath] = true
+		err = s.walkDirFollowingLinks(path, realPath, ancestors)
+		delete(ancestors, realPath)
 
-		if walkErr != nil {
-			s.errCh <- walkErr
+		if err != nil {
+			return err
 		}
-	}()
+	}
+
+	return nil
 }
 
 // newRealFileScanner creates a new scanner for the given directory.
 func newRealFileScanner(root string) *realFileScanner {
 	return &realFileScanner{
 		root: root,
+		stop: make(chan struct{}),
 	}
 }
@@ -2,6 +2,7 @@
 package filesystem
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -336,3 +337,173 @@ func TestRealFileScanner_YieldsProgressively_NotAllAtOnce(t *testing.T) {
 		t.Errorf("Expected at least 100 files, got %d", fileCount)
 	}
 }
+
+// TestRealFileScanner_FollowLinks tests that a following scan yields links as their targets,
+// walks linked directories, and stops at a link back to an ancestor.
+func TestRealFileScanner_FollowLinks(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	outside := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(outside, "shared.txt"), []byte("shared content"), 0o644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	err = os.MkdirAll(filepath.Join(tmpDir, "sub"), 0o755)
+	if err != nil {
+		t.Fatalf("Failed to create subdirectory: %v", err)
+	}
+
+	for link, target := range map[string]string{
+		"linked":          outside,
+		"sub/loop":        tmpDir,
+		"sub/shared-copy": filepath.Join(outside, "shared.txt"),
+		"dangling":        filepath.Join(outside, "missing.txt"),
+	} {
+		err := os.Symlink(target, filepath.Join(tmpDir, link))
+		if err != nil {
+			t.Skipf("symlinks unavailable: %v", err)
+		}
+	}
+
+	scanner := newRealFileScanner(tmpDir)
+	scanner.followLinks = true
+
+	seen := make(map[string]FileInfo)
+	for {
+		file, ok := scanner.Next()
+		if !ok {
+			break
+		}
+
+		seen[file.RelativePath] = file
+	}
+
+	if scanner.Err() != nil {
+		t.Fatalf("Unexpected error: %v", scanner.Err())
+	}
+
+	want := []string{"dangling", "linked", filepath.Join("linked", "shared.txt"), "sub", filepath.Join("sub", "shared-copy")}
+	if len(seen) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, seen)
+	}
+
+	for _, path := range want {
+		if _, ok := seen[path]; !ok {
+			t.Errorf("Expected %s to be yielded", path)
+		}
+	}
+
+	if info := seen["linked"]; !info.IsDir || info.IsSymlink {
+		t.Errorf("Expected the linked directory to be yielded as a directory, got %+v", info)
+	}
+
+	if info := seen[filepath.Join("sub", "shared-copy")]; info.Size != int64(len("shared content")) || info.IsSymlink {
+		t.Errorf("Expected the file link to be yielded as its target, got %+v", info)
+	}
+
+	if info := seen["dangling"]; !info.IsSymlink {
+		t.Errorf("Expected the dangling link to be yielded as a link, got %+v", info)
+	}
+}
+
+// TestRealFileScanner_StopEndsWalk verifies that stopping a scan partway lets the walk goroutine
+// finish instead of blocking forever on an entry nobody will read.
+func TestRealFileScanner_StopEndsWalk(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	for i := range 5 {
+		err := os.WriteFile(filepath.Join(tmpDir, "file"+string(rune('0'+i))+".txt"), []byte("content"), 0o644)
+		if err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	scanner := newRealFileScanner(tmpDir)
+
+	_, ok := scanner.Next()
+	if !ok {
+		t.Fatal("Expected a first entry")
+	}
+
+	scanner.Stop()
+
+	select {
+	case err := <-scanner.errCh:
+		if !errors.Is(err, errScanStopped) {
+			t.Errorf("Expected the walk to end with errScanStopped, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Walk still running after Stop")
+	}
+
+	_, ok = scanner.Next()
+	if ok {
+		t.Error("Expected Next to return false after Stop")
+	}
+}
+
+// TestRealFileScanner_LimitDepth verifies that a depth-limited walk yields directories at the
+// limit but never enters them, with and without following links. An unreadable directory at the
+// limit shows that it isn't read (root can read them anyway, so the test is skipped as root).
+func TestRealFileScanner_LimitDepth(t *testing.T) {
+	t.Parallel()
+
+	if os.Geteuid() == 0 {
+		t.Skip("root can read unreadable directories")
+	}
+
+	for _, followLinks := range []bool{false, true} {
+		tmpDir := t.TempDir()
+
+		for _, dir := range []string{"a/b/c", "d"} {
+			err := os.MkdirAll(filepath.Join(tmpDir, dir), 0o755)
+			if err != nil {
+				t.Fatalf("Failed to create directory: %v", err)
+			}
+		}
+
+		err := os.WriteFile(filepath.Join(tmpDir, "a", "file.txt"), []byte("content"), 0o644)
+		if err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+
+		unreadable := filepath.Join(tmpDir, "a", "b")
+
+		err = os.Chmod(unreadable, 0)
+		if err != nil {
+			t.Fatalf("Failed to make %s unreadable: %v", unreadable, err)
+		}
+
+		t.Cleanup(func() { _ = os.Chmod(unreadable, 0o755) })
+
+		scanner := newRealFileScanner(tmpDir)
+		scanner.followLinks = followLinks
+		scanner.LimitDepth(2)
+
+		var seen []string
+
+		for file, ok := scanner.Next(); ok; file, ok = scanner.Next() {
+			seen = append(seen, file.RelativePath)
+		}
+
+		if scanner.Err() != nil {
+			t.Fatalf("followLinks=%v: a directory at the limit was read: %v", followLinks, scanner.Err())
+		}
+
+		want := []string{"a", filepath.Join("a", "b"), filepath.Join("a", "file.txt"), "d"}
+		if len(seen) != len(want) {
+			t.Fatalf("followLinks=%v: expected %v, got %v", followLinks, want, seen)
+		}
+
+		for i := range want {
+			if seen[i] != want[i] {
+				t.Fatalf("followLinks=%v: expected %v, got %v", followLinks, want, seen)
+			}
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package filesystem
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -16,8 +19,19 @@ type FileInfo struct {
 	// ModTime is the modification time
 	ModTime time.Time
 
+	// Mode is the permission bits
+	Mode os.FileMode
+
 	// IsDir indicates if this is a directory
 	IsDir bool
+
+	// IsSymlink indicates if this is a symbolic link (Scan doesn't follow links)
+	IsSymlink bool
+
+	// UID and GID are the owner's numeric user and group IDs, valid if HasOwner is set
+	UID      int
+	GID      int
+	HasOwner bool
 }
 
 // FileScanner is an iterator over files in a directory.
@@ -32,3 +46,28 @@ type FileScanner interface {
 	// Should be checked after Next() returns false.
 	Err() error
 }
+
+// ScanStopper is implemented by scanners that hold something until their scan ends, such as a
+// goroutine walking ahead or a pooled connection. A consumer that stops calling Next before the
+// scan is done calls Stop to let it go; Next returns false after Stop. Stop is a no-op once the
+// scan has ended, and must not be called while Next is running.
+type ScanStopper interface {
+	Stop()
+}
+
+// DepthLimiter is implemented by scanners that can stop descending at a depth, so directories
+// below it are never read. Directories maxDepth levels below the root are yielded but not
+// entered; 0 means unlimited. LimitDepth must be called before the first Next.
+type DepthLimiter interface {
+	LimitDepth(maxDepth int)
+}
+
+// atDepthLimit reports whether a directory at relPath is maxDepth levels below the root, or
+// deeper, so a walk limited to maxDepth mustn't enter it.
+func atDepthLimit(relPath string, maxDepth int) bool {
+	if maxDepth <= 0 {
+		return false
+	}
+
+	return strings.Count(filepath.ToSlash(relPath), "/")+1 >= maxDepth
+}
@@ -83,10 +83,21 @@ type SSHDialer interface {
 // Connect establishes an SSH connection and opens an SFTP session.
 // It uses SSH agent and default SSH keys for authentication.
 func Connect(host string, port int, user string) (*SFTPConnection, error) {
+	return ConnectWithCredential(host, port, user, nil)
+}
+
+// ConnectWithCredential is Connect with a resolved credential (see ResolveCredential).
+// The credential's password is tried after the SSH agent and default keys; a nil
+// credential means key-based authentication only.
+func ConnectWithCredential(host string, port int, user string, cred *Credential) (*SFTPConnection, error) {
 	// Get authentication methods
 	authMethods := getSSHAuthMethods()
+	if cred != nil && cred.Password != "" {
+		authMethods = append(authMethods, ssh.Password(cred.Password))
+	}
+
 	if len(authMethods) == 0 {
-		return nil, fmt.Errorf("no SSH authentication methods available (tried SSH agent and default keys)") //nolint:err113,perfsprint,lll // Descriptive error for auth failure
+		return nil, fmt.Errorf("no SSH authentication methods available (tried SSH agent, default keys and stored credentials)") //nolint:err113,perfsprint,lll // Descriptive error for auth failure
 	}
 
 	// Create SSH client config
@@ -1,8 +1,12 @@
 package filesystem
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -53,6 +57,22 @@ func NewSFTPFileSystem(conn *SFTPConnection, config *PoolConfig) (*SFTPFileSyste
 	}, nil
 }
 
+// Chmod changes the permission bits of a remote file.
+func (fs *SFTPFileSystem) Chmod(path string, mode os.FileMode) error {
+	client, err := fs.pool.Acquire()
+	if err != nil {
+		return fmt.Errorf("failed to acquire SFTP client: %w", err)
+	}
+	defer fs.pool.Release(client)
+
+	err = client.Chmod(path, mode)
+	if err != nil {
+		return fmt.Errorf("failed to change mode for remote file %s: %w", path, err)
+	}
+
+	return nil
+}
+
 // Chtimes changes the access and modification times of a remote file.
 func (fs *SFTPFileSystem) Chtimes(path string, atime, mtime time.Time) error {
 	client, err := fs.pool.Acquire()
@@ -103,6 +123,31 @@ func (fs *SFTPFileSystem) Create(path string) (File, error) {
 	return pooledFile, nil
 }
 
+// ExtractTar unpacks a tar stream into dir by running tar on the remote host over the SSH connection.
+// The remote host needs a tar that reads archives from stdin (GNU tar, bsdtar and busybox all do).
+func (fs *SFTPFileSystem) ExtractTar(dir string, r io.Reader) error {
+	session, err := fs.pool.sshClient.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to open SSH session: %w", err)
+	}
+
+	defer func() {
+		_ = session.Close()
+	}()
+
+	var stderr bytes.Buffer
+
+	session.Stdin = r
+	session.Stderr = &stderr
+
+	err = session.Run("tar -xf - -C " + shellQuote(dir))
+	if err != nil {
+		return fmt.Errorf("failed to extract tar into remote %s: %w (%s)", dir, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
 // MkdirAll creates a remote directory and all necessary parents.
 func (fs *SFTPFileSystem) MkdirAll(path string, perm os.FileMode) error { //nolint:revive,lll // perm unused - SFTP uses server defaults, parameter required by FileSystem interface
 	client, err := fs.pool.Acquire()
@@ -144,6 +189,40 @@ func (fs *SFTPFileSystem) Open(path string) (File, error) {
 	return pooledFile, nil
 }
 
+// OpenAppend opens an existing remote file for writing at its end. It seeks to the end rather
+// than relying on the append flag, which many SFTP servers ignore.
+func (fs *SFTPFileSystem) OpenAppend(path string) (File, error) {
+	client, err := fs.pool.Acquire()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire SFTP client: %w", err)
+	}
+
+	file, err := client.OpenFile(path, os.O_WRONLY)
+	if err != nil {
+		fs.pool.Release(client)
+		return nil, fmt.Errorf("failed to open remote file %s for appending: %w", path, err)
+	}
+
+	_, err = file.Seek(0, io.SeekEnd)
+	if err != nil {
+		_ = file.Close()
+		fs.pool.Release(client)
+
+		return nil, fmt.Errorf("failed to seek to the end of remote file %s: %w", path, err)
+	}
+
+	// Wrap with pooled file - auto-releases client on close
+	pooledFile, err := NewPooledSFTPFile(file, client, fs.pool)
+	if err != nil {
+		_ = file.Close()
+		fs.pool.Release(client)
+
+		return nil, fmt.Errorf("failed to create pooled file: %w", err)
+	}
+
+	return pooledFile, nil
+}
+
 // PoolMaxSize returns the maximum allowed pool size.
 func (fs *SFTPFileSystem) PoolMaxSize() int {
 	return fs.pool.MaxSize()
@@ -164,6 +243,22 @@ func (fs *SFTPFileSystem) PoolTargetSize() int {
 	return fs.pool.TargetSize()
 }
 
+// Readlink returns the target of a remote symbolic link.
+func (fs *SFTPFileSystem) Readlink(path string) (string, error) {
+	client, err := fs.pool.Acquire()
+	if err != nil {
+		return "", fmt.Errorf("failed to acquire SFTP client: %w", err)
+	}
+	defer fs.pool.Release(client)
+
+	target, err := client.ReadLink(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read remote link %s: %w", path, err)
+	}
+
+	return target, nil
+}
+
 // Remove removes a remote file or empty directory.
 func (fs *SFTPFileSystem) Remove(path string) error {
 	client, err := fs.pool.Acquire()
@@ -180,6 +275,32 @@ func (fs *SFTPFileSystem) Remove(path string) error {
 	return nil
 }
 
+// Rename moves a remote file, replacing any file at newpath. Servers without the
+// posix-rename extension can't replace files, so newpath is removed first there, leaving a
+// moment with neither.
+func (fs *SFTPFileSystem) Rename(oldpath, newpath string) error {
+	client, err := fs.pool.Acquire()
+	if err != nil {
+		return fmt.Errorf("failed to acquire SFTP client: %w", err)
+	}
+	defer fs.pool.Release(client)
+
+	if _, ok := client.HasExtension("posix-rename@openssh.com"); ok {
+		err = client.PosixRename(oldpath, newpath)
+	} else {
+		err = client.Remove(newpath)
+		if err == nil || errors.Is(err, os.ErrNotExist) {
+			err = client.Rename(oldpath, newpath)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to rename remote file %s to %s: %w", oldpath, newpath, err)
+	}
+
+	return nil
+}
+
 // ResizePool sets the target pool size.
 // Delegates to the underlying pool's Resize method.
 func (fs *SFTPFileSystem) ResizePool(targetSize int) {
@@ -215,6 +336,22 @@ func (fs *SFTPFileSystem) Stat(path string) (os.FileInfo, error) {
 	return info, nil
 }
 
+// Symlink creates a remote symbolic link at path pointing at target.
+func (fs *SFTPFileSystem) Symlink(target, path string) error {
+	client, err := fs.pool.Acquire()
+	if err != nil {
+		return fmt.Errorf("failed to acquire SFTP client: %w", err)
+	}
+	defer fs.pool.Release(client)
+
+	err = client.Symlink(target, path)
+	if err != nil {
+		return fmt.Errorf("failed to create remote link %s: %w", path, err)
+	}
+
+	return nil
+}
+
 // DefaultPoolConfig returns the default pool configuration.
 func DefaultPoolConfig() *PoolConfig {
 	return &PoolConfig{
@@ -223,3 +360,8 @@ func DefaultPoolConfig() *PoolConfig {
 		MaxSize:     16, //nolint:mnd // Maximum pool connections
 	}
 }
+
+// shellQuote quotes s for a POSIX shell command line.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
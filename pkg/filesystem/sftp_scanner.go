@@ -2,6 +2,7 @@ package filesystem
 
 import (
 	"fmt"
+	"os"
 	"path"
 
 	"github.com/kr/fs"
@@ -25,6 +26,11 @@ func (s *pooledSFTPScanner) Err() error {
 // Next advances to the next file and returns its info.
 // Automatically releases the client back to the pool when scanning is complete.
 func (s *pooledSFTPScanner) Next() (FileInfo, bool) {
+	// A stopped scan has given its client back
+	if s.done {
+		return FileInfo{}, false
+	}
+
 	info, hasNext := s.scanner.Next()
 
 	// If we're done scanning and haven't released yet, release the client
@@ -36,13 +42,34 @@ func (s *pooledSFTPScanner) Next() (FileInfo, bool) {
 	return info, hasNext
 }
 
+// LimitDepth stops the walk entering directories maxDepth levels below the root.
+func (s *pooledSFTPScanner) LimitDepth(maxDepth int) {
+	s.scanner.LimitDepth(maxDepth)
+}
+
+// Stop releases the client back to the pool before the scan is complete.
+func (s *pooledSFTPScanner) Stop() {
+	if s.done {
+		return
+	}
+
+	s.done = true
+	s.pool.Release(s.client)
+}
+
 // sftpScanner implements FileScanner for SFTP directories with progressive yielding.
 type sftpScanner struct {
-	client  *sftp.Client
-	root    string
-	walker  *fs.Walker
-	err     error
-	started bool
+	client   *sftp.Client
+	root     string
+	walker   *fs.Walker
+	maxDepth int // Levels below root to descend (0 = unlimited)
+	err      error
+	started  bool
+}
+
+// LimitDepth stops the walk entering directories maxDepth levels below the root.
+func (s *sftpScanner) LimitDepth(maxDepth int) {
+	s.maxDepth = maxDepth
 }
 
 // Err returns any error that occurred during scanning.
@@ -88,12 +115,23 @@ func (s *sftpScanner) Next() (FileInfo, bool) {
 			return FileInfo{}, false
 		}
 
+		if stat.IsDir() && atDepthLimit(relPath, s.maxDepth) {
+			s.walker.SkipDir()
+		}
+
+		uid, gid, hasOwner := FileOwner(stat)
+
 		// Return this file immediately (progressive yielding)
 		return FileInfo{
 			RelativePath: relPath,
 			Size:         stat.Size(),
 			ModTime:      stat.ModTime(),
+			Mode:         stat.Mode().Perm(),
 			IsDir:        stat.IsDir(),
+			IsSymlink:    stat.Mode()&os.ModeSymlink != 0,
+			UID:          uid,
+			GID:          gid,
+			HasOwner:     hasOwner,
 		}, true
 	}
 
@@ -0,0 +1,49 @@
+package filesystem
+
+import (
+	"errors"
+	"regexp"
+)
+
+// Exported variables.
+var (
+	ErrSnapshotNotSupported = errors.New("source filesystem does not support snapshots")
+	ErrSnapshotNested       = errors.New("source contains a filesystem its snapshot would leave out")
+)
+
+// snapshotDirPattern matches the hidden directory a btrfs snapshot is taken into at the root of
+// the source's subvolume (see snapshotName).
+var snapshotDirPattern = regexp.MustCompile(`^\.glowsync-\d{8}-\d{6}-\d+$`)
+
+// IsSnapshotDir reports whether a directory named name is a snapshot CreateSnapshot took inside
+// the tree it snapshots, which a run that died before removing it leaves behind. Such
+// directories aren't part of the tree.
+func IsSnapshotDir(name string) bool {
+	return snapshotDirPattern.MatchString(name)
+}
+
+// Snapshot is a read-only, point-in-time copy of a directory's filesystem, so a live tree can
+// be read without files changing underneath.
+type Snapshot struct {
+	Path string // The snapshotted directory's counterpart inside the snapshot
+	Kind string // How the snapshot was taken: btrfs, zfs or lvm
+
+	cleanup []func() error // Steps that undo the snapshot, run in reverse by Remove
+}
+
+// Remove deletes the snapshot. Every cleanup step runs even if an earlier one fails, and the
+// first failure is returned. Removing an already removed snapshot does nothing.
+func (s *Snapshot) Remove() error {
+	var firstErr error
+
+	for i := len(s.cleanup) - 1; i >= 0; i-- {
+		err := s.cleanup[i]()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	s.cleanup = nil
+
+	return firstErr
+}
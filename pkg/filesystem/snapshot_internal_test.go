@@ -0,0 +1,41 @@
+//nolint:testpackage // Tests unexported cleanup steps
+package filesystem
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+)
+
+// TestSnapshot_Remove_RunsEveryStepInReverse verifies that cleanup undoes the snapshot in reverse
+// order, keeps going past a failed step, and only runs once.
+func TestSnapshot_Remove_RunsEveryStepInReverse(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	var order []string
+
+	errUnmount := errors.New("target is busy")
+	snapshot := &Snapshot{cleanup: []func() error{
+		func() error { order = append(order, "remove volume"); return nil },
+		func() error { order = append(order, "remove mount point"); return nil },
+		func() error { order = append(order, "unmount"); return errUnmount },
+	}}
+
+	g.Expect(snapshot.Remove()).To(MatchError(errUnmount))
+	g.Expect(order).To(Equal([]string{"unmount", "remove mount point", "remove volume"}))
+
+	g.Expect(snapshot.Remove()).To(Succeed())
+	g.Expect(order).To(HaveLen(3))
+}
+
+func TestIsSnapshotDir(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	g.Expect(IsSnapshotDir(".glowsync-20240506-070809-4242")).To(BeTrue())
+	g.Expect(IsSnapshotDir(".glowsync-state.json")).To(BeFalse())
+	g.Expect(IsSnapshotDir("glowsync-20240506-070809-4242")).To(BeFalse())
+	g.Expect(IsSnapshotDir(".glowsync-20240506-070809-4242.bak")).To(BeFalse())
+}
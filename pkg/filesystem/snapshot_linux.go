@@ -0,0 +1,331 @@
+//go:build linux
+
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Filesystem magic numbers reported by statfs, and the inode of every btrfs subvolume root.
+const (
+	btrfsSuperMagic       uint32 = 0x9123683e
+	xfsSuperMagic         uint32 = 0x58465342
+	zfsSuperMagic         uint32 = 0x2fc12fc1
+	btrfsSubvolumeRootIno        = 256
+)
+
+// CreateSnapshot takes a read-only snapshot of the filesystem holding path: a btrfs or ZFS
+// snapshot on those filesystems, or an LVM snapshot mounted read-only when the filesystem sits
+// on a logical volume. It shells out to btrfs, zfs or lvcreate and mount, which usually needs
+// root. Filesystems that can't be snapshotted return ErrSnapshotNotSupported. A snapshot covers
+// a single filesystem, subvolume or dataset, so a path with another one below it, such as a
+// mount, a child ZFS dataset or a nested btrfs subvolume, returns ErrSnapshotNested rather than
+// a snapshot that shows it as an empty directory.
+func CreateSnapshot(path string) (*Snapshot, error) {
+	resolved, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+
+	resolved, err = filepath.EvalSymlinks(resolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+
+	var stat syscall.Statfs_t
+
+	err = syscall.Statfs(resolved, &stat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat filesystem of %s: %w", resolved, err)
+	}
+
+	nested, err := nestedFilesystem(resolved, deviceOf)
+	if err != nil {
+		return nil, err
+	}
+
+	if nested != "" {
+		return nil, fmt.Errorf("%w: %s is on its own filesystem, subvolume or dataset", ErrSnapshotNested, nested)
+	}
+
+	switch uint32(stat.Type) { //nolint:gosec // Magic numbers are 32-bit
+	case btrfsSuperMagic:
+		return snapshotBtrfs(resolved)
+	case zfsSuperMagic:
+		return snapshotZFS(resolved)
+	default:
+		return snapshotLVM(resolved, uint32(stat.Type) == xfsSuperMagic) //nolint:gosec // Magic numbers are 32-bit
+	}
+}
+
+// btrfsSubvolumeRoot returns the root of the btrfs subvolume containing path.
+func btrfsSubvolumeRoot(path string) (string, error) {
+	for dir := path; ; dir = filepath.Dir(dir) {
+		var stat syscall.Stat_t
+
+		err := syscall.Stat(dir, &stat)
+		if err != nil {
+			return "", fmt.Errorf("failed to stat %s: %w", dir, err)
+		}
+
+		if stat.Ino == btrfsSubvolumeRootIno {
+			return dir, nil
+		}
+
+		if dir == filepath.Dir(dir) {
+			return "", fmt.Errorf("%w: no btrfs subvolume contains %s", ErrSnapshotNotSupported, path)
+		}
+	}
+}
+
+// deviceOf returns the device of the filesystem holding path.
+func deviceOf(path string) (uint64, error) {
+	var stat syscall.Stat_t
+
+	err := syscall.Lstat(path, &stat)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	return uint64(stat.Dev), nil //nolint:unconvert // Dev is 32-bit on some platforms
+}
+
+// deviceNumber formats a device as the major:minor pair used under /sys/dev/block.
+func deviceNumber(dev uint64) string {
+	major := (dev>>8)&0xfff | (dev>>32)&^0xfff
+	minor := dev&0xff | (dev>>12)&^0xff
+
+	return fmt.Sprintf("%d:%d", major, minor)
+}
+
+// mountPointOf returns the mount point of the filesystem containing path.
+func mountPointOf(path string) (string, error) {
+	for dir := path; ; dir = filepath.Dir(dir) {
+		mounted, err := IsMountPoint(dir)
+		if err != nil {
+			return "", err
+		}
+
+		if mounted {
+			return dir, nil
+		}
+	}
+}
+
+// nestedFilesystem returns the first directory below path on another device than path, as
+// deviceOf reports it: a mount point, a child ZFS dataset or a nested btrfs subvolume. Returns
+// "" if there is none.
+func nestedFilesystem(path string, deviceOf func(string) (uint64, error)) (string, error) {
+	root, err := deviceOf(path)
+	if err != nil {
+		return "", err
+	}
+
+	var nested string
+
+	err = filepath.WalkDir(path, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !entry.IsDir() || dir == path {
+			return nil
+		}
+
+		// ZFS's control directory, and btrfs snapshots an earlier run left, hold snapshots
+		// rather than files
+		if entry.Name() == ".zfs" || IsSnapshotDir(entry.Name()) {
+			return filepath.SkipDir
+		}
+
+		device, err := deviceOf(dir)
+		if err != nil {
+			return err
+		}
+
+		if device != root {
+			nested = dir
+			return filepath.SkipAll
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to look for filesystems below %s: %w", path, err)
+	}
+
+	return nested, nil
+}
+
+// runSnapshotCommand runs a snapshot tool, including its output in any error.
+func runSnapshotCommand(name string, args ...string) (string, error) {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s %s failed: %w: %s", name, strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// snapshotBtrfs snapshots the subvolume containing path into a hidden directory at its root,
+// which scans skip if it's left behind (see IsSnapshotDir).
+func snapshotBtrfs(path string) (*Snapshot, error) {
+	root, err := btrfsSubvolumeRoot(path)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotDir := filepath.Join(root, "."+snapshotName())
+
+	_, err = runSnapshotCommand("btrfs", "subvolume", "snapshot", "-r", root, snapshotDir)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate %s in its subvolume: %w", path, err)
+	}
+
+	return &Snapshot{
+		Path: filepath.Join(snapshotDir, rel),
+		Kind: "btrfs",
+		cleanup: []func() error{func() error {
+			_, err := runSnapshotCommand("btrfs", "subvolume", "delete", snapshotDir)
+			return err
+		}},
+	}, nil
+}
+
+// snapshotLVM snapshots the logical volume holding path and mounts the snapshot read-only in a
+// temporary directory. XFS refuses to mount a second copy of a filesystem without nouuid.
+func snapshotLVM(path string, xfs bool) (*Snapshot, error) {
+	mountPoint, err := mountPointOf(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var stat syscall.Stat_t
+
+	err = syscall.Stat(mountPoint, &stat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", mountPoint, err)
+	}
+
+	sysDir := filepath.Join("/sys/dev/block", deviceNumber(uint64(stat.Dev))) //nolint:unconvert // Dev is 32-bit on some platforms
+
+	uuid, err := os.ReadFile(filepath.Join(sysDir, "dm", "uuid")) //nolint:gosec // Path is built from a device number
+	if err != nil || !strings.HasPrefix(string(uuid), "LVM-") {
+		return nil, fmt.Errorf("%w: %s is not on btrfs, ZFS or an LVM logical volume", ErrSnapshotNotSupported, path)
+	}
+
+	dmName, err := os.ReadFile(filepath.Join(sysDir, "dm", "name")) //nolint:gosec // Path is built from a device number
+	if err != nil {
+		return nil, fmt.Errorf("failed to read device mapper name of %s: %w", mountPoint, err)
+	}
+
+	out, err := runSnapshotCommand("lvs", "--noheadings", "-o", "vg_name,lv_name",
+		"/dev/mapper/"+strings.TrimSpace(string(dmName)))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(out)
+	if len(fields) != 2 { //nolint:mnd // Volume group and logical volume
+		return nil, fmt.Errorf("failed to parse lvs output: %q", out)
+	}
+
+	name := snapshotName()
+	snapshotLV := fields[0] + "/" + name
+	snapshot := &Snapshot{Kind: "lvm"}
+
+	_, err = runSnapshotCommand("lvcreate", "--snapshot", "--extents", "10%ORIGIN", "--name", name,
+		fields[0]+"/"+fields[1])
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.cleanup = append(snapshot.cleanup, func() error {
+		_, err := runSnapshotCommand("lvremove", "--force", snapshotLV)
+		return err
+	})
+
+	mountDir, err := os.MkdirTemp("", name)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to create snapshot mount point: %w", err), snapshot.Remove())
+	}
+
+	snapshot.cleanup = append(snapshot.cleanup, func() error { return os.Remove(mountDir) })
+
+	options := "ro"
+	if xfs {
+		options += ",nouuid"
+	}
+
+	_, err = runSnapshotCommand("mount", "-o", options, "/dev/"+snapshotLV, mountDir)
+	if err != nil {
+		return nil, errors.Join(err, snapshot.Remove())
+	}
+
+	snapshot.cleanup = append(snapshot.cleanup, func() error {
+		_, err := runSnapshotCommand("umount", mountDir)
+		return err
+	})
+
+	rel, err := filepath.Rel(mountPoint, path)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to locate %s in its filesystem: %w", path, err), snapshot.Remove())
+	}
+
+	snapshot.Path = filepath.Join(mountDir, rel)
+
+	return snapshot, nil
+}
+
+// snapshotName returns a name unique to this process and moment.
+func snapshotName() string {
+	return fmt.Sprintf("glowsync-%s-%d", time.Now().Format("20060102-150405"), os.Getpid())
+}
+
+// snapshotZFS snapshots the dataset containing path, read through its .zfs/snapshot directory.
+func snapshotZFS(path string) (*Snapshot, error) {
+	dataset, err := runSnapshotCommand("zfs", "list", "-H", "-o", "name", path)
+	if err != nil {
+		return nil, err
+	}
+
+	mountPoint, err := runSnapshotCommand("zfs", "get", "-H", "-o", "value", "mountpoint", dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := filepath.Rel(mountPoint, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil, fmt.Errorf("%w: %s is not under dataset %s's mount point", ErrSnapshotNotSupported, path, dataset)
+	}
+
+	name := snapshotName()
+	fullName := dataset + "@" + name
+
+	_, err = runSnapshotCommand("zfs", "snapshot", fullName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Snapshot{
+		Path: filepath.Join(mountPoint, ".zfs", "snapshot", name, rel),
+		Kind: "zfs",
+		cleanup: []func() error{func() error {
+			_, err := runSnapshotCommand("zfs", "destroy", fullName)
+			return err
+		}},
+	}, nil
+}
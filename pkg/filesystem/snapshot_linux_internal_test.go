@@ -0,0 +1,109 @@
+//nolint:testpackage // Tests the unexported nested filesystem check
+package filesystem
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega" //nolint:revive // Dot import is idiomatic for Gomega matchers
+)
+
+// TestNestedFilesystem_FindsOtherDevices verifies that a directory below the source on another
+// device, as a mount or nested subvolume is, is found, and that the source's own device passes.
+func TestNestedFilesystem_FindsOtherDevices(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	root := t.TempDir()
+	mounted := filepath.Join(root, "photos", "archive")
+
+	g.Expect(os.MkdirAll(mounted, 0o750)).To(Succeed())
+	g.Expect(os.MkdirAll(filepath.Join(root, "docs"), 0o750)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(root, "docs", "a.txt"), []byte("a"), 0o600)).To(Succeed())
+
+	sameDevice := func(string) (uint64, error) { return 1, nil }
+
+	nested, err := nestedFilesystem(root, sameDevice)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(nested).To(BeEmpty())
+
+	mountedDevice := func(path string) (uint64, error) {
+		if path == mounted {
+			return 2, nil
+		}
+
+		return 1, nil
+	}
+
+	nested, err = nestedFilesystem(root, mountedDevice)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(nested).To(Equal(mounted))
+}
+
+// TestNestedFilesystem_SkipsLeftoverSnapshots verifies that a btrfs snapshot an earlier run left
+// in the source, which is its own subvolume, isn't taken for a nested filesystem.
+func TestNestedFilesystem_SkipsLeftoverSnapshots(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	root := t.TempDir()
+	leftover := filepath.Join(root, "."+snapshotName())
+	g.Expect(os.Mkdir(leftover, 0o750)).To(Succeed())
+
+	snapshotDevice := func(path string) (uint64, error) {
+		if path == leftover {
+			return 2, nil
+		}
+
+		return 1, nil
+	}
+
+	nested, err := nestedFilesystem(root, snapshotDevice)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(nested).To(BeEmpty())
+}
+
+// TestNestedFilesystem_ReportsStatFailures verifies that a directory that can't be checked fails
+// the check rather than being assumed to be on the source's device.
+func TestNestedFilesystem_ReportsStatFailures(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	root := t.TempDir()
+	g.Expect(os.Mkdir(filepath.Join(root, "sub"), 0o750)).To(Succeed())
+
+	errStat := errors.New("stat failed")
+	failing := func(path string) (uint64, error) {
+		if path == root {
+			return 1, nil
+		}
+
+		return 0, errStat
+	}
+
+	_, err := nestedFilesystem(root, failing)
+	g.Expect(err).To(MatchError(errStat))
+}
+
+// TestCreateSnapshot_RefusesNestedMounts verifies that a source with a mount below it is refused
+// before anything is snapshotted. /dev has /dev/pts mounted below it on most Linux systems.
+func TestCreateSnapshot_RefusesNestedMounts(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	devDevice, err := deviceOf("/dev")
+	if err != nil {
+		t.Skipf("can't stat /dev: %v", err)
+	}
+
+	ptsDevice, err := deviceOf("/dev/pts")
+	if err != nil || ptsDevice == devDevice {
+		t.Skip("/dev/pts isn't mounted separately here")
+	}
+
+	snapshot, err := CreateSnapshot("/dev")
+	g.Expect(err).To(MatchError(ErrSnapshotNested))
+	g.Expect(snapshot).To(BeNil())
+}
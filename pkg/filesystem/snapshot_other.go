@@ -0,0 +1,8 @@
+//go:build !linux
+
+package filesystem
+
+// CreateSnapshot is not supported on this platform.
+func CreateSnapshot(string) (*Snapshot, error) {
+	return nil, ErrSnapshotNotSupported
+}
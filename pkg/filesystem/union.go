@@ -0,0 +1,324 @@
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// UnionRoot is the path of a UnionFileSystem's top directory, which holds each member under
+// its name.
+const UnionRoot = "/"
+
+// Exported variables.
+var (
+	ErrDuplicateUnionName   = errors.New("two sources share a folder name")
+	ErrInvalidUnionName     = errors.New("invalid source folder name")
+	ErrSymlinksNotSupported = errors.New("filesystem does not support symbolic links")
+	ErrUnionRoot            = errors.New("the top of a multi-source tree only holds its sources")
+)
+
+// UnionMember is one directory a UnionFileSystem shows as a top-level folder.
+type UnionMember struct {
+	Name string     // Folder it appears as under UnionRoot
+	FS   FileSystem // Filesystem it is on, local or remote
+	Root string     // Its path on FS
+}
+
+// UnionFileSystem shows several directories, each on its own filesystem, as one tree: each
+// member is a folder under UnionRoot, so their paths can't collide. It is read as a source, so
+// the top directory itself can't be written to.
+type UnionFileSystem struct {
+	members []UnionMember
+}
+
+// NewUnionFileSystem creates a UnionFileSystem of members, whose names must be distinct folder
+// names.
+func NewUnionFileSystem(members []UnionMember) (*UnionFileSystem, error) {
+	seen := make(map[string]bool, len(members))
+
+	for _, member := range members {
+		if member.Name == "" || member.Name == "." || member.Name == ".." || strings.ContainsAny(member.Name, `/\`) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidUnionName, member.Name)
+		}
+
+		if seen[member.Name] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateUnionName, member.Name)
+		}
+
+		seen[member.Name] = true
+	}
+
+	return &UnionFileSystem{members: members}, nil
+}
+
+// Members returns the directories the union shows, in the order they were given.
+func (fs *UnionFileSystem) Members() []UnionMember {
+	return fs.members
+}
+
+// Chtimes changes the access and modification times of a file in a member.
+func (fs *UnionFileSystem) Chtimes(filePath string, atime, mtime time.Time) error {
+	member, memberPath, err := fs.resolve(filePath)
+	if err != nil {
+		return err
+	}
+
+	return member.FS.Chtimes(memberPath, atime, mtime) //nolint:wrapcheck // The member's error already names the path
+}
+
+// Create creates a file in a member.
+func (fs *UnionFileSystem) Create(filePath string) (File, error) {
+	member, memberPath, err := fs.resolve(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return member.FS.Create(memberPath) //nolint:wrapcheck // The member's error already names the path
+}
+
+// MkdirAll creates a directory in a member, along with any parents it needs.
+func (fs *UnionFileSystem) MkdirAll(filePath string, perm os.FileMode) error {
+	member, memberPath, err := fs.resolve(filePath)
+	if err != nil {
+		return err
+	}
+
+	return member.FS.MkdirAll(memberPath, perm) //nolint:wrapcheck // The member's error already names the path
+}
+
+// Open opens a file in a member for reading.
+func (fs *UnionFileSystem) Open(filePath string) (File, error) {
+	member, memberPath, err := fs.resolve(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return member.FS.Open(memberPath) //nolint:wrapcheck // The member's error already names the path
+}
+
+// Readlink returns the target of a symbolic link in a member.
+func (fs *UnionFileSystem) Readlink(filePath string) (string, error) {
+	member, memberPath, err := fs.resolve(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	linker, ok := member.FS.(Symlinker)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrSymlinksNotSupported, filePath)
+	}
+
+	return linker.Readlink(memberPath) //nolint:wrapcheck // The member's error already names the path
+}
+
+// Remove deletes a file or empty directory in a member.
+func (fs *UnionFileSystem) Remove(filePath string) error {
+	member, memberPath, err := fs.resolve(filePath)
+	if err != nil {
+		return err
+	}
+
+	return member.FS.Remove(memberPath) //nolint:wrapcheck // The member's error already names the path
+}
+
+// Scan returns an iterator over a directory tree in the union. Scanning UnionRoot yields each
+// member's folder followed by everything in it.
+func (fs *UnionFileSystem) Scan(filePath string) FileScanner {
+	return fs.scan(filePath, func(member UnionMember, memberPath string) FileScanner {
+		return member.FS.Scan(memberPath)
+	})
+}
+
+// ScanFollowingSymlinks is Scan through symbolic links, for members whose filesystems can
+// follow them; the others are scanned without following links.
+func (fs *UnionFileSystem) ScanFollowingSymlinks(filePath string) FileScanner {
+	return fs.scan(filePath, func(member UnionMember, memberPath string) FileScanner {
+		follower, ok := member.FS.(SymlinkFollower)
+		if !ok {
+			return member.FS.Scan(memberPath)
+		}
+
+		return follower.ScanFollowingSymlinks(memberPath)
+	})
+}
+
+// Stat returns file information for a path in the union. UnionRoot is a directory modified
+// when its most recently modified member was.
+func (fs *UnionFileSystem) Stat(filePath string) (os.FileInfo, error) {
+	if isUnionRoot(filePath) {
+		info := &unionRootInfo{}
+
+		for _, member := range fs.members {
+			memberInfo, err := member.FS.Stat(member.Root)
+			if err != nil {
+				return nil, fmt.Errorf("failed to stat source %s: %w", member.Name, err)
+			}
+
+			if memberInfo.ModTime().After(info.modTime) {
+				info.modTime = memberInfo.ModTime()
+			}
+		}
+
+		return info, nil
+	}
+
+	member, memberPath, err := fs.resolve(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return member.FS.Stat(memberPath) //nolint:wrapcheck // The member's error already names the path
+}
+
+// resolve returns the member holding filePath and its path on the member's filesystem.
+func (fs *UnionFileSystem) resolve(filePath string) (UnionMember, string, error) {
+	rel := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(filePath)), "/")
+	if rel == "" {
+		return UnionMember{}, "", fmt.Errorf("%w: %s", ErrUnionRoot, filePath)
+	}
+
+	name, rest, _ := strings.Cut(rel, "/")
+
+	for _, member := range fs.members {
+		if member.Name == name {
+			if rest == "" {
+				return member, member.Root, nil
+			}
+
+			return member, path.Join(member.Root, rest), nil
+		}
+	}
+
+	return UnionMember{}, "", fmt.Errorf("no source named %s: %w", name, os.ErrNotExist)
+}
+
+// scan scans filePath with scanMember, which scans one member's directory.
+func (fs *UnionFileSystem) scan(filePath string, scanMember func(UnionMember, string) FileScanner) FileScanner {
+	if !isUnionRoot(filePath) {
+		member, memberPath, err := fs.resolve(filePath)
+		if err != nil {
+			return &unionScanner{err: err}
+		}
+
+		return scanMember(member, memberPath)
+	}
+
+	return &unionScanner{fs: fs, scanMember: scanMember}
+}
+
+// isUnionRoot reports whether filePath is UnionRoot.
+func isUnionRoot(filePath string) bool {
+	return path.Clean("/"+filepath.ToSlash(filePath)) == UnionRoot
+}
+
+// unionRootInfo implements os.FileInfo for UnionRoot.
+type unionRootInfo struct {
+	modTime time.Time
+}
+
+func (i *unionRootInfo) IsDir() bool        { return true }
+func (i *unionRootInfo) ModTime() time.Time { return i.modTime }
+func (i *unionRootInfo) Mode() os.FileMode  { return os.ModeDir | unionRootPerm }
+func (i *unionRootInfo) Name() string       { return UnionRoot }
+func (i *unionRootInfo) Size() int64        { return 0 }
+func (i *unionRootInfo) Sys() any           { return nil }
+
+// unionScanner scans UnionRoot: each member's folder, then the member's tree under it.
+type unionScanner struct {
+	fs         *UnionFileSystem
+	scanMember func(UnionMember, string) FileScanner
+	next       int         // Index of the next member to start
+	current    FileScanner // Scanner of the member being scanned
+	name       string      // Current member's folder
+	maxDepth   int         // Levels below UnionRoot to descend (0 = unlimited)
+	err        error
+	stopped    bool
+}
+
+// Err returns the error that stopped the scan, if any.
+func (s *unionScanner) Err() error {
+	return s.err
+}
+
+// Next returns the next entry in the union, its RelativePath under UnionRoot.
+func (s *unionScanner) Next() (FileInfo, bool) {
+	if s.err != nil || s.fs == nil || s.stopped {
+		return FileInfo{}, false
+	}
+
+	for {
+		if s.current != nil {
+			info, ok := s.current.Next()
+			if ok {
+				info.RelativePath = filepath.Join(s.name, info.RelativePath)
+				return info, true
+			}
+
+			err := s.current.Err()
+			if err != nil {
+				s.err = fmt.Errorf("failed to scan source %s: %w", s.name, err)
+				return FileInfo{}, false
+			}
+
+			s.current = nil
+		}
+
+		if s.next == len(s.fs.members) {
+			return FileInfo{}, false
+		}
+
+		member := s.fs.members[s.next]
+		s.next++
+
+		rootInfo, err := member.FS.Stat(member.Root)
+		if err != nil {
+			s.err = fmt.Errorf("failed to stat source %s: %w", member.Name, err)
+			return FileInfo{}, false
+		}
+
+		// Member folders are the first level, so their trees are scanned one level shallower
+		if s.maxDepth != 1 {
+			s.current = s.scanMember(member, member.Root)
+			limitDepth(s.current, max(s.maxDepth-1, 0))
+		}
+
+		s.name = member.Name
+
+		return FileInfo{
+			RelativePath: member.Name,
+			ModTime:      rootInfo.ModTime(),
+			Mode:         rootInfo.Mode().Perm(),
+			IsDir:        true,
+		}, true
+	}
+}
+
+// LimitDepth stops the scan entering directories maxDepth levels below UnionRoot.
+func (s *unionScanner) LimitDepth(maxDepth int) {
+	s.maxDepth = maxDepth
+}
+
+// limitDepth limits scanner to maxDepth if it can be limited.
+func limitDepth(scanner FileScanner, maxDepth int) {
+	if limiter, ok := scanner.(DepthLimiter); ok {
+		limiter.LimitDepth(maxDepth)
+	}
+}
+
+// Stop stops the member being scanned, and the scan.
+func (s *unionScanner) Stop() {
+	if stopper, ok := s.current.(ScanStopper); ok {
+		stopper.Stop()
+	}
+
+	s.current = nil
+	s.stopped = true
+}
+
+// unionRootPerm is the permission bits UnionRoot reports.
+const unionRootPerm = 0o755
@@ -0,0 +1,185 @@
+package filesystem_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/joe/copy-files/pkg/filesystem"
+)
+
+func TestUnionFileSystem_ScansEachMemberUnderItsName(t *testing.T) {
+	t.Parallel()
+
+	photos := t.TempDir()
+	docs := t.TempDir()
+	writeUnionTestFile(t, filepath.Join(photos, "a.jpg"), "photo")
+	writeUnionTestFile(t, filepath.Join(docs, "notes", "b.txt"), "doc")
+
+	union := newTestUnion(t, photos, docs)
+
+	var paths []string
+
+	scanner := union.Scan(filesystem.UnionRoot)
+	for {
+		info, ok := scanner.Next()
+		if !ok {
+			break
+		}
+
+		paths = append(paths, info.RelativePath)
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	sort.Strings(paths)
+
+	want := []string{"docs", "docs/notes", "docs/notes/b.txt", "photos", "photos/a.jpg"}
+	if len(paths) != len(want) {
+		t.Fatalf("Scan yielded %v, want %v", paths, want)
+	}
+
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Fatalf("Scan yielded %v, want %v", paths, want)
+		}
+	}
+}
+
+func TestUnionFileSystem_OpensFilesInTheirMember(t *testing.T) {
+	t.Parallel()
+
+	photos := t.TempDir()
+	docs := t.TempDir()
+	writeUnionTestFile(t, filepath.Join(docs, "notes", "b.txt"), "doc")
+
+	union := newTestUnion(t, photos, docs)
+
+	file, err := union.Open(filepath.Join(filesystem.UnionRoot, "docs", "notes", "b.txt"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	data, err := io.ReadAll(file)
+	if err != nil || string(data) != "doc" {
+		t.Fatalf("read %q, %v; want %q", data, err, "doc")
+	}
+
+	info, err := union.Stat(filesystem.UnionRoot)
+	if err != nil || !info.IsDir() {
+		t.Fatalf("Stat of the root = %v, %v; want a directory", info, err)
+	}
+
+	_, err = union.Stat(filepath.Join(filesystem.UnionRoot, "music", "c.mp3"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Stat outside every member = %v, want os.ErrNotExist", err)
+	}
+
+	_, err = union.Open(filesystem.UnionRoot)
+	if !errors.Is(err, filesystem.ErrUnionRoot) {
+		t.Errorf("Open of the root = %v, want ErrUnionRoot", err)
+	}
+}
+
+func TestNewUnionFileSystem_RejectsDuplicateNames(t *testing.T) {
+	t.Parallel()
+
+	fs := filesystem.NewRealFileSystem()
+
+	_, err := filesystem.NewUnionFileSystem([]filesystem.UnionMember{
+		{Name: "photos", FS: fs, Root: t.TempDir()},
+		{Name: "photos", FS: fs, Root: t.TempDir()},
+	})
+	if !errors.Is(err, filesystem.ErrDuplicateUnionName) {
+		t.Errorf("NewUnionFileSystem() error = %v, want ErrDuplicateUnionName", err)
+	}
+
+	_, err = filesystem.NewUnionFileSystem([]filesystem.UnionMember{{Name: "a/b", FS: fs, Root: t.TempDir()}})
+	if !errors.Is(err, filesystem.ErrInvalidUnionName) {
+		t.Errorf("NewUnionFileSystem() error = %v, want ErrInvalidUnionName", err)
+	}
+}
+
+// newTestUnion returns a union of local photos and docs directories, named after them.
+func newTestUnion(t *testing.T, photos, docs string) *filesystem.UnionFileSystem {
+	t.Helper()
+
+	fs := filesystem.NewRealFileSystem()
+
+	union, err := filesystem.NewUnionFileSystem([]filesystem.UnionMember{
+		{Name: "photos", FS: fs, Root: photos},
+		{Name: "docs", FS: fs, Root: docs},
+	})
+	if err != nil {
+		t.Fatalf("NewUnionFileSystem failed: %v", err)
+	}
+
+	return union
+}
+
+// writeUnionTestFile writes content to path, creating its directory.
+func writeUnionTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatalf("Failed to create directory for %s: %v", path, err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestUnionFileSystem_LimitsMemberScansBelowTheirFolders(t *testing.T) {
+	t.Parallel()
+
+	photos := t.TempDir()
+	docs := t.TempDir()
+	writeUnionTestFile(t, filepath.Join(photos, "a.jpg"), "photo")
+	writeUnionTestFile(t, filepath.Join(docs, "notes", "b.txt"), "doc")
+
+	union := newTestUnion(t, photos, docs)
+
+	for maxDepth, want := range map[int][]string{
+		1: {"docs", "photos"},
+		2: {"docs", "docs/notes", "photos", "photos/a.jpg"},
+	} {
+		scanner := union.Scan(filesystem.UnionRoot)
+
+		limiter, ok := scanner.(filesystem.DepthLimiter)
+		if !ok {
+			t.Fatal("Expected the union scanner to be depth-limitable")
+		}
+
+		limiter.LimitDepth(maxDepth)
+
+		var paths []string
+
+		for info, ok := scanner.Next(); ok; info, ok = scanner.Next() {
+			paths = append(paths, filepath.ToSlash(info.RelativePath))
+		}
+
+		if err := scanner.Err(); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+
+		sort.Strings(paths)
+
+		if len(paths) != len(want) {
+			t.Fatalf("Depth %d scan yielded %v, want %v", maxDepth, paths, want)
+		}
+
+		for i := range want {
+			if paths[i] != want[i] {
+				t.Fatalf("Depth %d scan yielded %v, want %v", maxDepth, paths, want)
+			}
+		}
+	}
+}